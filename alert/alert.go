@@ -0,0 +1,184 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/third_party/log"
+)
+
+// Kinds of critical events.
+const (
+	// DASubmitFailures is raised when DA submission failed configured number of times in a row.
+	DASubmitFailures = "da_submit_failures"
+	// AppHashMismatch is raised when state of the node diverged from the chain.
+	AppHashMismatch = "app_hash_mismatch"
+	// Halt is raised when the node stops producing or applying blocks.
+	Halt = "halt"
+	// DiskPressure is raised when free disk space is low and the node enters safe mode.
+	DiskPressure = "disk_pressure"
+	// DoubleSign is raised when the sequencer signed two different blocks at the same height.
+	DoubleSign = "double_sign"
+	// DALowBalance is raised when balance of the DA account paying for submissions is below configured minimum.
+	DALowBalance = "da_low_balance"
+)
+
+const (
+	// requestTimeout limits duration of a single webhook request.
+	requestTimeout = 10 * time.Second
+	// queueSize is the number of alerts waiting to be sent, further alerts are dropped.
+	queueSize = 100
+	// repeatInterval is the minimal interval between alerts with the same kind and message.
+	repeatInterval = 10 * time.Minute
+)
+
+// Handler is called by components of the node to raise an alert. It must not block.
+type Handler func(kind, message string)
+
+// Event is a critical event reported to the webhook.
+type Event struct {
+	Kind    string
+	Message string
+	Time    time.Time
+}
+
+// Notifier posts alerts to a webhook, so that operators are paged without scraping logs or metrics.
+//
+// Alerts are queued and sent in the background; failures are logged and don't affect the node. Repeated alerts with
+// the same kind and message are suppressed for repeatInterval.
+type Notifier struct {
+	conf   config.AlertsConfig
+	source string
+	client *http.Client
+	queue  chan Event
+
+	mtx  sync.Mutex
+	last map[Event]time.Time
+
+	logger log.Logger
+}
+
+// NewNotifier creates Notifier posting alerts of node identified by source (e.g. chain ID and moniker).
+func NewNotifier(conf config.AlertsConfig, source string, logger log.Logger) (*Notifier, error) {
+	switch conf.Format {
+	case config.AlertFormatSlack:
+	case config.AlertFormatPagerDuty:
+		if conf.PagerDutyRoutingKey == "" {
+			return nil, fmt.Errorf("PagerDuty routing key is required by %s alert format", conf.Format)
+		}
+	default:
+		return nil, fmt.Errorf("unknown alert format %q", conf.Format)
+	}
+	return &Notifier{
+		conf:   conf,
+		source: source,
+		client: &http.Client{Timeout: requestTimeout},
+		queue:  make(chan Event, queueSize),
+		last:   make(map[Event]time.Time),
+		logger: logger,
+	}, nil
+}
+
+// Notify queues an alert. It implements Handler.
+func (n *Notifier) Notify(kind, message string) {
+	now := time.Now()
+	key := Event{Kind: kind, Message: message}
+	n.mtx.Lock()
+	if last, ok := n.last[key]; ok && now.Sub(last) < repeatInterval {
+		n.mtx.Unlock()
+		return
+	}
+	n.last[key] = now
+	n.mtx.Unlock()
+
+	select {
+	case n.queue <- Event{Kind: kind, Message: message, Time: now}:
+	default:
+		n.logger.Error("alert queue is full, dropping alert", "kind", kind, "message", message)
+	}
+}
+
+// Run sends queued alerts until context is cancelled.
+func (n *Notifier) Run(ctx context.Context) {
+	n.logger.Info("sending alerts", "format", n.conf.Format)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-n.queue:
+			if err := n.send(ctx, event); err != nil && ctx.Err() == nil {
+				n.logger.Error("failed to send alert", "kind", event.Kind, "error", err)
+			}
+		}
+	}
+}
+
+// send posts event to the webhook.
+func (n *Notifier) send(ctx context.Context, event Event) error {
+	blob, err := json.Marshal(n.payload(event))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.conf.WebhookURL, bytes.NewReader(blob))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+// payload returns JSON payload of event in configured format.
+func (n *Notifier) payload(event Event) any {
+	if n.conf.Format == config.AlertFormatPagerDuty {
+		return pagerDutyEvent{
+			RoutingKey:  n.conf.PagerDutyRoutingKey,
+			EventAction: "trigger",
+			// alerts of the same kind are grouped into a single incident
+			DedupKey: n.source + "/" + event.Kind,
+			Payload: pagerDutyPayload{
+				Summary:   event.Message,
+				Source:    n.source,
+				Severity:  "critical",
+				Timestamp: event.Time.UTC().Format(time.RFC3339),
+				Component: "rollkit",
+				Class:     event.Kind,
+			},
+		}
+	}
+	return slackMessage{Text: fmt.Sprintf(":rotating_light: *%s* [%s] %s", event.Kind, n.source, event.Message)}
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// pagerDutyEvent is an event of PagerDuty Events API v2.
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary   string `json:"summary"`
+	Source    string `json:"source"`
+	Severity  string `json:"severity"`
+	Timestamp string `json:"timestamp"`
+	Component string `json:"component"`
+	Class     string `json:"class"`
+}
@@ -0,0 +1,87 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestNotifier(t *testing.T) {
+	cases := []struct {
+		name   string
+		conf   config.AlertsConfig
+		verify func(t *testing.T, payload map[string]any)
+	}{
+		{
+			name: "slack",
+			conf: config.AlertsConfig{Format: config.AlertFormatSlack},
+			verify: func(t *testing.T, payload map[string]any) {
+				assert.Contains(t, payload["text"], "halt")
+				assert.Contains(t, payload["text"], "chain halted at height 5")
+			},
+		},
+		{
+			name: "pagerduty",
+			conf: config.AlertsConfig{Format: config.AlertFormatPagerDuty, PagerDutyRoutingKey: "key"},
+			verify: func(t *testing.T, payload map[string]any) {
+				assert.Equal(t, "key", payload["routing_key"])
+				assert.Equal(t, "trigger", payload["event_action"])
+				assert.Equal(t, "chain/halt", payload["dedup_key"])
+				details := payload["payload"].(map[string]any)
+				assert.Equal(t, "chain halted at height 5", details["summary"])
+				assert.Equal(t, "chain", details["source"])
+				assert.Equal(t, "critical", details["severity"])
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			payloads := make(chan map[string]any, 10)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				var payload map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				payloads <- payload
+			}))
+			defer srv.Close()
+
+			c.conf.WebhookURL = srv.URL
+			n, err := NewNotifier(c.conf, "chain", test.NewFileLogger(t))
+			require.NoError(t, err)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go n.Run(ctx)
+
+			n.Notify(Halt, "chain halted at height 5")
+			// repeated alert is suppressed
+			n.Notify(Halt, "chain halted at height 5")
+			select {
+			case payload := <-payloads:
+				c.verify(t, payload)
+			case <-time.After(5 * time.Second):
+				t.Fatal("alert not sent")
+			}
+			select {
+			case <-payloads:
+				t.Fatal("repeated alert sent")
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestNewNotifierErrors(t *testing.T) {
+	_, err := NewNotifier(config.AlertsConfig{WebhookURL: "http://localhost", Format: "email"}, "chain", test.NewFileLogger(t))
+	assert.Error(t, err)
+	_, err = NewNotifier(config.AlertsConfig{WebhookURL: "http://localhost", Format: config.AlertFormatPagerDuty}, "chain", test.NewFileLogger(t))
+	assert.Error(t, err)
+}
@@ -0,0 +1,438 @@
+// Package bench provides reproducible end-to-end benchmarks of Rollkit.
+//
+// A benchmark runs an aggregator in-process, against in-process mock DA and mock sequencer, submits
+// transactions from concurrent clients and measures latency of each transaction from submission until it's
+// included in a block and until the block is included in DA. Results are machine-readable (JSON), so results
+// of different releases can be compared to detect performance regressions, see Compare.
+package bench
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	cmconfig "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/types"
+)
+
+const (
+	// chainID is the chain ID of benchmarked chain.
+	chainID = "bench"
+	// namespace is the DA namespace of benchmarked chain.
+	namespace = "00000000000000000000000000000000000000000000000062656e6368"
+	// daPollInterval is the interval of checking DA inclusion of blocks.
+	daPollInterval = 10 * time.Millisecond
+	// minTxSize is the minimum size of a transaction, which has to fit the key, the separator and a value.
+	minTxSize = keySize + 2
+	// keySize is the size of the hex-encoded transaction number used as the key of kvstore transaction.
+	keySize = 16
+)
+
+// Milestones of a transaction, measured from its submission.
+const (
+	// MilestoneBroadcast is reached when transaction is accepted to the mempool and submitted to the sequencer.
+	MilestoneBroadcast = "broadcast"
+	// MilestoneSoftBlock is reached when transaction is included in a block produced by the aggregator.
+	MilestoneSoftBlock = node.TxStageSoftBlock
+	// MilestoneDAIncluded is reached when the block with transaction is included in DA.
+	MilestoneDAIncluded = node.TxStageDAIncluded
+)
+
+// Config describes a benchmark. Durations are encoded in JSON as nanoseconds.
+type Config struct {
+	// Txs is the number of submitted transactions.
+	Txs int `json:"txs"`
+	// TxSize is the size of each transaction in bytes.
+	TxSize int `json:"tx_size"`
+	// Concurrency is the number of clients submitting transactions concurrently.
+	Concurrency int `json:"concurrency"`
+	// BlockTime is the block time of the aggregator.
+	BlockTime time.Duration `json:"block_time"`
+	// DABlockTime is the interval of submitting blocks to DA.
+	DABlockTime time.Duration `json:"da_block_time"`
+	// Timeout limits the whole benchmark, including startup of the node.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// DefaultConfig returns configuration of the default benchmark.
+func DefaultConfig() Config {
+	return Config{
+		Txs:         2000,
+		TxSize:      256,
+		Concurrency: 8,
+		BlockTime:   100 * time.Millisecond,
+		DABlockTime: 200 * time.Millisecond,
+		Timeout:     2 * time.Minute,
+	}
+}
+
+// Validate checks that configuration describes a valid benchmark.
+func (c Config) Validate() error {
+	switch {
+	case c.Txs <= 0:
+		return errors.New("number of transactions must be positive")
+	case c.TxSize < minTxSize:
+		return fmt.Errorf("transaction size must be at least %d bytes", minTxSize)
+	case c.Concurrency <= 0:
+		return errors.New("concurrency must be positive")
+	case c.BlockTime <= 0 || c.DABlockTime <= 0:
+		return errors.New("block time and DA block time must be positive")
+	case c.Timeout <= 0:
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
+// Environment describes where the benchmark was run. Results are comparable only between the same environments.
+type Environment struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	NumCPU    int    `json:"num_cpu"`
+}
+
+// Result is the machine-readable result of a benchmark.
+type Result struct {
+	Config      Config      `json:"config"`
+	Environment Environment `json:"environment"`
+	// Blocks is the number of blocks produced during the benchmark, including empty blocks.
+	Blocks uint64 `json:"blocks"`
+	// Duration is the time from the first submission until DA inclusion of the last transaction, in seconds.
+	Duration float64 `json:"duration_s"`
+	// Throughput is the number of transactions included in DA per second.
+	Throughput float64 `json:"throughput_tps"`
+	// Latency contains latencies of transactions, by milestone (see MilestoneBroadcast, MilestoneSoftBlock
+	// and MilestoneDAIncluded).
+	Latency map[string]node.StageLatency `json:"latency"`
+	// Stages contains latencies of block production stages reported by the aggregator.
+	Stages map[string]node.StageLatency `json:"stages"`
+}
+
+// Run runs the benchmark described by cfg.
+func Run(ctx context.Context, cfg Config, logger log.Logger) (*Result, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	mocks, err := startMockServices()
+	if err != nil {
+		return nil, err
+	}
+	defer mocks.stop()
+
+	n, err := newAggregator(ctx, cfg, mocks, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create node: %w", err)
+	}
+	if err := n.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start node: %w", err)
+	}
+	defer func() {
+		if err := n.Stop(); err != nil {
+			logger.Error("failed to stop node", "error", err)
+		}
+	}()
+	client, ok := n.GetClient().(*node.FullClient)
+	if !ok {
+		return nil, errors.New("benchmarked node is not a full node")
+	}
+
+	r := newRun(cfg, client)
+	if err := r.run(ctx); err != nil {
+		return nil, err
+	}
+	return r.result(ctx)
+}
+
+// newAggregator creates an in-memory aggregator, with in-memory kvstore app.
+func newAggregator(ctx context.Context, cfg Config, mocks *mockServices, logger log.Logger) (node.Node, error) {
+	nodeConfig := config.DefaultNodeConfig
+	nodeConfig.Aggregator = true
+	nodeConfig.P2P.ListenAddress = "/ip4/127.0.0.1/tcp/0"
+	nodeConfig.DAAddress = mocks.daAddress
+	nodeConfig.DANamespace = namespace
+	nodeConfig.SequencerAddress = mocks.sequencerAddress
+	nodeConfig.SequencerRollupID = chainID
+	nodeConfig.BlockTime = cfg.BlockTime
+	nodeConfig.DABlockTime = cfg.DABlockTime
+	nodeConfig.Concurrency = config.ConcurrencyConfig{}.WithDefaults()
+
+	genesis, genesisValidatorKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, chainID)
+	signingKey, err := types.PrivKeyToSigningKey(genesisValidatorKey)
+	if err != nil {
+		return nil, err
+	}
+	p2pKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return node.NewNode(ctx, nodeConfig, p2pKey, signingKey, proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()), genesis,
+		node.DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()), logger)
+}
+
+// run tracks milestones of submitted transactions.
+type run struct {
+	cfg    Config
+	client *node.FullClient
+
+	txs     []cmtypes.Tx
+	indices map[cmtypes.TxKey]int
+
+	mtx         sync.Mutex
+	submitted   []time.Time
+	latency     map[string][]time.Duration
+	byHeight    map[uint64][]int
+	blockHeight uint64
+	daIncluded  int
+	lastDA      time.Time
+	done        chan struct{}
+
+	startHeight uint64
+	endHeight   uint64
+}
+
+func newRun(cfg Config, client *node.FullClient) *run {
+	r := &run{
+		cfg:       cfg,
+		client:    client,
+		txs:       make([]cmtypes.Tx, cfg.Txs),
+		indices:   make(map[cmtypes.TxKey]int, cfg.Txs),
+		submitted: make([]time.Time, cfg.Txs),
+		latency:   make(map[string][]time.Duration),
+		byHeight:  make(map[uint64][]int),
+		done:      make(chan struct{}),
+	}
+	// transactions are deterministic, so benchmarks are reproducible
+	value := bytes.Repeat([]byte{'v'}, cfg.TxSize-keySize-1)
+	for i := range r.txs {
+		tx := cmtypes.Tx(fmt.Sprintf("%0*x=%s", keySize, i, value))
+		r.txs[i] = tx
+		r.indices[tx.Key()] = i
+	}
+	return r
+}
+
+func (r *run) run(ctx context.Context) error {
+	blocks, err := r.client.Subscribe(ctx, "bench", cmtypes.EventQueryNewBlock.String(), 100)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to blocks: %w", err)
+	}
+	state, err := r.client.DumpNodeState(ctx)
+	if err != nil {
+		return err
+	}
+	r.startHeight = state.Height
+	r.blockHeight = state.Height
+
+	go r.trackBlocks(ctx, blocks)
+	go r.trackDAInclusion(ctx)
+
+	errCh := make(chan error, r.cfg.Concurrency)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < r.cfg.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if err := r.submit(ctx, i); err != nil {
+					errCh <- err
+					return
+				}
+			}
+		}()
+	}
+submit:
+	for i := range r.txs {
+		select {
+		case next <- i:
+		case err = <-errCh:
+			break submit
+		case <-ctx.Done():
+			break submit
+		}
+	}
+	close(next)
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-r.done:
+		return nil
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		return fmt.Errorf("only %d of %d transactions included in DA: %w", r.daIncluded, r.cfg.Txs, ctx.Err())
+	}
+}
+
+// submit submits i-th transaction.
+func (r *run) submit(ctx context.Context, i int) error {
+	start := time.Now()
+	r.mtx.Lock()
+	r.submitted[i] = start
+	r.mtx.Unlock()
+
+	res, err := r.client.BroadcastTxSync(ctx, r.txs[i])
+	if err != nil {
+		return fmt.Errorf("failed to broadcast transaction %d: %w", i, err)
+	}
+	if res.Code != 0 {
+		return fmt.Errorf("transaction %d rejected with code %d: %s", i, res.Code, res.Log)
+	}
+	r.observe(MilestoneBroadcast, time.Since(start))
+	return nil
+}
+
+func (r *run) observe(milestone string, d time.Duration) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.latency[milestone] = append(r.latency[milestone], d)
+}
+
+// trackBlocks records inclusion of transactions in blocks.
+func (r *run) trackBlocks(ctx context.Context, blocks <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-blocks:
+			if !ok {
+				return
+			}
+			data, ok := ev.Data.(cmtypes.EventDataNewBlock)
+			if !ok || data.Block == nil {
+				continue
+			}
+			now := time.Now()
+			height := uint64(data.Block.Height) //nolint:gosec
+			r.mtx.Lock()
+			for _, tx := range data.Block.Txs {
+				if i, ok := r.indices[tx.Key()]; ok {
+					r.latency[MilestoneSoftBlock] = append(r.latency[MilestoneSoftBlock], now.Sub(r.submitted[i]))
+					r.byHeight[height] = append(r.byHeight[height], i)
+				}
+			}
+			r.blockHeight = height
+			r.mtx.Unlock()
+		}
+	}
+}
+
+// trackDAInclusion polls DA included height of the node and records DA inclusion of transactions.
+func (r *run) trackDAInclusion(ctx context.Context) {
+	ticker := time.NewTicker(daPollInterval)
+	defer ticker.Stop()
+	var daHeight uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		state, err := r.client.DumpNodeState(ctx)
+		if err != nil {
+			continue
+		}
+		now := time.Now()
+		r.mtx.Lock()
+		// blocks are DA included only after they are tracked, so no transaction is missed
+		for ; daHeight < min(state.DAIncludedHeight, r.blockHeight); daHeight++ {
+			for _, i := range r.byHeight[daHeight+1] {
+				r.latency[MilestoneDAIncluded] = append(r.latency[MilestoneDAIncluded], now.Sub(r.submitted[i]))
+				r.daIncluded++
+				r.lastDA = now
+			}
+			delete(r.byHeight, daHeight+1)
+		}
+		finished := r.daIncluded == r.cfg.Txs
+		if finished {
+			r.endHeight = state.Height
+		}
+		r.mtx.Unlock()
+		if finished {
+			close(r.done)
+			return
+		}
+	}
+}
+
+// result aggregates recorded milestones of a finished run.
+func (r *run) result(ctx context.Context) (*Result, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	first := slices.MinFunc(r.submitted, func(a, b time.Time) int { return a.Compare(b) })
+	duration := r.lastDA.Sub(first).Seconds()
+	res := &Result{
+		Config: r.cfg,
+		Environment: Environment{
+			Version:   config.Version,
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			NumCPU:    runtime.NumCPU(),
+		},
+		Blocks:   r.endHeight - r.startHeight,
+		Duration: duration,
+		Latency:  make(map[string]node.StageLatency, len(r.latency)),
+	}
+	if duration > 0 {
+		res.Throughput = float64(r.cfg.Txs) / duration
+	}
+	for milestone, samples := range r.latency {
+		res.Latency[milestone] = latencyStats(samples)
+	}
+	perf, err := r.client.ProposerPerformance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res.Stages = perf.Stages
+	return res, nil
+}
+
+// latencyStats aggregates latency samples, percentiles are computed with the nearest-rank method.
+func latencyStats(samples []time.Duration) node.StageLatency {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+	percentile := func(p int) time.Duration {
+		rank := max((p*len(sorted)+99)/100, 1)
+		return sorted[rank-1]
+	}
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+	return node.StageLatency{
+		Count: uint64(len(sorted)),
+		Avg:   ms(sum / time.Duration(len(sorted))),
+		P50:   ms(percentile(50)),
+		P90:   ms(percentile(90)),
+		P99:   ms(percentile(99)),
+		Max:   ms(sorted[len(sorted)-1]),
+	}
+}
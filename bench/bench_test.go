@@ -0,0 +1,92 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/node"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestRun(t *testing.T) {
+	cfg := Config{
+		Txs:         100,
+		TxSize:      64,
+		Concurrency: 4,
+		BlockTime:   50 * time.Millisecond,
+		DABlockTime: 100 * time.Millisecond,
+		Timeout:     time.Minute,
+	}
+	res, err := Run(context.Background(), cfg, test.NewFileLogger(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, cfg, res.Config)
+	assert.NotZero(t, res.Blocks)
+	assert.Positive(t, res.Duration)
+	assert.Positive(t, res.Throughput)
+	for _, milestone := range []string{MilestoneBroadcast, MilestoneSoftBlock, MilestoneDAIncluded} {
+		latency, ok := res.Latency[milestone]
+		require.True(t, ok, milestone)
+		assert.Equal(t, uint64(cfg.Txs), latency.Count, milestone)
+		assert.LessOrEqual(t, latency.P50, latency.Max, milestone)
+	}
+	// milestones are reached in order
+	assert.LessOrEqual(t, res.Latency[MilestoneSoftBlock].Max, res.Latency[MilestoneDAIncluded].Max)
+	assert.Contains(t, res.Stages, "total")
+
+	// results are machine-readable
+	encoded, err := json.Marshal(res)
+	require.NoError(t, err)
+	var decoded Result
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, *res, decoded)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+
+	invalid := []func(*Config){
+		func(c *Config) { c.Txs = 0 },
+		func(c *Config) { c.TxSize = minTxSize - 1 },
+		func(c *Config) { c.Concurrency = 0 },
+		func(c *Config) { c.BlockTime = 0 },
+		func(c *Config) { c.Timeout = 0 },
+	}
+	for _, modify := range invalid {
+		cfg := DefaultConfig()
+		modify(&cfg)
+		assert.Error(t, cfg.Validate())
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := &Result{
+		Throughput: 1000,
+		Latency: map[string]node.StageLatency{
+			MilestoneSoftBlock:  {P50: 100, P99: 200},
+			MilestoneDAIncluded: {P50: 300, P99: 500},
+		},
+	}
+	current := &Result{
+		Throughput: 950,
+		Latency: map[string]node.StageLatency{
+			MilestoneSoftBlock:  {P50: 105, P99: 300},
+			MilestoneDAIncluded: {P50: 200, P99: 400},
+		},
+	}
+	assert.Empty(t, Compare(baseline, baseline, 0))
+	assert.Equal(t, []Regression{
+		{Metric: "latency.soft_block.p99_ms", Baseline: 200, Current: 300, Change: 0.5},
+	}, Compare(baseline, current, 0.1))
+
+	regressions := Compare(baseline, current, 0.01)
+	require.Len(t, regressions, 3)
+	assert.Equal(t, "throughput_tps", regressions[0].Metric)
+	assert.InDelta(t, 0.05, regressions[0].Change, 1e-9)
+	assert.Equal(t, "latency.soft_block.p50_ms", regressions[1].Metric)
+}
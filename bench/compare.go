@@ -0,0 +1,64 @@
+package bench
+
+import (
+	"fmt"
+	"sort"
+)
+
+// minLatencyChange is the minimum absolute change of latency in milliseconds considered a regression, so
+// noise of sub-millisecond latencies is not reported.
+const minLatencyChange = 1
+
+// Regression is a metric which is worse than its baseline by more than the tolerance.
+type Regression struct {
+	Metric   string  `json:"metric"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+	// Change is the relative change of the metric, positive if the metric got worse.
+	Change float64 `json:"change"`
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("%s: %.2f -> %.2f (%+.1f%% worse)", r.Metric, r.Baseline, r.Current, r.Change*100)
+}
+
+// Compare compares the result with the baseline result and returns metrics which got worse by more than the
+// tolerance, e.g. 0.1 for 10%. Compared metrics are throughput and p50 and p99 latencies of each milestone;
+// latencies which got worse by less than a millisecond are ignored.
+// Results are comparable only if they were measured with the same configuration, in the same environment.
+func Compare(baseline, current *Result, tolerance float64) []Regression {
+	var regressions []Regression
+	if baseline.Throughput > 0 {
+		// lower throughput is worse
+		change := (baseline.Throughput - current.Throughput) / baseline.Throughput
+		if change > tolerance {
+			regressions = append(regressions, Regression{Metric: "throughput_tps", Baseline: baseline.Throughput, Current: current.Throughput, Change: change})
+		}
+	}
+
+	milestones := make([]string, 0, len(baseline.Latency))
+	for milestone := range baseline.Latency {
+		milestones = append(milestones, milestone)
+	}
+	sort.Strings(milestones)
+	for _, milestone := range milestones {
+		base, cur := baseline.Latency[milestone], current.Latency[milestone]
+		for _, m := range []struct {
+			name              string
+			baseline, current float64
+		}{
+			{"p50_ms", base.P50, cur.P50},
+			{"p99_ms", base.P99, cur.P99},
+		} {
+			if m.baseline <= 0 {
+				continue
+			}
+			// higher latency is worse
+			change := (m.current - m.baseline) / m.baseline
+			if change > tolerance && m.current-m.baseline >= minLatencyChange {
+				regressions = append(regressions, Regression{Metric: "latency." + milestone + "." + m.name, Baseline: m.baseline, Current: m.current, Change: change})
+			}
+		}
+	}
+	return regressions
+}
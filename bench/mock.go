@@ -0,0 +1,55 @@
+package bench
+
+import (
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	goDAproxy "github.com/rollkit/go-da/proxy/grpc"
+	goDATest "github.com/rollkit/go-da/test"
+	seqGRPC "github.com/rollkit/go-sequencing/proxy/grpc"
+	seqTest "github.com/rollkit/go-sequencing/test"
+)
+
+// mockServices are in-process mock DA and mock sequencer, listening on random local ports.
+type mockServices struct {
+	daAddress        string
+	sequencerAddress string
+
+	da        *grpc.Server
+	sequencer *grpc.Server
+}
+
+func startMockServices() (*mockServices, error) {
+	daLis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for mock DA: %w", err)
+	}
+	seqLis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		_ = daLis.Close()
+		return nil, fmt.Errorf("failed to listen for mock sequencer: %w", err)
+	}
+
+	m := &mockServices{
+		daAddress:        "grpc://" + daLis.Addr().String(),
+		sequencerAddress: seqLis.Addr().String(),
+		da:               goDAproxy.NewServer(goDATest.NewDummyDA(), grpc.Creds(insecure.NewCredentials())),
+	}
+	seq := seqTest.NewMultiRollupSequencer()
+	m.sequencer = seqGRPC.NewServer(seq, seq, seq)
+	go func() {
+		_ = m.da.Serve(daLis)
+	}()
+	go func() {
+		_ = m.sequencer.Serve(seqLis)
+	}()
+	return m, nil
+}
+
+func (m *mockServices) stop() {
+	m.da.Stop()
+	m.sequencer.Stop()
+}
@@ -0,0 +1,46 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/rollkit/rollkit/alert"
+	"github.com/rollkit/rollkit/types"
+)
+
+// SetAlertHandler sets function notified about critical events, see alert package.
+func (m *Manager) SetAlertHandler(handler alert.Handler) {
+	m.alertHandler = handler
+}
+
+// raiseAlert notifies alert handler, if set.
+func (m *Manager) raiseAlert(kind, message string) {
+	if m.alertHandler != nil {
+		m.alertHandler(kind, message)
+	}
+}
+
+// daSubmitFailed counts consecutive failed DA submission attempts and raises an alert when configured threshold
+// is reached. Counter is reset by successful submission.
+func (m *Manager) daSubmitFailed(reason string) {
+	m.daSubmitFailures++
+	if threshold := m.conf.Alerts.DASubmitFailures; threshold > 0 && m.daSubmitFailures == threshold {
+		m.raiseAlert(alert.DASubmitFailures, fmt.Sprintf("DA submission failed %d times in a row: %s", threshold, reason))
+	}
+}
+
+// checkDoubleSign raises an alert if header proposed by the same sequencer as the stored block at the same height
+// has a different hash. Header must be validated, i.e. signed by the proposer.
+func (m *Manager) checkDoubleSign(ctx context.Context, header *types.SignedHeader) {
+	stored, err := m.store.GetHeader(ctx, header.Height())
+	if err != nil {
+		return
+	}
+	if !bytes.Equal(stored.ProposerAddress, header.ProposerAddress) || bytes.Equal(stored.Hash(), header.Hash()) {
+		return
+	}
+	m.logger.Error("sequencer signed conflicting blocks", "height", header.Height(), "storedHash", stored.Hash(), "hash", header.Hash())
+	m.metrics.DoubleSigns.Add(1)
+	m.raiseAlert(alert.DoubleSign, fmt.Sprintf("sequencer signed conflicting blocks at height %d: %s and %s", header.Height(), stored.Hash(), header.Hash()))
+}
@@ -0,0 +1,69 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	goDATest "github.com/rollkit/go-da/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/alert"
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestAlerts(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m := getManager(t, goDATest.NewDummyDA())
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m.store = store.New(kv)
+	m.metrics = NopMetrics()
+	m.conf = config.BlockManagerConfig{Alerts: config.AlertsConfig{DASubmitFailures: 2}}
+	var alerts []string
+	m.SetAlertHandler(func(kind, message string) { alerts = append(alerts, kind) })
+
+	// alert is raised once threshold of consecutive failures is reached
+	m.daSubmitFailed("timeout")
+	require.Empty(alerts)
+	m.daSubmitFailed("timeout")
+	m.daSubmitFailed("timeout")
+	require.Equal([]string{alert.DASubmitFailures}, alerts)
+
+	// header of the stored block is not a double sign, conflicting header of the same proposer is
+	conf := types.BlockConfig{Height: 5, NTxs: 1}
+	header, data, _ := types.GenerateRandomBlockCustom(&conf, "TestAlerts")
+	require.NoError(m.store.SaveBlockData(ctx, header, data, &header.Signature))
+	m.checkDoubleSign(ctx, header)
+	require.Len(alerts, 1)
+
+	conflicting, _, _ := types.GenerateRandomBlockCustom(&conf, "TestAlerts")
+	m.checkDoubleSign(ctx, conflicting)
+	require.Equal([]string{alert.DASubmitFailures, alert.DoubleSign}, alerts)
+
+	other, _ := types.GetRandomBlock(5, 1, "TestAlerts")
+	m.checkDoubleSign(ctx, other)
+	require.Len(alerts, 2)
+}
+
+func TestDABalanceAlert(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m := getManager(t, goDATest.NewDummyDA())
+	m.metrics = NopMetrics()
+	m.conf = config.BlockManagerConfig{DASigner: config.DASignerConfig{Address: "celestia1abc", MinBalance: 100}}
+	var alerts []string
+	m.SetAlertHandler(func(kind, message string) { alerts = append(alerts, kind) })
+	balance := uint64(150)
+	m.SetDABalance(func(context.Context) (uint64, error) { return balance, nil })
+
+	require.NoError(m.checkDABalance(ctx))
+	require.Empty(alerts)
+	balance = 99
+	require.NoError(m.checkDABalance(ctx))
+	require.Equal([]string{alert.DALowBalance}, alerts)
+}
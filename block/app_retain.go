@@ -0,0 +1,46 @@
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/store"
+)
+
+// AppRetainHeightKey is the metadata key of the lowest height the app retains state of, as reported in
+// RetainHeight of Commit responses. State of lower heights might be pruned by the app, so it can't be queried.
+const AppRetainHeightKey = "app retain height"
+
+// setAppRetainHeight records retain height reported by the app. Retain height never decreases; lower values
+// (including 0, meaning the app doesn't prune) are ignored.
+func (m *Manager) setAppRetainHeight(ctx context.Context, height uint64) error {
+	if height <= m.appRetainHeight.Load() {
+		return nil
+	}
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, height)
+	if err := m.store.SetMetadata(ctx, AppRetainHeightKey, value); err != nil {
+		return fmt.Errorf("failed to save app retain height: %w", err)
+	}
+	m.appRetainHeight.Store(height)
+	return nil
+}
+
+// LoadAppRetainHeight returns the lowest height the app retains state of, or 0 if the app never reported it.
+func LoadAppRetainHeight(ctx context.Context, s store.Store) (uint64, error) {
+	value, err := s.GetMetadata(ctx, AppRetainHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid app retain height, length %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
@@ -0,0 +1,40 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/store"
+)
+
+func TestAppRetainHeight(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := store.New(kv)
+	m := &Manager{store: s}
+
+	height, err := LoadAppRetainHeight(ctx, s)
+	require.NoError(err)
+	assert.Zero(t, height)
+
+	require.NoError(m.setAppRetainHeight(ctx, 0))
+	require.NoError(m.setAppRetainHeight(ctx, 5))
+	// retain height never decreases
+	require.NoError(m.setAppRetainHeight(ctx, 3))
+	require.NoError(m.setAppRetainHeight(ctx, 0))
+
+	height, err = LoadAppRetainHeight(ctx, s)
+	require.NoError(err)
+	assert.Equal(t, uint64(5), height)
+
+	// retain height is restored on restart
+	m = &Manager{store: s}
+	m.init(ctx)
+	assert.Equal(t, uint64(5), m.appRetainHeight.Load())
+}
@@ -0,0 +1,115 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// backfillBatchSize is the number of blocks backfilled in a single run. Runs are spaced by block time, so that
+// backfill doesn't compete with syncing of new blocks.
+const backfillBatchSize = 64
+
+// BackfillSource fetches header and data of the block at given height, e.g. from peers. Returned block is not
+// trusted; it's verified against the block following it before being saved.
+type BackfillSource func(ctx context.Context, height uint64) (*types.SignedHeader, *types.Data, error)
+
+// SetBackfillSource sets the source of blocks below the earliest stored height, see BackfillLoop.
+func (m *Manager) SetBackfillSource(source BackfillSource) {
+	m.backfillSource = source
+}
+
+// BackfillLoop fetches blocks below the earliest stored height of node started from trusted height, in reverse
+// order, until the store contains all blocks since genesis. Backfilled blocks are verified by hash against the
+// blocks following them (starting with the trusted block) and saved, but not executed, so their block results
+// are not available. Progress is persisted, so backfill resumes after restart.
+func (m *Manager) BackfillLoop(ctx context.Context) {
+	if !m.conf.Backfill || m.backfillSource == nil {
+		return
+	}
+	ticker := time.NewTicker(m.conf.BlockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		done, err := m.backfill(ctx)
+		if err != nil && ctx.Err() == nil {
+			m.logger.Error("failed to backfill blocks", "error", err)
+		}
+		if done {
+			return
+		}
+	}
+}
+
+// backfill fetches and saves up to backfillBatchSize blocks below the earliest stored height. It returns true
+// once all blocks since genesis are stored.
+func (m *Manager) backfill(ctx context.Context) (bool, error) {
+	earliest, err := LoadEarliestHeight(ctx, m.store)
+	if err != nil {
+		return false, err
+	}
+	initialHeight := uint64(m.genesis.InitialHeight) //nolint:gosec
+	if earliest <= initialHeight {
+		m.logger.Info("all blocks since genesis are stored, backfill completed")
+		return true, nil
+	}
+	if m.store.Height() < earliest {
+		// trusted block is not applied yet
+		return false, nil
+	}
+	next, err := m.store.GetHeader(ctx, earliest)
+	if err != nil {
+		return false, fmt.Errorf("failed to load earliest block %d: %w", earliest, err)
+	}
+
+	lowest := earliest
+	defer func() {
+		if lowest < earliest {
+			m.logger.Info("backfilled blocks", "from", lowest, "to", earliest-1)
+		}
+	}()
+	for height := earliest - 1; height >= initialHeight && earliest-height <= backfillBatchSize; height-- {
+		header, data, err := m.backfillSource(ctx, height)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch block %d: %w", height, err)
+		}
+		if err := m.verifyBackfilledBlock(header, data, next); err != nil {
+			return false, fmt.Errorf("invalid block %d: %w", height, err)
+		}
+		if err := m.store.SaveBlockData(ctx, header, data, &header.Signature); err != nil {
+			return false, SaveBlockError{err}
+		}
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, height)
+		if err := m.store.SetMetadata(ctx, EarliestHeightKey, value); err != nil {
+			return false, fmt.Errorf("failed to save earliest height: %w", err)
+		}
+		lowest, next = height, header
+	}
+	return lowest <= initialHeight, nil
+}
+
+// verifyBackfilledBlock checks that block is the parent of already stored next block.
+func (m *Manager) verifyBackfilledBlock(header *types.SignedHeader, data *types.Data, next *types.SignedHeader) error {
+	if header.Height()+1 != next.Height() {
+		return fmt.Errorf("unexpected height %d", header.Height())
+	}
+	if header.ChainID() != m.genesis.ChainID {
+		return fmt.Errorf("unexpected chain ID %q", header.ChainID())
+	}
+	if hash := header.Hash(); !bytes.Equal(hash, next.LastHeaderHash) {
+		return fmt.Errorf("header hash %s doesn't match last header hash %s of block %d", hash, next.LastHeaderHash, next.Height())
+	}
+	if err := header.ValidateBasic(); err != nil {
+		return err
+	}
+	return types.Validate(header, data)
+}
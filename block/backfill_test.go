@@ -0,0 +1,93 @@
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestBackfill(t *testing.T) {
+	const chainID = "TestBackfill"
+	const trustedHeight = 100
+	require := require.New(t)
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	headers := make(map[uint64]*types.SignedHeader)
+	data := make(map[uint64]*types.Data)
+	h, d, privKey := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 1, NTxs: 1}, chainID)
+	headers[1], data[1] = h, d
+	for height := uint64(2); height <= trustedHeight; height++ {
+		headers[height], data[height] = types.GetRandomNextBlock(headers[height-1], data[height-1], privKey, nil, 1, chainID)
+	}
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := store.New(kv)
+	require.NoError(s.SaveBlockData(ctx, headers[trustedHeight], data[trustedHeight], &headers[trustedHeight].Signature))
+	s.SetHeight(ctx, trustedHeight)
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, trustedHeight)
+	require.NoError(s.SetMetadata(ctx, EarliestHeightKey, value))
+
+	var tampered uint64
+	m := &Manager{
+		conf:    config.BlockManagerConfig{Backfill: true},
+		genesis: &cmtypes.GenesisDoc{ChainID: chainID, InitialHeight: 1},
+		store:   s,
+		logger:  test.NewLogger(t),
+	}
+	m.SetBackfillSource(func(_ context.Context, height uint64) (*types.SignedHeader, *types.Data, error) {
+		header, ok := headers[height]
+		if !ok {
+			return nil, nil, errors.New("not found")
+		}
+		if height == tampered {
+			other, _, err := types.GetRandomSignedHeader(chainID)
+			require.NoError(err)
+			return other, data[height], nil
+		}
+		return header, data[height], nil
+	})
+
+	// block not linked to the earliest stored block is rejected
+	tampered = trustedHeight - 1
+	done, err := m.backfill(ctx)
+	require.Error(err)
+	assert.False(done)
+	earliest, err := LoadEarliestHeight(ctx, s)
+	require.NoError(err)
+	assert.Equal(uint64(trustedHeight), earliest)
+
+	tampered = 0
+	done, err = m.backfill(ctx)
+	require.NoError(err)
+	assert.False(done)
+	earliest, err = LoadEarliestHeight(ctx, s)
+	require.NoError(err)
+	assert.Equal(uint64(trustedHeight-backfillBatchSize), earliest)
+
+	done, err = m.backfill(ctx)
+	require.NoError(err)
+	assert.True(done)
+	for height := uint64(1); height <= trustedHeight; height++ {
+		header, blockData, err := s.GetBlockData(ctx, height)
+		require.NoError(err)
+		assert.Equal(headers[height].Hash(), header.Hash())
+		assert.Equal(data[height].Hash(), blockData.Hash())
+	}
+
+	done, err = m.backfill(ctx)
+	require.NoError(err)
+	assert.True(done)
+}
@@ -1,7 +1,13 @@
 package block
 
 import (
+	"fmt"
 	"sync"
+	"time"
+
+	"github.com/rollkit/go-sequencing"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
 )
 
 // BatchQueue is a queue of transaction batches with timestamps
@@ -42,3 +48,97 @@ func (bq *BatchQueue) Next() *BatchWithTime {
 	bq.queue = bq.queue[1:]
 	return &batch
 }
+
+// Peek returns the next batch in the queue without removing it
+func (bq *BatchQueue) Peek() *BatchWithTime {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if len(bq.queue) == 0 {
+		return nil
+	}
+	batch := bq.queue[0]
+	return &batch
+}
+
+// Drain removes all batches from the queue and returns them.
+func (bq *BatchQueue) Drain() []BatchWithTime {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	batches := bq.queue
+	bq.queue = make([]BatchWithTime, 0)
+	return batches
+}
+
+// encodeBatches encodes batches with their timestamps, so the batch queue can be persisted.
+func encodeBatches(batches []BatchWithTime) ([]byte, error) {
+	queue := pb.BatchQueue{Batches: make([]*pb.QueuedBatch, len(batches))}
+	for i, batch := range batches {
+		blob, err := batch.Batch.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		queue.Batches[i] = &pb.QueuedBatch{Batch: blob}
+		// zero time can't be represented as Unix nanoseconds, so it's omitted
+		if !batch.Time.IsZero() {
+			queue.Batches[i].Time = batch.Time.UnixNano()
+		}
+	}
+	return queue.Marshal()
+}
+
+// decodeBatches decodes batches encoded by encodeBatches.
+func decodeBatches(b []byte) ([]BatchWithTime, error) {
+	var queue pb.BatchQueue
+	if err := queue.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	var batches []BatchWithTime
+	for _, queued := range queue.Batches {
+		var batch BatchWithTime
+		if queued.Time != 0 {
+			batch.Time = time.Unix(0, queued.Time)
+		}
+		batch.Batch = new(sequencing.Batch)
+		if err := batch.Batch.Unmarshal(queued.Batch); err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// Snapshot returns a copy of batches in the queue, without removing them.
+func (bq *BatchQueue) Snapshot() []BatchWithTime {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return append([]BatchWithTime(nil), bq.queue...)
+}
+
+// verifyBatchReceipt compares transactions of batch returned by the sequencer with transactions submitted to it,
+// and reports discrepancies (unexpected, duplicated, reordered or skipped transactions), which indicate a buggy
+// or malicious sequencer. Batch is processed regardless.
+func (m *Manager) verifyBatchReceipt(batch *sequencing.Batch, hash []byte) {
+	if m.mempoolReaper == nil {
+		return
+	}
+	receipt := m.mempoolReaper.VerifyBatch(batch.Transactions)
+	if receipt.Empty() {
+		return
+	}
+	for kind, n := range map[string]int{
+		"unexpected": receipt.Unexpected,
+		"duplicated": receipt.Duplicated,
+		"reordered":  receipt.Reordered,
+		"skipped":    receipt.Skipped,
+	} {
+		if n > 0 {
+			m.metrics.SequencerBatchDiscrepancies.With("kind", kind).Add(float64(n))
+		}
+	}
+	m.logger.Error("batch returned by sequencer doesn't match submitted transactions",
+		"batchHash", fmt.Sprintf("%X", hash),
+		"unexpected", receipt.Unexpected,
+		"duplicated", receipt.Duplicated,
+		"reordered", receipt.Reordered,
+		"skipped", receipt.Skipped)
+}
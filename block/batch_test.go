@@ -67,3 +67,23 @@ func TestBatchQueue_Next(t *testing.T) {
 	require.Equal(t, batch2, *nextBatch, "Next should return the second batch added")
 	require.Empty(t, bq.queue, "BatchQueue should be empty after retrieving all batches")
 }
+
+func TestBatchQueue_Peek(t *testing.T) {
+	// Create a new BatchQueue
+	bq := NewBatchQueue()
+
+	// Test with empty queue
+	require.Nil(t, bq.Peek(), "Peek should return nil when the queue is empty")
+
+	// Add batches
+	bq.AddBatch(batch1)
+	bq.AddBatch(batch2)
+
+	// Peek doesn't remove the batch
+	for i := 0; i < 2; i++ {
+		nextBatch := bq.Peek()
+		require.NotNil(t, nextBatch, "Peek should return the first batch when called")
+		require.Equal(t, batch1, *nextBatch, "Peek should return the first batch added")
+		require.Len(t, bq.queue, 2, "BatchQueue should still have 2 batches after peeking")
+	}
+}
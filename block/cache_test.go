@@ -64,4 +64,13 @@ func TestBlockCache(t *testing.T) {
 	require.False(dc.isDAIncluded("hash"), "DAIncluded should be false for unseen hash")
 	dc.setDAIncluded("hash")
 	require.True(dc.isDAIncluded("hash"), "DAIncluded should be true for seen hash")
+
+	// Test clearSeen
+	hc.setHeader(height, header)
+	hc.clearSeen()
+	dc.clearSeen()
+	require.False(hc.isSeen("hash"), "isSeen should return false after clearSeen")
+	require.False(dc.isSeen("hash"), "isSeen should return false after clearSeen")
+	require.True(hc.isDAIncluded("hash"), "clearSeen should keep DAIncluded")
+	require.NotNil(hc.getHeader(height), "clearSeen should keep cached headers")
 }
@@ -0,0 +1,40 @@
+package block
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/celestiaorg/go-header"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// chainSubscriber is a subscriber of gossiped headers or block data, which rejects messages of other chains.
+//
+// Gossip topics are bound to chain ID and genesis hash (see p2p.Client.GossipNamespace), so messages of other
+// networks are not delivered. Headers and block data are gossiped by go-header without envelope (see
+// p2p.WithEnvelope), but chain ID of every message is validated before the message is processed by the syncer,
+// so that messages published in the topic by misbehaving peers are rejected (and peers penalized) before any
+// other verification.
+type chainSubscriber[H header.Header[H]] struct {
+	header.Subscriber[H]
+	chainID string
+}
+
+// SetVerifier registers verification func, called after chain ID of the message is validated.
+func (s *chainSubscriber[H]) SetVerifier(verifier func(context.Context, H) error) error {
+	return s.Subscriber.SetVerifier(func(ctx context.Context, h H) error {
+		if chainID := gossipChainID(h); chainID != s.chainID {
+			return fmt.Errorf("%w: chain ID %q, expected %q", ErrWrongChain, chainID, s.chainID)
+		}
+		return verifier(ctx, h)
+	})
+}
+
+// gossipChainID returns chain ID of gossiped header or block data, or empty string if it's not set.
+func gossipChainID[H header.Header[H]](h H) string {
+	if data, ok := any(h).(*types.Data); ok && data.Metadata == nil {
+		return ""
+	}
+	return h.ChainID()
+}
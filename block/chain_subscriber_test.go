@@ -0,0 +1,48 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/go-header"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// verifierSubscriber records verifier set by syncer.
+type verifierSubscriber[H header.Header[H]] struct {
+	header.Subscriber[H]
+	verifier func(context.Context, H) error
+}
+
+func (s *verifierSubscriber[H]) SetVerifier(verifier func(context.Context, H) error) error {
+	s.verifier = verifier
+	return nil
+}
+
+func TestChainSubscriber(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	headers := &verifierSubscriber[*types.SignedHeader]{}
+	verified := 0
+	sub := &chainSubscriber[*types.SignedHeader]{Subscriber: headers, chainID: "chain"}
+	require.NoError(sub.SetVerifier(func(context.Context, *types.SignedHeader) error {
+		verified++
+		return nil
+	}))
+	header, data := types.GetRandomBlock(1, 1, "chain")
+	require.NoError(headers.verifier(ctx, header))
+	other, _ := types.GetRandomBlock(1, 1, "other")
+	require.ErrorIs(headers.verifier(ctx, other), ErrWrongChain)
+	require.Equal(1, verified)
+
+	blocks := &verifierSubscriber[*types.Data]{}
+	dataSub := &chainSubscriber[*types.Data]{Subscriber: blocks, chainID: "chain"}
+	require.NoError(dataSub.SetVerifier(func(context.Context, *types.Data) error { return nil }))
+	data.Metadata = &types.Metadata{ChainID: "chain", Height: 1}
+	require.NoError(blocks.verifier(ctx, data))
+	data.Metadata = nil
+	require.ErrorIs(blocks.verifier(ctx, data), ErrWrongChain)
+}
@@ -0,0 +1,84 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rollkit/rollkit/alert"
+	"github.com/rollkit/rollkit/da"
+)
+
+// daThrottleFactor is the factor by which block time and interval between DA submissions are increased while DA
+// account balance is below throttling threshold.
+const daThrottleFactor = 4
+
+// SetDABalance sets function querying balance of the DA account paying for submissions, see DABalanceLoop.
+func (m *Manager) SetDABalance(balance da.BalanceFunc) {
+	m.daBalance = balance
+}
+
+// DABalanceLoop monitors balance of the DA account paying for submissions. It raises an alert while balance is
+// below configured minimum, and throttles block production and DA submissions while it's below throttling
+// threshold, so that the chain doesn't halt because the account silently ran dry.
+func (m *Manager) DABalanceLoop(ctx context.Context) {
+	conf := m.conf.DASigner
+	if m.daBalance == nil || (conf.MinBalance == 0 && conf.ThrottleBalance == 0) || conf.BalanceCheckInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(conf.BalanceCheckInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.checkDABalance(ctx); err != nil && ctx.Err() == nil {
+			m.logger.Error("failed to check DA account balance", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkDABalance queries balance of the DA account, raises an alert if it's below configured minimum and updates
+// throttling.
+func (m *Manager) checkDABalance(ctx context.Context) error {
+	balance, err := m.daBalance(ctx)
+	if err != nil {
+		return err
+	}
+	m.metrics.DABalance.Set(float64(balance))
+	threshold := m.conf.DASigner.ThrottleBalance
+	throttled := threshold > 0 && balance < threshold
+	if m.daThrottled.Swap(throttled) != throttled {
+		if throttled {
+			m.logger.Info("WARNING: DA account balance is low, slowing down block production and DA submissions",
+				"balance", balance, "throttleBalance", threshold, "factor", daThrottleFactor)
+			m.metrics.DAThrottled.Set(1)
+		} else {
+			m.logger.Info("DA account balance restored, block production and DA submissions are no longer throttled", "balance", balance)
+			m.metrics.DAThrottled.Set(0)
+		}
+	}
+	if minBalance := m.conf.DASigner.MinBalance; balance < minBalance {
+		m.logger.Error("DA account balance is low", "balance", balance, "minBalance", minBalance)
+		// balance is not a part of the message, so that repeated alerts are deduplicated
+		m.raiseAlert(alert.DALowBalance, fmt.Sprintf("balance of DA account %s is below %d", m.conf.DASigner.Address, minBalance))
+	}
+	return nil
+}
+
+// skipDASubmission returns true if DA submission should be skipped because DA account balance is low. Headers are
+// then submitted every daThrottleFactor DA block times, in larger batches, saving fixed costs of submissions.
+func (m *Manager) skipDASubmission() bool {
+	if !m.daThrottled.Load() {
+		m.daSkippedSubmissions = 0
+		return false
+	}
+	m.daSkippedSubmissions++
+	if m.daSkippedSubmissions < daThrottleFactor {
+		return true
+	}
+	m.daSkippedSubmissions = 0
+	return false
+}
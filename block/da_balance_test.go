@@ -0,0 +1,44 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goDATest "github.com/rollkit/go-da/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+)
+
+func TestDABalanceThrottling(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m := getManager(t, goDATest.NewDummyDA())
+	m.metrics = NopMetrics()
+	m.conf = config.BlockManagerConfig{BlockTime: time.Second, DASigner: config.DASignerConfig{ThrottleBalance: 1000}}
+	balance := uint64(2000)
+	m.SetDABalance(func(context.Context) (uint64, error) { return balance, nil })
+
+	require.NoError(m.checkDABalance(ctx))
+	require.Equal(time.Second, m.getBlockTime())
+	require.False(m.skipDASubmission())
+
+	// below threshold, blocks are produced and submitted less frequently
+	balance = 500
+	require.NoError(m.checkDABalance(ctx))
+	require.Equal(daThrottleFactor*time.Second, m.getBlockTime())
+	submitted := 0
+	for range 2 * daThrottleFactor {
+		if !m.skipDASubmission() {
+			submitted++
+		}
+	}
+	require.Equal(2, submitted)
+
+	balance = 1000
+	require.NoError(m.checkDABalance(ctx))
+	require.Equal(time.Second, m.getBlockTime())
+	require.False(m.skipDASubmission())
+}
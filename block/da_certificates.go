@@ -0,0 +1,27 @@
+package block
+
+import (
+	"context"
+
+	goDA "github.com/rollkit/go-da"
+)
+
+// saveDACertificates persists inclusion certificates of blocks at given heights, included in DA at daHeight as
+// blobs with given IDs, if enabled with DACertificates. Errors are logged, certificates are not needed to
+// produce or sync blocks.
+func (m *Manager) saveDACertificates(ctx context.Context, daHeight uint64, heights []uint64, ids []goDA.ID, blobs [][]byte) {
+	if !m.conf.DACertificates || len(heights) == 0 {
+		return
+	}
+	certs, err := m.dalc.InclusionCertificates(ctx, daHeight, ids, blobs)
+	if err != nil {
+		m.logger.Error("failed to get DA inclusion certificates", "daHeight", daHeight, "error", err)
+		return
+	}
+	for i, cert := range certs {
+		cert.Height = heights[i]
+		if err := m.store.SaveDAInclusionCertificate(ctx, cert); err != nil {
+			m.logger.Error("failed to save DA inclusion certificate", "height", cert.Height, "error", err)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	goDATest "github.com/rollkit/go-da/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestSaveDACertificates(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m := &Manager{
+		store:  store.New(kv),
+		dalc:   da.NewDAClient(goDATest.NewDummyDA(), -1, -1, nil, nil, test.NewLogger(t)),
+		logger: test.NewLogger(t),
+	}
+	header1, _ := types.GetRandomBlock(1, 0, "TestSaveDACertificates")
+	header2, _ := types.GetRandomBlock(2, 0, "TestSaveDACertificates")
+	res := m.dalc.SubmitHeaders(ctx, []*types.SignedHeader{header1, header2}, goDATest.DefaultMaxBlobSize, -1)
+	require.Equal(da.StatusSuccess, res.Code)
+
+	// certificates are not saved unless enabled
+	m.saveDACertificates(ctx, res.DAHeight, []uint64{1, 2}, res.IDs, res.Blobs)
+	_, err = m.store.GetDAInclusionCertificate(ctx, 1)
+	require.Error(err)
+
+	m.conf = config.BlockManagerConfig{DACertificates: true}
+	m.saveDACertificates(ctx, res.DAHeight, []uint64{1, 2}, res.IDs, res.Blobs)
+	for i, height := range []uint64{1, 2} {
+		cert, err := m.store.GetDAInclusionCertificate(ctx, height)
+		require.NoError(err)
+		require.Equal(height, cert.Height)
+		require.Equal(res.DAHeight, cert.DAHeight)
+		require.EqualValues(res.IDs[i], cert.ID)
+		require.NotEmpty(cert.Proof)
+	}
+}
@@ -0,0 +1,73 @@
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/store"
+)
+
+// DARetrievalHeightKey is the metadata key of the DA height up to which (inclusive) DA blocks were fully
+// processed: retrieved, and all headers found in them applied.
+const DARetrievalHeightKey = "da retrieval height"
+
+// daCheckpoint records the highest block height found in a retrieved DA block.
+type daCheckpoint struct {
+	daHeight    uint64
+	blockHeight uint64
+}
+
+// daCheckpointer persists progress of DA retrieval, so that after restart retrieval resumes from the last
+// fully processed DA height, instead of rescanning DA from the height recorded in the last state, which
+// doesn't move while no blocks are synced. DA height is fully processed once all headers found in it are
+// applied, so headers retrieved, but not synced yet, are retrieved again after restart. It's only used by
+// RetrieveLoop.
+type daCheckpointer struct {
+	store   store.Store
+	pending []daCheckpoint
+	saved   uint64
+}
+
+// processed records that DA block at daHeight was retrieved, and the highest block height found in it.
+func (c *daCheckpointer) processed(daHeight, blockHeight uint64) {
+	c.pending = append(c.pending, daCheckpoint{daHeight: daHeight, blockHeight: blockHeight})
+}
+
+// advance persists the highest DA height which, together with all preceding DA heights, is fully processed.
+func (c *daCheckpointer) advance(ctx context.Context) error {
+	height := c.store.Height()
+	checkpoint := c.saved
+	for len(c.pending) > 0 && c.pending[0].blockHeight <= height {
+		checkpoint = c.pending[0].daHeight
+		c.pending = c.pending[1:]
+	}
+	if checkpoint <= c.saved {
+		return nil
+	}
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, checkpoint)
+	if err := c.store.SetMetadata(ctx, DARetrievalHeightKey, value); err != nil {
+		return err
+	}
+	c.saved = checkpoint
+	return nil
+}
+
+// loadDARetrievalHeight returns the last fully processed DA height, and false if it was never saved.
+func loadDARetrievalHeight(ctx context.Context, s store.Store) (uint64, bool, error) {
+	value, err := s.GetMetadata(ctx, DARetrievalHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if len(value) != 8 {
+		return 0, false, fmt.Errorf("invalid DA retrieval height, length %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), true, nil
+}
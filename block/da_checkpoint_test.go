@@ -0,0 +1,57 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/store"
+)
+
+func TestDACheckpointer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := store.New(kv)
+
+	_, found, err := loadDARetrievalHeight(ctx, s)
+	require.NoError(err)
+	assert.False(t, found)
+
+	c := &daCheckpointer{store: s}
+	c.processed(10, 0) // no headers
+	c.processed(11, 2)
+	c.processed(12, 0)
+	c.processed(13, 3)
+
+	// DA heights without headers are processed immediately
+	require.NoError(c.advance(ctx))
+	checkpoint, found, err := loadDARetrievalHeight(ctx, s)
+	require.NoError(err)
+	assert.True(t, found)
+	assert.Equal(t, uint64(10), checkpoint)
+
+	// DA height is not processed until all headers found in it are applied
+	s.SetHeight(ctx, 1)
+	require.NoError(c.advance(ctx))
+	checkpoint, _, err = loadDARetrievalHeight(ctx, s)
+	require.NoError(err)
+	assert.Equal(t, uint64(10), checkpoint)
+
+	s.SetHeight(ctx, 2)
+	require.NoError(c.advance(ctx))
+	checkpoint, _, err = loadDARetrievalHeight(ctx, s)
+	require.NoError(err)
+	assert.Equal(t, uint64(12), checkpoint)
+
+	s.SetHeight(ctx, 3)
+	require.NoError(c.advance(ctx))
+	checkpoint, _, err = loadDARetrievalHeight(ctx, s)
+	require.NoError(err)
+	assert.Equal(t, uint64(13), checkpoint)
+	assert.Empty(t, c.pending)
+}
@@ -0,0 +1,191 @@
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+// LastSequencerDAHeightKey is the key used for persisting the DA height of the latest sequencer header in store.
+const LastSequencerDAHeightKey = "last sequencer da height"
+
+// forcedInclusionEvent passes transactions posted directly to DA from RetrieveLoop to SyncLoop.
+type forcedInclusionEvent struct {
+	txs      cmtypes.Txs
+	daHeight uint64
+	daTime   time.Time
+	// done receives the result of deriving a block from transactions
+	done chan error
+}
+
+// DAOnly returns true if the sequencer is considered down and blocks are derived from DA only.
+func (m *Manager) DAOnly() bool {
+	return m.daOnly.Load()
+}
+
+// setLastSequencerDAHeight records DA height of a sequencer header retrieved from DA. It ends DA-only mode.
+func (m *Manager) setLastSequencerDAHeight(ctx context.Context, daHeight uint64) error {
+	if daHeight <= m.lastSequencerDAHeight.Load() {
+		return nil
+	}
+	m.lastSequencerDAHeight.Store(daHeight)
+	if m.daOnly.CompareAndSwap(true, false) {
+		m.logger.Info("sequencer is back, leaving DA-only mode", "daHeight", daHeight)
+		m.metrics.DAOnly.Set(0)
+	}
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, daHeight)
+	return m.store.SetMetadata(ctx, LastSequencerDAHeightKey, heightBytes)
+}
+
+// extendsDerivedBlocks returns true if sequencer header is built on top of the last block derived in DA-only mode.
+// Other sequencer headers (e.g. produced before DA-only mode and submitted late) are ignored in DA-only mode.
+func (m *Manager) extendsDerivedBlocks(ctx context.Context, header *types.SignedHeader) (bool, error) {
+	height := m.GetDAIncludedHeight()
+	if header.Height() != height+1 {
+		return false, nil
+	}
+	if m.store.Height() < height {
+		return false, fmt.Errorf("can't verify sequencer header before syncing up to DA included height %d, current height %d", height, m.store.Height())
+	}
+	lastHeader, _, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return false, fmt.Errorf("failed to load block at height %d: %w", height, err)
+	}
+	return lastHeader.Hash().String() == header.LastHeaderHash.String(), nil
+}
+
+// processDAOnly switches to DA-only mode if there were no sequencer headers in DA for SequencerDowntimeThreshold
+// DA blocks. In DA-only mode, a block is derived from transactions posted to forced inclusion namespace at given DA height.
+//
+// Both the switch and derived blocks depend only on DA, so that all full nodes derive the same blocks.
+func (m *Manager) processDAOnly(ctx context.Context, daHeight uint64) error {
+	threshold := m.conf.SequencerDowntimeThreshold
+	last := m.lastSequencerDAHeight.Load()
+	// DA-only mode is not possible before the first sequencer header is included in DA
+	if threshold == 0 || last == 0 || daHeight < last+threshold {
+		return nil
+	}
+	if m.daOnly.CompareAndSwap(false, true) {
+		m.logger.Info("no sequencer headers in DA, switching to DA-only mode", "daHeight", daHeight, "lastSequencerDAHeight", last)
+		m.metrics.DAOnly.Set(1)
+	}
+
+	res := m.dalc.RetrieveForcedInclusionTxs(ctx, daHeight)
+	switch res.Code {
+	case da.StatusSuccess:
+	case da.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to retrieve forced inclusion transactions: %s", res.Message)
+	}
+	txs := make(cmtypes.Txs, len(res.Txs))
+	for i := range res.Txs {
+		txs[i] = res.Txs[i]
+	}
+
+	event := forcedInclusionEvent{txs: txs, daHeight: daHeight, daTime: res.Timestamp, done: make(chan error, 1)}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case m.forcedInCh <- event:
+	}
+	// wait until block is derived, so that following DA heights are processed on top of it
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-event.done:
+		return err
+	}
+}
+
+// applyDerivedBlock derives a block from transactions posted to forced inclusion namespace, and applies it.
+//
+// Derived block is built on top of the last block included in DA. If the node applied blocks that were not
+// included in DA before switching to DA-only mode, these blocks are orphaned and the node is halted.
+func (m *Manager) applyDerivedBlock(ctx context.Context, event forcedInclusionEvent) error {
+	height := m.store.Height()
+	daIncludedHeight := m.GetDAIncludedHeight()
+	if height < daIncludedHeight {
+		return fmt.Errorf("can't derive block before syncing up to DA included height %d, current height %d", daIncludedHeight, height)
+	}
+	if height > daIncludedHeight {
+		return fmt.Errorf("%w: blocks above DA included height %d were orphaned by DA-only mode, current height %d", ErrHalted, daIncludedHeight, height)
+	}
+
+	lastSignature, err := m.store.GetSignature(ctx, height)
+	if err != nil {
+		return fmt.Errorf("error while loading last commit: %w", err)
+	}
+	lastHeader, lastData, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return fmt.Errorf("error while loading last block: %w", err)
+	}
+	blockTime := event.daTime
+	if blockTime.Before(lastHeader.Time()) {
+		blockTime = lastHeader.Time()
+	}
+
+	header, data := m.executor.CreateDerivedBlock(height+1, lastSignature, lastHeader.Hash(), m.lastState, event.txs, blockTime)
+	header.DataHash = data.Hash()
+	header.Validators = m.getLastStateValidators()
+	header.ValidatorHash = header.Validators.Hash()
+	// derived blocks are not signed
+	header.Signature = types.Signature{}
+	data.Metadata = &types.Metadata{
+		ChainID:      header.ChainID(),
+		Height:       header.Height(),
+		Time:         header.BaseHeader.Time,
+		LastDataHash: lastData.Hash(),
+	}
+
+	newState, responses, err := m.executor.ApplyDerivedBlock(ctx, m.lastState, header, data)
+	if err != nil {
+		return fmt.Errorf("failed to apply derived block: %w", err)
+	}
+	if err := m.store.SaveBlockData(ctx, header, data, &header.Signature); err != nil {
+		return SaveBlockError{err}
+	}
+	_, retainHeight, err := m.executor.Commit(ctx, newState, header, data, responses)
+	if err != nil {
+		return fmt.Errorf("failed to Commit: %w", err)
+	}
+	if err := m.setAppRetainHeight(ctx, retainHeight); err != nil {
+		return err
+	}
+	if err := m.store.SaveBlockResponses(ctx, header.Height(), responses); err != nil {
+		return SaveBlockResponsesError{err}
+	}
+	m.store.SetHeight(ctx, header.Height())
+	if event.daHeight > newState.DAHeight {
+		newState.DAHeight = event.daHeight
+	}
+	if err := m.updateState(ctx, newState); err != nil {
+		m.logger.Error("failed to save updated state", "error", err)
+	}
+	// derived blocks are included in DA by definition
+	m.headerCache.setDAIncluded(header.Hash().String())
+	if err := m.setDAIncludedHeight(ctx, header.Height()); err != nil {
+		return err
+	}
+	m.logger.Info("derived block from DA", "height", header.Height(), "daHeight", event.daHeight, "num_tx", len(data.Txs))
+
+	// pass derived block to sync services, so that they accept sequencer blocks built on top of it
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case m.HeaderCh <- header:
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case m.DataCh <- data:
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	goDA "github.com/rollkit/go-da"
+	goDAMock "github.com/rollkit/go-da/mocks"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestDAOnlyMode(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	namespace := []byte("forced")
+	daTime := time.Unix(1700000000, 0)
+	mockDA := new(goDAMock.MockDA)
+	mockDA.On("GetIDs", mock.Anything, uint64(15), namespace).Return(&goDA.GetIDsResult{Timestamp: daTime}, nil)
+	mockDA.On("GetIDs", mock.Anything, uint64(16), namespace).Return(&goDA.GetIDsResult{IDs: []goDA.ID{[]byte("id1"), []byte("id2")}, Timestamp: daTime}, nil)
+	mockDA.On("Get", mock.Anything, []goDA.ID{[]byte("id1"), []byte("id2")}, namespace).Return([]goDA.Blob{[]byte("tx1"), []byte("tx2")}, nil)
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	logger := test.NewLogger(t)
+	m := &Manager{
+		conf:       config.BlockManagerConfig{SequencerDowntimeThreshold: 5},
+		store:      store.New(kv),
+		dalc:       da.NewDAClient(mockDA, -1, -1, nil, nil, logger),
+		forcedInCh: make(chan forcedInclusionEvent),
+		metrics:    NopMetrics(),
+		logger:     logger,
+	}
+	m.dalc.ForcedInclusionNamespace = namespace
+
+	// DA-only mode is not possible before the first sequencer header
+	require.NoError(m.processDAOnly(ctx, 100))
+	require.False(m.DAOnly())
+
+	require.NoError(m.setLastSequencerDAHeight(ctx, 10))
+	require.NoError(m.processDAOnly(ctx, 14))
+	require.False(m.DAOnly())
+
+	// no transactions in forced inclusion namespace, no block is derived
+	require.NoError(m.processDAOnly(ctx, 15))
+	require.True(m.DAOnly())
+
+	go func() {
+		event := <-m.forcedInCh
+		if event.daHeight != 16 || !daTime.Equal(event.daTime) || len(event.txs) != 2 {
+			event.done <- errors.New("unexpected event")
+			return
+		}
+		event.done <- nil
+	}()
+	require.NoError(m.processDAOnly(ctx, 16))
+	require.True(m.DAOnly())
+
+	// sequencer header ends DA-only mode, last sequencer DA height is persisted
+	require.NoError(m.setLastSequencerDAHeight(ctx, 17))
+	require.False(m.DAOnly())
+	m2 := &Manager{store: m.store}
+	m2.init(ctx)
+	require.Equal(uint64(17), m2.lastSequencerDAHeight.Load())
+	mockDA.AssertExpectations(t)
+}
+
+func TestApplyDerivedBlockOrphaned(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m := &Manager{
+		store:  store.New(kv),
+		logger: test.NewLogger(t),
+	}
+	event := forcedInclusionEvent{txs: cmtypes.Txs{[]byte("tx")}, daHeight: 20}
+
+	// blocks included in DA must be synced first
+	m.daIncludedHeight.Store(5)
+	m.store.SetHeight(ctx, 4)
+	err = m.applyDerivedBlock(ctx, event)
+	require.Error(err)
+	require.NotErrorIs(err, ErrHalted)
+
+	// blocks not included in DA are orphaned
+	m.store.SetHeight(ctx, 6)
+	require.ErrorIs(m.applyDerivedBlock(ctx, event), ErrHalted)
+}
@@ -0,0 +1,84 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rollkit/rollkit/da"
+)
+
+// daPrefetcher retrieves headers from following DA heights in the background while syncing from DA, so
+// that up to workers DA heights are retrieved in parallel. Headers are still processed by RetrieveLoop in
+// order of DA heights. Following heights are prefetched only while the retrieved DA blocks are older than
+// a few DA block times, so DA is not queried for heights from the future once retrieval catches up. It's
+// only used by RetrieveLoop.
+type daPrefetcher struct {
+	retrieveHeaders func(ctx context.Context, daHeight uint64) da.ResultRetrieveHeaders
+	workers         int
+	daBlockTime     time.Duration
+
+	mtx sync.Mutex
+	// pending holds results of prefetched DA heights, channels are buffered so that fetches are never blocked
+	pending map[uint64]chan da.ResultRetrieveHeaders
+}
+
+func newDAPrefetcher(retrieveHeaders func(ctx context.Context, daHeight uint64) da.ResultRetrieveHeaders, workers int, daBlockTime time.Duration) *daPrefetcher {
+	return &daPrefetcher{
+		retrieveHeaders: retrieveHeaders,
+		workers:         workers,
+		daBlockTime:     daBlockTime,
+		pending:         make(map[uint64]chan da.ResultRetrieveHeaders),
+	}
+}
+
+// retrieve returns headers from given DA height, prefetched if available. Failed prefetches are not
+// retried in the background, so when retrieve returns an error, calling it again fetches the height directly.
+func (p *daPrefetcher) retrieve(ctx context.Context, daHeight uint64) da.ResultRetrieveHeaders {
+	p.mtx.Lock()
+	ch, ok := p.pending[daHeight]
+	for h := range p.pending {
+		// results of already processed heights are not needed anymore
+		if h <= daHeight {
+			delete(p.pending, h)
+		}
+	}
+	p.mtx.Unlock()
+
+	var res da.ResultRetrieveHeaders
+	if ok {
+		select {
+		case res = <-ch:
+		case <-ctx.Done():
+			return da.ResultRetrieveHeaders{BaseResult: da.BaseResult{Code: da.StatusError, Message: ctx.Err().Error()}}
+		}
+	} else {
+		res = p.retrieveHeaders(ctx, daHeight)
+	}
+	if res.Code != da.StatusError && p.behind(res) {
+		p.prefetch(ctx, daHeight+1)
+	}
+	return res
+}
+
+// behind returns true if the retrieved DA block is not one of the latest ones. If DA layer doesn't report
+// time of DA blocks, retrieval is assumed to be behind.
+func (p *daPrefetcher) behind(res da.ResultRetrieveHeaders) bool {
+	return res.Timestamp.IsZero() || time.Since(res.Timestamp) > time.Duration(p.workers)*p.daBlockTime
+}
+
+// prefetch starts retrieval of workers-1 DA heights starting at from, which are not fetched yet.
+func (p *daPrefetcher) prefetch(ctx context.Context, from uint64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for h := from; h < from+uint64(p.workers-1); h++ { //nolint:gosec
+		if _, ok := p.pending[h]; ok {
+			continue
+		}
+		ch := make(chan da.ResultRetrieveHeaders, 1)
+		p.pending[h] = ch
+		go func() {
+			ch <- p.retrieveHeaders(ctx, h)
+		}()
+	}
+}
@@ -0,0 +1,70 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/da"
+)
+
+func TestDAPrefetcher(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mtx       sync.Mutex
+		calls     = make(map[uint64]int)
+		latest    = uint64(5)
+		timestamp = time.Now().Add(-time.Hour)
+	)
+	retrieve := func(_ context.Context, daHeight uint64) da.ResultRetrieveHeaders {
+		mtx.Lock()
+		defer mtx.Unlock()
+		calls[daHeight]++
+		if daHeight > latest {
+			return da.ResultRetrieveHeaders{BaseResult: da.BaseResult{Code: da.StatusError, Message: ErrHeightFromFutureStr}}
+		}
+		return da.ResultRetrieveHeaders{BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: daHeight}, Timestamp: timestamp}
+	}
+	callsOf := func(daHeight uint64) int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return calls[daHeight]
+	}
+
+	p := newDAPrefetcher(retrieve, 3, time.Second)
+	res := p.retrieve(ctx, 1)
+	require.Equal(t, da.StatusSuccess, res.Code)
+	// following heights are retrieved in the background
+	require.Eventually(t, func() bool { return callsOf(2) == 1 && callsOf(3) == 1 }, time.Second, time.Millisecond)
+
+	for daHeight := uint64(2); daHeight <= 5; daHeight++ {
+		res = p.retrieve(ctx, daHeight)
+		require.Equal(t, da.StatusSuccess, res.Code)
+		assert.Equal(t, daHeight, res.DAHeight)
+	}
+	require.Eventually(t, func() bool { return callsOf(6) == 1 && callsOf(7) == 1 }, time.Second, time.Millisecond)
+	for daHeight := uint64(1); daHeight <= 5; daHeight++ {
+		assert.Equal(t, 1, callsOf(daHeight), "DA height %d retrieved more than once", daHeight)
+	}
+
+	// prefetched error is returned, and the height is retrieved directly when retried
+	res = p.retrieve(ctx, 6)
+	assert.Equal(t, da.StatusError, res.Code)
+	assert.Equal(t, 1, callsOf(6))
+	mtx.Lock()
+	latest = 6
+	timestamp = time.Now()
+	mtx.Unlock()
+	res = p.retrieve(ctx, 6)
+	assert.Equal(t, da.StatusSuccess, res.Code)
+	assert.Equal(t, 2, callsOf(6))
+
+	// latest DA blocks don't trigger prefetching
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 1, callsOf(7))
+	assert.Equal(t, 0, callsOf(8))
+}
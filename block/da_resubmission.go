@@ -0,0 +1,194 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	goDA "github.com/rollkit/go-da"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+// DASubmissionKey is the key used for persisting the record of DA submissions which outcome is not known in store.
+const DASubmissionKey = "da submission"
+
+// daSubmission records headers submitted to DA, before the submission is confirmed. Submissions which timed out,
+// or were interrupted by a restart, may still be included in DA, so the record bounds the range of DA heights
+// searched for the headers before they are submitted again.
+type daSubmission struct {
+	// From and To are the heights of the first and the last submitted header.
+	From, To uint64
+	// DAHeight is the DA height retrieved when the first unconfirmed submission was made. Headers can't be
+	// included below it.
+	DAHeight uint64
+	// LastDAHeight is the DA height retrieved when the latest submission was made.
+	LastDAHeight uint64
+}
+
+func (s *daSubmission) encode() []byte {
+	b := make([]byte, 32)
+	binary.BigEndian.PutUint64(b, s.From)
+	binary.BigEndian.PutUint64(b[8:], s.To)
+	binary.BigEndian.PutUint64(b[16:], s.DAHeight)
+	binary.BigEndian.PutUint64(b[24:], s.LastDAHeight)
+	return b
+}
+
+// loadDASubmission returns the record of unconfirmed DA submissions, or nil if there is none.
+func (m *Manager) loadDASubmission(ctx context.Context) (*daSubmission, error) {
+	value, err := m.store.GetMetadata(ctx, DASubmissionKey)
+	if errors.Is(err, ds.ErrNotFound) || (err == nil && len(value) == 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load DA submission record: %w", err)
+	}
+	if len(value) != 32 {
+		return nil, fmt.Errorf("invalid DA submission record, length %d", len(value))
+	}
+	return &daSubmission{
+		From:         binary.BigEndian.Uint64(value),
+		To:           binary.BigEndian.Uint64(value[8:]),
+		DAHeight:     binary.BigEndian.Uint64(value[16:]),
+		LastDAHeight: binary.BigEndian.Uint64(value[24:]),
+	}, nil
+}
+
+// recordDASubmission persists the record of headers about to be submitted to DA, extending the record of previous
+// unconfirmed submissions.
+func (m *Manager) recordDASubmission(ctx context.Context, headers []*types.SignedHeader) error {
+	daHeight := atomic.LoadUint64(&m.daHeight)
+	s := &daSubmission{
+		From:         headers[0].Height(),
+		To:           headers[len(headers)-1].Height(),
+		DAHeight:     daHeight,
+		LastDAHeight: daHeight,
+	}
+	prev, err := m.loadDASubmission(ctx)
+	if err != nil {
+		return err
+	}
+	if prev != nil {
+		s.From = min(s.From, prev.From)
+		s.To = max(s.To, prev.To)
+		s.DAHeight = min(s.DAHeight, prev.DAHeight)
+	}
+	if err := m.store.SetMetadata(ctx, DASubmissionKey, s.encode()); err != nil {
+		return fmt.Errorf("failed to save DA submission record: %w", err)
+	}
+	return nil
+}
+
+// clearDASubmission removes the record of unconfirmed DA submissions, once all of them are confirmed.
+func (m *Manager) clearDASubmission(ctx context.Context) {
+	if err := m.store.SetMetadata(ctx, DASubmissionKey, nil); err != nil {
+		m.logger.Error("failed to clear DA submission record", "error", err)
+	}
+}
+
+// skipIncludedHeaders returns headers which are not included in DA yet, so that they are not submitted twice. Headers
+// are included if the retrieve loop has found them in DA, or if they are found in DA heights where unconfirmed
+// submissions could have been included: from the DA height of the first unconfirmed submission, up to DAMempoolTTL
+// heights after the latest one, as blobs not included within the TTL are dropped from DA mempool. Search stops at
+// the first DA height that can't be retrieved, e.g. because it wasn't produced yet, so headers that still may be
+// included are submitted again. Headers found in DA are marked as DA included, like after a successful submission.
+func (m *Manager) skipIncludedHeaders(ctx context.Context, headers []*types.SignedHeader) ([]*types.SignedHeader, error) {
+	submission, err := m.loadDASubmission(ctx)
+	if err != nil || submission == nil {
+		return headers, err
+	}
+
+	included := make(map[uint64]bool)
+	pending := make(map[uint64]*types.SignedHeader)
+	for _, header := range headers {
+		height := header.Height()
+		_, err := m.getBlockDAHeight(ctx, height)
+		switch {
+		case err == nil:
+			included[height] = true
+		case errors.Is(err, ds.ErrNotFound):
+			if height >= submission.From && height <= submission.To {
+				pending[height] = header
+			}
+		default:
+			return headers, err
+		}
+	}
+
+	found := 0
+	for daHeight := submission.DAHeight; len(pending) > 0 && daHeight <= submission.LastDAHeight+m.conf.DAMempoolTTL; daHeight++ {
+		resp := m.dalc.RetrieveHeaders(ctx, daHeight)
+		if resp.Code == da.StatusNotFound {
+			continue
+		}
+		if resp.Code != da.StatusSuccess {
+			m.logger.Debug("stopped searching DA for submitted headers", "daHeight", daHeight, "reason", resp.Message)
+			break
+		}
+		var heights []uint64
+		var ids []goDA.ID
+		var blobs [][]byte
+		for i, h := range resp.Headers {
+			if h == nil {
+				continue
+			}
+			header, ok := pending[h.Height()]
+			if !ok || !bytes.Equal(h.Hash(), header.Hash()) {
+				continue
+			}
+			if err := m.markSubmitted(ctx, header, daHeight); err != nil {
+				return headers, err
+			}
+			delete(pending, h.Height())
+			included[h.Height()] = true
+			found++
+			if i < len(resp.IDs) && i < len(resp.Blobs) {
+				heights = append(heights, h.Height())
+				ids = append(ids, resp.IDs[i])
+				blobs = append(blobs, resp.Blobs[i])
+			}
+		}
+		m.saveDACertificates(ctx, daHeight, heights, ids, blobs)
+	}
+
+	remaining := make([]*types.SignedHeader, 0, len(headers))
+	for _, header := range headers {
+		if !included[header.Height()] {
+			remaining = append(remaining, header)
+		}
+	}
+	if skipped := len(headers) - len(remaining); skipped > 0 {
+		m.logger.Info("skipping headers already included in DA", "skipped", skipped, "foundInDA", found)
+		m.metrics.DASkippedResubmissions.Add(float64(skipped))
+	}
+	// headers before the first remaining one are all included
+	lastSubmittedHeight := headers[len(headers)-1].Height()
+	if len(remaining) > 0 {
+		lastSubmittedHeight = remaining[0].Height() - 1
+	}
+	m.pendingHeaders.setLastSubmittedHeight(ctx, lastSubmittedHeight)
+	if len(remaining) == 0 {
+		m.clearDASubmission(ctx)
+	}
+	return remaining, nil
+}
+
+// markSubmitted marks header as included in DA at daHeight.
+func (m *Manager) markSubmitted(ctx context.Context, header *types.SignedHeader, daHeight uint64) error {
+	m.headerCache.setDAIncluded(header.Hash().String())
+	if err := m.setDAIncludedHeight(ctx, header.Height()); err != nil {
+		return err
+	}
+	if err := m.setBlockDAHeight(ctx, header.Height(), daHeight); err != nil {
+		return err
+	}
+	m.finality.daIncluded(header.Height(), time.Now())
+	return nil
+}
@@ -0,0 +1,68 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	goDATest "github.com/rollkit/go-da/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestSkipIncludedHeaders(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m := getManager(t, goDATest.NewDummyDA())
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m.store = store.New(kv)
+	m.metrics = NopMetrics()
+	m.conf = config.BlockManagerConfig{DAMempoolTTL: 5}
+	m.pendingHeaders, err = NewPendingHeaders(m.store, m.logger)
+	require.NoError(err)
+
+	headers := make([]*types.SignedHeader, 4)
+	for i := range headers {
+		header, data := types.GetRandomBlock(uint64(i+1), 1, "TestSkipIncludedHeaders") //nolint:gosec
+		require.NoError(m.store.SaveBlockData(ctx, header, data, &types.Signature{}))
+		headers[i] = header
+	}
+	m.store.SetHeight(ctx, 3)
+
+	// submission of headers 1 and 2 landed in DA, but node was restarted before it was confirmed
+	require.NoError(m.recordDASubmission(ctx, headers[:2]))
+	res := m.dalc.SubmitHeaders(ctx, headers[:2], 1<<20, -1)
+	require.Equal(da.StatusSuccess, res.Code)
+
+	require.NoError(m.submitHeadersToDA(ctx))
+	require.True(m.pendingHeaders.isEmpty())
+	require.Equal(uint64(3), m.GetDAIncludedHeight())
+	for height, daHeight := range map[uint64]uint64{1: res.DAHeight, 2: res.DAHeight, 3: res.DAHeight + 1} {
+		h, err := m.getBlockDAHeight(ctx, height)
+		require.NoError(err)
+		require.Equal(daHeight, h, "height %d", height)
+	}
+	// only header 3 was submitted again
+	retrieved := m.dalc.RetrieveHeaders(ctx, res.DAHeight+1)
+	require.Equal(da.StatusSuccess, retrieved.Code)
+	require.Len(retrieved.Headers, 1)
+	require.Equal(headers[2].Hash(), retrieved.Headers[0].Hash())
+	submission, err := m.loadDASubmission(ctx)
+	require.NoError(err)
+	require.Nil(submission)
+
+	// all pending headers are included, nothing is submitted
+	m.store.SetHeight(ctx, 4)
+	require.NoError(m.recordDASubmission(ctx, headers[3:]))
+	res = m.dalc.SubmitHeaders(ctx, headers[3:], 1<<20, -1)
+	require.Equal(da.StatusSuccess, res.Code)
+	require.NoError(m.submitHeadersToDA(ctx))
+	require.True(m.pendingHeaders.isEmpty())
+	require.Equal(uint64(4), m.GetDAIncludedHeight())
+	require.NotEqual(da.StatusSuccess, m.dalc.RetrieveHeaders(ctx, res.DAHeight+1).Code)
+}
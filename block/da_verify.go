@@ -0,0 +1,156 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/da"
+)
+
+// blockDAHeightKeyPrefix is the prefix of keys used for persisting DA heights of blocks in store.
+const blockDAHeightKeyPrefix = "da height/"
+
+// Results of re-verification of a historical block in DA.
+const (
+	DAVerifyAvailable   = "available"
+	DAVerifyUnavailable = "unavailable"
+	// DAVerifyError means that DA layer couldn't be queried, so availability of the block is unknown.
+	DAVerifyError = "error"
+)
+
+// DAVerification is the result of a re-verification run.
+type DAVerification struct {
+	Time time.Time
+	// Checked is the number of blocks re-fetched from DA.
+	Checked int
+	// Unavailable are heights of blocks which headers are no longer retrievable from DA.
+	Unavailable []uint64
+	// Failed are heights of blocks which couldn't be verified, because of DA errors.
+	Failed []uint64
+}
+
+func blockDAHeightKey(height uint64) string {
+	return fmt.Sprintf("%s%d", blockDAHeightKeyPrefix, height)
+}
+
+// setBlockDAHeight records the DA height at which header of the block was included.
+func (m *Manager) setBlockDAHeight(ctx context.Context, height, daHeight uint64) error {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, daHeight)
+	return m.store.SetMetadata(ctx, blockDAHeightKey(height), heightBytes)
+}
+
+// getBlockDAHeight returns the DA height at which header of the block was included.
+func (m *Manager) getBlockDAHeight(ctx context.Context, height uint64) (uint64, error) {
+	heightBytes, err := m.store.GetMetadata(ctx, blockDAHeightKey(height))
+	if err != nil {
+		return 0, err
+	}
+	if len(heightBytes) != 8 {
+		return 0, fmt.Errorf("invalid DA height of block %d, length %d", height, len(heightBytes))
+	}
+	return binary.BigEndian.Uint64(heightBytes), nil
+}
+
+// LastDAVerification returns the result of the latest re-verification run. Time is zero if there was no run yet.
+func (m *Manager) LastDAVerification() DAVerification {
+	m.daVerificationMtx.Lock()
+	defer m.daVerificationMtx.Unlock()
+	return m.daVerification
+}
+
+// DAVerifyLoop periodically re-fetches headers of a random sample of DA included blocks from DA, to confirm
+// they are still retrievable. Blocks that became unavailable are reported as errors and in metrics.
+// Only blocks which DA height was recorded when they were submitted or retrieved are verified.
+func (m *Manager) DAVerifyLoop(ctx context.Context) {
+	if m.conf.DAVerifyInterval <= 0 || m.conf.DAVerifySamples == 0 {
+		return
+	}
+	ticker := time.NewTicker(m.conf.DAVerifyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.verifyDA(ctx); err != nil && ctx.Err() == nil {
+			m.logger.Error("failed to re-verify blocks in DA", "error", err)
+		}
+	}
+}
+
+// verifyDA re-verifies availability of up to DAVerifySamples random DA included blocks.
+func (m *Manager) verifyDA(ctx context.Context) error {
+	first := uint64(m.genesis.InitialHeight) //nolint:gosec
+	last := m.GetDAIncludedHeight()
+	if last < first {
+		return nil
+	}
+
+	res := DAVerification{Time: time.Now()}
+	// blocks without recorded DA height are skipped, so some more attempts are made to fill the sample
+	for attempts := uint64(0); uint64(res.Checked) < m.conf.DAVerifySamples && attempts < 2*m.conf.DAVerifySamples; attempts++ { //nolint:gosec
+		height := first + rand.Uint64()%(last-first+1) //nolint:gosec
+		result, err := m.verifyBlockInDA(ctx, height)
+		if errors.Is(err, ds.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		res.Checked++
+		m.metrics.DAVerifications.With("result", result).Add(1)
+		switch result {
+		case DAVerifyUnavailable:
+			res.Unavailable = append(res.Unavailable, height)
+			m.logger.Error("block is no longer retrievable from DA", "height", height)
+		case DAVerifyError:
+			res.Failed = append(res.Failed, height)
+		}
+	}
+	m.metrics.DAUnavailableBlocks.Set(float64(len(res.Unavailable)))
+	m.logger.Info("re-verified blocks in DA", "checked", res.Checked, "unavailable", len(res.Unavailable), "failed", len(res.Failed))
+
+	m.daVerificationMtx.Lock()
+	m.daVerification = res
+	m.daVerificationMtx.Unlock()
+	return nil
+}
+
+// verifyBlockInDA checks if header of block at given height is retrievable from DA. ds.ErrNotFound is
+// returned if DA height of the block is not known.
+func (m *Manager) verifyBlockInDA(ctx context.Context, height uint64) (string, error) {
+	daHeight, err := m.getBlockDAHeight(ctx, height)
+	if err != nil {
+		return "", err
+	}
+	header, err := m.store.GetHeader(ctx, height)
+	if err != nil {
+		return "", fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	hash := header.Hash()
+
+	resp := m.dalc.RetrieveHeaders(ctx, daHeight)
+	switch resp.Code {
+	case da.StatusSuccess:
+		for _, h := range resp.Headers {
+			if h != nil && bytes.Equal(h.Hash(), hash) {
+				return DAVerifyAvailable, nil
+			}
+		}
+		return DAVerifyUnavailable, nil
+	case da.StatusNotFound:
+		return DAVerifyUnavailable, nil
+	default:
+		m.logger.Debug("failed to retrieve headers for re-verification", "height", height, "daHeight", daHeight, "error", resp.Message)
+		return DAVerifyError, nil
+	}
+}
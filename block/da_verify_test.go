@@ -0,0 +1,64 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+	goDATest "github.com/rollkit/go-da/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestVerifyDA(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m := getManager(t, goDATest.NewDummyDA())
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m.store = store.New(kv)
+	m.genesis = &cmtypes.GenesisDoc{InitialHeight: 1}
+	m.metrics = NopMetrics()
+	m.conf = config.BlockManagerConfig{DAVerifySamples: 100}
+
+	// no blocks included in DA yet
+	require.NoError(m.verifyDA(ctx))
+	require.Zero(m.LastDAVerification().Checked)
+
+	headers := make([]*types.SignedHeader, 4)
+	for i := range headers {
+		header, data := types.GetRandomBlock(uint64(i+1), 1, "TestVerifyDA") //nolint:gosec
+		require.NoError(m.store.SaveBlockData(ctx, header, data, &types.Signature{}))
+		headers[i] = header
+	}
+	res := m.dalc.SubmitHeaders(ctx, headers[:2], 1<<20, -1)
+	require.Equal(da.StatusSuccess, res.Code)
+	require.NoError(m.setBlockDAHeight(ctx, 1, res.DAHeight))
+	require.NoError(m.setBlockDAHeight(ctx, 2, res.DAHeight))
+	// header of block 3 is not in DA at recorded height, DA height of block 4 is unknown
+	other, _ := types.GetRandomBlock(3, 0, "TestVerifyDA")
+	otherRes := m.dalc.SubmitHeaders(ctx, []*types.SignedHeader{other}, 1<<20, -1)
+	require.Equal(da.StatusSuccess, otherRes.Code)
+	require.NoError(m.setBlockDAHeight(ctx, 3, otherRes.DAHeight))
+	m.daIncludedHeight.Store(4)
+
+	for height, expected := range map[uint64]string{1: DAVerifyAvailable, 2: DAVerifyAvailable, 3: DAVerifyUnavailable} {
+		result, err := m.verifyBlockInDA(ctx, height)
+		require.NoError(err)
+		require.Equal(expected, result, "height %d", height)
+	}
+
+	require.NoError(m.verifyDA(ctx))
+	v := m.LastDAVerification()
+	require.False(v.Time.IsZero())
+	require.NotZero(v.Checked)
+	require.Empty(v.Failed)
+	for _, height := range v.Unavailable {
+		require.Equal(uint64(3), height)
+	}
+}
@@ -61,3 +61,8 @@ func (hc *DataCache) isDAIncluded(hash string) bool {
 func (hc *DataCache) setDAIncluded(hash string) {
 	hc.daIncluded.Store(hash, true)
 }
+
+// clearSeen forgets hashes of seen blocks. Blocks at synced heights are still recognized by their height.
+func (hc *DataCache) clearSeen() {
+	hc.hashes.Clear()
+}
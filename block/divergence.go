@@ -0,0 +1,169 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rollkit/rollkit/alert"
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/state"
+	"github.com/rollkit/rollkit/types"
+)
+
+// DivergenceReportKey is the key used for persisting the last divergence report in store.
+const DivergenceReportKey = "divergence report"
+
+// txIndexUnknown is the reason why the index of the diverging transaction can't be determined.
+const txIndexUnknown = "blocks don't carry intermediate state roots"
+
+// ErrHalted is returned when the node has to stop syncing because local state diverged from the chain.
+var ErrHalted = errors.New("node halted")
+
+// DivergenceReport describes a block whose app hash doesn't match the result of local execution.
+type DivergenceReport struct {
+	// Height of the block that carries the unexpected app hash.
+	Height uint64 `json:"height"`
+	// LastExecutedHeight is the height of the last block executed locally, i.e. the block
+	// whose execution resulted in diverging state.
+	LastExecutedHeight uint64 `json:"last_executed_height"`
+	// ExpectedAppHash is the app hash from the block header.
+	ExpectedAppHash types.Hash `json:"expected_app_hash"`
+	// LocalAppHash is the app hash returned by the application.
+	LocalAppHash types.Hash `json:"local_app_hash"`
+	// NumTxs is the number of transactions in the last executed block.
+	NumTxs int `json:"num_txs"`
+	// TxIndex is the index of the first transaction with diverging intermediate state root.
+	// It's -1 if it can't be determined, see TxIndexUnknownReason.
+	TxIndex int `json:"tx_index"`
+	// TxIndexUnknownReason explains why TxIndex can't be determined, empty if it's known.
+	TxIndexUnknownReason string `json:"tx_index_unknown_reason,omitempty"`
+	// Policy is the app hash mismatch policy that was applied.
+	Policy string `json:"policy"`
+	// RolledBackTo is the height the store was rolled back to by rollback policy, 0 if it wasn't rolled back.
+	RolledBackTo uint64 `json:"rolled_back_to,omitempty"`
+	// Time is the local time of detection.
+	Time time.Time `json:"time"`
+}
+
+// validateAppHashMismatchPolicy returns an error if policy is not known.
+func validateAppHashMismatchPolicy(policy string) error {
+	switch policy {
+	case config.AppHashMismatchHalt, config.AppHashMismatchRollback, config.AppHashMismatchHeadersOnly:
+		return nil
+	default:
+		return fmt.Errorf("unknown app hash mismatch policy: %q", policy)
+	}
+}
+
+// GetDivergenceReport returns the last divergence report saved in store, if any.
+func (m *Manager) GetDivergenceReport(ctx context.Context) (*DivergenceReport, error) {
+	blob, err := m.store.GetMetadata(ctx, DivergenceReportKey)
+	if err != nil {
+		return nil, err
+	}
+	report := new(DivergenceReport)
+	if err := json.Unmarshal(blob, report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal divergence report: %w", err)
+	}
+	return report, nil
+}
+
+// IsHeadersOnly returns true if block execution was stopped because of app hash mismatch.
+func (m *Manager) IsHeadersOnly() bool {
+	return m.headersOnly.Load()
+}
+
+// handleAppHashMismatch reports the divergence and applies configured policy.
+//
+// Returned error is wrapping ErrHalted if the node must stop syncing.
+func (m *Manager) handleAppHashMismatch(ctx context.Context, header *types.SignedHeader, mismatch *state.AppHashMismatchError) error {
+	report := m.newDivergenceReport(ctx, mismatch)
+	var rollbackErr error
+	if m.conf.AppHashMismatchPolicy == config.AppHashMismatchRollback {
+		report.RolledBackTo, rollbackErr = m.rollbackDivergedBlock(ctx, report.LastExecutedHeight)
+	}
+	m.logger.Error("app hash mismatch detected",
+		"height", report.Height,
+		"lastExecutedHeight", report.LastExecutedHeight,
+		"expectedAppHash", report.ExpectedAppHash,
+		"localAppHash", report.LocalAppHash,
+		"numTxs", report.NumTxs,
+		"txIndex", report.TxIndex,
+		"policy", report.Policy,
+		"rolledBackTo", report.RolledBackTo,
+	)
+	m.raiseAlert(alert.AppHashMismatch, fmt.Sprintf("app hash mismatch at height %d, expected %s, local %s (policy %s)",
+		report.Height, report.ExpectedAppHash, report.LocalAppHash, report.Policy))
+	if blob, err := json.Marshal(report); err != nil {
+		m.logger.Error("failed to marshal divergence report", "error", err)
+	} else if err := m.store.SetMetadata(ctx, DivergenceReportKey, blob); err != nil {
+		m.logger.Error("failed to save divergence report", "error", err)
+	}
+
+	switch {
+	case m.conf.AppHashMismatchPolicy == config.AppHashMismatchHeadersOnly:
+		m.headersOnly.Store(true)
+		m.logger.Error("block execution stopped, following headers only", "height", header.Height())
+		return mismatch
+	case rollbackErr != nil:
+		return fmt.Errorf("%w: failed to roll back diverged block: %w: %w", ErrHalted, rollbackErr, mismatch)
+	case report.RolledBackTo != 0:
+		return fmt.Errorf("%w: store rolled back to height %d, roll back the app to the same height and restart "+
+			"to re-fetch and re-execute block %d: %w", ErrHalted, report.RolledBackTo, report.LastExecutedHeight, mismatch)
+	}
+	return fmt.Errorf("%w: %w", ErrHalted, mismatch)
+}
+
+// rollbackDivergedBlock rolls the store back to the height before the block whose execution diverged, so that the
+// block is retrieved and executed again after the app is rolled back too, and returns the height the store was
+// rolled back to. DA retrieval is rewound to the DA height of the block, if its DA inclusion is known. Application
+// state can't be rolled back over ABCI, so the node is halted afterwards: the app has to be rolled back by the
+// operator, and until then the handshake refuses the app being ahead of the store. Zero is returned if the block
+// is the first one of the chain, as there is no state to roll back to.
+func (m *Manager) rollbackDivergedBlock(ctx context.Context, diverged uint64) (uint64, error) {
+	if diverged <= uint64(m.genesis.InitialHeight) { //nolint:gosec
+		return 0, nil
+	}
+	target := diverged - 1
+	cert, certErr := m.store.GetDAInclusionCertificate(ctx, diverged)
+	if err := m.store.Rollback(ctx, target); err != nil {
+		return 0, err
+	}
+	s, err := m.store.GetState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if certErr == nil && cert.DAHeight > 0 && cert.DAHeight <= s.DAHeight {
+		s.DAHeight = cert.DAHeight
+		if err := m.store.UpdateState(ctx, s); err != nil {
+			return 0, err
+		}
+		atomic.StoreUint64(&m.daHeight, cert.DAHeight)
+	}
+	m.SetLastState(s)
+	return target, nil
+}
+
+func (m *Manager) newDivergenceReport(ctx context.Context, mismatch *state.AppHashMismatchError) DivergenceReport {
+	report := DivergenceReport{
+		Height:          mismatch.Height,
+		ExpectedAppHash: mismatch.Expected,
+		LocalAppHash:    mismatch.Actual,
+		// blocks don't carry intermediate state roots, which would identify the diverging transaction
+		TxIndex:              -1,
+		TxIndexUnknownReason: txIndexUnknown,
+		Policy:               m.conf.AppHashMismatchPolicy,
+		Time:                 time.Now(),
+	}
+	if mismatch.Height > 0 {
+		report.LastExecutedHeight = mismatch.Height - 1
+	}
+	if _, data, err := m.store.GetBlockData(ctx, report.LastExecutedHeight); err == nil {
+		report.NumTxs = len(data.Txs)
+	}
+	return report
+}
@@ -0,0 +1,127 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	ds "github.com/ipfs/go-datastore"
+)
+
+// ManagerState is a snapshot of the internal state of Manager, used to debug stuck nodes.
+type ManagerState struct {
+	Proposer bool `json:"proposer"`
+	// Height is the height of the last block produced or synced.
+	Height uint64 `json:"height"`
+	// StateHeight and AppHash describe the last applied state.
+	StateHeight uint64           `json:"state_height"`
+	AppHash     cmbytes.HexBytes `json:"app_hash"`
+	// DAHeight is the height of the DA block retriever is processing.
+	DAHeight uint64 `json:"da_height"`
+	// DAIncludedHeight is the height up to which all blocks are included in DA.
+	DAIncludedHeight uint64 `json:"da_included_height"`
+
+	DASubmission DASubmissionState `json:"da_submission"`
+	BatchQueue   BatchQueueState   `json:"batch_queue"`
+	Sync         SyncState         `json:"sync"`
+
+	// HeadersOnly is set when block execution was stopped because of app hash mismatch.
+	HeadersOnly bool `json:"headers_only"`
+	// LoadShedding is set when node is under memory pressure.
+	LoadShedding bool `json:"load_shedding"`
+	Halted       bool `json:"halted"`
+}
+
+// DASubmissionState describes headers waiting for submission to DA.
+type DASubmissionState struct {
+	LastSubmittedHeight uint64 `json:"last_submitted_height"`
+	PendingHeaders      uint64 `json:"pending_headers"`
+}
+
+// BatchQueueState describes batches retrieved from the sequencer, but not included in blocks yet.
+type BatchQueueState struct {
+	Batches int `json:"batches"`
+	Txs     int `json:"txs"`
+	// Next is the batch that will be included in the next block, nil if the queue is empty.
+	Next *QueuedBatch `json:"next"`
+	// LastBatchHash is the hash of the last non-empty batch retrieved from the sequencer.
+	LastBatchHash cmbytes.HexBytes `json:"last_batch_hash"`
+}
+
+// QueuedBatch describes a batch in the batch queue.
+type QueuedBatch struct {
+	Hash cmbytes.HexBytes `json:"hash"`
+	Txs  int              `json:"txs"`
+	Time time.Time        `json:"time"`
+}
+
+// SyncState describes blocks received from peers and DA, but not synced yet.
+type SyncState struct {
+	// HeaderStoreHeight and DataStoreHeight are heights of headers and data received from peers, which are
+	// the targets of syncing.
+	HeaderStoreHeight uint64 `json:"header_store_height"`
+	DataStoreHeight   uint64 `json:"data_store_height"`
+	// HeaderInQueue and DataInQueue are numbers of received headers and data waiting to be processed.
+	HeaderInQueue int `json:"header_in_queue"`
+	DataInQueue   int `json:"data_in_queue"`
+}
+
+// DumpState returns a snapshot of the internal state of the manager. It's safe to call concurrently with
+// the loops of the manager.
+func (m *Manager) DumpState(ctx context.Context) (*ManagerState, error) {
+	m.lastStateMtx.RLock()
+	lastState := m.lastState
+	m.lastStateMtx.RUnlock()
+
+	s := &ManagerState{
+		Proposer:         m.isProposer,
+		Height:           m.store.Height(),
+		StateHeight:      lastState.LastBlockHeight,
+		AppHash:          cmbytes.HexBytes(lastState.AppHash),
+		DAHeight:         atomic.LoadUint64(&m.daHeight),
+		DAIncludedHeight: m.GetDAIncludedHeight(),
+		HeadersOnly:      m.headersOnly.Load(),
+		LoadShedding:     m.loadShedding.Load(),
+		Halted:           m.HaltStatus().Marker != nil,
+	}
+	if m.pendingHeaders != nil {
+		s.DASubmission = DASubmissionState{
+			LastSubmittedHeight: m.pendingHeaders.lastSubmittedHeight.Load(),
+			PendingHeaders:      m.pendingHeaders.numPendingHeaders(),
+		}
+	}
+
+	if m.bq != nil {
+		batches := m.bq.Snapshot()
+		s.BatchQueue.Batches = len(batches)
+		for _, batch := range batches {
+			s.BatchQueue.Txs += len(batch.Transactions)
+		}
+		if len(batches) > 0 {
+			next := batches[0]
+			hash, err := next.Hash()
+			if err != nil {
+				return nil, err
+			}
+			s.BatchQueue.Next = &QueuedBatch{Hash: hash, Txs: len(next.Transactions), Time: next.Time}
+		}
+	}
+	// last batch hash is read from the store, as it's updated by BatchRetrieveLoop without synchronization
+	lastBatchHash, err := m.store.GetMetadata(ctx, LastBatchHashKey)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	s.BatchQueue.LastBatchHash = lastBatchHash
+
+	if m.headerStore != nil {
+		s.Sync.HeaderStoreHeight = m.headerStore.Height()
+	}
+	if m.dataStore != nil {
+		s.Sync.DataStoreHeight = m.dataStore.Height()
+	}
+	s.Sync.HeaderInQueue = len(m.headerInCh)
+	s.Sync.DataInQueue = len(m.dataInCh)
+	return s, nil
+}
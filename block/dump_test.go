@@ -0,0 +1,46 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestDumpState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m := &Manager{
+		store:        store.New(kv),
+		bq:           NewBatchQueue(),
+		lastStateMtx: new(sync.RWMutex),
+		isProposer:   true,
+		logger:       test.NewLogger(t),
+	}
+	m.store.SetHeight(ctx, 5)
+	m.bq.AddBatch(batch1)
+	m.bq.AddBatch(batch2)
+	require.NoError(m.store.SetMetadata(ctx, LastBatchHashKey, []byte{1, 2, 3}))
+
+	s, err := m.DumpState(ctx)
+	require.NoError(err)
+	assert.True(t, s.Proposer)
+	assert.Equal(t, uint64(5), s.Height)
+	assert.Equal(t, 2, s.BatchQueue.Batches)
+	assert.Equal(t, 2, s.BatchQueue.Txs)
+	require.NotNil(s.BatchQueue.Next)
+	hash, err := batch1.Hash()
+	require.NoError(err)
+	assert.EqualValues(t, hash, s.BatchQueue.Next.Hash)
+	assert.EqualValues(t, []byte{1, 2, 3}, s.BatchQueue.LastBatchHash)
+	// dumping state doesn't consume batches
+	assert.Equal(t, batch1.Transactions, m.bq.Peek().Transactions)
+}
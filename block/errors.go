@@ -12,6 +12,12 @@ var (
 
 	// ErrNotProposer is used when the manager is not a proposer
 	ErrNotProposer = errors.New("not a proposer")
+
+	// ErrWrongChain is used when gossiped header or block data belongs to another chain
+	ErrWrongChain = errors.New("message of another chain")
+
+	// ErrMessagingDisabled is used when cross-rollup messaging is not configured
+	ErrMessagingDisabled = errors.New("cross-rollup messaging is not configured")
 )
 
 // SaveBlockError is returned on failure to save block data
@@ -0,0 +1,121 @@
+package block
+
+import (
+	"sync"
+	"time"
+)
+
+// Units of soft-confirmation finality latency, used as "unit" label of finality metrics.
+const (
+	// FinalityBlock is latency of a block.
+	FinalityBlock = "block"
+	// FinalityTx is latency of a transaction, i.e. latency of its block weighted by the number of transactions.
+	FinalityTx = "tx"
+)
+
+// maxSoftConfirmed limits the number of soft-confirmed blocks waiting for DA inclusion tracked by finalityTracker.
+// Older blocks are not tracked, e.g. while DA submission is down for a long time.
+const maxSoftConfirmed = 100000
+
+// FinalityStats contains aggregated latency between soft confirmation of blocks and their inclusion in DA.
+type FinalityStats struct {
+	// Latencies by unit, see Finality* constants.
+	Latencies map[string]StageStats
+	// Pending is the number of soft-confirmed blocks waiting for DA inclusion.
+	Pending int
+	// OldestPending is the soft confirmation time of the oldest block waiting for DA inclusion, zero if there is none.
+	OldestPending time.Time
+	// SLA is the configured maximal latency, 0 if not set.
+	SLA time.Duration
+	// SLAViolations is the number of blocks included in DA later than SLA after soft confirmation.
+	SLAViolations uint64
+}
+
+type softConfirmation struct {
+	time time.Time
+	txs  int
+}
+
+// finalityTracker measures latency between soft confirmation of blocks (when they are produced or applied by the
+// node and served to users) and their inclusion in DA, which makes them final. Nil tracker doesn't track anything.
+type finalityTracker struct {
+	mtx        sync.Mutex
+	pending    map[uint64]softConfirmation
+	violations uint64
+
+	sla       time.Duration
+	latencies *performanceTracker
+	metrics   *Metrics
+}
+
+func newFinalityTracker(sla time.Duration, metrics *Metrics) *finalityTracker {
+	return &finalityTracker{
+		pending:   make(map[uint64]softConfirmation),
+		sla:       sla,
+		latencies: newLatencyTracker(metrics.FinalitySeconds, "unit"),
+		metrics:   metrics,
+	}
+}
+
+// softConfirmed records soft confirmation of the block at height with given number of transactions.
+func (f *finalityTracker) softConfirmed(height uint64, txs int, t time.Time) {
+	if f == nil {
+		return
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if len(f.pending) >= maxSoftConfirmed {
+		return
+	}
+	f.pending[height] = softConfirmation{time: t, txs: txs}
+}
+
+// daIncluded records inclusion of the block at height in DA. Blocks included in DA before they were soft-confirmed
+// (e.g. synced from DA) are ignored.
+func (f *finalityTracker) daIncluded(height uint64, t time.Time) {
+	if f == nil {
+		return
+	}
+	f.mtx.Lock()
+	sc, ok := f.pending[height]
+	delete(f.pending, height)
+	violated := ok && f.sla > 0 && t.Sub(sc.time) > f.sla
+	if violated {
+		f.violations++
+	}
+	f.mtx.Unlock()
+	if !ok {
+		return
+	}
+	latency := t.Sub(sc.time)
+	f.latencies.observe(FinalityBlock, latency)
+	for range sc.txs {
+		f.latencies.observe(FinalityTx, latency)
+	}
+	if violated {
+		f.metrics.FinalitySLAViolations.Add(1)
+	}
+}
+
+// stats returns aggregated finality latencies.
+func (f *finalityTracker) stats() FinalityStats {
+	if f == nil {
+		return FinalityStats{}
+	}
+	f.mtx.Lock()
+	stats := FinalityStats{Pending: len(f.pending), SLA: f.sla, SLAViolations: f.violations}
+	for _, sc := range f.pending {
+		if stats.OldestPending.IsZero() || sc.time.Before(stats.OldestPending) {
+			stats.OldestPending = sc.time
+		}
+	}
+	f.mtx.Unlock()
+	stats.Latencies = f.latencies.stats()
+	return stats
+}
+
+// FinalityStats returns aggregated latencies between soft confirmation of the latest blocks and their inclusion
+// in DA, so that chains advertising fast finality can monitor it.
+func (m *Manager) FinalityStats() FinalityStats {
+	return m.finality.stats()
+}
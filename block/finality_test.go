@@ -0,0 +1,41 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalityTracker(t *testing.T) {
+	f := newFinalityTracker(5*time.Second, NopMetrics())
+	start := time.Now()
+	f.softConfirmed(1, 3, start)
+	f.softConfirmed(2, 1, start.Add(time.Second))
+	f.softConfirmed(3, 0, start.Add(2*time.Second))
+
+	stats := f.stats()
+	assert.Equal(t, 3, stats.Pending)
+	assert.Equal(t, start, stats.OldestPending)
+
+	f.daIncluded(1, start.Add(2*time.Second))
+	f.daIncluded(2, start.Add(10*time.Second))
+	// blocks included in DA before soft confirmation are not tracked
+	f.daIncluded(4, start.Add(10*time.Second))
+
+	stats = f.stats()
+	assert.Equal(t, 1, stats.Pending)
+	assert.EqualValues(t, 1, stats.SLAViolations)
+	require.Contains(t, stats.Latencies, FinalityBlock)
+	assert.EqualValues(t, 2, stats.Latencies[FinalityBlock].Count)
+	assert.Equal(t, 9*time.Second, stats.Latencies[FinalityBlock].Max)
+	// transactions are weighted by blocks
+	assert.EqualValues(t, 4, stats.Latencies[FinalityTx].Count)
+	assert.Equal(t, 2*time.Second, stats.Latencies[FinalityTx].P50)
+
+	var nilTracker *finalityTracker
+	nilTracker.softConfirmed(1, 1, start)
+	nilTracker.daIncluded(1, start)
+	assert.Zero(t, nilTracker.stats().Pending)
+}
@@ -0,0 +1,106 @@
+package block
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// governorSlowDownFactor is the multiplier applied to block time when DA is congested.
+	governorSlowDownFactor = 1.5
+	// governorSpeedUpFactor is the multiplier applied to block time when DA keeps up with block production.
+	governorSpeedUpFactor = 0.9
+	// governorSmoothing is the weight of the latest observation in moving averages.
+	governorSmoothing = 0.2
+)
+
+// blockTimeGovernor adapts block time to DA throughput.
+//
+// Block time is increased (within configured bounds) when DA submissions fail, take longer than
+// DA block time, or when the number of headers pending DA submission grows. Otherwise block time
+// is gradually decreased back to the configured minimum.
+type blockTimeGovernor struct {
+	mtx sync.Mutex
+
+	minBlockTime time.Duration
+	maxBlockTime time.Duration
+	daBlockTime  time.Duration
+
+	blockTime   time.Duration
+	lastPending uint64
+	lastObserve time.Time
+
+	// moving averages of DA submission latency and throughput (in headers per second)
+	avgLatency    time.Duration
+	avgThroughput float64
+}
+
+func newBlockTimeGovernor(minBlockTime, maxBlockTime, daBlockTime time.Duration) *blockTimeGovernor {
+	return &blockTimeGovernor{
+		minBlockTime: minBlockTime,
+		maxBlockTime: maxBlockTime,
+		daBlockTime:  daBlockTime,
+		blockTime:    minBlockTime,
+	}
+}
+
+// getBlockTime returns current block time.
+func (g *blockTimeGovernor) getBlockTime() time.Duration {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return g.blockTime
+}
+
+// daStats returns moving averages of DA submission latency and throughput (in headers per second).
+func (g *blockTimeGovernor) daStats() (time.Duration, float64) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return g.avgLatency, g.avgThroughput
+}
+
+// observe records the result of DA submission and adjusts block time.
+//
+// submitted is the number of headers submitted, latency is the duration of submission and
+// pending is the number of headers still pending DA submission.
+func (g *blockTimeGovernor) observe(now time.Time, success bool, submitted uint64, latency time.Duration, pending uint64) time.Duration {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.avgLatency = time.Duration(governorSmoothing*float64(latency) + (1-governorSmoothing)*float64(g.avgLatency))
+	if !g.lastObserve.IsZero() {
+		if elapsed := now.Sub(g.lastObserve).Seconds(); elapsed > 0 {
+			throughput := float64(submitted) / elapsed
+			g.avgThroughput = governorSmoothing*throughput + (1-governorSmoothing)*g.avgThroughput
+		}
+	}
+	g.lastObserve = now
+
+	congested := !success || pending > g.lastPending || g.avgLatency > g.daBlockTime
+	g.lastPending = pending
+
+	if congested {
+		g.setBlockTime(time.Duration(float64(g.blockTime) * governorSlowDownFactor))
+	} else {
+		g.setBlockTime(time.Duration(float64(g.blockTime) * governorSpeedUpFactor))
+	}
+	return g.blockTime
+}
+
+// relax decreases block time when there is nothing pending DA submission.
+func (g *blockTimeGovernor) relax() time.Duration {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.lastPending = 0
+	g.setBlockTime(time.Duration(float64(g.blockTime) * governorSpeedUpFactor))
+	return g.blockTime
+}
+
+func (g *blockTimeGovernor) setBlockTime(blockTime time.Duration) {
+	if blockTime < g.minBlockTime {
+		blockTime = g.minBlockTime
+	}
+	if blockTime > g.maxBlockTime {
+		blockTime = g.maxBlockTime
+	}
+	g.blockTime = blockTime
+}
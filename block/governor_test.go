@@ -0,0 +1,46 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockTimeGovernor(t *testing.T) {
+	assert := assert.New(t)
+
+	g := newBlockTimeGovernor(time.Second, 4*time.Second, 10*time.Second)
+	assert.Equal(time.Second, g.getBlockTime())
+
+	now := time.Now()
+	// successful submission, nothing pending - block time can't go below min
+	assert.Equal(time.Second, g.observe(now, true, 5, time.Second, 0))
+
+	// failed submission - slow down
+	now = now.Add(10 * time.Second)
+	assert.Equal(1500*time.Millisecond, g.observe(now, false, 0, time.Second, 5))
+
+	// growing backlog - slow down
+	now = now.Add(10 * time.Second)
+	assert.Equal(2250*time.Millisecond, g.observe(now, true, 2, time.Second, 8))
+
+	// slow down, but not above max
+	now = now.Add(10 * time.Second)
+	assert.Equal(3375*time.Millisecond, g.observe(now, false, 0, time.Second, 8))
+	now = now.Add(10 * time.Second)
+	assert.Equal(4*time.Second, g.observe(now, false, 0, time.Second, 8))
+
+	latency, throughput := g.daStats()
+	assert.Greater(latency, time.Duration(0))
+	assert.Greater(throughput, 0.0)
+
+	// backlog is shrinking - speed up
+	now = now.Add(10 * time.Second)
+	assert.Equal(3600*time.Millisecond, g.observe(now, true, 8, time.Second, 0))
+	assert.Equal(3240*time.Millisecond, g.relax())
+
+	// high latency - slow down
+	g = newBlockTimeGovernor(time.Second, 4*time.Second, time.Second)
+	assert.Equal(1500*time.Millisecond, g.observe(time.Now(), true, 1, 10*time.Second, 0))
+}
@@ -0,0 +1,138 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/alert"
+)
+
+// HaltMarkerKey is the key used for persisting the halt marker in store.
+const HaltMarkerKey = "halt"
+
+// HaltMarker is persisted when the chain is halted at configured halt height or time.
+type HaltMarker struct {
+	// Height is the height of the last block produced or applied before the halt.
+	Height uint64 `json:"height"`
+	// Time is the time of the block that wasn't produced or applied because of the halt.
+	Time time.Time `json:"time"`
+	// Reason describes the halt condition that was met.
+	Reason string `json:"reason"`
+}
+
+// HaltStatus describes scheduled and active chain halt.
+type HaltStatus struct {
+	// HaltHeight is 0 if halt height is not set.
+	HaltHeight uint64
+	// HaltTime is zero if halt time is not set.
+	HaltTime time.Time
+	// Marker is nil if the chain is not halted.
+	Marker *HaltMarker
+}
+
+// initHalt sets halt height and time from configuration and loads halt marker from store.
+//
+// If node is resumed, the marker is cleared and halt height and time already reached are ignored,
+// so that configuration doesn't have to be changed to resume the chain.
+func (m *Manager) initHalt(ctx context.Context) error {
+	m.haltHeight = m.conf.HaltHeight
+	if m.conf.HaltTime != 0 {
+		m.haltTime = time.Unix(int64(m.conf.HaltTime), 0) //nolint:gosec
+	}
+
+	blob, err := m.store.GetMetadata(ctx, HaltMarkerKey)
+	if errors.Is(err, ds.ErrNotFound) || (err == nil && len(blob) == 0) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load halt marker: %w", err)
+	}
+	marker := new(HaltMarker)
+	if err := json.Unmarshal(blob, marker); err != nil {
+		return fmt.Errorf("failed to unmarshal halt marker: %w", err)
+	}
+
+	if !m.conf.Resume {
+		m.haltMarker = marker
+		m.logger.Error("chain is halted, restart node with --rollkit.resume to resume it", "height", marker.Height, "reason", marker.Reason)
+		return nil
+	}
+	if m.haltHeight != 0 && m.haltHeight <= marker.Height {
+		m.haltHeight = 0
+	}
+	if !m.haltTime.IsZero() && !m.haltTime.After(marker.Time) {
+		m.haltTime = time.Time{}
+	}
+	if err := m.store.SetMetadata(ctx, HaltMarkerKey, nil); err != nil {
+		return fmt.Errorf("failed to clear halt marker: %w", err)
+	}
+	m.logger.Info("resuming halted chain", "height", marker.Height, "reason", marker.Reason)
+	return nil
+}
+
+// halted returns true if block at given height and time must be neither produced nor applied.
+// When halt height or time is reached, halt marker is persisted and the chain stays halted until resumed.
+func (m *Manager) halted(ctx context.Context, height uint64, blockTime time.Time) bool {
+	m.haltMtx.Lock()
+	defer m.haltMtx.Unlock()
+	if m.haltMarker != nil {
+		return true
+	}
+
+	var reason string
+	switch {
+	case m.haltHeight != 0 && height > m.haltHeight:
+		reason = fmt.Sprintf("reached halt height %d", m.haltHeight)
+	case !m.haltTime.IsZero() && !blockTime.Before(m.haltTime):
+		reason = fmt.Sprintf("reached halt time %s", m.haltTime.UTC().Format(time.RFC3339))
+	default:
+		return false
+	}
+
+	m.haltMarker = &HaltMarker{
+		Height: height - 1,
+		Time:   blockTime,
+		Reason: reason,
+	}
+	// chain is halted even if marker can't be persisted, but it won't stay halted after restart
+	blob, err := json.Marshal(m.haltMarker)
+	if err == nil {
+		err = m.store.SetMetadata(ctx, HaltMarkerKey, blob)
+	}
+	if err != nil {
+		m.logger.Error("failed to persist halt marker", "error", err)
+	}
+	m.logger.Info("chain halted", "height", m.haltMarker.Height, "reason", reason)
+	m.raiseAlert(alert.Halt, fmt.Sprintf("chain halted at height %d: %s", m.haltMarker.Height, reason))
+	return true
+}
+
+// ScheduleHalt overrides halt height and time. Zero values disable respective halt condition.
+// It doesn't resume the chain that is already halted.
+func (m *Manager) ScheduleHalt(height uint64, haltTime time.Time) error {
+	if current := m.store.Height(); height != 0 && height < current {
+		return fmt.Errorf("halt height %d is lower than current height %d", height, current)
+	}
+	m.haltMtx.Lock()
+	defer m.haltMtx.Unlock()
+	m.haltHeight = height
+	m.haltTime = haltTime
+	m.logger.Info("chain halt scheduled", "height", height, "time", haltTime)
+	return nil
+}
+
+// HaltStatus returns scheduled halt height and time, and the halt marker if the chain is halted.
+func (m *Manager) HaltStatus() HaltStatus {
+	m.haltMtx.Lock()
+	defer m.haltMtx.Unlock()
+	return HaltStatus{
+		HaltHeight: m.haltHeight,
+		HaltTime:   m.haltTime,
+		Marker:     m.haltMarker,
+	}
+}
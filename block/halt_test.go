@@ -0,0 +1,64 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestHalt(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := store.New(kv)
+	haltTime := time.Unix(1700000000, 0)
+	newManager := func(resume bool) *Manager {
+		m := &Manager{
+			conf:   config.BlockManagerConfig{HaltHeight: 5, HaltTime: uint64(haltTime.Unix()), Resume: resume},
+			store:  s,
+			logger: test.NewLogger(t),
+		}
+		require.NoError(m.initHalt(ctx))
+		return m
+	}
+
+	m := newManager(false)
+	require.Equal(HaltStatus{HaltHeight: 5, HaltTime: haltTime}, m.HaltStatus())
+	require.False(m.halted(ctx, 5, haltTime.Add(-time.Second)))
+	require.True(m.halted(ctx, 6, haltTime.Add(-time.Second)))
+	// chain stays halted
+	require.True(m.halted(ctx, 5, haltTime.Add(-time.Second)))
+	marker := m.HaltStatus().Marker
+	require.NotNil(marker)
+	require.Equal(uint64(5), marker.Height)
+
+	// marker is persisted
+	m = newManager(false)
+	require.Equal(marker.Height, m.HaltStatus().Marker.Height)
+	require.True(m.halted(ctx, 6, haltTime.Add(-time.Second)))
+
+	// halt height is ignored after resuming, halt time is not reached yet
+	m = newManager(true)
+	require.Equal(HaltStatus{HaltTime: haltTime}, m.HaltStatus())
+	require.False(m.halted(ctx, 6, haltTime.Add(-time.Second)))
+	require.True(m.halted(ctx, 7, haltTime))
+	require.Contains(m.HaltStatus().Marker.Reason, "halt time")
+
+	m = newManager(true)
+	require.Equal(HaltStatus{}, m.HaltStatus())
+	require.False(m.halted(ctx, 7, haltTime))
+
+	// halt can be scheduled at runtime, but not below current height
+	s.SetHeight(ctx, 10)
+	require.Error(m.ScheduleHalt(9, time.Time{}))
+	require.NoError(m.ScheduleHalt(10, time.Time{}))
+	require.True(m.halted(ctx, 11, haltTime))
+}
@@ -0,0 +1,111 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cometbft/cometbft/proxy"
+
+	"github.com/rollkit/rollkit/state"
+	"github.com/rollkit/rollkit/types"
+)
+
+// Handshake brings ABCI application in sync with the node after (re)connecting to it, e.g. after application
+// process was restarted. Application restarted with empty state is initialized with genesis, and blocks it's
+// missing are replayed from the store. If the node stopped after the app committed a block, but before the state
+// was saved, the state is re-derived from the saved block responses, see recoverState. Given connections are used
+// directly, as connections used by the manager are not available until handshake is completed.
+func (m *Manager) Handshake(ctx context.Context, consensus proxy.AppConnConsensus, query proxy.AppConnQuery) error {
+	info, err := query.Info(ctx, proxy.RequestInfo)
+	if err != nil {
+		return fmt.Errorf("error calling Info: %w", err)
+	}
+	if info.LastBlockHeight < 0 {
+		return fmt.Errorf("got a negative last block height (%d) from the app", info.LastBlockHeight)
+	}
+	appHeight := uint64(info.LastBlockHeight)
+	m.lastStateMtx.RLock()
+	s := m.lastState
+	m.lastStateMtx.RUnlock()
+	if appHeight == s.LastBlockHeight+1 {
+		// with pipelined execution, the node may stop after the block was committed in the app, before the new state
+		// was saved
+		if s, err = m.recoverState(ctx, s, appHeight); err != nil {
+			return err
+		}
+	}
+	if appHeight > s.LastBlockHeight {
+		return fmt.Errorf("app block height (%d) is higher than node height (%d)", appHeight, s.LastBlockHeight)
+	}
+	m.logger.Info("ABCI handshake", "appHeight", appHeight, "appHash", fmt.Sprintf("%X", info.LastBlockAppHash), "height", s.LastBlockHeight)
+
+	if appHeight > 0 && appHeight == s.LastBlockHeight {
+		if !bytes.Equal(info.LastBlockAppHash, s.AppHash) {
+			return &state.AppHashMismatchError{Height: appHeight, Expected: s.AppHash, Actual: info.LastBlockAppHash}
+		}
+		return nil
+	}
+
+	if appHeight == 0 {
+		if _, err := state.InitChain(ctx, consensus, m.genesis); err != nil {
+			return fmt.Errorf("error calling InitChain: %w", err)
+		}
+		appHeight = uint64(m.genesis.InitialHeight) - 1 //nolint:gosec
+	}
+
+	if appHeight >= s.LastBlockHeight {
+		return nil
+	}
+	blocks, err := m.store.BlockIterator(ctx, appHeight+1, s.LastBlockHeight)
+	if err != nil {
+		return fmt.Errorf("failed to load blocks for replay: %w", err)
+	}
+	defer blocks.Close()
+
+	var appHash []byte
+	for blocks.Next() {
+		header, data := blocks.Header(), blocks.Data()
+		height := header.Height()
+		// app hash after previous block is recorded in the header
+		if appHash != nil && !bytes.Equal(appHash, header.AppHash) {
+			return &state.AppHashMismatchError{Height: height, Expected: header.AppHash, Actual: appHash}
+		}
+		if appHash, err = state.ReplayBlock(ctx, consensus, s, header, data); err != nil {
+			return fmt.Errorf("failed to replay block %d: %w", height, err)
+		}
+	}
+	if err := blocks.Err(); err != nil {
+		return fmt.Errorf("failed to load blocks for replay: %w", err)
+	}
+	if !bytes.Equal(appHash, s.AppHash) {
+		return &state.AppHashMismatchError{Height: s.LastBlockHeight, Expected: s.AppHash, Actual: appHash}
+	}
+	m.logger.Info("replayed blocks to ABCI app", "from", appHeight+1, "to", s.LastBlockHeight)
+	return nil
+}
+
+// recoverState re-derives the state after the block at given height from the block and its responses, which are
+// saved before the block is committed in the app, see publishBlock. State is returned unchanged if the block or
+// its responses are not in the store.
+func (m *Manager) recoverState(ctx context.Context, s types.State, height uint64) (types.State, error) {
+	header, data, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return s, nil
+	}
+	responses, err := m.store.GetBlockResponses(ctx, height)
+	if err != nil {
+		return s, nil
+	}
+	newState, err := m.executor.NextState(s, header, data, responses)
+	if err != nil {
+		return s, fmt.Errorf("failed to recover state after block %d: %w", height, err)
+	}
+	newState.DAHeight = s.DAHeight
+	if err := m.updateState(ctx, newState); err != nil {
+		return s, fmt.Errorf("failed to save recovered state after block %d: %w", height, err)
+	}
+	m.store.SetHeight(ctx, height)
+	m.logger.Info("recovered state after block committed in the app", "height", height)
+	return newState, nil
+}
@@ -61,3 +61,8 @@ func (hc *HeaderCache) isDAIncluded(hash string) bool {
 func (hc *HeaderCache) setDAIncluded(hash string) {
 	hc.daIncluded.Store(hash, true)
 }
+
+// clearSeen forgets hashes of seen blocks. Blocks at synced heights are still recognized by their height.
+func (hc *HeaderCache) clearSeen() {
+	hc.hashes.Clear()
+}
@@ -0,0 +1,126 @@
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+
+	"github.com/celestiaorg/go-header"
+)
+
+const (
+	// headerPruneInterval is how often light nodes prune headers below the retention window.
+	headerPruneInterval = time.Minute
+
+	// maxHeadersPrunedPerRun limits the number of heights pruned in a single batch, so that catching up with
+	// a long history doesn't block the store.
+	maxHeadersPrunedPerRun = 10000
+)
+
+// prunedHeightKey is the key of the highest pruned height, stored next to headers in the sync store.
+var prunedHeightKey = ds.NewKey("pruned height")
+
+// pruningEnabled returns true if headers below the retention window are pruned. Only light nodes prune
+// headers; full nodes serve the full history of headers to peers.
+func (syncService *SyncService[H]) pruningEnabled() bool {
+	return syncService.conf.Light && syncService.syncType == headerSync && syncService.conf.HeaderRetentionBlocks > 0
+}
+
+// pruneLoop periodically prunes headers below the retention window until context is cancelled.
+func (syncService *SyncService[H]) pruneLoop(ctx context.Context) {
+	ticker := time.NewTicker(headerPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := syncService.pruneHeaders(ctx); err != nil && ctx.Err() == nil {
+				syncService.logger.Error("failed to prune headers", "error", err)
+			}
+		}
+	}
+}
+
+// pruneHeaders removes headers below the retention window, except checkpoints. New headers are verified
+// against the head of the store only, so pruned history is not needed for syncing.
+//
+// go-header store doesn't support deletions, so height index entries and headers are removed directly from
+// the datastore. Removed headers might still be served from store caches until evicted.
+func (syncService *SyncService[H]) pruneHeaders(ctx context.Context) error {
+	if !syncService.isInitialized() {
+		return nil
+	}
+	// headers are flushed to the datastore in batches; headers which might be still pending are not pruned
+	retention := max(syncService.conf.HeaderRetentionBlocks, uint64(syncService.store.Params.WriteBatchSize)) //nolint:gosec
+	head := syncService.store.Height()
+	if head <= retention {
+		return nil
+	}
+	from, to := syncService.earliest.Load(), head-retention
+	if from > to {
+		return nil
+	}
+	to = min(to, from+maxHeadersPrunedPerRun-1)
+
+	batch, err := syncService.prunerDS.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+	interval := syncService.conf.HeaderCheckpointInterval
+	for height := from; height <= to; height++ {
+		if interval > 0 && height%interval == 0 {
+			continue
+		}
+		h, err := syncService.store.GetByHeight(ctx, height)
+		if err != nil && !errors.Is(err, header.ErrNotFound) {
+			return fmt.Errorf("failed to get header at height %d: %w", height, err)
+		}
+		if err == nil {
+			if err := batch.Delete(ctx, ds.NewKey(h.Hash().String())); err != nil {
+				return err
+			}
+		}
+		if err := batch.Delete(ctx, ds.NewKey(strconv.FormatUint(height, 10))); err != nil {
+			return err
+		}
+	}
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, to)
+	if err := batch.Put(ctx, prunedHeightKey, value); err != nil {
+		return err
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	syncService.earliest.Store(to + 1)
+	syncService.logger.Debug("pruned headers", "from", from, "to", to)
+	return nil
+}
+
+// loadPrunedHeight returns the highest pruned height, or 0 if headers were never pruned.
+func (syncService *SyncService[H]) loadPrunedHeight(ctx context.Context) (uint64, error) {
+	value, err := syncService.prunerDS.Get(ctx, prunedHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid pruned height length: %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// newPrunerDatastore returns the datastore with the same prefix as the go-header store, so that keys of
+// headers and height index can be removed directly.
+func newPrunerDatastore(store ds.Batching, syncType syncType) ds.Batching {
+	return namespace.Wrap(store, ds.NewKey(string(syncType)))
+}
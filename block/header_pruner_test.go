@@ -0,0 +1,77 @@
+package block
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/cometbft/cometbft/libs/log"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goheaderstore "github.com/celestiaorg/go-header/store"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestPruneHeaders(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	kv := dssync.MutexWrap(ds.NewMapDatastore())
+	store, err := goheaderstore.NewStore[*types.SignedHeader](kv,
+		goheaderstore.WithStorePrefix(string(headerSync)),
+		goheaderstore.WithWriteBatchSize(1),
+	)
+	require.NoError(err)
+	require.NoError(store.Start(ctx))
+
+	first, privKey, err := types.GetRandomSignedHeader("test")
+	require.NoError(err)
+	require.NoError(store.Init(ctx, first))
+	headers := []*types.SignedHeader{first}
+	for len(headers) < 30 {
+		next, err := types.GetRandomNextSignedHeader(headers[len(headers)-1], privKey, "test")
+		require.NoError(err)
+		require.NoError(store.Append(ctx, next))
+		headers = append(headers, next)
+	}
+	require.NoError(store.Stop(ctx))
+
+	conf := config.NodeConfig{Light: true}
+	conf.HeaderRetentionBlocks = 10
+	conf.HeaderCheckpointInterval = 5
+	syncService := &SyncService[*types.SignedHeader]{
+		conf:     conf,
+		store:    store,
+		syncType: headerSync,
+		prunerDS: newPrunerDatastore(kv, headerSync),
+		logger:   log.NewNopLogger(),
+	}
+	syncService.earliest.Store(first.Height())
+	require.True(syncService.pruningEnabled())
+
+	require.NoError(syncService.pruneHeaders(ctx))
+	head := store.Height()
+	pruned := head - conf.HeaderRetentionBlocks
+	assert.Equal(pruned+1, syncService.earliest.Load())
+	assert.Equal(pruned+1, syncService.findEarliest(ctx))
+
+	for _, h := range headers {
+		kept := h.Height() > pruned || h.Height()%conf.HeaderCheckpointInterval == 0
+		has, err := syncService.prunerDS.Has(ctx, ds.NewKey(strconv.FormatUint(h.Height(), 10)))
+		require.NoError(err)
+		assert.Equal(kept, has, "height index at %d", h.Height())
+		has, err = syncService.prunerDS.Has(ctx, ds.NewKey(h.Hash().String()))
+		require.NoError(err)
+		assert.Equal(kept, has, "header at %d", h.Height())
+	}
+
+	// nothing new to prune
+	require.NoError(syncService.pruneHeaders(ctx))
+	assert.Equal(pruned+1, syncService.earliest.Load())
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
@@ -26,8 +25,10 @@ import (
 
 	goheaderstore "github.com/celestiaorg/go-header/store"
 
+	goDA "github.com/rollkit/go-da"
 	"github.com/rollkit/go-sequencing"
 	"github.com/rollkit/go-sequencing/proxy/grpc"
+	"github.com/rollkit/rollkit/alert"
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
 	"github.com/rollkit/rollkit/mempool"
@@ -121,6 +122,12 @@ type Manager struct {
 	dalc *da.DAClient
 	// daHeight is the height of the latest processed DA block
 	daHeight uint64
+	// daCheckpointer persists DA retrieval progress, see DARetrievalHeightKey
+	daCheckpointer *daCheckpointer
+	// daPrefetcher retrieves following DA heights in parallel, it's nil if DA heights are retrieved serially
+	daPrefetcher *daPrefetcher
+	// daSubs are subscriptions of namespaces retrieved by RetrieveLoop, if DA retrieval is shared by a watcher
+	daSubs []*da.Subscription
 
 	HeaderCh chan *types.SignedHeader
 	DataCh   chan *types.Data
@@ -159,10 +166,83 @@ type Manager struct {
 	// daIncludedHeight is rollup height at which all blocks have been included
 	// in the DA
 	daIncludedHeight atomic.Uint64
+	// daIncludedChanged is closed whenever DA included height increases, see WaitForDAIncludedHeight
+	daIncludedMtx     sync.Mutex
+	daIncludedChanged chan struct{}
 	// grpc client for sequencing middleware
 	seqClient     *grpc.Client
 	lastBatchHash []byte
 	bq            *BatchQueue
+	// mempoolReaper submits transactions to the sequencer, batches returned by sequencer are verified against it
+	mempoolReaper *mempool.CListMempoolReaper
+	// appliedBatchHash is the hash of the last non-empty batch taken from bq, persisted once the block is saved
+	appliedBatchHash []byte
+	// resendFallbackHash is set while batches are re-requested after unclean shutdown, see RecoverBatches
+	resendFallbackHash []byte
+
+	// headersOnly is set when block execution is stopped because of app hash mismatch
+	headersOnly atomic.Bool
+
+	// governor adapts block time to DA throughput, nil if block time is fixed
+	governor *blockTimeGovernor
+	// perf tracks latency of block production stages
+	perf *performanceTracker
+	// finality measures latency between soft confirmation of blocks and their inclusion in DA
+	finality *finalityTracker
+	// finalizeCh passes committed blocks to the finalizer if execution is pipelined, see startFinalizer
+	finalizeCh chan *committedBlock
+	// finalizeErr is set when the finalizer fails to save the state, which halts block production
+	finalizeErr atomic.Pointer[error]
+
+	// haltMtx protects halt height, time and marker, which can be changed via admin RPC
+	haltMtx    sync.Mutex
+	haltHeight uint64
+	haltTime   time.Time
+	// haltMarker is nil unless the chain is halted
+	haltMarker *HaltMarker
+
+	// daTime is the time (unix nanoseconds) of the latest DA block retrieved, used as block time source
+	daTime atomic.Int64
+
+	// lastSequencerDAHeight is the DA height of the latest sequencer header retrieved from DA
+	lastSequencerDAHeight atomic.Uint64
+	// daOnly is set when sequencer is considered down and blocks are derived from DA only
+	daOnly     atomic.Bool
+	forcedInCh chan forcedInclusionEvent
+
+	// daVerification is the result of the latest re-verification of historical blocks in DA
+	daVerification    DAVerification
+	daVerificationMtx sync.Mutex
+
+	// syncPaused is set when syncing is paused because of MaxPendingHeaders limit or safe mode (accessed only by SyncLoop)
+	syncPaused bool
+
+	// safeModeCheck returns error if blocks must not be produced nor applied, e.g. because of low disk space
+	safeModeCheck func() error
+	// alertHandler is notified about critical events, it's nil if alerts are disabled
+	alertHandler alert.Handler
+	// daSubmitFailures is the number of consecutive failed DA submission attempts
+	daSubmitFailures uint64
+	// daBalance queries balance of the DA account, it's nil if balance is not monitored
+	daBalance da.BalanceFunc
+	// daThrottled is set while DA account balance is below throttling threshold, see skipDASubmission
+	daThrottled atomic.Bool
+	// daSkippedSubmissions is the number of DA submissions skipped in a row because of throttling
+	daSkippedSubmissions uint64
+
+	// loadShedding is set when node is under memory pressure; DA is not queried ahead of DA block time
+	loadShedding atomic.Bool
+
+	// appRetainHeight is the highest retain height reported by the app, see AppRetainHeightKey
+	appRetainHeight atomic.Uint64
+
+	// trustedBootstrap is set when fresh node must be initialized from the trusted header before syncing
+	trustedBootstrap bool
+	// backfillSource fetches blocks below the trusted height, see BackfillLoop
+	backfillSource BackfillSource
+
+	// inbox holds verified cross-rollup messages waiting to be included in blocks, nil if messaging is disabled
+	inbox *messageInbox
 }
 
 // getInitialState tries to load lastState from Store, and if it's not available it reads GenesisDoc.
@@ -239,6 +319,30 @@ func NewManager(
 		conf.DAMempoolTTL = defaultMempoolTTL
 	}
 
+	if conf.AppHashMismatchPolicy == "" {
+		logger.Info("Using default app hash mismatch policy", "AppHashMismatchPolicy", config.AppHashMismatchHalt)
+		conf.AppHashMismatchPolicy = config.AppHashMismatchHalt
+	}
+	if err := validateAppHashMismatchPolicy(conf.AppHashMismatchPolicy); err != nil {
+		return nil, err
+	}
+
+	if conf.BlockTimeSource == "" {
+		logger.Info("Using default block time source", "BlockTimeSource", config.TimeSourceSequencer)
+		conf.BlockTimeSource = config.TimeSourceSequencer
+	}
+	if err := validateTimeSource(conf.BlockTimeSource); err != nil {
+		return nil, err
+	}
+
+	if conf.SequencerDowntimeThreshold != 0 && len(dalc.ForcedInclusionNamespace) == 0 {
+		return nil, errors.New("sequencer downtime threshold requires forced inclusion namespace")
+	}
+
+	if conf.MaxBlockTime != 0 && conf.MaxBlockTime < conf.BlockTime {
+		return nil, fmt.Errorf("max block time (%s) must not be lower than block time (%s)", conf.MaxBlockTime, conf.BlockTime)
+	}
+
 	proposerAddress := s.Validators.Proposer.Address.Bytes()
 
 	maxBlobSize, err := dalc.DA.MaxBlobSize(context.Background())
@@ -249,7 +353,18 @@ func NewManager(
 	maxBlobSize -= blockProtocolOverhead
 
 	exec := state.NewBlockExecutor(proposerAddress, genesis.ChainID, mempool, mempoolReaper, proxyApp, eventBus, maxBlobSize, logger, execMetrics)
-	if s.LastBlockHeight+1 == uint64(genesis.InitialHeight) { //nolint:gosec
+	if conf.SystemTxs != "" {
+		template, err := state.ParseProposalTemplate(conf.SystemTxs)
+		if err != nil {
+			return nil, err
+		}
+		exec.SetProposalTemplate(template)
+	}
+	initialHeight := uint64(genesis.InitialHeight) //nolint:gosec
+	fresh := s.LastBlockHeight+1 == initialHeight
+	// fresh node starting from trusted height doesn't initialize the app, see BootstrapFromTrustedHeader
+	trustedBootstrap := fresh && conf.TrustedHeight > initialHeight
+	if fresh && !trustedBootstrap {
 		res, err := exec.InitChain(genesis)
 		if err != nil {
 			return nil, err
@@ -272,6 +387,10 @@ func NewManager(
 	if err != nil {
 		return nil, err
 	}
+	// headers are submitted from initial height of the chain, which might be greater than 1
+	if initialHeight > 1 {
+		pendingHeaders.lastSubmittedHeight.CompareAndSwap(0, initialHeight-1)
+	}
 
 	// If lastBatchHash is not set, retrieve the last batch hash from store
 	lastBatchHash, err := store.GetMetadata(context.Background(), LastBatchHashKey)
@@ -293,6 +412,7 @@ func NewManager(
 		DataCh:         make(chan *types.Data, channelLength),
 		headerInCh:     make(chan NewHeaderEvent, headerInChLength),
 		dataInCh:       make(chan NewDataEvent, headerInChLength),
+		forcedInCh:     make(chan forcedInclusionEvent),
 		headerStoreCh:  make(chan struct{}, 1),
 		dataStoreCh:    make(chan struct{}, 1),
 		headerStore:    headerStore,
@@ -308,9 +428,41 @@ func NewManager(
 		metrics:        seqMetrics,
 		isProposer:     isProposer,
 		seqClient:      seqClient,
+		mempoolReaper:  mempoolReaper,
 		bq:             NewBatchQueue(),
+		perf:           newPerformanceTracker(seqMetrics),
+		finality:       newFinalityTracker(conf.FinalitySLA, seqMetrics),
+	}
+	agg.trustedBootstrap = trustedBootstrap
+	checkpoint, found, err := loadDARetrievalHeight(context.Background(), store)
+	if err != nil {
+		return nil, err
+	}
+	agg.daCheckpointer = &daCheckpointer{store: store, saved: checkpoint}
+	if conf.Concurrency.DAFetchWorkers > 1 {
+		agg.daPrefetcher = newDAPrefetcher(dalc.RetrieveHeaders, conf.Concurrency.DAFetchWorkers, conf.DABlockTime)
+	}
+	// retrieval resumes after the last fully processed DA height, unless state points further
+	if found && checkpoint+1 > agg.daHeight {
+		logger.Info("resuming DA retrieval from checkpoint", "daHeight", checkpoint+1, "stateDAHeight", agg.daHeight)
+		agg.daHeight = checkpoint + 1
+	}
+	if dalc.Watcher != nil {
+		agg.daSubs = append(agg.daSubs, dalc.Watcher.Subscribe(dalc.Namespace, agg.daHeight))
+		if len(dalc.ForcedInclusionNamespace) > 0 {
+			agg.daSubs = append(agg.daSubs, dalc.Watcher.Subscribe(dalc.ForcedInclusionNamespace, agg.daHeight))
+		}
+	}
+	if conf.MaxBlockTime != 0 {
+		agg.governor = newBlockTimeGovernor(conf.BlockTime, conf.MaxBlockTime, conf.DABlockTime)
 	}
 	agg.init(context.Background())
+	if err := agg.initHalt(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := agg.initMessaging(context.Background()); err != nil {
+		return nil, err
+	}
 	return agg, nil
 }
 
@@ -318,9 +470,26 @@ func (m *Manager) init(ctx context.Context) {
 	// initialize da included height
 	if height, err := m.store.GetMetadata(ctx, DAIncludedHeightKey); err == nil && len(height) == 8 {
 		m.daIncludedHeight.Store(binary.BigEndian.Uint64(height))
+	} else {
+		// there are no blocks below initial height of the chain to include in DA
+		m.daIncludedHeight.Store(m.initialHeight() - 1)
+	}
+	if height, err := m.store.GetMetadata(ctx, LastSequencerDAHeightKey); err == nil && len(height) == 8 {
+		m.lastSequencerDAHeight.Store(binary.BigEndian.Uint64(height))
+	}
+	if height, err := LoadAppRetainHeight(ctx, m.store); err == nil {
+		m.appRetainHeight.Store(height)
 	}
 }
 
+// initialHeight returns height of the first block of the chain, which is 1 unless genesis sets another initial height.
+func (m *Manager) initialHeight() uint64 {
+	if m.genesis == nil || m.genesis.InitialHeight < 1 {
+		return 1
+	}
+	return uint64(m.genesis.InitialHeight)
+}
+
 func (m *Manager) setDAIncludedHeight(ctx context.Context, newHeight uint64) error {
 	for {
 		currentHeight := m.daIncludedHeight.Load()
@@ -328,6 +497,7 @@ func (m *Manager) setDAIncludedHeight(ctx context.Context, newHeight uint64) err
 			break
 		}
 		if m.daIncludedHeight.CompareAndSwap(currentHeight, newHeight) {
+			m.notifyDAIncludedHeight()
 			heightBytes := make([]byte, 8)
 			binary.BigEndian.PutUint64(heightBytes, newHeight)
 			return m.store.SetMetadata(ctx, DAIncludedHeightKey, heightBytes)
@@ -342,11 +512,71 @@ func (m *Manager) GetDAIncludedHeight() uint64 {
 	return m.daIncludedHeight.Load()
 }
 
+// WaitForDAIncludedHeight blocks until all blocks up to given height are included in the DA, or context is done.
+func (m *Manager) WaitForDAIncludedHeight(ctx context.Context, height uint64) error {
+	for {
+		m.daIncludedMtx.Lock()
+		if m.daIncludedChanged == nil {
+			m.daIncludedChanged = make(chan struct{})
+		}
+		changed := m.daIncludedChanged
+		m.daIncludedMtx.Unlock()
+		// height is checked after taking the channel, so an increase right after the check closes the channel
+		if m.GetDAIncludedHeight() >= height {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// notifyDAIncludedHeight wakes up callers of WaitForDAIncludedHeight.
+func (m *Manager) notifyDAIncludedHeight() {
+	m.daIncludedMtx.Lock()
+	defer m.daIncludedMtx.Unlock()
+	if m.daIncludedChanged != nil {
+		close(m.daIncludedChanged)
+		m.daIncludedChanged = nil
+	}
+}
+
 // SetDALC is used to set DataAvailabilityLayerClient used by Manager.
 func (m *Manager) SetDALC(dalc *da.DAClient) {
 	m.dalc = dalc
 }
 
+// SetSafeModeCheck sets function used to check if node is in safe mode. Blocks are neither produced
+// nor applied while check returns an error.
+func (m *Manager) SetSafeModeCheck(check func() error) {
+	m.safeModeCheck = check
+}
+
+// checkSafeMode returns error if node is in safe mode.
+func (m *Manager) checkSafeMode() error {
+	if m.safeModeCheck == nil {
+		return nil
+	}
+	return m.safeModeCheck()
+}
+
+// SetLoadShedding enables or disables load shedding. While enabled, blocks are retrieved from DA only
+// once per DA block time, instead of catching up with DA as fast as possible.
+func (m *Manager) SetLoadShedding(enabled bool) {
+	if m.loadShedding.Swap(enabled) != enabled {
+		m.logger.Info("load shedding changed", "enabled", enabled)
+	}
+}
+
+// ShrinkCaches releases memory used by caches of seen block hashes. Cached blocks waiting to be synced
+// are kept, as they might not be retrievable again.
+func (m *Manager) ShrinkCaches() {
+	m.headerCache.clearSeen()
+	m.dataCache.clearSeen()
+}
+
 // isProposer returns whether or not the manager is a proposer
 func isProposer(signerPrivKey crypto.PrivKey, s types.State) (bool, error) {
 	if len(s.Validators.Validators) == 0 {
@@ -396,7 +626,7 @@ func (m *Manager) IsDAIncluded(hash types.Hash) bool {
 // getRemainingSleep calculates the remaining sleep time based on config and a start time.
 func (m *Manager) getRemainingSleep(start time.Time) time.Duration {
 	elapsed := time.Since(start)
-	interval := m.conf.BlockTime
+	interval := m.getBlockTime()
 
 	if m.conf.LazyAggregator {
 		if m.buildingBlock && elapsed >= interval {
@@ -415,6 +645,37 @@ func (m *Manager) getRemainingSleep(start time.Time) time.Duration {
 	return 0
 }
 
+// ProductionStats returns aggregated latencies of block production stages (see Stage* constants), computed
+// from the latest blocks produced by this node.
+func (m *Manager) ProductionStats() map[string]StageStats {
+	return m.perf.stats()
+}
+
+// SetSystemTxSource sets source of system transactions of given kind, defined by SystemTxs. It must be called
+// before blocks are produced or synced.
+func (m *Manager) SetSystemTxSource(kind string, source state.SystemTxSource) error {
+	return m.executor.SetSystemTxSource(kind, source)
+}
+
+// SetDataSectionSource sets source of application-defined sections of block data of given type. It must be
+// called before blocks are produced or synced.
+func (m *Manager) SetDataSectionSource(t types.DataSectionType, source state.DataSectionSource) error {
+	return m.executor.SetDataSectionSource(t, source)
+}
+
+// getBlockTime returns block time, adapted to DA throughput if governor is enabled, and slowed down while DA
+// account balance is low.
+func (m *Manager) getBlockTime() time.Duration {
+	blockTime := m.conf.BlockTime
+	if m.governor != nil {
+		blockTime = m.governor.getBlockTime()
+	}
+	if m.daThrottled.Load() {
+		blockTime *= daThrottleFactor
+	}
+	return blockTime
+}
+
 // BatchRetrieveLoop is responsible for retrieving batches from the sequencer.
 func (m *Manager) BatchRetrieveLoop(ctx context.Context) {
 	// Initialize batchTimer to fire immediately on start
@@ -440,6 +701,12 @@ func (m *Manager) BatchRetrieveLoop(ctx context.Context) {
 
 			if err != nil {
 				m.logger.Error("error while retrieving batch", "error", err)
+				if m.resendFallbackHash != nil {
+					m.logger.Error("sequencer didn't resend batches retrieved before unclean shutdown, they may be lost")
+					m.lastBatchHash, m.resendFallbackHash = m.resendFallbackHash, nil
+				}
+			} else {
+				m.resendFallbackHash = nil
 			}
 
 			if res != nil && res.Batch != nil {
@@ -448,6 +715,7 @@ func (m *Manager) BatchRetrieveLoop(ctx context.Context) {
 
 				// Calculate and store batch hash only if hashing succeeds
 				if h, err := batch.Hash(); err == nil {
+					m.verifyBatchReceipt(batch, h)
 					m.bq.AddBatch(BatchWithTime{Batch: batch, Time: batchTime})
 
 					// Update lastBatchHash only if the batch contains transactions
@@ -477,6 +745,9 @@ func (m *Manager) BatchRetrieveLoop(ctx context.Context) {
 
 // AggregationLoop is responsible for aggregating transactions into rollup-blocks.
 func (m *Manager) AggregationLoop(ctx context.Context) {
+	if m.conf.PipelineExecution {
+		defer m.startFinalizer(ctx)()
+	}
 	initialHeight := uint64(m.genesis.InitialHeight) //nolint:gosec
 	height := m.store.Height()
 	var delay time.Duration
@@ -579,22 +850,57 @@ func (m *Manager) HeaderSubmissionLoop(ctx context.Context) {
 		case <-timer.C:
 		}
 		if m.pendingHeaders.isEmpty() {
+			if m.governor != nil {
+				m.governor.relax()
+			}
+			continue
+		}
+		if m.skipDASubmission() {
 			continue
 		}
+		pendingBefore := m.pendingHeaders.numPendingHeaders()
+		start := time.Now()
 		err := m.submitHeadersToDA(ctx)
 		if err != nil {
 			m.logger.Error("error while submitting block to DA", "error", err)
+		} else {
+			m.perf.since(StageDASubmit, start)
+		}
+		if m.governor != nil {
+			m.observeDASubmission(start, err == nil, pendingBefore)
 		}
 	}
 }
 
+// observeDASubmission passes the result of DA submission to governor.
+func (m *Manager) observeDASubmission(start time.Time, success bool, pendingBefore uint64) {
+	now := time.Now()
+	pending := m.pendingHeaders.numPendingHeaders()
+	var submitted uint64
+	if pendingBefore > pending {
+		submitted = pendingBefore - pending
+	}
+	oldBlockTime := m.governor.getBlockTime()
+	blockTime := m.governor.observe(now, success, submitted, now.Sub(start), pending)
+	if blockTime != oldBlockTime {
+		latency, throughput := m.governor.daStats()
+		m.logger.Info("block time adjusted to DA throughput",
+			"blockTime", blockTime,
+			"pendingHeaders", pending,
+			"daLatency", latency,
+			"daThroughput", throughput,
+		)
+	}
+}
+
 func (m *Manager) handleEmptyDataHash(ctx context.Context, header *types.Header) {
 	headerHeight := header.Height()
 	if bytes.Equal(header.DataHash, dataHashForEmptyTxs) {
 		var lastDataHash types.Hash
 		var err error
 		var lastData *types.Data
-		if headerHeight > 1 {
+		// first block of the chain, at initial height, has no previous data
+		if headerHeight > m.initialHeight() {
 			_, lastData, err = m.store.GetBlockData(ctx, headerHeight-1)
 			if lastData != nil {
 				lastDataHash = lastData.Hash()
@@ -629,9 +935,31 @@ func (m *Manager) SyncLoop(ctx context.Context, cancel context.CancelFunc) {
 		select {
 		case <-daTicker.C:
 			m.sendNonBlockingSignalToRetrieveCh()
+			if m.syncPaused {
+				// DA included height might have advanced or safe mode is over, headers are already cached
+				err := m.trySyncNextBlock(ctx, atomic.LoadUint64(&m.daHeight))
+				if errors.Is(err, ErrHalted) {
+					m.logger.Error("halting the node", "error", err)
+					m.raiseAlert(alert.Halt, fmt.Sprintf("node halted: %s", err))
+					cancel()
+					return
+				}
+				if err != nil {
+					m.logger.Info("failed to sync next block", "error", err)
+				}
+			}
 		case <-blockTicker.C:
 			m.sendNonBlockingSignalToHeaderStoreCh()
 			m.sendNonBlockingSignalToDataStoreCh()
+		case event := <-m.forcedInCh:
+			err := m.applyDerivedBlock(ctx, event)
+			event.done <- err
+			if errors.Is(err, ErrHalted) {
+				m.logger.Error("halting the node", "error", err)
+				m.raiseAlert(alert.Halt, fmt.Sprintf("node halted: %s", err))
+				cancel()
+				return
+			}
 		case headerEvent := <-m.headerInCh:
 			// Only validated headers are sent to headerInCh, so we can safely assume that headerEvent.header is valid
 			header := headerEvent.Header
@@ -645,6 +973,9 @@ func (m *Manager) SyncLoop(ctx context.Context, cancel context.CancelFunc) {
 			)
 			if headerHeight <= m.store.Height() || m.headerCache.isSeen(headerHash) {
 				m.logger.Debug("header already seen", "height", headerHeight, "block hash", headerHash)
+				if headerHeight <= m.store.Height() {
+					m.checkDoubleSign(ctx, header)
+				}
 				continue
 			}
 			m.headerCache.setHeader(headerHeight, header)
@@ -658,6 +989,12 @@ func (m *Manager) SyncLoop(ctx context.Context, cancel context.CancelFunc) {
 			m.handleEmptyDataHash(ctx, &header.Header)
 
 			err := m.trySyncNextBlock(ctx, daHeight)
+			if errors.Is(err, ErrHalted) {
+				m.logger.Error("halting the node", "error", err)
+				m.raiseAlert(alert.Halt, fmt.Sprintf("node halted: %s", err))
+				cancel()
+				return
+			}
 			if err != nil {
 				m.logger.Info("failed to sync next block", "error", err)
 				continue
@@ -682,6 +1019,12 @@ func (m *Manager) SyncLoop(ctx context.Context, cancel context.CancelFunc) {
 			m.sendNonBlockingSignalToDataStoreCh()
 
 			err := m.trySyncNextBlock(ctx, daHeight)
+			if errors.Is(err, ErrHalted) {
+				m.logger.Error("halting the node", "error", err)
+				m.raiseAlert(alert.Halt, fmt.Sprintf("node halted: %s", err))
+				cancel()
+				return
+			}
 			if err != nil {
 				m.logger.Info("failed to sync next block", "error", err)
 				continue
@@ -727,6 +1070,14 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 			return ctx.Err()
 		default:
 		}
+		if m.headersOnly.Load() {
+			return nil
+		}
+		if err := m.checkSafeMode(); err != nil {
+			// retried by SyncLoop when safe mode is over
+			m.syncPaused = true
+			return err
+		}
 		currentHeight := m.store.Height()
 		h := m.headerCache.getHeader(currentHeight + 1)
 		if h == nil {
@@ -740,9 +1091,40 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 		}
 
 		hHeight := h.Height()
+		if m.halted(ctx, hHeight, h.Time()) {
+			return nil
+		}
+		if m.daOnly.Load() && !m.headerCache.isDAIncluded(h.Hash().String()) {
+			// in DA-only mode, blocks are applied only if included in DA
+			m.syncPaused = true
+			return nil
+		}
+		if m.exceedsMaxPendingHeaders(h) {
+			if !m.syncPaused {
+				m.logger.Info("pausing sync, too many blocks ahead of DA included height",
+					"height", hHeight, "daIncludedHeight", m.GetDAIncludedHeight(), "limit", m.conf.MaxPendingHeaders)
+			}
+			m.syncPaused = true
+			return nil
+		}
+		if m.syncPaused {
+			m.logger.Info("resuming sync", "height", hHeight, "daIncludedHeight", m.GetDAIncludedHeight())
+			m.syncPaused = false
+		}
 		m.logger.Info("Syncing header and data", "height", hHeight)
 		// Validate the received block before applying
 		if err := m.executor.Validate(m.lastState, h, d); err != nil {
+			var mismatch *state.AppHashMismatchError
+			if errors.As(err, &mismatch) {
+				return m.handleAppHashMismatch(ctx, h, mismatch)
+			}
+			return fmt.Errorf("failed to validate block: %w", err)
+		}
+		if err := m.validateBlockTime(h, m.lastState.LastBlockTime); err != nil {
+			if errors.Is(err, ErrBlockTimeInFuture) {
+				// retried by SyncLoop, as local clock catches up
+				m.syncPaused = true
+			}
 			return fmt.Errorf("failed to validate block: %w", err)
 		}
 		newState, responses, err := m.applyBlock(ctx, h, d)
@@ -757,10 +1139,13 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 		if err != nil {
 			return SaveBlockError{err}
 		}
-		_, _, err = m.executor.Commit(ctx, newState, h, d, responses)
+		_, retainHeight, err := m.executor.Commit(ctx, newState, h, d, responses)
 		if err != nil {
 			return fmt.Errorf("failed to Commit: %w", err)
 		}
+		if err := m.setAppRetainHeight(ctx, retainHeight); err != nil {
+			return err
+		}
 
 		err = m.store.SaveBlockResponses(ctx, hHeight, responses)
 		if err != nil {
@@ -769,6 +1154,9 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 
 		// Height gets updated
 		m.store.SetHeight(ctx, hHeight)
+		if !m.headerCache.isDAIncluded(h.Hash().String()) {
+			m.finality.softConfirmed(hHeight, len(d.Txs), time.Now())
+		}
 
 		if daHeight > newState.DAHeight {
 			newState.DAHeight = daHeight
@@ -782,9 +1170,20 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 	}
 }
 
+// exceedsMaxPendingHeaders returns true if header is not included in DA, and it's too far ahead of DA included height
+// to be applied, according to MaxPendingHeaders. This bounds the number of blocks trusted only because of sequencer
+// signature, e.g. during DA outage.
+func (m *Manager) exceedsMaxPendingHeaders(header *types.SignedHeader) bool {
+	if m.conf.MaxPendingHeaders == 0 || m.headerCache.isDAIncluded(header.Hash().String()) {
+		return false
+	}
+	return header.Height() > m.GetDAIncludedHeight()+m.conf.MaxPendingHeaders
+}
+
 // HeaderStoreRetrieveLoop is responsible for retrieving headers from the Header Store.
 func (m *Manager) HeaderStoreRetrieveLoop(ctx context.Context) {
-	lastHeaderStoreHeight := uint64(0)
+	// header store might not contain heights already applied, e.g. when node started from trusted height
+	lastHeaderStoreHeight := m.store.Height()
 	for {
 		select {
 		case <-ctx.Done():
@@ -799,7 +1198,8 @@ func (m *Manager) HeaderStoreRetrieveLoop(ctx context.Context) {
 				continue
 			}
 			daHeight := atomic.LoadUint64(&m.daHeight)
-			for _, header := range headers {
+			valid := m.usingExpectedCentralizedSequencer(headers)
+			for i, header := range headers {
 				// Check for shut down event prior to logging
 				// and sending header to headerInCh. The reason
 				// for checking for the shutdown event
@@ -812,7 +1212,7 @@ func (m *Manager) HeaderStoreRetrieveLoop(ctx context.Context) {
 				default:
 				}
 				// early validation to reject junk headers
-				if !m.isUsingExpectedCentralizedSequencer(header) {
+				if !valid[i] {
 					continue
 				}
 				m.logger.Debug("header retrieved from p2p header sync", "headerHeight", header.Height(), "daHeight", daHeight)
@@ -825,7 +1225,8 @@ func (m *Manager) HeaderStoreRetrieveLoop(ctx context.Context) {
 
 // DataStoreRetrieveLoop is responsible for retrieving data from the Data Store.
 func (m *Manager) DataStoreRetrieveLoop(ctx context.Context) {
-	lastDataStoreHeight := uint64(0)
+	// data store might not contain heights already applied, e.g. when node started from trusted height
+	lastDataStoreHeight := m.store.Height()
 	for {
 		select {
 		case <-ctx.Done():
@@ -898,6 +1299,11 @@ func (m *Manager) RetrieveLoop(ctx context.Context) {
 	// This enables syncing faster than the DA block time.
 	headerFoundCh := make(chan struct{}, 1)
 	defer close(headerFoundCh)
+	defer func() {
+		for _, sub := range m.daSubs {
+			sub.Close()
+		}
+	}()
 	for {
 		select {
 		case <-ctx.Done():
@@ -906,7 +1312,16 @@ func (m *Manager) RetrieveLoop(ctx context.Context) {
 		case <-headerFoundCh:
 		}
 		daHeight := atomic.LoadUint64(&m.daHeight)
-		err := m.processNextDAHeader(ctx)
+		blockHeight, err := m.processNextDAHeader(ctx)
+		if err == nil {
+			m.daCheckpointer.processed(daHeight, blockHeight)
+			for _, sub := range m.daSubs {
+				sub.Processed(daHeight)
+			}
+		}
+		if cpErr := m.daCheckpointer.advance(ctx); cpErr != nil {
+			m.logger.Error("failed to save DA retrieval height", "error", cpErr)
+		}
 		if err != nil && ctx.Err() == nil {
 			// if the requested da height is not yet available, wait silently, otherwise log the error and wait
 			if !strings.Contains(err.Error(), ErrHeightFromFutureStr) {
@@ -914,7 +1329,11 @@ func (m *Manager) RetrieveLoop(ctx context.Context) {
 			}
 			continue
 		}
-		// Signal the headerFoundCh to try and retrieve the next block
+		// Signal the headerFoundCh to try and retrieve the next block, unless DA prefetching is paused
+		if m.loadShedding.Load() {
+			atomic.AddUint64(&m.daHeight, 1)
+			continue
+		}
 		select {
 		case headerFoundCh <- struct{}{}:
 		default:
@@ -923,10 +1342,11 @@ func (m *Manager) RetrieveLoop(ctx context.Context) {
 	}
 }
 
-func (m *Manager) processNextDAHeader(ctx context.Context) error {
+// processNextDAHeader retrieves headers from the next DA block and returns the highest height of headers found.
+func (m *Manager) processNextDAHeader(ctx context.Context) (uint64, error) {
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return 0, ctx.Err()
 	default:
 	}
 
@@ -935,34 +1355,83 @@ func (m *Manager) processNextDAHeader(ctx context.Context) error {
 	daHeight := atomic.LoadUint64(&m.daHeight)
 
 	var err error
+	var maxHeight uint64
 	m.logger.Debug("trying to retrieve block from DA", "daHeight", daHeight)
 	for r := 0; r < maxRetries; r++ {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return 0, ctx.Err()
 		default:
 		}
 		headerResp, fetchErr := m.fetchHeaders(ctx, daHeight)
 		if fetchErr == nil {
+			m.observeDATime(headerResp.Timestamp)
 			if headerResp.Code == da.StatusNotFound {
 				m.logger.Debug("no header found", "daHeight", daHeight, "reason", headerResp.Message)
-				return nil
+				return 0, m.processDAOnly(ctx, daHeight)
 			}
 			m.logger.Debug("retrieved potential headers", "n", len(headerResp.Headers), "daHeight", daHeight)
-			for _, header := range headerResp.Headers {
+			for reason, n := range headerResp.Discarded {
+				m.metrics.DADiscardedBlobs.With("reason", reason).Add(float64(n))
+			}
+			valid := m.usingExpectedCentralizedSequencer(headerResp.Headers)
+			// headers failing validation are quarantined for diagnosis, see QuarantineKey
+			var quarantined []QuarantinedBlob
+			// heights, IDs and blobs of DA included headers, see saveDACertificates
+			var certHeights []uint64
+			var certIDs []goDA.ID
+			var certBlobs [][]byte
+			for i, header := range headerResp.Headers {
 				// early validation to reject junk headers
-				if !m.isUsingExpectedCentralizedSequencer(header) {
+				if !valid[i] {
 					m.logger.Debug("skipping header from unexpected sequencer",
 						"headerHeight", header.Height(),
 						"headerHash", header.Hash().String())
+					m.metrics.DADiscardedBlobs.With("reason", da.DiscardSignature).Add(1)
+					quarantined = append(quarantined, newQuarantinedBlob(daHeight, header, QuarantineSignature,
+						errors.New("header is not signed by the sequencer")))
+					continue
+				}
+				if chainID := header.ChainID(); chainID != m.genesis.ChainID {
+					m.metrics.DADiscardedBlobs.With("reason", QuarantineChainID).Add(1)
+					quarantined = append(quarantined, newQuarantinedBlob(daHeight, header, QuarantineChainID,
+						fmt.Errorf("chain ID %q doesn't match %q", chainID, m.genesis.ChainID)))
+					continue
+				}
+				if err := m.validateDAInclusionTime(header, headerResp.Timestamp); err != nil {
+					m.logger.Error("skipping header with invalid time", "headerHeight", header.Height(), "error", err)
+					quarantined = append(quarantined, newQuarantinedBlob(daHeight, header, QuarantineTime, err))
 					continue
 				}
+				if m.daOnly.Load() {
+					extends, err := m.extendsDerivedBlocks(ctx, header)
+					if err != nil {
+						return 0, err
+					}
+					if !extends {
+						m.logger.Info("skipping sequencer header not extending blocks derived in DA-only mode", "headerHeight", header.Height())
+						continue
+					}
+				}
+				if err := m.setLastSequencerDAHeight(ctx, daHeight); err != nil {
+					return 0, err
+				}
+				maxHeight = max(maxHeight, header.Height())
 				blockHash := header.Hash().String()
 				m.headerCache.setDAIncluded(blockHash)
 				err = m.setDAIncludedHeight(ctx, header.Height())
 				if err != nil {
-					return err
+					return 0, err
+				}
+				if err := m.setBlockDAHeight(ctx, header.Height(), daHeight); err != nil {
+					return 0, err
+				}
+				if i < len(headerResp.IDs) && i < len(headerResp.Blobs) {
+					certHeights = append(certHeights, header.Height())
+					certIDs = append(certIDs, headerResp.IDs[i])
+					certBlobs = append(certBlobs, headerResp.Blobs[i])
 				}
+				m.finality.daIncluded(header.Height(), time.Now())
 				m.logger.Info("block marked as DA included", "blockHeight", header.Height(), "blockHash", blockHash)
 				if !m.headerCache.isSeen(blockHash) {
 					// Check for shut down event prior to logging
@@ -973,13 +1442,15 @@ func (m *Manager) processNextDAHeader(ctx context.Context) error {
 					// are satisfied.
 					select {
 					case <-ctx.Done():
-						return fmt.Errorf("unable to send block to blockInCh, context done: %w", ctx.Err())
+						return 0, fmt.Errorf("unable to send block to blockInCh, context done: %w", ctx.Err())
 					default:
 					}
 					m.headerInCh <- NewHeaderEvent{header, daHeight}
 				}
 			}
-			return nil
+			m.quarantine(ctx, quarantined)
+			m.saveDACertificates(ctx, daHeight, certHeights, certIDs, certBlobs)
+			return maxHeight, m.processDAOnly(ctx, daHeight)
 		}
 
 		// Track the error
@@ -987,20 +1458,39 @@ func (m *Manager) processNextDAHeader(ctx context.Context) error {
 		// Delay before retrying
 		select {
 		case <-ctx.Done():
-			return err
+			return 0, err
 		case <-time.After(100 * time.Millisecond):
 		}
 	}
-	return err
+	return 0, err
 }
 
-func (m *Manager) isUsingExpectedCentralizedSequencer(header *types.SignedHeader) bool {
-	return bytes.Equal(header.ProposerAddress, m.genesis.Validators[0].Address.Bytes()) && header.ValidateBasic() == nil
+// usingExpectedCentralizedSequencer reports which headers are valid and signed by the expected centralized sequencer.
+// Signatures of all headers are verified in a batch, see types.VerifySignedHeaders.
+func (m *Manager) usingExpectedCentralizedSequencer(headers []*types.SignedHeader) []bool {
+	valid := make([]bool, len(headers))
+	candidates := make([]*types.SignedHeader, 0, len(headers))
+	indices := make([]int, 0, len(headers))
+	for i, header := range headers {
+		if bytes.Equal(header.ProposerAddress, m.genesis.Validators[0].Address.Bytes()) {
+			candidates = append(candidates, header)
+			indices = append(indices, i)
+		}
+	}
+	for j, err := range types.VerifySignedHeaders(candidates) {
+		valid[indices[j]] = err == nil
+	}
+	return valid
 }
 
 func (m *Manager) fetchHeaders(ctx context.Context, daHeight uint64) (da.ResultRetrieveHeaders, error) {
 	var err error
-	headerRes := m.dalc.RetrieveHeaders(ctx, daHeight)
+	var headerRes da.ResultRetrieveHeaders
+	if m.daPrefetcher != nil {
+		headerRes = m.daPrefetcher.retrieve(ctx, daHeight)
+	} else {
+		headerRes = m.dalc.RetrieveHeaders(ctx, daHeight)
+	}
 	if headerRes.Code == da.StatusError {
 		err = fmt.Errorf("failed to retrieve block: %s", headerRes.Message)
 	}
@@ -1024,6 +1514,12 @@ func (m *Manager) getTxsFromBatch() (cmtypes.Txs, *time.Time, error) {
 		// batch is nil when there is nothing to process
 		return nil, nil, ErrNoBatch
 	}
+	m.appliedBatchHash = nil
+	if batch.Transactions != nil {
+		if h, err := batch.Hash(); err == nil {
+			m.appliedBatchHash = h
+		}
+	}
 	txs := make(cmtypes.Txs, 0, len(batch.Transactions))
 	for _, tx := range batch.Transactions {
 		txs = append(txs, tx)
@@ -1042,11 +1538,25 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 		return ErrNotProposer
 	}
 
+	if err := m.checkSafeMode(); err != nil {
+		return fmt.Errorf("refusing to create block: %w", err)
+	}
+	if err := m.finalizeError(); err != nil {
+		return fmt.Errorf("refusing to create block: %w", err)
+	}
+
+	if m.halted(ctx, m.store.Height()+1, time.Now()) {
+		return nil
+	}
+
 	if m.conf.MaxPendingBlocks != 0 && m.pendingHeaders.numPendingHeaders() >= m.conf.MaxPendingBlocks {
 		return fmt.Errorf("refusing to create block: pending blocks [%d] reached limit [%d]",
 			m.pendingHeaders.numPendingHeaders(), m.conf.MaxPendingBlocks)
 	}
 
+	start := time.Now()
+	timer := stageTimer{}
+
 	var (
 		lastSignature  *types.Signature
 		lastHeaderHash types.Hash
@@ -1092,6 +1602,13 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 			return fmt.Errorf("failed to load extended commit for height %d: %w", height, err)
 		}
 
+		// batch must not be consumed before block time is known
+		if m.conf.BlockTimeSource == config.TimeSourceDA && m.lastDATime().IsZero() {
+			m.logger.Info(ErrNoDATime.Error())
+			return nil
+		}
+
+		fetchStart := time.Now()
 		txs, timestamp, err := m.getTxsFromBatch()
 		if errors.Is(err, ErrNoBatch) {
 			m.logger.Info(err.Error())
@@ -1100,12 +1617,14 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to get transactions from batch: %w", err)
 		}
-		// sanity check timestamp for monotonically increasing
-		if timestamp.Before(lastHeaderTime) {
-			return fmt.Errorf("timestamp is not monotonically increasing: %s < %s", timestamp, m.getLastBlockTime())
+		timer.track(StageBatchFetch, fetchStart)
+		txs = m.withInboundMessages(txs)
+		blockTime, err := m.blockTime(*timestamp, lastHeaderTime)
+		if err != nil {
+			return err
 		}
 		m.logger.Info("Creating and publishing block", "height", newHeight)
-		header, data, err = m.createBlock(newHeight, lastSignature, lastHeaderHash, extendedCommit, txs, *timestamp)
+		header, data, err = m.createBlock(ctx, newHeight, lastSignature, lastHeaderHash, extendedCommit, txs, blockTime)
 		if err != nil {
 			return err
 		}
@@ -1120,19 +1639,30 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 		header.Validators = m.getLastStateValidators()
 		header.ValidatorHash = header.Validators.Hash()
 
+		signStart := time.Now()
 		signature, err = m.getSignature(header.Header)
 		if err != nil {
 			return err
 		}
+		timer.track(StageSign, signStart)
 
 		// set the signature to current block's signed header
 		header.Signature = *signature
-		err = m.store.SaveBlockData(ctx, header, data, signature)
+		storeStart := time.Now()
+		// transactions of the batch are saved in the pending block, so the batch doesn't have to be
+		// re-requested from the sequencer after unclean shutdown
+		metadata := make(map[string][]byte)
+		if m.appliedBatchHash != nil {
+			metadata[LastAppliedBatchHashKey] = m.appliedBatchHash
+		}
+		err = m.store.SaveBlockDataWithMetadata(ctx, header, data, signature, metadata)
 		if err != nil {
 			return SaveBlockError{err}
 		}
+		timer.track(StageStore, storeStart)
 	}
 
+	executeStart := time.Now()
 	newState, responses, err := m.applyBlock(ctx, header, data)
 	if err != nil {
 		if ctx.Err() != nil {
@@ -1141,13 +1671,16 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 		// if call to applyBlock fails, we halt the node, see https://github.com/cometbft/cometbft/pull/496
 		panic(err)
 	}
+	timer.track(StageExecute, executeStart)
 	// Before taking the hash, we need updated ISRs, hence after ApplyBlock
 	header.Header.DataHash = data.Hash()
 
+	signStart := time.Now()
 	signature, err = m.getSignature(header.Header)
 	if err != nil {
 		return err
 	}
+	timer.track(StageSign, signStart)
 
 	if err := m.processVoteExtension(ctx, header, data, newHeight); err != nil {
 		return err
@@ -1173,55 +1706,65 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 	headerHash := header.Hash().String()
 	m.headerCache.setSeen(headerHash)
 
-	// SaveBlock commits the DB tx
-	err = m.store.SaveBlockData(ctx, header, data, signature)
+	// SaveBlock commits the DB tx. Block responses are saved with the block before it's committed in the app, so
+	// they're never lost and the new state can be re-derived from them after a crash, see Handshake.
+	storeStart := time.Now()
+	metadata := make(map[string][]byte)
+	m.setMessagesDelivered(data, metadata)
+	err = m.store.SaveBlockDataWithResponses(ctx, header, data, signature, responses, metadata)
 	if err != nil {
 		return SaveBlockError{err}
 	}
+	timer.track(StageStore, storeStart)
+
+	// state of the previous block may fail to be saved while this one is executed, see startFinalizer
+	if err := m.finalizeError(); err != nil {
+		return fmt.Errorf("refusing to commit block: %w", err)
+	}
 
 	// Commit the new state and block which writes to disk on the proxy app
-	appHash, _, err := m.executor.Commit(ctx, newState, header, data, responses)
+	commitStart := time.Now()
+	appHash, retainHeight, err := m.executor.Commit(ctx, newState, header, data, responses)
 	if err != nil {
 		return err
 	}
+	if err := m.setAppRetainHeight(ctx, retainHeight); err != nil {
+		return err
+	}
+	timer.track(StageExecute, commitStart)
 	// Update app hash in state
 	newState.AppHash = appHash
 
-	// SaveBlockResponses commits the DB tx
-	err = m.store.SaveBlockResponses(ctx, headerHeight, responses)
-	if err != nil {
-		return SaveBlockResponsesError{err}
-	}
-
 	// Update the store height before submitting to the DA layer but after committing to the DB
 	m.store.SetHeight(ctx, headerHeight)
+	m.finality.softConfirmed(headerHeight, len(data.Txs), time.Now())
 
 	newState.DAHeight = atomic.LoadUint64(&m.daHeight)
-	// After this call m.lastState is the NEW state returned from ApplyBlock
-	// updateState also commits the DB tx
-	err = m.updateState(ctx, newState)
-	if err != nil {
-		return err
+	b := &committedBlock{header: header, data: data, state: newState, start: start, timer: timer}
+	if m.finalizeCh != nil {
+		// with pipelined execution, the new state is saved while the next block is produced
+		m.SetLastState(newState)
+		m.finalizeCh <- b
+		return nil
 	}
-	m.recordMetrics(data)
-	// Check for shut down event prior to sending the header and block to
-	// their respective channels. The reason for checking for the shutdown
-	// event separately is due to the inconsistent nature of the select
-	// statement when multiple cases are satisfied.
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("unable to send header and block, context done: %w", ctx.Err())
-	default:
+	if err := m.saveState(ctx, b); err != nil {
+		return err
 	}
+	m.SetLastState(newState)
+	return m.broadcastBlock(ctx, b)
+}
 
-	// Publish header to channel so that header exchange service can broadcast
-	m.HeaderCh <- header
-
-	// Publish block to channel so that block exchange service can broadcast
-	m.DataCh <- data
-
-	m.logger.Debug("successfully proposed header", "proposer", hex.EncodeToString(header.ProposerAddress), "height", headerHeight)
-
+// SignPreconfirmation signs preconfirmation with the proposer key. It returns ErrNotProposer if the node is not
+// the proposer, as only the sequencer can promise inclusion of transactions.
+func (m *Manager) SignPreconfirmation(p *types.Preconfirmation) error {
+	if !m.isProposer {
+		return ErrNotProposer
+	}
+	sig, err := m.sign(p.SignBytes())
+	if err != nil {
+		return err
+	}
+	p.Signature = sig
 	return nil
 }
 
@@ -1329,6 +1872,13 @@ func (m *Manager) submitHeadersToDA(ctx context.Context) error {
 		// The error is logged and normal processing of pending blocks continues.
 		m.logger.Error("error while fetching blocks pending DA", "err", err)
 	}
+	// headers submitted before restart may be included in DA, even if the submission wasn't confirmed
+	if headersToSubmit, err = m.skipIncludedHeaders(ctx, headersToSubmit); err != nil {
+		return err
+	}
+	if len(headersToSubmit) == 0 {
+		return nil
+	}
 	numSubmittedHeaders := 0
 	attempt := 0
 	maxBlobSize, err := m.dalc.DA.MaxBlobSize(ctx)
@@ -1338,6 +1888,8 @@ func (m *Manager) submitHeadersToDA(ctx context.Context) error {
 	initialMaxBlobSize := maxBlobSize
 	initialGasPrice := m.dalc.GasPrice
 	gasPrice := m.dalc.GasPrice
+	// uncertain is set if the previous submission failed, but it may still be included in DA
+	uncertain := false
 
 daSubmitRetryLoop:
 	for !submittedAllHeaders && attempt < maxSubmitAttempts {
@@ -1347,22 +1899,41 @@ daSubmitRetryLoop:
 		case <-time.After(backoff):
 		}
 
+		if uncertain {
+			if headersToSubmit, err = m.skipIncludedHeaders(ctx, headersToSubmit); err != nil {
+				return err
+			}
+			if len(headersToSubmit) == 0 {
+				submittedAllHeaders = true
+				break
+			}
+			uncertain = false
+		}
+		if err := m.recordDASubmission(ctx, headersToSubmit); err != nil {
+			return err
+		}
 		res := m.dalc.SubmitHeaders(ctx, headersToSubmit, maxBlobSize, gasPrice)
 		switch res.Code {
 		case da.StatusSuccess:
 			m.logger.Info("successfully submitted Rollkit headers to DA layer", "gasPrice", gasPrice, "daHeight", res.DAHeight, "headerCount", res.SubmittedCount)
+			m.daSubmitFailures = 0
 			if res.SubmittedCount == uint64(len(headersToSubmit)) {
 				submittedAllHeaders = true
 			}
 			submittedBlocks, notSubmittedBlocks := headersToSubmit[:res.SubmittedCount], headersToSubmit[res.SubmittedCount:]
 			numSubmittedHeaders += len(submittedBlocks)
 			for _, block := range submittedBlocks {
-				m.headerCache.setDAIncluded(block.Hash().String())
-				err = m.setDAIncludedHeight(ctx, block.Height())
-				if err != nil {
+				if err = m.markSubmitted(ctx, block, res.DAHeight); err != nil {
 					return err
 				}
 			}
+			if len(res.IDs) == len(submittedBlocks) {
+				heights := make([]uint64, len(submittedBlocks))
+				for i, block := range submittedBlocks {
+					heights[i] = block.Height()
+				}
+				m.saveDACertificates(ctx, res.DAHeight, heights, res.IDs, res.Blobs)
+			}
 			lastSubmittedHeight := uint64(0)
 			if l := len(submittedBlocks); l > 0 {
 				lastSubmittedHeight = submittedBlocks[l-1].Height()
@@ -1382,6 +1953,8 @@ daSubmitRetryLoop:
 			m.logger.Debug("resetting DA layer submission options", "backoff", backoff, "gasPrice", gasPrice, "maxBlobSize", maxBlobSize)
 		case da.StatusNotIncludedInBlock, da.StatusAlreadyInMempool:
 			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", attempt)
+			m.daSubmitFailed(res.Message)
+			uncertain = true
 			backoff = m.conf.DABlockTime * time.Duration(m.conf.DAMempoolTTL) //nolint:gosec
 			if m.dalc.GasMultiplier > 0 && gasPrice != -1 {
 				gasPrice = gasPrice * m.dalc.GasMultiplier
@@ -1393,12 +1966,18 @@ daSubmitRetryLoop:
 			fallthrough
 		default:
 			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", attempt)
+			m.daSubmitFailed(res.Message)
+			uncertain = res.Code == da.StatusContextDeadline
 			backoff = m.exponentialBackoff(backoff)
 		}
 
 		attempt += 1
 	}
 
+	if submittedAllHeaders {
+		m.clearDASubmission(ctx)
+	}
+
 	if !submittedAllHeaders {
 		return fmt.Errorf(
 			"failed to submit all blocks to DA layer, submitted %d blocks (%d left) after %d attempts",
@@ -1446,10 +2025,10 @@ func (m *Manager) getLastBlockTime() time.Time {
 	return m.lastState.LastBlockTime
 }
 
-func (m *Manager) createBlock(height uint64, lastSignature *types.Signature, lastHeaderHash types.Hash, extendedCommit abci.ExtendedCommitInfo, txs cmtypes.Txs, timestamp time.Time) (*types.SignedHeader, *types.Data, error) {
+func (m *Manager) createBlock(ctx context.Context, height uint64, lastSignature *types.Signature, lastHeaderHash types.Hash, extendedCommit abci.ExtendedCommitInfo, txs cmtypes.Txs, timestamp time.Time) (*types.SignedHeader, *types.Data, error) {
 	m.lastStateMtx.RLock()
 	defer m.lastStateMtx.RUnlock()
-	return m.executor.CreateBlock(height, lastSignature, extendedCommit, lastHeaderHash, m.lastState, txs, timestamp)
+	return m.executor.CreateBlock(ctx, height, lastSignature, extendedCommit, lastHeaderHash, m.lastState, txs, timestamp)
 }
 
 func (m *Manager) applyBlock(ctx context.Context, header *types.SignedHeader, data *types.Data) (types.State, *abci.ResponseFinalizeBlock, error) {
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	abciclient "github.com/cometbft/cometbft/abci/client"
 	abci "github.com/cometbft/cometbft/abci/types"
 	cfg "github.com/cometbft/cometbft/config"
 	cmcrypto "github.com/cometbft/cometbft/crypto"
@@ -178,6 +179,14 @@ func TestHandleEmptyDataHash(t *testing.T) {
 	require.Equal(d.Metadata.ChainID, header.ChainID())
 	require.Equal(d.Metadata.Height, header.Height())
 	require.Equal(d.Metadata.Time, header.BaseHeader.Time)
+
+	// first block of the chain starting at initial height has no previous data
+	m.genesis = &cmtypes.GenesisDoc{InitialHeight: 3}
+	header.BaseHeader.Height = 3
+	m.handleEmptyDataHash(ctx, header)
+	d = dataCache.getData(3)
+	require.NotNil(d)
+	require.Empty(d.Metadata.LastDataHash)
 }
 
 func TestInitialStateUnexpectedHigherGenesis(t *testing.T) {
@@ -250,6 +259,33 @@ func TestIsDAIncluded(t *testing.T) {
 	require.True(m.IsDAIncluded(hash))
 }
 
+func TestWaitForDAIncludedHeight(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m := &Manager{store: store.New(kv)}
+	require.NoError(m.WaitForDAIncludedHeight(ctx, 0))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.WaitForDAIncludedHeight(ctx, 3)
+	}()
+	require.NoError(m.setDAIncludedHeight(ctx, 2))
+	select {
+	case err := <-errCh:
+		require.Fail("returned before height was included", "error: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(m.setDAIncludedHeight(ctx, 5))
+	require.NoError(<-errCh)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(m.WaitForDAIncludedHeight(canceled, 6), context.Canceled)
+}
+
 func TestSubmitBlocksToMockDA(t *testing.T) {
 	ctx := context.Background()
 
@@ -303,6 +339,8 @@ func TestSubmitBlocksToMockDA(t *testing.T) {
 			// * retry with a higher gas price
 			// * successfully submit
 			mockDA.On("MaxBlobSize", mock.Anything).Return(uint64(12345), nil)
+			// after timeouts, DA is searched for the submitted blob before it's submitted again
+			mockDA.On("GetIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 			mockDA.
 				On("Submit", mock.Anything, blobs, tc.expectedGasPrices[0], []byte(nil)).
 				Return([][]byte{}, &goDA.ErrTxTimedOut{}).Once()
@@ -467,16 +505,18 @@ func Test_submitBlocksToDA_BlockMarshalErrorCase1(t *testing.T) {
 
 	m := getManager(t, goDATest.NewDummyDA())
 
-	header1, data1 := types.GetRandomBlock(uint64(1), 5, chainID)
-	header2, data2 := types.GetRandomBlock(uint64(2), 5, chainID)
-	header3, data3 := types.GetRandomBlock(uint64(3), 5, chainID)
+	header1, _ := types.GetRandomBlock(uint64(1), 5, chainID)
+	header2, _ := types.GetRandomBlock(uint64(2), 5, chainID)
+	header3, _ := types.GetRandomBlock(uint64(3), 5, chainID)
 
 	store := mocks.NewStore(t)
 	invalidateBlockHeader(header1)
 	store.On("GetMetadata", ctx, LastSubmittedHeightKey).Return(nil, ds.ErrNotFound)
-	store.On("GetBlockData", ctx, uint64(1)).Return(header1, data1, nil)
-	store.On("GetBlockData", ctx, uint64(2)).Return(header2, data2, nil)
-	store.On("GetBlockData", ctx, uint64(3)).Return(header3, data3, nil)
+	store.On("GetMetadata", ctx, DASubmissionKey).Return(nil, ds.ErrNotFound)
+	store.On("SetMetadata", ctx, DASubmissionKey, mock.Anything).Return(nil)
+	store.On("GetHeader", ctx, uint64(1)).Return(header1, nil)
+	store.On("GetHeader", ctx, uint64(2)).Return(header2, nil)
+	store.On("GetHeader", ctx, uint64(3)).Return(header3, nil)
 	store.On("Height").Return(uint64(3))
 
 	m.store = store
@@ -502,19 +542,23 @@ func Test_submitBlocksToDA_BlockMarshalErrorCase2(t *testing.T) {
 
 	m := getManager(t, goDATest.NewDummyDA())
 
-	header1, data1 := types.GetRandomBlock(uint64(1), 5, chainID)
-	header2, data2 := types.GetRandomBlock(uint64(2), 5, chainID)
-	header3, data3 := types.GetRandomBlock(uint64(3), 5, chainID)
+	header1, _ := types.GetRandomBlock(uint64(1), 5, chainID)
+	header2, _ := types.GetRandomBlock(uint64(2), 5, chainID)
+	header3, _ := types.GetRandomBlock(uint64(3), 5, chainID)
 
 	store := mocks.NewStore(t)
 	invalidateBlockHeader(header3)
 	store.On("SetMetadata", ctx, DAIncludedHeightKey, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}).Return(nil)
 	store.On("SetMetadata", ctx, DAIncludedHeightKey, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02}).Return(nil)
+	store.On("SetMetadata", ctx, blockDAHeightKey(1), []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}).Return(nil)
+	store.On("SetMetadata", ctx, blockDAHeightKey(2), []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}).Return(nil)
 	store.On("SetMetadata", ctx, LastSubmittedHeightKey, []byte(strconv.FormatUint(2, 10))).Return(nil)
 	store.On("GetMetadata", ctx, LastSubmittedHeightKey).Return(nil, ds.ErrNotFound)
-	store.On("GetBlockData", ctx, uint64(1)).Return(header1, data1, nil)
-	store.On("GetBlockData", ctx, uint64(2)).Return(header2, data2, nil)
-	store.On("GetBlockData", ctx, uint64(3)).Return(header3, data3, nil)
+	store.On("GetMetadata", ctx, DASubmissionKey).Return(nil, ds.ErrNotFound)
+	store.On("SetMetadata", ctx, DASubmissionKey, mock.Anything).Return(nil)
+	store.On("GetHeader", ctx, uint64(1)).Return(header1, nil)
+	store.On("GetHeader", ctx, uint64(2)).Return(header2, nil)
+	store.On("GetHeader", ctx, uint64(3)).Return(header3, nil)
 	store.On("Height").Return(uint64(3))
 
 	m.store = store
@@ -723,10 +767,10 @@ func TestManager_publishBlock(t *testing.T) {
 		metrics:     NopMetrics(),
 	}
 
-	t.Run("height should not be updated if saving block responses fails", func(t *testing.T) {
+	t.Run("height should not be updated if saving block fails", func(t *testing.T) {
 		mockStore.On("Height").Return(uint64(0))
 		signature := types.Signature([]byte{1, 1, 1})
-		header, data, err := executor.CreateBlock(0, &signature, abci.ExtendedCommitInfo{}, []byte{}, lastState, cmtypes.Txs{}, time.Now())
+		header, data, err := executor.CreateBlock(context.Background(), 0, &signature, abci.ExtendedCommitInfo{}, []byte{}, lastState, cmtypes.Txs{}, time.Now())
 		require.NoError(err)
 		require.NotNil(header)
 		require.NotNil(data)
@@ -741,12 +785,11 @@ func TestManager_publishBlock(t *testing.T) {
 		header.Validators = lastState.Validators
 
 		mockStore.On("GetBlockData", mock.Anything, uint64(1)).Return(header, data, nil).Once()
-		mockStore.On("SaveBlockData", mock.Anything, header, data, mock.Anything).Return(nil).Once()
-		mockStore.On("SaveBlockResponses", mock.Anything, uint64(0), mock.Anything).Return(SaveBlockResponsesError{}).Once()
+		mockStore.On("SaveBlockDataWithResponses", mock.Anything, header, data, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
 
 		ctx := context.Background()
 		err = m.publishBlock(ctx)
-		assert.ErrorAs(err, &SaveBlockResponsesError{})
+		assert.ErrorAs(err, &SaveBlockError{})
 
 		mockStore.AssertExpectations(t)
 	})
@@ -977,3 +1020,202 @@ func TestGetTxsFromBatch_ValidBatch(t *testing.T) {
 	assert.NotNil(t, timestamp, "Timestamp should not be nil for valid batch")
 	assert.Equal(t, cmtypes.Txs{cmtypes.Tx([]byte("tx1")), cmtypes.Tx([]byte("tx2"))}, txs, "Transactions do not match")
 }
+
+func TestHandleAppHashMismatch(t *testing.T) {
+	chainID := "TestHandleAppHashMismatch"
+	header, _ := types.GetRandomBlock(2, 0, chainID)
+	mismatch := &state.AppHashMismatchError{
+		Height:   header.Height(),
+		Expected: []byte("expected"),
+		Actual:   []byte("actual"),
+	}
+
+	cases := []struct {
+		name        string
+		policy      string
+		halted      bool
+		headersOnly bool
+	}{
+		{"halt", config.AppHashMismatchHalt, true, false},
+		{"headers only", config.AppHashMismatchHeadersOnly, false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+			kv, err := store.NewDefaultInMemoryKVStore()
+			require.NoError(err)
+			m := &Manager{
+				store:       store.New(kv),
+				conf:        config.BlockManagerConfig{AppHashMismatchPolicy: c.policy},
+				headerCache: NewHeaderCache(),
+				dataCache:   NewDataCache(),
+				retrieveCh:  make(chan struct{}, 1),
+				logger:      test.NewLogger(t),
+				daHeight:    10,
+			}
+
+			err = m.handleAppHashMismatch(ctx, header, mismatch)
+			require.ErrorIs(err, mismatch)
+			require.Equal(c.halted, errors.Is(err, ErrHalted))
+			require.Equal(c.headersOnly, m.IsHeadersOnly())
+
+			report, err := m.GetDivergenceReport(ctx)
+			require.NoError(err)
+			require.Equal(header.Height(), report.Height)
+			require.Equal(header.Height()-1, report.LastExecutedHeight)
+			require.Equal(types.Hash(mismatch.Expected), report.ExpectedAppHash)
+			require.Equal(types.Hash(mismatch.Actual), report.LocalAppHash)
+			require.Equal(c.policy, report.Policy)
+			require.Equal(-1, report.TxIndex)
+			require.NotEmpty(report.TxIndexUnknownReason)
+		})
+	}
+}
+
+func TestHandleAppHashMismatchRollback(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	chainID := "TestHandleAppHashMismatchRollback"
+	validatorSet := types.GetRandomValidatorSet()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := store.New(kv)
+	for h := uint64(1); h <= 3; h++ {
+		header, data := types.GetRandomBlock(h, 1, chainID)
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		require.NoError(s.SaveDAInclusionCertificate(ctx, &types.DAInclusionCertificate{Height: h, DAHeight: 5 + h}))
+		s.SetHeight(ctx, h)
+		require.NoError(s.UpdateState(ctx, types.State{
+			ChainID:         chainID,
+			LastBlockHeight: h,
+			LastBlockTime:   header.Time(),
+			DAHeight:        10,
+			NextValidators:  validatorSet,
+			Validators:      validatorSet,
+			LastValidators:  validatorSet,
+		}))
+	}
+
+	header, _ := types.GetRandomBlock(4, 0, chainID)
+	mismatch := &state.AppHashMismatchError{Height: 4, Expected: []byte("expected"), Actual: []byte("actual")}
+	m := &Manager{
+		store:        s,
+		genesis:      &cmtypes.GenesisDoc{ChainID: chainID, InitialHeight: 1},
+		conf:         config.BlockManagerConfig{AppHashMismatchPolicy: config.AppHashMismatchRollback},
+		headerCache:  NewHeaderCache(),
+		dataCache:    NewDataCache(),
+		logger:       test.NewLogger(t),
+		lastStateMtx: new(sync.RWMutex),
+		daHeight:     10,
+	}
+
+	err = m.handleAppHashMismatch(ctx, header, mismatch)
+	require.ErrorIs(err, mismatch)
+	require.ErrorIs(err, ErrHalted)
+	require.False(m.IsHeadersOnly())
+
+	// block 3 is re-fetched from its DA height and re-executed after restart
+	require.Equal(uint64(2), s.Height())
+	st, err := s.GetState(ctx)
+	require.NoError(err)
+	require.Equal(uint64(2), st.LastBlockHeight)
+	require.Equal(uint64(8), st.DAHeight)
+	require.Equal(uint64(8), m.daHeight)
+	require.Equal(uint64(2), m.lastState.LastBlockHeight)
+
+	report, err := m.GetDivergenceReport(ctx)
+	require.NoError(err)
+	require.Equal(uint64(3), report.LastExecutedHeight)
+	require.Equal(uint64(2), report.RolledBackTo)
+
+	// there is no state to roll back to before the first block
+	m.genesis.InitialHeight = 2
+	err = m.handleAppHashMismatch(ctx, header, &state.AppHashMismatchError{Height: 3, Expected: []byte("expected"), Actual: []byte("actual")})
+	require.ErrorIs(err, ErrHalted)
+	require.Equal(uint64(2), s.Height())
+}
+
+func TestExceedsMaxPendingHeaders(t *testing.T) {
+	require := require.New(t)
+
+	m := &Manager{
+		conf:        config.BlockManagerConfig{MaxPendingHeaders: 2},
+		headerCache: NewHeaderCache(),
+	}
+	m.daIncludedHeight.Store(3)
+
+	header4, _ := types.GetRandomBlock(4, 0, "TestExceedsMaxPendingHeaders")
+	header5, _ := types.GetRandomBlock(5, 0, "TestExceedsMaxPendingHeaders")
+	header6, _ := types.GetRandomBlock(6, 0, "TestExceedsMaxPendingHeaders")
+
+	require.False(m.exceedsMaxPendingHeaders(header4))
+	require.False(m.exceedsMaxPendingHeaders(header5))
+	require.True(m.exceedsMaxPendingHeaders(header6))
+
+	// headers included in DA are always applied
+	m.headerCache.setDAIncluded(header6.Hash().String())
+	require.False(m.exceedsMaxPendingHeaders(header6))
+
+	// no limit
+	m.conf.MaxPendingHeaders = 0
+	header10, _ := types.GetRandomBlock(10, 0, "TestExceedsMaxPendingHeaders")
+	require.False(m.exceedsMaxPendingHeaders(header10))
+}
+
+func TestHandshake(t *testing.T) {
+	appHash := []byte("app hash")
+	nextAppHash := []byte("next app hash")
+	cases := []struct {
+		name      string
+		appHeight int64
+		appHash   []byte
+		committed bool // block 6 was saved with its responses before it was committed in the app
+		err       bool
+	}{
+		{"in sync", 5, appHash, false, false},
+		{"app hash mismatch", 5, []byte("other"), false, true},
+		{"app ahead", 6, nextAppHash, false, true},
+		{"state recovered", 6, nextAppHash, true, false},
+		{"recovered app hash mismatch", 6, []byte("other"), true, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			app := &mocks.Application{}
+			app.On("Info", mock.Anything, mock.Anything).Return(&abci.ResponseInfo{LastBlockHeight: c.appHeight, LastBlockAppHash: c.appHash}, nil)
+			client := abciclient.NewLocalClient(nil, app)
+			kv, err := store.NewDefaultInMemoryKVStore()
+			require.NoError(t, err)
+			validators := types.GetRandomValidatorSet()
+			m := &Manager{
+				lastState:    types.State{LastBlockHeight: 5, AppHash: appHash, Validators: validators, NextValidators: validators, LastValidators: validators},
+				lastStateMtx: new(sync.RWMutex),
+				store:        store.New(kv),
+				executor:     state.NewBlockExecutor(nil, "TestHandshake", nil, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 100, log.NewNopLogger(), state.NopMetrics()),
+				metrics:      NopMetrics(),
+				logger:       test.NewLogger(t),
+			}
+			if c.committed {
+				header, data := types.GetRandomBlock(6, 1, "TestHandshake")
+				require.NoError(t, m.store.SaveBlockDataWithResponses(context.Background(), header, data, &header.Signature, &abci.ResponseFinalizeBlock{AppHash: nextAppHash}, nil))
+			}
+
+			err = m.Handshake(context.Background(), proxy.NewAppConnConsensus(client, proxy.NopMetrics()), proxy.NewAppConnQuery(client, proxy.NopMetrics()))
+			if c.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if c.committed {
+				assert.Equal(t, uint64(6), m.lastState.LastBlockHeight)
+				assert.Equal(t, uint64(6), m.store.Height())
+				s, err := m.store.GetState(context.Background())
+				require.NoError(t, err)
+				assert.Equal(t, nextAppHash, []byte(s.AppHash))
+			}
+		})
+	}
+}
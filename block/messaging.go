@@ -0,0 +1,243 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtypes "github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+// LastInboundMessageKey is the key used for persisting the source height and index of the last cross-rollup
+// message included in a block.
+const LastInboundMessageKey = "last inbound message"
+
+// maxPendingInboundMessages limits the number of verified messages waiting to be included in blocks.
+const maxPendingInboundMessages = 10000
+
+// messageInbox holds verified messages of the source rollup, waiting to be included in blocks.
+type messageInbox struct {
+	chainID string
+	pubKey  ed25519.PubKey
+	// dalc retrieves headers of the source rollup, from its namespace
+	dalc *da.DAClient
+
+	mtx sync.Mutex
+	// pending messages, ordered by source height and index
+	pending []types.InboundMessage
+	// lastHeight and lastIndex identify the last message included in a block, if delivered is set
+	delivered  bool
+	lastHeight uint64
+	lastIndex  uint32
+}
+
+// newMessageInbox creates inbox of messages of the source rollup, retrieving its headers from the same DA layer.
+func newMessageInbox(conf config.MessagingConfig, dalc *da.DAClient) (*messageInbox, error) {
+	namespace, err := hex.DecodeString(conf.SourceNamespace)
+	if err != nil || len(namespace) == 0 {
+		return nil, fmt.Errorf("invalid messaging source namespace %q", conf.SourceNamespace)
+	}
+	key, err := hex.DecodeString(conf.SourceSequencerKey)
+	if err != nil || len(key) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("invalid messaging source sequencer key %q, expected hex encoded ed25519 public key", conf.SourceSequencerKey)
+	}
+	pubKey := ed25519.PubKey(key)
+	sourceDALC := da.NewDAClient(dalc.DA, dalc.GasPrice, dalc.GasMultiplier, namespace, nil, dalc.Logger)
+	sourceDALC.RetrieveTimeout = dalc.RetrieveTimeout
+	sourceDALC.Watcher = dalc.Watcher
+	if f := dalc.HeaderFilter; f != nil {
+		// spam is filtered like in the namespace of this rollup, but headers are proposed by the source sequencer
+		sourceDALC.HeaderFilter = &da.HeaderFilter{MaxBlobSize: f.MaxBlobSize, BlockVersion: f.BlockVersion, ProposerAddress: pubKey.Address()}
+	}
+	return &messageInbox{chainID: conf.SourceChainID, pubKey: pubKey, dalc: sourceDALC}, nil
+}
+
+// isDelivered returns true if message was already included in a block.
+func (in *messageInbox) isDelivered(msg types.InboundMessage) bool {
+	return in.delivered && (msg.Before(in.lastHeight, in.lastIndex) || (msg.SourceHeight == in.lastHeight && msg.Index == in.lastIndex))
+}
+
+// add queues messages which are neither delivered nor pending, and returns them.
+func (in *messageInbox) add(msgs []types.InboundMessage) ([]types.InboundMessage, error) {
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+	var added []types.InboundMessage
+	for _, msg := range msgs {
+		if in.isDelivered(msg) || in.isPending(msg) {
+			continue
+		}
+		if len(in.pending) >= maxPendingInboundMessages {
+			return added, fmt.Errorf("too many pending messages (%d)", len(in.pending))
+		}
+		in.pending = append(in.pending, msg)
+		added = append(added, msg)
+	}
+	sort.Slice(in.pending, func(i, j int) bool {
+		return in.pending[i].Before(in.pending[j].SourceHeight, in.pending[j].Index)
+	})
+	return added, nil
+}
+
+func (in *messageInbox) isPending(msg types.InboundMessage) bool {
+	for _, p := range in.pending {
+		if p.SourceHeight == msg.SourceHeight && p.Index == msg.Index {
+			return true
+		}
+	}
+	return false
+}
+
+// txs returns pending messages wrapped in transactions.
+func (in *messageInbox) txs() cmtypes.Txs {
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+	txs := make(cmtypes.Txs, len(in.pending))
+	for i, msg := range in.pending {
+		txs[i] = cmtypes.Tx(types.NewInboundMessageTx(msg))
+	}
+	return txs
+}
+
+// setDelivered records the last message included in a block, and removes it and preceding messages from pending.
+func (in *messageInbox) setDelivered(height uint64, index uint32) {
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+	in.delivered, in.lastHeight, in.lastIndex = true, height, index
+	pending := in.pending[:0]
+	for _, msg := range in.pending {
+		if !in.isDelivered(msg) {
+			pending = append(pending, msg)
+		}
+	}
+	in.pending = pending
+}
+
+// initMessaging creates inbox of cross-rollup messages, if messaging is configured, and restores delivery progress.
+func (m *Manager) initMessaging(ctx context.Context) error {
+	if !m.conf.Messaging.Enabled() {
+		return nil
+	}
+	inbox, err := newMessageInbox(m.conf.Messaging, m.dalc)
+	if err != nil {
+		return err
+	}
+	last, err := m.store.GetMetadata(ctx, LastInboundMessageKey)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return fmt.Errorf("failed to load last inbound message: %w", err)
+	}
+	if len(last) == 12 {
+		inbox.setDelivered(binary.BigEndian.Uint64(last), binary.BigEndian.Uint32(last[8:]))
+	}
+	m.inbox = inbox
+	return nil
+}
+
+// RelayMessages verifies proof of outbound messages of the source rollup and queues messages sent to this rollup,
+// to be included in the next blocks. Block of the proof must be signed by the source sequencer, and its header
+// must be included in DA, in the namespace of the source rollup. Messages already delivered or queued are
+// skipped; the rest are returned.
+func (m *Manager) RelayMessages(ctx context.Context, proof *types.MessageProof) ([]types.InboundMessage, error) {
+	if m.inbox == nil {
+		return nil, ErrMessagingDisabled
+	}
+	if !m.isProposer {
+		return nil, ErrNotProposer
+	}
+	header, msgs, err := proof.Verify(m.inbox.chainID, m.inbox.pubKey, m.genesis.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	res := m.inbox.dalc.RetrieveHeaders(ctx, proof.DAHeight)
+	if res.Code != da.StatusSuccess {
+		return nil, fmt.Errorf("%w: failed to retrieve source headers at DA height %d: %s", types.ErrInvalidMessageProof, proof.DAHeight, res.Message)
+	}
+	hash := header.Hash()
+	included := false
+	for _, h := range res.Headers {
+		if bytes.Equal(h.Hash(), hash) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return nil, fmt.Errorf("%w: header %d is not included at DA height %d", types.ErrInvalidMessageProof, header.Height(), proof.DAHeight)
+	}
+	added, err := m.inbox.add(msgs)
+	if len(added) > 0 {
+		m.logger.Info("queued cross-rollup messages", "source", m.inbox.chainID, "height", header.Height(), "messages", len(added))
+	}
+	return added, err
+}
+
+// OutboundMessageProof returns proof of outbound messages included in the block at given height, which can be
+// relayed to destination rollups. Block must be included in DA.
+func (m *Manager) OutboundMessageProof(ctx context.Context, height uint64) (*types.MessageProof, error) {
+	header, data, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	daHeight, err := m.getBlockDAHeight(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("block %d is not included in DA yet: %w", height, err)
+	}
+	return types.NewMessageProof(daHeight, header, data)
+}
+
+// withInboundMessages prepends pending messages to transactions of a new block. Inbound message transactions are
+// only included by the aggregator, so the ones submitted by users are dropped.
+func (m *Manager) withInboundMessages(txs cmtypes.Txs) cmtypes.Txs {
+	var msgTxs cmtypes.Txs
+	if m.inbox != nil {
+		msgTxs = m.inbox.txs()
+	}
+	res := make(cmtypes.Txs, 0, len(msgTxs)+len(txs))
+	res = append(res, msgTxs...)
+	for _, tx := range txs {
+		if types.IsInboundMessageTx(types.Tx(tx)) {
+			m.logger.Info("dropping inbound message transaction submitted by user", "hash", types.TxHash(tx))
+			continue
+		}
+		res = append(res, tx)
+	}
+	return res
+}
+
+// setMessagesDelivered records the last inbound message included in the block in metadata saved with the block.
+// Messages dropped by the application (e.g. in PrepareProposal) and preceding it are not included later.
+func (m *Manager) setMessagesDelivered(data *types.Data, metadata map[string][]byte) {
+	if m.inbox == nil {
+		return
+	}
+	var last *types.InboundMessage
+	for _, tx := range data.Txs {
+		if !types.IsInboundMessageTx(tx) {
+			continue
+		}
+		msg, err := types.ParseInboundMessageTx(tx)
+		if err != nil {
+			continue
+		}
+		if last == nil || !msg.Before(last.SourceHeight, last.Index) {
+			last = &msg
+		}
+	}
+	if last == nil {
+		return
+	}
+	m.inbox.setDelivered(last.SourceHeight, last.Index)
+	value := make([]byte, 12)
+	binary.BigEndian.PutUint64(value, last.SourceHeight)
+	binary.BigEndian.PutUint32(value[8:], last.Index)
+	metadata[LastInboundMessageKey] = value
+}
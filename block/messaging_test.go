@@ -0,0 +1,85 @@
+package block
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+	goDATest "github.com/rollkit/go-da/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestRelayMessages(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	const source = "TestRelayMessagesSource"
+	sourceNamespace := []byte("source")
+
+	m := getManager(t, goDATest.NewDummyDA())
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m.store = store.New(kv)
+	m.genesis = &cmtypes.GenesisDoc{ChainID: "dest"}
+	m.isProposer = true
+	require.NoError(m.initMessaging(ctx))
+	_, err = m.RelayMessages(ctx, &types.MessageProof{})
+	require.ErrorIs(err, ErrMessagingDisabled)
+
+	// block of the source rollup, with a message to this rollup, included in DA
+	header, data, privKey := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 3, NTxs: 1}, source)
+	msgTx, err := types.NewOutboundMessageTx(types.OutboundMessage{Destination: "dest", Payload: []byte("hello")})
+	require.NoError(err)
+	data.Txs = append(data.Txs, msgTx)
+	header.DataHash = (&types.Data{Txs: data.Txs}).Hash()
+	signature, err := types.GetSignature(header.Header, privKey)
+	require.NoError(err)
+	header.Signature = *signature
+	sourceDALC := da.NewDAClient(m.dalc.DA, -1, -1, sourceNamespace, nil, m.logger)
+	res := sourceDALC.SubmitHeaders(ctx, []*types.SignedHeader{header}, 1<<20, -1)
+	require.Equal(da.StatusSuccess, res.Code)
+
+	m.conf.Messaging = config.MessagingConfig{
+		SourceChainID:      source,
+		SourceNamespace:    hex.EncodeToString(sourceNamespace),
+		SourceSequencerKey: hex.EncodeToString(privKey.PubKey().Bytes()),
+	}
+	require.NoError(m.initMessaging(ctx))
+
+	proof, err := types.NewMessageProof(res.DAHeight+1, header, data)
+	require.NoError(err)
+	_, err = m.RelayMessages(ctx, proof)
+	assert.ErrorIs(t, err, types.ErrInvalidMessageProof, "header is not included at DA height")
+
+	proof.DAHeight = res.DAHeight
+	expected := types.InboundMessage{SourceChainID: source, SourceHeight: 3, Index: 1, Payload: []byte("hello")}
+	queued, err := m.RelayMessages(ctx, proof)
+	require.NoError(err)
+	require.Equal([]types.InboundMessage{expected}, queued)
+	// relaying the same proof again doesn't queue messages again
+	queued, err = m.RelayMessages(ctx, proof)
+	require.NoError(err)
+	require.Empty(queued)
+
+	// pending messages are prepended to the block, inbound messages submitted by users are dropped
+	userTx := cmtypes.Tx("tx")
+	forged := cmtypes.Tx(types.NewInboundMessageTx(types.InboundMessage{SourceChainID: source, SourceHeight: 4}))
+	txs := m.withInboundMessages(cmtypes.Txs{forged, userTx})
+	require.Equal(cmtypes.Txs{cmtypes.Tx(types.NewInboundMessageTx(expected)), userTx}, txs)
+
+	// delivered messages are not included again, also after restart
+	metadata := make(map[string][]byte)
+	m.setMessagesDelivered(&types.Data{Txs: types.Txs{types.Tx(txs[0]), types.Tx(userTx)}}, metadata)
+	require.NoError(m.store.SetMetadata(ctx, LastInboundMessageKey, metadata[LastInboundMessageKey]))
+	require.Equal(cmtypes.Txs{userTx}, m.withInboundMessages(cmtypes.Txs{userTx}))
+	require.NoError(m.initMessaging(ctx))
+	queued, err = m.RelayMessages(ctx, proof)
+	require.NoError(err)
+	require.Empty(queued)
+}
@@ -26,6 +26,30 @@ type Metrics struct {
 	TotalTxs metrics.Gauge
 	// The latest block height.
 	CommittedHeight metrics.Gauge `metrics_name:"latest_block_height"`
+	// Latency of block production stages.
+	BlockProductionSeconds metrics.Histogram `metrics_labels:"stage"`
+	// Whether blocks are derived from DA only, because sequencer is down.
+	DAOnly metrics.Gauge
+	// Number of historical blocks re-verified in DA, by result.
+	DAVerifications metrics.Counter `metrics_labels:"result"`
+	// Number of blocks found unavailable in DA in the latest re-verification.
+	DAUnavailableBlocks metrics.Gauge
+	// Number of headers not submitted to DA again, because they were found already included.
+	DASkippedResubmissions metrics.Counter
+	// Number of blobs retrieved from DA and discarded as spam, by reason.
+	DADiscardedBlobs metrics.Counter `metrics_labels:"reason"`
+	// Number of transactions in batches returned by sequencer not matching submitted transactions, by kind.
+	SequencerBatchDiscrepancies metrics.Counter `metrics_labels:"kind"`
+	// Number of conflicting blocks signed by the sequencer at heights of stored blocks.
+	DoubleSigns metrics.Counter
+	// Balance of the DA account paying for submissions, in the smallest denomination.
+	DABalance metrics.Gauge
+	// Whether block production and DA submissions are throttled because of low DA account balance.
+	DAThrottled metrics.Gauge
+	// Latency between soft confirmation of blocks and their inclusion in DA, by unit (block or tx).
+	FinalitySeconds metrics.Histogram `metrics_labels:"unit"`
+	// Number of blocks included in DA later than finality SLA after soft confirmation.
+	FinalitySLAViolations metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -67,6 +91,80 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "latest_block_height",
 			Help:      "The latest block height.",
 		}, labels).With(labelsAndValues...),
+		BlockProductionSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_production_seconds",
+			Help:      "Latency of block production stages.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 15),
+		}, append(labels, "stage")).With(labelsAndValues...),
+		DAOnly: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_only",
+			Help:      "Whether blocks are derived from DA only, because sequencer is down.",
+		}, labels).With(labelsAndValues...),
+		DAVerifications: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_verifications",
+			Help:      "Number of historical blocks re-verified in DA, by result.",
+		}, append(labels, "result")).With(labelsAndValues...),
+		DAUnavailableBlocks: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_unavailable_blocks",
+			Help:      "Number of blocks found unavailable in DA in the latest re-verification.",
+		}, labels).With(labelsAndValues...),
+		DASkippedResubmissions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_skipped_resubmissions",
+			Help:      "Number of headers not submitted to DA again, because they were found already included.",
+		}, labels).With(labelsAndValues...),
+		DADiscardedBlobs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_discarded_blobs",
+			Help:      "Number of blobs retrieved from DA and discarded as spam, by reason.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		SequencerBatchDiscrepancies: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "batch_discrepancies",
+			Help:      "Number of transactions in batches returned by sequencer not matching submitted transactions, by kind.",
+		}, append(labels, "kind")).With(labelsAndValues...),
+		DoubleSigns: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "double_signs",
+			Help:      "Number of conflicting blocks signed by the sequencer at heights of stored blocks.",
+		}, labels).With(labelsAndValues...),
+		DABalance: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_balance",
+			Help:      "Balance of the DA account paying for submissions, in the smallest denomination.",
+		}, labels).With(labelsAndValues...),
+		DAThrottled: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_throttled",
+			Help:      "Whether block production and DA submissions are throttled because of low DA account balance.",
+		}, labels).With(labelsAndValues...),
+		FinalitySeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "finality_seconds",
+			Help:      "Latency between soft confirmation of blocks and their inclusion in DA, by unit (block or tx).",
+			Buckets:   stdprometheus.ExponentialBuckets(0.5, 2, 12),
+		}, append(labels, "unit")).With(labelsAndValues...),
+		FinalitySLAViolations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "finality_sla_violations",
+			Help:      "Number of blocks included in DA later than finality SLA after soft confirmation.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -78,5 +176,18 @@ func NopMetrics() *Metrics {
 		BlockSizeBytes:  discard.NewGauge(),
 		TotalTxs:        discard.NewGauge(),
 		CommittedHeight: discard.NewGauge(),
+
+		BlockProductionSeconds:      discard.NewHistogram(),
+		DAOnly:                      discard.NewGauge(),
+		DAVerifications:             discard.NewCounter(),
+		DAUnavailableBlocks:         discard.NewGauge(),
+		DASkippedResubmissions:      discard.NewCounter(),
+		DADiscardedBlobs:            discard.NewCounter(),
+		SequencerBatchDiscrepancies: discard.NewCounter(),
+		DoubleSigns:                 discard.NewCounter(),
+		DABalance:                   discard.NewGauge(),
+		DAThrottled:                 discard.NewGauge(),
+		FinalitySeconds:             discard.NewHistogram(),
+		FinalitySLAViolations:       discard.NewCounter(),
 	}
 }
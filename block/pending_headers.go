@@ -65,7 +65,7 @@ func (pb *PendingHeaders) getPendingHeaders(ctx context.Context) ([]*types.Signe
 
 	headers := make([]*types.SignedHeader, 0, height-lastSubmitted)
 	for i := lastSubmitted + 1; i <= height; i++ {
-		header, _, err := pb.store.GetBlockData(ctx, i)
+		header, err := pb.store.GetHeader(ctx, i)
 		if err != nil {
 			// return as much as possible + error information
 			return headers, err
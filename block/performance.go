@@ -0,0 +1,135 @@
+package block
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+)
+
+// Stages of block production, tracked by Manager and used as "stage" label of block production metrics.
+const (
+	// StageBatchFetch is fetching a batch of transactions from the sequencer.
+	StageBatchFetch = "batch_fetch"
+	// StageExecute is executing the block and committing it in the app.
+	StageExecute = "execute"
+	// StageSign is signing the block header.
+	StageSign = "sign"
+	// StageStore is persisting the block, block responses and state.
+	StageStore = "store"
+	// StageDASubmit is submitting pending headers to DA, including retries.
+	StageDASubmit = "da_submit"
+	// StageTotal is producing the whole block, excluding DA submission which is asynchronous.
+	StageTotal = "total"
+)
+
+// PerformanceWindow is the number of the latest samples per stage used to compute aggregates.
+const PerformanceWindow = 1000
+
+// StageStats contains aggregated latency of a block production stage.
+type StageStats struct {
+	// Count is the total number of samples, while latencies are computed from the latest samples only.
+	Count uint64
+	Avg   time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// performanceTracker keeps the latest latency samples of block production stages.
+type performanceTracker struct {
+	mtx     sync.Mutex
+	samples map[string][]time.Duration
+	next    map[string]int
+	count   map[string]uint64
+
+	// histogram observes all samples, with stage passed as label
+	histogram metrics.Histogram
+	label     string
+}
+
+func newPerformanceTracker(metrics *Metrics) *performanceTracker {
+	return newLatencyTracker(metrics.BlockProductionSeconds, "stage")
+}
+
+// newLatencyTracker creates tracker of latencies observed by histogram, with stage passed as given label.
+func newLatencyTracker(histogram metrics.Histogram, label string) *performanceTracker {
+	return &performanceTracker{
+		samples:   make(map[string][]time.Duration),
+		next:      make(map[string]int),
+		count:     make(map[string]uint64),
+		histogram: histogram,
+		label:     label,
+	}
+}
+
+// observe records latency of a stage.
+func (t *performanceTracker) observe(stage string, d time.Duration) {
+	t.histogram.With(t.label, stage).Observe(d.Seconds())
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.count[stage]++
+	if samples := t.samples[stage]; len(samples) < PerformanceWindow {
+		t.samples[stage] = append(samples, d)
+		return
+	}
+	// window is full, overwrite the oldest sample
+	t.samples[stage][t.next[stage]] = d
+	t.next[stage] = (t.next[stage] + 1) % PerformanceWindow
+}
+
+// since records latency of a stage started at given time.
+func (t *performanceTracker) since(stage string, start time.Time) {
+	t.observe(stage, time.Since(start))
+}
+
+// observeBlock records latencies of all stages of a produced block.
+func (t *performanceTracker) observeBlock(stages stageTimer) {
+	for stage, d := range stages {
+		t.observe(stage, d)
+	}
+}
+
+// stageTimer accumulates latencies of stages while producing a single block, as some stages are repeated.
+type stageTimer map[string]time.Duration
+
+// track adds latency of a stage started at given time.
+func (st stageTimer) track(stage string, start time.Time) {
+	st[stage] += time.Since(start)
+}
+
+// stats returns aggregated latencies of all observed stages.
+func (t *performanceTracker) stats() map[string]StageStats {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	stats := make(map[string]StageStats, len(t.samples))
+	for stage, samples := range t.samples {
+		sorted := slices.Clone(samples)
+		slices.Sort(sorted)
+		var sum time.Duration
+		for _, d := range sorted {
+			sum += d
+		}
+		stats[stage] = StageStats{
+			Count: t.count[stage],
+			Avg:   sum / time.Duration(len(sorted)),
+			P50:   percentile(sorted, 50),
+			P90:   percentile(sorted, 90),
+			P99:   percentile(sorted, 99),
+			Max:   sorted[len(sorted)-1],
+		}
+	}
+	return stats
+}
+
+// percentile returns p-th percentile of sorted, non-empty samples (nearest-rank method).
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
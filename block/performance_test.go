@@ -0,0 +1,41 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerformanceTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	tracker := newPerformanceTracker(NopMetrics())
+	assert.Empty(tracker.stats())
+
+	for i := 1; i <= 100; i++ {
+		tracker.observe(StageExecute, time.Duration(i)*time.Millisecond)
+	}
+	tracker.observeBlock(stageTimer{StageSign: time.Millisecond})
+
+	stats := tracker.stats()
+	assert.Len(stats, 2)
+	assert.Equal(StageStats{
+		Count: 100,
+		Avg:   50500 * time.Microsecond,
+		P50:   50 * time.Millisecond,
+		P90:   90 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+		Max:   100 * time.Millisecond,
+	}, stats[StageExecute])
+	assert.Equal(StageStats{Count: 1, Avg: time.Millisecond, P50: time.Millisecond, P90: time.Millisecond, P99: time.Millisecond, Max: time.Millisecond}, stats[StageSign])
+
+	// only the latest samples are used to compute latencies
+	for i := 0; i < PerformanceWindow; i++ {
+		tracker.observe(StageExecute, time.Second)
+	}
+	stats = tracker.stats()
+	assert.Equal(uint64(100+PerformanceWindow), stats[StageExecute].Count)
+	assert.Equal(time.Second, stats[StageExecute].P50)
+	assert.Equal(time.Second, stats[StageExecute].Avg)
+}
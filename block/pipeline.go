@@ -0,0 +1,102 @@
+package block
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// committedBlock is a block produced by publishBlock: executed, saved together with its responses and committed
+// in the app. Nothing in production of the next block depends on its finalization.
+type committedBlock struct {
+	header *types.SignedHeader
+	data   *types.Data
+	state  types.State
+	start  time.Time
+	timer  stageTimer
+}
+
+// saveState saves the state after a committed block.
+func (m *Manager) saveState(ctx context.Context, b *committedBlock) error {
+	storeStart := time.Now()
+	if err := m.store.UpdateState(ctx, b.state); err != nil {
+		return err
+	}
+	m.metrics.Height.Set(float64(b.state.LastBlockHeight))
+	b.timer.track(StageStore, storeStart)
+	return nil
+}
+
+// broadcastBlock records metrics of a committed block and publishes it for broadcasting to peers.
+func (m *Manager) broadcastBlock(ctx context.Context, b *committedBlock) error {
+	b.timer.track(StageTotal, b.start)
+	m.perf.observeBlock(b.timer)
+	m.recordMetrics(b.data)
+	// Check for shut down event prior to sending the header and block to
+	// their respective channels. The reason for checking for the shutdown
+	// event separately is due to the inconsistent nature of the select
+	// statement when multiple cases are satisfied.
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("unable to send header and block, context done: %w", ctx.Err())
+	default:
+	}
+
+	// Publish header to channel so that header exchange service can broadcast
+	m.HeaderCh <- b.header
+
+	// Publish block to channel so that block exchange service can broadcast
+	m.DataCh <- b.data
+
+	m.logger.Debug("successfully proposed header", "proposer", hex.EncodeToString(b.header.ProposerAddress), "height", b.header.Height())
+	return nil
+}
+
+// startFinalizer starts finalizing committed blocks in the background: saving the new state and broadcasting
+// blocks. Execution of the next block overlaps with finalization of the previous one, and DA submission is
+// asynchronous anyway, see HeaderSubmissionLoop. Returned function waits until all committed blocks are
+// finalized, it must be called after the last call to publishBlock.
+//
+// If the state can't be saved, block production is halted, like without pipelining: the error is returned by
+// publishBlock, which neither commits nor produces further blocks, and later blocks are not finalized. On restart,
+// the state of blocks committed in the app is re-derived from the saved blocks and their responses, see Handshake.
+func (m *Manager) startFinalizer(ctx context.Context) func() {
+	// channel is unbuffered, so at most one block is finalized while the next one is produced
+	m.finalizeCh = make(chan *committedBlock)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for b := range m.finalizeCh {
+			if err := m.finalizeError(); err != nil {
+				m.logger.Error("not finalizing block, saving state of previous block failed", "height", b.header.Height(), "error", err)
+				continue
+			}
+			// state is saved even after shutdown, so the store is in sync with the app
+			if err := m.saveState(ctx, b); err != nil {
+				err = fmt.Errorf("failed to save state after block %d: %w", b.header.Height(), err)
+				m.finalizeErr.Store(&err)
+				m.logger.Error("block production halted", "error", err)
+				continue
+			}
+			if err := m.broadcastBlock(ctx, b); err != nil && ctx.Err() == nil {
+				m.logger.Error("error while broadcasting block", "height", b.header.Height(), "error", err)
+			}
+		}
+	}()
+	return func() {
+		close(m.finalizeCh)
+		m.finalizeCh = nil
+		<-done
+	}
+}
+
+// finalizeError returns the error of the finalizer, if it failed to save the state.
+func (m *Manager) finalizeError() error {
+	if err := m.finalizeErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
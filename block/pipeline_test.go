@@ -0,0 +1,106 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestFinalizer(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m := &Manager{
+		store:    store.New(kv),
+		HeaderCh: make(chan *types.SignedHeader, 3),
+		DataCh:   make(chan *types.Data, 3),
+		logger:   test.NewLogger(t),
+		metrics:  NopMetrics(),
+		perf:     newPerformanceTracker(NopMetrics()),
+	}
+
+	stop := m.startFinalizer(ctx)
+	var headers []*types.SignedHeader
+	for height := uint64(1); height <= 3; height++ {
+		header, data := types.GetRandomBlock(height, 1, "TestFinalizer")
+		headers = append(headers, header)
+		s := types.State{LastBlockHeight: height, AppHash: []byte{byte(height)}}
+		m.finalizeCh <- &committedBlock{header: header, data: data, state: s, start: time.Now(), timer: stageTimer{}}
+	}
+	// all committed blocks are finalized when finalizer is stopped
+	stop()
+	assert.Nil(t, m.finalizeCh)
+
+	for height := uint64(1); height <= 3; height++ {
+		record, err := m.store.GetStateRecord(ctx, height)
+		require.NoError(err)
+		assert.Equal(t, []byte{byte(height)}, []byte(record.AppHash))
+	}
+	// blocks are broadcast in order
+	for _, header := range headers {
+		assert.Equal(t, header, <-m.HeaderCh)
+		<-m.DataCh
+	}
+	assert.Equal(t, uint64(3), m.perf.stats()[StageTotal].Count)
+}
+
+// failingStateStore fails to save state after block at given height.
+type failingStateStore struct {
+	store.Store
+	height uint64
+}
+
+func (s *failingStateStore) UpdateState(ctx context.Context, state types.State) error {
+	if state.LastBlockHeight == s.height {
+		return errors.New("disk failure")
+	}
+	return s.Store.UpdateState(ctx, state)
+}
+
+func TestFinalizerHalts(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m := &Manager{
+		store:      &failingStateStore{Store: store.New(kv), height: 2},
+		HeaderCh:   make(chan *types.SignedHeader, 3),
+		DataCh:     make(chan *types.Data, 3),
+		logger:     test.NewLogger(t),
+		metrics:    NopMetrics(),
+		perf:       newPerformanceTracker(NopMetrics()),
+		isProposer: true,
+	}
+
+	stop := m.startFinalizer(ctx)
+	for height := uint64(1); height <= 3; height++ {
+		header, data := types.GetRandomBlock(height, 1, "TestFinalizerHalts")
+		s := types.State{LastBlockHeight: height}
+		m.finalizeCh <- &committedBlock{header: header, data: data, state: s, start: time.Now(), timer: stageTimer{}}
+	}
+	stop()
+
+	// block production is halted after the state fails to be saved, blocks are not broadcast
+	require.ErrorContains(m.finalizeError(), "disk failure")
+	require.ErrorContains(m.publishBlock(ctx), "disk failure")
+	_, err = m.store.GetStateRecord(ctx, 1)
+	require.NoError(err)
+	for height := uint64(2); height <= 3; height++ {
+		_, err = m.store.GetStateRecord(ctx, height)
+		require.Error(err)
+	}
+	assert.Len(t, m.HeaderCh, 1)
+}
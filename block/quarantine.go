@@ -0,0 +1,123 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// QuarantineKey is the metadata key of blobs retrieved from DA which decode as signed headers, but failed
+// validation.
+const QuarantineKey = "quarantine"
+
+// MaxQuarantinedBlobs is the number of the latest quarantined blobs kept in store. Older blobs are dropped.
+const MaxQuarantinedBlobs = 100
+
+// Reasons of quarantining blobs.
+const (
+	// QuarantineSignature means that header is not signed by the sequencer, or its signature is invalid.
+	QuarantineSignature = "signature"
+	// QuarantineChainID means that header belongs to a different chain.
+	QuarantineChainID = "chain_id"
+	// QuarantineTime means that header time is invalid, e.g. after the time of DA block including it.
+	QuarantineTime = "time"
+)
+
+// QuarantinedBlob is a blob retrieved from DA, which decodes as a signed header, but failed validation. Such
+// blobs are posted by misconfigured sequencers (e.g. with a wrong key or chain ID), or by malicious posters.
+type QuarantinedBlob struct {
+	DAHeight uint64 `json:"da_height"`
+	// Height, Hash, ChainID and ProposerAddress are read from the header, so they can't be trusted.
+	Height          uint64           `json:"height"`
+	Hash            types.Hash       `json:"hash"`
+	ChainID         string           `json:"chain_id"`
+	ProposerAddress cmbytes.HexBytes `json:"proposer_address"`
+	Reason          string           `json:"reason"`
+	Error           string           `json:"error"`
+	// Blob is the encoded signed header.
+	Blob []byte `json:"blob"`
+	// Time is the local time of retrieval.
+	Time time.Time `json:"time"`
+}
+
+// newQuarantinedBlob describes the header retrieved from DA height that failed validation.
+func newQuarantinedBlob(daHeight uint64, header *types.SignedHeader, reason string, err error) QuarantinedBlob {
+	blob, _ := header.MarshalBinary()
+	return QuarantinedBlob{
+		DAHeight:        daHeight,
+		Height:          header.Height(),
+		Hash:            header.Hash(),
+		ChainID:         header.ChainID(),
+		ProposerAddress: header.ProposerAddress,
+		Reason:          reason,
+		Error:           err.Error(),
+		Blob:            blob,
+		Time:            time.Now(),
+	}
+}
+
+// quarantine logs and persists blobs which failed validation. Blobs already quarantined (e.g. retrieved again
+// after restart) are skipped. Failures are only logged, as quarantine is a diagnostic aid.
+func (m *Manager) quarantine(ctx context.Context, blobs []QuarantinedBlob) {
+	if len(blobs) == 0 {
+		return
+	}
+	for _, b := range blobs {
+		m.logger.Info("quarantining blob which failed validation", "daHeight", b.DAHeight, "headerHeight", b.Height,
+			"headerHash", b.Hash.String(), "reason", b.Reason, "error", b.Error)
+	}
+	quarantined, err := LoadQuarantine(ctx, m.store)
+	if err != nil {
+		m.logger.Error("failed to load quarantine", "error", err)
+		return
+	}
+	for _, b := range blobs {
+		if !isQuarantined(quarantined, b) {
+			quarantined = append(quarantined, b)
+		}
+	}
+	if len(quarantined) > MaxQuarantinedBlobs {
+		quarantined = quarantined[len(quarantined)-MaxQuarantinedBlobs:]
+	}
+	value, err := json.Marshal(quarantined)
+	if err != nil {
+		m.logger.Error("failed to marshal quarantine", "error", err)
+		return
+	}
+	if err := m.store.SetMetadata(ctx, QuarantineKey, value); err != nil {
+		m.logger.Error("failed to save quarantine", "error", err)
+	}
+}
+
+func isQuarantined(quarantined []QuarantinedBlob, b QuarantinedBlob) bool {
+	for _, q := range quarantined {
+		if q.DAHeight == b.DAHeight && q.Hash.String() == b.Hash.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadQuarantine returns quarantined blobs saved in store, oldest first.
+func LoadQuarantine(ctx context.Context, s store.Store) ([]QuarantinedBlob, error) {
+	value, err := s.GetMetadata(ctx, QuarantineKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var quarantined []QuarantinedBlob
+	if err := json.Unmarshal(value, &quarantined); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal quarantine: %w", err)
+	}
+	return quarantined, nil
+}
@@ -0,0 +1,88 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	goDA "github.com/rollkit/go-da"
+	goDAMock "github.com/rollkit/go-da/mocks"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestQuarantine(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	const chainID = "TestQuarantine"
+
+	genesis, privKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, chainID)
+	valid, _, _ := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 1, PrivKey: privKey}, chainID)
+	otherChain, _, _ := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 2, PrivKey: privKey}, "other")
+	otherSequencer, _ := types.GetRandomBlock(3, 0, chainID)
+	var blobs []goDA.Blob
+	for _, header := range []*types.SignedHeader{valid, otherChain, otherSequencer} {
+		blob, err := header.MarshalBinary()
+		require.NoError(err)
+		blobs = append(blobs, blob)
+	}
+
+	mockDA := new(goDAMock.MockDA)
+	ids := []goDA.ID{[]byte("id1"), []byte("id2"), []byte("id3")}
+	mockDA.On("GetIDs", mock.Anything, uint64(5), mock.Anything).Return(&goDA.GetIDsResult{IDs: ids, Timestamp: time.Now()}, nil)
+	mockDA.On("Get", mock.Anything, ids, mock.Anything).Return(blobs, nil)
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	logger := test.NewLogger(t)
+	m := &Manager{
+		genesis:     genesis,
+		store:       store.New(kv),
+		dalc:        da.NewDAClient(mockDA, -1, -1, nil, nil, logger),
+		headerCache: NewHeaderCache(),
+		headerInCh:  make(chan NewHeaderEvent, 3),
+		metrics:     NopMetrics(),
+		logger:      logger,
+		daHeight:    5,
+	}
+
+	height, err := m.processNextDAHeader(ctx)
+	require.NoError(err)
+	assert.Equal(t, uint64(1), height)
+	require.Len(m.headerInCh, 1)
+
+	quarantined, err := LoadQuarantine(ctx, m.store)
+	require.NoError(err)
+	require.Len(quarantined, 2)
+	assert.Equal(t, QuarantineChainID, quarantined[0].Reason)
+	assert.Equal(t, "other", quarantined[0].ChainID)
+	assert.Equal(t, uint64(5), quarantined[0].DAHeight)
+	assert.Equal(t, []byte(blobs[1]), quarantined[0].Blob)
+	assert.Equal(t, QuarantineSignature, quarantined[1].Reason)
+	assert.Equal(t, otherSequencer.Hash().String(), quarantined[1].Hash.String())
+
+	// blobs retrieved again are not duplicated
+	_, err = m.processNextDAHeader(ctx)
+	require.NoError(err)
+	quarantined, err = LoadQuarantine(ctx, m.store)
+	require.NoError(err)
+	require.Len(quarantined, 2)
+
+	// only the latest blobs are kept
+	more := make([]QuarantinedBlob, MaxQuarantinedBlobs)
+	for i := range more {
+		more[i] = newQuarantinedBlob(uint64(10+i), otherSequencer, QuarantineSignature, ErrNotProposer)
+	}
+	m.quarantine(ctx, more)
+	quarantined, err = LoadQuarantine(ctx, m.store)
+	require.NoError(err)
+	require.Len(quarantined, MaxQuarantinedBlobs)
+	assert.Equal(t, uint64(10), quarantined[0].DAHeight)
+}
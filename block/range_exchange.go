@@ -0,0 +1,166 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/celestiaorg/go-header"
+	goheaderp2p "github.com/celestiaorg/go-header/p2p"
+	p2p_pb "github.com/celestiaorg/go-header/p2p/pb"
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+
+	"github.com/rollkit/rollkit/p2p"
+)
+
+const (
+	// maxHeadersPerRangeRequest limits number of headers/blocks requested from single peer at once.
+	maxHeadersPerRangeRequest = 64
+
+	// rangeRequestTimeout limits duration of single range request.
+	rangeRequestTimeout = 8 * time.Second
+)
+
+// rangeExchange routes range requests of the syncer only to peers that advertised having the requested range.
+//
+// Other requests, and range requests that no peer advertised availability for (e.g. peers not supporting
+// status exchange), are handled by go-header Exchange.
+type rangeExchange[H header.Header[H]] struct {
+	*goheaderp2p.Exchange[H]
+
+	host       host.Host
+	p2p        *p2p.Client
+	store      string
+	protocolID protocol.ID
+	chainID    string
+
+	logger log.Logger
+}
+
+func newRangeExchange[H header.Header[H]](
+	ex *goheaderp2p.Exchange[H],
+	p2p *p2p.Client,
+	store, network, chainID string,
+	logger log.Logger,
+) *rangeExchange[H] {
+	return &rangeExchange[H]{
+		Exchange: ex,
+		host:     p2p.Host(),
+		p2p:      p2p,
+		store:    store,
+		// protocol ID used by go-header ExchangeServer
+		protocolID: protocol.ID(fmt.Sprintf("/%s/header-ex/v0.0.3", network)),
+		chainID:    chainID,
+		logger:     logger,
+	}
+}
+
+// GetRangeByHeight returns the range [from.Height()+1:to), requested from peers having it.
+func (ex *rangeExchange[H]) GetRangeByHeight(ctx context.Context, from H, to uint64) ([]H, error) {
+	if to <= from.Height()+1 {
+		return ex.Exchange.GetRangeByHeight(ctx, from, to)
+	}
+	peers := ex.p2p.PeersWithRange(ex.store, from.Height()+1, to-1)
+	if len(peers) == 0 {
+		return ex.Exchange.GetRangeByHeight(ctx, from, to)
+	}
+
+	result := make([]H, 0, to-from.Height()-1)
+	trusted := from
+	for i := 0; trusted.Height()+1 < to; {
+		amount := min(to-trusted.Height()-1, maxHeadersPerRangeRequest)
+		p := peers[i%len(peers)]
+		headers, err := ex.request(ctx, p, trusted, amount)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			ex.logger.Debug("range request failed", "peer", p, "from", trusted.Height()+1, "amount", amount, "error", err)
+			peers = append(peers[:i%len(peers)], peers[i%len(peers)+1:]...)
+			if len(peers) == 0 {
+				// none of the advertising peers delivered, fall back to regular exchange
+				rest, err := ex.Exchange.GetRangeByHeight(ctx, trusted, to)
+				if err != nil {
+					return nil, err
+				}
+				return append(result, rest...), nil
+			}
+			continue
+		}
+		result = append(result, headers...)
+		trusted = headers[len(headers)-1]
+		i++
+	}
+	return result, nil
+}
+
+// request fetches up to amount headers/blocks following trusted from given peer, and verifies them.
+func (ex *rangeExchange[H]) request(ctx context.Context, p peer.ID, trusted H, amount uint64) ([]H, error) {
+	ctx, cancel := context.WithTimeout(ctx, rangeRequestTimeout)
+	defer cancel()
+	stream, err := ex.host.NewStream(ctx, p, ex.protocolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a new stream: %w", err)
+	}
+	defer stream.Close() //nolint:errcheck
+	if dl, ok := ctx.Deadline(); ok {
+		if err := stream.SetDeadline(dl); err != nil {
+			ex.logger.Debug("error setting stream deadline", "error", err)
+		}
+	}
+
+	req := &p2p_pb.HeaderRequest{
+		Data:   &p2p_pb.HeaderRequest_Origin{Origin: trusted.Height() + 1},
+		Amount: amount,
+	}
+	if _, err := serde.Write(stream, req); err != nil {
+		stream.Reset() //nolint:errcheck
+		return nil, fmt.Errorf("failed to write a request: %w", err)
+	}
+	if err := stream.CloseWrite(); err != nil {
+		return nil, err
+	}
+
+	headers := make([]H, 0, amount)
+	for uint64(len(headers)) < amount {
+		resp := new(p2p_pb.HeaderResponse)
+		if _, err := serde.Read(stream, resp); err != nil {
+			// peer closes the stream if it has only a part of the requested range
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			stream.Reset() //nolint:errcheck
+			return nil, fmt.Errorf("failed to read a response: %w", err)
+		}
+		if resp.StatusCode != p2p_pb.StatusCode_OK {
+			break
+		}
+		var empty H
+		untrusted := empty.New()
+		if err := untrusted.UnmarshalBinary(resp.Body); err != nil {
+			return nil, err
+		}
+		if untrusted.ChainID() != ex.chainID {
+			return nil, fmt.Errorf("received header/block with different chain ID: %s", untrusted.ChainID())
+		}
+		if untrusted.Height() != trusted.Height()+1 {
+			return nil, fmt.Errorf("peer sent non-adjacent header/block. expected:%d, received:%d", trusted.Height()+1, untrusted.Height())
+		}
+		if err := trusted.Verify(untrusted); err != nil {
+			return nil, err
+		}
+		headers = append(headers, untrusted)
+		trusted = untrusted
+	}
+	if len(headers) == 0 {
+		return nil, header.ErrNotFound
+	}
+	return headers, nil
+}
@@ -0,0 +1,117 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/store"
+)
+
+// PendingBatchesKey is the metadata key of batches retrieved from the sequencer, but not included in blocks
+// yet, persisted on graceful shutdown.
+const PendingBatchesKey = "pending batches"
+
+// LastAppliedBatchHashKey is the metadata key of the hash of the last non-empty batch saved in a block.
+const LastAppliedBatchHashKey = "last applied batch hash"
+
+// PersistBatchQueue saves batches retrieved from the sequencer, but not included in blocks yet, so they are
+// not lost on graceful shutdown. It must be called after BatchRetrieveLoop and AggregationLoop are stopped.
+// Batches are restored by RestoreBatchQueue.
+func (m *Manager) PersistBatchQueue(ctx context.Context) error {
+	batches := m.bq.Drain()
+	if len(batches) == 0 {
+		return nil
+	}
+	blob, err := encodeBatches(batches)
+	if err != nil {
+		return fmt.Errorf("failed to encode batches: %w", err)
+	}
+	if err := m.store.SetMetadata(ctx, PendingBatchesKey, blob); err != nil {
+		return err
+	}
+	m.logger.Info("persisted batch queue", "batches", len(batches))
+	return nil
+}
+
+// RestoreBatchQueue adds batches persisted by PersistBatchQueue to the batch queue. Persisted batches are
+// removed from the store, so they are restored only once.
+func (m *Manager) RestoreBatchQueue(ctx context.Context) error {
+	blob, err := m.store.GetMetadata(ctx, PendingBatchesKey)
+	if errors.Is(err, ds.ErrNotFound) || len(blob) == 0 {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	batches, err := decodeBatches(blob)
+	if err != nil {
+		return fmt.Errorf("failed to decode persisted batches: %w", err)
+	}
+	for _, batch := range batches {
+		m.bq.AddBatch(batch)
+	}
+	m.logger.Info("restored batch queue", "batches", len(batches))
+	return m.store.SetMetadata(ctx, PendingBatchesKey, nil)
+}
+
+// RecoverBatches is called after unclean shutdown, when batches retrieved from the sequencer, but not saved
+// in blocks, were lost with the batch queue. Batches following the last batch saved in a block are requested
+// from the sequencer again. If sequencer refuses to resend them, retrieval continues after the last retrieved
+// batch. It must be called before BatchRetrieveLoop is started.
+func (m *Manager) RecoverBatches(ctx context.Context) error {
+	applied, err := m.store.GetMetadata(ctx, LastAppliedBatchHashKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(applied, m.lastBatchHash) {
+		return nil
+	}
+	m.logger.Info("re-requesting batches lost on unclean shutdown", "lastAppliedBatch", fmt.Sprintf("%X", applied), "lastBatch", fmt.Sprintf("%X", m.lastBatchHash))
+	m.resendFallbackHash = m.lastBatchHash
+	m.lastBatchHash = applied
+	return nil
+}
+
+// CheckIntegrity checks consistency of the store after unclean shutdown: height and state must match, and
+// the last block must be readable. Block saved, but not applied yet, is allowed, as it's applied on start.
+func (m *Manager) CheckIntegrity(ctx context.Context) error {
+	height := m.store.Height()
+	s, err := m.store.GetState(ctx)
+	if errors.Is(err, ds.ErrNotFound) {
+		if height != 0 {
+			return fmt.Errorf("state not found, but store height is %d", height)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if height < s.LastBlockHeight || height > s.LastBlockHeight+1 {
+		return fmt.Errorf("store height %d doesn't match state height %d", height, s.LastBlockHeight)
+	}
+	// no blocks were produced yet; chain may start at initial height greater than 1
+	if height < max(s.InitialHeight, 1) {
+		return nil
+	}
+	header, err := m.store.GetHeader(ctx, height)
+	if err != nil {
+		return fmt.Errorf("failed to load header %d: %w", height, err)
+	}
+	if header.Height() != height {
+		return fmt.Errorf("header at height %d has height %d", height, header.Height())
+	}
+	if _, _, err := m.store.GetBlockData(ctx, height); err != nil && !errors.Is(err, store.ErrBlockDataPruned) {
+		return fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	if _, err := m.store.GetSignature(ctx, height); err != nil {
+		return fmt.Errorf("failed to load signature %d: %w", height, err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/go-sequencing"
+
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestPersistBatchQueue(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := store.New(kv)
+	newManager := func() *Manager {
+		return &Manager{store: s, bq: NewBatchQueue(), logger: test.NewLogger(t)}
+	}
+
+	m := newManager()
+	batch3 := BatchWithTime{&sequencing.Batch{}, time.Unix(0, 1700000000000000000)}
+	m.bq.AddBatch(batch1)
+	m.bq.AddBatch(batch2)
+	m.bq.AddBatch(batch3)
+	require.NoError(m.PersistBatchQueue(ctx))
+	require.Nil(m.bq.Peek())
+
+	m = newManager()
+	require.NoError(m.RestoreBatchQueue(ctx))
+	for _, expected := range []BatchWithTime{batch1, batch2, batch3} {
+		batch := m.bq.Next()
+		require.NotNil(batch)
+		assert.Equal(t, expected.Transactions, batch.Transactions)
+		assert.True(t, expected.Time.Equal(batch.Time))
+	}
+	require.Nil(m.bq.Next())
+
+	// batches are restored only once
+	m = newManager()
+	require.NoError(m.RestoreBatchQueue(ctx))
+	require.Nil(m.bq.Peek())
+}
+
+func TestRecoverBatches(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m := &Manager{store: store.New(kv), bq: NewBatchQueue(), lastBatchHash: []byte("retrieved"), logger: test.NewLogger(t)}
+
+	// nothing to recover before the first block
+	require.NoError(m.RecoverBatches(ctx))
+	require.Equal([]byte("retrieved"), m.lastBatchHash)
+
+	// batch is saved in a block
+	m.bq.AddBatch(batch1)
+	_, _, err = m.getTxsFromBatch()
+	require.NoError(err)
+	applied, err := batch1.Hash()
+	require.NoError(err)
+	require.Equal(applied, m.appliedBatchHash)
+	require.NoError(m.store.SetMetadata(ctx, LastAppliedBatchHashKey, m.appliedBatchHash))
+
+	require.NoError(m.RecoverBatches(ctx))
+	require.Equal(applied, m.lastBatchHash)
+	require.Equal([]byte("retrieved"), m.resendFallbackHash)
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	m := &Manager{store: store.New(kv), logger: test.NewLogger(t)}
+	require.NoError(m.CheckIntegrity(ctx))
+
+	header, data, privKey := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 1, NTxs: 2}, "TestCheckIntegrity")
+	require.NoError(m.store.SaveBlockData(ctx, header, data, &header.Signature))
+	require.NoError(m.store.UpdateState(ctx, types.State{LastBlockHeight: 1, Validators: header.Validators, NextValidators: header.Validators, LastValidators: header.Validators}))
+	// block is not found
+	require.Error(m.CheckIntegrity(ctx))
+
+	m.store.SetHeight(ctx, 1)
+	require.NoError(m.CheckIntegrity(ctx))
+
+	// block saved, but not applied yet
+	next, nextData := types.GetRandomNextBlock(header, data, privKey, nil, 0, "TestCheckIntegrity")
+	m.store.SetHeight(ctx, 2)
+	require.Error(m.CheckIntegrity(ctx))
+	require.NoError(m.store.SaveBlockData(ctx, next, nextData, &types.Signature{}))
+	require.NoError(m.CheckIntegrity(ctx))
+
+	m.store.SetHeight(ctx, 3)
+	require.Error(m.CheckIntegrity(ctx))
+}
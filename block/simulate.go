@@ -0,0 +1,86 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// Sources of transactions of simulated block.
+const (
+	SimulationSourceBatch   = "batch"
+	SimulationSourcePending = "pending"
+)
+
+// SimulatedBlock is a block built by SimulateBlock. It's neither executed, nor signed.
+type SimulatedBlock struct {
+	Header *types.SignedHeader
+	Data   *types.Data
+	// Source is SimulationSourceBatch if transactions were taken from the next batch, or
+	// SimulationSourcePending if no batch was queued.
+	Source string
+}
+
+// SimulateBlock builds the block that would be produced next, without executing, signing or storing it.
+//
+// Transactions are taken from the next batch received from the sequencer, which is not consumed. If no
+// batch is queued, given pending transactions (e.g. from mempool) are used instead. Like when producing
+// a block, transactions are passed to PrepareProposal; FinalizeBlock is not called.
+func (m *Manager) SimulateBlock(ctx context.Context, pending cmtypes.Txs) (*SimulatedBlock, error) {
+	if !m.isProposer {
+		return nil, ErrNotProposer
+	}
+
+	var (
+		lastSignature  *types.Signature
+		lastHeaderHash types.Hash
+		lastHeaderTime time.Time
+		err            error
+	)
+	height := m.store.Height()
+	newHeight := height + 1
+	if newHeight == uint64(m.genesis.InitialHeight) { //nolint:gosec
+		lastSignature = &types.Signature{}
+	} else {
+		lastSignature, err = m.store.GetSignature(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("error while loading last commit: %w", err)
+		}
+		lastHeader, _, err := m.store.GetBlockData(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("error while loading last block: %w", err)
+		}
+		lastHeaderHash = lastHeader.Hash()
+		lastHeaderTime = lastHeader.Time()
+	}
+	extendedCommit, err := m.getExtendedCommit(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load extended commit for height %d: %w", height, err)
+	}
+
+	txs, timestamp, source := pending, time.Now(), SimulationSourcePending
+	if batch := m.bq.Peek(); batch != nil {
+		txs = make(cmtypes.Txs, 0, len(batch.Transactions))
+		for _, tx := range batch.Transactions {
+			txs = append(txs, tx)
+		}
+		timestamp, source = batch.Time, SimulationSourceBatch
+	}
+	blockTime, err := m.blockTime(timestamp, lastHeaderTime)
+	if err != nil {
+		return nil, err
+	}
+
+	header, data, err := m.createBlock(ctx, newHeight, lastSignature, lastHeaderHash, extendedCommit, txs, blockTime)
+	if err != nil {
+		return nil, err
+	}
+	header.DataHash = data.Hash()
+	header.Validators = m.getLastStateValidators()
+	header.ValidatorHash = header.Validators.Hash()
+	return &SimulatedBlock{Header: header, Data: data, Source: source}, nil
+}
@@ -0,0 +1,26 @@
+package block
+
+import (
+	"fmt"
+
+	goheaderp2p "github.com/celestiaorg/go-header/p2p"
+
+	"github.com/rollkit/rollkit/p2p"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// storeHeaders is the suffix of network ID of the exchange server serving headers of stored blocks.
+const storeHeaders = "storeHeaders"
+
+// NewStoreHeaderServer returns go-header ExchangeServer serving headers of all blocks in the store, including
+// blocks synced from DA, which are missing in the store of header sync service. Headers are served with
+// network ID "<chain ID>-storeHeaders", so go-header clients (e.g. Exchange of light clients) can request them
+// with the standard protocol. P2P client must be started.
+func NewStoreHeaderServer(p2p *p2p.Client, s store.Store) (*goheaderp2p.ExchangeServer[*types.SignedHeader], error) {
+	_, _, network, err := p2p.Info()
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching the network: %w", err)
+	}
+	return newP2PServer[*types.SignedHeader](p2p.Host(), store.NewHeaderStore(s), network+"-"+storeHeaders)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"github.com/cometbft/cometbft/libs/log"
 	cmtypes "github.com/cometbft/cometbft/types"
@@ -40,15 +41,22 @@ type SyncService[H header.Header[H]] struct {
 	conf      config.NodeConfig
 	genesis   *cmtypes.GenesisDoc
 	p2p       *p2p.Client
-	ex        *goheaderp2p.Exchange[H]
+	ex        *rangeExchange[H]
 	sub       *goheaderp2p.Subscriber[H]
 	p2pServer *goheaderp2p.ExchangeServer[H]
 	store     *goheaderstore.Store[H]
 	syncType  syncType
 
+	// prunerDS is the datastore of the store, used to prune headers of light nodes
+	prunerDS    ds.Batching
+	cancelPrune context.CancelFunc
+
 	syncer       *goheadersync.Syncer[H]
 	syncerStatus *SyncerStatus
 
+	// earliest is the lowest height available in the store, advertised to peers
+	earliest atomic.Uint64
+
 	logger log.Logger
 }
 
@@ -90,15 +98,18 @@ func newSyncService[H header.Header[H]](store ds.TxnDatastore, syncType syncType
 		return nil, fmt.Errorf("failed to initialize the %s store: %w", syncType, err)
 	}
 
-	return &SyncService[H]{
+	syncService := &SyncService[H]{
 		conf:         conf,
 		genesis:      genesis,
 		p2p:          p2p,
 		store:        ss,
 		syncType:     syncType,
+		prunerDS:     newPrunerDatastore(storeBatch, syncType),
 		logger:       logger,
 		syncerStatus: new(SyncerStatus),
-	}, nil
+	}
+	p2p.SetStatusSource(string(syncType), syncService.storedRange)
+	return syncService, nil
 }
 
 // Store returns the store of the SyncService
@@ -106,6 +117,16 @@ func (syncService *SyncService[H]) Store() *goheaderstore.Store[H] {
 	return syncService.store
 }
 
+// FetchByHeight requests the header/block at given height from peers, e.g. to backfill heights below the
+// lowest height in the store. Returned header/block is not verified.
+func (syncService *SyncService[H]) FetchByHeight(ctx context.Context, height uint64) (H, error) {
+	if syncService.ex == nil {
+		var zero H
+		return zero, errors.New("sync service is not started")
+	}
+	return syncService.ex.GetByHeight(ctx, height)
+}
+
 func (syncService *SyncService[H]) initStoreAndStartSyncer(ctx context.Context, initial H) error {
 	if initial.IsZero() {
 		return errors.New("failed to initialize the store and start syncer")
@@ -113,6 +134,7 @@ func (syncService *SyncService[H]) initStoreAndStartSyncer(ctx context.Context,
 	if err := syncService.store.Init(ctx, initial); err != nil {
 		return err
 	}
+	syncService.earliest.Store(initial.Height())
 	if err := syncService.StartSyncer(ctx); err != nil {
 		return err
 	}
@@ -131,6 +153,7 @@ func (syncService *SyncService[H]) WriteToStoreAndBroadcast(ctx context.Context,
 		if err := syncService.store.Init(ctx, headerOrData); err != nil {
 			return errors.New("failed to initialize the store")
 		}
+		syncService.earliest.Store(headerOrData.Height())
 	}
 
 	firstStart := false
@@ -173,6 +196,12 @@ func (syncService *SyncService[H]) Start(ctx context.Context) error {
 		return err
 	}
 
+	if syncService.pruningEnabled() {
+		var pruneCtx context.Context
+		pruneCtx, syncService.cancelPrune = context.WithCancel(ctx)
+		go syncService.pruneLoop(pruneCtx)
+	}
+
 	return syncService.setFirstAndStart(ctx, peerIDs)
 }
 
@@ -184,7 +213,7 @@ func (syncService *SyncService[H]) setupP2P(ctx context.Context) ([]peer.ID, err
 	syncService.sub, err = goheaderp2p.NewSubscriber[H](
 		ps,
 		pubsub.DefaultMsgIdFn,
-		goheaderp2p.WithSubscriberNetworkID(syncService.getChainID()),
+		goheaderp2p.WithSubscriberNetworkID(syncService.getGossipNetworkID()),
 		goheaderp2p.WithSubscriberMetrics(),
 	)
 	if err != nil {
@@ -200,6 +229,9 @@ func (syncService *SyncService[H]) setupP2P(ctx context.Context) ([]peer.ID, err
 	if err := syncService.store.Start(ctx); err != nil {
 		return nil, fmt.Errorf("error while starting store: %w", err)
 	}
+	if syncService.isInitialized() {
+		syncService.earliest.Store(syncService.findEarliest(ctx))
+	}
 
 	_, _, network, err := syncService.p2p.Info()
 	if err != nil {
@@ -215,9 +247,11 @@ func (syncService *SyncService[H]) setupP2P(ctx context.Context) ([]peer.ID, err
 	}
 
 	peerIDs := syncService.getPeerIDs()
-	if syncService.ex, err = newP2PExchange[H](syncService.p2p.Host(), peerIDs, networkID, syncService.genesis.ChainID, syncService.p2p.ConnectionGater()); err != nil {
+	ex, err := newP2PExchange[H](syncService.p2p.Host(), peerIDs, networkID, syncService.genesis.ChainID, syncService.p2p.ConnectionGater())
+	if err != nil {
 		return nil, fmt.Errorf("error while creating exchange: %w", err)
 	}
+	syncService.ex = newRangeExchange(ex, syncService.p2p, string(syncService.syncType), networkID, syncService.genesis.ChainID, syncService.logger)
 	if err := syncService.ex.Start(ctx); err != nil {
 		return nil, fmt.Errorf("error while starting exchange: %w", err)
 	}
@@ -233,8 +267,8 @@ func (syncService *SyncService[H]) prepareSyncer(ctx context.Context) error {
 	if syncService.syncer, err = newSyncer[H](
 		syncService.ex,
 		syncService.store,
-		syncService.sub,
-		[]goheadersync.Option{goheadersync.WithBlockTime(syncService.conf.BlockTime)},
+		&chainSubscriber[H]{Subscriber: syncService.sub, chainID: syncService.genesis.ChainID},
+		syncService.syncerOptions(),
 	); err != nil {
 		return nil
 	}
@@ -248,8 +282,19 @@ func (syncService *SyncService[H]) prepareSyncer(ctx context.Context) error {
 	return err
 }
 
+// syncerOptions returns options of the syncer set in node configuration.
+func (syncService *SyncService[H]) syncerOptions() []goheadersync.Option {
+	opts := []goheadersync.Option{goheadersync.WithBlockTime(syncService.conf.BlockTime)}
+	// zero trusting period keeps the go-header default
+	if syncService.conf.TrustingPeriod > 0 {
+		opts = append(opts, goheadersync.WithTrustingPeriod(syncService.conf.TrustingPeriod))
+	}
+	return opts
+}
+
 // setFirstAndStart looks up for the trusted hash or the genesis header/block.
-// If trusted hash is available, it fetches the trusted header/block (by hash) from peers.
+// If trusted hash is available, it fetches the trusted header/block (by hash) from peers. Block data of node
+// starting from trusted height is fetched by height.
 // Otherwise, it tries to fetch the genesis header/block by height.
 // If trusted header/block is available, syncer is started.
 func (syncService *SyncService[H]) setFirstAndStart(ctx context.Context, peerIDs []peer.ID) error {
@@ -257,7 +302,13 @@ func (syncService *SyncService[H]) setFirstAndStart(ctx context.Context, peerIDs
 	var trusted H
 	// Try fetching the trusted header/block from peers if exists
 	if len(peerIDs) > 0 {
-		if syncService.conf.TrustedHash != "" {
+		if syncService.syncType == dataSync && syncService.conf.TrustedHeight > 0 {
+			// trusted hash identifies the header; block data is verified against it before the block is applied
+			var err error
+			if trusted, err = syncService.ex.GetByHeight(ctx, syncService.conf.TrustedHeight); err != nil {
+				return fmt.Errorf("failed to fetch the trusted block for initializing the store: %w", err)
+			}
+		} else if syncService.conf.TrustedHash != "" {
 			trustedHashBytes, err := hex.DecodeString(syncService.conf.TrustedHash)
 			if err != nil {
 				return fmt.Errorf("failed to parse the trusted hash for initializing the store: %w", err)
@@ -285,6 +336,9 @@ func (syncService *SyncService[H]) setFirstAndStart(ctx context.Context, peerIDs
 //
 // `store` is closed last because it's used by other services.
 func (syncService *SyncService[H]) Stop(ctx context.Context) error {
+	if syncService.cancelPrune != nil {
+		syncService.cancelPrune()
+	}
 	err := errors.Join(
 		syncService.p2pServer.Stop(ctx),
 		syncService.ex.Stop(ctx),
@@ -300,7 +354,7 @@ func (syncService *SyncService[H]) Stop(ctx context.Context) error {
 // newP2PServer constructs a new ExchangeServer using the given Network as a protocolID suffix.
 func newP2PServer[H header.Header[H]](
 	host host.Host,
-	store *goheaderstore.Store[H],
+	store header.Store[H],
 	network string,
 	opts ...goheaderp2p.Option[goheaderp2p.ServerParameters],
 ) (*goheaderp2p.ExchangeServer[H], error) {
@@ -352,12 +406,44 @@ func (syncService *SyncService[H]) StartSyncer(ctx context.Context) error {
 	return nil
 }
 
+// storedRange returns the range of heights available in the store.
+func (syncService *SyncService[H]) storedRange() p2p.HeightRange {
+	if !syncService.isInitialized() {
+		return p2p.HeightRange{}
+	}
+	return p2p.HeightRange{Earliest: syncService.earliest.Load(), Latest: syncService.store.Height()}
+}
+
+// findEarliest looks up the lowest height available in the store. Stored heights are contiguous,
+// starting from genesis or trusted header/block, so binary search is used. If headers were pruned,
+// heights are contiguous above the pruned height only (checkpoints are kept below it).
+func (syncService *SyncService[H]) findEarliest(ctx context.Context) uint64 {
+	pruned, err := syncService.loadPrunedHeight(ctx)
+	if err != nil {
+		syncService.logger.Error("failed to load pruned height", "error", err)
+	}
+	if pruned > 0 {
+		return pruned + 1
+	}
+	low, high := uint64(max(syncService.genesis.InitialHeight, 1)), syncService.store.Height()
+	for low < high {
+		mid := low + (high-low)/2
+		if _, err := syncService.store.GetByHeight(ctx, mid); err == nil {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low
+}
+
 func (syncService *SyncService[H]) getNetworkID(network string) string {
 	return network + "-" + string(syncService.syncType)
 }
 
-func (syncService *SyncService[H]) getChainID() string {
-	return syncService.genesis.ChainID + "-" + string(syncService.syncType)
+// getGossipNetworkID returns network ID of the gossip topic, bound to chain ID and genesis hash.
+func (syncService *SyncService[H]) getGossipNetworkID() string {
+	return syncService.p2p.GossipNamespace() + "-" + string(syncService.syncType)
 }
 
 func (syncService *SyncService[H]) getPeerIDs() []peer.ID {
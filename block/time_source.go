@@ -0,0 +1,98 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+var (
+	// ErrNoDATime is returned when block time is taken from DA, but no DA block was observed yet.
+	ErrNoDATime = errors.New("no DA block time observed yet")
+
+	// ErrInvalidBlockTime is returned when time of a block violates block time rules.
+	ErrInvalidBlockTime = errors.New("invalid block time")
+
+	// ErrBlockTimeInFuture is returned when time of synced block is too far ahead of local clock.
+	ErrBlockTimeInFuture = errors.New("block time too far in the future")
+)
+
+// validateTimeSource returns an error if block time source is not known or not supported.
+func validateTimeSource(source string) error {
+	switch source {
+	case config.TimeSourceSequencer, config.TimeSourceDA:
+		return nil
+	default:
+		return fmt.Errorf("unknown block time source: %q", source)
+	}
+}
+
+// observeDATime records time of a DA block retrieved by RetrieveLoop. Observed time never decreases.
+func (m *Manager) observeDATime(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	for {
+		current := m.daTime.Load()
+		if t.UnixNano() <= current || m.daTime.CompareAndSwap(current, t.UnixNano()) {
+			return
+		}
+	}
+}
+
+// lastDATime returns time of the latest DA block observed, or zero time if no DA block was observed yet.
+func (m *Manager) lastDATime() time.Time {
+	nanos := m.daTime.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// blockTime returns time of a new block according to configured time source.
+// batchTime is the time reported by the sequencer with the batch of transactions.
+// If block time is taken from DA, caller must ensure that a DA block was observed.
+func (m *Manager) blockTime(batchTime time.Time, lastBlockTime time.Time) (time.Time, error) {
+	if m.conf.BlockTimeSource != config.TimeSourceDA {
+		if batchTime.Before(lastBlockTime) {
+			return time.Time{}, fmt.Errorf("%w: timestamp is not monotonically increasing: %s < %s", ErrInvalidBlockTime, batchTime, lastBlockTime)
+		}
+		return batchTime, nil
+	}
+
+	daTime := m.lastDATime()
+	// after restart, RetrieveLoop may not have caught up with DA blocks observed before
+	if daTime.Before(lastBlockTime) {
+		return lastBlockTime, nil
+	}
+	return daTime, nil
+}
+
+// validateBlockTime checks time of a synced block against time of the previous block and local clock.
+func (m *Manager) validateBlockTime(header *types.SignedHeader, lastBlockTime time.Time) error {
+	blockTime := header.Time()
+	if blockTime.Before(lastBlockTime) {
+		return fmt.Errorf("%w: block time %s is before previous block time %s", ErrInvalidBlockTime, blockTime, lastBlockTime)
+	}
+	if m.conf.MaxClockDrift != 0 {
+		if limit := time.Now().Add(m.conf.MaxClockDrift); blockTime.After(limit) {
+			return fmt.Errorf("%w: block time %s is after %s", ErrBlockTimeInFuture, blockTime, limit)
+		}
+	}
+	return nil
+}
+
+// validateDAInclusionTime checks that header taken from DA block with given time is not later than this block.
+// When block time is taken from DA, sequencer can only use time of DA blocks that precede the one including the header.
+func (m *Manager) validateDAInclusionTime(header *types.SignedHeader, daTime time.Time) error {
+	if m.conf.BlockTimeSource != config.TimeSourceDA || daTime.IsZero() {
+		return nil
+	}
+	if header.Time().After(daTime) {
+		return fmt.Errorf("%w: block time %s is after time %s of DA block including it", ErrInvalidBlockTime, header.Time(), daTime)
+	}
+	return nil
+}
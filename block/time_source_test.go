@@ -0,0 +1,75 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestValidateTimeSource(t *testing.T) {
+	require := require.New(t)
+	require.NoError(validateTimeSource(config.TimeSourceSequencer))
+	require.NoError(validateTimeSource(config.TimeSourceDA))
+	require.Error(validateTimeSource("attester_median"))
+	require.ErrorContains(validateTimeSource("bft"), "unknown block time source")
+}
+
+func TestBlockTime(t *testing.T) {
+	require := require.New(t)
+	lastBlockTime := time.Unix(1700000000, 0)
+	batchTime := lastBlockTime.Add(time.Second)
+
+	m := &Manager{conf: config.BlockManagerConfig{BlockTimeSource: config.TimeSourceSequencer}}
+	blockTime, err := m.blockTime(batchTime, lastBlockTime)
+	require.NoError(err)
+	require.Equal(batchTime, blockTime)
+	_, err = m.blockTime(lastBlockTime.Add(-time.Second), lastBlockTime)
+	require.ErrorIs(err, ErrInvalidBlockTime)
+
+	m.conf.BlockTimeSource = config.TimeSourceDA
+	require.True(m.lastDATime().IsZero())
+	daTime := lastBlockTime.Add(5 * time.Second)
+	m.observeDATime(daTime)
+	// observed DA time never decreases
+	m.observeDATime(lastBlockTime)
+	m.observeDATime(time.Time{})
+	require.True(daTime.Equal(m.lastDATime()))
+	blockTime, err = m.blockTime(batchTime, lastBlockTime)
+	require.NoError(err)
+	require.True(daTime.Equal(blockTime))
+
+	// block time doesn't decrease, even if DA time observed is older than previous block
+	blockTime, err = m.blockTime(batchTime, daTime.Add(time.Second))
+	require.NoError(err)
+	require.True(daTime.Add(time.Second).Equal(blockTime))
+}
+
+func TestValidateBlockTime(t *testing.T) {
+	require := require.New(t)
+	lastBlockTime := time.Now()
+	header := func(blockTime time.Time) *types.SignedHeader {
+		h := new(types.SignedHeader)
+		h.BaseHeader.Time = uint64(blockTime.UnixNano()) //nolint:gosec
+		return h
+	}
+
+	m := &Manager{conf: config.BlockManagerConfig{MaxClockDrift: time.Minute}}
+	require.NoError(m.validateBlockTime(header(lastBlockTime), lastBlockTime))
+	require.NoError(m.validateBlockTime(header(lastBlockTime.Add(time.Second)), lastBlockTime))
+	require.ErrorIs(m.validateBlockTime(header(lastBlockTime.Add(-time.Second)), lastBlockTime), ErrInvalidBlockTime)
+	require.ErrorIs(m.validateBlockTime(header(lastBlockTime.Add(time.Hour)), lastBlockTime), ErrBlockTimeInFuture)
+	m.conf.MaxClockDrift = 0
+	require.NoError(m.validateBlockTime(header(lastBlockTime.Add(time.Hour)), lastBlockTime))
+
+	// inclusion time is validated only if block time is taken from DA
+	daTime := lastBlockTime.Add(time.Second)
+	require.NoError(m.validateDAInclusionTime(header(daTime.Add(time.Second)), daTime))
+	m.conf.BlockTimeSource = config.TimeSourceDA
+	require.NoError(m.validateDAInclusionTime(header(daTime), daTime))
+	require.NoError(m.validateDAInclusionTime(header(daTime.Add(time.Second)), time.Time{}))
+	require.ErrorIs(m.validateDAInclusionTime(header(daTime.Add(time.Second)), daTime), ErrInvalidBlockTime)
+}
@@ -0,0 +1,98 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/proxy"
+	cmtypes "github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/celestiaorg/go-header"
+
+	"github.com/rollkit/rollkit/state"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// EarliestHeightKey is the metadata key of the lowest height of blocks available in the store, saved when the
+// node didn't sync from genesis. Blocks below it were never synced.
+const EarliestHeightKey = "earliest height"
+
+// BootstrapFromTrustedHeader initializes state of a fresh node from the trusted header at TrustedHeight, so that
+// the node syncs blocks following the trusted block, instead of replaying the chain from genesis. The trusted
+// header is taken from the header store, initialized with the header identified by hash.
+//
+// ABCI app must be restored to the state preceding the trusted block beforehand, e.g. from a state snapshot.
+// Height and app hash reported by the app are verified against the trusted header. The trusted block itself is
+// the first block applied by the node. It's a no-op if the node is not fresh or trusted height is not set.
+func (m *Manager) BootstrapFromTrustedHeader(ctx context.Context, query proxy.AppConnQuery, hash types.Hash) error {
+	if !m.trustedBootstrap {
+		return nil
+	}
+	trusted, err := m.headerStore.Get(ctx, header.Hash(hash))
+	if errors.Is(err, header.ErrNotFound) {
+		return fmt.Errorf("trusted header %s not found in header store, node must be connected to peers", hash)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get trusted header: %w", err)
+	}
+	height := trusted.Height()
+	if height != m.conf.TrustedHeight {
+		return fmt.Errorf("trusted header height (%d) doesn't match trusted height (%d)", height, m.conf.TrustedHeight)
+	}
+
+	info, err := query.Info(ctx, proxy.RequestInfo)
+	if err != nil {
+		return fmt.Errorf("error calling Info: %w", err)
+	}
+	if info.LastBlockHeight < 0 || uint64(info.LastBlockHeight) != height-1 {
+		return fmt.Errorf("app block height (%d) doesn't match height preceding trusted block (%d), app state must be restored first", info.LastBlockHeight, height-1)
+	}
+	// app hash after previous block is recorded in the header
+	if !bytes.Equal(info.LastBlockAppHash, trusted.AppHash) {
+		return &state.AppHashMismatchError{Height: height - 1, Expected: trusted.AppHash, Actual: info.LastBlockAppHash}
+	}
+
+	m.lastStateMtx.RLock()
+	s := m.lastState
+	m.lastStateMtx.RUnlock()
+	s.LastBlockHeight = height - 1
+	s.LastBlockID = cmtypes.BlockID{Hash: cmbytes.HexBytes(trusted.LastHeaderHash)}
+	s.AppHash = trusted.AppHash
+	s.LastResultsHash = trusted.LastResultsHash
+	s.Version.Consensus.App = trusted.Version.App
+
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, height)
+	if err := m.store.SetMetadata(ctx, EarliestHeightKey, value); err != nil {
+		return fmt.Errorf("failed to save earliest height: %w", err)
+	}
+	if err := m.updateState(ctx, s); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	m.store.SetHeight(ctx, s.LastBlockHeight)
+	m.trustedBootstrap = false
+	m.logger.Info("bootstrapped from trusted header", "height", height, "hash", trusted.Hash(), "daHeight", s.DAHeight)
+	return nil
+}
+
+// LoadEarliestHeight returns the lowest height of blocks available in the store, or 0 if the node synced
+// from genesis.
+func LoadEarliestHeight(ctx context.Context, s store.Store) (uint64, error) {
+	value, err := s.GetMetadata(ctx, EarliestHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid earliest height, length %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
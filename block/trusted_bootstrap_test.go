@@ -0,0 +1,90 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	abciclient "github.com/cometbft/cometbft/abci/client"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/proxy"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	goheaderstore "github.com/celestiaorg/go-header/store"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/store"
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestBootstrapFromTrustedHeader(t *testing.T) {
+	ctx := context.Background()
+	trusted, _, err := types.GetRandomSignedHeader("test")
+	require.NoError(t, err)
+	height := trusted.Height()
+
+	cases := []struct {
+		name          string
+		trustedHeight uint64
+		appHeight     int64
+		appHash       []byte
+		err           bool
+	}{
+		{"app restored", height, int64(height - 1), trusted.AppHash, false},
+		{"height mismatch", height + 1, int64(height - 1), trusted.AppHash, true},
+		{"app not restored", height, 0, nil, true},
+		{"app hash mismatch", height, int64(height - 1), []byte("other"), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			headerStore, err := goheaderstore.NewStore[*types.SignedHeader](dssync.MutexWrap(ds.NewMapDatastore()))
+			require.NoError(err)
+			require.NoError(headerStore.Init(ctx, trusted))
+			kv, err := store.NewDefaultInMemoryKVStore()
+			require.NoError(err)
+			s := store.New(kv)
+
+			app := &mocks.Application{}
+			app.On("Info", mock.Anything, mock.Anything).Return(&abci.ResponseInfo{LastBlockHeight: c.appHeight, LastBlockAppHash: c.appHash}, nil)
+			client := abciclient.NewLocalClient(nil, app)
+			m := &Manager{
+				conf:             config.BlockManagerConfig{TrustedHeight: c.trustedHeight},
+				store:            s,
+				headerStore:      headerStore,
+				lastState:        types.State{InitialHeight: 1},
+				lastStateMtx:     new(sync.RWMutex),
+				trustedBootstrap: true,
+				metrics:          NopMetrics(),
+				logger:           test.NewLogger(t),
+			}
+
+			err = m.BootstrapFromTrustedHeader(ctx, proxy.NewAppConnQuery(client, proxy.NopMetrics()), types.Hash(trusted.Hash()))
+			if c.err {
+				require.Error(err)
+				assert.Zero(s.Height())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(height-1, s.Height())
+			assert.Equal(height-1, m.lastState.LastBlockHeight)
+			assert.Equal(trusted.AppHash, m.lastState.AppHash)
+			assert.Equal(trusted.LastResultsHash, m.lastState.LastResultsHash)
+			earliest, err := LoadEarliestHeight(ctx, s)
+			require.NoError(err)
+			assert.Equal(height, earliest)
+
+			// bootstrap is done once
+			require.NoError(m.BootstrapFromTrustedHeader(ctx, nil, nil))
+		})
+	}
+}
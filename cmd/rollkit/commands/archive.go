@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/spf13/cobra"
+
+	"github.com/rollkit/rollkit/block"
+	rollconf "github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/store"
+)
+
+// NewExportCmd returns the command exporting blocks and state of the node into a portable archive.
+func NewExportCmd() *cobra.Command {
+	var from, to uint64
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export blocks and state of the node into a portable archive",
+		Long: `This command writes blocks with their signatures, responses, state records and DA inclusion certificates,
+followed by the state after applying the last exported block, into a versioned archive. The archive can be imported
+with "rollkit import" to bootstrap a new node, or to migrate the node to another database backend, without syncing
+from DA. "-" writes the archive to standard output. The node must be stopped.
+
+By default, all blocks available in the store are exported.`,
+		Example: `  rollkit export chain.archive
+  rollkit export --from 100 --to 200 chain.archive`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return parseConfig(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			rollconf.GetNodeConfig(&nodeConfig, config)
+
+			w := cmd.OutOrStdout()
+			if args[0] != "-" {
+				f, err := os.Create(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to create archive: %w", err)
+				}
+				defer func() {
+					if cerr := f.Close(); cerr != nil && err == nil {
+						err = fmt.Errorf("failed to close archive: %w", cerr)
+					}
+				}()
+				w = f
+			}
+			from, to, err := exportStore(cmd.Context(), nodeConfig, w, from, to)
+			if err != nil {
+				return err
+			}
+			if args[0] != "-" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Exported blocks %d-%d\n", from, to)
+			}
+			return nil
+		},
+	}
+
+	addNodeFlags(cmd)
+	cmd.Flags().Uint64Var(&from, "from", 0, "height of the first exported block (default: the earliest available block)")
+	cmd.Flags().Uint64Var(&to, "to", 0, "height of the last exported block (default: the state height)")
+
+	return cmd
+}
+
+// NewImportCmd returns the command importing blocks and state of the node from an archive.
+func NewImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import blocks and state of the node from an archive",
+		Long: `This command imports blocks and state from an archive written by "rollkit export". Blocks are validated
+against their headers and linked with previous headers. If the store of the node is not empty, the archive must
+continue from its state height. "-" reads the archive from standard input. The node must be stopped.
+
+Only the store is imported: application state has to be restored separately, e.g. from a snapshot of the application
+at the last imported height.`,
+		Example: `  rollkit import chain.archive
+  rollkit export - | rollkit import --home /path/to/new/node -`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return parseConfig(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rollconf.GetNodeConfig(&nodeConfig, config)
+
+			r := cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to open archive: %w", err)
+				}
+				defer f.Close() //nolint:errcheck
+				r = f
+			}
+			from, to, err := importStore(cmd.Context(), nodeConfig, r)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Imported blocks %d-%d\n", from, to)
+			return nil
+		},
+	}
+
+	addNodeFlags(cmd)
+
+	return cmd
+}
+
+// exportStore exports blocks from from to to (both 0 by default, meaning all available blocks) into w, and
+// returns the range of exported blocks.
+func exportStore(ctx context.Context, nc rollconf.NodeConfig, w io.Writer, from, to uint64) (_, _ uint64, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s, err := openStore(ctx, nc)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if cerr := s.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close store: %w", cerr)
+		}
+	}()
+
+	state, err := s.GetState(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if to == 0 {
+		to = state.LastBlockHeight
+	}
+	if from == 0 {
+		// node started from trusted height doesn't store blocks below it, and data of old blocks may be pruned
+		earliest, err := block.LoadEarliestHeight(ctx, s)
+		if err != nil {
+			return 0, 0, err
+		}
+		pruned, err := store.PrunedHeight(ctx, s)
+		if err != nil {
+			return 0, 0, err
+		}
+		from = max(state.InitialHeight, earliest, pruned+1, 1)
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := s.Export(ctx, bw, from, to); err != nil {
+		return 0, 0, fmt.Errorf("failed to export store: %w", err)
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return from, to, nil
+}
+
+// importStore imports archive read from r, and returns the range of imported blocks. If the store was empty and
+// the archive doesn't start at the initial height, the first imported height is saved as the earliest height of
+// blocks, like when node is started from trusted height.
+func importStore(ctx context.Context, nc rollconf.NodeConfig, r io.Reader) (from, to uint64, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s, err := openStore(ctx, nc)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if cerr := s.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close store: %w", cerr)
+		}
+	}()
+
+	_, err = s.GetState(ctx)
+	empty := errors.Is(err, ds.ErrNotFound)
+	if err != nil && !empty {
+		return 0, 0, err
+	}
+	if from, to, err = s.Import(ctx, r); err != nil {
+		return 0, 0, fmt.Errorf("failed to import archive: %w", err)
+	}
+	if !empty {
+		return from, to, nil
+	}
+	state, err := s.GetState(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if from > max(state.InitialHeight, 1) {
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, from)
+		if err := s.SetMetadata(ctx, block.EarliestHeightKey, value); err != nil {
+			return 0, 0, fmt.Errorf("failed to save earliest height: %w", err)
+		}
+	}
+	return from, to, nil
+}
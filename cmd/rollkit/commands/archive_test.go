@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/block"
+	rollconf "github.com/rollkit/rollkit/config"
+	rolltypes "github.com/rollkit/rollkit/types"
+)
+
+func TestExportImportStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	newConfig := func() rollconf.NodeConfig {
+		nc := rollconf.DefaultNodeConfig
+		nc.RootDir = t.TempDir()
+		nc.DBPath = "data"
+		return nc
+	}
+	src := newConfig()
+	s, err := openStore(ctx, src)
+	require.NoError(err)
+	validatorSet := rolltypes.GetRandomValidatorSet()
+	header, data, privKey := rolltypes.GenerateRandomBlockCustom(&rolltypes.BlockConfig{Height: 1, NTxs: 1}, "TestExportImportStore")
+	for h := uint64(1); h <= 3; h++ {
+		if h > 1 {
+			header, data = rolltypes.GetRandomNextBlock(header, data, privKey, nil, 1, "TestExportImportStore")
+		}
+		require.NoError(s.SaveBlockData(ctx, header, data, &header.Signature))
+		require.NoError(s.UpdateState(ctx, rolltypes.State{
+			ChainID:         "TestExportImportStore",
+			InitialHeight:   1,
+			LastBlockHeight: h,
+			AppHash:         []byte{byte(h)},
+			Validators:      validatorSet,
+			NextValidators:  validatorSet,
+			LastValidators:  validatorSet,
+		}))
+	}
+	require.NoError(s.Close())
+
+	var archive bytes.Buffer
+	from, to, err := exportStore(ctx, src, &archive, 0, 0)
+	require.NoError(err)
+	require.Equal(uint64(1), from)
+	require.Equal(uint64(3), to)
+
+	dst := newConfig()
+	from, to, err = importStore(ctx, dst, &archive)
+	require.NoError(err)
+	require.Equal(uint64(1), from)
+	require.Equal(uint64(3), to)
+
+	// archive starting above the initial height sets the earliest height of blocks
+	archive.Reset()
+	_, _, err = exportStore(ctx, src, &archive, 2, 3)
+	require.NoError(err)
+	trusted := newConfig()
+	_, _, err = importStore(ctx, trusted, &archive)
+	require.NoError(err)
+
+	s, err = openStore(ctx, trusted)
+	require.NoError(err)
+	defer func() { require.NoError(s.Close()) }()
+	state, err := s.GetState(ctx)
+	require.NoError(err)
+	require.Equal(uint64(3), state.LastBlockHeight)
+	require.Equal(rolltypes.Hash{3}, state.AppHash)
+	earliest, err := s.GetMetadata(ctx, block.EarliestHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(2), binary.BigEndian.Uint64(earliest))
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/spf13/cobra"
+
+	"github.com/rollkit/rollkit/bench"
+)
+
+// NewBenchCmd returns the command running end-to-end benchmark of Rollkit against in-process mock services.
+func NewBenchCmd() *cobra.Command {
+	cfg := bench.DefaultConfig()
+	var output, baseline string
+	var maxRegression float64
+	var verbose bool
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Run end-to-end throughput and latency benchmark",
+		Long: `This command runs an in-memory aggregator against in-process mock DA and mock sequencer,
+submits transactions from concurrent clients and measures throughput and latency of transactions from
+submission until inclusion in a block and in DA. Results are printed as JSON.
+
+If --baseline is set, results are compared with results of a previous run, e.g. of the previous release,
+and the command exits with error if throughput or latency got worse by more than --max-regression.
+Results are comparable only if they were measured with the same flags, on the same machine.`,
+		Example: `  rollkit bench --output results.json
+  rollkit bench --baseline results.json --max-regression 0.1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := cometlog.NewNopLogger()
+			if verbose {
+				logger = cometlog.NewTMLogger(cometlog.NewSyncWriter(os.Stderr))
+			}
+			res, err := bench.Run(cmd.Context(), cfg, logger)
+			if err != nil {
+				return fmt.Errorf("benchmark failed: %w", err)
+			}
+			encoded, err := json.MarshalIndent(res, "", "  ")
+			if err != nil {
+				return err
+			}
+			if output != "" {
+				err = os.WriteFile(output, append(encoded, '\n'), 0o644) //nolint:gosec
+			} else {
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(encoded))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write results: %w", err)
+			}
+			if baseline == "" {
+				return nil
+			}
+			base, err := readBenchResult(baseline)
+			if err != nil {
+				return err
+			}
+			return checkBenchRegressions(cmd.ErrOrStderr(), base, res, maxRegression)
+		},
+	}
+	cmd.Flags().IntVar(&cfg.Txs, "txs", cfg.Txs, "number of submitted transactions")
+	cmd.Flags().IntVar(&cfg.TxSize, "tx-size", cfg.TxSize, "size of each transaction in bytes")
+	cmd.Flags().IntVar(&cfg.Concurrency, "concurrency", cfg.Concurrency, "number of clients submitting transactions concurrently")
+	cmd.Flags().DurationVar(&cfg.BlockTime, "block-time", cfg.BlockTime, "block time of the aggregator")
+	cmd.Flags().DurationVar(&cfg.DABlockTime, "da-block-time", cfg.DABlockTime, "interval of submitting blocks to DA")
+	cmd.Flags().DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "maximum duration of the benchmark")
+	cmd.Flags().StringVar(&output, "output", "", "file to write results to (default: standard output)")
+	cmd.Flags().StringVar(&baseline, "baseline", "", "results of a previous run to compare with")
+	cmd.Flags().Float64Var(&maxRegression, "max-regression", 0.1, "maximum allowed relative regression of any metric compared to baseline")
+	cmd.Flags().BoolVar(&verbose, "verbose", false, "print logs of the benchmarked node to standard error")
+	return cmd
+}
+
+func readBenchResult(path string) (*bench.Result, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var res bench.Result
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	return &res, nil
+}
+
+// checkBenchRegressions prints regressions of the result compared to the baseline and returns error if there
+// are any.
+func checkBenchRegressions(w io.Writer, baseline, current *bench.Result, tolerance float64) error {
+	if baseline.Config != current.Config {
+		fmt.Fprintln(w, "WARNING: baseline was measured with different configuration, results may not be comparable")
+	}
+	// baseline is usually measured with a different version
+	baseEnv, curEnv := baseline.Environment, current.Environment
+	baseEnv.Version, curEnv.Version = "", ""
+	if baseEnv != curEnv {
+		fmt.Fprintln(w, "WARNING: baseline was measured in different environment, results may not be comparable")
+	}
+	regressions := bench.Compare(baseline, current, tolerance)
+	for _, r := range regressions {
+		fmt.Fprintln(w, "REGRESSION:", r)
+	}
+	if len(regressions) > 0 {
+		return fmt.Errorf("%d metrics regressed by more than %.0f%%", len(regressions), tolerance*100)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rollkit/rollkit/bench"
+)
+
+func TestCheckBenchRegressions(t *testing.T) {
+	baseline := &bench.Result{
+		Config:      bench.DefaultConfig(),
+		Environment: bench.Environment{Version: "0.38.4", OS: "linux"},
+		Throughput:  1000,
+	}
+	current := *baseline
+	current.Environment.Version = "0.38.5"
+	current.Throughput = 950
+
+	var out bytes.Buffer
+	assert.NoError(t, checkBenchRegressions(&out, baseline, &current, 0.1))
+	assert.Empty(t, out.String())
+
+	current.Throughput = 800
+	current.Config.Txs++
+	assert.Error(t, checkBenchRegressions(&out, baseline, &current, 0.1))
+	assert.Contains(t, out.String(), "WARNING: baseline was measured with different configuration")
+	assert.Contains(t, out.String(), "REGRESSION: throughput_tps: 1000.00 -> 800.00 (+20.0% worse)")
+}
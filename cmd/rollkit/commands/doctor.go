@@ -0,0 +1,384 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	proxyda "github.com/rollkit/go-da/proxy"
+
+	rollconf "github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/store"
+	rolltypes "github.com/rollkit/rollkit/types"
+)
+
+const (
+	// doctorTimeout is the timeout of each network check.
+	doctorTimeout = 5 * time.Second
+	// namespaceSize is the size of Celestia namespace: version byte followed by 28 bytes of ID.
+	namespaceSize = 29
+	// namespaceV0Zeros is the number of leading zero bytes of version 0 namespace ID.
+	namespaceV0Zeros = 18
+	// minDateDriftResolution accounts for 1 second resolution of HTTP Date header.
+	minDateDriftResolution = 2 * time.Second
+)
+
+// Statuses of doctor checks.
+const (
+	doctorOK   = "OK"
+	doctorWarn = "WARN"
+	doctorFail = "FAIL"
+)
+
+// doctorResult is the result of a single startup self-check.
+type doctorResult struct {
+	Name    string
+	Status  string
+	Message string
+	// Hint suggests how to fix the problem.
+	Hint string
+}
+
+func doctorOKf(name, format string, args ...interface{}) doctorResult {
+	return doctorResult{Name: name, Status: doctorOK, Message: fmt.Sprintf(format, args...)}
+}
+
+func doctorWarnf(name, hint, format string, args ...interface{}) doctorResult {
+	return doctorResult{Name: name, Status: doctorWarn, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+func doctorFailf(name, hint, format string, args ...interface{}) doctorResult {
+	return doctorResult{Name: name, Status: doctorFail, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+// NewDoctorCmd returns the command checking configuration and environment of the node before it's started.
+func NewDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check node configuration and environment before starting the node",
+		Long: `This command checks DA endpoint reachability and namespace validity, sequencer endpoint,
+free disk space, clock drift, key file permissions and config sanity. It accepts the same flags as
+the start command. Each failed check is printed with a hint how to fix it.
+
+The command exits with error if any check failed. Warnings don't prevent the node from starting,
+but should be reviewed before running the node in production.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := parseConfig(cmd); err != nil {
+				return err
+			}
+			// same default as in start command
+			if !cmd.Flags().Lookup("rollkit.aggregator").Changed {
+				nodeConfig.Aggregator = true
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rollconf.GetNodeConfig(&nodeConfig, config)
+			if err := rollconf.TranslateAddresses(&nodeConfig); err != nil {
+				return err
+			}
+
+			results := runDoctorChecks(cmd.Context(), nodeConfig)
+			failed := printDoctorResults(cmd.OutOrStdout(), results)
+			if failed > 0 {
+				return fmt.Errorf("%d checks failed", failed)
+			}
+			return nil
+		},
+	}
+
+	addNodeFlags(cmd)
+
+	return cmd
+}
+
+// runDoctorChecks runs all startup self-checks.
+func runDoctorChecks(ctx context.Context, nc rollconf.NodeConfig) []doctorResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	results := checkConfigSanity(nc)
+	results = append(results,
+		checkNamespace("DA namespace", nc.DANamespace, true),
+		checkDAEndpoint(ctx, nc),
+	)
+	if nc.DAForcedInclusionNamespace != "" {
+		results = append(results, checkNamespace("DA forced inclusion namespace", nc.DAForcedInclusionNamespace, false))
+	}
+	if nc.Messaging.Enabled() {
+		results = append(results, checkNamespace("messaging source namespace", nc.Messaging.SourceNamespace, false))
+	}
+	if nc.Aggregator {
+		results = append(results, checkSequencerEndpoint(ctx, nc.SequencerAddress))
+	}
+	results = append(results,
+		checkDiskSpace(store.Path(nc.RootDir, nc.DBPath, "rollkit"), nc.DBMinFreeDiskMB),
+		checkClockDrift(ctx, nc.DAAddress, nc.MaxClockDrift, time.Now),
+		checkGenesis(config.GenesisFile()),
+		checkKeyFile("node key", config.NodeKeyFile()),
+		checkKeyFile("validator key", config.PrivValidatorKeyFile()),
+	)
+	if nc.RPCAPIKeysFile != "" {
+		results = append(results, checkKeyFile("RPC API keys", nc.RPCAPIKeysFile))
+	}
+	return results
+}
+
+// printDoctorResults prints results of checks and returns the number of failed checks.
+func printDoctorResults(w io.Writer, results []doctorResult) int {
+	failed, warned := 0, 0
+	for _, r := range results {
+		fmt.Fprintf(w, "[%-4s] %s: %s\n", r.Status, r.Name, r.Message)
+		if r.Hint != "" {
+			fmt.Fprintf(w, "       hint: %s\n", r.Hint)
+		}
+		switch r.Status {
+		case doctorFail:
+			failed++
+		case doctorWarn:
+			warned++
+		}
+	}
+	fmt.Fprintf(w, "\n%d checks, %d failed, %d warnings\n", len(results), failed, warned)
+	return failed
+}
+
+// checkConfigSanity checks for invalid or suspicious combinations of configuration options.
+func checkConfigSanity(nc rollconf.NodeConfig) []doctorResult {
+	const name = "config"
+	var results []doctorResult
+	fail := func(hint, format string, args ...interface{}) {
+		results = append(results, doctorFailf(name, hint, format, args...))
+	}
+	warn := func(hint, format string, args ...interface{}) {
+		results = append(results, doctorWarnf(name, hint, format, args...))
+	}
+
+	if nc.Aggregator && nc.Light {
+		fail("disable either --rollkit.aggregator or --rollkit.light", "aggregator can't run as light node")
+	}
+	if nc.Aggregator && nc.ReadOnly {
+		fail("disable either --rollkit.aggregator or --rollkit.read_only", "aggregator can't run in read-only mode")
+	}
+	if nc.ReplicateFrom != "" && !nc.ReadOnly {
+		fail("enable --rollkit.read_only", "replicating store from primary node requires read-only mode")
+	}
+	if nc.BlockTime <= 0 {
+		fail("set --rollkit.block_time to a positive duration", "block time must be positive, got %s", nc.BlockTime)
+	}
+	if nc.DABlockTime <= 0 {
+		fail("set --rollkit.da_block_time to block time of the DA layer", "DA block time must be positive, got %s", nc.DABlockTime)
+	}
+	if nc.MaxBlockTime != 0 && nc.MaxBlockTime < nc.BlockTime {
+		fail("set --rollkit.max_block_time to at least --rollkit.block_time, or 0 to disable adaptive block time",
+			"max block time %s is lower than block time %s", nc.MaxBlockTime, nc.BlockTime)
+	}
+	if nc.LazyAggregator && nc.LazyBlockTime < nc.BlockTime {
+		warn("set --rollkit.lazy_block_time to at least --rollkit.block_time",
+			"lazy block time %s is lower than block time %s", nc.LazyBlockTime, nc.BlockTime)
+	}
+	if nc.DAGasMultiplier < 0 {
+		fail("set --rollkit.da_gas_multiplier to a non-negative value", "DA gas multiplier must not be negative, got %g", nc.DAGasMultiplier)
+	}
+	switch nc.AppHashMismatchPolicy {
+	case rollconf.AppHashMismatchHalt, rollconf.AppHashMismatchRollback, rollconf.AppHashMismatchHeadersOnly:
+	default:
+		fail("use one of: halt, rollback, headers_only", "unknown app hash mismatch policy %q", nc.AppHashMismatchPolicy)
+	}
+	if nc.MemoryHardLimitMB != 0 && nc.MemoryHardLimitMB < nc.MemorySoftLimitMB {
+		warn("set --rollkit.memory_hard_limit_mb above --rollkit.memory_soft_limit_mb",
+			"memory hard limit %d MiB is lower than soft limit %d MiB", nc.MemoryHardLimitMB, nc.MemorySoftLimitMB)
+	}
+	if nc.RPCAdmin && nc.RPCAPIKeysFile == "" {
+		fail("set --rollkit.rpc_api_keys_file and allow admin methods in admin_methods of selected keys",
+			"admin RPC methods are enabled without authentication")
+	}
+
+	if len(results) == 0 {
+		results = append(results, doctorOKf(name, "no problems found"))
+	}
+	return results
+}
+
+// checkNamespace checks if namespace is a valid hex encoded Celestia namespace.
+func checkNamespace(name, namespace string, required bool) doctorResult {
+	if namespace == "" {
+		if required {
+			return doctorWarnf(name, "set --rollkit.da_namespace to the namespace of the rollup", "namespace is not set")
+		}
+		return doctorOKf(name, "not set")
+	}
+	ns, err := hex.DecodeString(namespace)
+	if err != nil {
+		return doctorFailf(name, "namespace must be hex encoded", "invalid namespace %q: %s", namespace, err)
+	}
+	if len(ns) != namespaceSize {
+		return doctorWarnf(name, fmt.Sprintf("Celestia namespaces are %d bytes (version byte followed by ID)", namespaceSize),
+			"namespace is %d bytes long", len(ns))
+	}
+	if ns[0] == 0 && !bytes.Equal(ns[1:1+namespaceV0Zeros], make([]byte, namespaceV0Zeros)) {
+		return doctorFailf(name, fmt.Sprintf("version 0 namespace ID must start with %d zero bytes", namespaceV0Zeros),
+			"invalid version 0 namespace %s", namespace)
+	}
+	return doctorOKf(name, "%s", namespace)
+}
+
+// checkDAEndpoint checks if DA layer is reachable.
+func checkDAEndpoint(ctx context.Context, nc rollconf.NodeConfig) doctorResult {
+	const name = "DA endpoint"
+	const hint = "start DA node or fix --rollkit.da_address; the start command would otherwise launch a mock DA server, which must not be used in production"
+	client, err := proxyda.NewClient(nc.DAAddress, nc.DAAuthToken)
+	if err != nil {
+		return doctorFailf(name, hint, "failed to create client for %s: %s", nc.DAAddress, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+	maxBlobSize, err := client.MaxBlobSize(ctx)
+	if err != nil {
+		return doctorFailf(name, hint, "%s is not reachable: %s", nc.DAAddress, err)
+	}
+	return doctorOKf(name, "%s is reachable, max blob size %d bytes", nc.DAAddress, maxBlobSize)
+}
+
+// checkSequencerEndpoint checks if sequencer accepts connections.
+func checkSequencerEndpoint(ctx context.Context, address string) doctorResult {
+	const name = "sequencer endpoint"
+	const hint = "start sequencer or fix --rollkit.sequencer_address; the start command would otherwise launch a mock sequencer, which must not be used in production"
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", strings.TrimPrefix(address, "grpc://"))
+	if err != nil {
+		return doctorFailf(name, hint, "%s is not reachable: %s", address, err)
+	}
+	_ = conn.Close()
+	return doctorOKf(name, "%s is reachable", address)
+}
+
+// checkDiskSpace checks free disk space on the volume of the database.
+func checkDiskSpace(dir string, minFreeMB uint64) doctorResult {
+	const name = "disk space"
+	// database directory might not exist yet
+	for {
+		if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	free, err := store.FreeDiskSpace(dir)
+	if err != nil {
+		return doctorWarnf(name, "", "failed to check free disk space of %s: %s", dir, err)
+	}
+	freeMB := free >> 20
+	if minFreeMB == 0 {
+		return doctorWarnf(name, "set --rollkit.db_min_free_disk_mb to stop the node safely before the disk is full",
+			"%d MiB free in %s, low disk space protection is disabled", freeMB, dir)
+	}
+	if freeMB < minFreeMB {
+		return doctorFailf(name, "free disk space, or lower --rollkit.db_min_free_disk_mb",
+			"%d MiB free in %s, node would start in safe mode (limit %d MiB)", freeMB, dir, minFreeMB)
+	}
+	if freeMB < 2*minFreeMB {
+		return doctorWarnf(name, "free disk space before the node enters safe mode",
+			"%d MiB free in %s, close to the limit of %d MiB", freeMB, dir, minFreeMB)
+	}
+	return doctorOKf(name, "%d MiB free in %s", freeMB, dir)
+}
+
+// checkClockDrift compares local clock with the time reported by DA endpoint in HTTP Date header.
+func checkClockDrift(ctx context.Context, daAddress string, maxDrift time.Duration, now func() time.Time) doctorResult {
+	const name = "clock drift"
+	const hint = "synchronize system clock with NTP"
+	u, err := url.Parse(daAddress)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return doctorWarnf(name, "", "unable to determine, DA endpoint %s doesn't use HTTP", daAddress)
+	}
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, daAddress, nil)
+	if err != nil {
+		return doctorWarnf(name, "", "unable to determine: %s", err)
+	}
+	start := now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return doctorWarnf(name, "", "unable to determine, DA endpoint is not reachable: %s", err)
+	}
+	_ = resp.Body.Close()
+	remote, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return doctorWarnf(name, "", "unable to determine, DA endpoint doesn't report time")
+	}
+	// assume that the time was reported in the middle of request
+	end := now()
+	local := start.Add(end.Sub(start) / 2)
+	drift := local.Sub(remote)
+	if drift < 0 {
+		drift = -drift
+	}
+	limit := maxDrift
+	if limit == 0 {
+		limit = rollconf.DefaultNodeConfig.MaxClockDrift
+	}
+	limit = max(limit, minDateDriftResolution)
+	if drift > limit {
+		return doctorFailf(name, hint, "local clock differs from DA endpoint clock by %s (limit %s)", drift.Round(time.Second), limit)
+	}
+	if drift > limit/2 {
+		return doctorWarnf(name, hint, "local clock differs from DA endpoint clock by %s (limit %s)", drift.Round(time.Second), limit)
+	}
+	return doctorOKf(name, "local clock differs from DA endpoint clock by %s", drift.Round(time.Second))
+}
+
+// checkGenesis checks if genesis file is valid and reports its canonical hash, which must be the same on all nodes
+// of the chain.
+func checkGenesis(path string) doctorResult {
+	const name = "genesis"
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return doctorWarnf(name, "make sure this is a new sequencer, or copy genesis of the chain you are joining",
+			"%s doesn't exist, new genesis will be generated", path)
+	}
+	genDoc, err := rolltypes.GenesisDocFromFile(path)
+	if err != nil {
+		return doctorFailf(name, "check the genesis file against the genesis of the chain", "failed to load %s: %s", path, err)
+	}
+	if err := rolltypes.ValidateGenesis(genDoc); err != nil {
+		return doctorFailf(name, "", "%s", err)
+	}
+	timeSource, err := rollconf.BlockTimeSourceFromFile(path)
+	if err != nil {
+		return doctorFailf(name, "fix block_time_source of genesis", "%s", err)
+	}
+	hash, err := rolltypes.GenesisHash(genDoc)
+	if err != nil {
+		return doctorFailf(name, "", "failed to compute genesis hash: %s", err)
+	}
+	return doctorOKf(name, "chain %s, genesis hash %X, block time source %s", genDoc.ChainID, hash, timeSource)
+}
+
+// checkKeyFile checks if key file is not accessible by other users.
+func checkKeyFile(name, path string) doctorResult {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return doctorWarnf(name, "make sure this is a new node, or restore the key from backup",
+			"%s doesn't exist, new key will be generated", path)
+	}
+	if err != nil {
+		return doctorFailf(name, "", "failed to check %s: %s", path, err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return doctorFailf(name, fmt.Sprintf("run: chmod 600 %s", path), "%s is accessible by other users (mode %04o)", path, perm)
+	}
+	return doctorOKf(name, "%s", path)
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rollconf "github.com/rollkit/rollkit/config"
+	rolltypes "github.com/rollkit/rollkit/types"
+)
+
+func TestCheckNamespace(t *testing.T) {
+	cases := []struct {
+		namespace string
+		required  bool
+		status    string
+	}{
+		{"", true, doctorWarn},
+		{"", false, doctorOK},
+		{"zz", true, doctorFail},
+		{"deadbeef", true, doctorWarn},
+		{"00000000000000000000000000000000000000000000000000deadbeef", true, doctorOK},
+		{"00ff000000000000000000000000000000000000000000000000deadbe", true, doctorFail},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.status, checkNamespace("ns", c.namespace, c.required).Status, c.namespace)
+	}
+}
+
+func TestCheckConfigSanity(t *testing.T) {
+	nc := rollconf.DefaultNodeConfig
+	results := checkConfigSanity(nc)
+	require.Len(t, results, 1)
+	assert.Equal(t, doctorOK, results[0].Status)
+
+	nc.Aggregator = true
+	nc.Light = true
+	nc.BlockTime = 0
+	results = checkConfigSanity(nc)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		assert.Equal(t, doctorFail, r.Status)
+		assert.NotEmpty(t, r.Hint)
+	}
+}
+
+func TestCheckKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.json")
+	assert.Equal(t, doctorWarn, checkKeyFile("key", path).Status)
+
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+	assert.Equal(t, doctorOK, checkKeyFile("key", path).Status)
+
+	require.NoError(t, os.Chmod(path, 0o644))
+	assert.Equal(t, doctorFail, checkKeyFile("key", path).Status)
+}
+
+func TestCheckGenesis(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	assert.Equal(t, doctorWarn, checkGenesis(path).Status)
+
+	genDoc, _ := rolltypes.GetGenesisWithPrivkey("ed25519", "TestCheckGenesis")
+	genDoc.GenesisTime = time.Time{}
+	require.NoError(t, genDoc.SaveAs(path))
+	res := checkGenesis(path)
+	assert.Equal(t, doctorFail, res.Status)
+	assert.Contains(t, res.Message, "genesis_time is missing")
+
+	genDoc.GenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, genDoc.SaveAs(path))
+	res = checkGenesis(path)
+	assert.Equal(t, doctorOK, res.Status, res.Message)
+	assert.Contains(t, res.Message, "genesis hash")
+
+	blob, err := os.ReadFile(path) //nolint:gosec
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, bytes.Replace(blob, []byte("{"), []byte(`{"block_time_source":"attester_median",`), 1), 0o600))
+	res = checkGenesis(path)
+	assert.Equal(t, doctorFail, res.Status)
+	assert.Contains(t, res.Message, "attester_median")
+
+	genDoc.Validators = nil
+	require.NoError(t, genDoc.SaveAs(path))
+	res = checkGenesis(path)
+	assert.Equal(t, doctorFail, res.Status)
+	assert.Contains(t, res.Message, "validators are empty")
+}
+
+func TestCheckDiskSpace(t *testing.T) {
+	// database directory doesn't exist yet, so free space of the parent is checked
+	dir := filepath.Join(t.TempDir(), "data", "rollkit")
+	assert.Equal(t, doctorOK, checkDiskSpace(dir, 1).Status)
+	assert.Equal(t, doctorFail, checkDiskSpace(dir, 1<<40).Status)
+}
+
+func TestCheckClockDrift(t *testing.T) {
+	serverTime := time.Now()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	res := checkClockDrift(ctx, srv.URL, 10*time.Second, time.Now)
+	assert.Equal(t, doctorOK, res.Status, res.Message)
+
+	ahead := func() time.Time { return time.Now().Add(time.Minute) }
+	res = checkClockDrift(ctx, srv.URL, 10*time.Second, ahead)
+	assert.Equal(t, doctorFail, res.Status, res.Message)
+
+	res = checkClockDrift(ctx, "grpc://localhost:1", 10*time.Second, time.Now)
+	assert.Equal(t, doctorWarn, res.Status, res.Message)
+}
+
+func TestCheckSequencerEndpoint(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	assert.Equal(t, doctorOK, checkSequencerEndpoint(context.Background(), addr).Status)
+
+	require.NoError(t, l.Close())
+	assert.Equal(t, doctorFail, checkSequencerEndpoint(context.Background(), addr).Status)
+}
+
+func TestPrintDoctorResults(t *testing.T) {
+	var buf bytes.Buffer
+	failed := printDoctorResults(&buf, []doctorResult{
+		doctorOKf("a", "fine"),
+		doctorWarnf("b", "look at it", "suspicious"),
+		doctorFailf("c", "fix it", "broken"),
+	})
+	assert.Equal(t, 1, failed)
+	assert.Contains(t, buf.String(), "[FAIL] c: broken\n       hint: fix it\n")
+	assert.Contains(t, buf.String(), "3 checks, 1 failed, 1 warnings")
+}
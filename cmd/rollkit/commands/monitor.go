@@ -0,0 +1,311 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	cmjson "github.com/cometbft/cometbft/libs/json"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/spf13/cobra"
+
+	"github.com/rollkit/rollkit/node"
+)
+
+const (
+	// monitorTimeout is the timeout of each request to the node.
+	monitorTimeout = 5 * time.Second
+	// monitorErrors is the number of the latest errors displayed by the monitor.
+	monitorErrors = 5
+	// monitorBlocks is the number of the latest blocks displayed by the monitor.
+	monitorBlocks = 5
+	// clearScreen moves the cursor to the top left corner of the terminal and clears it.
+	clearScreen = "\033[H\033[2J"
+)
+
+// NewMonitorCmd returns the command displaying live dashboard of a running node in the terminal.
+func NewMonitorCmd() *cobra.Command {
+	var (
+		addr, apiKey string
+		interval     time.Duration
+		once         bool
+	)
+	cmd := &cobra.Command{
+		Use:   "monitor",
+		Short: "Display live dashboard of a running node in the terminal",
+		Long: `This command polls the dashboard RPC method of a running node and displays heights, sync progress,
+peers, mempool, DA submission queue, block times, transaction throughput, disk usage and recent errors in the
+terminal, refreshed every --interval. It's useful for operators without access to Grafana, e.g. over SSH.
+
+Recent errors are failed requests to the node, and problems reported by the node: halt, block execution stopped
+because of app hash mismatch (headers only) and safe mode because of low free disk space.`,
+		Example: `  rollkit monitor
+  rollkit monitor --node http://10.0.0.5:26657 --interval 5s`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return errors.New("interval must be positive")
+			}
+			m := newMonitor(addr, apiKey)
+			return m.run(cmd.Context(), cmd.OutOrStdout(), interval, once)
+		},
+	}
+	cmd.Flags().StringVar(&addr, "node", "http://127.0.0.1:26657", "RPC address of the node")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key sent with requests, if RPC requires authentication")
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "refresh interval")
+	cmd.Flags().BoolVar(&once, "once", false, "print the dashboard once, without clearing the terminal, and exit")
+	return cmd
+}
+
+// monitorEvent is an error observed by the monitor.
+type monitorEvent struct {
+	Time    time.Time
+	Message string
+}
+
+// monitorSnapshot is the data displayed by a single refresh of the monitor.
+type monitorSnapshot struct {
+	Time time.Time
+	// Dashboard is nil if it couldn't be fetched.
+	Dashboard *node.ResultDashboard
+	// TxRate is the number of transactions per second in the recent blocks.
+	TxRate float64
+	// Errors are the latest errors, oldest first.
+	Errors []monitorEvent
+}
+
+// monitor polls the node and keeps the latest errors between refreshes.
+type monitor struct {
+	addr   string
+	apiKey string
+	client *http.Client
+
+	errors []monitorEvent
+	// problems are problems reported by the node in the previous refresh, so that each of them is recorded once
+	problems map[string]bool
+}
+
+func newMonitor(addr, apiKey string) *monitor {
+	// node RPC address is usually configured as tcp://host:port
+	if rest, ok := strings.CutPrefix(addr, "tcp://"); ok {
+		addr = "http://" + rest
+	}
+	return &monitor{
+		addr:     strings.TrimSuffix(addr, "/"),
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: monitorTimeout},
+		problems: make(map[string]bool),
+	}
+}
+
+// run refreshes the dashboard every interval until context is done. If once is set, dashboard is printed once,
+// and error is returned if it couldn't be fetched.
+func (m *monitor) run(ctx context.Context, w io.Writer, interval time.Duration, once bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		snap := m.refresh(ctx)
+		if once {
+			renderMonitor(w, m.addr, snap)
+			if snap.Dashboard == nil {
+				return errors.New(snap.Errors[len(snap.Errors)-1].Message)
+			}
+			return nil
+		}
+		fmt.Fprint(w, clearScreen)
+		renderMonitor(w, m.addr, snap)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh fetches the dashboard and transactions of the recent blocks from the node.
+func (m *monitor) refresh(ctx context.Context) *monitorSnapshot {
+	snap := &monitorSnapshot{Time: time.Now()}
+	var dashboard node.ResultDashboard
+	if err := m.call(ctx, "dashboard", nil, &dashboard); err != nil {
+		m.recordError(snap.Time, fmt.Sprintf("failed to fetch dashboard: %v", err))
+	} else {
+		snap.Dashboard = &dashboard
+		m.recordProblems(snap.Time, &dashboard)
+		if rate, err := m.txRate(ctx, &dashboard); err != nil {
+			m.recordError(snap.Time, fmt.Sprintf("failed to fetch recent blocks: %v", err))
+		} else {
+			snap.TxRate = rate
+		}
+	}
+	snap.Errors = m.errors
+	return snap
+}
+
+// txRate returns the number of transactions per second in the recent blocks of the dashboard.
+func (m *monitor) txRate(ctx context.Context, d *node.ResultDashboard) (float64, error) {
+	if len(d.RecentBlocks) < 2 {
+		return 0, nil
+	}
+	newest, oldest := d.RecentBlocks[0], d.RecentBlocks[len(d.RecentBlocks)-1]
+	params := url.Values{}
+	params.Set("minHeight", strconv.FormatUint(oldest.Height, 10))
+	params.Set("maxHeight", strconv.FormatUint(newest.Height, 10))
+	var res ctypes.ResultBlockchainInfo
+	if err := m.call(ctx, "blockchain", params, &res); err != nil {
+		return 0, err
+	}
+	span := newest.Time.Sub(oldest.Time).Seconds()
+	if span <= 0 {
+		return 0, nil
+	}
+	// transactions of the oldest block were included before the measured span
+	txs := 0
+	for _, meta := range res.BlockMetas {
+		if uint64(meta.Header.Height) != oldest.Height { //nolint:gosec
+			txs += meta.NumTxs
+		}
+	}
+	return float64(txs) / span, nil
+}
+
+// recordProblems records problems reported by the node, which weren't reported in the previous refresh.
+func (m *monitor) recordProblems(t time.Time, d *node.ResultDashboard) {
+	problems := []struct {
+		message string
+		active  bool
+	}{
+		{"node is halted", d.Sync != nil && d.Sync.Halted},
+		{"block execution stopped because of app hash mismatch, syncing headers only", d.Sync != nil && d.Sync.HeadersOnly},
+		{"node is in safe mode, free disk space is low", d.Disk != nil && d.Disk.SafeMode},
+	}
+	for _, p := range problems {
+		if p.active && !m.problems[p.message] {
+			m.recordError(t, p.message)
+		}
+		m.problems[p.message] = p.active
+	}
+}
+
+// recordError records an error, keeping the latest monitorErrors errors.
+func (m *monitor) recordError(t time.Time, message string) {
+	m.errors = append(m.errors, monitorEvent{Time: t, Message: message})
+	if len(m.errors) > monitorErrors {
+		m.errors = m.errors[len(m.errors)-monitorErrors:]
+	}
+}
+
+// call calls RPC method of the node with given URI parameters and decodes the result.
+func (m *monitor) call(ctx context.Context, method string, params url.Values, result interface{}) error {
+	uri := m.addr + "/" + method
+	if len(params) > 0 {
+		uri += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+	if m.apiKey != "" {
+		req.Header.Set("X-API-Key", m.apiKey)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string      `json:"message"`
+			Data    interface{} `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("invalid response (HTTP status %d): %w", resp.StatusCode, err)
+	}
+	if rpcResp.Error != nil {
+		if rpcResp.Error.Data != nil {
+			return fmt.Errorf("%s: %v", rpcResp.Error.Message, rpcResp.Error.Data)
+		}
+		return errors.New(rpcResp.Error.Message)
+	}
+	return cmjson.Unmarshal(rpcResp.Result, result)
+}
+
+// renderMonitor writes the dashboard of given snapshot.
+func renderMonitor(w io.Writer, addr string, snap *monitorSnapshot) {
+	fmt.Fprintf(w, "Rollkit monitor  %s  %s\n\n", addr, snap.Time.Format(time.DateTime))
+	if d := snap.Dashboard; d != nil {
+		fmt.Fprintf(w, "Chain     %s (%s), node %s\n", d.ChainID, d.Mode, d.NodeID)
+		height := fmt.Sprintf("latest %d", d.LatestHeight)
+		if !d.LatestBlockTime.IsZero() {
+			height += fmt.Sprintf(" (%s ago)", snap.Time.Sub(d.LatestBlockTime).Round(time.Second))
+		}
+		fmt.Fprintf(w, "Height    %s, earliest %d\n", height, d.EarliestHeight)
+		if s := d.Sync; s != nil {
+			status := "synced"
+			if s.CatchingUp {
+				status = "catching up"
+			}
+			fmt.Fprintf(w, "Sync      target %d, %.1f%%, %s\n", s.TargetHeight, s.Progress*100, status)
+		}
+		if da := d.DA; da != nil {
+			fmt.Fprintf(w, "DA        height %d, included %d, pending headers %d, last submitted %d\n",
+				da.Height, da.IncludedHeight, da.PendingHeaders, da.LastSubmittedHeight)
+		}
+		fmt.Fprintf(w, "Peers     %d\n", d.Peers)
+		fmt.Fprintf(w, "Mempool   %d txs, %s\n", d.Mempool.Txs, formatBytes(uint64(max(d.Mempool.Bytes, 0))))
+		fmt.Fprintf(w, "Blocks    avg interval %s, throughput %.2f tx/s\n", formatMs(d.AvgBlockInterval), snap.TxRate)
+		if d.Disk != nil {
+			fmt.Fprintf(w, "Disk      used %s, free %s\n", formatBytes(d.Disk.Used), formatBytes(d.Disk.Free))
+		}
+
+		fmt.Fprintf(w, "\nRecent blocks\n")
+		for i, b := range d.RecentBlocks {
+			if i == monitorBlocks {
+				break
+			}
+			fmt.Fprintf(w, "  %-10d %s  +%s\n", b.Height, b.Time.Local().Format(time.TimeOnly), formatMs(b.Interval))
+		}
+		if len(d.RecentBlocks) == 0 {
+			fmt.Fprintf(w, "  none\n")
+		}
+	}
+
+	fmt.Fprintf(w, "\nRecent errors\n")
+	for i := len(snap.Errors) - 1; i >= 0; i-- {
+		fmt.Fprintf(w, "  %s  %s\n", snap.Errors[i].Time.Format(time.TimeOnly), snap.Errors[i].Message)
+	}
+	if len(snap.Errors) == 0 {
+		fmt.Fprintf(w, "  none\n")
+	}
+}
+
+// formatMs formats duration given in milliseconds.
+func formatMs(ms float64) string {
+	return time.Duration(ms * float64(time.Millisecond)).Round(time.Millisecond).String()
+}
+
+// formatBytes formats size in bytes with binary unit.
+func formatBytes(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cmjson "github.com/cometbft/cometbft/libs/json"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/node"
+)
+
+func TestMonitor(t *testing.T) {
+	require := require.New(t)
+	now := time.Now()
+
+	halted := false
+	failing := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("secret", r.Header.Get("X-API-Key"))
+		var result interface{}
+		switch r.URL.Path {
+		case "/dashboard":
+			if failing {
+				fmt.Fprint(w, `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"store closed"}}`)
+				return
+			}
+			result = &node.ResultDashboard{
+				ChainID:      "test",
+				Mode:         "aggregator",
+				LatestHeight: 12,
+				Sync:         &node.DashboardSync{TargetHeight: 12, Progress: 1, Halted: halted},
+				Peers:        3,
+				RecentBlocks: []node.DashboardBlock{
+					{Height: 12, Time: now, Interval: 1000},
+					{Height: 11, Time: now.Add(-time.Second), Interval: 1000},
+					{Height: 10, Time: now.Add(-2 * time.Second)},
+				},
+			}
+		case "/blockchain":
+			require.Equal("10", r.URL.Query().Get("minHeight"))
+			require.Equal("12", r.URL.Query().Get("maxHeight"))
+			res := &ctypes.ResultBlockchainInfo{LastHeight: 12}
+			for h := int64(12); h >= 10; h-- {
+				res.BlockMetas = append(res.BlockMetas, &cmtypes.BlockMeta{Header: cmtypes.Header{Height: h}, NumTxs: 5})
+			}
+			result = res
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		raw, err := cmjson.Marshal(result)
+		require.NoError(err)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":-1,"result":%s}`, raw)
+	}))
+	defer srv.Close()
+
+	m := newMonitor(srv.URL+"/", "secret")
+	var out bytes.Buffer
+	require.NoError(m.run(context.Background(), &out, time.Second, true))
+	require.Contains(out.String(), "latest 12")
+	require.Contains(out.String(), "Peers     3")
+	// transactions of the oldest block are not counted
+	require.Contains(out.String(), "throughput 5.00 tx/s")
+	require.Contains(out.String(), "Recent errors\n  none")
+
+	// problems reported by the node are recorded once
+	halted = true
+	m.refresh(context.Background())
+	snap := m.refresh(context.Background())
+	require.Len(snap.Errors, 1)
+	require.Equal("node is halted", snap.Errors[0].Message)
+
+	failing = true
+	out.Reset()
+	require.Error(m.run(context.Background(), &out, time.Second, true))
+	require.Contains(out.String(), "failed to fetch dashboard: Internal error: store closed")
+	require.Contains(out.String(), "node is halted")
+}
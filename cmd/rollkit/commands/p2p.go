@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	cometcli "github.com/cometbft/cometbft/libs/cli"
+	"github.com/spf13/cobra"
+
+	"github.com/rollkit/rollkit/p2p"
+)
+
+// NewP2PCmd creates a new cobra command group for P2P identity operations.
+func NewP2PCmd() *cobra.Command {
+	P2PCmd := &cobra.Command{
+		Use:     "p2p",
+		Short:   "P2P identity operations",
+		Long:    `This command group is used to manage P2P identity of the node.`,
+		Example: `  rollkit p2p rotate-key`,
+	}
+
+	P2PCmd.AddCommand(rotateKeyCmd)
+
+	return P2PCmd
+}
+
+var rotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "Replace P2P key of the node with a newly generated one",
+	Long: `This command replaces P2P key of the node with a newly generated one. The node must be stopped.
+
+Previous key is saved next to the node key. After restart, the node announces previous identity on
+--rollkit.p2p_previous_listen_address until --rollkit.p2p_key_rotation_grace_period passes, so that
+peers knowing only previous identity can still find the node. Peer address book is kept.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		home := os.Getenv("RKHOME")
+		if home == "" {
+			var err error
+			home, err = cmd.Flags().GetString(cometcli.HomeFlag)
+			if err != nil {
+				return err
+			}
+		}
+		config.RootDir = home
+
+		oldID, newID, err := p2p.RotateNodeKey(config.NodeKeyFile(), time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Rotated P2P key: %s -> %s\n", oldID, newID)
+		fmt.Fprintf(cmd.OutOrStdout(), "Previous key saved in %s\n", p2p.PreviousNodeKeyFile(config.NodeKeyFile()))
+		return nil
+	},
+}
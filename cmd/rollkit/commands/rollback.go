@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	cometlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/spf13/cobra"
+
+	"github.com/rollkit/rollkit/block"
+	rollconf "github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// NewRollbackCmd returns the command rolling back blocks and state of the node to given height.
+func NewRollbackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback [height]",
+		Short: "Roll back blocks and state of the node to given height",
+		Long: `This command removes blocks, signatures, responses and indexes above given height from the store and
+rewrites saved state to the state after applying the block at given height, like "tendermint rollback --hard".
+It can be used to recover from app hash mismatches or corrupted DA submissions. The node must be stopped.
+
+State of the application is not rolled back: it has to be rolled back to the same height separately, e.g. with
+rollback command of the application. After restart, blocks above given height are synced or produced again.
+DA height of the state is kept, so blocks which were already retrieved from DA are synced from peers.`,
+		Example: `  rollkit rollback 100`,
+		Args:    cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return parseConfig(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height %q: %w", args[0], err)
+			}
+			rollconf.GetNodeConfig(&nodeConfig, config)
+
+			if err := rollbackStore(cmd.Context(), nodeConfig, height); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Rolled back to height %d\n", height)
+			return nil
+		},
+	}
+
+	addNodeFlags(cmd)
+
+	return cmd
+}
+
+// rollbackStore opens the store of the node and rolls it back to given height. DA included height is lowered
+// to given height, so that blocks above it are submitted to DA again.
+func rollbackStore(ctx context.Context, nc rollconf.NodeConfig, height uint64) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s, err := openStore(ctx, nc)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := s.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close store: %w", cerr)
+		}
+	}()
+
+	if err := s.Rollback(ctx, height); err != nil {
+		return fmt.Errorf("failed to roll back store: %w", err)
+	}
+	daIncluded, err := s.GetMetadata(ctx, block.DAIncludedHeightKey)
+	if err == nil && len(daIncluded) == 8 && binary.BigEndian.Uint64(daIncluded) > height {
+		binary.BigEndian.PutUint64(daIncluded, height)
+		if err := s.SetMetadata(ctx, block.DAIncludedHeightKey, daIncluded); err != nil {
+			return fmt.Errorf("failed to update DA included height: %w", err)
+		}
+	}
+	return nil
+}
+
+// openStore opens the store of the node, decrypting it if encryption key is configured, and migrates it to the
+// current schema version. The configured hash function of transactions is set, as the store indexes transactions
+// by their hashes.
+func openStore(ctx context.Context, nc rollconf.NodeConfig) (store.Store, error) {
+	if nc.TxHash != "" {
+		if err := types.SetTxHasherByName(nc.TxHash); err != nil {
+			return nil, err
+		}
+	}
+	baseKV, err := store.NewBackendKVStore(nc.DBBackend, nc.RootDir, nc.DBPath, "rollkit", nc.DBGCDiscardRatio)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+	// blocks and state are stored under the same prefix as by the node
+	kv, err := node.MainKV(ctx, baseKV, nc)
+	if err != nil {
+		_ = baseKV.Close()
+		return nil, err
+	}
+	if err := store.Migrate(ctx, kv, cometlog.NewNopLogger()); err != nil {
+		_ = kv.Close()
+		return nil, err
+	}
+	return store.New(kv), nil
+}
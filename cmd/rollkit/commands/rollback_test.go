@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/block"
+	rollconf "github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/store"
+	rolltypes "github.com/rollkit/rollkit/types"
+)
+
+func TestRollbackStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	nc := rollconf.DefaultNodeConfig
+	nc.RootDir = t.TempDir()
+	nc.DBPath = "data"
+	open := func() store.Store {
+		s, err := openStore(ctx, nc)
+		require.NoError(err)
+		return s
+	}
+
+	s := open()
+	validatorSet := rolltypes.GetRandomValidatorSet()
+	for h := uint64(1); h <= 3; h++ {
+		header, data := rolltypes.GetRandomBlock(h, 1, "TestRollbackStore")
+		require.NoError(s.SaveBlockData(ctx, header, data, &rolltypes.Signature{}))
+		require.NoError(s.UpdateState(ctx, rolltypes.State{
+			LastBlockHeight: h,
+			AppHash:         []byte{byte(h)},
+			Validators:      validatorSet,
+			NextValidators:  validatorSet,
+			LastValidators:  validatorSet,
+		}))
+	}
+	daIncluded := make([]byte, 8)
+	binary.BigEndian.PutUint64(daIncluded, 3)
+	require.NoError(s.SetMetadata(ctx, block.DAIncludedHeightKey, daIncluded))
+	require.NoError(s.Close())
+
+	require.Error(rollbackStore(ctx, nc, 3))
+	require.NoError(rollbackStore(ctx, nc, 2))
+
+	s = open()
+	defer func() { require.NoError(s.Close()) }()
+	state, err := s.GetState(ctx)
+	require.NoError(err)
+	require.Equal(uint64(2), state.LastBlockHeight)
+	require.Equal(rolltypes.Hash{2}, state.AppHash)
+	_, _, err = s.GetBlockData(ctx, 3)
+	require.Error(err)
+	daIncluded, err = s.GetMetadata(ctx, block.DAIncludedHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(2), binary.BigEndian.Uint64(daIncluded))
+}
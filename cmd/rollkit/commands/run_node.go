@@ -17,10 +17,8 @@ import (
 	cometflags "github.com/cometbft/cometbft/libs/cli/flags"
 	cometlog "github.com/cometbft/cometbft/libs/log"
 	cometos "github.com/cometbft/cometbft/libs/os"
-	cometnode "github.com/cometbft/cometbft/node"
 	cometp2p "github.com/cometbft/cometbft/p2p"
 	cometprivval "github.com/cometbft/cometbft/privval"
-	cometproxy "github.com/cometbft/cometbft/proxy"
 	comettypes "github.com/cometbft/cometbft/types"
 	comettime "github.com/cometbft/cometbft/types/time"
 	"github.com/mitchellh/mapstructure"
@@ -37,7 +35,10 @@ import (
 
 	rollconf "github.com/rollkit/rollkit/config"
 	rollnode "github.com/rollkit/rollkit/node"
+	rollp2p "github.com/rollkit/rollkit/p2p"
 	rollrpc "github.com/rollkit/rollkit/rpc"
+	rpcjson "github.com/rollkit/rollkit/rpc/json"
+	"github.com/rollkit/rollkit/rpc/replay"
 	rolltypes "github.com/rollkit/rollkit/types"
 )
 
@@ -95,8 +96,7 @@ func NewRunNodeCmd() *cobra.Command {
 			return initFiles()
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			genDocProvider := cometnode.DefaultGenesisDocProviderFunc(config)
-			genDoc, err := genDocProvider()
+			genDoc, err := rolltypes.GenesisDocFromFile(config.GenesisFile())
 			if err != nil {
 				return err
 			}
@@ -125,6 +125,23 @@ func NewRunNodeCmd() *cobra.Command {
 				return err
 			}
 
+			// DA anchor from genesis provides defaults for DA namespace and start height
+			daGenesis, err := rollconf.DAGenesisFromFile(config.GenesisFile())
+			if err != nil {
+				return err
+			}
+			if err := nodeConfig.ApplyDAGenesis(daGenesis); err != nil {
+				return err
+			}
+			if daGenesis != nil {
+				logger.Info("using DA anchor from genesis", "daChainID", daGenesis.ChainID, "namespace", nodeConfig.DANamespace, "daStartHeight", nodeConfig.DAStartHeight)
+			}
+			// block time source is a chain-wide rule committed to in genesis
+			nodeConfig.BlockTimeSource, err = rollconf.BlockTimeSourceFromFile(config.GenesisFile())
+			if err != nil {
+				return err
+			}
+
 			// initialize the metrics
 			metrics := rollnode.DefaultMetricsProvider(cometconf.DefaultInstrumentationConfig())
 
@@ -170,7 +187,7 @@ func NewRunNodeCmd() *cobra.Command {
 				nodeConfig,
 				p2pKey,
 				signingKey,
-				cometproxy.DefaultClientCreator(config.ProxyApp, config.ABCI, nodeConfig.DBPath),
+				rollnode.NewClientCreator(config.ProxyApp, config.ABCI, nodeConfig.DBPath, nodeConfig.ABCIGRPCConnections),
 				genDoc,
 				metrics,
 				logger,
@@ -179,13 +196,61 @@ func NewRunNodeCmd() *cobra.Command {
 				return fmt.Errorf("failed to create new rollkit node: %w", err)
 			}
 
+			// announce identity used before P2P key rotation during grace period
+			prevNodeKey, err := rollp2p.LoadPreviousNodeKey(config.NodeKeyFile())
+			if err != nil {
+				return fmt.Errorf("failed to load previous node key: %w", err)
+			}
+			if n, ok := rollnode.(interface {
+				SetPreviousP2PKey(*rollp2p.RotatedKey) error
+			}); ok && prevNodeKey != nil {
+				if err := n.SetPreviousP2PKey(prevNodeKey); err != nil {
+					return fmt.Errorf("failed to set previous node key: %w", err)
+				}
+			}
+
+			var rpcOpts []rollrpc.ServerOption
+			if nodeConfig.RPCAdmin && nodeConfig.RPCAPIKeysFile == "" {
+				return errors.New("admin RPC methods require --rollkit.rpc_api_keys_file")
+			}
+			if nodeConfig.RPCAPIKeysFile != "" {
+				keys, err := rpcjson.LoadAPIKeys(nodeConfig.RPCAPIKeysFile)
+				if err != nil {
+					return fmt.Errorf("failed to load RPC API keys: %w", err)
+				}
+				var authMetrics *rpcjson.AuthMetrics
+				if config.Instrumentation.Prometheus {
+					authMetrics = rpcjson.PrometheusAuthMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID)
+				}
+				rpcOpts = append(rpcOpts, rollrpc.WithAPIKeys(keys, authMetrics))
+			}
+
+			if nodeConfig.RPCGraphQL {
+				rpcOpts = append(rpcOpts, rollrpc.WithGraphQL())
+			}
+
+			if nodeConfig.RPCAdmin {
+				rpcOpts = append(rpcOpts, rollrpc.WithAdminAPI())
+			}
+
+			if n := nodeConfig.Concurrency.RPCMaxConcurrentRequests; n > 0 {
+				rpcOpts = append(rpcOpts, rollrpc.WithMaxConcurrentRequests(n))
+			}
+
 			// Launch the RPC server
-			server := rollrpc.NewServer(rollnode, config.RPC, logger)
+			server := rollrpc.NewServer(rollnode, config.RPC, logger, rpcOpts...)
 			err = server.Start()
 			if err != nil {
 				return fmt.Errorf("failed to launch RPC server: %w", err)
 			}
 
+			if nodeConfig.EventReplayAddress != "" {
+				replayServer := replay.NewServer(rollnode.GetClient(), nodeConfig.EventReplayAddress, logger)
+				if err := replayServer.Start(); err != nil {
+					return fmt.Errorf("failed to launch event replay server: %w", err)
+				}
+			}
+
 			// Start the node
 			if err := rollnode.Start(); err != nil {
 				return fmt.Errorf("failed to start node: %w", err)
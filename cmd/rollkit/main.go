@@ -21,7 +21,14 @@ func main() {
 		cmd.NewRunNodeCmd(),
 		cmd.VersionCmd,
 		cmd.NewTomlCmd(),
+		cmd.NewP2PCmd(),
 		cmd.RebuildCmd,
+		cmd.NewDoctorCmd(),
+		cmd.NewBenchCmd(),
+		cmd.NewRollbackCmd(),
+		cmd.NewExportCmd(),
+		cmd.NewImportCmd(),
+		cmd.NewMonitorCmd(),
 	)
 
 	// In case there is a rollkit.toml file in the current dir or somewhere up the
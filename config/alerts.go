@@ -0,0 +1,27 @@
+package config
+
+// Formats of alert webhook payloads.
+const (
+	// AlertFormatSlack posts a message compatible with Slack incoming webhooks (and Mattermost, Discord /slack).
+	AlertFormatSlack = "slack"
+	// AlertFormatPagerDuty posts an event compatible with PagerDuty Events API v2.
+	AlertFormatPagerDuty = "pagerduty"
+)
+
+// AlertsConfig configures webhook notifications of critical events, like failing DA submissions, app hash
+// mismatch, chain halt, low disk space or a double-signing sequencer.
+type AlertsConfig struct {
+	// WebhookURL receives alerts. Alerts are disabled if empty.
+	WebhookURL string `mapstructure:"webhook_url"`
+	// Format of the webhook payload, slack or pagerduty.
+	Format string `mapstructure:"format"`
+	// PagerDutyRoutingKey is the integration key of PagerDuty service, required by pagerduty format.
+	PagerDutyRoutingKey string `mapstructure:"pagerduty_routing_key"`
+	// DASubmitFailures is the number of consecutive failed DA submission attempts which raises an alert.
+	DASubmitFailures uint64 `mapstructure:"da_submit_failures"`
+}
+
+// Enabled returns true if alert webhook is configured.
+func (c AlertsConfig) Enabled() bool {
+	return c.WebhookURL != ""
+}
@@ -0,0 +1,38 @@
+package config
+
+import "runtime"
+
+// ConcurrencyConfig groups limits of concurrency across the node, so that it can be tuned for the available
+// hardware, from a small VPS to a large server. GetViperConfig replaces zero values with defaults derived
+// from GOMAXPROCS, see WithDefaults. Components treat zero value as serial processing or no limit.
+type ConcurrencyConfig struct {
+	// DAFetchWorkers is the number of DA heights retrieved in parallel while syncing from DA. Headers are
+	// still processed in order of DA heights.
+	DAFetchWorkers int `mapstructure:"da_fetch_workers"`
+	// GossipValidationWorkers is the number of workers validating messages gossiped by peers.
+	GossipValidationWorkers int `mapstructure:"gossip_validation_workers"`
+	// RPCMaxConcurrentRequests is the maximum number of RPC requests handled at once, further requests wait.
+	// WebSocket connections are limited by RPC max open connections instead.
+	RPCMaxConcurrentRequests int `mapstructure:"rpc_max_concurrent_requests"`
+	// IndexerWorkers is the number of workers preparing index entries of transactions in a block.
+	IndexerWorkers int `mapstructure:"indexer_workers"`
+}
+
+// WithDefaults returns the config with zero values replaced by defaults derived from GOMAXPROCS.
+func (c ConcurrencyConfig) WithDefaults() ConcurrencyConfig {
+	procs := runtime.GOMAXPROCS(0)
+	if c.DAFetchWorkers <= 0 {
+		// retrieval is bound by DA latency rather than CPU, but blobs are decoded in parallel too
+		c.DAFetchWorkers = min(procs, 4)
+	}
+	if c.GossipValidationWorkers <= 0 {
+		c.GossipValidationWorkers = procs
+	}
+	if c.RPCMaxConcurrentRequests <= 0 {
+		c.RPCMaxConcurrentRequests = 8 * procs
+	}
+	if c.IndexerWorkers <= 0 {
+		c.IndexerWorkers = procs
+	}
+	return c
+}
@@ -34,6 +34,20 @@ const (
 	FlagLight = "rollkit.light"
 	// FlagTrustedHash is a flag for specifying the trusted hash
 	FlagTrustedHash = "rollkit.trusted_hash"
+	// FlagTrustedHeight is a flag for specifying the height of the trusted block full node starts syncing from
+	FlagTrustedHeight = "rollkit.trusted_height"
+	// FlagBackfill is a flag for enabling backfill of blocks below trusted height
+	FlagBackfill = "rollkit.backfill"
+	// FlagSyncProfile is a flag for specifying the sync profile of full node
+	FlagSyncProfile = "rollkit.sync_profile"
+	// FlagTrustingPeriod is a flag for specifying how long a synced header is trusted
+	FlagTrustingPeriod = "rollkit.trusting_period"
+	// FlagHeaderRetentionBlocks is a flag for specifying the number of recent headers kept by light nodes
+	FlagHeaderRetentionBlocks = "rollkit.header_retention_blocks"
+	// FlagHeaderCheckpointInterval is a flag for specifying the interval of checkpoint headers kept by light nodes
+	FlagHeaderCheckpointInterval = "rollkit.header_checkpoint_interval"
+	// FlagStoreHeaderExchange is a flag for serving headers of stored blocks over go-header exchange protocol
+	FlagStoreHeaderExchange = "rollkit.store_header_exchange"
 	// FlagLazyAggregator is a flag for enabling lazy aggregation
 	FlagLazyAggregator = "rollkit.lazy_aggregator"
 	// FlagMaxPendingBlocks is a flag to pause aggregator in case of large number of blocks pending DA submission
@@ -46,6 +60,203 @@ const (
 	FlagSequencerAddress = "rollkit.sequencer_address"
 	// FlagSequencerRollupID is a flag for specifying the sequencer middleware rollup ID
 	FlagSequencerRollupID = "rollkit.sequencer_rollup_id"
+	// FlagSequencerTLS is a flag for enabling TLS on the connection to sequencer middleware
+	FlagSequencerTLS = "rollkit.sequencer_tls"
+	// FlagSequencerTLSCAFile is a flag for specifying the CA certificates used to verify sequencer middleware certificate
+	FlagSequencerTLSCAFile = "rollkit.sequencer_tls_ca_file"
+	// FlagSequencerTLSPin is a flag for specifying the pinned public key of sequencer middleware certificate
+	FlagSequencerTLSPin = "rollkit.sequencer_tls_pin"
+	// FlagSequencerAuthToken is a flag for specifying the sequencer middleware auth token
+	FlagSequencerAuthToken = "rollkit.sequencer_auth_token" // #nosec G101
+	// FlagAppHashMismatchPolicy is a flag for specifying how to react to app hash mismatch while syncing
+	FlagAppHashMismatchPolicy = "rollkit.app_hash_mismatch_policy"
+	// FlagMaxBlockTime is a flag for specifying the upper bound of block time adapted to DA throughput
+	FlagMaxBlockTime = "rollkit.max_block_time"
+	// FlagSystemTxs is a flag for specifying system transactions required at fixed positions of every block
+	FlagSystemTxs = "rollkit.system_txs"
+	// FlagFinalitySLA is a flag for specifying the maximal expected latency between soft confirmation and DA inclusion
+	FlagFinalitySLA = "rollkit.finality_sla"
+	// FlagMaxPendingHeaders is a flag to pause syncing of gossiped blocks too far ahead of DA included height
+	FlagMaxPendingHeaders = "rollkit.max_pending_headers"
+	// FlagHaltHeight is a flag for specifying the height of the last block before chain is halted
+	FlagHaltHeight = "rollkit.halt_height"
+	// FlagHaltTime is a flag for specifying the time (unix seconds) at which chain is halted
+	FlagHaltTime = "rollkit.halt_time"
+	// FlagResume is a flag for resuming chain halted at halt height or time
+	FlagResume = "rollkit.resume"
+	// FlagMaxClockDrift is a flag for specifying how far ahead of local clock synced block time can be
+	FlagMaxClockDrift = "rollkit.max_clock_drift"
+	// FlagSequencerDowntimeThreshold is a flag for specifying the number of DA blocks without sequencer headers after which blocks are derived from DA only
+	FlagSequencerDowntimeThreshold = "rollkit.sequencer_downtime_threshold"
+	// FlagDAForcedInclusionNamespace is a flag for specifying the DA namespace of transactions posted directly to DA
+	FlagDAForcedInclusionNamespace = "rollkit.da_forced_inclusion_namespace"
+	// FlagDAVerifyInterval is a flag for specifying the interval of re-verifying retrievability of historical blocks from DA
+	FlagDAVerifyInterval = "rollkit.da_verify_interval"
+	// FlagDAVerifySamples is a flag for specifying the number of historical blocks re-verified in each run
+	FlagDAVerifySamples = "rollkit.da_verify_samples"
+	// FlagDACertificates is a flag for enabling persistence of DA inclusion certificates of blocks
+	FlagDACertificates = "rollkit.da_certificates"
+	// FlagPipelineExecution is a flag for enabling execution of the next block while the previous one is finalized
+	FlagPipelineExecution = "rollkit.pipeline_execution"
+	// FlagConcurrencyDAFetchWorkers is a flag for specifying the number of DA heights retrieved in parallel
+	FlagConcurrencyDAFetchWorkers = "rollkit.concurrency.da_fetch_workers"
+	// FlagConcurrencyGossipValidationWorkers is a flag for specifying the number of workers validating gossiped messages
+	FlagConcurrencyGossipValidationWorkers = "rollkit.concurrency.gossip_validation_workers"
+	// FlagConcurrencyRPCMaxConcurrentRequests is a flag for specifying the maximum number of RPC requests handled at once
+	FlagConcurrencyRPCMaxConcurrentRequests = "rollkit.concurrency.rpc_max_concurrent_requests"
+	// FlagConcurrencyIndexerWorkers is a flag for specifying the number of workers indexing transactions
+	FlagConcurrencyIndexerWorkers = "rollkit.concurrency.indexer_workers"
+	// FlagMessagingSourceChainID is a flag for specifying the chain ID of the rollup sending cross-rollup messages
+	FlagMessagingSourceChainID = "rollkit.messaging.source_chain_id"
+	// FlagMessagingSourceNamespace is a flag for specifying the DA namespace of the rollup sending cross-rollup messages
+	FlagMessagingSourceNamespace = "rollkit.messaging.source_namespace"
+	// FlagMessagingSourceSequencerKey is a flag for specifying the sequencer public key of the rollup sending cross-rollup messages
+	FlagMessagingSourceSequencerKey = "rollkit.messaging.source_sequencer_key"
+	// FlagAlertsWebhookURL is a flag for specifying the URL receiving alerts on critical events
+	FlagAlertsWebhookURL = "rollkit.alerts.webhook_url"
+	// FlagAlertsFormat is a flag for specifying the format of alert webhook payload
+	FlagAlertsFormat = "rollkit.alerts.format"
+	// FlagAlertsPagerDutyRoutingKey is a flag for specifying the PagerDuty integration key
+	FlagAlertsPagerDutyRoutingKey = "rollkit.alerts.pagerduty_routing_key"
+	// FlagAlertsDASubmitFailures is a flag for specifying the number of consecutive failed DA submissions raising an alert
+	FlagAlertsDASubmitFailures = "rollkit.alerts.da_submit_failures"
+	// FlagDASignerKeyName is a flag for specifying the name of the key of the DA account in the keyring of the DA node
+	FlagDASignerKeyName = "rollkit.da_signer.key_name"
+	// FlagDASignerAddress is a flag for specifying the address of the DA account paying for submissions
+	FlagDASignerAddress = "rollkit.da_signer.address"
+	// FlagDASignerMinBalance is a flag for specifying the DA account balance below which an alert is raised
+	FlagDASignerMinBalance = "rollkit.da_signer.min_balance"
+	// FlagDASignerThrottleBalance is a flag for specifying the DA account balance below which block production is slowed down
+	FlagDASignerThrottleBalance = "rollkit.da_signer.throttle_balance"
+	// FlagDASignerBalanceCheckInterval is a flag for specifying the interval between DA account balance checks
+	FlagDASignerBalanceCheckInterval = "rollkit.da_signer.balance_check_interval"
+	// FlagMaxDecodeBytes is a flag for specifying the maximum size of decoded blocks and state
+	FlagMaxDecodeBytes = "rollkit.max_decode_bytes"
+	// FlagMaxDecodeTxs is a flag for specifying the maximum number of transactions in decoded block data
+	FlagMaxDecodeTxs = "rollkit.max_decode_txs"
+	// FlagMaxDecodeValidators is a flag for specifying the maximum number of validators in decoded validator sets
+	FlagMaxDecodeValidators = "rollkit.max_decode_validators"
+	// FlagTxHash is a flag for specifying the hash function identifying transactions
+	FlagTxHash = "rollkit.tx_hash"
+	// FlagRPCAPIKeysFile is a flag for specifying the file with API keys required by RPC
+	FlagRPCAPIKeysFile = "rollkit.rpc_api_keys_file"
+	// FlagRPCGraphQL is a flag for enabling GraphQL endpoint in RPC
+	FlagRPCGraphQL = "rollkit.rpc_graphql"
+	// FlagRPCAdmin is a flag for enabling admin methods in RPC
+	FlagRPCAdmin = "rollkit.rpc_admin"
+	// FlagRPCMinGasPrice is a flag for specifying the minimum gas price reported by estimate_gas
+	FlagRPCMinGasPrice = "rollkit.rpc_min_gas_price"
+	// FlagRPCEstimateGasQuery is a flag for specifying the ABCI query path used by estimate_gas to simulate transactions
+	FlagRPCEstimateGasQuery = "rollkit.rpc_estimate_gas_query"
+	// FlagEventReplayAddress is a flag for the listen address of gRPC event replay service
+	FlagEventReplayAddress = "rollkit.event_replay_address"
+	// FlagTraceProxyApp is a flag for specifying the address of the ABCI app used for tracing transactions
+	FlagTraceProxyApp = "rollkit.trace_proxy_app"
+	// FlagDBBackend is a flag for specifying the database backend
+	FlagDBBackend = "rollkit.db_backend"
+	// FlagDBEncryptionKey is a flag for specifying the key encrypting blocks and state in the database
+	FlagDBEncryptionKey = "rollkit.db_encryption_key" // #nosec G101
+	// FlagDBEncryptionKeyFile is a flag for specifying the file with the key encrypting blocks and state in the database
+	FlagDBEncryptionKeyFile = "rollkit.db_encryption_key_file" // #nosec G101
+	// FlagDBGCInterval is a flag for specifying the interval of database value log garbage collection
+	FlagDBGCInterval = "rollkit.db_gc_interval"
+	// FlagDBGCDiscardRatio is a flag for specifying the discard ratio of database value log garbage collection
+	FlagDBGCDiscardRatio = "rollkit.db_gc_discard_ratio"
+	// FlagDBSyncPolicy is a flag for specifying when database writes are synced to disk
+	FlagDBSyncPolicy = "rollkit.db_sync_policy"
+	// FlagDBSyncBlocks is a flag for specifying the number of blocks between database syncs with interval sync policy
+	FlagDBSyncBlocks = "rollkit.db_sync_blocks"
+	// FlagEventRetentionBlocks is a flag for specifying the number of latest blocks which events are retained
+	FlagEventRetentionBlocks = "rollkit.event_retention_blocks"
+	// FlagEventPruneInterval is a flag for specifying the interval between event pruning runs
+	FlagEventPruneInterval = "rollkit.event_prune_interval"
+	// FlagBlockDataRetentionBlocks is a flag for specifying the number of latest blocks which data is retained
+	FlagBlockDataRetentionBlocks = "rollkit.block_data_retention_blocks"
+	// FlagBlockDataPruneInterval is a flag for specifying the interval between block data pruning runs
+	FlagBlockDataPruneInterval = "rollkit.block_data_prune_interval"
+	// FlagKeepRecent is a flag for specifying the number of latest blocks kept in the store
+	FlagKeepRecent = "rollkit.keep_recent"
+	// FlagPruneInterval is a flag for specifying the interval between block pruning runs
+	FlagPruneInterval = "rollkit.prune_interval"
+	// FlagPreconfirmationWindow is a flag for specifying the number of blocks in which sequencer promises to include preconfirmed transactions
+	FlagPreconfirmationWindow = "rollkit.preconfirmation_window"
+	// FlagPersistQueues is a flag for enabling persistence of mempool and batch queue on graceful shutdown
+	FlagPersistQueues = "rollkit.persist_queues"
+	// FlagDBMinFreeDiskMB is a flag for specifying the free disk space below which the node enters safe mode
+	FlagDBMinFreeDiskMB = "rollkit.db_min_free_disk_mb"
+	// FlagDBStatsInterval is a flag for specifying the interval between collections of store usage statistics
+	FlagDBStatsInterval = "rollkit.db_stats_interval"
+	// FlagMemorySoftLimitMB is a flag for specifying the heap usage above which the node sheds load
+	FlagMemorySoftLimitMB = "rollkit.memory_soft_limit_mb"
+	// FlagMemoryHardLimitMB is a flag for specifying the heap usage above which the node also shrinks caches
+	FlagMemoryHardLimitMB = "rollkit.memory_hard_limit_mb"
+	// FlagReadOnly is a flag for running node in read-only mode, serving RPC from existing store
+	FlagReadOnly = "rollkit.read_only"
+	// FlagReplicationAddress is a flag for the listen address of gRPC store replication service
+	FlagReplicationAddress = "rollkit.replication_address"
+	// FlagReplicateFrom is a flag for the address of primary node's store replication service
+	FlagReplicateFrom = "rollkit.replicate_from"
+	// FlagP2PAnnounceAddresses is a flag for specifying the P2P addresses advertised to peers instead of listen addresses
+	FlagP2PAnnounceAddresses = "rollkit.p2p_announce_addresses"
+	// FlagP2PPreviousListenAddress is a flag for specifying the listen address of P2P identity used before key rotation
+	FlagP2PPreviousListenAddress = "rollkit.p2p_previous_listen_address"
+	// FlagP2PKeyRotationGracePeriod is a flag for specifying how long P2P identity used before key rotation is announced
+	FlagP2PKeyRotationGracePeriod = "rollkit.p2p_key_rotation_grace_period"
+	// FlagTelemetryEndpoint is a flag for specifying the endpoint receiving anonymized node telemetry reports
+	FlagTelemetryEndpoint = "rollkit.telemetry_endpoint"
+	// FlagTelemetryInterval is a flag for specifying how often node telemetry is reported
+	FlagTelemetryInterval = "rollkit.telemetry_interval"
+	// FlagABCIRetryInterval is a flag for specifying the initial interval between attempts to reconnect to ABCI app
+	FlagABCIRetryInterval = "rollkit.abci_retry_interval"
+	// FlagABCIReconnectTimeout is a flag for specifying how long the node tries to reconnect to ABCI app before stopping
+	FlagABCIReconnectTimeout = "rollkit.abci_reconnect_timeout"
+	// FlagABCICheckTxTimeout is a flag for specifying the timeout of CheckTx calls to ABCI app
+	FlagABCICheckTxTimeout = "rollkit.abci_check_tx_timeout"
+	// FlagABCIFinalizeBlockTimeout is a flag for specifying the timeout of FinalizeBlock calls to ABCI app
+	FlagABCIFinalizeBlockTimeout = "rollkit.abci_finalize_block_timeout"
+	// FlagABCICommitTimeout is a flag for specifying the timeout of Commit calls to ABCI app
+	FlagABCICommitTimeout = "rollkit.abci_commit_timeout"
+	// FlagABCIGRPCConnections is a flag for specifying the number of gRPC connections to ABCI app
+	FlagABCIGRPCConnections = "rollkit.abci_grpc_connections"
+	// FlagABCICheckTxConcurrency is a flag for specifying the number of ABCI connections used to check new transactions
+	FlagABCICheckTxConcurrency = "rollkit.abci_check_tx_concurrency"
+)
+
+const (
+	// AppHashMismatchHalt stops the node when a synced block's app hash doesn't match local execution.
+	AppHashMismatchHalt = "halt"
+	// AppHashMismatchRollback rolls the store back to the height before the diverged block and stops the node, so
+	// that the block is re-fetched from DA and re-executed after the app is rolled back to the same height.
+	AppHashMismatchRollback = "rollback"
+	// AppHashMismatchHeadersOnly stops block execution and keeps the node following headers only.
+	AppHashMismatchHeadersOnly = "headers_only"
+)
+
+const (
+	// DBBackendBadger stores data in BadgerDB, the default backend.
+	DBBackendBadger = "badger"
+	// DBBackendPebble stores data in Pebble, it requires binary built with pebbledb build tag.
+	DBBackendPebble = "pebble"
+	// DBBackendLevelDB stores data in goleveldb.
+	DBBackendLevelDB = "leveldb"
+	// DBBackendMemory keeps data in memory only, it's lost on restart.
+	DBBackendMemory = "memory"
+)
+
+const (
+	// DBSyncPolicyBlock syncs database to disk after every committed block.
+	DBSyncPolicyBlock = "block"
+	// DBSyncPolicyInterval syncs database to disk after every DBSyncBlocks committed blocks.
+	DBSyncPolicyInterval = "interval"
+	// DBSyncPolicyAsync leaves syncing to the database, which writes data to disk in the background.
+	DBSyncPolicyAsync = "async"
+)
+
+const (
+	// TimeSourceSequencer uses wall clock time of the sequencer, as reported with the batch.
+	TimeSourceSequencer = "sequencer"
+	// TimeSourceDA uses time of the latest DA block observed by the aggregator.
+	TimeSourceDA = "da"
 )
 
 // NodeConfig stores Rollkit node configuration.
@@ -71,11 +282,161 @@ type NodeConfig struct {
 	DANamespace       string `mapstructure:"da_namespace"`
 	SequencerAddress  string `mapstructure:"sequencer_address"`
 	SequencerRollupID string `mapstructure:"sequencer_rollup_id"`
+
+	// SequencerTLS secures the connection to sequencer with TLS. Sequencer certificate is verified against
+	// SequencerTLSCAFile (PEM encoded CA certificates), or system CA certificates if it's empty.
+	SequencerTLS       bool   `mapstructure:"sequencer_tls"`
+	SequencerTLSCAFile string `mapstructure:"sequencer_tls_ca_file"`
+	// SequencerTLSPin is the base64 encoded SHA-256 hash of sequencer certificate's public key (SPKI). If set,
+	// certificate must match it; without SequencerTLSCAFile, certificate chain is not verified (e.g. self-signed).
+	SequencerTLSPin string `mapstructure:"sequencer_tls_pin"`
+	// SequencerAuthToken is sent as bearer token with every request to sequencer, to authenticate the rollup.
+	// Requires TLS.
+	SequencerAuthToken string `mapstructure:"sequencer_auth_token"`
+
+	// DAForcedInclusionNamespace is the DA namespace of transactions posted directly to DA, used in DA-only mode.
+	DAForcedInclusionNamespace string `mapstructure:"da_forced_inclusion_namespace"`
+
+	// MaxDecodeBytes, MaxDecodeTxs and MaxDecodeValidators limit the size of blocks and state decoded from
+	// untrusted sources (DA and peers), to protect the node from running out of memory. 0 disables a limit.
+	MaxDecodeBytes      uint64 `mapstructure:"max_decode_bytes"`
+	MaxDecodeTxs        uint64 `mapstructure:"max_decode_txs"`
+	MaxDecodeValidators uint64 `mapstructure:"max_decode_validators"`
+	// TxHash is the hash function identifying transactions in RPC responses, the transaction indexer and logs,
+	// sha256 or keccak256. If empty, SHA-256 or hash function set by the app with types.SetTxHasher is used.
+	TxHash string `mapstructure:"tx_hash"`
+
+	// RPCAPIKeysFile is the path to JSON file with API keys required to access RPC.
+	// RPC doesn't require authentication if empty.
+	RPCAPIKeysFile string `mapstructure:"rpc_api_keys_file"`
+
+	// RPCGraphQL enables GraphQL endpoint for querying blocks, transactions and events.
+	RPCGraphQL bool `mapstructure:"rpc_graphql"`
+	// RPCAdmin enables admin RPC methods, e.g. scheduling chain halt. It requires RPCAPIKeysFile, admin methods
+	// are allowed only for API keys listing them in admin_methods.
+	RPCAdmin bool `mapstructure:"rpc_admin"`
+	// RPCMinGasPrice is the minimum gas price (e.g. "0.025stake") reported by estimate_gas, used by
+	// clients to compute fees. It should match the minimum gas price enforced by the app.
+	RPCMinGasPrice string `mapstructure:"rpc_min_gas_price"`
+	// RPCEstimateGasQuery is the ABCI query path used by estimate_gas to simulate transactions. App is
+	// expected to return protobuf encoded ResponseCheckTx as query value. If empty, CheckTx is used.
+	RPCEstimateGasQuery string `mapstructure:"rpc_estimate_gas_query"`
+	// EventReplayAddress is the listen address of gRPC service replaying stored events. Service is disabled if empty.
+	EventReplayAddress string `mapstructure:"event_replay_address"`
+
+	// TraceProxyApp is the address of the ABCI app used by debug_traceTx to re-execute blocks. Tracing is
+	// disabled if empty. The method is enabled with admin methods, see RPCAdmin.
+	TraceProxyApp string `mapstructure:"trace_proxy_app"`
+
+	// DBBackend is the key-value database storing blocks and state: badger, pebble, leveldb or memory.
+	// Garbage collection settings apply only to badger.
+	DBBackend string `mapstructure:"db_backend"`
+	// DBEncryptionKey is the hex encoded AES key (16, 24 or 32 bytes) encrypting blocks, signatures, state,
+	// validators and transaction and block indexes at rest with AES-GCM. It should be supplied with
+	// RK_ROLLKIT_DB_ENCRYPTION_KEY environment variable rather than a flag. Encryption is disabled if neither the
+	// key nor DBEncryptionKeyFile is set.
+	DBEncryptionKey string `mapstructure:"db_encryption_key"`
+	// DBEncryptionKeyFile is the path to the file with hex encoded database encryption key, see DBEncryptionKey.
+	DBEncryptionKeyFile string `mapstructure:"db_encryption_key_file"`
+	// DBGCInterval is the interval between database value log garbage collection runs. 0 disables GC.
+	DBGCInterval time.Duration `mapstructure:"db_gc_interval"`
+	// DBGCDiscardRatio is the minimal fraction of stale data required to rewrite a value log file during GC.
+	DBGCDiscardRatio float64 `mapstructure:"db_gc_discard_ratio"`
+	// DBSyncPolicy defines when database writes are synced (fsync) to disk: after every block (block), every
+	// DBSyncBlocks blocks (interval), or in the background (async). Blocks committed since the last sync may be
+	// lost on crash, and are synced again from DA or peers after restart, trading durability for throughput.
+	DBSyncPolicy string `mapstructure:"db_sync_policy"`
+	// DBSyncBlocks is the number of blocks between database syncs with interval sync policy.
+	DBSyncBlocks uint64 `mapstructure:"db_sync_blocks"`
+	// EventRetentionBlocks is the number of latest blocks which indexed transactions, block events and block
+	// responses are retained. Older event data is pruned, blocks are kept. 0 disables pruning.
+	EventRetentionBlocks uint64 `mapstructure:"event_retention_blocks"`
+	// EventPruneInterval is the interval between event pruning runs.
+	EventPruneInterval time.Duration `mapstructure:"event_prune_interval"`
+	// BlockDataRetentionBlocks is the number of latest blocks which data (transactions) is retained. Headers and
+	// hashes of transactions of older blocks are kept, so that inclusion proofs can still be served. 0 disables pruning.
+	BlockDataRetentionBlocks uint64 `mapstructure:"block_data_retention_blocks"`
+	// BlockDataPruneInterval is the interval between block data pruning runs.
+	BlockDataPruneInterval time.Duration `mapstructure:"block_data_prune_interval"`
+	// KeepRecent is the number of latest blocks kept in the store. Older blocks, along with their signatures,
+	// results and state records, are deleted; blocks which are not DA included yet are kept. Latest state is
+	// kept. 0 disables pruning.
+	KeepRecent uint64 `mapstructure:"keep_recent"`
+	// PruneInterval is the interval between block pruning runs.
+	PruneInterval time.Duration `mapstructure:"prune_interval"`
+	// PreconfirmationWindow is the number of blocks in which the sequencer promises to include transactions it signs
+	// preconfirmations for. 0 disables preconfirmations.
+	PreconfirmationWindow uint64 `mapstructure:"preconfirmation_window"`
+	// PersistQueues enables persistence of mempool and batch queue on graceful shutdown (e.g. SIGTERM). Graceful
+	// shutdown is recorded in the store; after unclean shutdown, store integrity is checked and batches lost with
+	// the batch queue are requested from the sequencer again.
+	PersistQueues bool `mapstructure:"persist_queues"`
+	// DBMinFreeDiskMB is the free disk space (in MiB) below which the node switches to safe mode,
+	// neither producing nor applying blocks. 0 disables the check.
+	DBMinFreeDiskMB uint64 `mapstructure:"db_min_free_disk_mb"`
+	// DBStatsInterval is the interval between collections of store usage statistics, reported as metrics.
+	// Collection iterates over all keys in the store. 0 disables periodic collection.
+	DBStatsInterval time.Duration `mapstructure:"db_stats_interval"`
+
+	// MemorySoftLimitMB is the heap usage (in MiB) above which the node sheds load: new RPC subscriptions
+	// are rejected and DA is not queried ahead of DA block time. 0 disables the limit.
+	MemorySoftLimitMB uint64 `mapstructure:"memory_soft_limit_mb"`
+	// MemoryHardLimitMB is the heap usage (in MiB) above which the node additionally shrinks caches and
+	// returns memory to OS. 0 disables the limit.
+	MemoryHardLimitMB uint64 `mapstructure:"memory_hard_limit_mb"`
+
+	// ReadOnly runs node serving RPC from a store opened in read-only mode. Node neither syncs nor
+	// produces blocks, so the store must be a snapshot of a store of another node, which is not running on it.
+	ReadOnly bool `mapstructure:"read_only"`
+	// ReplicationAddress is the listen address of gRPC service streaming store entries to follower nodes.
+	// Service is disabled if empty.
+	ReplicationAddress string `mapstructure:"replication_address"`
+	// ReplicateFrom is the address of primary node's replication service. If set, read-only node
+	// opens its store for writing and replicates entries from the primary node, instead of serving a copy.
+	ReplicateFrom string `mapstructure:"replicate_from"`
+
+	// TelemetryEndpoint is the URL receiving periodic reports of anonymized node health (version, height,
+	// peers, sync lag). Telemetry is opt-in, it's disabled if empty.
+	TelemetryEndpoint string `mapstructure:"telemetry_endpoint"`
+	// TelemetryInterval is the interval between telemetry reports.
+	TelemetryInterval time.Duration `mapstructure:"telemetry_interval"`
+
+	// ABCIRetryInterval is the initial interval between attempts to reconnect to ABCI app after connection
+	// is broken (e.g. app process was restarted). Interval is doubled after each failed attempt.
+	ABCIRetryInterval time.Duration `mapstructure:"abci_retry_interval"`
+	// ABCIReconnectTimeout is how long the node tries to reconnect to ABCI app before it's stopped.
+	// 0 disables reconnecting, node is stopped as soon as connection is broken.
+	ABCIReconnectTimeout time.Duration `mapstructure:"abci_reconnect_timeout"`
+	// ABCICheckTxTimeout, ABCIFinalizeBlockTimeout and ABCICommitTimeout limit duration of respective calls
+	// to ABCI app, so that hung app doesn't freeze the node silently. Call exceeding the limit fails with
+	// an error. 0 disables the timeout.
+	ABCICheckTxTimeout       time.Duration `mapstructure:"abci_check_tx_timeout"`
+	ABCIFinalizeBlockTimeout time.Duration `mapstructure:"abci_finalize_block_timeout"`
+	ABCICommitTimeout        time.Duration `mapstructure:"abci_commit_timeout"`
+	// ABCIGRPCConnections is the number of gRPC connections shared by all ABCI connections, when ABCI app
+	// is accessed over gRPC (grpc:// proxy_app address or grpc transport).
+	ABCIGRPCConnections int `mapstructure:"abci_grpc_connections"`
+	// ABCICheckTxConcurrency is the number of ABCI connections used to check new transactions concurrently.
+	// Transactions may enter the mempool in different order than they were received. 1 checks transactions
+	// serially, in order, which is required by apps that depend on the order of CheckTx calls.
+	ABCICheckTxConcurrency int `mapstructure:"abci_check_tx_concurrency"`
 }
 
 // HeaderConfig allows node to pass the initial trusted header hash to start the header exchange service
 type HeaderConfig struct {
 	TrustedHash string `mapstructure:"trusted_hash"`
+	// TrustingPeriod is how long the latest synced header is trusted. If the node was offline for longer, it
+	// re-initializes from the head of a trusted peer instead of syncing from its stale head.
+	TrustingPeriod time.Duration `mapstructure:"trusting_period"`
+	// HeaderRetentionBlocks is the number of the most recent headers kept by light nodes. Older headers are
+	// pruned, except checkpoints. 0 keeps all headers.
+	HeaderRetentionBlocks uint64 `mapstructure:"header_retention_blocks"`
+	// HeaderCheckpointInterval is the interval of heights of headers kept by light nodes after pruning. Hashes
+	// of checkpoint headers can be used as trusted hash to initialize new nodes. 0 disables checkpoints.
+	HeaderCheckpointInterval uint64 `mapstructure:"header_checkpoint_interval"`
+	// StoreHeaderExchange enables serving headers of all stored blocks, including blocks synced from DA, over
+	// go-header exchange protocol, so that go-header light clients can sync headers from full nodes.
+	StoreHeaderExchange bool `mapstructure:"store_header_exchange"`
 }
 
 // BlockManagerConfig consists of all parameters required by BlockManagerConfig
@@ -96,6 +457,68 @@ type BlockManagerConfig struct {
 	// LazyBlockTime defines how often new blocks are produced in lazy mode
 	// even if there are no transactions
 	LazyBlockTime time.Duration `mapstructure:"lazy_block_time"`
+	// AppHashMismatchPolicy defines how the node reacts when app hash of a synced block
+	// doesn't match the result of local execution (halt, rollback or headers_only).
+	AppHashMismatchPolicy string `mapstructure:"app_hash_mismatch_policy"`
+	// MaxBlockTime enables adapting block time to DA throughput. Block time is increased
+	// up to MaxBlockTime during DA congestion, and decreased back to BlockTime afterwards.
+	// 0 means block time is fixed.
+	MaxBlockTime time.Duration `mapstructure:"max_block_time"`
+	// SystemTxs defines system transactions (e.g. oracle updates) required at fixed positions of every block, as
+	// a comma separated list of kind:position pairs, e.g. "oracle:0,beacon:-1". See state.ProposalTemplate.
+	SystemTxs string `mapstructure:"system_txs"`
+	// FinalitySLA is the maximal expected latency between soft confirmation of a block and its inclusion in DA.
+	// Blocks exceeding it are counted as SLA violations. 0 disables counting.
+	FinalitySLA time.Duration `mapstructure:"finality_sla"`
+	// MaxPendingHeaders defines how many heights full node can apply ahead of DA included height. 0 means no limit.
+	// When limit is reached, blocks received via P2P are not applied until they (or later blocks) are included in DA.
+	MaxPendingHeaders uint64 `mapstructure:"max_pending_headers"`
+	// HaltHeight is the height of the last block produced or applied before the chain is halted. 0 means no halt height.
+	HaltHeight uint64 `mapstructure:"halt_height"`
+	// HaltTime is the time (in unix seconds) since which blocks are no longer produced or applied. 0 means no halt time.
+	HaltTime uint64 `mapstructure:"halt_time"`
+	// Resume clears the marker persisted when the chain was halted, so that blocks are produced and applied again.
+	// Halt height and time already reached are ignored.
+	Resume bool `mapstructure:"resume"`
+	// BlockTimeSource defines where the time recorded in block headers comes from (sequencer or da).
+	// It's a chain-wide rule committed to in genesis (see BlockTimeSourceFromJSON), so it's not configurable
+	// per node: all nodes use the source from genesis to validate synced blocks.
+	BlockTimeSource string `mapstructure:"-"`
+	// MaxClockDrift defines how far ahead of local clock the time of synced block can be. 0 disables the check.
+	MaxClockDrift time.Duration `mapstructure:"max_clock_drift"`
+	// SequencerDowntimeThreshold is the number of consecutive DA blocks without sequencer headers after which
+	// full nodes derive blocks only from transactions posted to the forced inclusion namespace. 0 disables DA-only mode.
+	// It's a chain-wide setting, all full nodes must use the same threshold.
+	SequencerDowntimeThreshold uint64 `mapstructure:"sequencer_downtime_threshold"`
+	// DAVerifyInterval is the interval between runs of the job re-fetching a random sample of historical block
+	// headers from DA, to confirm they are still retrievable. 0 disables the job.
+	DAVerifyInterval time.Duration `mapstructure:"da_verify_interval"`
+	// DAVerifySamples is the number of historical blocks re-verified in each run.
+	DAVerifySamples uint64 `mapstructure:"da_verify_samples"`
+	// DACertificates enables persisting an inclusion certificate (DA height, blob ID, commitment and inclusion
+	// proof) of every block confirmed in DA, used by settlement and bridge tooling. It costs additional DA
+	// requests per submitted or retrieved batch of headers.
+	DACertificates bool `mapstructure:"da_certificates"`
+	// PipelineExecution enables executing the next block while the state after the previous one is saved and the
+	// block is broadcast to peers.
+	PipelineExecution bool `mapstructure:"pipeline_execution"`
+	// Concurrency groups limits of concurrency across the node.
+	Concurrency ConcurrencyConfig `mapstructure:"concurrency"`
+	// Messaging configures the experimental cross-rollup messaging.
+	Messaging MessagingConfig `mapstructure:"messaging"`
+	// Alerts configures webhook notifications of critical events.
+	Alerts AlertsConfig `mapstructure:"alerts"`
+	// DASigner configures the DA account paying for and signing submissions.
+	DASigner DASignerConfig `mapstructure:"da_signer"`
+	// TrustedHeight is the height of the block identified by TrustedHash. Fresh full node starts syncing from
+	// this block, instead of genesis. ABCI app must be restored to the state preceding the trusted block. 0 means
+	// the node syncs from genesis.
+	TrustedHeight uint64 `mapstructure:"trusted_height"`
+	// Backfill enables fetching blocks below TrustedHeight from peers in the background, in reverse order, until
+	// all blocks since genesis are stored. Backfilled blocks are not executed.
+	Backfill bool `mapstructure:"backfill"`
+	// SyncProfile defines what full node syncs and stores, full (default) or app_only, see ApplySyncProfile.
+	SyncProfile string `mapstructure:"sync_profile"`
 }
 
 // GetNodeConfig translates Tendermint's configuration into Rollkit configuration.
@@ -142,11 +565,106 @@ func (nc *NodeConfig) GetViperConfig(v *viper.Viper) error {
 	nc.LazyAggregator = v.GetBool(FlagLazyAggregator)
 	nc.Light = v.GetBool(FlagLight)
 	nc.TrustedHash = v.GetString(FlagTrustedHash)
+	nc.TrustedHeight = v.GetUint64(FlagTrustedHeight)
+	nc.Backfill = v.GetBool(FlagBackfill)
+	nc.SyncProfile = v.GetString(FlagSyncProfile)
+	nc.TrustingPeriod = v.GetDuration(FlagTrustingPeriod)
+	nc.HeaderRetentionBlocks = v.GetUint64(FlagHeaderRetentionBlocks)
+	nc.HeaderCheckpointInterval = v.GetUint64(FlagHeaderCheckpointInterval)
+	nc.StoreHeaderExchange = v.GetBool(FlagStoreHeaderExchange)
 	nc.MaxPendingBlocks = v.GetUint64(FlagMaxPendingBlocks)
 	nc.DAMempoolTTL = v.GetUint64(FlagDAMempoolTTL)
 	nc.LazyBlockTime = v.GetDuration(FlagLazyBlockTime)
 	nc.SequencerAddress = v.GetString(FlagSequencerAddress)
 	nc.SequencerRollupID = v.GetString(FlagSequencerRollupID)
+	nc.SequencerTLS = v.GetBool(FlagSequencerTLS)
+	nc.SequencerTLSCAFile = v.GetString(FlagSequencerTLSCAFile)
+	nc.SequencerTLSPin = v.GetString(FlagSequencerTLSPin)
+	nc.SequencerAuthToken = v.GetString(FlagSequencerAuthToken)
+	nc.AppHashMismatchPolicy = v.GetString(FlagAppHashMismatchPolicy)
+	nc.MaxBlockTime = v.GetDuration(FlagMaxBlockTime)
+	nc.SystemTxs = v.GetString(FlagSystemTxs)
+	nc.FinalitySLA = v.GetDuration(FlagFinalitySLA)
+	nc.MaxPendingHeaders = v.GetUint64(FlagMaxPendingHeaders)
+	nc.HaltHeight = v.GetUint64(FlagHaltHeight)
+	nc.HaltTime = v.GetUint64(FlagHaltTime)
+	nc.Resume = v.GetBool(FlagResume)
+	nc.MaxClockDrift = v.GetDuration(FlagMaxClockDrift)
+	nc.SequencerDowntimeThreshold = v.GetUint64(FlagSequencerDowntimeThreshold)
+	nc.DAVerifyInterval = v.GetDuration(FlagDAVerifyInterval)
+	nc.DAVerifySamples = v.GetUint64(FlagDAVerifySamples)
+	nc.DACertificates = v.GetBool(FlagDACertificates)
+	nc.PipelineExecution = v.GetBool(FlagPipelineExecution)
+	nc.Concurrency = ConcurrencyConfig{
+		DAFetchWorkers:           v.GetInt(FlagConcurrencyDAFetchWorkers),
+		GossipValidationWorkers:  v.GetInt(FlagConcurrencyGossipValidationWorkers),
+		RPCMaxConcurrentRequests: v.GetInt(FlagConcurrencyRPCMaxConcurrentRequests),
+		IndexerWorkers:           v.GetInt(FlagConcurrencyIndexerWorkers),
+	}.WithDefaults()
+	nc.Messaging = MessagingConfig{
+		SourceChainID:      v.GetString(FlagMessagingSourceChainID),
+		SourceNamespace:    v.GetString(FlagMessagingSourceNamespace),
+		SourceSequencerKey: v.GetString(FlagMessagingSourceSequencerKey),
+	}
+	nc.Alerts = AlertsConfig{
+		WebhookURL:          v.GetString(FlagAlertsWebhookURL),
+		Format:              v.GetString(FlagAlertsFormat),
+		PagerDutyRoutingKey: v.GetString(FlagAlertsPagerDutyRoutingKey),
+		DASubmitFailures:    v.GetUint64(FlagAlertsDASubmitFailures),
+	}
+	nc.DASigner = DASignerConfig{
+		KeyName:              v.GetString(FlagDASignerKeyName),
+		Address:              v.GetString(FlagDASignerAddress),
+		MinBalance:           v.GetUint64(FlagDASignerMinBalance),
+		ThrottleBalance:      v.GetUint64(FlagDASignerThrottleBalance),
+		BalanceCheckInterval: v.GetDuration(FlagDASignerBalanceCheckInterval),
+	}
+	nc.DAForcedInclusionNamespace = v.GetString(FlagDAForcedInclusionNamespace)
+	nc.MaxDecodeBytes = v.GetUint64(FlagMaxDecodeBytes)
+	nc.MaxDecodeTxs = v.GetUint64(FlagMaxDecodeTxs)
+	nc.MaxDecodeValidators = v.GetUint64(FlagMaxDecodeValidators)
+	nc.TxHash = v.GetString(FlagTxHash)
+	nc.RPCAPIKeysFile = v.GetString(FlagRPCAPIKeysFile)
+	nc.RPCGraphQL = v.GetBool(FlagRPCGraphQL)
+	nc.RPCAdmin = v.GetBool(FlagRPCAdmin)
+	nc.RPCMinGasPrice = v.GetString(FlagRPCMinGasPrice)
+	nc.RPCEstimateGasQuery = v.GetString(FlagRPCEstimateGasQuery)
+	nc.EventReplayAddress = v.GetString(FlagEventReplayAddress)
+	nc.TraceProxyApp = v.GetString(FlagTraceProxyApp)
+	nc.DBGCInterval = v.GetDuration(FlagDBGCInterval)
+	nc.DBGCDiscardRatio = v.GetFloat64(FlagDBGCDiscardRatio)
+	nc.DBBackend = v.GetString(FlagDBBackend)
+	nc.DBEncryptionKey = v.GetString(FlagDBEncryptionKey)
+	nc.DBEncryptionKeyFile = v.GetString(FlagDBEncryptionKeyFile)
+	nc.DBSyncPolicy = v.GetString(FlagDBSyncPolicy)
+	nc.DBSyncBlocks = v.GetUint64(FlagDBSyncBlocks)
+	nc.EventRetentionBlocks = v.GetUint64(FlagEventRetentionBlocks)
+	nc.EventPruneInterval = v.GetDuration(FlagEventPruneInterval)
+	nc.BlockDataRetentionBlocks = v.GetUint64(FlagBlockDataRetentionBlocks)
+	nc.BlockDataPruneInterval = v.GetDuration(FlagBlockDataPruneInterval)
+	nc.KeepRecent = v.GetUint64(FlagKeepRecent)
+	nc.PruneInterval = v.GetDuration(FlagPruneInterval)
+	nc.PreconfirmationWindow = v.GetUint64(FlagPreconfirmationWindow)
+	nc.PersistQueues = v.GetBool(FlagPersistQueues)
+	nc.DBMinFreeDiskMB = v.GetUint64(FlagDBMinFreeDiskMB)
+	nc.DBStatsInterval = v.GetDuration(FlagDBStatsInterval)
+	nc.MemorySoftLimitMB = v.GetUint64(FlagMemorySoftLimitMB)
+	nc.MemoryHardLimitMB = v.GetUint64(FlagMemoryHardLimitMB)
+	nc.ReadOnly = v.GetBool(FlagReadOnly)
+	nc.ReplicationAddress = v.GetString(FlagReplicationAddress)
+	nc.ReplicateFrom = v.GetString(FlagReplicateFrom)
+	nc.P2P.AnnounceAddresses = v.GetString(FlagP2PAnnounceAddresses)
+	nc.P2P.PreviousListenAddress = v.GetString(FlagP2PPreviousListenAddress)
+	nc.P2P.KeyRotationGracePeriod = v.GetDuration(FlagP2PKeyRotationGracePeriod)
+	nc.TelemetryEndpoint = v.GetString(FlagTelemetryEndpoint)
+	nc.TelemetryInterval = v.GetDuration(FlagTelemetryInterval)
+	nc.ABCIRetryInterval = v.GetDuration(FlagABCIRetryInterval)
+	nc.ABCIReconnectTimeout = v.GetDuration(FlagABCIReconnectTimeout)
+	nc.ABCICheckTxTimeout = v.GetDuration(FlagABCICheckTxTimeout)
+	nc.ABCIFinalizeBlockTimeout = v.GetDuration(FlagABCIFinalizeBlockTimeout)
+	nc.ABCICommitTimeout = v.GetDuration(FlagABCICommitTimeout)
+	nc.ABCIGRPCConnections = v.GetInt(FlagABCIGRPCConnections)
+	nc.ABCICheckTxConcurrency = v.GetInt(FlagABCICheckTxConcurrency)
 
 	return nil
 }
@@ -170,9 +688,96 @@ func AddFlags(cmd *cobra.Command) {
 	cmd.Flags().String(FlagDASubmitOptions, def.DASubmitOptions, "DA submit options")
 	cmd.Flags().Bool(FlagLight, def.Light, "run light client")
 	cmd.Flags().String(FlagTrustedHash, def.TrustedHash, "initial trusted hash to start the header exchange service")
+	cmd.Flags().Uint64(FlagTrustedHeight, def.TrustedHeight, "height of the trusted block (by trusted hash) full node starts syncing from, instead of genesis")
+	cmd.Flags().Bool(FlagBackfill, def.Backfill, "backfill blocks below trusted height from peers in the background")
+	cmd.Flags().String(FlagSyncProfile, def.SyncProfile, "sync profile of full node: full or app_only (state, recent blocks and app snapshot only, for app state queries)")
+	cmd.Flags().Duration(FlagTrustingPeriod, def.TrustingPeriod, "period the latest synced header is trusted for; when expired, node re-initializes from a trusted peer")
+	cmd.Flags().Uint64(FlagHeaderRetentionBlocks, def.HeaderRetentionBlocks, "number of recent headers kept by light nodes (0 to keep all headers)")
+	cmd.Flags().Uint64(FlagHeaderCheckpointInterval, def.HeaderCheckpointInterval, "interval of checkpoint headers kept by light nodes after pruning (0 to disable checkpoints)")
+	cmd.Flags().Bool(FlagStoreHeaderExchange, def.StoreHeaderExchange, "serve headers of all stored blocks over go-header exchange protocol, with network ID <chain ID>-storeHeaders")
 	cmd.Flags().Uint64(FlagMaxPendingBlocks, def.MaxPendingBlocks, "limit of blocks pending DA submission (0 for no limit)")
 	cmd.Flags().Uint64(FlagDAMempoolTTL, def.DAMempoolTTL, "number of DA blocks until transaction is dropped from the mempool")
 	cmd.Flags().Duration(FlagLazyBlockTime, def.LazyBlockTime, "block time (for lazy mode)")
 	cmd.Flags().String(FlagSequencerAddress, def.SequencerAddress, "sequencer middleware address (host:port)")
 	cmd.Flags().String(FlagSequencerRollupID, def.SequencerRollupID, "sequencer middleware rollup ID (default: mock-rollup)")
+	cmd.Flags().Bool(FlagSequencerTLS, def.SequencerTLS, "secure connection to sequencer middleware with TLS")
+	cmd.Flags().String(FlagSequencerTLSCAFile, def.SequencerTLSCAFile, "PEM file with CA certificates verifying sequencer middleware certificate (system CA certificates if empty)")
+	cmd.Flags().String(FlagSequencerTLSPin, def.SequencerTLSPin, "base64 encoded SHA-256 hash of sequencer middleware certificate public key, certificate chain is not verified if CA file is empty")
+	cmd.Flags().String(FlagSequencerAuthToken, def.SequencerAuthToken, "auth token sent to sequencer middleware (requires TLS)")
+	cmd.Flags().String(FlagAppHashMismatchPolicy, def.AppHashMismatchPolicy, "reaction to app hash mismatch while syncing (halt | rollback | headers_only)")
+	cmd.Flags().Duration(FlagMaxBlockTime, def.MaxBlockTime, "upper bound of block time adapted to DA throughput (0 for fixed block time)")
+	cmd.Flags().Duration(FlagFinalitySLA, def.FinalitySLA, "maximal expected latency between soft confirmation of a block and its inclusion in DA, reported as SLA violations (0 disables)")
+	cmd.Flags().String(FlagSystemTxs, def.SystemTxs, "system transactions required at fixed positions of every block, as kind:position list (negative positions count from the end), e.g. oracle:0,beacon:-1")
+	cmd.Flags().Uint64(FlagMaxPendingHeaders, def.MaxPendingHeaders, "limit of heights synced from P2P ahead of DA included height (0 for no limit)")
+	cmd.Flags().Uint64(FlagHaltHeight, def.HaltHeight, "height of the last block produced or applied before the chain is halted (0 to disable)")
+	cmd.Flags().Uint64(FlagHaltTime, def.HaltTime, "time (unix seconds) since which blocks are not produced or applied (0 to disable)")
+	cmd.Flags().Bool(FlagResume, def.Resume, "resume chain halted at halt height or time")
+	cmd.Flags().Duration(FlagMaxClockDrift, def.MaxClockDrift, "how far ahead of local clock the time of synced block can be (0 to disable)")
+	cmd.Flags().Uint64(FlagSequencerDowntimeThreshold, def.SequencerDowntimeThreshold, "number of DA blocks without sequencer headers after which blocks are derived from DA only (0 to disable)")
+	cmd.Flags().Duration(FlagDAVerifyInterval, def.DAVerifyInterval, "interval between re-verifications of retrievability of historical blocks from DA (0 to disable)")
+	cmd.Flags().Uint64(FlagDAVerifySamples, def.DAVerifySamples, "number of random historical blocks re-fetched from DA in each re-verification")
+	cmd.Flags().Bool(FlagDACertificates, def.DACertificates, "persist DA inclusion certificates (DA height, commitment and proof) of blocks")
+	cmd.Flags().Bool(FlagPipelineExecution, def.PipelineExecution, "execute the next block while the previous one is finalized (state saved and block broadcast)")
+	cmd.Flags().Int(FlagConcurrencyDAFetchWorkers, def.Concurrency.DAFetchWorkers, "number of DA heights retrieved in parallel while syncing (0 derives from GOMAXPROCS)")
+	cmd.Flags().Int(FlagConcurrencyGossipValidationWorkers, def.Concurrency.GossipValidationWorkers, "number of workers validating messages gossiped by peers (0 derives from GOMAXPROCS)")
+	cmd.Flags().Int(FlagConcurrencyRPCMaxConcurrentRequests, def.Concurrency.RPCMaxConcurrentRequests, "maximum number of RPC requests handled at once (0 derives from GOMAXPROCS)")
+	cmd.Flags().Int(FlagConcurrencyIndexerWorkers, def.Concurrency.IndexerWorkers, "number of workers preparing transaction index entries (0 derives from GOMAXPROCS)")
+	cmd.Flags().String(FlagMessagingSourceChainID, def.Messaging.SourceChainID, "chain ID of the rollup sending cross-rollup messages to this rollup (experimental, disabled if empty)")
+	cmd.Flags().String(FlagMessagingSourceNamespace, def.Messaging.SourceNamespace, "hex encoded DA namespace of the rollup sending cross-rollup messages")
+	cmd.Flags().String(FlagMessagingSourceSequencerKey, def.Messaging.SourceSequencerKey, "hex encoded ed25519 public key of the sequencer of the rollup sending cross-rollup messages")
+	cmd.Flags().String(FlagAlertsWebhookURL, def.Alerts.WebhookURL, "URL receiving alerts on critical events (disabled if empty)")
+	cmd.Flags().String(FlagAlertsFormat, def.Alerts.Format, "format of alert webhook payload (slack, pagerduty)")
+	cmd.Flags().String(FlagAlertsPagerDutyRoutingKey, def.Alerts.PagerDutyRoutingKey, "integration key of PagerDuty service receiving alerts")
+	cmd.Flags().Uint64(FlagAlertsDASubmitFailures, def.Alerts.DASubmitFailures, "number of consecutive failed DA submission attempts raising an alert")
+	cmd.Flags().String(FlagDASignerKeyName, def.DASigner.KeyName, "name of the key of the DA account paying for submissions, in the keyring of the DA node")
+	cmd.Flags().String(FlagDASignerAddress, def.DASigner.Address, "address of the DA account paying for submissions")
+	cmd.Flags().Uint64(FlagDASignerMinBalance, def.DASigner.MinBalance, "DA account balance (in the smallest denomination) below which an alert is raised (0 disables monitoring)")
+	cmd.Flags().Uint64(FlagDASignerThrottleBalance, def.DASigner.ThrottleBalance, "DA account balance (in the smallest denomination) below which block production and DA submissions are slowed down (0 disables throttling)")
+	cmd.Flags().Duration(FlagDASignerBalanceCheckInterval, def.DASigner.BalanceCheckInterval, "interval between DA account balance checks")
+	cmd.Flags().String(FlagDAForcedInclusionNamespace, def.DAForcedInclusionNamespace, "DA namespace of transactions posted directly to DA, used when sequencer is down")
+	cmd.Flags().Uint64(FlagMaxDecodeBytes, def.MaxDecodeBytes, "maximum size in bytes of blocks and state decoded from DA and peers (0 to disable)")
+	cmd.Flags().Uint64(FlagMaxDecodeTxs, def.MaxDecodeTxs, "maximum number of transactions in block data decoded from DA and peers (0 to disable)")
+	cmd.Flags().Uint64(FlagMaxDecodeValidators, def.MaxDecodeValidators, "maximum number of validators in validator sets decoded from DA and peers (0 to disable)")
+	cmd.Flags().String(FlagTxHash, def.TxHash, "hash function identifying transactions: sha256 or keccak256 (default sha256, unless set by the app)")
+	cmd.Flags().String(FlagRPCAPIKeysFile, def.RPCAPIKeysFile, "path to JSON file with API keys required to access RPC (authentication is disabled if empty)")
+	cmd.Flags().Bool(FlagRPCGraphQL, def.RPCGraphQL, "enable GraphQL endpoint (/graphql) for querying blocks, transactions and events")
+	cmd.Flags().Bool(FlagRPCAdmin, def.RPCAdmin, "enable admin RPC methods (admin_halt, admin_halt_status, admin_simulate_block, admin_misbehavior_ledger, admin_store_stats, debug_traceTx), requires API keys allowing them in admin_methods")
+	cmd.Flags().String(FlagRPCMinGasPrice, def.RPCMinGasPrice, "minimum gas price reported by estimate_gas RPC method (e.g. 0.025stake)")
+	cmd.Flags().String(FlagRPCEstimateGasQuery, def.RPCEstimateGasQuery, "ABCI query path used by estimate_gas to simulate transactions (CheckTx is used if empty)")
+	cmd.Flags().String(FlagEventReplayAddress, def.EventReplayAddress, "listen address of gRPC event replay service for indexers (e.g. tcp://0.0.0.0:9091), disabled if empty")
+	cmd.Flags().String(FlagTraceProxyApp, def.TraceProxyApp, "address of the ABCI app used for tracing transactions with debug_traceTx, enabled with admin RPC methods (tracing is disabled if empty)")
+	cmd.Flags().String(FlagDBBackend, def.DBBackend, "database backend (badger | pebble | leveldb | memory), pebble requires binary built with pebbledb tag")
+	cmd.Flags().String(FlagDBEncryptionKey, def.DBEncryptionKey, "hex encoded AES key encrypting blocks, state and indexes in the database, prefer RK_ROLLKIT_DB_ENCRYPTION_KEY environment variable (encryption is disabled if empty)")
+	cmd.Flags().String(FlagDBEncryptionKeyFile, def.DBEncryptionKeyFile, "path to file with hex encoded AES key encrypting blocks, state and indexes in the database")
+	cmd.Flags().Duration(FlagDBGCInterval, def.DBGCInterval, "interval between database garbage collection runs (0 to disable)")
+	cmd.Flags().Float64(FlagDBGCDiscardRatio, def.DBGCDiscardRatio, "minimal fraction of stale data in database value log file required to rewrite it during garbage collection")
+	cmd.Flags().String(FlagDBSyncPolicy, def.DBSyncPolicy, "when database writes are synced to disk (block | interval | async), blocks lost on crash are synced again from DA")
+	cmd.Flags().Uint64(FlagDBSyncBlocks, def.DBSyncBlocks, "number of blocks between database syncs with interval sync policy")
+	cmd.Flags().Uint64(FlagEventRetentionBlocks, def.EventRetentionBlocks, "number of latest blocks which indexed transactions and block events are retained, blocks are kept (0 to keep all events)")
+	cmd.Flags().Duration(FlagEventPruneInterval, def.EventPruneInterval, "interval between event pruning runs")
+	cmd.Flags().Uint64(FlagBlockDataRetentionBlocks, def.BlockDataRetentionBlocks, "number of latest blocks which data is retained, headers and transaction hashes needed for inclusion proofs are kept (0 to keep all data)")
+	cmd.Flags().Duration(FlagBlockDataPruneInterval, def.BlockDataPruneInterval, "interval between block data pruning runs")
+	cmd.Flags().Uint64(FlagKeepRecent, def.KeepRecent, "number of latest blocks kept in the store, older blocks are deleted once DA included (0 to keep all blocks)")
+	cmd.Flags().Duration(FlagPruneInterval, def.PruneInterval, "interval between block pruning runs")
+	cmd.Flags().Uint64(FlagPreconfirmationWindow, def.PreconfirmationWindow, "number of blocks in which sequencer promises to include transactions it signs preconfirmations for (0 to disable preconfirmations)")
+	cmd.Flags().Bool(FlagPersistQueues, def.PersistQueues, "persist mempool and batch queue on graceful shutdown, and recover lost batches after unclean shutdown")
+	cmd.Flags().Uint64(FlagDBMinFreeDiskMB, def.DBMinFreeDiskMB, "free disk space in MiB below which the node stops producing and applying blocks (0 to disable)")
+	cmd.Flags().Duration(FlagDBStatsInterval, def.DBStatsInterval, "interval between collections of store usage statistics reported as metrics (0 to disable)")
+	cmd.Flags().Uint64(FlagMemorySoftLimitMB, def.MemorySoftLimitMB, "heap usage in MiB above which the node rejects new RPC subscriptions and pauses DA prefetching (0 to disable)")
+	cmd.Flags().Uint64(FlagMemoryHardLimitMB, def.MemoryHardLimitMB, "heap usage in MiB above which the node also shrinks caches and returns memory to OS (0 to disable)")
+	cmd.Flags().Bool(FlagReadOnly, def.ReadOnly, "run node in read-only mode, serving RPC from a store snapshot (or a store replicated with --rollkit.replicate_from) without syncing blocks")
+	cmd.Flags().String(FlagReplicationAddress, def.ReplicationAddress, "listen address of gRPC store replication service for follower nodes (e.g. tcp://0.0.0.0:9092), disabled if empty")
+	cmd.Flags().String(FlagReplicateFrom, def.ReplicateFrom, "address of primary node's store replication service (e.g. 127.0.0.1:9092), requires read-only mode")
+	cmd.Flags().String(FlagP2PAnnounceAddresses, def.P2P.AnnounceAddresses, "comma separated list of multiaddrs advertised to peers instead of listen addresses")
+	cmd.Flags().String(FlagP2PPreviousListenAddress, def.P2P.PreviousListenAddress, "listen address of P2P identity used before key rotation, announced during grace period")
+	cmd.Flags().Duration(FlagP2PKeyRotationGracePeriod, def.P2P.KeyRotationGracePeriod, "how long P2P identity used before key rotation is announced (0 to disable)")
+	cmd.Flags().String(FlagTelemetryEndpoint, def.TelemetryEndpoint, "URL receiving periodic reports of anonymized node health (opt-in, disabled if empty)")
+	cmd.Flags().Duration(FlagTelemetryInterval, def.TelemetryInterval, "interval between node telemetry reports")
+	cmd.Flags().Duration(FlagABCIRetryInterval, def.ABCIRetryInterval, "initial interval between attempts to reconnect to ABCI app, doubled after each failed attempt")
+	cmd.Flags().Duration(FlagABCIReconnectTimeout, def.ABCIReconnectTimeout, "how long to try reconnecting to ABCI app before stopping the node (0 to stop immediately)")
+	cmd.Flags().Duration(FlagABCICheckTxTimeout, def.ABCICheckTxTimeout, "timeout of CheckTx calls to ABCI app (0 to disable)")
+	cmd.Flags().Duration(FlagABCIFinalizeBlockTimeout, def.ABCIFinalizeBlockTimeout, "timeout of FinalizeBlock calls to ABCI app (0 to disable)")
+	cmd.Flags().Duration(FlagABCICommitTimeout, def.ABCICommitTimeout, "timeout of Commit calls to ABCI app (0 to disable)")
+	cmd.Flags().Int(FlagABCIGRPCConnections, def.ABCIGRPCConnections, "number of gRPC connections to ABCI app shared by all ABCI connections (with grpc:// proxy_app or grpc transport)")
+	cmd.Flags().Int(FlagABCICheckTxConcurrency, def.ABCICheckTxConcurrency, "number of ABCI connections checking new transactions concurrently (1 to check serially, in order)")
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"runtime"
 	"testing"
 	"time"
 
@@ -50,6 +51,8 @@ func TestViperAndCobra(t *testing.T) {
 	assert.NoError(cmd.Flags().Set(FlagDAAddress, `{"json":true}`))
 	assert.NoError(cmd.Flags().Set(FlagBlockTime, "1234s"))
 	assert.NoError(cmd.Flags().Set(FlagDANamespace, "0102030405060708"))
+	assert.NoError(cmd.Flags().Set(FlagConcurrencyIndexerWorkers, "3"))
+	assert.NoError(cmd.Flags().Set(FlagHeaderRetentionBlocks, "1000"))
 
 	nc := DefaultNodeConfig
 
@@ -58,4 +61,69 @@ func TestViperAndCobra(t *testing.T) {
 	assert.Equal(true, nc.Aggregator)
 	assert.Equal(`{"json":true}`, nc.DAAddress)
 	assert.Equal(1234*time.Second, nc.BlockTime)
+	assert.Equal(3, nc.Concurrency.IndexerWorkers)
+	assert.Equal(runtime.GOMAXPROCS(0), nc.Concurrency.GossipValidationWorkers)
+	assert.Equal(uint64(1000), nc.HeaderRetentionBlocks)
+	assert.Equal(uint64(10000), nc.HeaderCheckpointInterval)
+	assert.Equal(336*time.Hour, nc.TrustingPeriod)
+}
+
+func TestConcurrencyDefaults(t *testing.T) {
+	t.Parallel()
+
+	procs := runtime.GOMAXPROCS(0)
+	c := ConcurrencyConfig{RPCMaxConcurrentRequests: 5}.WithDefaults()
+	assert.Equal(t, ConcurrencyConfig{
+		DAFetchWorkers:           min(procs, 4),
+		GossipValidationWorkers:  procs,
+		RPCMaxConcurrentRequests: 5,
+		IndexerWorkers:           procs,
+	}, c)
+}
+
+func TestApplySyncProfile(t *testing.T) {
+	t.Parallel()
+
+	nc := DefaultNodeConfig
+	assert.NoError(t, nc.ApplySyncProfile())
+	assert.Equal(t, DefaultNodeConfig, nc)
+
+	nc.SyncProfile = "archive"
+	assert.Error(t, nc.ApplySyncProfile())
+
+	// app state must be restored from a snapshot at trusted height
+	nc.SyncProfile = SyncProfileAppOnly
+	assert.Error(t, nc.ApplySyncProfile())
+
+	nc.TrustedHeight = 100
+	nc.TrustedHash = "AABB"
+	nc.EventRetentionBlocks = 10
+	assert.NoError(t, nc.ApplySyncProfile())
+	assert.EqualValues(t, AppOnlyRetentionBlocks, nc.BlockDataRetentionBlocks)
+	assert.EqualValues(t, 10, nc.EventRetentionBlocks)
+	assert.Zero(t, nc.DAVerifyInterval)
+
+	nc.Backfill = true
+	assert.Error(t, nc.ApplySyncProfile())
+	nc.Backfill = false
+	nc.Aggregator = true
+	assert.Error(t, nc.ApplySyncProfile())
+}
+
+func TestDASignerSubmitOptions(t *testing.T) {
+	t.Parallel()
+
+	c := DASignerConfig{KeyName: "da", Address: "celestia1abc"}
+	opts, err := c.SubmitOptions("")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key_name":"da","signer_address":"celestia1abc"}`, opts)
+
+	opts, err = DASignerConfig{KeyName: "da"}.SubmitOptions(`{"gas":100,"key_name":"da"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gas":100,"key_name":"da"}`, opts)
+
+	_, err = c.SubmitOptions(`{"key_name":"sequencer"}`)
+	assert.Error(t, err)
+	_, err = c.SubmitOptions("gas=100")
+	assert.Error(t, err)
 }
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DASignerConfig configures the DA account paying for and signing blob submissions, separately from the key
+// signing blocks. The key of the account is managed by the DA node (e.g. in the keyring of Celestia node), Rollkit
+// only selects it.
+type DASignerConfig struct {
+	// KeyName is the name of the key of the DA account in the keyring of the DA node.
+	KeyName string `mapstructure:"key_name"`
+	// Address is the address of the DA account, used to select the signer and to monitor its balance.
+	Address string `mapstructure:"address"`
+	// MinBalance is the balance of the DA account (in the smallest denomination, e.g. utia) below which an alert is
+	// raised. 0 disables balance monitoring.
+	MinBalance uint64 `mapstructure:"min_balance"`
+	// ThrottleBalance is the balance of the DA account below which block production and DA submissions are slowed
+	// down, so that the account lasts longer. 0 disables throttling.
+	ThrottleBalance uint64 `mapstructure:"throttle_balance"`
+	// BalanceCheckInterval is the interval between balance checks.
+	BalanceCheckInterval time.Duration `mapstructure:"balance_check_interval"`
+}
+
+// Enabled returns true if DA account is selected explicitly.
+func (c DASignerConfig) Enabled() bool {
+	return c.KeyName != "" || c.Address != ""
+}
+
+// SubmitOptions returns DA submit options selecting the DA account, merged with options, which must be empty or a
+// JSON object. Fields follow transaction config of Celestia node.
+func (c DASignerConfig) SubmitOptions(options string) (string, error) {
+	fields := make(map[string]any)
+	if options != "" {
+		if err := json.Unmarshal([]byte(options), &fields); err != nil {
+			return "", fmt.Errorf("DA submit options must be a JSON object to select DA signer: %w", err)
+		}
+	}
+	for field, value := range map[string]string{"key_name": c.KeyName, "signer_address": c.Address} {
+		if value == "" {
+			continue
+		}
+		if v, ok := fields[field]; ok && v != value {
+			return "", fmt.Errorf("DA submit options set %s to %v, conflicting with DA signer", field, v)
+		}
+		fields[field] = value
+	}
+	blob, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(blob), nil
+}
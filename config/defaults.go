@@ -9,6 +9,8 @@ import (
 const (
 	// DefaultListenAddress is a default listen address for P2P client.
 	DefaultListenAddress = "/ip4/0.0.0.0/tcp/7676"
+	// DefaultPreviousListenAddress is a default listen address for P2P identity used before key rotation.
+	DefaultPreviousListenAddress = "/ip4/0.0.0.0/tcp/7677"
 	// Version is the current rollkit version
 	// Please keep updated with each new release
 	Version = "0.38.5"
@@ -23,24 +25,62 @@ const (
 // DefaultNodeConfig keeps default values of NodeConfig
 var DefaultNodeConfig = NodeConfig{
 	P2P: P2PConfig{
-		ListenAddress: DefaultListenAddress,
-		Seeds:         "",
+		ListenAddress:          DefaultListenAddress,
+		Seeds:                  "",
+		PreviousListenAddress:  DefaultPreviousListenAddress,
+		KeyRotationGracePeriod: 24 * time.Hour,
 	},
 	Aggregator: false,
 	BlockManagerConfig: BlockManagerConfig{
-		BlockTime:      1 * time.Second,
-		DABlockTime:    15 * time.Second,
-		LazyAggregator: false,
-		LazyBlockTime:  60 * time.Second,
+		BlockTime:             1 * time.Second,
+		DABlockTime:           15 * time.Second,
+		LazyAggregator:        false,
+		LazyBlockTime:         60 * time.Second,
+		AppHashMismatchPolicy: AppHashMismatchHalt,
+		BlockTimeSource:       TimeSourceSequencer,
+		MaxClockDrift:         10 * time.Second,
+		DAVerifyInterval:      10 * time.Minute,
+		DAVerifySamples:       3,
+		SyncProfile:           SyncProfileFull,
+		Alerts: AlertsConfig{
+			Format:           AlertFormatSlack,
+			DASubmitFailures: 10,
+		},
+		DASigner: DASignerConfig{
+			BalanceCheckInterval: 10 * time.Minute,
+		},
 	},
 	DAAddress:       DefaultDAAddress,
 	DAGasPrice:      -1,
 	DAGasMultiplier: 0,
 	Light:           false,
 	HeaderConfig: HeaderConfig{
-		TrustedHash: "",
+		TrustedHash:              "",
+		TrustingPeriod:           336 * time.Hour,
+		HeaderCheckpointInterval: 10000,
 	},
-	Instrumentation:   config.DefaultInstrumentationConfig(),
-	SequencerAddress:  DefaultSequencerAddress,
-	SequencerRollupID: DefaultSequencerRollupID,
+	Instrumentation:        config.DefaultInstrumentationConfig(),
+	SequencerAddress:       DefaultSequencerAddress,
+	SequencerRollupID:      DefaultSequencerRollupID,
+	MaxDecodeBytes:         64 << 20,
+	MaxDecodeTxs:           1 << 20,
+	MaxDecodeValidators:    10000,
+	DBBackend:              DBBackendBadger,
+	DBGCInterval:           15 * time.Minute,
+	DBGCDiscardRatio:       0.5,
+	DBSyncPolicy:           DBSyncPolicyBlock,
+	DBSyncBlocks:           100,
+	EventPruneInterval:     10 * time.Minute,
+	BlockDataPruneInterval: 10 * time.Minute,
+	PruneInterval:          10 * time.Minute,
+	DBStatsInterval:        1 * time.Hour,
+	TelemetryInterval:      1 * time.Hour,
+
+	ABCIRetryInterval:        1 * time.Second,
+	ABCIReconnectTimeout:     5 * time.Minute,
+	ABCICheckTxTimeout:       10 * time.Second,
+	ABCIFinalizeBlockTimeout: 1 * time.Minute,
+	ABCICommitTimeout:        1 * time.Minute,
+	ABCIGRPCConnections:      2,
+	ABCICheckTxConcurrency:   1,
 }
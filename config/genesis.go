@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DAGenesis anchors the rollup in the DA network. It's read from the optional "da" field of the genesis
+// document, so that nodes joining the network know where rollup data begins without out-of-band configuration.
+type DAGenesis struct {
+	// ChainID is the chain ID of the DA network.
+	ChainID string `json:"chain_id"`
+	// Namespace is the DA namespace of rollup blocks, hex encoded.
+	Namespace string `json:"namespace"`
+	// StartHeight is the DA height of the first rollup block.
+	StartHeight uint64 `json:"start_height,string"`
+}
+
+// DAGenesisFromJSON reads DA anchor from genesis document. It returns nil if genesis has no DA anchor.
+func DAGenesisFromJSON(jsonBlob []byte) (*DAGenesis, error) {
+	var doc struct {
+		DA *DAGenesis `json:"da"`
+	}
+	if err := json.Unmarshal(jsonBlob, &doc); err != nil {
+		return nil, fmt.Errorf("invalid DA genesis: %w", err)
+	}
+	return doc.DA, nil
+}
+
+// DAGenesisFromFile reads DA anchor from genesis file. It returns nil if genesis has no DA anchor.
+func DAGenesisFromFile(genDocFile string) (*DAGenesis, error) {
+	jsonBlob, err := os.ReadFile(genDocFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read genesis file: %w", err)
+	}
+	return DAGenesisFromJSON(jsonBlob)
+}
+
+// BlockTimeSourceFromJSON reads block time source from the optional "block_time_source" field of genesis document.
+// Block time source is a chain-wide rule, so it's committed to in genesis. Sequencer time is used if genesis
+// doesn't set it.
+func BlockTimeSourceFromJSON(jsonBlob []byte) (string, error) {
+	var doc struct {
+		BlockTimeSource string `json:"block_time_source"`
+	}
+	if err := json.Unmarshal(jsonBlob, &doc); err != nil {
+		return "", fmt.Errorf("invalid genesis: %w", err)
+	}
+	switch doc.BlockTimeSource {
+	case "":
+		return TimeSourceSequencer, nil
+	case TimeSourceSequencer, TimeSourceDA:
+		return doc.BlockTimeSource, nil
+	default:
+		return "", fmt.Errorf("unknown block time source %q in genesis, use one of: %s, %s", doc.BlockTimeSource, TimeSourceSequencer, TimeSourceDA)
+	}
+}
+
+// BlockTimeSourceFromFile reads block time source from genesis file, see BlockTimeSourceFromJSON.
+func BlockTimeSourceFromFile(genDocFile string) (string, error) {
+	jsonBlob, err := os.ReadFile(genDocFile)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read genesis file: %w", err)
+	}
+	return BlockTimeSourceFromJSON(jsonBlob)
+}
+
+// ApplyDAGenesis sets DA namespace and start height from genesis, if they are not configured. Configured
+// namespace must match the one from genesis, because rollup blocks wouldn't be found in a different namespace.
+// Configured start height takes precedence, e.g. to skip DA heights known to contain no rollup blocks.
+func (nc *NodeConfig) ApplyDAGenesis(g *DAGenesis) error {
+	if g == nil {
+		return nil
+	}
+	if g.Namespace != "" {
+		if nc.DANamespace == "" {
+			nc.DANamespace = g.Namespace
+		} else if !strings.EqualFold(nc.DANamespace, g.Namespace) {
+			return fmt.Errorf("DA namespace %s doesn't match namespace %s from genesis", nc.DANamespace, g.Namespace)
+		}
+	}
+	if nc.DAStartHeight == 0 {
+		nc.DAStartHeight = g.StartHeight
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGenesis(t *testing.T) {
+	t.Parallel()
+
+	g, err := DAGenesisFromJSON([]byte(`{"chain_id":"rollup","genesis_time":"2024-01-01T00:00:00Z"}`))
+	require.NoError(t, err)
+	assert.Nil(t, g)
+
+	g, err = DAGenesisFromJSON([]byte(`{"chain_id":"rollup","da":{"chain_id":"mocha-4","namespace":"00000000000000000000000000000000000000000000000000726f6c6c","start_height":"1234"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, g)
+	assert.Equal(t, DAGenesis{ChainID: "mocha-4", Namespace: "00000000000000000000000000000000000000000000000000726f6c6c", StartHeight: 1234}, *g)
+
+	cases := []struct {
+		name     string
+		input    NodeConfig
+		expected NodeConfig
+		err      bool
+	}{
+		{"not configured", NodeConfig{}, NodeConfig{DANamespace: g.Namespace, BlockManagerConfig: BlockManagerConfig{DAStartHeight: 1234}}, false},
+		{"start height configured", NodeConfig{BlockManagerConfig: BlockManagerConfig{DAStartHeight: 2000}}, NodeConfig{DANamespace: g.Namespace, BlockManagerConfig: BlockManagerConfig{DAStartHeight: 2000}}, false},
+		{"same namespace", NodeConfig{DANamespace: "00000000000000000000000000000000000000000000000000726F6C6C"}, NodeConfig{DANamespace: "00000000000000000000000000000000000000000000000000726F6C6C", BlockManagerConfig: BlockManagerConfig{DAStartHeight: 1234}}, false},
+		{"different namespace", NodeConfig{DANamespace: "0000"}, NodeConfig{}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			nc := c.input
+			err := nc.ApplyDAGenesis(g)
+			if c.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, nc)
+		})
+	}
+
+	nc := NodeConfig{DANamespace: "0000"}
+	require.NoError(t, nc.ApplyDAGenesis(nil))
+	assert.Equal(t, NodeConfig{DANamespace: "0000"}, nc)
+}
+
+func TestBlockTimeSourceFromJSON(t *testing.T) {
+	t.Parallel()
+
+	source, err := BlockTimeSourceFromJSON([]byte(`{"chain_id":"rollup"}`))
+	require.NoError(t, err)
+	assert.Equal(t, TimeSourceSequencer, source)
+
+	source, err = BlockTimeSourceFromJSON([]byte(`{"chain_id":"rollup","block_time_source":"da"}`))
+	require.NoError(t, err)
+	assert.Equal(t, TimeSourceDA, source)
+
+	_, err = BlockTimeSourceFromJSON([]byte(`{"chain_id":"rollup","block_time_source":"attester_median"}`))
+	assert.Error(t, err)
+}
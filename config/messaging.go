@@ -0,0 +1,18 @@
+package config
+
+// MessagingConfig configures the experimental cross-rollup messaging: the node accepts messages sent to this rollup
+// by the source rollup, after verifying that they were included in a block signed by the source sequencer and
+// posted to DA. Source rollup must use the same DA layer.
+type MessagingConfig struct {
+	// SourceChainID is the chain ID of the source rollup. Messaging is disabled if empty.
+	SourceChainID string `mapstructure:"source_chain_id"`
+	// SourceNamespace is the hex encoded DA namespace of the source rollup.
+	SourceNamespace string `mapstructure:"source_namespace"`
+	// SourceSequencerKey is the hex encoded ed25519 public key of the source rollup sequencer.
+	SourceSequencerKey string `mapstructure:"source_sequencer_key"`
+}
+
+// Enabled returns true if cross-rollup messaging is configured.
+func (c MessagingConfig) Enabled() bool {
+	return c.SourceChainID != ""
+}
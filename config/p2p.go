@@ -1,9 +1,18 @@
 package config
 
+import "time"
+
 // P2PConfig stores configuration related to peer-to-peer networking.
 type P2PConfig struct {
 	ListenAddress string // Address to listen for incoming connections
 	Seeds         string // Comma separated list of seed nodes to connect to
 	BlockedPeers  string // Comma separated list of nodes to ignore
 	AllowedPeers  string // Comma separated list of nodes to whitelist
+
+	// AnnounceAddresses is a comma separated list of multiaddrs advertised to peers instead of listen addresses.
+	AnnounceAddresses string
+	// PreviousListenAddress is the address on which the identity used before key rotation listens during grace period.
+	PreviousListenAddress string
+	// KeyRotationGracePeriod defines how long after key rotation the previous identity is still announced.
+	KeyRotationGracePeriod time.Duration
 }
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sync profiles of a full node.
+const (
+	// SyncProfileFull syncs, stores and indexes all blocks since the earliest synced height.
+	SyncProfileFull = "full"
+	// SyncProfileAppOnly is a cold-start profile of application backends, which only need queries of current
+	// app state. The node starts from a trusted block with app state restored from a snapshot, keeps only
+	// recent blocks and block results, and doesn't index transactions.
+	SyncProfileAppOnly = "app_only"
+)
+
+// AppOnlyRetentionBlocks is the default number of latest blocks which data and results are retained with
+// app_only sync profile.
+const AppOnlyRetentionBlocks = 100
+
+// ApplySyncProfile adjusts configuration to the sync profile. It returns an error if profile is not known or
+// configuration conflicts with it.
+func (nc *NodeConfig) ApplySyncProfile() error {
+	switch nc.SyncProfile {
+	case "", SyncProfileFull:
+		return nil
+	case SyncProfileAppOnly:
+	default:
+		return fmt.Errorf("unknown sync profile %q", nc.SyncProfile)
+	}
+	switch {
+	case nc.Aggregator:
+		return errors.New("app_only sync profile is not supported by aggregator")
+	case nc.Light:
+		return errors.New("app_only sync profile is not supported by light node")
+	case nc.TrustedHeight == 0 || nc.TrustedHash == "":
+		return errors.New("app_only sync profile requires trusted height and hash, with app state restored from a snapshot")
+	case nc.Backfill:
+		return errors.New("app_only sync profile doesn't store historical blocks, backfill must be disabled")
+	}
+	if nc.BlockDataRetentionBlocks == 0 {
+		nc.BlockDataRetentionBlocks = AppOnlyRetentionBlocks
+	}
+	if nc.EventRetentionBlocks == 0 {
+		nc.EventRetentionBlocks = AppOnlyRetentionBlocks
+	}
+	// historical blocks are not re-verified in DA
+	nc.DAVerifyInterval = 0
+	return nil
+}
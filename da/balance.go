@@ -0,0 +1,90 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// balanceRequestTimeout limits duration of a single balance query.
+const balanceRequestTimeout = 10 * time.Second
+
+// BalanceFunc returns balance of the DA account paying for blob submissions, in the smallest denomination.
+type BalanceFunc func(ctx context.Context) (uint64, error)
+
+// NewCelestiaBalance returns BalanceFunc querying balance of account from JSON-RPC API of Celestia node at addr,
+// which also serves DA API of the node.
+func NewCelestiaBalance(addr, authToken, account string) (BalanceFunc, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("DA balance can only be queried from JSON-RPC API over HTTP, got %q", addr)
+	}
+	if account == "" {
+		return nil, errors.New("DA account address is required to query its balance")
+	}
+	client := &http.Client{Timeout: balanceRequestTimeout}
+	return func(ctx context.Context) (uint64, error) {
+		return celestiaBalance(ctx, client, addr, authToken, account)
+	}, nil
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type celestiaBalanceResponse struct {
+	Result *struct {
+		Denom  string `json:"denom"`
+		Amount string `json:"amount"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func celestiaBalance(ctx context.Context, client *http.Client, addr, authToken, account string) (uint64, error) {
+	blob, err := json.Marshal(jsonRPCRequest{JSONRPC: "2.0", ID: 1, Method: "state.BalanceForAddress", Params: []any{account}})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr, bytes.NewReader(blob))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode/100 != 2 {
+		return 0, fmt.Errorf("DA node responded with status %s", resp.Status)
+	}
+	var res celestiaBalanceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return 0, fmt.Errorf("failed to decode balance: %w", err)
+	}
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to query balance: %s (code %d)", res.Error.Message, res.Error.Code)
+	}
+	if res.Result == nil {
+		return 0, errors.New("failed to query balance: empty result")
+	}
+	return strconv.ParseUint(res.Result.Amount, 10, 64)
+}
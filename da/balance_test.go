@@ -0,0 +1,50 @@
+package da
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCelestiaBalance(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if req.Method != "state.BalanceForAddress" || req.Params[0] != "celestia1abc" {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"unknown account"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"denom":"utia","amount":"12345"}}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	balance, err := NewCelestiaBalance(srv.URL, "token", "celestia1abc")
+	require.NoError(t, err)
+	amount, err := balance(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 12345, amount)
+
+	balance, err = NewCelestiaBalance(srv.URL, "token", "celestia1xyz")
+	require.NoError(t, err)
+	_, err = balance(ctx)
+	assert.ErrorContains(t, err, "unknown account")
+
+	balance, err = NewCelestiaBalance(srv.URL, "", "celestia1abc")
+	require.NoError(t, err)
+	_, err = balance(ctx)
+	assert.Error(t, err)
+
+	_, err = NewCelestiaBalance("grpc://localhost:26650", "", "celestia1abc")
+	assert.Error(t, err)
+	_, err = NewCelestiaBalance(srv.URL, "", "")
+	assert.Error(t, err)
+}
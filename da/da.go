@@ -7,12 +7,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/gogo/protobuf/proto"
-
 	goDA "github.com/rollkit/go-da"
 	"github.com/rollkit/rollkit/third_party/log"
 	"github.com/rollkit/rollkit/types"
-	pb "github.com/rollkit/rollkit/types/pb/rollkit"
 )
 
 const (
@@ -56,9 +53,10 @@ type BaseResult struct {
 // ResultSubmit contains information returned from DA layer after block headers/data submission.
 type ResultSubmit struct {
 	BaseResult
-	// Not sure if this needs to be bubbled up to other
-	// parts of Rollkit.
-	// Hash hash.Hash
+	// IDs identify submitted blobs in DA layer, in order of submitted headers.
+	IDs []goDA.ID
+	// Blobs are the submitted blobs, used to compute inclusion certificates, see InclusionCertificates.
+	Blobs [][]byte
 }
 
 // ResultRetrieveHeaders contains batch of block headers returned from DA layer client.
@@ -67,6 +65,32 @@ type ResultRetrieveHeaders struct {
 	// Header is the block header retrieved from Data Availability Layer.
 	// If Code is not equal to StatusSuccess, it has to be nil.
 	Headers []*types.SignedHeader
+	// IDs and Blobs identify blobs of Headers in DA layer, in the same order.
+	IDs   []goDA.ID
+	Blobs [][]byte
+	// Timestamp is the time of DA block at retrieved height. It's zero if not reported by DA layer.
+	Timestamp time.Time
+	// Discarded is the number of discarded blobs, by reason.
+	Discarded map[string]uint64
+}
+
+// ResultRetrieveTxs contains transactions posted directly to DA, returned from DA layer client.
+type ResultRetrieveTxs struct {
+	BaseResult
+	// Txs are raw transactions in the order of inclusion in DA block.
+	Txs [][]byte
+	// Timestamp is the time of DA block at retrieved height. It's zero if not reported by DA layer.
+	Timestamp time.Time
+}
+
+// ResultRetrieveBlobs contains all blobs of a namespace at a DA height, before they are decoded.
+type ResultRetrieveBlobs struct {
+	BaseResult
+	// IDs identify Blobs in DA layer, in the same order.
+	IDs   []goDA.ID
+	Blobs [][]byte
+	// Timestamp is the time of DA block at retrieved height. It's zero if not reported by DA layer.
+	Timestamp time.Time
 }
 
 // DAClient is a new DA implementation.
@@ -79,6 +103,14 @@ type DAClient struct {
 	SubmitTimeout   time.Duration
 	RetrieveTimeout time.Duration
 	Logger          log.Logger
+
+	// ForcedInclusionNamespace is the namespace of transactions posted directly to DA, bypassing the sequencer.
+	ForcedInclusionNamespace goDA.Namespace
+	// HeaderFilter discards spam blobs before they are unmarshaled. Nil disables pre-checks.
+	HeaderFilter *HeaderFilter
+	// Watcher shares DA retrieval between consumers, so each DA height is fetched once per namespace. Nil
+	// disables sharing.
+	Watcher *Watcher
 }
 
 // NewDAClient returns a new DA client.
@@ -166,14 +198,110 @@ func (dac *DAClient) SubmitHeaders(ctx context.Context, headers []*types.SignedH
 			DAHeight:       binary.LittleEndian.Uint64(ids[0]),
 			SubmittedCount: uint64(len(ids)),
 		},
+		IDs:   ids,
+		Blobs: blobs[:len(ids)],
 	}
 }
 
 // RetrieveHeaders retrieves block headers from DA.
 func (dac *DAClient) RetrieveHeaders(ctx context.Context, dataLayerHeight uint64) ResultRetrieveHeaders {
-	result, err := dac.DA.GetIDs(ctx, dataLayerHeight, dac.Namespace)
+	result := dac.retrieveBlobs(ctx, dac.Namespace, dataLayerHeight)
+	if result.Code != StatusSuccess {
+		return ResultRetrieveHeaders{BaseResult: result.BaseResult, Timestamp: result.Timestamp}
+	}
+	blobs := result.Blobs
+
+	// namespace may be public, so malformed or oversized blobs are skipped instead of failing retrieval
+	headers := make([]*types.SignedHeader, 0, len(blobs))
+	ids := make([]goDA.ID, 0, len(blobs))
+	headerBlobs := make([][]byte, 0, len(blobs))
+	var discarded map[string]uint64
+	discard := func(reason string) {
+		if discarded == nil {
+			discarded = make(map[string]uint64)
+		}
+		discarded[reason]++
+	}
+	for i, blob := range blobs {
+		if dac.HeaderFilter != nil {
+			if reason := dac.HeaderFilter.Check(blob); reason != "" {
+				dac.Logger.Debug("discarding blob", "daHeight", dataLayerHeight, "position", i, "reason", reason)
+				discard(reason)
+				continue
+			}
+		}
+		header := new(types.SignedHeader)
+		if err := header.UnmarshalBinary(blob); err != nil {
+			dac.Logger.Error("failed to unmarshal block", "daHeight", dataLayerHeight, "position", i, "error", err)
+			discard(DiscardMalformed)
+			continue
+		}
+		headers = append(headers, header)
+		ids = append(ids, result.IDs[i])
+		headerBlobs = append(headerBlobs, blob)
+	}
+
+	return ResultRetrieveHeaders{
+		BaseResult: BaseResult{
+			Code:     StatusSuccess,
+			DAHeight: dataLayerHeight,
+		},
+		Headers:   headers,
+		IDs:       ids,
+		Blobs:     headerBlobs,
+		Timestamp: result.Timestamp,
+		Discarded: discarded,
+	}
+}
+
+// RetrieveForcedInclusionTxs retrieves transactions posted directly to DA, in forced inclusion namespace.
+func (dac *DAClient) RetrieveForcedInclusionTxs(ctx context.Context, dataLayerHeight uint64) ResultRetrieveTxs {
+	if len(dac.ForcedInclusionNamespace) == 0 {
+		return ResultRetrieveTxs{
+			BaseResult: BaseResult{
+				Code:     StatusError,
+				Message:  "forced inclusion namespace is not set",
+				DAHeight: dataLayerHeight,
+			},
+		}
+	}
+	result := dac.retrieveBlobs(ctx, dac.ForcedInclusionNamespace, dataLayerHeight)
+	if result.Code != StatusSuccess {
+		return ResultRetrieveTxs{BaseResult: result.BaseResult, Timestamp: result.Timestamp}
+	}
+	blobs := result.Blobs
+	limits := types.GetDecodeLimits()
+	txs := make([][]byte, 0, len(blobs))
+	for i, blob := range blobs {
+		if err := limits.CheckSize("forced inclusion tx", blob); err != nil {
+			dac.Logger.Error("skipping forced inclusion tx", "daHeight", dataLayerHeight, "position", i, "error", err)
+			continue
+		}
+		txs = append(txs, blob)
+	}
+	return ResultRetrieveTxs{
+		BaseResult: BaseResult{
+			Code:     StatusSuccess,
+			DAHeight: dataLayerHeight,
+		},
+		Txs:       txs,
+		Timestamp: result.Timestamp,
+	}
+}
+
+// retrieveBlobs retrieves all blobs of namespace at given DA height, sharing the fetch with other consumers if
+// watcher is set.
+func (dac *DAClient) retrieveBlobs(ctx context.Context, namespace goDA.Namespace, dataLayerHeight uint64) ResultRetrieveBlobs {
+	if dac.Watcher != nil {
+		return dac.Watcher.Retrieve(ctx, namespace, dataLayerHeight)
+	}
+	return retrieveBlobs(ctx, dac.DA, namespace, dataLayerHeight, dac.RetrieveTimeout)
+}
+
+func retrieveBlobs(ctx context.Context, da goDA.DA, namespace goDA.Namespace, dataLayerHeight uint64, timeout time.Duration) ResultRetrieveBlobs {
+	result, err := da.GetIDs(ctx, dataLayerHeight, namespace)
 	if err != nil {
-		return ResultRetrieveHeaders{
+		return ResultRetrieveBlobs{
 			BaseResult: BaseResult{
 				Code:     StatusError,
 				Message:  fmt.Sprintf("failed to get IDs: %s", err.Error()),
@@ -182,22 +310,26 @@ func (dac *DAClient) RetrieveHeaders(ctx context.Context, dataLayerHeight uint64
 		}
 	}
 
-	// If no blocks are found, return a non-blocking error.
-	if len(result.IDs) == 0 {
-		return ResultRetrieveHeaders{
+	// If no blobs are found, return a non-blocking error.
+	if result == nil || len(result.IDs) == 0 {
+		res := ResultRetrieveBlobs{
 			BaseResult: BaseResult{
 				Code:     StatusNotFound,
 				Message:  (&goDA.ErrBlobNotFound{}).Error(),
 				DAHeight: dataLayerHeight,
 			},
 		}
+		if result != nil {
+			res.Timestamp = result.Timestamp
+		}
+		return res
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, dac.RetrieveTimeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	blobs, err := dac.DA.Get(ctx, result.IDs, dac.Namespace)
+	blobs, err := da.Get(ctx, result.IDs, namespace)
 	if err != nil {
-		return ResultRetrieveHeaders{
+		return ResultRetrieveBlobs{
 			BaseResult: BaseResult{
 				Code:     StatusError,
 				Message:  fmt.Sprintf("failed to get blobs: %s", err.Error()),
@@ -205,34 +337,55 @@ func (dac *DAClient) RetrieveHeaders(ctx context.Context, dataLayerHeight uint64
 			},
 		}
 	}
-
-	headers := make([]*types.SignedHeader, len(blobs))
-	for i, blob := range blobs {
-		var header pb.SignedHeader
-		err = proto.Unmarshal(blob, &header)
-		if err != nil {
-			dac.Logger.Error("failed to unmarshal block", "daHeight", dataLayerHeight, "position", i, "error", err)
-			continue
-		}
-		headers[i] = new(types.SignedHeader)
-		err := headers[i].FromProto(&header)
-		if err != nil {
-			return ResultRetrieveHeaders{
-				BaseResult: BaseResult{
-					Code:    StatusError,
-					Message: err.Error(),
-				},
-			}
+	if len(blobs) != len(result.IDs) {
+		return ResultRetrieveBlobs{
+			BaseResult: BaseResult{
+				Code:     StatusError,
+				Message:  fmt.Sprintf("failed to get blobs: got %d blobs of %d IDs", len(blobs), len(result.IDs)),
+				DAHeight: dataLayerHeight,
+			},
 		}
 	}
-
-	return ResultRetrieveHeaders{
+	return ResultRetrieveBlobs{
 		BaseResult: BaseResult{
 			Code:     StatusSuccess,
 			DAHeight: dataLayerHeight,
 		},
-		Headers: headers,
+		IDs:       result.IDs,
+		Blobs:     blobs,
+		Timestamp: result.Timestamp,
+	}
+}
+
+// InclusionCertificates returns certificates of inclusion of blobs with given IDs at DA height, with commitments
+// to the blobs and proofs of their inclusion computed by DA layer. Heights of blocks are not set.
+func (dac *DAClient) InclusionCertificates(ctx context.Context, daHeight uint64, ids []goDA.ID, blobs [][]byte) ([]*types.DAInclusionCertificate, error) {
+	if len(ids) != len(blobs) {
+		return nil, fmt.Errorf("number of IDs %d doesn't match number of blobs %d", len(ids), len(blobs))
+	}
+	ctx, cancel := context.WithTimeout(ctx, dac.RetrieveTimeout)
+	defer cancel()
+	commitments, err := dac.DA.Commit(ctx, blobs, dac.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute commitments: %w", err)
+	}
+	proofs, err := dac.DA.GetProofs(ctx, ids, dac.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inclusion proofs: %w", err)
+	}
+	if len(commitments) != len(ids) || len(proofs) != len(ids) {
+		return nil, fmt.Errorf("unexpected number of commitments %d or proofs %d of %d blobs", len(commitments), len(proofs), len(ids))
+	}
+	certs := make([]*types.DAInclusionCertificate, len(ids))
+	for i := range ids {
+		certs[i] = &types.DAInclusionCertificate{
+			DAHeight:   daHeight,
+			ID:         ids[i],
+			Commitment: commitments[i],
+			Proof:      proofs[i],
+		}
 	}
+	return certs, nil
 }
 
 func (dac *DAClient) submit(ctx context.Context, blobs []goDA.Blob, gasPrice float64, namespace goDA.Namespace) ([]goDA.ID, error) {
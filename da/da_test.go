@@ -384,3 +384,55 @@ func TestSubmitWithOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestRetrieveSkipsMalformedHeaders(t *testing.T) {
+	mockDA := &damock.MockDA{}
+	dalc := NewDAClient(mockDA, -1, -1, nil, nil, log.TestingLogger())
+	header, _ := types.GetRandomBlock(1, 0, "TestRetrieveSkipsMalformedHeaders")
+	headerBytes, err := header.MarshalBinary()
+	require.NoError(t, err)
+
+	ids := []da.ID{[]byte("1"), []byte("2"), []byte("3")}
+	mockDA.On("GetIDs", mock.Anything, uint64(1), []byte(nil)).Return(&da.GetIDsResult{IDs: ids}, nil)
+	// junk and empty blobs are skipped, instead of failing retrieval of valid headers
+	mockDA.On("Get", mock.Anything, ids, []byte(nil)).Return([]da.Blob{{0xff, 0xff}, {}, headerBytes}, nil)
+
+	resp := dalc.RetrieveHeaders(context.Background(), 1)
+	require.Equal(t, StatusSuccess, resp.Code)
+	require.Len(t, resp.Headers, 1)
+	assert.Equal(t, header.Hash(), resp.Headers[0].Hash())
+	assert.Equal(t, []da.ID{ids[2]}, resp.IDs)
+	assert.Equal(t, [][]byte{headerBytes}, resp.Blobs)
+}
+
+func TestInclusionCertificates(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dummyDA := goDATest.NewDummyDA()
+	dalc := NewDAClient(dummyDA, -1, -1, nil, nil, log.TestingLogger())
+
+	header1, _ := types.GetRandomBlock(1, 0, "TestInclusionCertificates")
+	header2, _ := types.GetRandomBlock(2, 0, "TestInclusionCertificates")
+	maxBlobSize, err := dalc.DA.MaxBlobSize(ctx)
+	require.NoError(err)
+	res := dalc.SubmitHeaders(ctx, []*types.SignedHeader{header1, header2}, maxBlobSize, -1)
+	require.Equal(StatusSuccess, res.Code)
+	require.Len(res.IDs, 2)
+	require.Len(res.Blobs, 2)
+
+	certs, err := dalc.InclusionCertificates(ctx, res.DAHeight, res.IDs, res.Blobs)
+	require.NoError(err)
+	require.Len(certs, 2)
+	for i, cert := range certs {
+		require.Equal(res.DAHeight, cert.DAHeight)
+		require.EqualValues(res.IDs[i], cert.ID)
+		require.NotEmpty(cert.Commitment)
+		// certificate can be verified by DA layer without the blob
+		valid, err := dummyDA.Validate(ctx, []da.ID{cert.ID}, []da.Proof{cert.Proof}, nil)
+		require.NoError(err)
+		require.Equal([]bool{true}, valid)
+	}
+
+	_, err = dalc.InclusionCertificates(ctx, res.DAHeight, res.IDs, res.Blobs[:1])
+	require.Error(err)
+}
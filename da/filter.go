@@ -0,0 +1,76 @@
+package da
+
+import (
+	"bytes"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// Reasons of discarding blobs retrieved from DA, reported in ResultRetrieveHeaders.Discarded.
+const (
+	// DiscardMagic means that blob doesn't start like an encoded signed header.
+	DiscardMagic = "magic"
+	// DiscardSize means that blob exceeds maximum size.
+	DiscardSize = "size"
+	// DiscardVersion means that header has unexpected block protocol version.
+	DiscardVersion = "version"
+	// DiscardProposer means that header is not proposed by the sequencer, or it's not signed.
+	DiscardProposer = "proposer"
+	// DiscardMalformed means that blob is not a valid encoding of signed header.
+	DiscardMalformed = "malformed"
+	// DiscardSignature means that header failed validation, including signature verification, after unmarshaling.
+	DiscardSignature = "signature"
+)
+
+// signed header is encoded with field 1 (header) first, which serves as magic bytes of header blobs
+var headerMagic = protowire.AppendTag(nil, 1, protowire.BytesType)
+
+// HeaderFilter discards spam blobs, which others post into a shared namespace, before they are unmarshaled.
+// Checks decode only the header fields of signed header, so they are much cheaper than unmarshaling, which
+// decodes the whole validator set. Signature is verified by the block manager, after
+// unmarshaling headers which passed the checks.
+type HeaderFilter struct {
+	// MaxBlobSize is the maximum size of a header blob. Zero disables the check.
+	MaxBlobSize uint64
+	// BlockVersion is the expected block protocol version.
+	BlockVersion uint64
+	// ProposerAddress is the address of the sequencer. Empty address disables the check.
+	ProposerAddress []byte
+}
+
+// Check returns a reason of discarding the blob, or empty string if the blob passes all checks.
+func (f *HeaderFilter) Check(blob []byte) string {
+	if !bytes.HasPrefix(blob, headerMagic) {
+		return DiscardMagic
+	}
+	if f.MaxBlobSize != 0 && uint64(len(blob)) > f.MaxBlobSize {
+		return DiscardSize
+	}
+	version, proposer, signature, err := decodeHeaderBlob(blob)
+	if err != nil {
+		return DiscardMalformed
+	}
+	if version != f.BlockVersion {
+		return DiscardVersion
+	}
+	if len(signature) == 0 || (len(f.ProposerAddress) != 0 && !bytes.Equal(proposer, f.ProposerAddress)) {
+		return DiscardProposer
+	}
+	return ""
+}
+
+// decodeHeaderBlob returns block version, proposer address and signature of encoded signed header.
+// Validator set is skipped, and header is decoded on its own, as it contains no nested messages but version.
+func decodeHeaderBlob(blob []byte) (version uint64, proposer, signature []byte, err error) {
+	var signedHeader pb.SignedHeaderBlob
+	if err := signedHeader.Unmarshal(blob); err != nil {
+		return 0, nil, nil, err
+	}
+	var header pb.Header
+	if err := header.Unmarshal(signedHeader.Header); err != nil {
+		return 0, nil, nil, err
+	}
+	return header.Version.GetBlock(), header.ProposerAddress, signedHeader.Signature, nil
+}
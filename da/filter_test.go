@@ -0,0 +1,84 @@
+package da
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/go-da"
+	damock "github.com/rollkit/go-da/mocks"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestHeaderFilter(t *testing.T) {
+	header, _ := types.GetRandomBlock(1, 0, "TestHeaderFilter")
+	headerBytes, err := header.MarshalBinary()
+	require.NoError(t, err)
+	filter := &HeaderFilter{
+		MaxBlobSize:     uint64(len(headerBytes)),
+		BlockVersion:    header.Version.Block,
+		ProposerAddress: header.ProposerAddress,
+	}
+	assert.Equal(t, "", filter.Check(headerBytes))
+
+	other, _ := types.GetRandomBlock(1, 0, "TestHeaderFilter")
+	otherBytes, err := other.MarshalBinary()
+	require.NoError(t, err)
+
+	wrongVersion := *header
+	wrongVersion.Version.Block++
+	wrongVersionBytes, err := wrongVersion.MarshalBinary()
+	require.NoError(t, err)
+
+	unsigned := *header
+	unsigned.Signature = nil
+	unsignedBytes, err := unsigned.MarshalBinary()
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		blob   []byte
+		reason string
+	}{
+		{"empty", nil, DiscardMagic},
+		{"junk", []byte("spam spam spam"), DiscardMagic},
+		{"truncated", headerBytes[:len(headerBytes)/2], DiscardMalformed},
+		{"too big", append(headerBytes, headerBytes...), DiscardSize},
+		{"wrong version", wrongVersionBytes, DiscardVersion},
+		{"other proposer", otherBytes, DiscardProposer},
+		{"unsigned", unsignedBytes, DiscardProposer},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.reason, filter.Check(c.blob))
+		})
+	}
+}
+
+func TestRetrieveDiscardsSpam(t *testing.T) {
+	mockDA := &damock.MockDA{}
+	dalc := NewDAClient(mockDA, -1, -1, nil, nil, log.TestingLogger())
+	header, _ := types.GetRandomBlock(1, 0, "TestRetrieveDiscardsSpam")
+	headerBytes, err := header.MarshalBinary()
+	require.NoError(t, err)
+	dalc.HeaderFilter = &HeaderFilter{BlockVersion: header.Version.Block, ProposerAddress: header.ProposerAddress}
+
+	other, _ := types.GetRandomBlock(1, 0, "TestRetrieveDiscardsSpam")
+	otherBytes, err := other.MarshalBinary()
+	require.NoError(t, err)
+
+	ids := []da.ID{[]byte("1"), []byte("2"), []byte("3"), []byte("4")}
+	mockDA.On("GetIDs", mock.Anything, uint64(1), []byte(nil)).Return(&da.GetIDsResult{IDs: ids}, nil)
+	mockDA.On("Get", mock.Anything, ids, []byte(nil)).Return([]da.Blob{[]byte("spam"), otherBytes, headerBytes, []byte("more spam")}, nil)
+
+	resp := dalc.RetrieveHeaders(context.Background(), 1)
+	require.Equal(t, StatusSuccess, resp.Code)
+	require.Len(t, resp.Headers, 1)
+	assert.Equal(t, header.Hash(), resp.Headers[0].Hash())
+	assert.Equal(t, map[string]uint64{DiscardMagic: 2, DiscardProposer: 1}, resp.Discarded)
+}
@@ -0,0 +1,22 @@
+package da
+
+// Parameters of gas model of blob submission, following PayForBlobs transaction in Celestia.
+const (
+	shareSize            = 512
+	shareContentSize     = 478
+	gasPerBlobByte       = 8
+	bytesPerBlobInfo     = 70
+	txSizeCostPerByte    = 10
+	payForBlobsFixedCost = 75000
+)
+
+// EstimateGas estimates gas required to submit blobs of given sizes in a single transaction. Estimation
+// follows gas model of Celestia; actual gas depends on the DA layer and may differ.
+func EstimateGas(blobSizes ...uint64) uint64 {
+	gas := uint64(payForBlobsFixedCost)
+	for _, size := range blobSizes {
+		shares := (size + shareContentSize - 1) / shareContentSize
+		gas += shares*shareSize*gasPerBlobByte + bytesPerBlobInfo*txSizeCostPerByte
+	}
+	return gas
+}
@@ -0,0 +1,154 @@
+package da
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	goDA "github.com/rollkit/go-da"
+)
+
+// Watcher fetches blobs from DA on behalf of multiple consumers (block retriever, forced inclusion scanner, DA
+// verifier, cross-rollup message reader), so that each DA height is fetched once per namespace.
+//
+// Concurrent requests for the same namespace and height share a single fetch. Successful results (including
+// heights without blobs) are kept while any subscription of the namespace hasn't processed the height yet, so
+// consumers progressing at different pace don't fetch the same height again. Failed fetches are not kept and are
+// repeated on the next request.
+type Watcher struct {
+	da goDA.DA
+	// RetrieveTimeout is the timeout of fetching blobs, once IDs at a height are known.
+	RetrieveTimeout time.Duration
+
+	mtx     sync.Mutex
+	fetches map[watchKey]*blobFetch
+	subs    map[string]map[*Subscription]struct{}
+}
+
+type watchKey struct {
+	namespace string
+	daHeight  uint64
+}
+
+// blobFetch is a fetch of blobs at a single DA height. Result is set before done is closed.
+type blobFetch struct {
+	done chan struct{}
+	res  ResultRetrieveBlobs
+}
+
+// Subscription is a consumer of a namespace. Results at heights not processed by a subscription are retained.
+// Methods of nil subscription are no-ops.
+type Subscription struct {
+	w         *Watcher
+	namespace string
+	// next is the lowest DA height not processed yet, guarded by w.mtx.
+	next uint64
+}
+
+// NewWatcher returns a new watcher of DA.
+func NewWatcher(da goDA.DA) *Watcher {
+	return &Watcher{
+		da:              da,
+		RetrieveTimeout: defaultRetrieveTimeout,
+		fetches:         make(map[watchKey]*blobFetch),
+		subs:            make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe registers a consumer of namespace, which processes DA heights starting from given height.
+func (w *Watcher) Subscribe(namespace goDA.Namespace, from uint64) *Subscription {
+	s := &Subscription{w: w, namespace: string(namespace), next: from}
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.subs[s.namespace] == nil {
+		w.subs[s.namespace] = make(map[*Subscription]struct{})
+	}
+	w.subs[s.namespace][s] = struct{}{}
+	return s
+}
+
+// Retrieve returns all blobs of namespace at given DA height. Blobs are fetched from DA only if no other request
+// for the height is in progress and the result isn't retained.
+func (w *Watcher) Retrieve(ctx context.Context, namespace goDA.Namespace, daHeight uint64) ResultRetrieveBlobs {
+	key := watchKey{namespace: string(namespace), daHeight: daHeight}
+	w.mtx.Lock()
+	f, ok := w.fetches[key]
+	if !ok {
+		f = &blobFetch{done: make(chan struct{})}
+		w.fetches[key] = f
+	}
+	w.mtx.Unlock()
+
+	if ok {
+		select {
+		case <-f.done:
+			return f.res
+		case <-ctx.Done():
+			return ResultRetrieveBlobs{
+				BaseResult: BaseResult{Code: StatusError, Message: ctx.Err().Error(), DAHeight: daHeight},
+			}
+		}
+	}
+
+	// fetch is done in context of the first request; if it's canceled, waiting requests get the error and retry
+	f.res = retrieveBlobs(ctx, w.da, namespace, daHeight, w.RetrieveTimeout)
+	w.mtx.Lock()
+	if f.res.Code == StatusError || !w.needed(key) {
+		delete(w.fetches, key)
+	}
+	w.mtx.Unlock()
+	close(f.done)
+	return f.res
+}
+
+// needed returns true if any subscription of the namespace hasn't processed the height yet. Must be called with
+// w.mtx held.
+func (w *Watcher) needed(key watchKey) bool {
+	for s := range w.subs[key.namespace] {
+		if s.next <= key.daHeight {
+			return true
+		}
+	}
+	return false
+}
+
+// release drops completed fetches of namespace, which are no longer needed. Must be called with w.mtx held.
+func (w *Watcher) release(namespace string) {
+	for key, f := range w.fetches {
+		if key.namespace != namespace {
+			continue
+		}
+		select {
+		case <-f.done:
+		default:
+			continue
+		}
+		if !w.needed(key) {
+			delete(w.fetches, key)
+		}
+	}
+}
+
+// Processed marks all DA heights up to and including daHeight as processed by the subscription.
+func (s *Subscription) Processed(daHeight uint64) {
+	if s == nil {
+		return
+	}
+	s.w.mtx.Lock()
+	defer s.w.mtx.Unlock()
+	if daHeight+1 > s.next {
+		s.next = daHeight + 1
+	}
+	s.w.release(s.namespace)
+}
+
+// Close unregisters the subscription.
+func (s *Subscription) Close() {
+	if s == nil {
+		return
+	}
+	s.w.mtx.Lock()
+	defer s.w.mtx.Unlock()
+	delete(s.w.subs[s.namespace], s)
+	s.w.release(s.namespace)
+}
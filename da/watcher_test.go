@@ -0,0 +1,87 @@
+package da
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/go-da"
+	goDATest "github.com/rollkit/go-da/test"
+)
+
+// countingDA counts GetIDs calls and blocks them until release is closed.
+type countingDA struct {
+	da.DA
+	calls   atomic.Uint64
+	release chan struct{}
+}
+
+func (c *countingDA) GetIDs(ctx context.Context, height uint64, ns da.Namespace) (*da.GetIDsResult, error) {
+	c.calls.Add(1)
+	<-c.release
+	return c.DA.GetIDs(ctx, height, ns)
+}
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	dummy := goDATest.NewDummyDA()
+	ns := da.Namespace("ns")
+	ids, err := dummy.Submit(ctx, []da.Blob{[]byte("blob")}, -1, ns)
+	require.NoError(t, err)
+	daHeight := binary.LittleEndian.Uint64(ids[0])
+
+	counting := &countingDA{DA: dummy, release: make(chan struct{})}
+	w := NewWatcher(counting)
+	retriever := w.Subscribe(ns, daHeight)
+	scanner := w.Subscribe(ns, daHeight)
+
+	// concurrent requests share a single fetch
+	var wg sync.WaitGroup
+	results := make([]ResultRetrieveBlobs, 3)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = w.Retrieve(ctx, ns, daHeight)
+		}()
+	}
+	require.Eventually(t, func() bool { return counting.calls.Load() > 0 }, time.Second, time.Millisecond)
+	close(counting.release)
+	wg.Wait()
+	for _, res := range results {
+		require.Equal(t, StatusSuccess, res.Code, res.Message)
+		assert.Equal(t, [][]byte{[]byte("blob")}, res.Blobs)
+	}
+	assert.EqualValues(t, 1, counting.calls.Load())
+
+	// result is retained until processed by all subscriptions
+	retriever.Processed(daHeight)
+	assert.Equal(t, StatusSuccess, w.Retrieve(ctx, ns, daHeight).Code)
+	assert.EqualValues(t, 1, counting.calls.Load())
+
+	scanner.Processed(daHeight)
+	assert.Equal(t, StatusSuccess, w.Retrieve(ctx, ns, daHeight).Code)
+	assert.EqualValues(t, 2, counting.calls.Load())
+
+	// results of namespaces without subscriptions and failed fetches are not retained
+	assert.Equal(t, StatusSuccess, w.Retrieve(ctx, da.Namespace("other"), daHeight).Code)
+	assert.Equal(t, StatusSuccess, w.Retrieve(ctx, da.Namespace("other"), daHeight).Code)
+	assert.Equal(t, StatusError, w.Retrieve(ctx, ns, daHeight+1).Code)
+	assert.Equal(t, StatusError, w.Retrieve(ctx, ns, daHeight+1).Code)
+	assert.EqualValues(t, 6, counting.calls.Load())
+	w.mtx.Lock()
+	assert.Empty(t, w.fetches)
+	w.mtx.Unlock()
+
+	scanner.Close()
+	retriever.Close()
+	var nilSub *Subscription
+	nilSub.Processed(1)
+	nilSub.Close()
+}
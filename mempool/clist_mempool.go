@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/cometbft/cometbft/types"
 
 	"github.com/rollkit/rollkit/mempool/clist"
+	rktypes "github.com/rollkit/rollkit/types"
 )
 
 // CListMempool is an ordered in-memory pool for transactions before they are
@@ -37,12 +39,19 @@ type CListMempool struct {
 	// Exclusive mutex for Update method to prevent concurrent execution of
 	// CheckTx or ReapMaxBytesMaxGas(ReapMaxTxs) methods.
 	updateMtx sync.RWMutex
+	// batchMtx is held exclusively by CheckTxBatch, so that transactions of a batch are added to the list
+	// next to each other, and reaped transactions never include only a part of a batch.
+	batchMtx  sync.RWMutex
 	preCheck  PreCheckFunc
 	postCheck PostCheckFunc
 
 	txs          *clist.CList // concurrent linked-list of good txs
 	proxyAppConn proxy.AppConnMempool
 
+	// checkTxConns are additional connections used to check new txs concurrently, see WithCheckTxConns
+	checkTxConns []proxy.AppConnMempool
+	nextConn     atomic.Uint64
+
 	// Track whether we're rechecking txs.
 	// These are not protected by a mutex and are expected to be mutated in
 	// serial (ie. by abci responses which are called in serial).
@@ -124,6 +133,20 @@ func WithPostCheck(f PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithCheckTxConns sets additional connections to the application, used together with the main connection
+// to check new transactions concurrently. Transactions are assigned to connections in round-robin fashion,
+// so they may be added to the mempool in a different order than they were checked in. Rechecking uses the
+// main connection only. Without additional connections, transactions are checked serially, in order.
+func WithCheckTxConns(conns ...proxy.AppConnMempool) CListMempoolOption {
+	return func(mem *CListMempool) {
+		for _, conn := range conns {
+			// responses are handled by request specific callbacks only, see reqResCb
+			conn.SetResponseCallback(func(*abci.Request, *abci.Response) {})
+		}
+		mem.checkTxConns = conns
+	}
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
@@ -151,9 +174,27 @@ func (mem *CListMempool) SizeBytes() int64 {
 
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) FlushAppConn() error {
+	for _, conn := range mem.checkTxConns {
+		if err := conn.Flush(context.TODO()); err != nil {
+			return err
+		}
+	}
 	return mem.proxyAppConn.Flush(context.TODO())
 }
 
+// checkTxConn returns the connection used to check the next new transaction, and true if it's one of the
+// additional connections.
+func (mem *CListMempool) checkTxConn() (proxy.AppConnMempool, bool) {
+	if len(mem.checkTxConns) == 0 {
+		return mem.proxyAppConn, false
+	}
+	i := (mem.nextConn.Add(1) - 1) % uint64(len(mem.checkTxConns)+1)
+	if i == 0 {
+		return mem.proxyAppConn, false
+	}
+	return mem.checkTxConns[i-1], true
+}
+
 // XXX: Unsafe! Calling Flush may leave mempool in inconsistent state.
 func (mem *CListMempool) Flush() {
 	mem.updateMtx.RLock()
@@ -203,6 +244,56 @@ func (mem *CListMempool) CheckTx(
 	tx types.Tx,
 	cb func(*abci.ResponseCheckTx),
 	txInfo TxInfo,
+) error {
+	mem.batchMtx.RLock()
+	defer mem.batchMtx.RUnlock()
+	return mem.checkTx(tx, cb, txInfo)
+}
+
+// CheckTxBatch checks transactions in order and adds them to the mempool atomically: if any transaction
+// is rejected, transactions of the batch which were already added are removed, so that either all or
+// none of them end up in the mempool. Responses of checked transactions are returned, along with true if
+// the batch was added. Transactions following the rejected one are not checked.
+//
+// NOTE: the application keeps changes of its check state made by removed transactions until the mempool
+// is rechecked after the next block.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) CheckTxBatch(txs types.Txs, txInfo TxInfo) ([]*abci.ResponseCheckTx, bool, error) {
+	mem.batchMtx.Lock()
+	defer mem.batchMtx.Unlock()
+
+	responses := make([]*abci.ResponseCheckTx, 0, len(txs))
+	for i, tx := range txs {
+		resCh := make(chan *abci.ResponseCheckTx, 1)
+		if err := mem.checkTx(tx, func(res *abci.ResponseCheckTx) { resCh <- res }, txInfo); err != nil {
+			mem.removeBatch(txs[:i])
+			return responses, false, fmt.Errorf("tx %d: %w", i, err)
+		}
+		res := <-resCh
+		responses = append(responses, res)
+		// transaction might be rejected by post-check, or because mempool is full, even if code is OK
+		if _, added := mem.txsMap.Load(tx.Key()); !added {
+			mem.removeBatch(txs[:i])
+			return responses, false, nil
+		}
+	}
+	return responses, true, nil
+}
+
+// removeBatch removes transactions of a rejected batch from the mempool and the cache, so that the batch
+// can be submitted again.
+func (mem *CListMempool) removeBatch(txs types.Txs) {
+	for _, tx := range txs {
+		_ = mem.RemoveTxByKey(tx.Key())
+		mem.cache.Remove(tx)
+	}
+}
+
+func (mem *CListMempool) checkTx(
+	tx types.Tx,
+	cb func(*abci.ResponseCheckTx),
+	txInfo TxInfo,
 ) error {
 	mem.updateMtx.RLock()
 	// use defer to unlock mutex because application (*local client*) might panic
@@ -229,8 +320,9 @@ func (mem *CListMempool) CheckTx(
 		}
 	}
 
+	conn, concurrent := mem.checkTxConn()
 	// NOTE: proxyAppConn may error if tx buffer is full
-	if err := mem.proxyAppConn.Error(); err != nil {
+	if err := conn.Error(); err != nil {
 		return err
 	}
 
@@ -249,11 +341,11 @@ func (mem *CListMempool) CheckTx(
 		return ErrTxInCache
 	}
 
-	reqRes, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.RequestCheckTx{Tx: tx})
+	reqRes, err := conn.CheckTxAsync(context.TODO(), &abci.RequestCheckTx{Tx: tx})
 	if err != nil {
 		return err
 	}
-	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb))
+	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb, concurrent))
 
 	return nil
 }
@@ -289,14 +381,18 @@ func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
 // when all other response processing is complete.
 //
 // Used in CheckTx to record PeerID who sent us the tx.
+//
+// Responses from additional connections (concurrent is true) may be received while the mempool is rechecked
+// via the main connection.
 func (mem *CListMempool) reqResCb(
 	tx []byte,
 	peerID uint16,
 	peerP2PID p2p.ID,
 	externalCb func(*abci.ResponseCheckTx),
+	concurrent bool,
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
-		if mem.recheckCursor != nil {
+		if !concurrent && mem.recheckCursor != nil {
 			// this should never happen
 			panic("recheck cursor is not nil in reqResCb")
 		}
@@ -399,7 +495,7 @@ func (mem *CListMempool) resCbFirstTime(
 			mem.addTx(memTx)
 			mem.logger.Debug(
 				"added good transaction",
-				"tx", types.Tx(tx).Hash(),
+				"tx", rktypes.TxHash(tx),
 				"res", r,
 				"height", memTx.height,
 				"total", mem.Size(),
@@ -409,7 +505,7 @@ func (mem *CListMempool) resCbFirstTime(
 			// ignore bad transaction
 			mem.logger.Debug(
 				"rejected bad transaction",
-				"tx", types.Tx(tx).Hash(),
+				"tx", rktypes.TxHash(tx),
 				"peerID", peerP2PID,
 				"res", r,
 				"err", postCheckErr,
@@ -472,7 +568,7 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 
 		if (r.CheckTx.Code != abci.CodeTypeOK) || postCheckErr != nil {
 			// Tx became invalidated due to newly committed block.
-			mem.logger.Debug("tx is no longer valid", "tx", types.Tx(tx).Hash(), "res", r, "err", postCheckErr)
+			mem.logger.Debug("tx is no longer valid", "tx", rktypes.TxHash(tx), "res", r, "err", postCheckErr)
 			mem.removeTx(tx, mem.recheckCursor)
 			// We remove the invalid tx from the cache because it might be good later
 			if !mem.config.KeepInvalidTxsInCache {
@@ -521,6 +617,8 @@ func (mem *CListMempool) notifyTxsAvailable() {
 
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	mem.batchMtx.RLock()
+	defer mem.batchMtx.RUnlock()
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
@@ -551,8 +649,22 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	return txs
 }
 
+// GasWanted returns gas wanted by transaction, as reported by CheckTx. Second return value is false if
+// transaction is not in the mempool.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) GasWanted(tx types.Tx) (int64, bool) {
+	e, ok := mem.txsMap.Load(tx.Key())
+	if !ok {
+		return 0, false
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx).gasWanted, true
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
+	mem.batchMtx.RLock()
+	defer mem.batchMtx.RUnlock()
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
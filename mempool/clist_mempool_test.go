@@ -7,6 +7,7 @@ import (
 	mrand "math/rand"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -455,6 +456,41 @@ func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	}
 }
 
+func TestMempoolCheckTxBatch(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// all transactions are added in order
+	responses, accepted, err := mp.CheckTxBatch(types.Txs{types.Tx("a=1"), types.Tx("b=2")}, TxInfo{})
+	require.NoError(t, err)
+	assert.True(t, accepted)
+	require.Len(t, responses, 2)
+	assert.Equal(t, types.Txs{types.Tx("a=1"), types.Tx("b=2")}, mp.ReapMaxTxs(-1))
+
+	// invalid transaction rejects the whole batch, following transactions are not checked
+	responses, accepted, err = mp.CheckTxBatch(types.Txs{types.Tx("c=3"), types.Tx("invalid"), types.Tx("d=4")}, TxInfo{})
+	require.NoError(t, err)
+	assert.False(t, accepted)
+	require.Len(t, responses, 2)
+	assert.Equal(t, abci.CodeTypeOK, responses[0].Code)
+	assert.Equal(t, kvstore.CodeTypeInvalidTxFormat, responses[1].Code)
+	assert.Equal(t, 2, mp.Size())
+
+	// transaction already in the mempool rejects the batch with error
+	_, accepted, err = mp.CheckTxBatch(types.Txs{types.Tx("c=3"), types.Tx("a=1")}, TxInfo{})
+	assert.ErrorIs(t, err, ErrTxInCache)
+	assert.False(t, accepted)
+	assert.Equal(t, 2, mp.Size())
+
+	// removed transactions are removed from the cache, so the batch can be submitted again
+	_, accepted, err = mp.CheckTxBatch(types.Txs{types.Tx("c=3"), types.Tx("d=4")}, TxInfo{})
+	require.NoError(t, err)
+	assert.True(t, accepted)
+	assert.Equal(t, 4, mp.Size())
+}
+
 func TestTxsAvailable(t *testing.T) {
 	app := kvstore.NewInMemoryApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -786,6 +822,68 @@ func TestMempoolRemoteAppConcurrency(t *testing.T) {
 	require.NoError(t, mp.FlushAppConn())
 }
 
+// countingConn counts CheckTx and Flush calls on a mempool connection.
+type countingConn struct {
+	proxy.AppConnMempool
+	checkTxs atomic.Int64
+	flushes  atomic.Int64
+}
+
+func (c *countingConn) CheckTxAsync(ctx context.Context, req *abci.RequestCheckTx) (*abciclient.ReqRes, error) {
+	c.checkTxs.Add(1)
+	return c.AppConnMempool.CheckTxAsync(ctx, req)
+}
+
+func (c *countingConn) Flush(ctx context.Context) error {
+	c.flushes.Add(1)
+	return c.AppConnMempool.Flush(ctx)
+}
+
+func TestMempoolConcurrentCheckTx(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	var conns []proxy.AppConnMempool
+	var counting []*countingConn
+	for i := 0; i < 2; i++ {
+		client, err := cc.NewABCIClient()
+		require.NoError(t, err)
+		require.NoError(t, client.Start())
+		t.Cleanup(func() { _ = client.Stop() })
+		conn := &countingConn{AppConnMempool: proxy.NewAppConnMempool(client, proxy.NopMetrics())}
+		conns = append(conns, conn)
+		counting = append(counting, conn)
+	}
+
+	cfg := ResetTestRoot("mempool_test")
+	defer os.RemoveAll(cfg.RootDir)
+	client, err := cc.NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() { _ = client.Stop() })
+	mp := NewCListMempool(cfg.Mempool, client, 0, WithCheckTxConns(conns...))
+	mp.SetLogger(log.TestingLogger())
+
+	txs := checkTxs(t, mp, 30, UnknownPeerID)
+	require.NoError(t, mp.FlushAppConn())
+	assert.Equal(t, 30, mp.Size())
+	for _, conn := range counting {
+		// transactions are distributed evenly between main and additional connections
+		assert.Equal(t, int64(10), conn.checkTxs.Load())
+		assert.Equal(t, int64(1), conn.flushes.Load())
+	}
+
+	// rechecking uses the main connection only
+	mp.Lock()
+	err = mp.Update(1, txs[:10], abciResponses(10, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, mp.FlushAppConn())
+	assert.Equal(t, 20, mp.Size())
+	for _, conn := range counting {
+		assert.Equal(t, int64(10), conn.checkTxs.Load())
+	}
+}
+
 // caller must close server
 func newRemoteApp(t *testing.T, addr string, app abci.Application) (abciclient.Client, service.Service) {
 	clientCreator, err := abciclient.NewClient(addr, "socket", true)
@@ -25,9 +25,42 @@ type CListMempoolReaper struct {
 	stopCh     chan struct{}
 	grpcClient *grpc.Client
 	rollupId   []byte
-	submitted  map[cmtypes.TxKey]struct{}
+	submitted  map[cmtypes.TxKey]*submission
 	mu         sync.RWMutex // Add a mutex to protect the submitted map
 	logger     log.Logger
+
+	// seq is the sequence number of the last submitted transaction
+	seq uint64
+	// lastReceived is the highest sequence number of transactions returned by the sequencer in batches
+	lastReceived uint64
+}
+
+// submission of a transaction to the sequencer.
+type submission struct {
+	// seq orders transactions by submission
+	seq uint64
+	// received is set when transaction is returned by the sequencer in a batch
+	received bool
+	// skipped is set when transaction is reported as skipped by the sequencer
+	skipped bool
+}
+
+// BatchReceipt lists discrepancies between transactions submitted to the sequencer and a batch returned by it.
+type BatchReceipt struct {
+	// Unexpected is the number of transactions in the batch which were not submitted by this node.
+	Unexpected int
+	// Duplicated is the number of transactions which were already returned in previous batches (or earlier in
+	// the same batch).
+	Duplicated int
+	// Reordered is the number of transactions returned after transactions submitted later.
+	Reordered int
+	// Skipped is the number of transactions not returned yet, while transactions submitted later were returned.
+	Skipped int
+}
+
+// Empty returns true if there are no discrepancies.
+func (r BatchReceipt) Empty() bool {
+	return r == BatchReceipt{}
 }
 
 // NewCListMempoolReaper initializes the mempool and sets up the gRPC client.
@@ -37,7 +70,7 @@ func NewCListMempoolReaper(mempool Mempool, rollupId []byte, seqClient *grpc.Cli
 		stopCh:     make(chan struct{}),
 		grpcClient: seqClient,
 		rollupId:   rollupId,
-		submitted:  make(map[cmtypes.TxKey]struct{}),
+		submitted:  make(map[cmtypes.TxKey]*submission),
 		logger:     logger,
 	}
 }
@@ -71,6 +104,62 @@ func (r *CListMempoolReaper) UpdateCommitedTxs(txs []cmtypes.Tx) {
 	}
 }
 
+// IsSubmitted returns true if transaction with given key was submitted to the sequencer, and it's not
+// committed yet.
+func (r *CListMempoolReaper) IsSubmitted(key cmtypes.TxKey) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.submitted[key]
+	return ok
+}
+
+// SubmitTx submits transaction directly to the sequencer, bypassing the mempool. It's used for
+// transactions which are not checked by the application, e.g. bundles of transactions.
+func (r *CListMempoolReaper) SubmitTx(ctx context.Context, tx cmtypes.Tx) error {
+	if err := r.retrySubmitTransaction(ctx, tx, MaxRetries, RetryDelay); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.markSubmitted(tx.Key())
+	r.mu.Unlock()
+	return nil
+}
+
+// VerifyBatch compares transactions of a batch returned by the sequencer with transactions submitted to it,
+// in order of submission. Discrepancies indicate a buggy or malicious sequencer. Transactions submitted
+// before the node was started are unknown, so unexpected transactions are reported only after the sequencer
+// returned a transaction submitted since start.
+func (r *CListMempoolReaper) VerifyBatch(txs [][]byte) BatchReceipt {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var receipt BatchReceipt
+	for _, tx := range txs {
+		sub, ok := r.submitted[cmtypes.Tx(tx).Key()]
+		switch {
+		case !ok:
+			if r.lastReceived > 0 {
+				receipt.Unexpected++
+			}
+		case sub.received:
+			receipt.Duplicated++
+		default:
+			sub.received = true
+			if sub.seq < r.lastReceived {
+				receipt.Reordered++
+			} else {
+				r.lastReceived = sub.seq
+			}
+		}
+	}
+	for _, sub := range r.submitted {
+		if !sub.received && !sub.skipped && sub.seq < r.lastReceived {
+			sub.skipped = true
+			receipt.Skipped++
+		}
+	}
+	return receipt
+}
+
 // StopReaper stops the reaper goroutine.
 func (r *CListMempoolReaper) StopReaper() {
 	close(r.stopCh)
@@ -94,11 +183,20 @@ func (r *CListMempoolReaper) reap(ctx context.Context) {
 		r.logger.Info("Reaper submitted transaction successfully", "tx key", tx.Key())
 
 		r.mu.Lock() // Lock the mutex before writing to the map
-		r.submitted[tx.Key()] = struct{}{}
+		r.markSubmitted(tx.Key())
 		r.mu.Unlock() // Unlock after modifying the map
 	}
 }
 
+// markSubmitted records submission of transaction with given key. Caller must hold the lock.
+func (r *CListMempoolReaper) markSubmitted(key cmtypes.TxKey) {
+	if _, ok := r.submitted[key]; ok {
+		return
+	}
+	r.seq++
+	r.submitted[key] = &submission{seq: r.seq}
+}
+
 func (reaper *CListMempoolReaper) retrySubmitTransaction(ctx context.Context, tx cmtypes.Tx, maxRetries int, delay time.Duration) error {
 	var err error
 	for i := 0; i < maxRetries; i++ {
@@ -0,0 +1,31 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	assert := assert.New(t)
+	r := NewCListMempoolReaper(nil, []byte("rollup"), nil, log.NewNopLogger())
+	txs := make([][]byte, 6)
+	for i := range txs {
+		txs[i] = []byte{byte(i)}
+	}
+
+	// transactions submitted before start are not known
+	assert.Equal(BatchReceipt{}, r.VerifyBatch([][]byte{[]byte("old")}))
+
+	for _, tx := range txs {
+		r.markSubmitted(cmtypes.Tx(tx).Key())
+	}
+	assert.True(r.VerifyBatch([][]byte{txs[0], txs[1]}).Empty())
+	// txs[2] is skipped
+	assert.Equal(BatchReceipt{Skipped: 1}, r.VerifyBatch([][]byte{txs[3]}))
+	assert.Equal(BatchReceipt{Unexpected: 1, Duplicated: 1, Reordered: 2},
+		r.VerifyBatch([][]byte{txs[5], txs[4], []byte("other"), txs[1], txs[2]}))
+	assert.True(r.VerifyBatch(nil).Empty())
+}
@@ -0,0 +1,549 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/proxy"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/state"
+)
+
+// maxABCIRetryInterval limits the interval between attempts to reconnect to ABCI application.
+const maxABCIRetryInterval = 30 * time.Second
+
+var (
+	// ErrAppUnavailable is returned when connection to ABCI application is broken and node is reconnecting to it.
+	ErrAppUnavailable = errors.New("ABCI application is unavailable, reconnecting")
+
+	// ErrAppTimeout is returned when call to ABCI application doesn't finish within configured timeout.
+	ErrAppTimeout = errors.New("ABCI application call timed out")
+)
+
+// names of connections to ABCI application, in order of appConnSet.clients
+var appConnNames = [...]string{"consensus", "mempool", "query", "snapshot"}
+
+// appConnName returns the name of i-th connection in appConnSet.clients. Connections following the standard
+// ones are additional mempool connections, used to check transactions concurrently.
+func appConnName(i int) string {
+	if i < len(appConnNames) {
+		return appConnNames[i]
+	}
+	return fmt.Sprintf("check_tx_%d", i-len(appConnNames)+1)
+}
+
+// AppHandshake brings ABCI application in sync with the node after reconnecting to it.
+type AppHandshake func(ctx context.Context, consensus proxy.AppConnConsensus, query proxy.AppConnQuery) error
+
+// appConnSet is a set of connections to ABCI application, created with the same client creator.
+type appConnSet struct {
+	clients   []abcicli.Client
+	consensus proxy.AppConnConsensus
+	mempool   proxy.AppConnMempool
+	query     proxy.AppConnQuery
+	snapshot  proxy.AppConnSnapshot
+	// checkTx are additional mempool connections, see appConns.CheckTxConns
+	checkTx []proxy.AppConnMempool
+}
+
+// appConns implements proxy.AppConns, reconnecting to ABCI application when connection is broken, e.g.
+// because application process was redeployed. Unlike in CometBFT, node is not killed on connection error.
+//
+// Reconnection is retried with exponential backoff, starting at retryInterval. After reconnecting, handshake
+// is performed before connections are available again. If application doesn't come back within
+// reconnectTimeout, node is stopped. While reconnecting, all calls return ErrAppUnavailable.
+//
+// CheckTx, FinalizeBlock and Commit calls are limited by configured timeouts. Call exceeding the timeout
+// fails with ErrAppTimeout, while the hung call is left running in the background.
+type appConns struct {
+	service.BaseService
+
+	clientCreator    proxy.ClientCreator
+	metrics          *proxy.Metrics
+	stateMetrics     *state.Metrics
+	retryInterval    time.Duration
+	reconnectTimeout time.Duration
+	handshake        AppHandshake
+
+	checkTxTimeout       time.Duration
+	finalizeBlockTimeout time.Duration
+	commitTimeout        time.Duration
+	checkTxConcurrency   int
+
+	conns     atomic.Pointer[appConnSet]
+	available atomic.Bool
+	// mempoolCbs are set again on mempool connections after reconnecting, indexed like mempoolConn.idx
+	mempoolCbs map[int]abcicli.Callback
+	mtx        sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+var _ proxy.AppConns = (*appConns)(nil)
+
+func newAppConns(clientCreator proxy.ClientCreator, nodeConfig config.NodeConfig, metrics *proxy.Metrics, stateMetrics *state.Metrics) *appConns {
+	a := &appConns{
+		clientCreator:        clientCreator,
+		metrics:              metrics,
+		stateMetrics:         stateMetrics,
+		retryInterval:        nodeConfig.ABCIRetryInterval,
+		reconnectTimeout:     nodeConfig.ABCIReconnectTimeout,
+		checkTxTimeout:       nodeConfig.ABCICheckTxTimeout,
+		finalizeBlockTimeout: nodeConfig.ABCIFinalizeBlockTimeout,
+		commitTimeout:        nodeConfig.ABCICommitTimeout,
+		checkTxConcurrency:   nodeConfig.ABCICheckTxConcurrency,
+		mempoolCbs:           make(map[int]abcicli.Callback),
+	}
+	a.BaseService = *service.NewBaseService(nil, "appConns", a)
+	return a
+}
+
+// SetHandshake sets the handshake performed after reconnecting to ABCI application.
+func (a *appConns) SetHandshake(handshake AppHandshake) {
+	a.handshake = handshake
+}
+
+// Err returns ErrAppUnavailable while node is reconnecting to ABCI application.
+func (a *appConns) Err() error {
+	if !a.available.Load() {
+		return ErrAppUnavailable
+	}
+	return nil
+}
+
+// OnStart is a part of Service interface.
+func (a *appConns) OnStart() error {
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+	conns, err := a.connect()
+	if err != nil {
+		return err
+	}
+	a.conns.Store(conns)
+	a.available.Store(true)
+	go a.monitor(conns)
+	return nil
+}
+
+// OnStop is a part of Service interface.
+func (a *appConns) OnStop() {
+	a.cancel()
+	a.available.Store(false)
+	if conns := a.conns.Load(); conns != nil {
+		a.stopClients(conns.clients)
+	}
+}
+
+// connect creates and starts clients of all connections to ABCI application.
+func (a *appConns) connect() (*appConnSet, error) {
+	conns := &appConnSet{clients: make([]abcicli.Client, len(appConnNames)+max(a.checkTxConcurrency-1, 0))}
+	for i := range conns.clients {
+		name := appConnName(i)
+		client, err := a.clientCreator.NewABCIClient()
+		if err != nil {
+			a.stopClients(conns.clients[:i])
+			return nil, fmt.Errorf("error creating ABCI client (%s connection): %w", name, err)
+		}
+		client.SetLogger(a.Logger.With("module", "abci-client", "connection", name))
+		if err := client.Start(); err != nil {
+			a.stopClients(conns.clients[:i])
+			return nil, fmt.Errorf("error starting ABCI client (%s connection): %w", name, err)
+		}
+		conns.clients[i] = client
+	}
+	conns.consensus = proxy.NewAppConnConsensus(conns.clients[0], a.metrics)
+	conns.mempool = proxy.NewAppConnMempool(conns.clients[1], a.metrics)
+	conns.query = proxy.NewAppConnQuery(conns.clients[2], a.metrics)
+	conns.snapshot = proxy.NewAppConnSnapshot(conns.clients[3], a.metrics)
+	for _, client := range conns.clients[len(appConnNames):] {
+		conns.checkTx = append(conns.checkTx, proxy.NewAppConnMempool(client, a.metrics))
+	}
+
+	a.mtx.Lock()
+	for idx, cb := range a.mempoolCbs {
+		conns.mempoolConn(idx).SetResponseCallback(cb)
+	}
+	a.mtx.Unlock()
+	return conns, nil
+}
+
+func (a *appConns) stopClients(clients []abcicli.Client) {
+	for i, client := range clients {
+		if client == nil || !client.IsRunning() {
+			continue
+		}
+		if err := client.Stop(); err != nil {
+			a.Logger.Error("error while stopping ABCI client", "connection", appConnName(i), "error", err)
+		}
+	}
+}
+
+// mempoolConn returns mempool connection with given index, see mempoolConn.idx.
+func (conns *appConnSet) mempoolConn(idx int) proxy.AppConnMempool {
+	if idx == 0 {
+		return conns.mempool
+	}
+	return conns.checkTx[idx-1]
+}
+
+// waitQuit returns index of the first client which quits, or -1 if ctx is done first.
+func (conns *appConnSet) waitQuit(ctx context.Context) int {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	quit := make(chan int, len(conns.clients))
+	for i, client := range conns.clients {
+		go func() {
+			select {
+			case <-client.Quit():
+				quit <- i
+			case <-ctx.Done():
+			}
+		}()
+	}
+	select {
+	case i := <-quit:
+		return i
+	case <-ctx.Done():
+		return -1
+	}
+}
+
+// monitor waits until any connection is broken and reconnects.
+func (a *appConns) monitor(conns *appConnSet) {
+	for {
+		i := conns.waitQuit(a.ctx)
+		if i < 0 || a.ctx.Err() != nil {
+			return
+		}
+		a.available.Store(false)
+		a.Logger.Error("ABCI connection terminated. Did the application crash? Reconnecting", "connection", appConnName(i), "error", conns.clients[i].Error())
+		a.stopClients(conns.clients)
+
+		var err error
+		if conns, err = a.reconnect(); err != nil {
+			if a.ctx.Err() != nil {
+				return
+			}
+			a.Logger.Error("failed to reconnect to ABCI application, stopping the node", "error", err)
+			if err := cmtos.Kill(); err != nil {
+				a.Logger.Error("Failed to kill this process - please do so manually", "err", err)
+			}
+			return
+		}
+		a.conns.Store(conns)
+		a.available.Store(true)
+		a.Logger.Info("reconnected to ABCI application")
+	}
+}
+
+// reconnect connects to ABCI application and performs handshake, until it succeeds or reconnect timeout passes.
+func (a *appConns) reconnect() (*appConnSet, error) {
+	if a.reconnectTimeout == 0 {
+		return nil, errors.New("reconnecting is disabled")
+	}
+	deadline := time.Now().Add(a.reconnectTimeout)
+	interval := a.retryInterval
+	if interval <= 0 {
+		interval = config.DefaultNodeConfig.ABCIRetryInterval
+	}
+	for {
+		conns, err := a.connect()
+		if err == nil {
+			if a.handshake != nil {
+				err = a.handshake(a.ctx, conns.consensus, conns.query)
+			}
+			if err == nil {
+				return conns, nil
+			}
+			a.stopClients(conns.clients)
+			err = fmt.Errorf("handshake failed: %w", err)
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return nil, err
+		}
+		a.Logger.Info("failed to reconnect to ABCI application, retrying", "error", err, "retryIn", interval)
+		select {
+		case <-a.ctx.Done():
+			return nil, a.ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, maxABCIRetryInterval)
+	}
+}
+
+// current returns connections to ABCI application, or ErrAppUnavailable if node is reconnecting.
+func (a *appConns) current() (*appConnSet, error) {
+	if err := a.Err(); err != nil {
+		return nil, err
+	}
+	return a.conns.Load(), nil
+}
+
+// withTimeout runs call, failing with ErrAppTimeout if it doesn't finish within timeout. ABCI clients don't
+// have to respect context cancellation (e.g. local client), so call is run in a separate goroutine.
+func withTimeout[T any](a *appConns, ctx context.Context, method string, timeout time.Duration, call func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return call(ctx)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	type result struct {
+		res T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer cancel()
+		res, err := call(callCtx)
+		done <- result{res, err}
+	}()
+	select {
+	case r := <-done:
+		return r.res, r.err
+	case <-callCtx.Done():
+		var zero T
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+		a.stateMetrics.ABCITimeouts.With("method", method).Add(1)
+		a.Logger.Error("ABCI application call timed out, is the application hung?", "method", method, "timeout", timeout)
+		return zero, fmt.Errorf("%w: %s didn't finish within %s", ErrAppTimeout, method, timeout)
+	}
+}
+
+// Consensus is a part of proxy.AppConns interface.
+func (a *appConns) Consensus() proxy.AppConnConsensus {
+	return consensusConn{a}
+}
+
+// Mempool is a part of proxy.AppConns interface.
+func (a *appConns) Mempool() proxy.AppConnMempool {
+	return mempoolConn{a, 0}
+}
+
+// CheckTxConns returns additional mempool connections, used together with Mempool connection to check new
+// transactions concurrently. There are ABCICheckTxConcurrency-1 of them.
+func (a *appConns) CheckTxConns() []proxy.AppConnMempool {
+	var conns []proxy.AppConnMempool
+	for i := 1; i < a.checkTxConcurrency; i++ {
+		conns = append(conns, mempoolConn{a, i})
+	}
+	return conns
+}
+
+// Query is a part of proxy.AppConns interface.
+func (a *appConns) Query() proxy.AppConnQuery {
+	return queryConn{a}
+}
+
+// Snapshot is a part of proxy.AppConns interface.
+func (a *appConns) Snapshot() proxy.AppConnSnapshot {
+	return snapshotConn{a}
+}
+
+// consensusConn forwards calls to consensus connection in use.
+type consensusConn struct{ *appConns }
+
+func (c consensusConn) Error() error {
+	conns, err := c.current()
+	if err != nil {
+		return err
+	}
+	return conns.consensus.Error()
+}
+
+func (c consensusConn) InitChain(ctx context.Context, req *abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.consensus.InitChain(ctx, req)
+}
+
+func (c consensusConn) PrepareProposal(ctx context.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.consensus.PrepareProposal(ctx, req)
+}
+
+func (c consensusConn) ProcessProposal(ctx context.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.consensus.ProcessProposal(ctx, req)
+}
+
+func (c consensusConn) ExtendVote(ctx context.Context, req *abci.RequestExtendVote) (*abci.ResponseExtendVote, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.consensus.ExtendVote(ctx, req)
+}
+
+func (c consensusConn) VerifyVoteExtension(ctx context.Context, req *abci.RequestVerifyVoteExtension) (*abci.ResponseVerifyVoteExtension, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.consensus.VerifyVoteExtension(ctx, req)
+}
+
+func (c consensusConn) FinalizeBlock(ctx context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return withTimeout(c.appConns, ctx, "FinalizeBlock", c.finalizeBlockTimeout, func(ctx context.Context) (*abci.ResponseFinalizeBlock, error) {
+		return conns.consensus.FinalizeBlock(ctx, req)
+	})
+}
+
+func (c consensusConn) Commit(ctx context.Context) (*abci.ResponseCommit, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return withTimeout(c.appConns, ctx, "Commit", c.commitTimeout, conns.consensus.Commit)
+}
+
+// mempoolConn forwards calls to mempool connection in use. Index 0 is the main mempool connection, others are
+// additional connections returned by CheckTxConns.
+type mempoolConn struct {
+	*appConns
+	idx int
+}
+
+func (c mempoolConn) SetResponseCallback(cb abcicli.Callback) {
+	c.mtx.Lock()
+	c.mempoolCbs[c.idx] = cb
+	c.mtx.Unlock()
+	if conns := c.conns.Load(); conns != nil {
+		conns.mempoolConn(c.idx).SetResponseCallback(cb)
+	}
+}
+
+func (c mempoolConn) Error() error {
+	conns, err := c.current()
+	if err != nil {
+		return err
+	}
+	return conns.mempoolConn(c.idx).Error()
+}
+
+func (c mempoolConn) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return withTimeout(c.appConns, ctx, "CheckTx", c.checkTxTimeout, func(ctx context.Context) (*abci.ResponseCheckTx, error) {
+		return conns.mempoolConn(c.idx).CheckTx(ctx, req)
+	})
+}
+
+func (c mempoolConn) CheckTxAsync(ctx context.Context, req *abci.RequestCheckTx) (*abcicli.ReqRes, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return withTimeout(c.appConns, ctx, "CheckTx", c.checkTxTimeout, func(ctx context.Context) (*abcicli.ReqRes, error) {
+		return conns.mempoolConn(c.idx).CheckTxAsync(ctx, req)
+	})
+}
+
+func (c mempoolConn) Flush(ctx context.Context) error {
+	conns, err := c.current()
+	if err != nil {
+		return err
+	}
+	return conns.mempoolConn(c.idx).Flush(ctx)
+}
+
+// queryConn forwards calls to query connection in use.
+type queryConn struct{ *appConns }
+
+func (c queryConn) Error() error {
+	conns, err := c.current()
+	if err != nil {
+		return err
+	}
+	return conns.query.Error()
+}
+
+func (c queryConn) Echo(ctx context.Context, msg string) (*abci.ResponseEcho, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.query.Echo(ctx, msg)
+}
+
+func (c queryConn) Info(ctx context.Context, req *abci.RequestInfo) (*abci.ResponseInfo, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.query.Info(ctx, req)
+}
+
+func (c queryConn) Query(ctx context.Context, req *abci.RequestQuery) (*abci.ResponseQuery, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.query.Query(ctx, req)
+}
+
+// snapshotConn forwards calls to snapshot connection in use.
+type snapshotConn struct{ *appConns }
+
+func (c snapshotConn) Error() error {
+	conns, err := c.current()
+	if err != nil {
+		return err
+	}
+	return conns.snapshot.Error()
+}
+
+func (c snapshotConn) ListSnapshots(ctx context.Context, req *abci.RequestListSnapshots) (*abci.ResponseListSnapshots, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.snapshot.ListSnapshots(ctx, req)
+}
+
+func (c snapshotConn) OfferSnapshot(ctx context.Context, req *abci.RequestOfferSnapshot) (*abci.ResponseOfferSnapshot, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.snapshot.OfferSnapshot(ctx, req)
+}
+
+func (c snapshotConn) LoadSnapshotChunk(ctx context.Context, req *abci.RequestLoadSnapshotChunk) (*abci.ResponseLoadSnapshotChunk, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.snapshot.LoadSnapshotChunk(ctx, req)
+}
+
+func (c snapshotConn) ApplySnapshotChunk(ctx context.Context, req *abci.RequestApplySnapshotChunk) (*abci.ResponseApplySnapshotChunk, error) {
+	conns, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	return conns.snapshot.ApplySnapshotChunk(ctx, req)
+}
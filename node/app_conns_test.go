@@ -0,0 +1,111 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	abciserver "github.com/cometbft/cometbft/abci/server"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/state"
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/test/mocks"
+)
+
+func startABCIServer(t *testing.T, addr string) service.Service {
+	server := abciserver.NewSocketServer(addr, abci.NewBaseApplication())
+	server.SetLogger(cmtlog.NewNopLogger())
+	require.NoError(t, server.Start())
+	return server
+}
+
+func TestAppConnsReconnect(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	addr := fmt.Sprintf("unix:///tmp/rollkit_app_%s.sock", cmtrand.Str(6))
+	server := startABCIServer(t, addr)
+
+	nodeConfig := config.DefaultNodeConfig
+	nodeConfig.ABCIRetryInterval = 10 * time.Millisecond
+	nodeConfig.ABCICheckTxConcurrency = 2
+	conns := newAppConns(proxy.NewRemoteClientCreator(addr, "socket", true), nodeConfig, proxy.NopMetrics(), state.NopMetrics())
+	conns.SetLogger(test.NewFileLogger(t))
+	var handshakes atomic.Int32
+	conns.SetHandshake(func(ctx context.Context, consensus proxy.AppConnConsensus, query proxy.AppConnQuery) error {
+		_, err := query.Info(ctx, proxy.RequestInfo)
+		handshakes.Add(1)
+		return err
+	})
+	require.NoError(conns.Start())
+	defer func() {
+		_ = conns.Stop()
+	}()
+	require.NoError(conns.Err())
+	_, err := conns.Query().Info(context.Background(), proxy.RequestInfo)
+	require.NoError(err)
+	checkTxConns := conns.CheckTxConns()
+	require.Len(checkTxConns, 1)
+	_, err = checkTxConns[0].CheckTx(context.Background(), &abci.RequestCheckTx{})
+	require.NoError(err)
+
+	require.NoError(server.Stop())
+	require.Eventually(func() bool { return conns.Err() != nil }, 5*time.Second, 10*time.Millisecond)
+	_, err = conns.Query().Info(context.Background(), proxy.RequestInfo)
+	assert.ErrorIs(err, ErrAppUnavailable)
+	_, err = conns.Mempool().CheckTx(context.Background(), &abci.RequestCheckTx{})
+	assert.ErrorIs(err, ErrAppUnavailable)
+	_, err = checkTxConns[0].CheckTx(context.Background(), &abci.RequestCheckTx{})
+	assert.ErrorIs(err, ErrAppUnavailable)
+
+	server = startABCIServer(t, addr)
+	defer func() {
+		_ = server.Stop()
+	}()
+	require.Eventually(func() bool { return conns.Err() == nil }, 10*time.Second, 10*time.Millisecond)
+	assert.EqualValues(1, handshakes.Load())
+	_, err = conns.Query().Info(context.Background(), proxy.RequestInfo)
+	assert.NoError(err)
+	_, err = checkTxConns[0].CheckTx(context.Background(), &abci.RequestCheckTx{})
+	assert.NoError(err)
+}
+
+func TestAppConnsTimeout(t *testing.T) {
+	require := require.New(t)
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	app := &mocks.Application{}
+	app.On("FinalizeBlock", mock.Anything, mock.Anything).Run(func(mock.Arguments) { <-unblock }).Return(&abci.ResponseFinalizeBlock{}, nil)
+	app.On("CheckTx", mock.Anything, mock.Anything).Return(&abci.ResponseCheckTx{}, nil)
+
+	nodeConfig := config.DefaultNodeConfig
+	nodeConfig.ABCIFinalizeBlockTimeout = 50 * time.Millisecond
+	nodeConfig.ABCICheckTxTimeout = 50 * time.Millisecond
+	conns := newAppConns(proxy.NewLocalClientCreator(app), nodeConfig, proxy.NopMetrics(), state.NopMetrics())
+	conns.SetLogger(test.NewFileLogger(t))
+	require.NoError(conns.Start())
+	defer func() {
+		_ = conns.Stop()
+	}()
+
+	start := time.Now()
+	_, err := conns.Consensus().FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{})
+	require.ErrorIs(err, ErrAppTimeout)
+	require.Less(time.Since(start), 5*time.Second)
+
+	// local client is locked by the hung call
+	_, err = conns.Mempool().CheckTx(context.Background(), &abci.RequestCheckTx{})
+	require.ErrorIs(err, ErrAppTimeout)
+}
@@ -0,0 +1,169 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/store"
+)
+
+// dashboardBlocks is the number of the latest blocks reported by Dashboard.
+const dashboardBlocks = 20
+
+// ResultDashboard aggregates data displayed by lightweight UIs and TUIs monitoring the node, so that they can
+// refresh it with a single request instead of calling status, net_info, num_unconfirmed_txs and others.
+type ResultDashboard struct {
+	ChainID string `json:"chain_id"`
+	NodeID  string `json:"node_id"`
+	// Mode is the mode of the node: "aggregator", "full" or "read-only".
+	Mode string `json:"mode"`
+
+	LatestHeight    uint64    `json:"latest_height"`
+	LatestBlockTime time.Time `json:"latest_block_time"`
+	EarliestHeight  uint64    `json:"earliest_height"`
+
+	// Sync and DA are nil for read-only nodes, as they neither sync nor submit blocks.
+	Sync *DashboardSync `json:"sync"`
+	DA   *DashboardDA   `json:"da"`
+
+	Peers   int              `json:"peers"`
+	Mempool DashboardMempool `json:"mempool"`
+
+	// RecentBlocks are the latest blocks, newest first. AvgBlockInterval is the average interval between them.
+	RecentBlocks     []DashboardBlock `json:"recent_blocks"`
+	AvgBlockInterval float64          `json:"avg_block_interval_ms"`
+
+	// Disk is nil if the store is kept in memory.
+	Disk *store.DiskUsage `json:"disk"`
+}
+
+// DashboardSync describes sync progress of the node.
+type DashboardSync struct {
+	// TargetHeight is the height of the latest header received from peers, or the latest height if it's higher.
+	TargetHeight uint64 `json:"target_height"`
+	// Progress is the fraction of blocks up to the target height synced by the node, between 0 and 1.
+	Progress   float64 `json:"progress"`
+	CatchingUp bool    `json:"catching_up"`
+	// HeadersOnly is set when block execution was stopped because of app hash mismatch.
+	HeadersOnly bool `json:"headers_only"`
+	Halted      bool `json:"halted"`
+}
+
+// DashboardDA describes DA retrieval and submission progress of the node.
+type DashboardDA struct {
+	// Height is the height of the DA block being retrieved.
+	Height uint64 `json:"height"`
+	// IncludedHeight is the height up to which all blocks are included in DA.
+	IncludedHeight uint64 `json:"included_height"`
+	// PendingHeaders is the number of headers waiting for submission to DA.
+	PendingHeaders      uint64 `json:"pending_headers"`
+	LastSubmittedHeight uint64 `json:"last_submitted_height"`
+}
+
+// DashboardMempool describes transactions waiting in the mempool.
+type DashboardMempool struct {
+	Txs   int   `json:"txs"`
+	Bytes int64 `json:"bytes"`
+}
+
+// DashboardBlock describes a recent block. Interval is the time since the previous block, 0 if it's not known.
+type DashboardBlock struct {
+	Height   uint64    `json:"height"`
+	Time     time.Time `json:"time"`
+	Interval float64   `json:"interval_ms"`
+}
+
+// Dashboard returns status, sync and DA progress, peers, mempool size, times of the latest blocks and disk usage
+// of the node at once. Blocks are not required, so it can be polled before the first block is produced.
+func (c *FullClient) Dashboard(ctx context.Context) (*ResultDashboard, error) {
+	genesis := c.node.GetGenesis()
+	id, _, _, err := c.node.p2pClient.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node p2p info: %w", err)
+	}
+	res := &ResultDashboard{
+		ChainID:      genesis.ChainID,
+		NodeID:       string(id),
+		Mode:         c.mode(),
+		LatestHeight: c.node.Store.Height(),
+		Peers:        len(c.node.p2pClient.PeerIDs()),
+		Mempool: DashboardMempool{
+			Txs:   c.node.Mempool.Size(),
+			Bytes: c.node.Mempool.SizeBytes(),
+		},
+	}
+
+	// node started from trusted height doesn't store blocks below it
+	res.EarliestHeight = c.earliestHeight()
+	if earliest, err := block.LoadEarliestHeight(ctx, c.node.Store); err != nil {
+		return nil, fmt.Errorf("failed to load earliest height: %w", err)
+	} else if earliest > res.EarliestHeight {
+		res.EarliestHeight = earliest
+	}
+	if err := c.loadRecentBlocks(ctx, res); err != nil {
+		return nil, err
+	}
+
+	if !c.node.readOnly {
+		state, err := c.node.blockManager.DumpState(ctx)
+		if err != nil {
+			return nil, err
+		}
+		target := max(state.Sync.HeaderStoreHeight, res.LatestHeight)
+		res.Sync = &DashboardSync{
+			TargetHeight: target,
+			Progress:     1,
+			CatchingUp:   res.LatestHeight < target,
+			HeadersOnly:  state.HeadersOnly,
+			Halted:       state.Halted,
+		}
+		// progress is computed from the initial height, as there are no blocks below it
+		if base := c.earliestHeight() - 1; target > base {
+			res.Sync.Progress = float64(max(res.LatestHeight, base)-base) / float64(target-base)
+		}
+		res.DA = &DashboardDA{
+			Height:              state.DAHeight,
+			IncludedHeight:      state.DAIncludedHeight,
+			PendingHeaders:      state.DASubmission.PendingHeaders,
+			LastSubmittedHeight: state.DASubmission.LastSubmittedHeight,
+		}
+	}
+
+	if c.node.maintainer != nil {
+		if res.Disk, err = c.node.maintainer.DiskUsage(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// loadRecentBlocks sets the latest blocks of the dashboard and the average interval between them.
+func (c *FullClient) loadRecentBlocks(ctx context.Context, res *ResultDashboard) error {
+	// one more block is loaded to compute the interval of the oldest reported block
+	var times []time.Time
+	for h := res.LatestHeight; h >= res.EarliestHeight && h > 0 && len(times) <= dashboardBlocks; h-- {
+		header, err := c.node.Store.GetHeader(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		times = append(times, header.Time())
+	}
+
+	res.RecentBlocks = make([]DashboardBlock, 0, min(len(times), dashboardBlocks))
+	for i := 0; i < len(times) && i < dashboardBlocks; i++ {
+		b := DashboardBlock{Height: res.LatestHeight - uint64(i), Time: times[i]} //nolint:gosec
+		if i+1 < len(times) {
+			b.Interval = ms(times[i].Sub(times[i+1]))
+		}
+		res.RecentBlocks = append(res.RecentBlocks, b)
+	}
+	if len(times) > 0 {
+		res.LatestBlockTime = times[0]
+	}
+	if len(times) > 1 {
+		res.AvgBlockInterval = ms(times[0].Sub(times[len(times)-1])) / float64(len(times)-1)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	ds "github.com/ipfs/go-datastore"
 	ktds "github.com/ipfs/go-datastore/keytransform"
@@ -15,7 +16,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	llcfg "github.com/cometbft/cometbft/config"
@@ -25,10 +25,14 @@ import (
 	proxy "github.com/cometbft/cometbft/proxy"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+
+	goheaderp2p "github.com/celestiaorg/go-header/p2p"
 
 	proxyda "github.com/rollkit/go-da/proxy"
 
 	seqGRPC "github.com/rollkit/go-sequencing/proxy/grpc"
+	"github.com/rollkit/rollkit/alert"
 	"github.com/rollkit/rollkit/block"
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
@@ -37,9 +41,13 @@ import (
 	"github.com/rollkit/rollkit/state"
 	"github.com/rollkit/rollkit/state/indexer"
 	blockidxkv "github.com/rollkit/rollkit/state/indexer/block/kv"
+	blockidxnull "github.com/rollkit/rollkit/state/indexer/block/null"
 	"github.com/rollkit/rollkit/state/txindex"
 	"github.com/rollkit/rollkit/state/txindex/kv"
+	"github.com/rollkit/rollkit/state/txindex/null"
 	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/store/replication"
+	"github.com/rollkit/rollkit/telemetry"
 	"github.com/rollkit/rollkit/types"
 )
 
@@ -63,12 +71,14 @@ type FullNode struct {
 	service.BaseService
 
 	genesis *cmtypes.GenesisDoc
+	// genesisHash is the canonical hash of genesis, see types.GenesisHash
+	genesisHash types.Hash
 	// cache of chunked genesis data.
 	genChunks []string
 
 	nodeConfig config.NodeConfig
 
-	proxyApp     proxy.AppConns
+	proxyApp     *appConns
 	eventBus     *cmtypes.EventBus
 	dalc         *da.DAClient
 	p2pClient    *p2p.Client
@@ -81,6 +91,35 @@ type FullNode struct {
 	blockManager *block.Manager
 	client       rpcclient.Client
 
+	// minGasPrice is reported by estimate_gas, nil if not configured
+	minGasPrice *gasPrice
+	// creates throwaway app connections for tracing transactions, nil if tracing is disabled
+	traceClientCreator proxy.ClientCreator
+	// maintainer is nil in in-memory mode
+	maintainer *store.Maintainer
+	// alerts is nil if alert webhook is not configured
+	alerts *alert.Notifier
+	// indexerKV holds transaction and block indexes, its usage is reported with store statistics
+	indexerKV    ds.Datastore
+	storeMetrics *store.Metrics
+	// memoryGovernor sheds load when heap usage exceeds configured limits
+	memoryGovernor *memoryGovernor
+	// eventPruner removes indexed events older than configured retention, nil in read-only mode
+	eventPruner *txindex.Pruner
+	// dataPruner removes data of blocks older than configured retention, nil in read-only mode
+	dataPruner *store.DataPruner
+	// pruner removes blocks older than configured retention, nil in read-only mode
+	pruner *store.Pruner
+	// readOnly is set if node only serves RPC from read-only store (see newReadOnlyNode)
+	readOnly bool
+	// storeHeaderServer serves headers of stored blocks over go-header exchange protocol, nil if disabled
+	storeHeaderServer *goheaderp2p.ExchangeServer[*types.SignedHeader]
+	// replicationSrv streams store entries to follower nodes, nil if disabled
+	replicationSrv *replication.Server
+	// follower replicates store from primary node, nil if node is not a follower
+	follower     *replication.Follower
+	followerConn *grpc.ClientConn
+
 	// Preserves cometBFT compatibility
 	TxIndexer      txindex.TxIndexer
 	BlockIndexer   indexer.BlockIndexer
@@ -93,6 +132,7 @@ type FullNode struct {
 	cancel        context.CancelFunc
 	threadManager *types.ThreadManager
 	seqClient     *seqGRPC.Client
+	seqDialOpts   []grpc.DialOption
 	mempoolReaper *mempool.CListMempoolReaper
 }
 
@@ -107,6 +147,39 @@ func newFullNode(
 	metricsProvider MetricsProvider,
 	logger log.Logger,
 ) (fn *FullNode, err error) {
+	if nodeConfig.ReplicateFrom != "" {
+		return nil, errors.New("replicating store from primary node requires read-only mode")
+	}
+	if err := nodeConfig.ApplySyncProfile(); err != nil {
+		return nil, err
+	}
+	if nodeConfig.TrustedHeight > 0 {
+		if nodeConfig.Aggregator {
+			return nil, errors.New("aggregator can't start from trusted height")
+		}
+		if nodeConfig.TrustedHash == "" {
+			return nil, errors.New("trusted height requires trusted hash")
+		}
+	}
+	if nodeConfig.KeepRecent > 0 && nodeConfig.Backfill {
+		return nil, errors.New("backfill can't be used with pruning of blocks")
+	}
+	minGasPrice, err := parseGasPrice(nodeConfig.RPCMinGasPrice)
+	if err != nil {
+		return nil, err
+	}
+	seqDialOpts, err := sequencerDialOptions(nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	syncInterval, err := storeSyncInterval(nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	if nodeConfig.Aggregator && nodeConfig.DBSyncPolicy != "" && nodeConfig.DBSyncPolicy != config.DBSyncPolicyBlock {
+		logger.Info("WARNING: blocks produced since the last database sync may be lost on crash and produced again differently", "DBSyncPolicy", nodeConfig.DBSyncPolicy)
+	}
+
 	// Create context with cancel so that all services using the context can
 	// catch the cancel signal when the node shutdowns
 	ctx, cancel := context.WithCancel(ctx)
@@ -117,9 +190,14 @@ func newFullNode(
 		}
 	}()
 
-	seqMetrics, p2pMetrics, memplMetrics, smMetrics, abciMetrics := metricsProvider(genesis.ChainID)
+	genesisHash, err := completeGenesis(genesis)
+	if err != nil {
+		return nil, err
+	}
 
-	proxyApp, err := initProxyApp(clientCreator, logger, abciMetrics)
+	seqMetrics, p2pMetrics, memplMetrics, smMetrics, abciMetrics, storeMetrics := metricsProvider(genesis.ChainID)
+
+	proxyApp, err := initProxyApp(clientCreator, nodeConfig, logger, abciMetrics, smMetrics)
 	if err != nil {
 		return nil, err
 	}
@@ -133,8 +211,9 @@ func newFullNode(
 	if err != nil {
 		return nil, err
 	}
+	maintainer := initStoreMaintainer(baseKV, nodeConfig, storeMetrics, logger)
 
-	dalc, err := initDALC(nodeConfig, logger)
+	dalc, err := initDALC(nodeConfig, genesis, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +223,13 @@ func newFullNode(
 		return nil, err
 	}
 
-	mainKV := newPrefixKV(baseKV, mainPrefix)
+	mainKV, err := initMainKV(ctx, baseKV, nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Migrate(ctx, mainKV, logger.With("module", "store")); err != nil {
+		return nil, err
+	}
 	headerSyncService, err := initHeaderSyncService(mainKV, nodeConfig, genesis, p2pClient, logger)
 	if err != nil {
 		return nil, err
@@ -160,13 +245,57 @@ func newFullNode(
 	seqClient := seqGRPC.NewClient()
 	mempoolReaper := initMempoolReaper(mempool, []byte(genesis.ChainID), seqClient, logger.With("module", "reaper"))
 
-	store := store.New(mainKV)
+	store := store.New(mainKV, store.WithSyncInterval(syncInterval))
+	if err := checkGenesisHash(ctx, store, genesisHash, true); err != nil {
+		return nil, err
+	}
 	blockManager, err := initBlockManager(signingKey, nodeConfig, genesis, store, mempool, mempoolReaper, seqClient, proxyApp, dalc, eventBus, logger, headerSyncService, dataSyncService, seqMetrics, smMetrics)
 	if err != nil {
 		return nil, err
 	}
+	// blocks are neither produced nor applied while disk space is low or ABCI app is unavailable
+	blockManager.SetSafeModeCheck(func() error {
+		if maintainer != nil {
+			if err := maintainer.SafeModeErr(); err != nil {
+				return err
+			}
+		}
+		return proxyApp.Err()
+	})
+	proxyApp.SetHandshake(blockManager.Handshake)
+	alerts, err := initAlerts(nodeConfig, genesis, logger)
+	if err != nil {
+		return nil, err
+	}
+	if alerts != nil {
+		blockManager.SetAlertHandler(alerts.Notify)
+		if maintainer != nil {
+			maintainer.SetAlertHandler(alerts.Notify)
+		}
+	}
+	blockManager.SetBackfillSource(func(ctx context.Context, height uint64) (*types.SignedHeader, *types.Data, error) {
+		header, err := headerSyncService.FetchByHeight(ctx, height)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := dataSyncService.FetchByHeight(ctx, height)
+		if err != nil {
+			return nil, nil, err
+		}
+		return header, data, nil
+	})
+	if nodeConfig.Aggregator && (nodeConfig.DASigner.MinBalance > 0 || nodeConfig.DASigner.ThrottleBalance > 0) {
+		balance, err := da.NewCelestiaBalance(nodeConfig.DAAddress, nodeConfig.DAAuthToken, nodeConfig.DASigner.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize DA balance monitoring: %w", err)
+		}
+		blockManager.SetDABalance(balance)
+	}
 
-	indexerKV := newPrefixKV(baseKV, indexerPrefix)
+	indexerKV, err := initIndexerKV(ctx, baseKV, nodeConfig)
+	if err != nil {
+		return nil, err
+	}
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(ctx, nodeConfig, indexerKV, eventBus, logger)
 	if err != nil {
 		return nil, err
@@ -176,15 +305,21 @@ func newFullNode(
 		proxyApp:       proxyApp,
 		eventBus:       eventBus,
 		genesis:        genesis,
+		genesisHash:    genesisHash,
 		nodeConfig:     nodeConfig,
 		p2pClient:      p2pClient,
 		blockManager:   blockManager,
 		dalc:           dalc,
 		Mempool:        mempool,
 		seqClient:      seqClient,
+		seqDialOpts:    seqDialOpts,
 		mempoolReaper:  mempoolReaper,
 		mempoolIDs:     newMempoolIDs(),
 		Store:          store,
+		maintainer:     maintainer,
+		alerts:         alerts,
+		indexerKV:      indexerKV,
+		storeMetrics:   storeMetrics,
 		TxIndexer:      txIndexer,
 		IndexerService: indexerService,
 		BlockIndexer:   blockIndexer,
@@ -193,17 +328,31 @@ func newFullNode(
 		ctx:            ctx,
 		cancel:         cancel,
 		threadManager:  types.NewThreadManager(),
+		minGasPrice:    minGasPrice,
+	}
+	if nodeConfig.TraceProxyApp != "" {
+		node.traceClientCreator = newTraceClientCreator(nodeConfig.TraceProxyApp)
+	}
+	if nodeConfig.ReplicationAddress != "" {
+		node.replicationSrv = replication.NewServer(store, nodeConfig.ReplicationAddress, logger.With("module", "replication"))
 	}
 
+	node.memoryGovernor = initMemoryGovernor(nodeConfig, node.shedLoad, logger)
+	node.eventPruner = txindex.NewPruner(store, txIndexer, blockIndexer, nodeConfig.EventRetentionBlocks, nodeConfig.EventPruneInterval, logger.With("module", "pruner"))
+	node.dataPruner = initDataPruner(store, nodeConfig, logger)
+	node.pruner = initPruner(store, nodeConfig, blockManager, logger)
+
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 	node.p2pClient.SetTxValidator(node.newTxValidator(p2pMetrics))
+	node.p2pClient.SetGossipValidationWorkers(nodeConfig.Concurrency.GossipValidationWorkers)
+	node.p2pClient.SetGenesisHash(genesisHash)
 	node.client = NewFullClient(node)
 
 	return node, nil
 }
 
-func initProxyApp(clientCreator proxy.ClientCreator, logger log.Logger, metrics *proxy.Metrics) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator, metrics)
+func initProxyApp(clientCreator proxy.ClientCreator, nodeConfig config.NodeConfig, logger log.Logger, metrics *proxy.Metrics, stateMetrics *state.Metrics) (*appConns, error) {
+	proxyApp := newAppConns(clientCreator, nodeConfig, metrics, stateMetrics)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error while starting proxy app connections: %w", err)
@@ -226,10 +375,84 @@ func initBaseKV(nodeConfig config.NodeConfig, logger log.Logger) (ds.TxnDatastor
 		logger.Info("WARNING: working in in-memory mode")
 		return store.NewDefaultInMemoryKVStore()
 	}
-	return store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, "rollkit")
+	if isBadgerBackend(nodeConfig) && nodeConfig.DBGCInterval > 0 && (nodeConfig.DBGCDiscardRatio <= 0 || nodeConfig.DBGCDiscardRatio >= 1) {
+		return nil, fmt.Errorf("invalid database GC discard ratio %v, must be between 0 and 1", nodeConfig.DBGCDiscardRatio)
+	}
+	if nodeConfig.DBBackend == config.DBBackendMemory {
+		logger.Info("WARNING: working in in-memory mode")
+	}
+	return store.NewBackendKVStore(nodeConfig.DBBackend, nodeConfig.RootDir, nodeConfig.DBPath, "rollkit", nodeConfig.DBGCDiscardRatio)
+}
+
+// isBadgerBackend returns true if blocks are stored in BadgerDB, the default backend.
+func isBadgerBackend(nodeConfig config.NodeConfig) bool {
+	return nodeConfig.DBBackend == "" || nodeConfig.DBBackend == config.DBBackendBadger
+}
+
+// isInMemoryStore returns true if the key-value store doesn't access disk.
+func isInMemoryStore(nodeConfig config.NodeConfig) bool {
+	return nodeConfig.RootDir == "" && nodeConfig.DBPath == "" || nodeConfig.DBBackend == config.DBBackendMemory
 }
 
-func initDALC(nodeConfig config.NodeConfig, logger log.Logger) (*da.DAClient, error) {
+// storeSyncInterval returns the number of blocks between syncs of the store to disk defined by database sync
+// policy, see store.WithSyncInterval.
+func storeSyncInterval(nodeConfig config.NodeConfig) (uint64, error) {
+	if isInMemoryStore(nodeConfig) { // in-memory store can't be synced
+		return 0, nil
+	}
+	switch nodeConfig.DBSyncPolicy {
+	case "", config.DBSyncPolicyBlock:
+		return 1, nil
+	case config.DBSyncPolicyInterval:
+		if nodeConfig.DBSyncBlocks == 0 {
+			return 0, errors.New("database sync blocks must be positive with interval sync policy")
+		}
+		return nodeConfig.DBSyncBlocks, nil
+	case config.DBSyncPolicyAsync:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("unknown database sync policy %q", nodeConfig.DBSyncPolicy)
+	}
+}
+
+// initStoreMaintainer initializes garbage collection and disk space watchdog of the on-disk key-value store.
+func initStoreMaintainer(baseKV ds.TxnDatastore, nodeConfig config.NodeConfig, metrics *store.Metrics, logger log.Logger) *store.Maintainer {
+	if isInMemoryStore(nodeConfig) {
+		return nil
+	}
+	dir := store.Path(nodeConfig.RootDir, nodeConfig.DBPath, "rollkit")
+	minFreeDisk := nodeConfig.DBMinFreeDiskMB << 20
+	return store.NewMaintainer(baseKV, dir, nodeConfig.DBGCInterval, minFreeDisk, metrics, logger.With("module", "store"))
+}
+
+// initAlerts initializes webhook notifications of critical events, if configured.
+func initAlerts(nodeConfig config.NodeConfig, genesis *cmtypes.GenesisDoc, logger log.Logger) (*alert.Notifier, error) {
+	if !nodeConfig.Alerts.Enabled() {
+		return nil, nil
+	}
+	notifier, err := alert.NewNotifier(nodeConfig.Alerts, genesis.ChainID, logger.With("module", "alerts"))
+	if err != nil {
+		return nil, fmt.Errorf("error while initializing alerts: %w", err)
+	}
+	return notifier, nil
+}
+
+// initDataPruner initializes pruning of block data, keeping data needed for transaction inclusion proofs.
+func initDataPruner(s store.Store, nodeConfig config.NodeConfig, logger log.Logger) *store.DataPruner {
+	return store.NewDataPruner(s, nodeConfig.BlockDataRetentionBlocks, nodeConfig.BlockDataPruneInterval, logger.With("module", "pruner"))
+}
+
+// initPruner initializes pruning of blocks, keeping blocks which are not DA included yet.
+func initPruner(s store.Store, nodeConfig config.NodeConfig, blockManager *block.Manager, logger log.Logger) *store.Pruner {
+	return store.NewPruner(s, nodeConfig.KeepRecent, nodeConfig.PruneInterval, blockManager.GetDAIncludedHeight, logger.With("module", "pruner"))
+}
+
+// initMemoryGovernor initializes load shedding under memory pressure.
+func initMemoryGovernor(nodeConfig config.NodeConfig, onChange func(memoryPressure), logger log.Logger) *memoryGovernor {
+	return newMemoryGovernor(nodeConfig.MemorySoftLimitMB<<20, nodeConfig.MemoryHardLimitMB<<20, onChange, logger.With("module", "memory"))
+}
+
+func initDALC(nodeConfig config.NodeConfig, genesis *cmtypes.GenesisDoc, logger log.Logger) (*da.DAClient, error) {
 	namespace := make([]byte, len(nodeConfig.DANamespace)/2)
 	_, err := hex.Decode(namespace, []byte(nodeConfig.DANamespace))
 	if err != nil {
@@ -245,16 +468,40 @@ func initDALC(nodeConfig config.NodeConfig, logger log.Logger) (*da.DAClient, er
 		return nil, fmt.Errorf("error while establishing connection to DA layer: %w", err)
 	}
 
+	submitOptions := nodeConfig.DASubmitOptions
+	if nodeConfig.DASigner.Enabled() {
+		// submissions are paid and signed by the DA account, not by the key signing blocks
+		if submitOptions, err = nodeConfig.DASigner.SubmitOptions(submitOptions); err != nil {
+			return nil, err
+		}
+	}
 	var submitOpts []byte
-	if nodeConfig.DASubmitOptions != "" {
-		submitOpts = []byte(nodeConfig.DASubmitOptions)
+	if submitOptions != "" {
+		submitOpts = []byte(submitOptions)
+	}
+	dalc := da.NewDAClient(client, nodeConfig.DAGasPrice, nodeConfig.DAGasMultiplier,
+		namespace, submitOpts, logger.With("module", "da_client"))
+	// retrieval is shared by block retriever, forced inclusion scanner, DA verifier and message reader
+	dalc.Watcher = da.NewWatcher(client)
+	if nodeConfig.DAForcedInclusionNamespace != "" {
+		dalc.ForcedInclusionNamespace, err = hex.DecodeString(nodeConfig.DAForcedInclusionNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding forced inclusion namespace: %w", err)
+		}
+	}
+	// namespace may be shared, so spam blobs are discarded before unmarshaling
+	dalc.HeaderFilter = &da.HeaderFilter{
+		MaxBlobSize:  types.GetDecodeLimits().MaxBytes,
+		BlockVersion: version.BlockProtocol,
 	}
-	return da.NewDAClient(client, nodeConfig.DAGasPrice, nodeConfig.DAGasMultiplier,
-		namespace, submitOpts, logger.With("module", "da_client")), nil
+	if len(genesis.Validators) == 1 {
+		dalc.HeaderFilter.ProposerAddress = genesis.Validators[0].Address
+	}
+	return dalc, nil
 }
 
-func initMempool(proxyApp proxy.AppConns, memplMetrics *mempool.Metrics) *mempool.CListMempool {
-	mempool := mempool.NewCListMempool(llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0, mempool.WithMetrics(memplMetrics))
+func initMempool(proxyApp *appConns, memplMetrics *mempool.Metrics) *mempool.CListMempool {
+	mempool := mempool.NewCListMempool(llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0, mempool.WithMetrics(memplMetrics), mempool.WithCheckTxConns(proxyApp.CheckTxConns()...))
 	mempool.EnableTxsAvailable()
 	return mempool
 }
@@ -348,11 +595,60 @@ func (n *FullNode) dataPublishLoop(ctx context.Context) {
 	}
 }
 
+// derivedBlockLoop appends blocks derived by block manager in DA-only mode to header and data stores, without
+// broadcasting them (derived blocks are not signed). This allows sync services to accept sequencer blocks built
+// on top of derived blocks, when sequencer is back.
+func (n *FullNode) derivedBlockLoop(ctx context.Context) {
+	for {
+		select {
+		case signedHeader := <-n.blockManager.HeaderCh:
+			if err := n.hSyncService.Store().Append(ctx, signedHeader); err != nil {
+				n.Logger.Error("failed to append derived header to header store", "height", signedHeader.Height(), "error", err)
+			}
+		case data := <-n.blockManager.DataCh:
+			if err := n.dSyncService.Store().Append(ctx, data); err != nil {
+				n.Logger.Error("failed to append derived data to data store", "height", data.Height(), "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // GetClient returns the RPC client for the full node.
 func (n *FullNode) GetClient() rpcclient.Client {
 	return n.client
 }
 
+// RegisterGossipTopic registers application-specific gossip topic (e.g. for preconfirmations or oracle data).
+// Topics have to be registered before the node is started.
+func (n *FullNode) RegisterGossipTopic(topic p2p.TopicConfig) error {
+	return n.p2pClient.RegisterTopic(topic)
+}
+
+// PublishGossip sends data to the gossip topic registered with RegisterGossipTopic.
+func (n *FullNode) PublishGossip(ctx context.Context, topic string, data []byte) error {
+	return n.p2pClient.Publish(ctx, topic, data)
+}
+
+// SetPreviousP2PKey sets P2P key used before key rotation, announced during grace period.
+// It has to be called before the node is started.
+func (n *FullNode) SetPreviousP2PKey(key *p2p.RotatedKey) error {
+	return n.p2pClient.SetPreviousKey(key)
+}
+
+// SetSystemTxSource sets plugin building and verifying system transactions of given kind, defined by
+// --rollkit.system_txs. It has to be called before the node is started.
+func (n *FullNode) SetSystemTxSource(kind string, source state.SystemTxSource) error {
+	return n.blockManager.SetSystemTxSource(kind, source)
+}
+
+// SetDataSectionSource sets plugin building and verifying application-defined sections of block data of given
+// type. All nodes of the chain must use the same sources. It has to be called before the node is started.
+func (n *FullNode) SetDataSectionSource(t types.DataSectionType, source state.DataSectionSource) error {
+	return n.blockManager.SetDataSectionSource(t, source)
+}
+
 // Cancel calls the underlying context's cancel function.
 func (n *FullNode) Cancel() {
 	n.cancel()
@@ -386,12 +682,55 @@ func (n *FullNode) OnStart() error {
 	if n.nodeConfig.Instrumentation != nil && n.nodeConfig.Instrumentation.IsPrometheusEnabled() {
 		n.prometheusSrv = n.startPrometheusServer()
 	}
+	if n.alerts != nil {
+		n.threadManager.Go(func() { n.alerts.Run(n.ctx) })
+	}
+	if n.maintainer != nil {
+		n.threadManager.Go(func() { n.maintainer.Run(n.ctx) })
+	}
+	n.threadManager.Go(func() { n.memoryGovernor.Run(n.ctx) })
+	if n.nodeConfig.DBStatsInterval > 0 {
+		n.threadManager.Go(func() { n.storeStatsLoop(n.ctx) })
+	}
+	if n.eventPruner != nil {
+		n.threadManager.Go(func() { n.eventPruner.Run(n.ctx) })
+	}
+	if n.dataPruner != nil {
+		n.threadManager.Go(func() { n.dataPruner.Run(n.ctx) })
+	}
+	if n.pruner != nil {
+		n.threadManager.Go(func() { n.pruner.Run(n.ctx) })
+	}
 	n.Logger.Info("starting P2P client")
 	err := n.p2pClient.Start(n.ctx)
 	if err != nil {
 		return fmt.Errorf("error while starting P2P client: %w", err)
 	}
 
+	if n.nodeConfig.TelemetryEndpoint != "" {
+		if n.nodeConfig.TelemetryInterval <= 0 {
+			return errors.New("telemetry interval must be positive")
+		}
+		reporter := telemetry.NewReporter(n.nodeConfig.TelemetryEndpoint, n.nodeConfig.TelemetryInterval, n.telemetrySource(time.Now()), n.Logger.With("module", "telemetry"))
+		n.threadManager.Go(func() { reporter.Run(n.ctx) })
+	}
+
+	if n.replicationSrv != nil {
+		if err = n.replicationSrv.Start(); err != nil {
+			return fmt.Errorf("error while starting replication server: %w", err)
+		}
+	}
+
+	if n.readOnly {
+		if n.follower != nil {
+			if err = n.follower.Start(); err != nil {
+				return fmt.Errorf("error while starting replication follower: %w", err)
+			}
+		}
+		n.Logger.Info("working in read-only mode", "height", n.Store.Height(), "replicating", n.follower != nil)
+		return nil
+	}
+
 	if err = n.hSyncService.Start(n.ctx); err != nil {
 		return fmt.Errorf("error while starting header sync service: %w", err)
 	}
@@ -400,13 +739,37 @@ func (n *FullNode) OnStart() error {
 		return fmt.Errorf("error while starting data sync service: %w", err)
 	}
 
-	if err := n.seqClient.Start(
-		n.nodeConfig.SequencerAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	); err != nil {
+	if n.nodeConfig.StoreHeaderExchange {
+		if n.storeHeaderServer, err = block.NewStoreHeaderServer(n.p2pClient, n.Store); err != nil {
+			return fmt.Errorf("error while creating store header server: %w", err)
+		}
+		if err = n.storeHeaderServer.Start(n.ctx); err != nil {
+			return fmt.Errorf("error while starting store header server: %w", err)
+		}
+	}
+
+	if n.nodeConfig.TrustedHeight > 0 {
+		trustedHash, err := hex.DecodeString(n.nodeConfig.TrustedHash)
+		if err != nil {
+			return fmt.Errorf("failed to parse trusted hash: %w", err)
+		}
+		if err := n.blockManager.BootstrapFromTrustedHeader(n.ctx, n.proxyApp.Query(), trustedHash); err != nil {
+			return fmt.Errorf("error while bootstrapping from trusted height: %w", err)
+		}
+	}
+
+	if err := n.seqClient.Start(n.nodeConfig.SequencerAddress, n.seqDialOpts...); err != nil {
 		return err
 	}
 
+	if n.nodeConfig.PersistQueues {
+		if err := n.recoverQueues(n.ctx); err != nil {
+			return fmt.Errorf("error while recovering queues: %w", err)
+		}
+	}
+
+	n.threadManager.Go(func() { n.blockManager.DAVerifyLoop(n.ctx) })
+
 	if n.nodeConfig.Aggregator {
 		n.Logger.Info("working in aggregator mode", "block time", n.nodeConfig.BlockTime)
 		// reaper is started only in aggregator mode
@@ -416,6 +779,7 @@ func (n *FullNode) OnStart() error {
 		n.threadManager.Go(func() { n.blockManager.BatchRetrieveLoop(n.ctx) })
 		n.threadManager.Go(func() { n.blockManager.AggregationLoop(n.ctx) })
 		n.threadManager.Go(func() { n.blockManager.HeaderSubmissionLoop(n.ctx) })
+		n.threadManager.Go(func() { n.blockManager.DABalanceLoop(n.ctx) })
 		n.threadManager.Go(func() { n.headerPublishLoop(n.ctx) })
 		n.threadManager.Go(func() { n.dataPublishLoop(n.ctx) })
 		return nil
@@ -424,6 +788,8 @@ func (n *FullNode) OnStart() error {
 	n.threadManager.Go(func() { n.blockManager.HeaderStoreRetrieveLoop(n.ctx) })
 	n.threadManager.Go(func() { n.blockManager.DataStoreRetrieveLoop(n.ctx) })
 	n.threadManager.Go(func() { n.blockManager.SyncLoop(n.ctx, n.cancel) })
+	n.threadManager.Go(func() { n.blockManager.BackfillLoop(n.ctx) })
+	n.threadManager.Go(func() { n.derivedBlockLoop(n.ctx) })
 	return nil
 }
 
@@ -449,13 +815,25 @@ func (n *FullNode) GetGenesisChunks() ([]string, error) {
 func (n *FullNode) OnStop() {
 	n.Logger.Info("halting full node...")
 	n.Logger.Info("shutting down full node sub services...")
-	err := errors.Join(
-		n.p2pClient.Close(),
-		n.hSyncService.Stop(n.ctx),
-		n.dSyncService.Stop(n.ctx),
-		n.seqClient.Stop(),
-		n.IndexerService.Stop(),
-	)
+	err := n.p2pClient.Close()
+	if !n.readOnly {
+		err = errors.Join(
+			err,
+			n.hSyncService.Stop(n.ctx),
+			n.dSyncService.Stop(n.ctx),
+			n.seqClient.Stop(),
+			n.IndexerService.Stop(),
+		)
+	}
+	if n.storeHeaderServer != nil {
+		err = errors.Join(err, n.storeHeaderServer.Stop(n.ctx))
+	}
+	if n.follower != nil {
+		err = errors.Join(err, n.follower.Stop(), n.followerConn.Close())
+	}
+	if n.replicationSrv != nil {
+		err = errors.Join(err, n.replicationSrv.Stop())
+	}
 	if n.prometheusSrv != nil {
 		err = errors.Join(err, n.prometheusSrv.Shutdown(n.ctx))
 	}
@@ -464,7 +842,10 @@ func (n *FullNode) OnStop() {
 	}
 	n.cancel()
 	n.threadManager.Wait()
-	err = errors.Join(err, n.Store.Close())
+	if n.nodeConfig.PersistQueues && !n.readOnly {
+		err = errors.Join(err, n.persistQueues(context.Background()))
+	}
+	err = errors.Join(err, n.proxyApp.Stop(), n.Store.Close())
 	n.Logger.Error("errors while stopping node:", "errors", err)
 }
 
@@ -533,8 +914,44 @@ func (n *FullNode) newTxValidator(metrics *p2p.Metrics) p2p.GossipValidator {
 	}
 }
 
+// initMainKV returns datastore of blocks and state, encrypted if database encryption key is configured.
+func initMainKV(ctx context.Context, baseKV ds.TxnDatastore, nodeConfig config.NodeConfig) (ds.TxnDatastore, error) {
+	return encryptKV(ctx, newPrefixKV(baseKV, mainPrefix), nodeConfig)
+}
+
+// MainKV returns datastore of blocks and state within the base key-value store of the node, encrypted if database
+// encryption key is configured. It's used by commands working with the store of a stopped node.
+func MainKV(ctx context.Context, baseKV ds.TxnDatastore, nodeConfig config.NodeConfig) (ds.TxnDatastore, error) {
+	return initMainKV(ctx, baseKV, nodeConfig)
+}
+
+// initIndexerKV returns datastore of transaction and block indexes, encrypted if database encryption key is
+// configured, as indexed transaction results contain raw transactions.
+func initIndexerKV(ctx context.Context, baseKV ds.TxnDatastore, nodeConfig config.NodeConfig) (ds.TxnDatastore, error) {
+	return encryptKV(ctx, newPrefixKV(baseKV, indexerPrefix), nodeConfig)
+}
+
+// encryptKV wraps kv with encrypted datastore if database encryption key is configured, and checks that kv was
+// written with the same encryption mode. Store opened read-only is only checked.
+func encryptKV(ctx context.Context, kv ds.TxnDatastore, nodeConfig config.NodeConfig) (ds.TxnDatastore, error) {
+	key, err := store.LoadEncryptionKey(nodeConfig.DBEncryptionKey, nodeConfig.DBEncryptionKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		if kv, err = store.NewEncryptedDatastore(kv, key); err != nil {
+			return nil, err
+		}
+	}
+	writable := !nodeConfig.ReadOnly || nodeConfig.ReplicateFrom != ""
+	if err := store.CheckEncryptionMode(ctx, kv, key != nil, writable); err != nil {
+		return nil, err
+	}
+	return kv, nil
+}
+
 func newPrefixKV(kvStore ds.Datastore, prefix string) ds.TxnDatastore {
-	return (ktds.Wrap(kvStore, ktds.PrefixTransform{Prefix: ds.NewKey(prefix)}).Children()[0]).(ds.TxnDatastore)
+	return store.NewTxnDatastore(ktds.Wrap(kvStore, ktds.PrefixTransform{Prefix: ds.NewKey(prefix)}).Children()[0])
 }
 
 func createAndStartIndexerService(
@@ -549,8 +966,14 @@ func createAndStartIndexerService(
 		blockIndexer indexer.BlockIndexer
 	)
 
-	txIndexer = kv.NewTxIndex(ctx, kvStore)
-	blockIndexer = blockidxkv.New(ctx, newPrefixKV(kvStore, "block_events"))
+	if conf.SyncProfile == config.SyncProfileAppOnly {
+		// app backends query app state, transactions and block events are not indexed
+		txIndexer = &null.TxIndex{}
+		blockIndexer = &blockidxnull.BlockerIndexer{}
+	} else {
+		txIndexer = kv.NewTxIndex(ctx, kvStore, kv.WithWorkers(conf.Concurrency.IndexerWorkers), kv.WithExistenceFilter())
+		blockIndexer = blockidxkv.New(ctx, newPrefixKV(kvStore, "block_events"))
+	}
 
 	indexerService := txindex.NewIndexerService(ctx, txIndexer, blockIndexer, eventBus, false)
 	indexerService.SetLogger(logger.With("module", "txindex"))
@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
@@ -9,18 +10,29 @@ import (
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/config"
+	cmcrypto "github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	cmbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	cmquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/libs/pubsub/query/syntax"
 	corep2p "github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/proxy"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	cmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
+	ds "github.com/ipfs/go-datastore"
 
+	"github.com/rollkit/rollkit/block"
 	rconfig "github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
 	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/p2p"
+	rstate "github.com/rollkit/rollkit/state"
+	"github.com/rollkit/rollkit/state/txindex"
+	"github.com/rollkit/rollkit/state/txindex/null"
+	"github.com/rollkit/rollkit/store"
 	"github.com/rollkit/rollkit/types"
 	abciconv "github.com/rollkit/rollkit/types/abci"
 )
@@ -29,15 +41,226 @@ const (
 	defaultPerPage = 30
 	maxPerPage     = 100
 
+	// maxBroadcastTxs is the maximum number of transactions submitted in a single BroadcastTxs call
+	maxBroadcastTxs = 100
+
 	// TODO(tzdybal): make this configurable
 	subscribeTimeout = 5 * time.Second
+
+	// maxWaitForHeight is the maximum time WaitForHeight and WaitForDAInclusion wait for a block
+	maxWaitForHeight = time.Minute
 )
 
 var (
 	// ErrConsensusStateNotAvailable is returned because Rollkit doesn't use Tendermint consensus.
 	ErrConsensusStateNotAvailable = errors.New("consensus state not available in Rollkit")
+
+	// ErrTxTracingDisabled is returned when transaction tracing is requested, but trace app is not configured.
+	ErrTxTracingDisabled = errors.New("transaction tracing is disabled")
+
+	// ErrTxCommitted is returned when submitted transaction is already included in a block.
+	ErrTxCommitted = errors.New("transaction is already committed")
+
+	// ErrQueryHeightUnavailable is returned when state is queried at a height which is not committed yet, or
+	// pruned by the app.
+	ErrQueryHeightUnavailable = errors.New("state at query height is not available")
+
+	// ErrStatePruned is returned when a transaction is traced, but the state before its block was pruned.
+	ErrStatePruned = errors.New("state was pruned")
 )
 
+// ResultDAInclusion is returned by WaitForDAInclusion once the block at Height is included in the DA.
+type ResultDAInclusion struct {
+	Height int64 `json:"height"`
+	// DAIncludedHeight is the rollup height up to which all blocks are included in the DA, at least Height.
+	DAIncludedHeight uint64 `json:"da_included_height"`
+}
+
+// ResultTraceTx contains the result of transaction re-execution.
+type ResultTraceTx struct {
+	Hash     cmbytes.HexBytes  `json:"hash"`
+	Height   int64             `json:"height"`
+	Index    uint32            `json:"index"`
+	TxResult abci.ExecTxResult `json:"tx_result"`
+}
+
+// ResultProposerPerformance contains latencies of block production stages, computed from the latest
+// blocks produced by the node.
+type ResultProposerPerformance struct {
+	Height uint64 `json:"height"`
+	// Window is the maximum number of the latest samples used to compute latencies of each stage.
+	Window int                     `json:"window"`
+	Stages map[string]StageLatency `json:"stages"`
+}
+
+// StageLatency contains aggregated latency of a block production stage, in milliseconds.
+type StageLatency struct {
+	Count uint64  `json:"count"`
+	Avg   float64 `json:"avg_ms"`
+	P50   float64 `json:"p50_ms"`
+	P90   float64 `json:"p90_ms"`
+	P99   float64 `json:"p99_ms"`
+	Max   float64 `json:"max_ms"`
+}
+
+// ResultFinalityStats contains latencies between soft confirmation of the latest blocks (when they were produced
+// or applied by the node) and their inclusion in DA, in milliseconds.
+type ResultFinalityStats struct {
+	Height           uint64 `json:"height"`
+	DAIncludedHeight uint64 `json:"da_included_height"`
+	// Window is the maximum number of the latest samples used to compute latencies of each unit.
+	Window int `json:"window"`
+	// Latencies by unit: block, or tx (latency of block weighted by its transactions).
+	Latencies map[string]StageLatency `json:"latencies"`
+	// Pending is the number of soft-confirmed blocks waiting for DA inclusion, the oldest of them waiting for
+	// OldestPending milliseconds.
+	Pending       int     `json:"pending"`
+	OldestPending float64 `json:"oldest_pending_ms"`
+	// SLA is the configured maximal latency (0 if not set), and SLAViolations is the number of blocks exceeding it.
+	SLA           float64 `json:"sla_ms"`
+	SLAViolations uint64  `json:"sla_violations"`
+}
+
+// ResultHaltStatus describes scheduled and active chain halt.
+type ResultHaltStatus struct {
+	// HaltHeight is 0 if halt height is not set.
+	HaltHeight uint64 `json:"halt_height"`
+	// HaltTime is nil if halt time is not set.
+	HaltTime *time.Time        `json:"halt_time"`
+	Halted   bool              `json:"halted"`
+	Marker   *block.HaltMarker `json:"marker"`
+}
+
+// ResultSimulateBlock describes the block that would be produced next.
+type ResultSimulateBlock struct {
+	Height uint64    `json:"height"`
+	Time   time.Time `json:"time"`
+	// Source is "batch" if transactions were taken from the next sequencer batch, or "pending" if no batch
+	// was queued and transactions were taken from the mempool.
+	Source string        `json:"source"`
+	Txs    []SimulatedTx `json:"txs"`
+	Size   int           `json:"size"`
+	// GasWanted is the sum of gas wanted by transactions, as reported by CheckTx. Transactions not found in
+	// the mempool are not included.
+	GasWanted int64 `json:"gas_wanted"`
+	// DABlobSize is the estimated size of the header blob submitted to DA.
+	DABlobSize     int    `json:"da_blob_size"`
+	EstimatedDAGas uint64 `json:"estimated_da_gas"`
+	// EstimatedDACost is nil if DA gas price is determined automatically.
+	EstimatedDACost *float64 `json:"estimated_da_cost"`
+}
+
+// ResultMisbehaviorLedger contains recorded misbehavior of peers, most recently offending peers first.
+type ResultMisbehaviorLedger struct {
+	Peers []p2p.LedgerEntry `json:"peers"`
+}
+
+// SimulatedTx describes a transaction included in simulated block.
+type SimulatedTx struct {
+	Hash      cmbytes.HexBytes `json:"hash"`
+	Tx        cmtypes.Tx       `json:"tx"`
+	GasWanted int64            `json:"gas_wanted"`
+}
+
+// ResultBroadcastTxs contains the result of atomic submission of a batch of transactions.
+type ResultBroadcastTxs struct {
+	// Accepted is true if all transactions were added to the mempool, none of them are added otherwise.
+	Accepted bool `json:"accepted"`
+	// Txs contains results of the transactions, in order. Transactions following the first rejected one
+	// are not checked, their results are empty.
+	Txs []ctypes.ResultBroadcastTx `json:"txs"`
+}
+
+// ResultBroadcastBundle contains the result of bundle submission.
+type ResultBroadcastBundle struct {
+	// Hash is the hash of the transaction wrapping the bundle, submitted to the sequencer.
+	Hash cmbytes.HexBytes `json:"hash"`
+	// TxHashes are hashes of transactions of the bundle, in order.
+	TxHashes []cmbytes.HexBytes `json:"tx_hashes"`
+}
+
+// ResultEstimateGas contains gas wanted by a transaction and the minimum fee required to include it.
+type ResultEstimateGas struct {
+	Code      uint32 `json:"code"`
+	Log       string `json:"log"`
+	Codespace string `json:"codespace"`
+	GasWanted int64  `json:"gas_wanted"`
+	GasUsed   int64  `json:"gas_used"`
+	// MinGasPrice and MinFee are empty if minimum gas price is not configured.
+	MinGasPrice string `json:"min_gas_price"`
+	// MinFee is the minimum gas price multiplied by gas wanted, rounded up.
+	MinFee string `json:"min_fee"`
+}
+
+// ResultState contains the record of the state after applying block at given height.
+type ResultState struct {
+	Height          int64            `json:"height"`
+	AppHash         cmbytes.HexBytes `json:"app_hash"`
+	LastResultsHash cmbytes.HexBytes `json:"last_results_hash"`
+	// LastHeightConsensusParamsChanged and AppVersion identify the version of consensus params.
+	LastHeightConsensusParamsChanged int64  `json:"last_height_consensus_params_changed"`
+	AppVersion                       uint64 `json:"app_version"`
+}
+
+// ResultStatus is the result of status RPC: CometBFT status extended with Rollkit specific fields.
+type ResultStatus struct {
+	NodeInfo      corep2p.DefaultNodeInfo `json:"node_info"`
+	SyncInfo      ctypes.SyncInfo         `json:"sync_info"`
+	ValidatorInfo ctypes.ValidatorInfo    `json:"validator_info"`
+	// GenesisHash is the canonical hash of genesis (see types.GenesisHash), empty if not known.
+	GenesisHash cmbytes.HexBytes `json:"genesis_hash,omitempty"`
+	// SyncProfile is the sync profile of the node, see config.ApplySyncProfile. Limitations describe data which
+	// is not served because of the profile.
+	SyncProfile string   `json:"sync_profile,omitempty"`
+	Limitations []string `json:"limitations,omitempty"`
+}
+
+// ResultDAQuarantine contains blobs retrieved from DA which decode as signed headers, but failed validation.
+type ResultDAQuarantine struct {
+	// Blobs are the latest quarantined blobs, oldest first.
+	Blobs []block.QuarantinedBlob `json:"blobs"`
+}
+
+// ResultChainInfo is a machine-readable descriptor of the chain, which wallets and tooling can use to configure
+// themselves against the chain.
+type ResultChainInfo struct {
+	ChainID string `json:"chain_id"`
+	// GenesisHash is the canonical hash of genesis, see types.GenesisHash.
+	GenesisHash   cmbytes.HexBytes   `json:"genesis_hash"`
+	InitialHeight int64              `json:"initial_height"`
+	DA            ChainInfoDA        `json:"da"`
+	Sequencer     ChainInfoSequencer `json:"sequencer"`
+	// Mode is the mode of the serving node: "aggregator", "full" or "read-only".
+	Mode string `json:"mode"`
+	// RPCFeatures are names of RPC methods served by the node. They are set by the RPC server.
+	RPCFeatures []string          `json:"rpc_features"`
+	Versions    ChainInfoVersions `json:"versions"`
+}
+
+// ChainInfoDA describes where blocks of the chain are published in DA.
+type ChainInfoDA struct {
+	// Namespace is the hex encoded DA namespace of blocks.
+	Namespace string `json:"namespace"`
+	// StartHeight is the DA height syncing starts from, 0 if not known.
+	StartHeight uint64 `json:"start_height"`
+}
+
+// ChainInfoSequencer identifies the sequencer of the chain, as set in genesis.
+type ChainInfoSequencer struct {
+	Address cmbytes.HexBytes `json:"address"`
+	PubKey  cmcrypto.PubKey  `json:"pub_key"`
+}
+
+// ChainInfoVersions contains versions of the software and protocols of the node.
+type ChainInfoVersions struct {
+	Rollkit  string `json:"rollkit"`
+	CometBFT string `json:"cometbft"`
+	ABCI     string `json:"abci"`
+	P2P      uint64 `json:"p2p"`
+	Block    uint64 `json:"block"`
+	App      uint64 `json:"app"`
+}
+
 var _ rpcclient.Client = &FullClient{}
 
 // FullClient implements tendermint RPC client interface.
@@ -72,8 +295,13 @@ func (c *FullClient) ABCIQuery(ctx context.Context, path string, data cmbytes.He
 	return c.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
 }
 
-// ABCIQueryWithOptions queries for data from application.
+// ABCIQueryWithOptions queries for data from application. Height 0 queries the latest state committed by the
+// application. Otherwise, state after executing the block at given height is queried; height must be committed
+// and not below retain height reported by the application.
 func (c *FullClient) ABCIQueryWithOptions(ctx context.Context, path string, data cmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if err := c.checkQueryHeight(ctx, opts.Height); err != nil {
+		return nil, err
+	}
 	resQuery, err := c.appClient().Query().Query(ctx, &abci.RequestQuery{
 		Path:   path,
 		Data:   data,
@@ -87,6 +315,32 @@ func (c *FullClient) ABCIQueryWithOptions(ctx context.Context, path string, data
 	return &ctypes.ResultABCIQuery{Response: *resQuery}, nil
 }
 
+// checkQueryHeight checks that state at the height is available. Blocks are committed in the application before
+// the store height is updated, so state of all heights up to the store height is committed.
+func (c *FullClient) checkQueryHeight(ctx context.Context, height int64) error {
+	if height == 0 {
+		return nil
+	}
+	if height < 0 {
+		return fmt.Errorf("%w: height %d is negative", ErrQueryHeightUnavailable, height)
+	}
+	if latest := c.node.Store.Height(); uint64(height) > latest { //nolint:gosec
+		return fmt.Errorf("%w: height %d is not committed yet, latest height is %d", ErrQueryHeightUnavailable, height, latest)
+	}
+	lowest := uint64(c.node.GetGenesis().InitialHeight) //nolint:gosec
+	retainHeight, err := block.LoadAppRetainHeight(ctx, c.node.Store)
+	if err != nil {
+		return fmt.Errorf("failed to load app retain height: %w", err)
+	}
+	if retainHeight > lowest {
+		lowest = retainHeight
+	}
+	if uint64(height) < lowest { //nolint:gosec
+		return fmt.Errorf("%w: height %d is pruned, lowest available height is %d", ErrQueryHeightUnavailable, height, lowest)
+	}
+	return nil
+}
+
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_commit
 func (c *FullClient) BroadcastTxCommit(ctx context.Context, tx cmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
@@ -95,6 +349,14 @@ func (c *FullClient) BroadcastTxCommit(ctx context.Context, tx cmtypes.Tx) (*cty
 	// This code is a local client, so we can assume that subscriber is ""
 	subscriber := "" //ctx.RemoteAddr()
 
+	// read-only node doesn't execute blocks, so transaction would never be seen as committed
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	if err := c.checkNotCommitted(tx); err != nil {
+		return nil, err
+	}
+
 	if c.EventBus.NumClients() >= c.config.MaxSubscriptionClients {
 		return nil, fmt.Errorf("max_subscription_clients %d reached", c.config.MaxSubscriptionClients)
 	} else if c.EventBus.NumClientSubscriptions(subscriber) >= c.config.MaxSubscriptionsPerClient {
@@ -135,7 +397,7 @@ func (c *FullClient) BroadcastTxCommit(ctx context.Context, tx cmtypes.Tx) (*cty
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:  *checkTxRes,
 			TxResult: abci.ExecTxResult{},
-			Hash:     tx.Hash(),
+			Hash:     types.TxHash(tx),
 		}, nil
 	}
 
@@ -152,7 +414,7 @@ func (c *FullClient) BroadcastTxCommit(ctx context.Context, tx cmtypes.Tx) (*cty
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:  *checkTxRes,
 			TxResult: deliverTxRes.Result,
-			Hash:     tx.Hash(),
+			Hash:     types.TxHash(tx),
 			Height:   deliverTxRes.Height,
 		}, nil
 	case <-deliverTxSub.Canceled():
@@ -167,7 +429,7 @@ func (c *FullClient) BroadcastTxCommit(ctx context.Context, tx cmtypes.Tx) (*cty
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:  *checkTxRes,
 			TxResult: abci.ExecTxResult{},
-			Hash:     tx.Hash(),
+			Hash:     types.TxHash(tx),
 		}, err
 	case <-time.After(c.config.TimeoutBroadcastTxCommit):
 		err = errors.New("timed out waiting for tx to be included in a block")
@@ -175,7 +437,7 @@ func (c *FullClient) BroadcastTxCommit(ctx context.Context, tx cmtypes.Tx) (*cty
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:  *checkTxRes,
 			TxResult: abci.ExecTxResult{},
-			Hash:     tx.Hash(),
+			Hash:     types.TxHash(tx),
 		}, err
 	}
 }
@@ -184,6 +446,9 @@ func (c *FullClient) BroadcastTxCommit(ctx context.Context, tx cmtypes.Tx) (*cty
 // CheckTx nor DeliverTx results.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_async
 func (c *FullClient) BroadcastTxAsync(ctx context.Context, tx cmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := c.checkNotCommitted(tx); err != nil {
+		return nil, err
+	}
 	err := c.node.Mempool.CheckTx(tx, nil, mempool.TxInfo{})
 	if err != nil {
 		return nil, err
@@ -193,13 +458,16 @@ func (c *FullClient) BroadcastTxAsync(ctx context.Context, tx cmtypes.Tx) (*ctyp
 	if err != nil {
 		return nil, fmt.Errorf("tx added to local mempool but failed to gossip: %w", err)
 	}
-	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+	return &ctypes.ResultBroadcastTx{Hash: types.TxHash(tx)}, nil
 }
 
 // BroadcastTxSync returns with the response from CheckTx. Does not wait for
 // DeliverTx result.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_sync
 func (c *FullClient) BroadcastTxSync(ctx context.Context, tx cmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := c.checkNotCommitted(tx); err != nil {
+		return nil, err
+	}
 	resCh := make(chan *abci.ResponseCheckTx, 1)
 	err := c.node.Mempool.CheckTx(tx, func(res *abci.ResponseCheckTx) {
 		select {
@@ -233,12 +501,106 @@ func (c *FullClient) BroadcastTxSync(ctx context.Context, tx cmtypes.Tx) (*ctype
 		Data:      res.Data,
 		Log:       res.Log,
 		Codespace: res.Codespace,
-		Hash:      tx.Hash(),
+		Hash:      types.TxHash(tx),
 	}, nil
 }
 
+// checkNotCommitted returns ErrTxCommitted if the transaction is already included in a block, so retries of
+// committed transactions are rejected before reaching the mempool, which only remembers recently seen
+// transactions. Lookups of transactions which are not committed are answered by existence filter of the indexer.
+func (c *FullClient) checkNotCommitted(tx cmtypes.Tx) error {
+	res, err := c.node.TxIndexer.Get(types.TxHash(tx))
+	if err != nil {
+		return err
+	}
+	if res != nil {
+		return ErrTxCommitted
+	}
+	return nil
+}
+
+// BroadcastTxs checks transactions in order and adds them to the mempool atomically: either all of them
+// are accepted or none. It returns results of CheckTx of every transaction. Accepted transactions are
+// gossiped in order. It supports clients submitting bundles of dependent transactions.
+func (c *FullClient) BroadcastTxs(ctx context.Context, txs []cmtypes.Tx) (*ResultBroadcastTxs, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("no transactions to broadcast")
+	}
+	if len(txs) > maxBroadcastTxs {
+		return nil, fmt.Errorf("too many transactions: %d, max: %d", len(txs), maxBroadcastTxs)
+	}
+	batchMempool, ok := c.node.Mempool.(interface {
+		CheckTxBatch(cmtypes.Txs, mempool.TxInfo) ([]*abci.ResponseCheckTx, bool, error)
+	})
+	if !ok {
+		return nil, errors.New("mempool doesn't support transaction batches")
+	}
+	for _, tx := range txs {
+		if err := c.checkNotCommitted(tx); err != nil {
+			return nil, fmt.Errorf("tx %X: %w", types.TxHash(tx), err)
+		}
+	}
+
+	responses, accepted, err := batchMempool.CheckTxBatch(txs, mempool.TxInfo{})
+	if err != nil {
+		return nil, err
+	}
+	res := &ResultBroadcastTxs{
+		Accepted: accepted,
+		Txs:      make([]ctypes.ResultBroadcastTx, len(txs)),
+	}
+	for i, tx := range txs {
+		res.Txs[i].Hash = types.TxHash(tx)
+		if i < len(responses) {
+			res.Txs[i].Code = responses[i].Code
+			res.Txs[i].Data = responses[i].Data
+			res.Txs[i].Log = responses[i].Log
+			res.Txs[i].Codespace = responses[i].Codespace
+		}
+	}
+	if !res.Accepted {
+		return res, nil
+	}
+	for _, tx := range txs {
+		if err := c.node.p2pClient.GossipTx(ctx, tx); err != nil {
+			return nil, fmt.Errorf("txs added to local mempool but failed to gossip: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// BroadcastBundle submits a bundle of transactions to the sequencer. Block builder includes all transactions
+// of the bundle in the same block, in order, or none of them, e.g. if application drops any of them in
+// PrepareProposal. Transactions of the bundle are not checked by the mempool.
+func (c *FullClient) BroadcastBundle(ctx context.Context, txs []cmtypes.Tx) (*ResultBroadcastBundle, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	if len(txs) > maxBroadcastTxs {
+		return nil, fmt.Errorf("too many transactions: %d, max: %d", len(txs), maxBroadcastTxs)
+	}
+	bundleTxs := make(types.Txs, len(txs))
+	res := &ResultBroadcastBundle{TxHashes: make([]cmbytes.HexBytes, len(txs))}
+	for i, tx := range txs {
+		bundleTxs[i] = types.Tx(tx)
+		res.TxHashes[i] = types.TxHash(tx)
+	}
+	bundle, err := types.NewBundleTx(bundleTxs)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.node.mempoolReaper.SubmitTx(ctx, cmtypes.Tx(bundle)); err != nil {
+		return nil, fmt.Errorf("failed to submit bundle to sequencer: %w", err)
+	}
+	res.Hash = types.TxHash(bundle)
+	return res, nil
+}
+
 // Subscribe subscribe given subscriber to a query.
 func (c *FullClient) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
+	if err := c.node.memoryGovernor.Err(); err != nil {
+		return nil, err
+	}
 	q, err := cmquery.New(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query: %w", err)
@@ -309,9 +671,9 @@ func (c *FullClient) GenesisChunked(context context.Context, id uint) (*ctypes.R
 func (c *FullClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	const limit int64 = 20
 
-	// Currently blocks are not pruned and are synced linearly so the base height is 0
+	// Headers are not pruned and are synced linearly so the base height is the initial height
 	minHeight, maxHeight, err := filterMinMax(
-		0,
+		int64(c.earliestHeight()),    //nolint:gosec
 		int64(c.node.Store.Height()), //nolint:gosec
 		minHeight,
 		maxHeight,
@@ -321,19 +683,17 @@ func (c *FullClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight in
 	}
 	c.Logger.Debug("BlockchainInfo", "maxHeight", maxHeight, "minHeight", minHeight)
 
-	blocks := make([]*cmtypes.BlockMeta, 0, maxHeight-minHeight+1)
-	for height := maxHeight; height >= minHeight; height-- {
-		header, data, err := c.node.Store.GetBlockData(ctx, uint64(height))
+	stored, err := c.node.Store.LoadBlockRange(ctx, uint64(minHeight), uint64(maxHeight)) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]*cmtypes.BlockMeta, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		cmblockmeta, err := abciconv.ToABCIBlockMeta(stored[i].Header, stored[i].Data)
 		if err != nil {
 			return nil, err
 		}
-		if header != nil && data != nil {
-			cmblockmeta, err := abciconv.ToABCIBlockMeta(header, data)
-			if err != nil {
-				return nil, err
-			}
-			blocks = append(blocks, cmblockmeta)
-		}
+		blocks = append(blocks, cmblockmeta)
 	}
 
 	return &ctypes.ResultBlockchainInfo{
@@ -381,9 +741,13 @@ func (c *FullClient) ConsensusParams(ctx context.Context, height *int64) (*ctype
 	if err != nil {
 		return nil, err
 	}
+	heightValue, err := c.validateHeight(height)
+	if err != nil {
+		return nil, err
+	}
 	params := state.ConsensusParams
 	return &ctypes.ResultConsensusParams{
-		BlockHeight: int64(c.normalizeHeight(height)), //nolint:gosec
+		BlockHeight: int64(heightValue), //nolint:gosec
 		ConsensusParams: cmtypes.ConsensusParams{
 			Block: cmtypes.BlockParams{
 				MaxBytes: params.Block.MaxBytes,
@@ -420,9 +784,16 @@ func (c *FullClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBl
 	switch {
 	// block tag = included
 	case height != nil && *height == -1:
-		heightValue = c.node.blockManager.GetDAIncludedHeight()
+		var err error
+		heightValue, err = c.node.daIncludedHeight(ctx)
+		if err != nil {
+			return nil, err
+		}
 	default:
-		heightValue = c.normalizeHeight(height)
+		var err error
+		if heightValue, err = c.validateHeight(height); err != nil {
+			return nil, err
+		}
 	}
 	header, data, err := c.node.Store.GetBlockData(ctx, heightValue)
 	if err != nil {
@@ -469,19 +840,62 @@ func (c *FullClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.Resu
 	}, nil
 }
 
+// WaitForHeight waits until block at given height is committed, at most maxWaitForHeight, and returns it.
+func (c *FullClient) WaitForHeight(ctx context.Context, height int64) (*ctypes.ResultBlock, error) {
+	if height < int64(c.earliestHeight()) { //nolint:gosec
+		return nil, &store.HeightRangeError{Height: height, Earliest: c.earliestHeight(), Latest: c.node.Store.Height()}
+	}
+	ctx, cancel := context.WithTimeout(ctx, maxWaitForHeight)
+	defer cancel()
+	if err := c.node.Store.WaitForHeight(ctx, uint64(height)); err != nil {
+		return nil, fmt.Errorf("block %d not committed: %w", height, err)
+	}
+	return c.Block(ctx, &height)
+}
+
+// WaitForDAInclusion waits until all blocks up to given height are included in the DA, at most maxWaitForHeight,
+// and returns the DA included height.
+func (c *FullClient) WaitForDAInclusion(ctx context.Context, height int64) (*ResultDAInclusion, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	if height < 1 {
+		return nil, fmt.Errorf("height must be greater than 0, but got %d", height)
+	}
+	ctx, cancel := context.WithTimeout(ctx, maxWaitForHeight)
+	defer cancel()
+	if err := c.node.blockManager.WaitForDAIncludedHeight(ctx, uint64(height)); err != nil {
+		return nil, fmt.Errorf("block %d not included in DA: %w", height, err)
+	}
+	return &ResultDAInclusion{Height: height, DAIncludedHeight: c.node.blockManager.GetDAIncludedHeight()}, nil
+}
+
+// BlockByTime returns the latest block with time not after given time, so timestamps can be mapped to heights.
+func (c *FullClient) BlockByTime(ctx context.Context, t time.Time) (*ctypes.ResultBlock, error) {
+	height, err := c.node.Store.LoadBlockByTime(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	h := int64(height) //nolint:gosec
+	return c.Block(ctx, &h)
+}
+
 // BlockResults returns information about transactions, events and updates of validator set and consensus params.
 func (c *FullClient) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
-	var h uint64
-	if height == nil {
-		h = c.node.Store.Height()
-	} else {
-		h = uint64(*height)
+	h, err := c.validateHeight(height)
+	if err != nil {
+		return nil, err
 	}
-	header, _, err := c.node.Store.GetBlockData(ctx, h)
+	header, err := c.node.Store.GetHeader(ctx, h)
 	if err != nil {
 		return nil, err
 	}
 	resp, err := c.node.Store.GetBlockResponses(ctx, h)
+	if errors.Is(err, ds.ErrNotFound) {
+		if pruned, perr := txindex.PrunedHeight(ctx, c.node.Store); perr == nil && h <= pruned {
+			return nil, fmt.Errorf("%w: results of height %d are not available, events were pruned up to height %d", txindex.ErrEventsPruned, h, pruned)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -498,7 +912,10 @@ func (c *FullClient) BlockResults(ctx context.Context, height *int64) (*ctypes.R
 
 // Commit returns signed header (aka commit) at given height.
 func (c *FullClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
-	heightValue := c.normalizeHeight(height)
+	heightValue, err := c.validateHeight(height)
+	if err != nil {
+		return nil, err
+	}
 	header, data, err := c.node.Store.GetBlockData(ctx, heightValue)
 	if err != nil {
 		return nil, err
@@ -520,9 +937,41 @@ func (c *FullClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultC
 	return ctypes.NewResultCommit(&block.Header, commit, true), nil
 }
 
+// State returns the record of the state after applying block at given height, or the latest state if height
+// is nil. Unlike block headers, which contain app hash of the previous block, it provides app hash and results
+// hash of the block at given height. Records are not available for blocks applied by older node versions.
+func (c *FullClient) State(ctx context.Context, height *int64) (*ResultState, error) {
+	var record *types.StateRecord
+	if height == nil {
+		state, err := c.node.Store.GetState(ctx)
+		if err != nil {
+			return nil, err
+		}
+		record = types.NewStateRecord(state)
+	} else {
+		heightValue, err := c.validateHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		if record, err = c.node.Store.GetStateRecord(ctx, heightValue); err != nil {
+			return nil, err
+		}
+	}
+	return &ResultState{
+		Height:                           int64(record.Height), //nolint:gosec
+		AppHash:                          cmbytes.HexBytes(record.AppHash),
+		LastResultsHash:                  cmbytes.HexBytes(record.LastResultsHash),
+		LastHeightConsensusParamsChanged: int64(record.LastHeightConsensusParamsChanged), //nolint:gosec
+		AppVersion:                       record.AppVersion,
+	}, nil
+}
+
 // Validators returns paginated list of validators at given height.
 func (c *FullClient) Validators(ctx context.Context, heightPtr *int64, pagePtr, perPagePtr *int) (*ctypes.ResultValidators, error) {
-	height := c.normalizeHeight(heightPtr)
+	height, err := c.validateHeight(heightPtr)
+	if err != nil {
+		return nil, err
+	}
 	genesisValidators := c.node.GetGenesis().Validators
 
 	if len(genesisValidators) != 1 {
@@ -550,7 +999,7 @@ func (c *FullClient) Validators(ctx context.Context, heightPtr *int64, pagePtr,
 
 // Tx returns detailed information about transaction identified by its hash.
 func (c *FullClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	res, err := c.node.TxIndexer.Get(hash)
+	res, err := c.getTx(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -564,8 +1013,10 @@ func (c *FullClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.R
 
 	var proof cmtypes.TxProof
 	if prove {
-		_, data, _ := c.node.Store.GetBlockData(ctx, uint64(height))
-		blockProof := data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
+		blockProof, err := c.txProof(ctx, uint64(height), int(index), types.Tx(res.Tx)) // XXX: overflow on 32-bit machines
+		if err != nil {
+			return nil, err
+		}
 		proof = cmtypes.TxProof{
 			RootHash: blockProof.RootHash,
 			Data:     cmtypes.Tx(blockProof.Data),
@@ -583,6 +1034,356 @@ func (c *FullClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.R
 	}, nil
 }
 
+// txProof returns inclusion proof of transaction at given index of block at given height. Leaves of Merkle tree of
+// transactions are SHA-256 hashes of transactions (see types.Tx.Hash). Hashes of transactions are kept after block
+// data is pruned, so proofs of old transactions can be served, but only if they are computed with the default
+// SHA-256 hash function.
+func (c *FullClient) txProof(ctx context.Context, height uint64, index int, tx types.Tx) (types.TxProof, error) {
+	var hashes [][]byte
+	if types.TxHashCometBFT() {
+		var err error
+		if hashes, err = c.node.Store.GetTxHashes(ctx, height); err != nil {
+			return types.TxProof{}, fmt.Errorf("failed to load transactions of block %d: %w", height, err)
+		}
+	} else {
+		_, data, err := c.node.Store.GetBlockData(ctx, height)
+		if errors.Is(err, store.ErrBlockDataPruned) {
+			return types.TxProof{}, fmt.Errorf("inclusion proofs of transactions of pruned blocks require %q tx hash: %w", types.TxHashSHA256, err)
+		}
+		if err != nil {
+			return types.TxProof{}, fmt.Errorf("failed to load transactions of block %d: %w", height, err)
+		}
+		hashes = make([][]byte, len(data.Txs))
+		for i, tx := range data.Txs {
+			hashes[i] = tx.Hash()
+		}
+	}
+	if index >= len(hashes) {
+		return types.TxProof{}, fmt.Errorf("tx index %d out of range of block %d", index, height)
+	}
+	return types.ProofFromTxHashes(hashes, index, tx), nil
+}
+
+// getTx returns result of transaction with given hash, or nil if it's not found. Transactions not found by the
+// indexer, e.g. if indexing is disabled, are looked up in the transaction index of the store.
+func (c *FullClient) getTx(ctx context.Context, hash []byte) (*abci.TxResult, error) {
+	res, idxErr := c.node.TxIndexer.Get(hash)
+	if idxErr == nil && res != nil {
+		return res, nil
+	}
+	height, index, err := c.node.Store.LoadTxByHash(ctx, hash)
+	if errors.Is(err, ds.ErrNotFound) {
+		if _, disabled := c.node.TxIndexer.(*null.TxIndex); disabled {
+			return nil, nil
+		}
+		return nil, idxErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, data, err := c.node.Store.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	responses, err := c.node.Store.GetBlockResponses(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load results of block %d: %w", height, err)
+	}
+	if int(index) >= len(data.Txs) || int(index) >= len(responses.TxResults) {
+		return nil, fmt.Errorf("tx index %d out of range of block %d", index, height)
+	}
+	return &abci.TxResult{
+		Height: int64(height), //nolint:gosec
+		Index:  index,
+		Tx:     data.Txs[index],
+		Result: *responses.TxResults[index],
+	}, nil
+}
+
+// TraceTx re-executes the block containing transaction identified by its hash, up to and including
+// that transaction, and returns the result of its execution.
+//
+// Block is executed using a new connection to the trace app, that has to be at the state right
+// before the block. The connection is discarded afterwards and the block is never committed.
+func (c *FullClient) TraceTx(ctx context.Context, hash []byte) (*ResultTraceTx, error) {
+	if c.node.traceClientCreator == nil {
+		return nil, ErrTxTracingDisabled
+	}
+
+	res, err := c.getTx(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+
+	header, data, err := c.node.Store.GetBlockData(ctx, uint64(res.Height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block %d: %w", res.Height, err)
+	}
+	state, err := c.stateBefore(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	appConn, err := c.node.traceClientCreator.NewABCIClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace app connection: %w", err)
+	}
+	if err := appConn.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start trace app connection: %w", err)
+	}
+	defer func() {
+		if err := appConn.Stop(); err != nil {
+			c.Logger.Error("failed to stop trace app connection", "error", err)
+		}
+	}()
+
+	info, err := appConn.Info(ctx, proxy.RequestInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trace app info: %w", err)
+	}
+	if info.LastBlockHeight != res.Height-1 {
+		return nil, fmt.Errorf("trace app is at height %d, tracing tx at height %d requires height %d", info.LastBlockHeight, res.Height, res.Height-1)
+	}
+
+	txResult, err := rstate.TraceTx(ctx, proxy.NewAppConnConsensus(appConn, proxy.NopMetrics()), state, header, data, int(res.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace tx (%X): %w", hash, err)
+	}
+
+	return &ResultTraceTx{
+		Hash:     hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		TxResult: *txResult,
+	}, nil
+}
+
+// stateBefore returns the state the block was applied to: the latest state rewound with the record of the state
+// after the previous block, or the genesis state for the first block. Validators are taken from the header.
+func (c *FullClient) stateBefore(ctx context.Context, header *types.SignedHeader) (types.State, error) {
+	state, err := c.node.Store.GetState(ctx)
+	if err != nil {
+		return types.State{}, fmt.Errorf("failed to load state: %w", err)
+	}
+	height := header.Height()
+	if height == state.InitialHeight {
+		if state, err = types.NewFromGenesisDoc(c.node.GetGenesis()); err != nil {
+			return types.State{}, fmt.Errorf("failed to load genesis state: %w", err)
+		}
+		// app hash returned by InitChain is recorded in the header of the first block
+		state.AppHash = header.AppHash
+	} else {
+		record, err := c.node.Store.GetStateRecord(ctx, height-1)
+		if errors.Is(err, ds.ErrNotFound) {
+			return types.State{}, fmt.Errorf("%w: state at height %d is not available", ErrStatePruned, height-1)
+		}
+		if err != nil {
+			return types.State{}, fmt.Errorf("failed to load state at height %d: %w", height-1, err)
+		}
+		state.LastBlockHeight = record.Height
+		state.AppHash = record.AppHash
+		state.LastResultsHash = record.LastResultsHash
+		state.LastHeightConsensusParamsChanged = record.LastHeightConsensusParamsChanged
+		state.Version.Consensus.App = record.AppVersion
+	}
+	if header.Validators != nil {
+		state.Validators = header.Validators
+	}
+	return state, nil
+}
+
+// ProposerPerformance returns latencies of block production stages (batch fetch, execution, signing,
+// storing and DA submission), so operators can identify which stage slows down block production.
+func (c *FullClient) ProposerPerformance(_ context.Context) (*ResultProposerPerformance, error) {
+	if !c.node.nodeConfig.Aggregator || c.node.blockManager == nil {
+		return nil, block.ErrNotProposer
+	}
+	stages := make(map[string]StageLatency)
+	for stage, stats := range c.node.blockManager.ProductionStats() {
+		stages[stage] = stageLatency(stats)
+	}
+	return &ResultProposerPerformance{
+		Height: c.node.Store.Height(),
+		Window: block.PerformanceWindow,
+		Stages: stages,
+	}, nil
+}
+
+// stageLatency converts aggregated latency to milliseconds.
+func stageLatency(stats block.StageStats) StageLatency {
+	return StageLatency{
+		Count: stats.Count,
+		Avg:   ms(stats.Avg),
+		P50:   ms(stats.P50),
+		P90:   ms(stats.P90),
+		P99:   ms(stats.P99),
+		Max:   ms(stats.Max),
+	}
+}
+
+// ms returns duration in milliseconds.
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// FinalityStats returns latencies between soft confirmation of blocks and their inclusion in DA, so that chains
+// advertising fast finality can monitor the guarantee provided to users.
+func (c *FullClient) FinalityStats(_ context.Context) (*ResultFinalityStats, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	stats := c.node.blockManager.FinalityStats()
+	res := &ResultFinalityStats{
+		Height:           c.node.Store.Height(),
+		DAIncludedHeight: c.node.blockManager.GetDAIncludedHeight(),
+		Window:           block.PerformanceWindow,
+		Latencies:        make(map[string]StageLatency),
+		Pending:          stats.Pending,
+		SLA:              ms(stats.SLA),
+		SLAViolations:    stats.SLAViolations,
+	}
+	if !stats.OldestPending.IsZero() {
+		res.OldestPending = ms(time.Since(stats.OldestPending))
+	}
+	for unit, latency := range stats.Latencies {
+		res.Latencies[unit] = stageLatency(latency)
+	}
+	return res, nil
+}
+
+// DumpNodeState returns a snapshot of the internal state of the block manager (pending batches, DA submission
+// queue, DA and sync progress), used to debug stuck nodes. It complements DumpConsensusState, which is not
+// available, as Rollkit doesn't use CometBFT consensus.
+func (c *FullClient) DumpNodeState(ctx context.Context) (*block.ManagerState, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	return c.node.blockManager.DumpState(ctx)
+}
+
+// DAInclusionCertificate returns certificate of inclusion of the block at given height in DA (DA height, blob ID,
+// commitment and inclusion proof), persisted if enabled with DACertificates.
+func (c *FullClient) DAInclusionCertificate(ctx context.Context, height int64) (*types.DAInclusionCertificate, error) {
+	h, err := c.validateHeight(&height)
+	if err != nil {
+		return nil, err
+	}
+	return c.node.Store.GetDAInclusionCertificate(ctx, h)
+}
+
+// DAQuarantine returns the latest blobs retrieved from DA which decode as signed headers, but failed validation
+// (e.g. bad signature or wrong chain ID). They help to diagnose misconfigured or malicious posters.
+func (c *FullClient) DAQuarantine(ctx context.Context) (*ResultDAQuarantine, error) {
+	blobs, err := block.LoadQuarantine(ctx, c.node.Store)
+	if err != nil {
+		return nil, err
+	}
+	if blobs == nil {
+		blobs = []block.QuarantinedBlob{}
+	}
+	return &ResultDAQuarantine{Blobs: blobs}, nil
+}
+
+// ScheduleHalt sets the height of the last block and the time (unix seconds) since which blocks are neither
+// produced nor applied. Zero values disable respective halt condition.
+func (c *FullClient) ScheduleHalt(_ context.Context, height uint64, haltTime uint64) (*ResultHaltStatus, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	var t time.Time
+	if haltTime != 0 {
+		t = time.Unix(int64(haltTime), 0) //nolint:gosec
+	}
+	if err := c.node.blockManager.ScheduleHalt(height, t); err != nil {
+		return nil, err
+	}
+	return c.haltStatus(), nil
+}
+
+// HaltStatus returns scheduled halt height and time, and the halt marker if the chain is halted.
+func (c *FullClient) HaltStatus(_ context.Context) (*ResultHaltStatus, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	return c.haltStatus(), nil
+}
+
+func (c *FullClient) haltStatus() *ResultHaltStatus {
+	status := c.node.blockManager.HaltStatus()
+	res := &ResultHaltStatus{
+		HaltHeight: status.HaltHeight,
+		Halted:     status.Marker != nil,
+		Marker:     status.Marker,
+	}
+	if !status.HaltTime.IsZero() {
+		res.HaltTime = &status.HaltTime
+	}
+	return res
+}
+
+// MisbehaviorLedger returns recorded misbehavior and bans of peers.
+func (c *FullClient) MisbehaviorLedger(_ context.Context) (*ResultMisbehaviorLedger, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	return &ResultMisbehaviorLedger{Peers: c.node.p2pClient.MisbehaviorLedger()}, nil
+}
+
+// SimulateBlock builds (but doesn't execute, sign or store) the block that would be produced next, and
+// returns its size, gas, transactions and estimated cost of DA submission.
+func (c *FullClient) SimulateBlock(ctx context.Context) (*ResultSimulateBlock, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	if !c.node.nodeConfig.Aggregator || c.node.blockManager == nil {
+		return nil, block.ErrNotProposer
+	}
+	sb, err := c.node.blockManager.SimulateBlock(ctx, c.node.Mempool.ReapMaxBytesMaxGas(-1, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ResultSimulateBlock{
+		Height: sb.Header.Height(),
+		Time:   sb.Header.Time(),
+		Source: sb.Source,
+		Txs:    make([]SimulatedTx, len(sb.Data.Txs)),
+	}
+	gasMempool, _ := c.node.Mempool.(interface {
+		GasWanted(cmtypes.Tx) (int64, bool)
+	})
+	for i, tx := range sb.Data.Txs {
+		res.Txs[i] = SimulatedTx{Hash: types.TxHash(tx), Tx: cmtypes.Tx(tx)}
+		if gasMempool != nil {
+			res.Txs[i].GasWanted, _ = gasMempool.GasWanted(cmtypes.Tx(tx))
+			res.GasWanted += res.Txs[i].GasWanted
+		}
+	}
+
+	// header is submitted to DA after signing
+	sb.Header.Signature = make(types.Signature, ed25519.SignatureSize)
+	headerBlob, err := sb.Header.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	dataBlob, err := sb.Data.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	res.Size = len(headerBlob) + len(dataBlob)
+	res.DABlobSize = len(headerBlob)
+	res.EstimatedDAGas = da.EstimateGas(uint64(len(headerBlob)))
+	if gasPrice := c.node.dalc.GasPrice; gasPrice >= 0 {
+		cost := float64(res.EstimatedDAGas) * gasPrice
+		res.EstimatedDACost = &cost
+	}
+	return res, nil
+}
+
 // TxSearch returns detailed information about transactions matching query.
 func (c *FullClient) TxSearch(ctx context.Context, query string, prove bool, pagePtr, perPagePtr *int, orderBy string) (*ctypes.ResultTxSearch, error) {
 	q, err := cmquery.New(query)
@@ -594,6 +1395,16 @@ func (c *FullClient) TxSearch(ctx context.Context, query string, prove bool, pag
 	if err != nil {
 		return nil, err
 	}
+	// transactions not indexed by the indexer can be still found by hash in the store
+	if hash, ok := txHashCondition(q); ok && len(results) == 0 {
+		res, err := c.getTx(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			results = append(results, res)
+		}
+	}
 
 	// sort results (must be done before pagination)
 	switch orderBy {
@@ -638,7 +1449,7 @@ func (c *FullClient) TxSearch(ctx context.Context, query string, prove bool, pag
 		}*/
 
 		apiResults = append(apiResults, &ctypes.ResultTx{
-			Hash:     cmtypes.Tx(r.Tx).Hash(),
+			Hash:     types.TxHash(r.Tx),
 			Height:   r.Height,
 			Index:    r.Index,
 			TxResult: r.Result,
@@ -650,6 +1461,16 @@ func (c *FullClient) TxSearch(ctx context.Context, query string, prove bool, pag
 	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
 }
 
+// txHashCondition returns hash of transaction if query consists of a single tx.hash condition.
+func txHashCondition(q *cmquery.Query) ([]byte, bool) {
+	conditions := q.Syntax()
+	if len(conditions) != 1 || conditions[0].Tag != cmtypes.TxHashKey || conditions[0].Op != syntax.TEq {
+		return nil, false
+	}
+	hash, err := hex.DecodeString(conditions[0].Arg.Value())
+	return hash, err == nil
+}
+
 // BlockSearch defines a method to search for a paginated set of blocks by
 // BeginBlock and EndBlock event search criteria.
 func (c *FullClient) BlockSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (*ctypes.ResultBlockSearch, error) {
@@ -712,6 +1533,79 @@ func (c *FullClient) BlockSearch(ctx context.Context, query string, page, perPag
 	return &ctypes.ResultBlockSearch{Blocks: blocks, TotalCount: totalCount}, nil
 }
 
+// GenesisHash returns the canonical hash of genesis of the node, see types.GenesisHash.
+func (c *FullClient) GenesisHash() cmbytes.HexBytes {
+	return cmbytes.HexBytes(c.node.genesisHash)
+}
+
+// SyncProfile returns the sync profile of the node and its limitations, which clients should be aware of.
+func (c *FullClient) SyncProfile(ctx context.Context) (string, []string) {
+	conf := c.node.nodeConfig
+	if conf.SyncProfile != rconfig.SyncProfileAppOnly {
+		return conf.SyncProfile, nil
+	}
+	var limitations []string
+	if earliest, err := block.LoadEarliestHeight(ctx, c.node.Store); err == nil && earliest > 0 {
+		limitations = append(limitations, fmt.Sprintf("blocks below height %d are not available", earliest))
+	}
+	if conf.BlockDataRetentionBlocks > 0 {
+		limitations = append(limitations, fmt.Sprintf("block data is retained for the latest %d blocks", conf.BlockDataRetentionBlocks))
+	}
+	if conf.EventRetentionBlocks > 0 {
+		limitations = append(limitations, fmt.Sprintf("block results are retained for the latest %d blocks", conf.EventRetentionBlocks))
+	}
+	limitations = append(limitations, "transactions and block events are not indexed")
+	return conf.SyncProfile, limitations
+}
+
+// ChainInfo returns the descriptor of the chain. RPC features are not set, as they depend on the RPC server.
+func (c *FullClient) ChainInfo(ctx context.Context) (*ResultChainInfo, error) {
+	genesis := c.node.GetGenesis()
+	if len(genesis.Validators) != 1 {
+		return nil, errors.New("there should be exactly one validator in genesis")
+	}
+	state, err := c.node.Store.GetState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the last saved state: %w", err)
+	}
+
+	sequencer := genesis.Validators[0]
+	return &ResultChainInfo{
+		ChainID:       genesis.ChainID,
+		GenesisHash:   cmbytes.HexBytes(c.node.genesisHash),
+		InitialHeight: genesis.InitialHeight,
+		DA: ChainInfoDA{
+			Namespace:   c.node.nodeConfig.DANamespace,
+			StartHeight: c.node.nodeConfig.DAStartHeight,
+		},
+		Sequencer: ChainInfoSequencer{
+			Address: sequencer.PubKey.Address(),
+			PubKey:  sequencer.PubKey,
+		},
+		Mode: c.mode(),
+		Versions: ChainInfoVersions{
+			Rollkit:  rconfig.Version,
+			CometBFT: version.TMCoreSemVer,
+			ABCI:     version.ABCISemVer,
+			P2P:      version.P2PProtocol,
+			Block:    state.Version.Consensus.Block,
+			App:      state.Version.Consensus.App,
+		},
+	}, nil
+}
+
+// mode returns the mode of the node: "aggregator", "full" or "read-only".
+func (c *FullClient) mode() string {
+	switch {
+	case c.node.nodeConfig.ReadOnly:
+		return "read-only"
+	case c.node.nodeConfig.Aggregator:
+		return "aggregator"
+	default:
+		return "full"
+	}
+}
+
 // Status returns detailed information about current status of the node.
 func (c *FullClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
 	var (
@@ -722,8 +1616,23 @@ func (c *FullClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
 		latestHeight = c.node.Store.Height()
 	)
 
-	if latestHeight != 0 {
-		header, _, err := c.node.Store.GetBlockData(ctx, latestHeight)
+	// node started from trusted height doesn't store blocks below it
+	earliestHeight, err := block.LoadEarliestHeight(ctx, c.node.Store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load earliest height: %w", err)
+	}
+	earliestHeight = max(earliestHeight, uint64(c.node.GetGenesis().InitialHeight)) //nolint:gosec
+	// blocks below pruned height were deleted
+	prunedHeight, err := store.PrunedHeight(ctx, c.node.Store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pruned height: %w", err)
+	}
+	if prunedHeight > 0 {
+		earliestHeight = max(earliestHeight, prunedHeight+1)
+	}
+
+	if latestHeight >= earliestHeight {
+		header, err := c.node.Store.GetHeader(ctx, latestHeight)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find latest block: %w", err)
 		}
@@ -732,7 +1641,7 @@ func (c *FullClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
 		latestBlockTime = header.Time()
 	}
 
-	initialHeader, _, err := c.node.Store.GetBlockData(ctx, uint64(c.node.GetGenesis().InitialHeight))
+	initialHeader, err := c.node.Store.GetHeader(ctx, earliestHeight)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find earliest block: %w", err)
 	}
@@ -766,6 +1675,9 @@ func (c *FullClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
 		return nil, fmt.Errorf("failed to load node p2p2 info: %w", err)
 	}
 	txIndexerStatus := "on"
+	if c.node.nodeConfig.SyncProfile == rconfig.SyncProfileAppOnly {
+		txIndexerStatus = "off"
+	}
 
 	result := &ctypes.ResultStatus{
 		NodeInfo: corep2p.DefaultNodeInfo{
@@ -841,9 +1753,51 @@ func (c *FullClient) CheckTx(ctx context.Context, tx cmtypes.Tx) (*ctypes.Result
 	return &ctypes.ResultCheckTx{ResponseCheckTx: *res}, nil
 }
 
+// EstimateGas returns gas wanted by transaction and minimum fee required to include it, so clients can
+// construct correctly priced transactions. Transaction is simulated with configured ABCI query, or checked
+// with CheckTx if query path is not configured. Transaction is not added to the mempool.
+func (c *FullClient) EstimateGas(ctx context.Context, tx cmtypes.Tx) (*ResultEstimateGas, error) {
+	var checkRes *abci.ResponseCheckTx
+	if path := c.node.nodeConfig.RPCEstimateGasQuery; path != "" {
+		queryRes, err := c.appClient().Query().Query(ctx, &abci.RequestQuery{Path: path, Data: tx})
+		if err != nil {
+			return nil, err
+		}
+		if queryRes.Code != abci.CodeTypeOK {
+			return &ResultEstimateGas{Code: queryRes.Code, Log: queryRes.Log, Codespace: queryRes.Codespace}, nil
+		}
+		checkRes = new(abci.ResponseCheckTx)
+		if err := checkRes.Unmarshal(queryRes.Value); err != nil {
+			return nil, fmt.Errorf("failed to decode %s query response: %w", path, err)
+		}
+	} else {
+		var err error
+		checkRes, err = c.appClient().Mempool().CheckTx(ctx, &abci.RequestCheckTx{Tx: tx})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := &ResultEstimateGas{
+		Code:      checkRes.Code,
+		Log:       checkRes.Log,
+		Codespace: checkRes.Codespace,
+		GasWanted: checkRes.GasWanted,
+		GasUsed:   checkRes.GasUsed,
+	}
+	if price := c.node.minGasPrice; price != nil {
+		res.MinGasPrice = price.String()
+		res.MinFee = price.fee(checkRes.GasWanted)
+	}
+	return res, nil
+}
+
 // Header returns a cometbft ResultsHeader for the FullClient
 func (c *FullClient) Header(ctx context.Context, heightPtr *int64) (*ctypes.ResultHeader, error) {
-	height := c.normalizeHeight(heightPtr)
+	height, err := c.validateHeight(heightPtr)
+	if err != nil {
+		return nil, err
+	}
 	blockMeta := c.getBlockMeta(ctx, height)
 	if blockMeta == nil {
 		return nil, fmt.Errorf("block at height %d not found", height)
@@ -930,15 +1884,24 @@ func (c *FullClient) appClient() proxy.AppConns {
 	return c.node.AppClient()
 }
 
-func (c *FullClient) normalizeHeight(height *int64) uint64 {
-	var heightValue uint64
+// validateHeight returns the requested height, or the latest height if height is nil. Heights outside of
+// [initial height, latest height] are rejected with store.HeightRangeError, which contains the valid bounds.
+func (c *FullClient) validateHeight(height *int64) (uint64, error) {
+	latest := c.node.Store.Height()
 	if height == nil {
-		heightValue = c.node.Store.Height()
-	} else {
-		heightValue = uint64(*height)
+		return latest, nil
 	}
+	earliest := c.earliestHeight()
+	if *height < int64(earliest) || uint64(*height) > latest { //nolint:gosec
+		return 0, &store.HeightRangeError{Height: *height, Earliest: earliest, Latest: latest}
+	}
+	return uint64(*height), nil
+}
 
-	return heightValue
+// earliestHeight returns the initial height of the chain. Headers are never pruned, so it's the earliest
+// height of blocks available.
+func (c *FullClient) earliestHeight() uint64 {
+	return uint64(max(c.node.GetGenesis().InitialHeight, 1)) //nolint:gosec
 }
 
 func (rpc *FullClient) getBlockMeta(ctx context.Context, n uint64) *cmtypes.BlockMeta {
@@ -1,10 +1,14 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	crand "crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -17,17 +21,24 @@ import (
 	cmconfig "github.com/cometbft/cometbft/config"
 	cmcrypto "github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/proxy"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	cmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
+	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/cometbft/cometbft/light"
 
+	"github.com/rollkit/rollkit/block"
 	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/store"
+	testapp "github.com/rollkit/rollkit/test/app"
 	test "github.com/rollkit/rollkit/test/log"
 	"github.com/rollkit/rollkit/test/mocks"
 	"github.com/rollkit/rollkit/types"
@@ -154,6 +165,42 @@ func TestCheckTx(t *testing.T) {
 	mockApp.AssertExpectations(t)
 }
 
+func TestEstimateGas(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	tx := []byte("tx data")
+
+	mockApp, rpc := getRPC(t, "TestEstimateGas")
+	mockApp.On("CheckTx", mock.Anything, &abci.RequestCheckTx{Tx: tx}).Return(&abci.ResponseCheckTx{GasWanted: 1001, GasUsed: 900}, nil)
+	res, err := rpc.EstimateGas(ctx, tx)
+	require.NoError(err)
+	require.Equal(&ResultEstimateGas{GasWanted: 1001, GasUsed: 900}, res)
+
+	rpc.node.minGasPrice, err = parseGasPrice("0.025stake")
+	require.NoError(err)
+	res, err = rpc.EstimateGas(ctx, tx)
+	require.NoError(err)
+	require.Equal("0.025stake", res.MinGasPrice)
+	require.Equal("26stake", res.MinFee)
+	require.Zero(rpc.node.Mempool.Size())
+
+	simulated, err := (&abci.ResponseCheckTx{GasWanted: 2000, GasUsed: 1500}).Marshal()
+	require.NoError(err)
+	rpc.node.nodeConfig.RPCEstimateGasQuery = "/simulate"
+	mockApp.On("Query", mock.Anything, &abci.RequestQuery{Path: "/simulate", Data: tx}).Once().Return(&abci.ResponseQuery{Value: simulated}, nil)
+	res, err = rpc.EstimateGas(ctx, tx)
+	require.NoError(err)
+	require.Equal(int64(2000), res.GasWanted)
+	require.Equal(int64(1500), res.GasUsed)
+	require.Equal("50stake", res.MinFee)
+
+	mockApp.On("Query", mock.Anything, mock.Anything).Once().Return(&abci.ResponseQuery{Code: 5, Log: "out of gas"}, nil)
+	res, err = rpc.EstimateGas(ctx, tx)
+	require.NoError(err)
+	require.Equal(uint32(5), res.Code)
+	require.Equal("out of gas", res.Log)
+}
+
 func TestGenesisChunked(t *testing.T) {
 	assert := assert.New(t)
 
@@ -327,6 +374,38 @@ func TestGetBlock(t *testing.T) {
 	assert.NotNil(blockResp.Block)
 }
 
+func TestHeightValidation(t *testing.T) {
+	require := require.New(t)
+
+	chainID := "TestHeightValidation"
+	_, rpc := getRPC(t, chainID)
+	ctx := context.Background()
+	for h := uint64(1); h <= 3; h++ {
+		header, data := types.GetRandomBlock(h, 1, chainID)
+		require.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+		rpc.node.Store.SetHeight(ctx, h)
+	}
+
+	for _, height := range []int64{-5, 0, 4, math.MaxInt64} {
+		var rangeErr *store.HeightRangeError
+		_, err := rpc.Block(ctx, &height)
+		require.ErrorAs(err, &rangeErr, "height %d", height)
+		require.Equal(store.HeightRangeError{Height: height, Earliest: 1, Latest: 3}, *rangeErr)
+		_, err = rpc.BlockResults(ctx, &height)
+		require.ErrorIs(err, store.ErrHeightOutOfRange, "height %d", height)
+		_, err = rpc.Commit(ctx, &height)
+		require.ErrorIs(err, store.ErrHeightOutOfRange, "height %d", height)
+		_, err = rpc.Header(ctx, &height)
+		require.ErrorIs(err, store.ErrHeightOutOfRange, "height %d", height)
+		_, err = rpc.Validators(ctx, &height, nil, nil)
+		require.ErrorIs(err, store.ErrHeightOutOfRange, "height %d", height)
+	}
+
+	height := int64(3)
+	_, err := rpc.Block(ctx, &height)
+	require.NoError(err)
+}
+
 func TestGetCommit(t *testing.T) {
 	chainID := "TestGetCommit"
 	require := require.New(t)
@@ -512,6 +591,7 @@ func TestGetBlockByHash(t *testing.T) {
 	header, data := types.GetRandomBlock(1, 10, chainID)
 	err := rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{})
 	require.NoError(err)
+	rpc.node.Store.SetHeight(ctx, header.Height())
 	abciBlock, err := abciconv.ToABCIBlock(header, data)
 	require.NoError(err)
 
@@ -593,6 +673,17 @@ func TestTx(t *testing.T) {
 	assert.NotNil(resTx)
 	assert.EqualValues(tx1, resTx.Tx)
 	assert.EqualValues(res.Hash, resTx.Hash)
+	assert.NoError(resTx.Proof.Proof.Verify(resTx.Proof.RootHash, tx1.Hash()))
+
+	// retries of committed transaction are rejected
+	_, err = rpc.BroadcastTxSync(ctx, tx1)
+	assert.ErrorIs(err, ErrTxCommitted)
+
+	// inclusion proof is still served after block data is pruned
+	require.NoError(rpc.node.Store.PruneBlockData(ctx, uint64(resTx.Height)))
+	prunedTx, err := rpc.Tx(ctx, res.Hash, true)
+	require.NoError(err)
+	assert.Equal(resTx.Proof, prunedTx.Proof)
 
 	tx2 := cmtypes.Tx("tx2")
 	resTx, errTx = rpc.Tx(ctx, tx2.Hash(), true)
@@ -601,6 +692,517 @@ func TestTx(t *testing.T) {
 	assert.Equal(fmt.Errorf("tx (%X) not found", tx2.Hash()), errTx)
 }
 
+func TestTxFromStore(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chainID := "TestTxFromStore"
+	_, rpc := getRPC(t, chainID)
+	ctx := context.Background()
+
+	// block is saved, but its transactions are not indexed by the indexer
+	header, data := types.GetRandomBlock(1, 2, chainID)
+	require.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+	require.NoError(rpc.node.Store.SaveBlockResponses(ctx, 1, &abci.ResponseFinalizeBlock{
+		TxResults: []*abci.ExecTxResult{{Log: "first"}, {Log: "second"}},
+	}))
+	rpc.node.Store.SetHeight(ctx, 1)
+
+	tx := cmtypes.Tx(data.Txs[1])
+	res, err := rpc.Tx(ctx, tx.Hash(), false)
+	require.NoError(err)
+	assert.EqualValues(1, res.Height)
+	assert.EqualValues(1, res.Index)
+	assert.EqualValues(tx, res.Tx)
+	assert.Equal("second", res.TxResult.Log)
+
+	search, err := rpc.TxSearch(ctx, fmt.Sprintf("tx.hash = '%X'", tx.Hash()), false, nil, nil, "asc")
+	require.NoError(err)
+	require.Len(search.Txs, 1)
+	assert.EqualValues(tx, search.Txs[0].Tx)
+	assert.Equal("second", search.Txs[0].TxResult.Log)
+
+	_, err = rpc.Tx(ctx, cmtypes.Tx("unknown").Hash(), false)
+	assert.Error(err)
+}
+
+func TestWaitForHeight(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chainID := "TestWaitForHeight"
+	_, rpc := getRPC(t, chainID)
+	ctx := context.Background()
+
+	header, data := types.GetRandomBlock(1, 0, chainID)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+		rpc.node.Store.SetHeight(ctx, 1)
+	}()
+	res, err := rpc.WaitForHeight(ctx, 1)
+	require.NoError(err)
+	assert.EqualValues(1, res.Block.Height)
+
+	_, err = rpc.WaitForHeight(ctx, 0)
+	assert.ErrorIs(err, store.ErrHeightOutOfRange)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = rpc.WaitForHeight(canceled, 2)
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestWaitForDAInclusion(t *testing.T) {
+	assert := assert.New(t)
+
+	_, rpc := getRPC(t, "TestWaitForDAInclusion")
+	ctx := context.Background()
+
+	_, err := rpc.WaitForDAInclusion(ctx, 0)
+	assert.Error(err)
+
+	// blocks are not submitted to DA, as the node is not started
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = rpc.WaitForDAInclusion(timeout, 1)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	rpc.node.readOnly = true
+	_, err = rpc.WaitForDAInclusion(ctx, 1)
+	assert.ErrorIs(err, ErrReadOnly)
+}
+
+func TestDAInclusionCertificate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chainID := "TestDAInclusionCertificate"
+	_, rpc := getRPC(t, chainID)
+	ctx := context.Background()
+
+	for h := uint64(1); h <= 2; h++ {
+		header, data := types.GetRandomBlock(h, 0, chainID)
+		require.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+		rpc.node.Store.SetHeight(ctx, h)
+	}
+	cert := &types.DAInclusionCertificate{Height: 1, DAHeight: 7, ID: []byte("id"), Commitment: []byte("commitment"), Proof: []byte("proof")}
+	require.NoError(rpc.node.Store.SaveDAInclusionCertificate(ctx, cert))
+
+	res, err := rpc.DAInclusionCertificate(ctx, 1)
+	require.NoError(err)
+	assert.Equal(cert, res)
+
+	// block is not confirmed in DA yet
+	_, err = rpc.DAInclusionCertificate(ctx, 2)
+	assert.ErrorIs(err, ds.ErrNotFound)
+
+	_, err = rpc.DAInclusionCertificate(ctx, 3)
+	assert.ErrorIs(err, store.ErrHeightOutOfRange)
+}
+
+func TestBlockByTime(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chainID := "TestBlockByTime"
+	_, rpc := getRPC(t, chainID)
+	ctx := context.Background()
+
+	start := time.Unix(1700000000, 0)
+	for h := uint64(1); h <= 3; h++ {
+		header, data := types.GetRandomBlock(h, 0, chainID)
+		header.BaseHeader.Time = uint64(start.Add(time.Duration(h) * time.Minute).UnixNano()) //nolint:gosec
+		require.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+		rpc.node.Store.SetHeight(ctx, h)
+	}
+
+	res, err := rpc.BlockByTime(ctx, start.Add(150*time.Second))
+	require.NoError(err)
+	assert.EqualValues(2, res.Block.Height)
+
+	_, err = rpc.BlockByTime(ctx, start)
+	assert.ErrorIs(err, ds.ErrNotFound)
+}
+
+func TestPreconfirmation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	mockApp := &mocks.Application{}
+	mockApp.On("InitChain", mock.Anything, mock.Anything).Return(&abci.ResponseInitChain{}, nil)
+	mockApp.On("PrepareProposal", mock.Anything, mock.Anything).Return(prepareProposalResponse).Maybe()
+	mockApp.On("ProcessProposal", mock.Anything, mock.Anything).Return(&abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil)
+	mockApp.On("Commit", mock.Anything, mock.Anything).Return(&abci.ResponseCommit{}, nil)
+	mockApp.On("CheckTx", mock.Anything, mock.Anything).Return(&abci.ResponseCheckTx{}, nil)
+	mockApp.On("FinalizeBlock", mock.Anything, mock.Anything).Return(finalizeBlockResponse)
+	key, _, _ := crypto.GenerateEd25519Key(crand.Reader)
+	genesisDoc, genesisValidatorKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestPreconfirmation")
+	signingKey, err := types.PrivKeyToSigningKey(genesisValidatorKey)
+	require.NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	node, err := newFullNode(ctx,
+		config.NodeConfig{
+			DAAddress:   MockDAAddress,
+			DANamespace: MockDANamespace,
+			Aggregator:  true,
+			BlockManagerConfig: config.BlockManagerConfig{
+				BlockTime: 200 * time.Millisecond,
+			},
+			SequencerAddress:      MockSequencerAddress,
+			PreconfirmationWindow: 10,
+		},
+		key, signingKey, proxy.NewLocalClientCreator(mockApp),
+		genesisDoc,
+		DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()),
+		test.NewFileLogger(t))
+	require.NoError(err)
+
+	rpc := NewFullClient(node)
+	startNodeWithCleanup(t, rpc.node)
+
+	tx := cmtypes.Tx("preconfirmed tx")
+	res, err := rpc.BroadcastTxPreconf(ctx, tx)
+	require.NoError(err)
+	require.NotNil(res.Preconfirmation)
+	pubKey := genesisDoc.Validators[0].PubKey
+	require.NoError(res.Preconfirmation.Verify(pubKey))
+	assert.EqualValues(tx.Hash(), res.Preconfirmation.TxHash)
+	assert.Equal(res.Preconfirmation.Height+10, res.Preconfirmation.MaxHeight)
+
+	_, err = rpc.PreconfirmationEvidence(ctx, *res.Preconfirmation)
+	assert.Error(err, "evidence can't be generated before promised height")
+
+	// preconfirmation of a transaction never submitted to the sequencer is violated
+	violated := types.Preconfirmation{
+		ChainID:   genesisDoc.ChainID,
+		TxHash:    cmtypes.Tx("never submitted").Hash(),
+		Height:    res.Preconfirmation.Height,
+		MaxHeight: res.Preconfirmation.MaxHeight,
+	}
+	require.NoError(rpc.node.blockManager.SignPreconfirmation(&violated))
+
+	require.NoError(waitForAtLeastNBlocks(rpc.node, int(res.Preconfirmation.MaxHeight), Store))
+	_, err = rpc.PreconfirmationEvidence(ctx, *res.Preconfirmation)
+	assert.ErrorIs(err, types.ErrPreconfirmationFulfilled)
+
+	evidence, err := rpc.PreconfirmationEvidence(ctx, violated)
+	require.NoError(err)
+	assert.NoError(evidence.Verify(pubKey))
+
+	violated.MaxHeight++
+	_, err = rpc.PreconfirmationEvidence(ctx, violated)
+	assert.ErrorIs(err, types.ErrPreconfirmationSignature)
+}
+
+func TestTraceTx(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chainID := "TestTraceTx"
+	_, rpc := getRPC(t, chainID)
+	ctx := context.Background()
+
+	header, data := types.GetRandomBlock(1, 3, chainID)
+	require.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+	state, err := types.NewFromGenesisDoc(rpc.node.genesis)
+	require.NoError(err)
+	// block is traced with the state before it, not the latest state. There is no record of the state before the
+	// first block, it's traced with the genesis state.
+	latest := state
+	latest.LastBlockHeight = 5
+	latest.Validators = types.GetRandomValidatorSet()
+	require.NoError(rpc.node.Store.UpdateState(ctx, latest))
+
+	tx := cmtypes.Tx(data.Txs[1])
+	require.NoError(rpc.node.TxIndexer.Index(&abci.TxResult{
+		Height: 1,
+		Index:  1,
+		Tx:     tx,
+	}))
+
+	// tracing is disabled by default
+	res, err := rpc.TraceTx(ctx, tx.Hash())
+	assert.ErrorIs(err, ErrTxTracingDisabled)
+	assert.Nil(res)
+
+	traceApp := &mocks.Application{}
+	traceApp.On("Info", mock.Anything, mock.Anything).Return(&abci.ResponseInfo{LastBlockHeight: 0}, nil)
+	traceApp.On("FinalizeBlock", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+			if !bytes.Equal(req.NextValidatorsHash, header.Validators.Hash()) {
+				return nil, errors.New("unexpected validators")
+			}
+			txResults := make([]*abci.ExecTxResult, len(req.Txs))
+			for i, tx := range req.Txs {
+				txResults[i] = &abci.ExecTxResult{Log: string(tx), GasUsed: int64(i + 1)}
+			}
+			return &abci.ResponseFinalizeBlock{TxResults: txResults}, nil
+		},
+	)
+	rpc.node.traceClientCreator = proxy.NewLocalClientCreator(traceApp)
+
+	res, err = rpc.TraceTx(ctx, tx.Hash())
+	require.NoError(err)
+	require.NotNil(res)
+	assert.EqualValues(1, res.Height)
+	assert.EqualValues(1, res.Index)
+	assert.Equal(string(tx), res.TxResult.Log)
+	assert.EqualValues(2, res.TxResult.GasUsed)
+	traceApp.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+
+	// trace app must be at the state right before the block
+	traceApp.ExpectedCalls[0].ReturnArguments = mock.Arguments{&abci.ResponseInfo{LastBlockHeight: 1}, nil}
+	res, err = rpc.TraceTx(ctx, tx.Hash())
+	assert.Error(err)
+	assert.Nil(res)
+
+	// state before later blocks is loaded from records, which can be pruned
+	header, data = types.GetRandomBlock(2, 1, chainID)
+	require.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+	tx = cmtypes.Tx(data.Txs[0])
+	require.NoError(rpc.node.TxIndexer.Index(&abci.TxResult{
+		Height: 2,
+		Tx:     tx,
+	}))
+	res, err = rpc.TraceTx(ctx, tx.Hash())
+	assert.ErrorIs(err, ErrStatePruned)
+	assert.Nil(res)
+}
+
+func TestABCIQueryHeight(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	app, rpc := getRPC(t, "TestABCIQueryHeight")
+	app.On("Query", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *abci.RequestQuery) (*abci.ResponseQuery, error) {
+			return &abci.ResponseQuery{Height: req.Height}, nil
+		},
+	)
+	rpc.node.Store.SetHeight(ctx, 10)
+	retainHeight := make([]byte, 8)
+	binary.BigEndian.PutUint64(retainHeight, 4)
+	require.NoError(rpc.node.Store.SetMetadata(ctx, block.AppRetainHeightKey, retainHeight))
+
+	for _, height := range []int64{0, 4, 10} {
+		res, err := rpc.ABCIQueryWithOptions(ctx, "/store", nil, rpcclient.ABCIQueryOptions{Height: height})
+		require.NoError(err)
+		require.Equal(height, res.Response.Height)
+	}
+	for _, height := range []int64{-1, 3, 11} {
+		_, err := rpc.ABCIQueryWithOptions(ctx, "/store", nil, rpcclient.ABCIQueryOptions{Height: height})
+		require.ErrorIs(err, ErrQueryHeightUnavailable, "height %d", height)
+	}
+}
+
+func TestProposerPerformance(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	_, rpc := getRPC(t, "TestProposerPerformance")
+	_, err := rpc.ProposerPerformance(ctx)
+	require.ErrorIs(err, block.ErrNotProposer)
+
+	node, _ := createAggregatorWithApp(ctx, "TestProposerPerformance", getMockApplication(), 0, types.DefaultSigningKeyType, t)
+	startNodeWithCleanup(t, node)
+	require.NoError(waitForAtLeastNBlocks(node, 2, Store))
+
+	res, err := node.GetClient().(*FullClient).ProposerPerformance(ctx)
+	require.NoError(err)
+	require.Equal(block.PerformanceWindow, res.Window)
+	for _, stage := range []string{block.StageBatchFetch, block.StageExecute, block.StageSign, block.StageStore, block.StageTotal} {
+		require.Contains(res.Stages, stage)
+		require.NotZero(res.Stages[stage].Count)
+		require.LessOrEqual(res.Stages[stage].P50, res.Stages[stage].Max)
+	}
+}
+
+func TestDumpNodeState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	node, _ := createAggregatorWithApp(ctx, "TestDumpNodeState", getMockApplication(), 0, types.DefaultSigningKeyType, t)
+	startNodeWithCleanup(t, node)
+	require.NoError(waitForAtLeastNBlocks(node, 2, Store))
+
+	res, err := node.GetClient().(*FullClient).DumpNodeState(ctx)
+	require.NoError(err)
+	require.True(res.Proposer)
+	require.GreaterOrEqual(res.Height, uint64(2))
+	require.GreaterOrEqual(res.Height, res.StateHeight)
+	require.LessOrEqual(res.DASubmission.LastSubmittedHeight, res.Height)
+	require.False(res.Halted)
+}
+
+func TestDashboard(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	node, _ := createAggregatorWithApp(ctx, "TestDashboard", getMockApplication(), 0, types.DefaultSigningKeyType, t)
+	startNodeWithCleanup(t, node)
+	require.NoError(waitForAtLeastNBlocks(node, 3, Store))
+
+	res, err := node.GetClient().(*FullClient).Dashboard(ctx)
+	require.NoError(err)
+	require.Equal("TestDashboard", res.ChainID)
+	require.Equal("aggregator", res.Mode)
+	require.NotEmpty(res.NodeID)
+	require.GreaterOrEqual(res.LatestHeight, uint64(3))
+	require.Equal(uint64(1), res.EarliestHeight)
+	require.Len(res.RecentBlocks, int(min(res.LatestHeight, dashboardBlocks)))
+	require.Equal(res.LatestHeight, res.RecentBlocks[0].Height)
+	require.Equal(res.LatestBlockTime, res.RecentBlocks[0].Time)
+	require.Positive(res.RecentBlocks[0].Interval)
+	require.Zero(res.RecentBlocks[len(res.RecentBlocks)-1].Interval)
+	require.Positive(res.AvgBlockInterval)
+	require.NotNil(res.Sync)
+	require.False(res.Sync.CatchingUp)
+	require.Equal(float64(1), res.Sync.Progress)
+	require.NotNil(res.DA)
+	require.LessOrEqual(res.DA.IncludedHeight, res.LatestHeight)
+	// store is kept in memory
+	require.Nil(res.Disk)
+}
+
+func TestScheduleHalt(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	node, _ := createAggregatorWithApp(ctx, "TestScheduleHalt", getMockApplication(), 0, types.DefaultSigningKeyType, t)
+	startNodeWithCleanup(t, node)
+	require.NoError(waitForAtLeastNBlocks(node, 2, Store))
+	client := node.GetClient().(*FullClient)
+
+	res, err := client.HaltStatus(ctx)
+	require.NoError(err)
+	require.Equal(&ResultHaltStatus{}, res)
+
+	_, err = client.ScheduleHalt(ctx, 1, 0)
+	require.Error(err)
+
+	haltHeight := node.(*FullNode).Store.Height() + 1
+	res, err = client.ScheduleHalt(ctx, haltHeight, 0)
+	require.NoError(err)
+	require.Equal(haltHeight, res.HaltHeight)
+	require.False(res.Halted)
+
+	require.Eventually(func() bool {
+		res, err := client.HaltStatus(ctx)
+		return err == nil && res.Halted
+	}, 5*time.Second, 50*time.Millisecond)
+	res, err = client.HaltStatus(ctx)
+	require.NoError(err)
+	require.Equal(haltHeight, res.Marker.Height)
+	require.Equal(haltHeight, node.(*FullNode).Store.Height())
+}
+
+func TestSimulateBlock(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	_, rpc := getRPC(t, "TestSimulateBlock")
+	_, err := rpc.SimulateBlock(ctx)
+	require.ErrorIs(err, block.ErrNotProposer)
+
+	app := testapp.New(testapp.WithCheckTx(func(tx []byte) *abci.ResponseCheckTx {
+		return &abci.ResponseCheckTx{GasWanted: 10}
+	}))
+	node, _ := createAggregatorWithApp(ctx, "TestSimulateBlock", app, 0, types.DefaultSigningKeyType, t)
+	startNodeWithCleanup(t, node)
+	require.NoError(waitForAtLeastNBlocks(node, 1, Store))
+	client := node.GetClient().(*FullClient)
+
+	tx := cmtypes.Tx("simulated tx")
+	_, err = client.BroadcastTxSync(ctx, tx)
+	require.NoError(err)
+
+	var res *ResultSimulateBlock
+	require.Eventually(func() bool {
+		height := node.(*FullNode).Store.Height()
+		res, err = client.SimulateBlock(ctx)
+		require.NoError(err)
+		require.GreaterOrEqual(res.Height, height+1)
+		return len(res.Txs) == 1
+	}, 5*time.Second, time.Millisecond)
+	require.Equal(cmbytes.HexBytes(tx.Hash()), res.Txs[0].Hash)
+	require.Equal(tx, res.Txs[0].Tx)
+	require.Equal(int64(10), res.GasWanted)
+	require.Contains([]string{block.SimulationSourceBatch, block.SimulationSourcePending}, res.Source)
+	require.NotZero(res.DABlobSize)
+	require.Greater(res.Size, res.DABlobSize)
+	require.Equal(da.EstimateGas(uint64(res.DABlobSize)), res.EstimatedDAGas)
+}
+
+func TestState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	node, _ := createAggregatorWithApp(ctx, "TestState", testapp.New(), 0, types.DefaultSigningKeyType, t)
+	startNodeWithCleanup(t, node)
+	require.NoError(waitForAtLeastNBlocks(node, 3, Store))
+	client := node.GetClient().(*FullClient)
+
+	latest, err := client.State(ctx, nil)
+	require.NoError(err)
+	require.GreaterOrEqual(latest.Height, int64(3))
+
+	// app hash after block is committed to in the header of the next block
+	height := int64(2)
+	res, err := client.State(ctx, &height)
+	require.NoError(err)
+	require.Equal(height, res.Height)
+	header, _, err := node.(*FullNode).Store.GetBlockData(ctx, uint64(height+1))
+	require.NoError(err)
+	require.Equal(cmbytes.HexBytes(header.AppHash), res.AppHash)
+	require.Equal(cmbytes.HexBytes(header.LastResultsHash), res.LastResultsHash)
+
+	height = latest.Height + 1000
+	_, err = client.State(ctx, &height)
+	require.Error(err)
+}
+
+func TestSubscribeTxFinality(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	node, _ := createAggregatorWithApp(ctx, "TestSubscribeTxFinality", testapp.New(), 0, types.DefaultSigningKeyType, t)
+	startNodeWithCleanup(t, node)
+	require.NoError(waitForAtLeastNBlocks(node, 1, Store))
+	client := node.GetClient().(*FullClient)
+
+	_, err := client.SubscribeTxFinality(ctx, []byte{1, 2, 3})
+	require.Error(err)
+
+	tx := cmtypes.Tx("finality tx")
+	events, err := client.SubscribeTxFinality(ctx, tx.Hash())
+	require.NoError(err)
+	_, err = client.BroadcastTxSync(ctx, tx)
+	require.NoError(err)
+
+	var stages []string
+	for ev := range events {
+		require.Equal(cmbytes.HexBytes(tx.Hash()), ev.Hash)
+		if ev.Stage != TxStageSequenced {
+			require.NotZero(ev.Height)
+		}
+		stages = append(stages, ev.Stage)
+	}
+	require.NoError(ctx.Err())
+	require.Equal([]string{TxStageSequenced, TxStageSoftBlock, TxStageDAIncluded}, stages)
+
+	// milestones already reached are reported immediately
+	events, err = client.SubscribeTxFinality(ctx, tx.Hash())
+	require.NoError(err)
+	stages = nil
+	for ev := range events {
+		stages = append(stages, ev.Stage)
+	}
+	require.Equal([]string{TxStageSequenced, TxStageSoftBlock, TxStageDAIncluded}, stages)
+}
+
 func TestUnconfirmedTxs(t *testing.T) {
 	tx1 := cmtypes.Tx("tx1")
 	tx2 := cmtypes.Tx("another tx")
@@ -974,6 +1576,11 @@ func TestStatus(t *testing.T) {
 		assert.Equal(genesisDoc.Validators[0].PubKey, resp.ValidatorInfo.PubKey)
 		assert.EqualValues(int64(1), resp.ValidatorInfo.VotingPower)
 	})
+	t.Run("GenesisHash", func(t *testing.T) {
+		genesisHash, err := types.GenesisHash(genesisDoc)
+		require.NoError(err)
+		assert.Equal(cmbytes.HexBytes(genesisHash), rpc.GenesisHash())
+	})
 	t.Run("NodeInfo", func(t *testing.T) {
 		// Changed the RPC method to get this from the genesis.
 		// specific validation
@@ -678,6 +678,8 @@ func doTestMaxPending(maxPending uint64, t *testing.T) {
 
 	// make sure mock DA is not accepting any submissions
 	mockDA.On("MaxBlobSize", mock.Anything).Return(uint64(123456789), nil)
+	// DA is searched for blobs of failed submissions before they are submitted again
+	mockDA.On("GetIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	mockDA.On("Submit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("DA not available"))
 
 	dalc := da.NewDAClient(mockDA, 1234, 5678, goDA.Namespace(MockDANamespace), nil, log.NewNopLogger())
@@ -1066,7 +1068,8 @@ func createNode(ctx context.Context, n int, aggregator bool, isLight bool, keys
 		},
 	}
 
-	genesis := &cmtypes.GenesisDoc{ChainID: chainID, Validators: genesisValidators}
+	// genesis time is fixed, so that genesis hash is the same for all nodes
+	genesis := &cmtypes.GenesisDoc{ChainID: chainID, GenesisTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Validators: genesisValidators}
 	// TODO: need to investigate why this needs to be done for light nodes
 	genesis.InitialHeight = 1
 	node, err := NewNode(
@@ -1,11 +1,17 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 	"time"
@@ -13,10 +19,12 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmconfig "github.com/cometbft/cometbft/config"
 	cmcrypto "github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/libs/log"
 	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/proxy"
 	cmtypes "github.com/cometbft/cometbft/types"
 
+	dsq "github.com/ipfs/go-datastore/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -30,6 +38,11 @@ import (
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
 	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/state/txindex/kv"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/store/replication"
+	"github.com/rollkit/rollkit/telemetry"
+	testapp "github.com/rollkit/rollkit/test/app"
 	test "github.com/rollkit/rollkit/test/log"
 	"github.com/rollkit/rollkit/test/mocks"
 	"github.com/rollkit/rollkit/types"
@@ -200,6 +213,8 @@ func TestPendingBlocks(t *testing.T) {
 
 	mockDA := new(damock.MockDA)
 	mockDA.On("MaxBlobSize", mock.Anything).Return(uint64(10240), nil)
+	// DA is searched for blobs of failed submissions before they are submitted again
+	mockDA.On("GetIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	mockDA.On("Submit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("DA not available"))
 
 	dac := da.NewDAClient(mockDA, 1234, -1, goDA.Namespace(MockDAAddress), nil, nil)
@@ -271,6 +286,191 @@ func TestPendingBlocks(t *testing.T) {
 
 }
 
+func TestReadOnlyNode(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	mockDA := new(damock.MockDA)
+	mockDA.On("MaxBlobSize", mock.Anything).Return(uint64(10240), nil)
+	// DA is searched for blobs of failed submissions before they are submitted again
+	mockDA.On("GetIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	mockDA.On("Submit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("DA not available"))
+	dac := da.NewDAClient(mockDA, 1234, -1, goDA.Namespace(MockDAAddress), nil, nil)
+	dbPath := t.TempDir()
+
+	genesis, genesisValidatorKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestReadOnlyNode")
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	newReadOnlyNode := func(genesis *cmtypes.GenesisDoc, name string) (Node, error) {
+		return NewNode(
+			ctx,
+			config.NodeConfig{
+				DBPath:   dbPath,
+				ReadOnly: true,
+			},
+			key,
+			nil,
+			proxy.NewLocalClientCreator(getMockApplication()),
+			genesis,
+			DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()),
+			test.NewFileLoggerCustom(t, test.TempLogFileName(t, name)),
+		)
+	}
+
+	aggregator, _ := createAggregatorWithPersistence(ctx, dbPath, dac, genesis, genesisValidatorKey, t)
+	require.NoError(aggregator.Start())
+	require.NoError(waitForAtLeastNBlocks(aggregator, 3, Store))
+	// store of a running node can't be opened, only its snapshot
+	_, err := newReadOnlyNode(genesis, "running")
+	require.Error(err)
+	require.NoError(aggregator.Stop())
+	height := aggregator.(*FullNode).Store.Height()
+
+	node, err := newReadOnlyNode(genesis, "")
+	require.NoError(err)
+	startNodeWithCleanup(t, node)
+
+	client := node.GetClient()
+	res, err := client.Block(ctx, nil)
+	require.NoError(err)
+	require.Equal(int64(height), res.Block.Height) //nolint:gosec
+
+	_, err = client.BroadcastTxCommit(ctx, []byte("tx"))
+	require.ErrorIs(err, ErrReadOnly)
+
+	// store is not writable
+	require.Error(node.(*FullNode).Store.SetMetadata(ctx, "key", []byte("value")))
+
+	// store was initialized by the aggregator with a different genesis
+	otherGenesis, _ := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestReadOnlyNodeOther")
+	_, err = newReadOnlyNode(otherGenesis, "other")
+	require.ErrorIs(err, ErrGenesisMismatch)
+}
+
+func TestCompleteGenesis(t *testing.T) {
+	require := require.New(t)
+
+	// genesis without genesis time is completed with current time, which must not change its hash
+	genesis, _ := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestCompleteGenesis")
+	genesis.GenesisTime = time.Time{}
+	again := *genesis
+	hash, err := completeGenesis(genesis)
+	require.NoError(err)
+	require.False(genesis.GenesisTime.IsZero())
+	time.Sleep(time.Millisecond)
+	sameHash, err := completeGenesis(&again)
+	require.NoError(err)
+	require.NotEqual(genesis.GenesisTime, again.GenesisTime)
+	require.Equal(hash, sameHash)
+}
+
+func TestPersistQueues(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dbPath := t.TempDir()
+	genesis, genesisValidatorKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestPersistQueues")
+	newNode := func() *FullNode {
+		node, _ := createAggregatorWithPersistence(ctx, dbPath, getMockDA(t), genesis, genesisValidatorKey, t)
+		fullNode := node.(*FullNode)
+		fullNode.nodeConfig.PersistQueues = true
+		return fullNode
+	}
+	closeNode := func(node *FullNode) {
+		require.NoError(node.proxyApp.Stop())
+		require.NoError(node.Store.Close())
+	}
+
+	// graceful shutdown
+	node := newNode()
+	require.NoError(node.Start())
+	require.NoError(waitForAtLeastNBlocks(node, 3, Store))
+	require.NoError(node.Stop())
+
+	node = newNode()
+	require.NoError(node.recoverQueues(ctx))
+	require.NoError(node.Mempool.CheckTx(cmtypes.Tx("pending tx"), nil, mempool.TxInfo{}))
+	require.NoError(node.persistQueues(ctx))
+	closeNode(node)
+
+	// mempool is restored after graceful shutdown
+	node = newNode()
+	require.NoError(node.recoverQueues(ctx))
+	require.Equal(1, node.Mempool.Size())
+	closeNode(node)
+
+	// store integrity is checked after unclean shutdown
+	node = newNode()
+	require.NoError(node.recoverQueues(ctx))
+	require.Equal(0, node.Mempool.Size())
+	closeNode(node)
+}
+
+func TestReplicatedReadOnlyNode(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	addr := listener.Addr().String()
+	require.NoError(listener.Close())
+
+	genesis, genesisValidatorKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestReplicatedReadOnlyNode")
+	primary, _ := createAggregatorWithPersistence(ctx, t.TempDir(), getMockDA(t), genesis, genesisValidatorKey, t)
+	primary.(*FullNode).replicationSrv = replication.NewServer(primary.(*FullNode).Store, addr, log.TestingLogger())
+	startNodeWithCleanup(t, primary)
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	follower, err := NewNode(
+		ctx,
+		config.NodeConfig{
+			ReadOnly:      true,
+			ReplicateFrom: addr,
+		},
+		key,
+		nil,
+		proxy.NewLocalClientCreator(getMockApplication()),
+		genesis,
+		DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()),
+		test.NewFileLoggerCustom(t, test.TempLogFileName(t, "")),
+	)
+	require.NoError(err)
+	startNodeWithCleanup(t, follower)
+
+	require.Eventually(func() bool { return follower.(*FullNode).Store.Height() >= 3 }, 5*time.Second, 50*time.Millisecond)
+	res, err := follower.GetClient().Block(ctx, nil)
+	require.NoError(err)
+	primaryRes, err := primary.GetClient().Block(ctx, &res.Block.Height)
+	require.NoError(err)
+	require.Equal(primaryRes.BlockID.Hash, res.BlockID.Hash)
+
+	// replication requires read-only mode
+	_, err = NewNode(ctx, config.NodeConfig{ReplicateFrom: addr}, key, generateSingleKey(), proxy.NewLocalClientCreator(getMockApplication()), genesis,
+		DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()), log.TestingLogger())
+	require.Error(err)
+}
+
+func TestTrustedHeightConfig(t *testing.T) {
+	ctx := context.Background()
+	genesis, _ := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestTrustedHeightConfig")
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+
+	cases := []struct {
+		name string
+		conf config.NodeConfig
+	}{
+		{"missing trusted hash", config.NodeConfig{BlockManagerConfig: config.BlockManagerConfig{TrustedHeight: 10}}},
+		{"aggregator", config.NodeConfig{Aggregator: true, BlockManagerConfig: config.BlockManagerConfig{TrustedHeight: 10}, HeaderConfig: config.HeaderConfig{TrustedHash: "00"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := NewNode(ctx, c.conf, key, generateSingleKey(), proxy.NewLocalClientCreator(getMockApplication()), genesis,
+				DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()), log.TestingLogger())
+			require.Error(t, err)
+		})
+	}
+}
+
 func TestVoteExtension(t *testing.T) {
 	const voteExtensionEnableHeight = 5
 	const expectedExtension = "vote extension from height %d"
@@ -390,6 +590,95 @@ func TestVoteExtension(t *testing.T) {
 	})
 }
 
+func TestTelemetry(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	reports := make(chan telemetry.Report, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report telemetry.Report
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		reports <- report
+	}))
+	defer srv.Close()
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	genesis, genesisValidatorKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestTelemetry")
+	signingKey, err := types.PrivKeyToSigningKey(genesisValidatorKey)
+	require.NoError(err)
+	node, err := NewNode(
+		ctx,
+		config.NodeConfig{
+			DAAddress:   MockDAAddress,
+			DANamespace: MockDANamespace,
+			Aggregator:  true,
+			BlockManagerConfig: config.BlockManagerConfig{
+				BlockTime:   100 * time.Millisecond,
+				DABlockTime: 300 * time.Millisecond,
+			},
+			SequencerAddress:  MockSequencerAddress,
+			TelemetryEndpoint: srv.URL,
+			TelemetryInterval: 100 * time.Millisecond,
+		},
+		key,
+		signingKey,
+		proxy.NewLocalClientCreator(getMockApplication()),
+		genesis,
+		DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()),
+		test.NewFileLoggerCustom(t, test.TempLogFileName(t, "")),
+	)
+	require.NoError(err)
+	startNodeWithCleanup(t, node)
+
+	p2pID := node.(*FullNode).p2pClient.Host().ID().String()
+	require.Eventually(func() bool {
+		report := <-reports
+		require.Equal(telemetry.AnonymizeID("TestTelemetry", p2pID), report.NodeID)
+		require.Equal("TestTelemetry", report.ChainID)
+		require.Equal(config.Version, report.Version)
+		require.Equal("aggregator", report.Mode)
+		return report.Height >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProgrammableApp(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	app := testapp.New(testapp.WithCheckTx(func(tx []byte) *abci.ResponseCheckTx {
+		if string(tx) == "invalid" {
+			return &abci.ResponseCheckTx{Code: 1}
+		}
+		return &abci.ResponseCheckTx{}
+	}))
+	node, _ := createAggregatorWithApp(ctx, "TestProgrammableApp", app, 0, types.DefaultSigningKeyType, t)
+	startNodeWithCleanup(t, node)
+	fn := node.(*FullNode)
+
+	res, err := fn.GetClient().BroadcastTxSync(ctx, []byte("invalid"))
+	require.NoError(err)
+	require.Equal(uint32(1), res.Code)
+	_, err = fn.GetClient().BroadcastTxSync(ctx, []byte("tx1"))
+	require.NoError(err)
+
+	require.Eventually(func() bool {
+		txs := app.Txs()
+		return len(txs) == 1 && string(txs[0]) == "tx1"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// app hash stored by the node evolves with app state
+	require.Eventually(func() bool {
+		appState := app.State()
+		state, err := fn.Store.GetState(ctx)
+		require.NoError(err)
+		if uint64(appState.Height) != state.LastBlockHeight { //nolint:gosec
+			return false
+		}
+		require.Equal(types.Hash(appState.AppHash), state.AppHash)
+		return true
+	}, 5*time.Second, time.Millisecond)
+}
+
 // Create & configure node with app. Get signing key for mock functions.
 func createNodeAndApp(ctx context.Context, chainID string, voteExtensionEnableHeight int64, signingKeyType string, t *testing.T) (*mocks.Application, Node, cmcrypto.PubKey) {
 	require := require.New(t)
@@ -537,3 +826,102 @@ func verifyMempoolSize(node *FullNode, t *testing.T) {
 		return fmt.Errorf("expected size %v, got size %v", expectedSize, actualSize)
 	}))
 }
+
+func TestInitialHeightOffset(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	genesis, genesisValidatorKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, "TestInitialHeightOffset")
+	genesis.InitialHeight = 100
+	aggregator, _ := createAggregatorWithPersistence(ctx, t.TempDir(), getMockDA(t), genesis, genesisValidatorKey, t)
+	fullNode := aggregator.(*FullNode)
+	require.Equal(uint64(99), fullNode.Store.Height())
+	startNodeWithCleanup(t, aggregator)
+	require.NoError(waitForAtLeastNBlocks(aggregator, 103, Store))
+
+	// full node syncs blocks from DA
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	node, err := NewNode(
+		ctx,
+		config.NodeConfig{
+			DAAddress:   MockDAAddress,
+			DANamespace: MockDANamespace,
+			BlockManagerConfig: config.BlockManagerConfig{
+				BlockTime:   100 * time.Millisecond,
+				DABlockTime: 100 * time.Millisecond,
+			},
+			SequencerAddress: MockSequencerAddress,
+		},
+		key,
+		key,
+		proxy.NewLocalClientCreator(getMockApplication()),
+		genesis,
+		DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()),
+		test.NewFileLoggerCustom(t, test.TempLogFileName(t, "full")),
+	)
+	require.NoError(err)
+	node.(*FullNode).dalc = fullNode.dalc
+	node.(*FullNode).blockManager.SetDALC(fullNode.dalc)
+	startNodeWithCleanup(t, node)
+	require.NoError(waitForAtLeastNBlocks(node, 102, Store))
+
+	client := aggregator.GetClient()
+	_, err = client.Block(ctx, nil)
+	require.NoError(err)
+	for _, h := range []int64{100, 101} {
+		res, err := client.Block(ctx, &h)
+		require.NoError(err)
+		require.Equal(h, res.Block.Height)
+	}
+	status, err := client.Status(ctx)
+	require.NoError(err)
+	require.Equal(int64(100), status.SyncInfo.EarliestBlockHeight)
+	info, err := client.BlockchainInfo(ctx, 0, 0)
+	require.NoError(err)
+	require.Equal(int64(100), info.BlockMetas[len(info.BlockMetas)-1].Header.Height)
+	h := int64(99)
+	_, err = client.Block(ctx, &h)
+	require.Error(err)
+
+	// blocks are submitted to DA
+	require.NoError(Retry(300, 100*time.Millisecond, func() error {
+		if fullNode.blockManager.GetDAIncludedHeight() < 102 {
+			return fmt.Errorf("DA included height %d", fullNode.blockManager.GetDAIncludedHeight())
+		}
+		return nil
+	}))
+}
+
+func TestIndexerKVEncrypted(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	baseKV, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	nodeConfig := config.NodeConfig{DBEncryptionKey: hex.EncodeToString(bytes.Repeat([]byte{1}, 32))}
+	indexerKV, err := initIndexerKV(ctx, baseKV, nodeConfig)
+	require.NoError(err)
+
+	tx := []byte("secret transaction")
+	txIndexer := kv.NewTxIndex(context.Background(), indexerKV)
+	require.NoError(txIndexer.Index(&abci.TxResult{Height: 1, Tx: tx}))
+	result, err := txIndexer.Get(cmtypes.Tx(tx).Hash())
+	require.NoError(err)
+	require.Equal(tx, result.Tx)
+
+	// raw transaction isn't stored in plain text
+	results, err := baseKV.Query(context.Background(), dsq.Query{})
+	require.NoError(err)
+	entries, err := results.Rest()
+	require.NoError(err)
+	require.NotEmpty(entries)
+	for _, entry := range entries {
+		require.NotContains(string(entry.Value), string(tx))
+	}
+
+	// encrypted indexes can't be opened without the key, or with another key
+	_, err = initIndexerKV(ctx, baseKV, config.NodeConfig{})
+	require.ErrorIs(err, store.ErrEncryptionMode)
+	nodeConfig.DBEncryptionKey = hex.EncodeToString(bytes.Repeat([]byte{2}, 32))
+	_, err = initIndexerKV(ctx, baseKV, nodeConfig)
+	require.ErrorIs(err, store.ErrEncryptionMode)
+}
@@ -0,0 +1,53 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// gasPriceRegexp matches decimal amount followed by denomination, e.g. "0.025stake".
+var gasPriceRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)([a-zA-Z][a-zA-Z0-9/:._-]*)$`)
+
+// gasPrice is a price of a unit of gas in given denomination.
+type gasPrice struct {
+	amount *big.Rat
+	denom  string
+}
+
+// parseGasPrice parses gas price in format "<amount><denom>", e.g. "0.025stake". Empty string means
+// that minimum gas price is not configured, nil is returned in such case.
+func parseGasPrice(s string) (*gasPrice, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	m := gasPriceRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("invalid gas price %q, expected format is <amount><denom>, e.g. 0.025stake", s)
+	}
+	amount, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return nil, fmt.Errorf("invalid gas price amount %q", m[1])
+	}
+	return &gasPrice{amount: amount, denom: m[2]}, nil
+}
+
+// String returns gas price in the same format as accepted by parseGasPrice.
+func (p *gasPrice) String() string {
+	return strings.TrimRight(strings.TrimRight(p.amount.FloatString(18), "0"), ".") + p.denom
+}
+
+// fee returns minimum fee required for given gas, rounded up to integer amount.
+func (p *gasPrice) fee(gas int64) string {
+	if gas < 0 {
+		gas = 0
+	}
+	fee := new(big.Rat).Mul(p.amount, new(big.Rat).SetInt64(gas))
+	q, r := new(big.Int).QuoRem(fee.Num(), fee.Denom(), new(big.Int))
+	if r.Sign() > 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q.String() + p.denom
+}
@@ -0,0 +1,45 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGasPrice(t *testing.T) {
+	cases := []struct {
+		input  string
+		price  string
+		gas    int64
+		fee    string
+		hasErr bool
+	}{
+		{"", "", 0, "", false},
+		{"0.025stake", "0.025stake", 1000, "25stake", false},
+		{"0.025stake", "0.025stake", 1001, "26stake", false},
+		{"1uatom", "1uatom", 200000, "200000uatom", false},
+		{"0.10ibc/27394FB0", "0.1ibc/27394FB0", 15, "2ibc/27394FB0", false},
+		{"0stake", "0stake", 1000, "0stake", false},
+		{"stake", "", 0, "", true},
+		{"0.025", "", 0, "", true},
+		{"-1stake", "", 0, "", true},
+		{"1.stake", "", 0, "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			require := require.New(t)
+			price, err := parseGasPrice(c.input)
+			if c.hasErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			if c.price == "" {
+				require.Nil(price)
+				return
+			}
+			require.Equal(c.price, price.String())
+			require.Equal(c.fee, price.fee(c.gas))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// genesisHashKey is the metadata key of the hash of genesis the store was initialized with.
+const genesisHashKey = "genesis hash"
+
+// ErrGenesisMismatch is returned when node is started with a different genesis than its store was initialized with.
+var ErrGenesisMismatch = errors.New("genesis doesn't match the store")
+
+// completeGenesis completes defaults of genesis and returns its hash. Genesis is hashed as it was loaded, before
+// completion sets missing genesis time to current time, so that the hash is the same on every start.
+func completeGenesis(genesis *cmtypes.GenesisDoc) (types.Hash, error) {
+	hash, err := types.GenesisHash(genesis)
+	if err != nil {
+		return nil, err
+	}
+	if err := genesis.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrInvalidGenesis, err)
+	}
+	return hash, nil
+}
+
+// checkGenesisHash compares the hash of genesis with the hash recorded in the store when the node was started
+// for the first time. If the store has no hash recorded yet and it's writable, the hash is recorded.
+func checkGenesisHash(ctx context.Context, s store.Store, hash types.Hash, writable bool) error {
+	stored, err := s.GetMetadata(ctx, genesisHashKey)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return fmt.Errorf("failed to load genesis hash: %w", err)
+	}
+	if len(stored) == 0 {
+		if !writable {
+			return nil
+		}
+		return s.SetMetadata(ctx, genesisHashKey, hash)
+	}
+	if !bytes.Equal(stored, hash) {
+		return fmt.Errorf("%w: store was initialized with genesis %X, but genesis hash is %X; start the node with the original genesis file, or with a new home directory to join another chain",
+			ErrGenesisMismatch, stored, []byte(hash))
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/proxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// grpcScheme is the scheme of proxy_app addresses of applications exposing ABCI over gRPC.
+	grpcScheme = "grpc://"
+
+	// grpcEchoTimeout limits a single attempt to check that application is reachable.
+	grpcEchoTimeout = 5 * time.Second
+	// grpcEchoRetryInterval is the interval between attempts to reach application.
+	grpcEchoRetryInterval = 1 * time.Second
+)
+
+// NewClientCreator returns a ClientCreator for the given proxy_app address and ABCI transport.
+//
+// Address with grpc:// scheme (or "grpc" transport) connects to application exposing ABCI over gRPC, e.g.
+// written in another language. All ABCI connections share a pool of grpcConnections gRPC connections.
+// Otherwise, CometBFT's default client creator is used (local apps or socket transport).
+func NewClientCreator(addr, transport, dbDir string, grpcConnections int) proxy.ClientCreator {
+	switch {
+	case strings.HasPrefix(addr, grpcScheme):
+		return newGRPCClientCreator(strings.TrimPrefix(addr, grpcScheme), grpcConnections, false)
+	case transport == "grpc":
+		return newGRPCClientCreator(strings.TrimPrefix(addr, "tcp://"), grpcConnections, false)
+	default:
+		return proxy.DefaultClientCreator(addr, transport, dbDir)
+	}
+}
+
+// newTraceClientCreator returns a ClientCreator connecting to the ABCI app used for tracing, over socket or
+// gRPC (grpc:// address). Tracing fails fast if the app is unreachable.
+func newTraceClientCreator(addr string) proxy.ClientCreator {
+	if strings.HasPrefix(addr, grpcScheme) {
+		return newGRPCClientCreator(strings.TrimPrefix(addr, grpcScheme), 1, true)
+	}
+	return proxy.NewRemoteClientCreator(addr, "socket", true)
+}
+
+// grpcConnPool is a pool of gRPC connections to ABCI application, shared by ABCI clients. Connections are
+// assigned to clients in round-robin fashion. Pool is closed when the last client is released, so that
+// clients created after reconnecting start with fresh connections.
+type grpcConnPool struct {
+	target string
+	size   int
+
+	mtx   sync.Mutex
+	conns []*grpc.ClientConn
+	next  int
+	refs  int
+}
+
+// acquire returns the next connection from the pool, dialing it if necessary.
+func (p *grpcConnPool) acquire() (*grpc.ClientConn, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if len(p.conns) < p.size {
+		conn, err := grpc.NewClient(p.target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, err
+		}
+		p.conns = append(p.conns, conn)
+	}
+	conn := p.conns[p.next%len(p.conns)]
+	p.next++
+	p.refs++
+	return conn, nil
+}
+
+// release marks connection acquired by a client as no longer used.
+func (p *grpcConnPool) release() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.refs--
+	if p.refs > 0 {
+		return
+	}
+	for _, conn := range p.conns {
+		_ = conn.Close()
+	}
+	p.conns, p.next, p.refs = nil, 0, 0
+}
+
+// grpcClientCreator creates ABCI clients using pooled gRPC connections.
+type grpcClientCreator struct {
+	pool        *grpcConnPool
+	mustConnect bool
+}
+
+func newGRPCClientCreator(target string, size int, mustConnect bool) *grpcClientCreator {
+	return &grpcClientCreator{
+		pool:        &grpcConnPool{target: target, size: max(size, 1)},
+		mustConnect: mustConnect,
+	}
+}
+
+// NewABCIClient implements proxy.ClientCreator interface.
+func (c *grpcClientCreator) NewABCIClient() (abcicli.Client, error) {
+	return newGRPCClient(c.pool, c.mustConnect), nil
+}
+
+// grpcClient is ABCI client using a connection from the pool.
+//
+// Unlike in CometBFT, calls don't wait for the application to become available; client is stopped when
+// application is unreachable, so that node can reconnect and perform handshake.
+type grpcClient struct {
+	service.BaseService
+
+	pool        *grpcConnPool
+	mustConnect bool
+	client      abci.ABCIClient
+	// chReqRes dispatches responses of "async" calls to callbacks in order, needed by mempool
+	chReqRes chan *abcicli.ReqRes
+
+	mtx   sync.Mutex
+	err   error
+	resCb abcicli.Callback
+}
+
+var _ abcicli.Client = (*grpcClient)(nil)
+
+func newGRPCClient(pool *grpcConnPool, mustConnect bool) *grpcClient {
+	cli := &grpcClient{
+		pool:        pool,
+		mustConnect: mustConnect,
+		chReqRes:    make(chan *abcicli.ReqRes, 64),
+	}
+	cli.BaseService = *service.NewBaseService(nil, "grpcClient", cli)
+	return cli
+}
+
+// OnStart implements service.Service interface.
+func (cli *grpcClient) OnStart() error {
+	conn, err := cli.pool.acquire()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection to %s: %w", cli.pool.target, err)
+	}
+	cli.client = abci.NewABCIClient(conn)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), grpcEchoTimeout)
+		_, err = cli.client.Echo(ctx, &abci.RequestEcho{Message: "hello"}, grpc.WaitForReady(true))
+		cancel()
+		if err == nil {
+			break
+		}
+		if cli.mustConnect {
+			cli.pool.release()
+			return fmt.Errorf("failed to connect to ABCI application at %s: %w", cli.pool.target, err)
+		}
+		cli.Logger.Error("failed to connect to ABCI application, retrying", "addr", cli.pool.target, "error", err)
+		select {
+		case <-cli.Quit():
+			// connection was released by OnStop
+			return fmt.Errorf("failed to connect to ABCI application at %s: %w", cli.pool.target, err)
+		case <-time.After(grpcEchoRetryInterval):
+		}
+	}
+
+	go cli.dispatchResponses()
+	return nil
+}
+
+// OnStop implements service.Service interface.
+func (cli *grpcClient) OnStop() {
+	cli.pool.release()
+	close(cli.chReqRes)
+}
+
+func (cli *grpcClient) dispatchResponses() {
+	for reqRes := range cli.chReqRes {
+		cli.mtx.Lock()
+		reqRes.Done()
+		if cli.resCb != nil {
+			cli.resCb(reqRes.Request, reqRes.Response)
+		}
+		reqRes.InvokeCallback()
+		cli.mtx.Unlock()
+	}
+}
+
+// check stops the client if application is unreachable.
+func (cli *grpcClient) check(err error) error {
+	if err == nil || status.Code(err) != codes.Unavailable {
+		return err
+	}
+	if !cli.IsRunning() {
+		return err
+	}
+	cli.mtx.Lock()
+	if cli.err == nil {
+		cli.err = err
+	}
+	cli.mtx.Unlock()
+	cli.Logger.Error("stopping gRPC ABCI client", "error", err)
+	if err := cli.Stop(); err != nil && !errors.Is(err, service.ErrAlreadyStopped) {
+		cli.Logger.Error("error stopping gRPC ABCI client", "error", err)
+	}
+	return err
+}
+
+// Error implements abcicli.Client interface.
+func (cli *grpcClient) Error() error {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.err
+}
+
+// SetResponseCallback implements abcicli.Client interface.
+func (cli *grpcClient) SetResponseCallback(resCb abcicli.Callback) {
+	cli.mtx.Lock()
+	cli.resCb = resCb
+	cli.mtx.Unlock()
+}
+
+// CheckTxAsync implements abcicli.Client interface. Call is synchronous, but callbacks are invoked
+// asynchronously, in order of calls.
+func (cli *grpcClient) CheckTxAsync(ctx context.Context, req *abci.RequestCheckTx) (*abcicli.ReqRes, error) {
+	res, err := cli.client.CheckTx(ctx, req)
+	if err != nil {
+		return nil, cli.check(err)
+	}
+	reqRes := abcicli.NewReqRes(abci.ToRequestCheckTx(req))
+	reqRes.Response = abci.ToResponseCheckTx(res)
+	cli.chReqRes <- reqRes
+	return reqRes, nil
+}
+
+// Flush implements abcicli.Client interface.
+func (cli *grpcClient) Flush(ctx context.Context) error {
+	_, err := cli.client.Flush(ctx, &abci.RequestFlush{})
+	return cli.check(err)
+}
+
+// Echo implements abcicli.Client interface.
+func (cli *grpcClient) Echo(ctx context.Context, msg string) (*abci.ResponseEcho, error) {
+	res, err := cli.client.Echo(ctx, &abci.RequestEcho{Message: msg})
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) Info(ctx context.Context, req *abci.RequestInfo) (*abci.ResponseInfo, error) {
+	res, err := cli.client.Info(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := cli.client.CheckTx(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) Query(ctx context.Context, req *abci.RequestQuery) (*abci.ResponseQuery, error) {
+	res, err := cli.client.Query(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) Commit(ctx context.Context, _ *abci.RequestCommit) (*abci.ResponseCommit, error) {
+	res, err := cli.client.Commit(ctx, &abci.RequestCommit{})
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) InitChain(ctx context.Context, req *abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	res, err := cli.client.InitChain(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) ListSnapshots(ctx context.Context, req *abci.RequestListSnapshots) (*abci.ResponseListSnapshots, error) {
+	res, err := cli.client.ListSnapshots(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) OfferSnapshot(ctx context.Context, req *abci.RequestOfferSnapshot) (*abci.ResponseOfferSnapshot, error) {
+	res, err := cli.client.OfferSnapshot(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) LoadSnapshotChunk(ctx context.Context, req *abci.RequestLoadSnapshotChunk) (*abci.ResponseLoadSnapshotChunk, error) {
+	res, err := cli.client.LoadSnapshotChunk(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) ApplySnapshotChunk(ctx context.Context, req *abci.RequestApplySnapshotChunk) (*abci.ResponseApplySnapshotChunk, error) {
+	res, err := cli.client.ApplySnapshotChunk(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) PrepareProposal(ctx context.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+	res, err := cli.client.PrepareProposal(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) ProcessProposal(ctx context.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+	res, err := cli.client.ProcessProposal(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) ExtendVote(ctx context.Context, req *abci.RequestExtendVote) (*abci.ResponseExtendVote, error) {
+	res, err := cli.client.ExtendVote(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) VerifyVoteExtension(ctx context.Context, req *abci.RequestVerifyVoteExtension) (*abci.ResponseVerifyVoteExtension, error) {
+	res, err := cli.client.VerifyVoteExtension(ctx, req)
+	return res, cli.check(err)
+}
+
+func (cli *grpcClient) FinalizeBlock(ctx context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+	res, err := cli.client.FinalizeBlock(ctx, req)
+	return res, cli.check(err)
+}
@@ -0,0 +1,90 @@
+package node
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	abciserver "github.com/cometbft/cometbft/abci/server"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCClient(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	addr := l.Addr().String()
+	require.NoError(l.Close())
+
+	server := abciserver.NewGRPCServer("tcp://"+addr, abci.NewBaseApplication())
+	server.SetLogger(cmtlog.NewNopLogger())
+	require.NoError(server.Start())
+
+	creator := NewClientCreator("grpc://"+addr, "socket", "", 2)
+	require.IsType(&grpcClientCreator{}, creator)
+	var clients []abcicli.Client
+	for range appConnNames {
+		client, err := creator.NewABCIClient()
+		require.NoError(err)
+		client.SetLogger(cmtlog.NewNopLogger())
+		require.NoError(client.Start())
+		clients = append(clients, client)
+	}
+	// all clients share 2 connections
+	assert.Len(creator.(*grpcClientCreator).pool.conns, 2)
+
+	info, err := clients[2].Info(context.Background(), proxy.RequestInfo)
+	require.NoError(err)
+	assert.NotNil(info)
+
+	called := make(chan struct{})
+	clients[1].SetResponseCallback(func(req *abci.Request, res *abci.Response) {
+		assert.NotNil(res.GetCheckTx())
+		close(called)
+	})
+	_, err = clients[1].CheckTxAsync(context.Background(), &abci.RequestCheckTx{Tx: []byte("tx")})
+	require.NoError(err)
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Fatal("response callback not called")
+	}
+
+	// client is stopped when application is unreachable
+	require.NoError(server.Stop())
+	require.Eventually(func() bool {
+		_, err := clients[0].Info(context.Background(), proxy.RequestInfo)
+		return err != nil && !clients[0].IsRunning()
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.Error(clients[0].Error())
+
+	for _, client := range clients[1:] {
+		require.NoError(client.Stop())
+	}
+	assert.Empty(creator.(*grpcClientCreator).pool.conns)
+}
+
+func TestNewClientCreator(t *testing.T) {
+	cases := []struct {
+		addr      string
+		transport string
+		grpc      bool
+	}{
+		{"grpc://127.0.0.1:26658", "socket", true},
+		{"tcp://127.0.0.1:26658", "grpc", true},
+		{"tcp://127.0.0.1:26658", "socket", false},
+		{"noop", "socket", false},
+	}
+	for _, c := range cases {
+		_, ok := NewClientCreator(c.addr, c.transport, "", 1).(*grpcClientCreator)
+		assert.Equal(t, c.grpc, ok, c.addr)
+	}
+}
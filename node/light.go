@@ -42,6 +42,12 @@ func (ln *LightNode) GetClient() rpcclient.Client {
 	return ln.client
 }
 
+// SetPreviousP2PKey sets P2P key used before key rotation, announced during grace period.
+// It has to be called before the node is started.
+func (ln *LightNode) SetPreviousP2PKey(key *p2p.RotatedKey) error {
+	return ln.P2P.SetPreviousKey(key)
+}
+
 func newLightNode(
 	ctx context.Context,
 	conf config.NodeConfig,
@@ -61,7 +67,12 @@ func newLightNode(
 		}
 	}()
 
-	_, p2pMetrics, _, _, abciMetrics := metricsProvider(genesis.ChainID)
+	genesisHash, err := completeGenesis(genesis)
+	if err != nil {
+		return nil, err
+	}
+
+	_, p2pMetrics, _, _, abciMetrics, _ := metricsProvider(genesis.ChainID)
 
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
 	proxyApp := proxy.NewAppConns(clientCreator, abciMetrics)
@@ -93,6 +104,8 @@ func newLightNode(
 	}
 
 	node.P2P.SetTxValidator(node.falseValidator())
+	node.P2P.SetGenesisHash(genesisHash)
+	node.P2P.SetGossipValidationWorkers(conf.Concurrency.GossipValidationWorkers)
 
 	node.BaseService = *service.NewBaseService(logger, "LightNode", node)
 
@@ -106,6 +119,9 @@ func openDatastore(conf config.NodeConfig, logger log.Logger) (ds.TxnDatastore,
 		logger.Info("WARNING: working in in-memory mode")
 		return store.NewDefaultInMemoryKVStore()
 	}
+	if !isBadgerBackend(conf) {
+		return store.NewBackendKVStore(conf.DBBackend, conf.RootDir, conf.DBPath, "rollkit-light", 0)
+	}
 	return store.NewDefaultKVStore(conf.RootDir, conf.DBPath, "rollkit-light")
 }
 
@@ -0,0 +1,158 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"runtime/metrics"
+	"sync/atomic"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// memoryCheckInterval is the interval between checks of heap usage.
+const memoryCheckInterval = time.Second
+
+// memoryRecoveryRatio is the fraction of a limit heap usage has to drop below to leave a pressure level.
+// It prevents flapping when heap usage oscillates around the limit.
+const memoryRecoveryRatio = 0.9
+
+// heapObjectsMetric is the runtime metric of memory occupied by live and not yet swept heap objects.
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// ErrMemoryPressure is returned when request is rejected, because node is under memory pressure.
+var ErrMemoryPressure = errors.New("node is under memory pressure, try again later")
+
+// memoryPressure is the level of memory pressure.
+type memoryPressure int32
+
+const (
+	memoryPressureNone memoryPressure = iota
+	// memoryPressureSoft: new RPC subscriptions are rejected and DA prefetching is paused.
+	memoryPressureSoft
+	// memoryPressureHard: additionally, caches are shrunk and memory is returned to OS.
+	memoryPressureHard
+)
+
+func (p memoryPressure) String() string {
+	switch p {
+	case memoryPressureSoft:
+		return "soft"
+	case memoryPressureHard:
+		return "hard"
+	default:
+		return "none"
+	}
+}
+
+// memoryGovernor monitors heap usage and sheds load when it exceeds configured limits, so the node
+// degrades gracefully instead of being killed by OOM killer.
+type memoryGovernor struct {
+	softLimit uint64
+	hardLimit uint64
+
+	pressure atomic.Int32
+
+	// heapUsage returns current heap usage in bytes
+	heapUsage func() uint64
+	// onChange is called when pressure level changes
+	onChange func(memoryPressure)
+	logger   log.Logger
+}
+
+// newMemoryGovernor creates memoryGovernor with limits in bytes. 0 disables a limit.
+func newMemoryGovernor(softLimit, hardLimit uint64, onChange func(memoryPressure), logger log.Logger) *memoryGovernor {
+	return &memoryGovernor{
+		softLimit: softLimit,
+		hardLimit: hardLimit,
+		heapUsage: readHeapUsage,
+		onChange:  onChange,
+		logger:    logger,
+	}
+}
+
+// Err returns ErrMemoryPressure if new load should be rejected, nil otherwise. It's safe to call on nil governor.
+func (g *memoryGovernor) Err() error {
+	if g != nil && memoryPressure(g.pressure.Load()) > memoryPressureNone {
+		return ErrMemoryPressure
+	}
+	return nil
+}
+
+// Run checks heap usage until context is cancelled.
+func (g *memoryGovernor) Run(ctx context.Context) {
+	if g.softLimit == 0 && g.hardLimit == 0 {
+		return
+	}
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.check()
+		}
+	}
+}
+
+// check updates pressure level, depending on heap usage.
+func (g *memoryGovernor) check() {
+	usage := g.heapUsage()
+	prev := memoryPressure(g.pressure.Load())
+	level := memoryPressureNone
+	switch {
+	case exceeds(usage, g.hardLimit, prev >= memoryPressureHard):
+		level = memoryPressureHard
+	case exceeds(usage, g.softLimit, prev >= memoryPressureSoft):
+		level = memoryPressureSoft
+	}
+	g.pressure.Store(int32(level))
+
+	if level == prev {
+		return
+	}
+	if level > prev {
+		g.logger.Error("memory pressure increased, shedding load", "level", level, "heap", usage, "softLimit", g.softLimit, "hardLimit", g.hardLimit)
+	} else {
+		g.logger.Info("memory pressure decreased", "level", level, "heap", usage)
+	}
+	g.onChange(level)
+}
+
+// exceeds returns true if usage exceeds limit. If limit was already exceeded, usage has to drop below
+// memoryRecoveryRatio of limit to not exceed it.
+func exceeds(usage, limit uint64, exceeded bool) bool {
+	if limit == 0 {
+		return false
+	}
+	if exceeded {
+		return float64(usage) >= float64(limit)*memoryRecoveryRatio
+	}
+	return usage >= limit
+}
+
+// readHeapUsage returns the size of heap objects, without stopping the world like runtime.ReadMemStats.
+func readHeapUsage() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// shedLoad adjusts node to memory pressure level. New RPC subscriptions are rejected by FullClient
+// directly, see memoryGovernor.Err.
+func (n *FullNode) shedLoad(level memoryPressure) {
+	if n.blockManager != nil {
+		n.blockManager.SetLoadShedding(level >= memoryPressureSoft)
+		if level >= memoryPressureHard {
+			n.blockManager.ShrinkCaches()
+		}
+	}
+	if level >= memoryPressureHard {
+		debug.FreeOSMemory()
+	}
+}
@@ -0,0 +1,47 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryGovernor(t *testing.T) {
+	var changes []memoryPressure
+	g := newMemoryGovernor(100, 200, func(level memoryPressure) {
+		changes = append(changes, level)
+	}, log.TestingLogger())
+	usage := uint64(0)
+	g.heapUsage = func() uint64 { return usage }
+
+	steps := []struct {
+		usage uint64
+		level memoryPressure
+	}{
+		{50, memoryPressureNone},
+		{100, memoryPressureSoft},
+		// usage has to drop below 90% of limit to leave pressure level
+		{95, memoryPressureSoft},
+		{250, memoryPressureHard},
+		{185, memoryPressureHard},
+		{150, memoryPressureSoft},
+		{89, memoryPressureNone},
+		{200, memoryPressureHard},
+	}
+	for _, s := range steps {
+		usage = s.usage
+		g.check()
+		assert.Equal(t, s.level, memoryPressure(g.pressure.Load()), "usage %d", s.usage)
+		if s.level == memoryPressureNone {
+			assert.NoError(t, g.Err())
+		} else {
+			assert.ErrorIs(t, g.Err(), ErrMemoryPressure)
+		}
+	}
+	require.Equal(t, []memoryPressure{memoryPressureSoft, memoryPressureHard, memoryPressureSoft, memoryPressureNone, memoryPressureHard}, changes)
+
+	var disabled *memoryGovernor
+	assert.NoError(t, disabled.Err())
+}
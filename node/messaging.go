@@ -0,0 +1,66 @@
+package node
+
+import (
+	"context"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// ResultOutboundMessages contains messages sent to other rollups in a block, and the proof which can be relayed
+// to destination rollups.
+type ResultOutboundMessages struct {
+	Height   uint64                  `json:"height"`
+	Messages []types.OutboundMessage `json:"messages"`
+	Proof    *types.MessageProof     `json:"proof"`
+}
+
+// ResultRelayMessages contains verified messages queued for inclusion in the next blocks.
+type ResultRelayMessages struct {
+	Queued []types.InboundMessage `json:"queued"`
+}
+
+// OutboundMessages returns messages sent to other rollups in the block at given height (latest if nil), together
+// with the proof of their inclusion. Block must be included in DA.
+func (c *FullClient) OutboundMessages(ctx context.Context, heightPtr *int64) (*ResultOutboundMessages, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	height, err := c.validateHeight(heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := c.node.blockManager.OutboundMessageProof(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	_, data, err := c.node.Store.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	res := &ResultOutboundMessages{Height: height, Messages: []types.OutboundMessage{}, Proof: proof}
+	for _, tx := range data.Txs {
+		if !types.IsOutboundMessageTx(tx) {
+			continue
+		}
+		if msg, err := types.ParseOutboundMessageTx(tx); err == nil {
+			res.Messages = append(res.Messages, msg)
+		}
+	}
+	return res, nil
+}
+
+// RelayMessages verifies proof of messages sent to this rollup by the source rollup, and queues them for inclusion
+// in the next blocks. It's served by the aggregator only.
+func (c *FullClient) RelayMessages(ctx context.Context, proof types.MessageProof) (*ResultRelayMessages, error) {
+	if c.node.readOnly {
+		return nil, ErrReadOnly
+	}
+	queued, err := c.node.blockManager.RelayMessages(ctx, &proof)
+	if err != nil {
+		return nil, err
+	}
+	if queued == nil {
+		queued = []types.InboundMessage{}
+	}
+	return &ResultRelayMessages{Queued: queued}, nil
+}
@@ -11,6 +11,7 @@ import (
 	cmtypes "github.com/cometbft/cometbft/types"
 
 	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/types"
 )
 
 // Node is the interface for a rollup node
@@ -22,7 +23,7 @@ type Node interface {
 	Cancel()
 }
 
-// NewNode returns a new Full or Light Node based on the config
+// NewNode returns a new Full, Light or read-only Full Node based on the config
 func NewNode(
 	ctx context.Context,
 	conf config.NodeConfig,
@@ -33,22 +34,46 @@ func NewNode(
 	metricsProvider MetricsProvider,
 	logger log.Logger,
 ) (Node, error) {
-	if !conf.Light {
-		return newFullNode(
+	if err := types.ValidateGenesis(genesis); err != nil {
+		return nil, err
+	}
+	types.SetDecodeLimits(types.DecodeLimits{
+		MaxBytes:      conf.MaxDecodeBytes,
+		MaxTxs:        conf.MaxDecodeTxs,
+		MaxValidators: conf.MaxDecodeValidators,
+	})
+	if conf.TxHash != "" {
+		if err := types.SetTxHasherByName(conf.TxHash); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case conf.Light:
+		return newLightNode(
 			ctx,
 			conf,
 			p2pKey,
-			signingKey,
 			appClient,
 			genesis,
 			metricsProvider,
 			logger,
 		)
-	} else {
-		return newLightNode(
+	case conf.ReadOnly:
+		return newReadOnlyNode(
+			ctx,
+			conf,
+			p2pKey,
+			appClient,
+			genesis,
+			metricsProvider,
+			logger,
+		)
+	default:
+		return newFullNode(
 			ctx,
 			conf,
 			p2pKey,
+			signingKey,
 			appClient,
 			genesis,
 			metricsProvider,
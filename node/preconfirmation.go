@@ -0,0 +1,100 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrPreconfirmationsDisabled is returned when preconfirmation is requested, but preconfirmation window is not configured.
+var ErrPreconfirmationsDisabled = errors.New("preconfirmations are disabled")
+
+// ResultBroadcastTxPreconf contains the result of CheckTx and preconfirmation signed by the sequencer, if
+// transaction was accepted.
+type ResultBroadcastTxPreconf struct {
+	Code            uint32                 `json:"code"`
+	Data            cmbytes.HexBytes       `json:"data"`
+	Log             string                 `json:"log"`
+	Codespace       string                 `json:"codespace"`
+	Hash            cmbytes.HexBytes       `json:"hash"`
+	Preconfirmation *types.Preconfirmation `json:"preconfirmation,omitempty"`
+}
+
+// BroadcastTxPreconf works like BroadcastTxSync, but if transaction is accepted, it also returns preconfirmation:
+// a promise of the sequencer, signed with its key, to include transaction in a block not higher than
+// Preconfirmation.MaxHeight. It's only available on the sequencer.
+func (c *FullClient) BroadcastTxPreconf(ctx context.Context, tx cmtypes.Tx) (*ResultBroadcastTxPreconf, error) {
+	window := c.node.nodeConfig.PreconfirmationWindow
+	if window == 0 {
+		return nil, ErrPreconfirmationsDisabled
+	}
+	if c.node.blockManager == nil || !c.node.nodeConfig.Aggregator {
+		return nil, errors.New("preconfirmations are only signed by the sequencer")
+	}
+	// height is read before transaction is accepted, so the promised blocks cover all blocks which can include it
+	height := c.node.Store.Height()
+	res, err := c.BroadcastTxSync(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	result := &ResultBroadcastTxPreconf{
+		Code:      res.Code,
+		Data:      res.Data,
+		Log:       res.Log,
+		Codespace: res.Codespace,
+		Hash:      res.Hash,
+	}
+	if res.Code != abci.CodeTypeOK {
+		return result, nil
+	}
+	p := &types.Preconfirmation{
+		ChainID:   c.node.GetGenesis().ChainID,
+		TxHash:    res.Hash,
+		Height:    height,
+		MaxHeight: height + window,
+	}
+	if err := c.node.blockManager.SignPreconfirmation(p); err != nil {
+		return nil, fmt.Errorf("failed to sign preconfirmation: %w", err)
+	}
+	result.Preconfirmation = p
+	return result, nil
+}
+
+// PreconfirmationEvidence returns the evidence of violation of preconfirmation, signed by the sequencer, if
+// transaction was not included up to the promised height. The evidence contains all blocks of the promised
+// window, so it can be verified without access to the chain, see types.PreconfirmationViolation. It returns
+// types.ErrPreconfirmationFulfilled if transaction was included in time.
+func (c *FullClient) PreconfirmationEvidence(ctx context.Context, p types.Preconfirmation) (*types.PreconfirmationViolation, error) {
+	genesis := c.node.GetGenesis()
+	if len(genesis.Validators) != 1 {
+		return nil, errors.New("there should be exactly one validator in genesis")
+	}
+	if p.ChainID != genesis.ChainID {
+		return nil, fmt.Errorf("invalid chain ID %q", p.ChainID)
+	}
+	// signature is verified first, so the window was chosen by the sequencer
+	if err := p.Verify(genesis.Validators[0].PubKey); err != nil {
+		return nil, err
+	}
+	if height := c.node.Store.Height(); height < p.MaxHeight {
+		return nil, fmt.Errorf("promised height %d not reached yet, current height %d", p.MaxHeight, height)
+	}
+
+	blocks, err := c.node.Store.LoadBlockRange(ctx, p.Height+1, p.MaxHeight)
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]*types.SignedHeader, 0, len(blocks))
+	data := make([]*types.Data, 0, len(blocks))
+	for _, block := range blocks {
+		headers = append(headers, block.Header)
+		data = append(data, block.Data)
+	}
+	return types.NewPreconfirmationViolation(p, headers, data)
+}
@@ -0,0 +1,227 @@
+package node
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	proxy "github.com/cometbft/cometbft/proxy"
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/p2p"
+	blockidxkv "github.com/rollkit/rollkit/state/indexer/block/kv"
+	"github.com/rollkit/rollkit/state/txindex"
+	"github.com/rollkit/rollkit/state/txindex/kv"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/store/replication"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrReadOnly is returned by RPC methods that are not supported in read-only mode.
+var ErrReadOnly = errors.New("not supported in read-only mode")
+
+// newReadOnlyNode creates a full node working in read-only mode.
+//
+// Read-only node opens existing store without write access and serves RPC from it. It doesn't sync, produce
+// nor index blocks. Transactions submitted via RPC are checked against the app and gossiped to other nodes.
+// The store must be a snapshot of a store of another node: badger locks its directory, so a store used by
+// a running node can't be opened, and the node would not see new blocks anyway. Alternatively, if nodeConfig.ReplicateFrom is set, node opens the store for writing and keeps it up to date
+// by replicating entries from primary node. Replicated blocks are indexed, but no events are published.
+// Multiple read-only nodes can be started behind a load balancer to scale out query capacity.
+func newReadOnlyNode(
+	ctx context.Context,
+	nodeConfig config.NodeConfig,
+	p2pKey crypto.PrivKey,
+	clientCreator proxy.ClientCreator,
+	genesis *cmtypes.GenesisDoc,
+	metricsProvider MetricsProvider,
+	logger log.Logger,
+) (fn *FullNode, err error) {
+	if nodeConfig.Aggregator {
+		return nil, errors.New("aggregator can't work in read-only mode")
+	}
+	replicate := nodeConfig.ReplicateFrom != ""
+	if !replicate && nodeConfig.RootDir == "" && nodeConfig.DBPath == "" {
+		return nil, errors.New("read-only mode requires on-disk store")
+	}
+	if !replicate && !isBadgerBackend(nodeConfig) {
+		return nil, fmt.Errorf("read-only mode is not supported with %s database backend", nodeConfig.DBBackend)
+	}
+	minGasPrice, err := parseGasPrice(nodeConfig.RPCMinGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	genesisHash, err := completeGenesis(genesis)
+	if err != nil {
+		return nil, err
+	}
+
+	_, p2pMetrics, memplMetrics, smMetrics, abciMetrics, storeMetrics := metricsProvider(genesis.ChainID)
+
+	proxyApp, err := initProxyApp(clientCreator, nodeConfig, logger, abciMetrics, smMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	eventBus, err := initEventBus(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseKV ds.TxnDatastore
+	if replicate {
+		if baseKV, err = initBaseKV(nodeConfig, logger); err != nil {
+			return nil, fmt.Errorf("failed to open store: %w", err)
+		}
+	} else {
+		if baseKV, err = store.NewReadOnlyKVStore(nodeConfig.RootDir, nodeConfig.DBPath, "rollkit"); err != nil {
+			return nil, fmt.Errorf("failed to open store snapshot (stores of running nodes can't be opened, use --%s "+
+				"to follow a running node): %w", config.FlagReplicateFrom, err)
+		}
+	}
+
+	// peer data is not persisted, as store is not writable
+	p2pClient, err := p2p.NewClient(nodeConfig.P2P, p2pKey, genesis.ChainID, dssync.MutexWrap(ds.NewMapDatastore()), logger.With("module", "p2p"), p2pMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	mainKV, err := initMainKV(ctx, baseKV, nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	// snapshot opened read-only must be migrated before, e.g. by the node which wrote it
+	if replicate {
+		err = store.Migrate(ctx, mainKV, logger.With("module", "store"))
+	} else {
+		err = store.CheckSchemaVersion(ctx, mainKV)
+	}
+	if err != nil {
+		return nil, err
+	}
+	mainStore := store.New(mainKV)
+	state, err := mainStore.GetState(ctx)
+	// follower can start with empty store
+	if err != nil && !(replicate && errors.Is(err, ds.ErrNotFound)) {
+		return nil, fmt.Errorf("failed to load state from store: %w", err)
+	}
+	mainStore.SetHeight(ctx, state.LastBlockHeight)
+	// store was written by another node, which records the genesis hash
+	if err := checkGenesisHash(ctx, mainStore, genesisHash, false); err != nil {
+		return nil, err
+	}
+
+	indexerKV, err := initIndexerKV(ctx, baseKV, nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &FullNode{
+		proxyApp:      proxyApp,
+		eventBus:      eventBus,
+		genesis:       genesis,
+		genesisHash:   genesisHash,
+		nodeConfig:    nodeConfig,
+		p2pClient:     p2pClient,
+		Mempool:       initMempool(proxyApp, memplMetrics),
+		mempoolIDs:    newMempoolIDs(),
+		Store:         mainStore,
+		TxIndexer:     kv.NewTxIndex(ctx, indexerKV),
+		BlockIndexer:  blockidxkv.New(ctx, newPrefixKV(indexerKV, "block_events")),
+		indexerKV:     indexerKV,
+		storeMetrics:  storeMetrics,
+		ctx:           ctx,
+		cancel:        cancel,
+		readOnly:      true,
+		threadManager: types.NewThreadManager(),
+		minGasPrice:   minGasPrice,
+	}
+
+	node.memoryGovernor = initMemoryGovernor(nodeConfig, node.shedLoad, logger)
+
+	node.BaseService = *service.NewBaseService(logger, "Node", node)
+	node.p2pClient.SetTxValidator(node.newTxValidator(p2pMetrics))
+	node.p2pClient.SetGossipValidationWorkers(nodeConfig.Concurrency.GossipValidationWorkers)
+	node.p2pClient.SetGenesisHash(genesisHash)
+	node.client = NewFullClient(node)
+
+	if nodeConfig.ReplicationAddress != "" {
+		node.replicationSrv = replication.NewServer(mainStore, nodeConfig.ReplicationAddress, logger.With("module", "replication"))
+	}
+	if replicate {
+		node.followerConn, err = grpc.NewClient(strings.TrimPrefix(nodeConfig.ReplicateFrom, "tcp://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create replication client: %w", err)
+		}
+		node.follower = replication.NewFollower(node.followerConn, mainStore, node.indexBlock, logger.With("module", "replication"))
+	}
+
+	return node, nil
+}
+
+// indexBlock indexes block replicated from primary node, in the same way as IndexerService indexes applied blocks.
+func (n *FullNode) indexBlock(_ context.Context, header *types.SignedHeader, data *types.Data, responses *abci.ResponseFinalizeBlock) error {
+	if len(responses.TxResults) != len(data.Txs) {
+		return fmt.Errorf("number of tx results %d doesn't match number of txs %d", len(responses.TxResults), len(data.Txs))
+	}
+	height := int64(header.Height()) //nolint:gosec
+	batch := txindex.NewBatch(int64(len(data.Txs)))
+	for i, tx := range data.Txs {
+		if err := batch.Add(&abci.TxResult{
+			Height: height,
+			Index:  uint32(i), //nolint:gosec
+			Tx:     tx,
+			Result: *responses.TxResults[i],
+		}); err != nil {
+			return err
+		}
+	}
+	if err := n.BlockIndexer.Index(cmtypes.EventDataNewBlockEvents{
+		Height: height,
+		Events: responses.Events,
+		NumTxs: int64(len(data.Txs)),
+	}); err != nil {
+		return err
+	}
+	return n.TxIndexer.AddBatch(batch)
+}
+
+// daIncludedHeight returns the rollup height at which all blocks have been included in the DA.
+func (n *FullNode) daIncludedHeight(ctx context.Context) (uint64, error) {
+	if !n.readOnly {
+		return n.blockManager.GetDAIncludedHeight(), nil
+	}
+	// block manager is not running in read-only mode, use value persisted by the node that wrote the store
+	height, err := n.Store.GetMetadata(ctx, block.DAIncludedHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(height) != 8 {
+		return 0, fmt.Errorf("invalid DA included height in store, length %d", len(height))
+	}
+	return binary.BigEndian.Uint64(height), nil
+}
@@ -0,0 +1,97 @@
+package node
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/rollkit/rollkit/config"
+)
+
+// sequencerDialOptions returns gRPC dial options of the connection to sequencer. Connection is secured with TLS
+// if enabled, and authenticated with the auth token, which requires TLS.
+func sequencerDialOptions(conf config.NodeConfig) ([]grpc.DialOption, error) {
+	if !conf.SequencerTLS {
+		if conf.SequencerAuthToken != "" {
+			return nil, errors.New("sequencer auth token requires TLS")
+		}
+		if conf.SequencerTLSCAFile != "" || conf.SequencerTLSPin != "" {
+			return nil, errors.New("sequencer TLS CA file and pin require TLS")
+		}
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, nil
+	}
+
+	tlsConfig, err := sequencerTLSConfig(conf.SequencerTLSCAFile, conf.SequencerTLSPin)
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	if conf.SequencerAuthToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(conf.SequencerAuthToken)))
+	}
+	return opts, nil
+}
+
+// sequencerTLSConfig returns TLS config verifying sequencer certificate against CA certificates in caFile, or
+// system CA certificates if caFile is empty. If pin (base64 encoded SHA-256 of the certificate's public key) is
+// set, public key of the sequencer certificate must match it. With pin and without caFile, certificate chain is
+// not verified, so that sequencer can use self-signed certificate.
+func sequencerTLSConfig(caFile, pin string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile) //nolint:gosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sequencer TLS CA file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in sequencer TLS CA file %s", caFile)
+		}
+	}
+	if pin == "" {
+		return tlsConfig, nil
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(pin)
+	if err != nil || len(expected) != sha256.Size {
+		return nil, fmt.Errorf("invalid sequencer TLS pin %q, must be base64 encoded SHA-256 hash", pin)
+	}
+	if caFile == "" {
+		// chain is not verified, certificate is trusted by pin only
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+	}
+	tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("sequencer didn't present a certificate")
+		}
+		actual := sha256.Sum256(cs.PeerCertificates[0].RawSubjectPublicKeyInfo)
+		if subtle.ConstantTimeCompare(actual[:], expected) != 1 {
+			return fmt.Errorf("sequencer certificate public key %s doesn't match pin", base64.StdEncoding.EncodeToString(actual[:]))
+		}
+		return nil
+	}
+	return tlsConfig, nil
+}
+
+// tokenCredentials authenticates every request to sequencer with bearer token.
+type tokenCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
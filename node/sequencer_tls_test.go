@@ -0,0 +1,119 @@
+package node
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/rollkit/go-sequencing"
+	seqGRPC "github.com/rollkit/go-sequencing/proxy/grpc"
+	seqTest "github.com/rollkit/go-sequencing/test"
+
+	"github.com/rollkit/rollkit/config"
+)
+
+func TestSequencerTLS(t *testing.T) {
+	req := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	req.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sequencer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	req.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	req.NoError(err)
+	spki := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	pin := base64.StdEncoding.EncodeToString(spki[:])
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	req.NoError(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	tokens := make(chan []string, 10)
+	seq := seqTest.NewMultiRollupSequencer()
+	srv := seqGRPC.NewServer(seq, seq, seq,
+		grpc.Creds(credentials.NewServerTLSFromCert(&tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key})),
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			tokens <- md.Get("authorization")
+			return handler(ctx, req)
+		}))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	req.NoError(err)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	otherPin := base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	cases := []struct {
+		name    string
+		conf    config.NodeConfig
+		confErr bool
+		dialErr bool
+	}{
+		{"pin", config.NodeConfig{SequencerTLS: true, SequencerTLSPin: pin, SequencerAuthToken: "secret"}, false, false},
+		{"CA file", config.NodeConfig{SequencerTLS: true, SequencerTLSCAFile: caFile}, false, false},
+		{"CA file and pin", config.NodeConfig{SequencerTLS: true, SequencerTLSCAFile: caFile, SequencerTLSPin: pin}, false, false},
+		{"pin mismatch", config.NodeConfig{SequencerTLS: true, SequencerTLSPin: otherPin}, false, true},
+		{"CA file and pin mismatch", config.NodeConfig{SequencerTLS: true, SequencerTLSCAFile: caFile, SequencerTLSPin: otherPin}, false, true},
+		{"untrusted certificate", config.NodeConfig{SequencerTLS: true}, false, true},
+		{"plaintext", config.NodeConfig{}, false, true},
+		{"invalid pin", config.NodeConfig{SequencerTLS: true, SequencerTLSPin: "pin"}, true, false},
+		{"token without TLS", config.NodeConfig{SequencerAuthToken: "secret"}, true, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require := require.New(t)
+			opts, err := sequencerDialOptions(c.conf)
+			if c.confErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+
+			client := seqGRPC.NewClient()
+			require.NoError(client.Start(lis.Addr().String(), opts...))
+			defer func() { _ = client.Stop() }()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err = client.SubmitRollupTransaction(ctx, sequencing.SubmitRollupTransactionRequest{RollupId: []byte("rollup"), Tx: []byte("tx")})
+			if c.dialErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			token := <-tokens
+			if c.conf.SequencerAuthToken != "" {
+				assert.Equal(t, []string{"Bearer " + c.conf.SequencerAuthToken}, token)
+			} else {
+				assert.Empty(t, token)
+			}
+		})
+	}
+}
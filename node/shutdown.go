@@ -0,0 +1,107 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/mempool"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// cleanShutdownKey is the metadata key set on graceful shutdown and cleared on start, so unclean shutdown
+// can be detected.
+const cleanShutdownKey = "clean shutdown"
+
+// pendingTxsKey is the metadata key of mempool transactions persisted on graceful shutdown.
+const pendingTxsKey = "pending txs"
+
+// persistQueues saves mempool transactions not submitted to the sequencer yet and batches not included in
+// blocks yet, and marks shutdown as clean. It's called when node is stopped, after all loops are finished.
+func (n *FullNode) persistQueues(ctx context.Context) error {
+	if n.nodeConfig.Aggregator {
+		if err := n.blockManager.PersistBatchQueue(ctx); err != nil {
+			return fmt.Errorf("failed to persist batch queue: %w", err)
+		}
+	}
+	var pending pb.PendingTxs
+	for _, tx := range n.Mempool.ReapMaxTxs(-1) {
+		// submitted transactions are already queued by the sequencer
+		if n.mempoolReaper != nil && n.mempoolReaper.IsSubmitted(tx.Key()) {
+			continue
+		}
+		pending.Txs = append(pending.Txs, tx)
+	}
+	blob, err := pending.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := n.Store.SetMetadata(ctx, pendingTxsKey, blob); err != nil {
+		return fmt.Errorf("failed to persist mempool: %w", err)
+	}
+	n.Logger.Info("persisted mempool", "txs", len(pending.Txs))
+	return n.Store.SetMetadata(ctx, cleanShutdownKey, []byte{1})
+}
+
+// recoverQueues restores queues persisted on graceful shutdown. After unclean shutdown, integrity of the
+// store is checked and batches lost with the batch queue are requested from the sequencer again.
+func (n *FullNode) recoverQueues(ctx context.Context) error {
+	clean, err := n.Store.GetMetadata(ctx, cleanShutdownKey)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	// marker is cleared, so that crash of this run is detected on next start
+	if err := n.Store.SetMetadata(ctx, cleanShutdownKey, nil); err != nil {
+		return err
+	}
+
+	if len(clean) == 0 {
+		if n.Store.Height() == 0 {
+			return nil
+		}
+		n.Logger.Info("recovering from unclean shutdown")
+		if err := n.blockManager.CheckIntegrity(ctx); err != nil {
+			return fmt.Errorf("store integrity check failed: %w", err)
+		}
+		if n.nodeConfig.Aggregator {
+			return n.blockManager.RecoverBatches(ctx)
+		}
+		return nil
+	}
+
+	if n.nodeConfig.Aggregator {
+		if err := n.blockManager.RestoreBatchQueue(ctx); err != nil {
+			return fmt.Errorf("failed to restore batch queue: %w", err)
+		}
+	}
+	return n.restoreMempool(ctx)
+}
+
+// restoreMempool checks transactions persisted on graceful shutdown again and adds them to the mempool.
+func (n *FullNode) restoreMempool(ctx context.Context) error {
+	blob, err := n.Store.GetMetadata(ctx, pendingTxsKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var pending pb.PendingTxs
+	if err := pending.Unmarshal(blob); err != nil {
+		return fmt.Errorf("invalid persisted mempool: %w", err)
+	}
+	checked := 0
+	for _, tx := range pending.Txs {
+		// transactions may be invalid after restart, e.g. if they were included in a block in the meantime
+		if err := n.Mempool.CheckTx(cmtypes.Tx(tx), nil, mempool.TxInfo{}); err != nil {
+			n.Logger.Debug("failed to restore transaction", "error", err)
+			continue
+		}
+		checked++
+	}
+	n.Logger.Info("restored mempool", "checkedTxs", checked)
+	return n.Store.SetMetadata(ctx, pendingTxsKey, nil)
+}
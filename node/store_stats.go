@@ -0,0 +1,70 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rollkit/rollkit/store"
+)
+
+// statsTxIndex is the kind of data of transaction and block indexes in store statistics.
+const statsTxIndex = "tx_index"
+
+// ResultStoreStats contains usage of the store by kind of data.
+type ResultStoreStats struct {
+	*store.Stats
+	// Duration is how long it took to collect statistics.
+	Duration time.Duration `json:"duration"`
+}
+
+// storeStats collects usage of the store, including indexes, and updates store metrics.
+func (n *FullNode) storeStats(ctx context.Context) (*store.Stats, error) {
+	stats, err := n.Store.Stats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect store statistics: %w", err)
+	}
+	if n.indexerKV != nil {
+		usage, err := store.KVUsage(ctx, n.indexerKV)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect indexer statistics: %w", err)
+		}
+		stats.Add(statsTxIndex, usage)
+	}
+	if n.storeMetrics != nil {
+		n.storeMetrics.ObserveStats(stats)
+	}
+	return stats, nil
+}
+
+// storeStatsLoop periodically collects store statistics, so that they are reported as metrics.
+func (n *FullNode) storeStatsLoop(ctx context.Context) {
+	ticker := time.NewTicker(n.nodeConfig.DBStatsInterval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		if stats, err := n.storeStats(ctx); err != nil {
+			if ctx.Err() == nil {
+				n.Logger.Error("failed to collect store statistics", "error", err)
+			}
+		} else {
+			n.Logger.Debug("collected store statistics", "keys", stats.Total.Keys, "bytes", stats.Total.Bytes, "duration", time.Since(start))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StoreStats returns number of keys and approximate size of stored data by kind of data, e.g. blocks, block
+// responses, state records and indexes. It iterates over all keys, so it may take a while for large stores.
+func (c *FullClient) StoreStats(ctx context.Context) (*ResultStoreStats, error) {
+	start := time.Now()
+	stats, err := c.node.storeStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ResultStoreStats{Stats: stats, Duration: time.Since(start)}, nil
+}
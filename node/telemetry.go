@@ -0,0 +1,42 @@
+package node
+
+import (
+	"time"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/telemetry"
+)
+
+// telemetrySource returns the source of anonymized health reports of the node started at startedAt.
+func (n *FullNode) telemetrySource(startedAt time.Time) telemetry.Source {
+	mode := "full"
+	switch {
+	case n.readOnly:
+		mode = "read_only"
+	case n.nodeConfig.Aggregator:
+		mode = "aggregator"
+	}
+	nodeID := telemetry.AnonymizeID(n.genesis.ChainID, n.p2pClient.Host().ID().String())
+
+	return func() telemetry.Report {
+		height := n.Store.Height()
+		var syncLag uint64
+		// headers synced from P2P network, but not applied yet
+		if n.hSyncService != nil && !n.nodeConfig.Aggregator {
+			if synced := n.hSyncService.Store().Height(); synced > height {
+				syncLag = synced - height
+			}
+		}
+		return telemetry.Report{
+			NodeID:    nodeID,
+			ChainID:   n.genesis.ChainID,
+			Version:   config.Version,
+			Mode:      mode,
+			Height:    height,
+			Peers:     len(n.p2pClient.Host().Network().Peers()),
+			SyncLag:   syncLag,
+			Uptime:    uint64(time.Since(startedAt).Seconds()),
+			Timestamp: time.Now().UTC(),
+		}
+	}
+}
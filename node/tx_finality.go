@@ -0,0 +1,151 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// Finality milestones of a transaction, in the order they are reached. Rollkit doesn't settle blocks to
+// another chain, so DA inclusion is the final milestone.
+const (
+	// TxStageSequenced is reached when transaction is submitted to the sequencer by this node. Nodes that
+	// didn't submit the transaction report it together with TxStageSoftBlock.
+	TxStageSequenced = "sequenced"
+	// TxStageSoftBlock is reached when transaction is included in a block produced or synced by the node.
+	TxStageSoftBlock = "soft_block"
+	// TxStageDAIncluded is reached when the block with transaction is included in the DA layer.
+	TxStageDAIncluded = "da_included"
+)
+
+// txFinalityPollInterval is the interval of checking the progress of subscribed transactions.
+const txFinalityPollInterval = 200 * time.Millisecond
+
+// txFinalitySubscriptions is used to generate unique names of event bus subscribers.
+var txFinalitySubscriptions atomic.Uint64
+
+// TxFinalityEvent notifies that transaction reached a finality milestone.
+type TxFinalityEvent struct {
+	Hash  cmbytes.HexBytes `json:"hash"`
+	Stage string           `json:"stage"`
+	// Height and Index are set once transaction is included in a block.
+	Height int64  `json:"height"`
+	Index  uint32 `json:"index"`
+}
+
+// SubscribeTxFinality returns channel receiving an event for each finality milestone reached by transaction
+// with given hash (see TxStageSequenced, TxStageSoftBlock and TxStageDAIncluded), so clients don't have
+// to poll for transaction status. Milestones already reached are reported immediately, each milestone is
+// reported once, in order. Channel is closed after the final milestone, or when ctx is done.
+func (c *FullClient) SubscribeTxFinality(ctx context.Context, hash []byte) (<-chan TxFinalityEvent, error) {
+	if size := len(types.TxHash(nil)); len(hash) != size {
+		return nil, fmt.Errorf("invalid tx hash length %d, expected %d", len(hash), size)
+	}
+	if err := c.node.memoryGovernor.Err(); err != nil {
+		return nil, err
+	}
+	// events are tagged with CometBFT hash of transaction, with other hash functions only the indexer is polled
+	var sub cmtypes.Subscription
+	subscriber := fmt.Sprintf("tx-finality-%d", txFinalitySubscriptions.Add(1))
+	if types.TxHashCometBFT() {
+		q := cmquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%X'", cmtypes.EventTypeKey, cmtypes.EventTx, cmtypes.TxHashKey, hash))
+		var err error
+		if sub, err = c.EventBus.Subscribe(ctx, subscriber, q, 1); err != nil {
+			return nil, fmt.Errorf("failed to subscribe: %w", err)
+		}
+	}
+
+	out := make(chan TxFinalityEvent, 3)
+	go c.txFinalityRoutine(ctx, hash, subscriber, sub, out)
+	return out, nil
+}
+
+func (c *FullClient) txFinalityRoutine(ctx context.Context, hash []byte, subscriber string, sub cmtypes.Subscription, out chan<- TxFinalityEvent) {
+	defer close(out)
+	defer func() {
+		if err := c.EventBus.UnsubscribeAll(context.Background(), subscriber); err != nil {
+			c.Logger.Debug("failed to unsubscribe tx finality subscriber", "subscriber", subscriber, "error", err)
+		}
+	}()
+
+	ev := TxFinalityEvent{Hash: hash}
+	var sequenced, included bool
+	emit := func(stage string) bool {
+		ev.Stage = stage
+		select {
+		case out <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	// include emits all milestones up to soft block, once transaction is found in a block
+	include := func(height int64, index uint32) bool {
+		ev.Height, ev.Index = height, index
+		if !sequenced && !emit(TxStageSequenced) {
+			return false
+		}
+		sequenced, included = true, true
+		return emit(TxStageSoftBlock)
+	}
+
+	var (
+		events   <-chan cmpubsub.Message
+		canceled <-chan struct{}
+	)
+	if sub != nil {
+		events, canceled = sub.Out(), sub.Canceled()
+	}
+	ticker := time.NewTicker(txFinalityPollInterval)
+	defer ticker.Stop()
+	for {
+		if !included {
+			// reaper tracks transactions by CometBFT hash
+			if c.node.mempoolReaper != nil && !sequenced && types.TxHashCometBFT() && c.node.mempoolReaper.IsSubmitted(cmtypes.TxKey(hash)) {
+				if !emit(TxStageSequenced) {
+					return
+				}
+				sequenced = true
+			}
+			if res, err := c.node.TxIndexer.Get(hash); err == nil && res != nil {
+				if !include(res.Height, res.Index) {
+					return
+				}
+			}
+		}
+		if included {
+			daHeight, err := c.node.daIncludedHeight(ctx)
+			if err != nil {
+				c.Logger.Error("failed to get DA included height", "error", err)
+			} else if daHeight >= uint64(ev.Height) { //nolint:gosec
+				emit(TxStageDAIncluded)
+				return
+			}
+		}
+
+		select {
+		case msg := <-events:
+			if data, ok := msg.Data().(cmtypes.EventDataTx); ok && !included {
+				if !include(data.Height, data.Index) {
+					return
+				}
+			}
+		case <-canceled:
+			// indexer is still polled, events only speed up notifications
+			events, canceled = nil, nil
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		case <-c.node.ctx.Done():
+			return
+		}
+	}
+}
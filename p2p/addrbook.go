@@ -0,0 +1,190 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/core/record"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// addrBookKey is the key of peer address book persisted in datastore.
+var addrBookKey = datastore.NewKey("/p2p/addrbook")
+
+// addrBookEntry is a persisted address book entry of a known-good peer.
+type addrBookEntry struct {
+	// Record is the signed peer record (envelope), if peer provided one.
+	Record []byte `json:"record,omitempty"`
+	// AddrInfo is used if signed peer record is not available.
+	AddrInfo *peer.AddrInfo `json:"addr_info,omitempty"`
+}
+
+// trackPeerRecords collects signed peer records received from peers during identification.
+func (c *Client) trackPeerRecords(ctx context.Context) error {
+	sub, err := c.host.EventBus().Subscribe(new(event.EvtPeerIdentificationCompleted))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to peer identification events: %w", err)
+	}
+	go func() {
+		defer sub.Close() //nolint:errcheck
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				evt := e.(event.EvtPeerIdentificationCompleted)
+				if evt.SignedPeerRecord == nil {
+					continue
+				}
+				c.recordsMtx.Lock()
+				c.peerRecords[evt.Peer] = evt.SignedPeerRecord
+				c.recordsMtx.Unlock()
+			}
+		}
+	}()
+	return nil
+}
+
+// saveAddrBook persists addresses of known-good peers, so that they survive restarts.
+// Peers are known-good if they were identified (and sent signed peer record), or are connected.
+func (c *Client) saveAddrBook(ctx context.Context) error {
+	prevID, _ := c.PreviousID()
+	addrBook := make([]addrBookEntry, 0)
+	saved := make(map[peer.ID]bool)
+
+	c.recordsMtx.Lock()
+	for id, envelope := range c.peerRecords {
+		if id == prevID {
+			continue
+		}
+		blob, err := envelope.Marshal()
+		if err != nil {
+			c.recordsMtx.Unlock()
+			return fmt.Errorf("failed to marshal peer record: %w", err)
+		}
+		addrBook = append(addrBook, addrBookEntry{Record: blob})
+		saved[id] = true
+	}
+	c.recordsMtx.Unlock()
+
+	for _, id := range c.host.Network().Peers() {
+		if saved[id] || id == prevID {
+			continue
+		}
+		addrInfo := c.host.Peerstore().PeerInfo(id)
+		if len(addrInfo.Addrs) > 0 {
+			addrBook = append(addrBook, addrBookEntry{AddrInfo: &addrInfo})
+		}
+	}
+
+	blob, err := json.Marshal(addrBook)
+	if err != nil {
+		return err
+	}
+	return c.ds.Put(ctx, addrBookKey, blob)
+}
+
+// loadAddrBook restores addresses of peers known before restart, and tries to connect to them.
+// Signed peer records are verified, entries with invalid signatures are skipped.
+func (c *Client) loadAddrBook(ctx context.Context) error {
+	blob, err := c.ds.Get(ctx, addrBookKey)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var addrBook []addrBookEntry
+	if err := json.Unmarshal(blob, &addrBook); err != nil {
+		return fmt.Errorf("failed to unmarshal peer address book: %w", err)
+	}
+
+	peers := make([]peer.AddrInfo, 0, len(addrBook))
+	for _, entry := range addrBook {
+		switch {
+		case entry.Record != nil:
+			envelope, rec, err := record.ConsumeEnvelope(entry.Record, peer.PeerRecordEnvelopeDomain)
+			if err != nil {
+				c.logger.Error("invalid signed peer record in address book", "error", err)
+				continue
+			}
+			peerRecord, ok := rec.(*peer.PeerRecord)
+			if !ok {
+				c.logger.Error("unexpected record type in address book")
+				continue
+			}
+			c.host.Peerstore().AddAddrs(peerRecord.PeerID, peerRecord.Addrs, peerstore.AddressTTL)
+			// record is kept until connection attempt fails, so that it's persisted again before peer is identified
+			c.recordsMtx.Lock()
+			c.peerRecords[peerRecord.PeerID] = envelope
+			c.recordsMtx.Unlock()
+			peers = append(peers, peer.AddrInfo{ID: peerRecord.PeerID, Addrs: peerRecord.Addrs})
+		case entry.AddrInfo != nil:
+			c.host.Peerstore().AddAddrs(entry.AddrInfo.ID, entry.AddrInfo.Addrs, peerstore.AddressTTL)
+			peers = append(peers, *entry.AddrInfo)
+		}
+	}
+
+	c.logger.Debug("loaded peer address book", "peers", len(peers))
+	for _, p := range peers {
+		if p.ID == c.host.ID() {
+			continue
+		}
+		go c.reconnect(ctx, p)
+	}
+	return nil
+}
+
+// reconnect tries to connect to peer from address book. Signed record of unreachable peer is forgotten.
+func (c *Client) reconnect(ctx context.Context, p peer.AddrInfo) {
+	if err := c.host.Connect(ctx, p); err != nil {
+		c.logger.Debug("failed to reconnect to peer from address book", "peer", p.ID, "error", err)
+		c.recordsMtx.Lock()
+		delete(c.peerRecords, p.ID)
+		c.recordsMtx.Unlock()
+	}
+}
+
+// addrBookLoop persists peer address book periodically, so that it isn't lost if the node crashes.
+func (c *Client) addrBookLoop(ctx context.Context) {
+	ticker := time.NewTicker(addrBookSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.saveAddrBook(ctx); err != nil {
+				c.logger.Error("failed to save peer address book", "error", err)
+			}
+		}
+	}
+}
+
+// parseMultiaddrList parses a comma separated string of multiaddrs.
+func parseMultiaddrList(addrs string) ([]multiaddr.Multiaddr, error) {
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	parts := strings.Split(addrs, ",")
+	maddrs := make([]multiaddr.Multiaddr, 0, len(parts))
+	for _, a := range parts {
+		maddr, err := multiaddr.NewMultiaddr(a)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse address %q: %w", a, err)
+		}
+		maddrs = append(maddrs, maddr)
+	}
+	return maddrs, nil
+}
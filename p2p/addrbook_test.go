@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestAddrBook(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := test.NewFileLogger(t)
+
+	// signed peer records are disabled in mocknet
+	clients := make([]*Client, 4)
+	for i := range clients {
+		privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(err)
+		clients[i], err = NewClient(config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0"}, privKey, "1",
+			sync.MutexWrap(datastore.NewMapDatastore()), logger, NopMetrics())
+		require.NoError(err)
+		clients[i].host, err = clients[i].listen(ctx)
+		require.NoError(err)
+		defer clients[i].host.Close() //nolint:errcheck
+	}
+	c := clients[0]
+	require.NoError(c.trackPeerRecords(ctx))
+
+	// connected peer sends signed peer record
+	require.NoError(c.host.Connect(ctx, peer.AddrInfo{ID: clients[1].host.ID(), Addrs: clients[1].host.Addrs()}))
+	require.Eventually(func() bool {
+		c.recordsMtx.Lock()
+		defer c.recordsMtx.Unlock()
+		return c.peerRecords[clients[1].host.ID()] != nil
+	}, 5*time.Second, 50*time.Millisecond)
+	// peer that was never connected is not known-good
+	c.host.Peerstore().AddAddrs(clients[2].host.ID(), clients[2].host.Addrs(), peerstore.PermanentAddrTTL)
+	require.NoError(c.saveAddrBook(ctx))
+
+	blob, err := c.ds.Get(ctx, addrBookKey)
+	require.NoError(err)
+	var addrBook []addrBookEntry
+	require.NoError(json.Unmarshal(blob, &addrBook))
+	require.Len(addrBook, 1)
+	require.NotEmpty(addrBook[0].Record)
+
+	// address book is restored by a client sharing datastore
+	c2, err := NewClient(config.P2PConfig{}, c.privKey, "1", c.ds, logger, NopMetrics())
+	require.NoError(err)
+	c2.host = clients[3].host
+	require.NoError(c2.loadAddrBook(ctx))
+	require.ElementsMatch(clients[1].host.Addrs(), c2.host.Peerstore().Addrs(clients[1].host.ID()))
+	require.Empty(c2.host.Peerstore().Addrs(clients[2].host.ID()))
+	// known-good peer is reconnected
+	require.Eventually(func() bool {
+		return len(c2.host.Network().ConnsToPeer(clients[1].host.ID())) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// records with invalid signature are skipped
+	addrBook[0].Record[len(addrBook[0].Record)-1] ^= 0xff
+	blob, err = json.Marshal(addrBook)
+	require.NoError(err)
+	c3, err := NewClient(config.P2PConfig{}, c.privKey, "1", sync.MutexWrap(datastore.NewMapDatastore()), logger, NopMetrics())
+	require.NoError(err)
+	c3.host = clients[2].host
+	require.NoError(c3.ds.Put(ctx, addrBookKey, blob))
+	require.NoError(c3.loadAddrBook(ctx))
+	require.Empty(c3.host.Peerstore().Addrs(clients[1].host.ID()))
+}
+
+func TestParseMultiaddrList(t *testing.T) {
+	require := require.New(t)
+	addrs, err := parseMultiaddrList("")
+	require.NoError(err)
+	require.Empty(addrs)
+	addrs, err = parseMultiaddrList("/ip4/1.2.3.4/tcp/7676,/dns4/example.com/tcp/7676")
+	require.NoError(err)
+	require.Len(addrs, 2)
+	_, err = parseMultiaddrList("1.2.3.4:7676")
+	require.Error(err)
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cometbft/cometbft/p2p"
@@ -20,6 +21,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/record"
 	discovery "github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	discutil "github.com/libp2p/go-libp2p/p2p/discovery/util"
 	routedhost "github.com/libp2p/go-libp2p/p2p/host/routed"
@@ -40,6 +42,24 @@ const (
 
 	// txTopicSuffix is added after namespace to create pubsub topic for TX gossiping.
 	txTopicSuffix = "-tx"
+
+	// addrBookSaveInterval defines how often peer address book is persisted.
+	addrBookSaveInterval = 5 * time.Minute
+
+	// statusProtocolSuffix is added after namespace to create protocol ID of status handshake.
+	statusProtocolSuffix = "/status/1.1.0"
+
+	// statusTopicSuffix is added after namespace to create pubsub topic for status gossiping.
+	statusTopicSuffix = "-status"
+
+	// statusInterval defines how often status (block availability) is gossiped.
+	statusInterval = 10 * time.Second
+
+	// statusRequestTimeout limits duration of status handshake.
+	statusRequestTimeout = 5 * time.Second
+
+	// maxStatusSize limits size of status received from peers.
+	maxStatusSize = 4096
 )
 
 // Client is a P2P client, implemented with libp2p.
@@ -51,6 +71,7 @@ type Client struct {
 	conf    config.P2PConfig
 	chainID string
 	privKey crypto.PrivKey
+	ds      datastore.Datastore
 
 	host  host.Host
 	dht   *dht.IpfsDHT
@@ -60,6 +81,37 @@ type Client struct {
 
 	txGossiper  *Gossiper
 	txValidator GossipValidator
+	// validateWorkers is the number of workers validating gossiped messages, libp2p default is used if 0
+	validateWorkers int
+	// genesisHash is exchanged during status handshake, to disconnect peers running a different genesis
+	genesisHash []byte
+
+	// topics registered by the application and their gossipers (available after start)
+	topics         []TopicConfig
+	topicGossipers map[string]*Gossiper
+	topicsMtx      sync.Mutex
+
+	// identity used before key rotation, announced during grace period
+	prevKey       crypto.PrivKey
+	prevRotatedAt time.Time
+	prevHost      host.Host
+	prevDHT       *dht.IpfsDHT
+	prevMtx       sync.Mutex
+
+	// signed peer records received from peers, persisted in address book
+	peerRecords map[peer.ID]*record.Envelope
+	recordsMtx  sync.Mutex
+
+	// block availability of this node and advertised by peers
+	statusSources  map[string]StatusSource
+	peerStatuses   map[peer.ID]Status
+	statusGossiper *Gossiper
+	statusMtx      sync.Mutex
+
+	// misbehavior of peers, persisted in datastore; reported offenses are queued and recorded asynchronously
+	ledger    map[peer.ID]*LedgerEntry
+	ledgerMtx sync.Mutex
+	offenses  chan reportedOffense
 
 	// cancel is used to cancel context passed to libp2p functions
 	// it's required because of discovery.Advertise call
@@ -92,8 +144,15 @@ func NewClient(conf config.P2PConfig, privKey crypto.PrivKey, chainID string, ds
 		gater:   gater,
 		privKey: privKey,
 		chainID: chainID,
+		ds:      ds,
 		logger:  logger,
-		metrics: metrics,
+
+		peerRecords:   make(map[peer.ID]*record.Envelope),
+		statusSources: make(map[string]StatusSource),
+		peerStatuses:  make(map[peer.ID]Status),
+		ledger:        make(map[peer.ID]*LedgerEntry),
+		offenses:      make(chan reportedOffense, offenseQueueSize),
+		metrics:       metrics,
 	}, nil
 }
 
@@ -131,11 +190,31 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 		return err
 	}
 
+	c.logger.Debug("loading misbehavior ledger")
+	if err := c.loadMisbehaviorLedger(ctx); err != nil {
+		return err
+	}
+	go c.misbehaviorLoop(ctx)
+
+	c.logger.Debug("loading peer address book")
+	if err := c.trackPeerRecords(ctx); err != nil {
+		return err
+	}
+	if err := c.loadAddrBook(ctx); err != nil {
+		return err
+	}
+	go c.addrBookLoop(ctx)
+
 	c.logger.Debug("setting up gossiping")
 	if err := c.setupGossiping(ctx); err != nil {
 		return err
 	}
 
+	c.logger.Debug("setting up status exchange")
+	if err := c.setupStatus(ctx); err != nil {
+		return err
+	}
+
 	c.logger.Debug("setting up DHT")
 	if err := c.setupDHT(ctx); err != nil {
 		return err
@@ -146,7 +225,7 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 		return err
 	}
 
-	return nil
+	return c.startPreviousIdentity(ctx)
 }
 
 // Close gently stops Client.
@@ -154,7 +233,11 @@ func (c *Client) Close() error {
 	c.cancel()
 
 	return errors.Join(
+		c.saveAddrBook(context.Background()),
+		c.closePreviousIdentity(),
 		c.txGossiper.Close(),
+		c.statusGossiper.Close(),
+		c.closeTopics(),
 		c.dht.Close(),
 		c.host.Close(),
 	)
@@ -171,6 +254,19 @@ func (c *Client) SetTxValidator(val GossipValidator) {
 	c.txValidator = val
 }
 
+// SetGossipValidationWorkers sets the number of workers validating messages gossiped by peers, on all topics.
+// It must be called before Start.
+func (c *Client) SetGossipValidationWorkers(n int) {
+	c.validateWorkers = n
+}
+
+// SetGenesisHash sets the hash of genesis of the chain (see types.GenesisHash), sent to peers during status
+// handshake. Peers advertising a different hash are disconnected. Gossip topics are bound to the hash, see
+// GossipNamespace. It must be called before Start.
+func (c *Client) SetGenesisHash(hash []byte) {
+	c.genesisHash = hash
+}
+
 // Addrs returns listen addresses of Client.
 func (c *Client) Addrs() []multiaddr.Multiaddr {
 	return c.host.Addrs()
@@ -248,7 +344,17 @@ func (c *Client) listen(ctx context.Context) (host.Host, error) {
 		return nil, err
 	}
 
-	return libp2p.New(libp2p.ListenAddrs(maddr), libp2p.Identity(c.privKey), libp2p.ConnectionGater(c.gater))
+	announceAddrs, err := parseMultiaddrList(c.conf.AnnounceAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("invalid announce addresses: %w", err)
+	}
+	options := []libp2p.Option{libp2p.ListenAddrs(maddr), libp2p.Identity(c.privKey), libp2p.ConnectionGater(c.gater)}
+	if len(announceAddrs) > 0 {
+		options = append(options, libp2p.AddrsFactory(func([]multiaddr.Multiaddr) []multiaddr.Multiaddr {
+			return announceAddrs
+		}))
+	}
+	return libp2p.New(options...)
 }
 
 func (c *Client) setupDHT(ctx context.Context) error {
@@ -353,18 +459,28 @@ func (c *Client) tryConnect(ctx context.Context, peer peer.AddrInfo) {
 
 func (c *Client) setupGossiping(ctx context.Context) error {
 	var err error
-	c.ps, err = pubsub.NewGossipSub(ctx, c.host)
+	opts := []pubsub.Option{
+		// messages are signed with the key of the originating node, and messages with missing or invalid
+		// signature are rejected before validators run
+		pubsub.WithMessageSignaturePolicy(pubsub.StrictSign),
+		// peers relaying invalid messages or flooding this node are recorded in misbehavior ledger
+		pubsub.WithRawTracer(misbehaviorTracer{client: c}),
+	}
+	if c.validateWorkers > 0 {
+		opts = append(opts, pubsub.WithValidateWorkers(c.validateWorkers))
+	}
+	c.ps, err = pubsub.NewGossipSub(ctx, c.host, opts...)
 	if err != nil {
 		return err
 	}
 
-	c.txGossiper, err = NewGossiper(c.host, c.ps, c.getTxTopic(), c.logger, WithValidator(c.txValidator))
+	c.txGossiper, err = NewGossiper(c.host, c.ps, c.getTxTopic(), c.logger, c.withEnvelope(), WithValidator(c.txValidator))
 	if err != nil {
 		return err
 	}
 	go c.txGossiper.ProcessMessages(ctx)
 
-	return nil
+	return c.setupTopics(ctx)
 }
 
 // parseAddrInfoList parses a comma separated string of multiaddrs into a list of peer.AddrInfo structs
@@ -398,8 +514,27 @@ func (c *Client) getNamespace() string {
 	return c.chainID
 }
 
+// GossipNamespace returns prefix of gossip topics, including topics of header and block sync.
+//
+// Gossip topics are bound to chain identity: chain ID and genesis hash (if set with SetGenesisHash). Messages
+// published in topics of other networks sharing libp2p infrastructure (e.g. with the same chain ID but different
+// genesis) are never delivered. Nodes with the genesis hash set don't exchange gossip with nodes using topics
+// bound to chain ID only.
+func (c *Client) GossipNamespace() string {
+	if len(c.genesisHash) == 0 {
+		return c.chainID
+	}
+	return c.chainID + "-" + hex.EncodeToString(c.genesisHash)
+}
+
+// withEnvelope returns option of gossipers wrapping messages in envelope with chain ID and genesis hash, which is
+// checked by topic validators, so that messages relayed from topics of other networks are rejected.
+func (c *Client) withEnvelope() GossiperOption {
+	return WithEnvelope(c.chainID, c.genesisHash)
+}
+
 func (c *Client) getTxTopic() string {
-	return c.getNamespace() + txTopicSuffix
+	return c.GossipNamespace() + txTopicSuffix
 }
 
 // -------
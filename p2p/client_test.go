@@ -18,6 +18,7 @@ import (
 
 	"github.com/rollkit/rollkit/config"
 	test "github.com/rollkit/rollkit/test/log"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
 )
 
 func TestClientStartup(t *testing.T) {
@@ -153,6 +154,48 @@ func TestGossiping(t *testing.T) {
 	wg.Wait()
 }
 
+func TestGossipNamespace(t *testing.T) {
+	require := require.New(t)
+	privKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	client, err := NewClient(config.P2PConfig{}, privKey, "TestChain",
+		dssync.MutexWrap(datastore.NewMapDatastore()), &test.MockLogger{}, NopMetrics())
+	require.NoError(err)
+
+	require.Equal("TestChain", client.GossipNamespace())
+	require.Equal("TestChain-tx", client.getTxTopic())
+	client.SetGenesisHash([]byte{0xab, 0xcd})
+	require.Equal("TestChain-abcd", client.GossipNamespace())
+	require.Equal("TestChain-abcd-tx", client.getTxTopic())
+	require.Equal("TestChain-abcd-status", client.getStatusTopic())
+	// peer discovery and status handshake are not bound to genesis, so that peers running a different genesis
+	// can be detected and disconnected
+	require.Equal("TestChain", client.getNamespace())
+}
+
+func TestGossipEnvelope(t *testing.T) {
+	require := require.New(t)
+	g := &Gossiper{}
+	require.NoError(WithEnvelope("TestChain", []byte{0xab, 0xcd})(g))
+
+	seal := func(chainID string, genesisHash []byte) []byte {
+		blob, err := (&pb.GossipEnvelope{ChainId: chainID, GenesisHash: genesisHash, Data: []byte("tx")}).Marshal()
+		require.NoError(err)
+		return blob
+	}
+	data, err := g.open(seal("TestChain", []byte{0xab, 0xcd}))
+	require.NoError(err)
+	require.Equal([]byte("tx"), data)
+
+	_, err = g.open(seal("OtherChain", []byte{0xab, 0xcd}))
+	require.ErrorIs(err, ErrWrongNetwork)
+	_, err = g.open(seal("TestChain", []byte{0xef}))
+	require.ErrorIs(err, ErrWrongNetwork)
+	_, err = g.open(seal("TestChain", nil))
+	require.ErrorIs(err, ErrWrongNetwork)
+	_, err = g.open([]byte{0xff, 0xff})
+	require.Error(err)
+}
+
 func TestSeedStringParsing(t *testing.T) {
 	t.Parallel()
 
@@ -1,16 +1,22 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/rollkit/rollkit/third_party/log"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
 )
 
+// ErrWrongNetwork is returned when gossiped message was published in a network with different chain ID or genesis.
+var ErrWrongNetwork = errors.New("message of another network")
+
 // GossipMessage represents message gossiped via P2P network (e.g. transaction, Block etc).
 type GossipMessage struct {
 	Data []byte
@@ -26,7 +32,24 @@ type GossiperOption func(*Gossiper) error
 // WithValidator options registers topic validator for Gossiper.
 func WithValidator(validator GossipValidator) GossiperOption {
 	return func(g *Gossiper) error {
-		return g.ps.RegisterTopicValidator(g.topic.String(), wrapValidator(validator))
+		return g.ps.RegisterTopicValidator(g.topic.String(), g.wrapValidator(validator))
+	}
+}
+
+// WithEnvelope option wraps published messages in envelope with chain ID and genesis hash. Received messages
+// without envelope, or with envelope of another network, are rejected by the topic validator.
+func WithEnvelope(chainID string, genesisHash []byte) GossiperOption {
+	return func(g *Gossiper) error {
+		g.envelope = &pb.GossipEnvelope{ChainId: chainID, GenesisHash: genesisHash}
+		return nil
+	}
+}
+
+// WithHandler option registers handler invoked for every valid message received from other peers.
+func WithHandler(handler GossipHandler) GossiperOption {
+	return func(g *Gossiper) error {
+		g.handler = handler
+		return nil
 	}
 }
 
@@ -38,6 +61,10 @@ type Gossiper struct {
 	topic *pubsub.Topic
 	sub   *pubsub.Subscription
 
+	handler GossipHandler
+	// envelope is nil if messages are not wrapped in envelope
+	envelope *pb.GossipEnvelope
+
 	logger log.Logger
 }
 
@@ -84,13 +111,35 @@ func (g *Gossiper) Close() error {
 
 // Publish publishes data to gossip topic.
 func (g *Gossiper) Publish(ctx context.Context, data []byte) error {
+	if g.envelope != nil {
+		envelope := &pb.GossipEnvelope{ChainId: g.envelope.ChainId, GenesisHash: g.envelope.GenesisHash, Data: data}
+		var err error
+		if data, err = envelope.Marshal(); err != nil {
+			return err
+		}
+	}
 	return g.topic.Publish(ctx, data)
 }
 
+// open returns data of message published in the topic, unwrapped from envelope, if messages are wrapped.
+func (g *Gossiper) open(data []byte) ([]byte, error) {
+	if g.envelope == nil {
+		return data, nil
+	}
+	var envelope pb.GossipEnvelope
+	if err := envelope.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("invalid gossip envelope: %w", err)
+	}
+	if envelope.ChainId != g.envelope.ChainId || !bytes.Equal(envelope.GenesisHash, g.envelope.GenesisHash) {
+		return nil, fmt.Errorf("%w: chain ID %q, genesis hash %X", ErrWrongNetwork, envelope.ChainId, envelope.GenesisHash)
+	}
+	return envelope.Data, nil
+}
+
 // ProcessMessages waits for messages published in the topic and execute handler.
 func (g *Gossiper) ProcessMessages(ctx context.Context) {
 	for {
-		_, err := g.sub.Next(ctx)
+		msg, err := g.sub.Next(ctx)
 		select {
 		case <-ctx.Done():
 			return
@@ -100,14 +149,30 @@ func (g *Gossiper) ProcessMessages(ctx context.Context) {
 				return
 			}
 		}
-		// Logic is handled in validator
+		// Logic is handled in validator, unless handler is registered
+		if g.handler != nil && msg.ReceivedFrom != g.ownID {
+			data, err := g.open(msg.Data)
+			if err != nil {
+				g.logger.Debug("dropping gossiped message", "from", msg.GetFrom(), "error", err)
+				continue
+			}
+			g.handler(ctx, &GossipMessage{
+				Data: data,
+				From: msg.GetFrom(),
+			})
+		}
 	}
 }
 
-func wrapValidator(validator GossipValidator) pubsub.Validator {
+func (g *Gossiper) wrapValidator(validator GossipValidator) pubsub.Validator {
 	return func(_ context.Context, _ peer.ID, msg *pubsub.Message) bool {
+		data, err := g.open(msg.Data)
+		if err != nil {
+			g.logger.Debug("rejecting gossiped message", "from", msg.GetFrom(), "error", err)
+			return false
+		}
 		return validator(&GossipMessage{
-			Data: msg.Data,
+			Data: data,
 			From: msg.GetFrom(),
 		})
 	}
@@ -30,6 +30,10 @@ type Metrics struct {
 	MessageReceiveBytesTotal metrics.Counter `metrics_labels:"message_type"`
 	// Number of bytes of each message type sent.
 	MessageSendBytesTotal metrics.Counter `metrics_labels:"message_type"`
+	// Number of peer misbehaviors of each reason.
+	Misbehaviors metrics.Counter `metrics_labels:"reason"`
+	// Number of bans of misbehaving peers.
+	PeerBans metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -83,6 +87,18 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type")).With(labelsAndValues...),
+		Misbehaviors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "misbehaviors",
+			Help:      "Number of peer misbehaviors of each reason.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		PeerBans: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_bans",
+			Help:      "Number of bans of misbehaving peers.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -96,5 +112,7 @@ func NopMetrics() *Metrics {
 		NumTxs:                   discard.NewGauge(),
 		MessageReceiveBytesTotal: discard.NewCounter(),
 		MessageSendBytesTotal:    discard.NewCounter(),
+		Misbehaviors:             discard.NewCounter(),
+		PeerBans:                 discard.NewCounter(),
 	}
 }
@@ -0,0 +1,334 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// Reasons of peer misbehavior.
+const (
+	// MisbehaviorInvalidMessage is recorded when gossiped message (transaction, header, block data or status)
+	// fails validation.
+	MisbehaviorInvalidMessage = "invalid_message"
+	// MisbehaviorSpam is recorded when peer floods this node with messages and is throttled.
+	MisbehaviorSpam = "spam"
+	// MisbehaviorProtocolViolation is recorded when peer violates P2P protocol, e.g. sends messages with invalid
+	// signatures or malformed status.
+	MisbehaviorProtocolViolation = "protocol_violation"
+)
+
+const (
+	// misbehaviorWindow is the period in which offenses are counted towards a ban.
+	misbehaviorWindow = time.Hour
+	// banScore is the score of offenses within misbehaviorWindow, at which peer is banned.
+	banScore = 100
+	// baseBanDuration is the duration of the first ban of a peer. Every following ban is twice as long.
+	baseBanDuration = 10 * time.Minute
+	// maxBanDuration limits duration of a ban.
+	maxBanDuration = 7 * 24 * time.Hour
+	// maxOffenses is the number of most recent offenses kept in the ledger for every peer.
+	maxOffenses = 50
+	// banCheckInterval is the interval of checking if bans expired.
+	banCheckInterval = time.Minute
+	// offenseQueueSize limits number of offenses waiting to be recorded, offenses are dropped if queue is full.
+	offenseQueueSize = 1024
+)
+
+// misbehaviorScores are scores of offenses, by reason. Unknown reasons (reported by the application) score as
+// invalid messages.
+var misbehaviorScores = map[string]int{
+	MisbehaviorInvalidMessage:    10,
+	MisbehaviorSpam:              5,
+	MisbehaviorProtocolViolation: 50,
+}
+
+// misbehaviorPrefix is the prefix of keys of misbehavior ledger entries persisted in datastore.
+var misbehaviorPrefix = datastore.NewKey("/p2p/misbehavior")
+
+// Offense is a recorded misbehavior of a peer.
+type Offense struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// LedgerEntry is the misbehavior record of a peer.
+type LedgerEntry struct {
+	Peer peer.ID `json:"peer"`
+	// Offenses are the most recent offenses of the peer, oldest first.
+	Offenses []Offense `json:"offenses"`
+	// TotalOffenses is the number of all offenses of the peer.
+	TotalOffenses uint64 `json:"total_offenses"`
+	// Bans is the number of times the peer was banned. Duration of bans grows with every ban.
+	Bans int `json:"bans"`
+	// BannedUntil is the expiry of the current ban, zero if peer is not banned.
+	BannedUntil time.Time `json:"banned_until,omitempty"`
+	// ScoreSince is the time of the last ban; only later offenses count towards the next ban.
+	ScoreSince time.Time `json:"score_since,omitempty"`
+}
+
+// ReportMisbehavior records misbehavior of a peer with given reason (e.g. MisbehaviorInvalidMessage) and
+// optional detail. Peers are banned when they accumulate enough offenses, see p2p.md for details.
+// Offenses are recorded asynchronously.
+func (c *Client) ReportMisbehavior(id peer.ID, reason, detail string) {
+	select {
+	case c.offenses <- reportedOffense{peer: id, offense: Offense{Time: time.Now(), Reason: reason, Detail: detail}}:
+	default:
+		c.logger.Debug("dropping peer misbehavior report, queue is full", "peer", id, "reason", reason)
+	}
+}
+
+// MisbehaviorLedger returns misbehavior records of all peers, most recently offending peers first.
+func (c *Client) MisbehaviorLedger() []LedgerEntry {
+	c.ledgerMtx.Lock()
+	defer c.ledgerMtx.Unlock()
+	entries := make([]LedgerEntry, 0, len(c.ledger))
+	for _, e := range c.ledger {
+		entry := *e
+		entry.Offenses = slices.Clone(e.Offenses)
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].lastOffense().After(entries[j].lastOffense())
+	})
+	return entries
+}
+
+type reportedOffense struct {
+	peer    peer.ID
+	offense Offense
+}
+
+func (e *LedgerEntry) lastOffense() time.Time {
+	if len(e.Offenses) == 0 {
+		return time.Time{}
+	}
+	return e.Offenses[len(e.Offenses)-1].Time
+}
+
+// score returns score of offenses committed within misbehaviorWindow before now, and after the last ban.
+func (e *LedgerEntry) score(now time.Time) int {
+	score := 0
+	for _, o := range e.Offenses {
+		if o.Time.After(now.Add(-misbehaviorWindow)) && o.Time.After(e.ScoreSince) {
+			s, ok := misbehaviorScores[o.Reason]
+			if !ok {
+				s = misbehaviorScores[MisbehaviorInvalidMessage]
+			}
+			score += s
+		}
+	}
+	return score
+}
+
+// banDuration returns duration of the next ban of the peer.
+func (e *LedgerEntry) banDuration() time.Duration {
+	d := baseBanDuration
+	for i := 0; i < e.Bans && d < maxBanDuration; i++ {
+		d *= 2
+	}
+	return min(d, maxBanDuration)
+}
+
+// loadMisbehaviorLedger restores misbehavior ledger, and blocks peers with active bans. Expired bans are lifted.
+func (c *Client) loadMisbehaviorLedger(ctx context.Context) error {
+	results, err := c.ds.Query(ctx, query.Query{Prefix: misbehaviorPrefix.String()})
+	if err != nil {
+		return fmt.Errorf("failed to query misbehavior ledger: %w", err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return fmt.Errorf("failed to load misbehavior ledger: %w", err)
+	}
+
+	c.ledgerMtx.Lock()
+	defer c.ledgerMtx.Unlock()
+	for _, r := range entries {
+		entry := new(LedgerEntry)
+		if err := json.Unmarshal(r.Value, entry); err != nil {
+			c.logger.Error("invalid misbehavior ledger entry", "key", r.Key, "error", err)
+			continue
+		}
+		c.ledger[entry.Peer] = entry
+	}
+	c.liftExpiredBans(ctx, time.Now())
+	for id, entry := range c.ledger {
+		if !entry.BannedUntil.IsZero() && !c.isAllowedPeer(id) {
+			if err := c.gater.BlockPeer(id); err != nil {
+				return fmt.Errorf("failed to block banned peer: %w", err)
+			}
+		}
+	}
+	c.logger.Debug("loaded misbehavior ledger", "peers", len(c.ledger))
+	return nil
+}
+
+// misbehaviorLoop records reported offenses and lifts expired bans.
+func (c *Client) misbehaviorLoop(ctx context.Context) {
+	ticker := time.NewTicker(banCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case r := <-c.offenses:
+			c.recordOffense(ctx, r.peer, r.offense)
+		case now := <-ticker.C:
+			c.ledgerMtx.Lock()
+			c.liftExpiredBans(ctx, now)
+			c.ledgerMtx.Unlock()
+		}
+	}
+}
+
+// recordOffense adds offense to the ledger and bans the peer if it accumulated enough offenses.
+func (c *Client) recordOffense(ctx context.Context, id peer.ID, offense Offense) {
+	c.metrics.Misbehaviors.With("reason", offense.Reason).Add(1)
+	c.ledgerMtx.Lock()
+	defer c.ledgerMtx.Unlock()
+
+	entry, ok := c.ledger[id]
+	if !ok {
+		entry = &LedgerEntry{Peer: id}
+		c.ledger[id] = entry
+	}
+	entry.Offenses = append(entry.Offenses, offense)
+	if len(entry.Offenses) > maxOffenses {
+		entry.Offenses = slices.Clone(entry.Offenses[len(entry.Offenses)-maxOffenses:])
+	}
+	entry.TotalOffenses++
+	c.logger.Debug("peer misbehavior", "peer", id, "reason", offense.Reason, "detail", offense.Detail)
+
+	if entry.BannedUntil.IsZero() && entry.score(offense.Time) >= banScore && !c.isAllowedPeer(id) {
+		c.ban(entry, offense.Time)
+	}
+	c.saveLedgerEntry(ctx, entry)
+}
+
+// ban blocks and disconnects the peer.
+func (c *Client) ban(entry *LedgerEntry, now time.Time) {
+	duration := entry.banDuration()
+	entry.Bans++
+	entry.BannedUntil = now.Add(duration)
+	entry.ScoreSince = now
+	c.metrics.PeerBans.Add(1)
+	c.logger.Info("banning misbehaving peer", "peer", entry.Peer, "duration", duration, "bans", entry.Bans)
+	if err := c.gater.BlockPeer(entry.Peer); err != nil {
+		c.logger.Error("failed to block peer", "peer", entry.Peer, "error", err)
+	}
+	if c.host != nil {
+		if err := c.host.Network().ClosePeer(entry.Peer); err != nil {
+			c.logger.Debug("failed to disconnect peer", "peer", entry.Peer, "error", err)
+		}
+	}
+}
+
+// liftExpiredBans unblocks peers which bans expired. Peers blocked in configuration stay blocked.
+// ledgerMtx must be held.
+func (c *Client) liftExpiredBans(ctx context.Context, now time.Time) {
+	for id, entry := range c.ledger {
+		if entry.BannedUntil.IsZero() || entry.BannedUntil.After(now) {
+			continue
+		}
+		entry.BannedUntil = time.Time{}
+		if !c.isBlockedPeer(id) {
+			if err := c.gater.UnblockPeer(id); err != nil {
+				c.logger.Error("failed to unblock peer", "peer", id, "error", err)
+				continue
+			}
+		}
+		c.logger.Info("ban of peer expired", "peer", id)
+		c.saveLedgerEntry(ctx, entry)
+	}
+}
+
+func (c *Client) saveLedgerEntry(ctx context.Context, entry *LedgerEntry) {
+	blob, err := json.Marshal(entry)
+	if err == nil {
+		err = c.ds.Put(ctx, misbehaviorPrefix.ChildString(entry.Peer.String()), blob)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		c.logger.Error("failed to save misbehavior ledger entry", "peer", entry.Peer, "error", err)
+	}
+}
+
+func (c *Client) isAllowedPeer(id peer.ID) bool {
+	return slices.ContainsFunc(c.parseAddrInfoList(c.conf.AllowedPeers), func(p peer.AddrInfo) bool { return p.ID == id })
+}
+
+func (c *Client) isBlockedPeer(id peer.ID) bool {
+	return slices.ContainsFunc(c.parseAddrInfoList(c.conf.BlockedPeers), func(p peer.AddrInfo) bool { return p.ID == id })
+}
+
+// misbehaviorTracer reports peers relaying invalid gossip messages, or flooding this node.
+type misbehaviorTracer struct {
+	client *Client
+}
+
+var _ pubsub.RawTracer = misbehaviorTracer{}
+
+// RejectMessage implements pubsub.RawTracer.
+func (t misbehaviorTracer) RejectMessage(msg *pubsub.Message, reason string) {
+	switch reason {
+	case pubsub.RejectValidationFailed:
+		t.client.ReportMisbehavior(msg.ReceivedFrom, MisbehaviorInvalidMessage, msg.GetTopic())
+	case pubsub.RejectInvalidSignature, pubsub.RejectMissingSignature, pubsub.RejectUnexpectedSignature,
+		pubsub.RejectUnexpectedAuthInfo:
+		t.client.ReportMisbehavior(msg.ReceivedFrom, MisbehaviorProtocolViolation, reason)
+	}
+}
+
+// ThrottlePeer implements pubsub.RawTracer.
+func (t misbehaviorTracer) ThrottlePeer(p peer.ID) {
+	t.client.ReportMisbehavior(p, MisbehaviorSpam, "throttled")
+}
+
+// AddPeer implements pubsub.RawTracer.
+func (misbehaviorTracer) AddPeer(peer.ID, protocol.ID) {}
+
+// RemovePeer implements pubsub.RawTracer.
+func (misbehaviorTracer) RemovePeer(peer.ID) {}
+
+// Join implements pubsub.RawTracer.
+func (misbehaviorTracer) Join(string) {}
+
+// Leave implements pubsub.RawTracer.
+func (misbehaviorTracer) Leave(string) {}
+
+// Graft implements pubsub.RawTracer.
+func (misbehaviorTracer) Graft(peer.ID, string) {}
+
+// Prune implements pubsub.RawTracer.
+func (misbehaviorTracer) Prune(peer.ID, string) {}
+
+// ValidateMessage implements pubsub.RawTracer.
+func (misbehaviorTracer) ValidateMessage(*pubsub.Message) {}
+
+// DeliverMessage implements pubsub.RawTracer.
+func (misbehaviorTracer) DeliverMessage(*pubsub.Message) {}
+
+// DuplicateMessage implements pubsub.RawTracer.
+func (misbehaviorTracer) DuplicateMessage(*pubsub.Message) {}
+
+// RecvRPC implements pubsub.RawTracer.
+func (misbehaviorTracer) RecvRPC(*pubsub.RPC) {}
+
+// SendRPC implements pubsub.RawTracer.
+func (misbehaviorTracer) SendRPC(*pubsub.RPC, peer.ID) {}
+
+// DropRPC implements pubsub.RawTracer.
+func (misbehaviorTracer) DropRPC(*pubsub.RPC, peer.ID) {}
+
+// UndeliverableMessage implements pubsub.RawTracer.
+func (misbehaviorTracer) UndeliverableMessage(*pubsub.Message) {}
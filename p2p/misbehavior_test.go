@@ -0,0 +1,88 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestMisbehaviorLedger(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	newClient := func() *Client {
+		privKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+		client, err := NewClient(config.P2PConfig{}, privKey, "TestChain", ds, &test.MockLogger{}, NopMetrics())
+		require.NoError(err)
+		require.NoError(client.loadMisbehaviorLedger(ctx))
+		return client
+	}
+	offense := func(at time.Time, reason string) Offense {
+		return Offense{Time: at, Reason: reason}
+	}
+	_, pub, _ := crypto.GenerateEd25519Key(rand.Reader)
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(err)
+
+	client := newClient()
+	now := time.Now()
+	// spam alone is not enough to ban a peer
+	client.recordOffense(ctx, id, offense(now, MisbehaviorSpam))
+	client.recordOffense(ctx, id, offense(now, MisbehaviorProtocolViolation))
+	require.Empty(client.gater.ListBlockedPeers())
+	client.recordOffense(ctx, id, offense(now, MisbehaviorProtocolViolation))
+	require.Equal([]peer.ID{id}, client.gater.ListBlockedPeers())
+
+	ledger := client.MisbehaviorLedger()
+	require.Len(ledger, 1)
+	require.Equal(id, ledger[0].Peer)
+	require.EqualValues(3, ledger[0].TotalOffenses)
+	require.Equal(1, ledger[0].Bans)
+	require.Equal(now.Add(baseBanDuration).Unix(), ledger[0].BannedUntil.Unix())
+
+	// ban is restored after restart
+	client = newClient()
+	require.Equal([]peer.ID{id}, client.gater.ListBlockedPeers())
+	require.Len(client.MisbehaviorLedger(), 1)
+
+	// ban expires, offenses committed before the ban don't count towards the next one
+	now = now.Add(baseBanDuration + time.Second)
+	client.liftExpiredBans(ctx, now)
+	require.Empty(client.gater.ListBlockedPeers())
+	client.recordOffense(ctx, id, offense(now, MisbehaviorProtocolViolation))
+	require.Empty(client.gater.ListBlockedPeers())
+
+	// next ban is twice as long
+	client.recordOffense(ctx, id, offense(now, MisbehaviorProtocolViolation))
+	require.Equal([]peer.ID{id}, client.gater.ListBlockedPeers())
+	ledger = client.MisbehaviorLedger()
+	require.Equal(2, ledger[0].Bans)
+	require.Equal(now.Add(2*baseBanDuration).Unix(), ledger[0].BannedUntil.Unix())
+
+	// lifted bans stay lifted after restart
+	client = newClient()
+	require.Equal([]peer.ID{id}, client.gater.ListBlockedPeers())
+	client.liftExpiredBans(ctx, now.Add(2*baseBanDuration+time.Second))
+	client = newClient()
+	require.Empty(client.gater.ListBlockedPeers())
+	require.True(client.MisbehaviorLedger()[0].BannedUntil.IsZero())
+}
+
+func TestBanDuration(t *testing.T) {
+	entry := LedgerEntry{}
+	require.Equal(t, baseBanDuration, entry.banDuration())
+	entry.Bans = 3
+	require.Equal(t, 8*baseBanDuration, entry.banDuration())
+	entry.Bans = 100
+	require.Equal(t, maxBanDuration, entry.banDuration())
+}
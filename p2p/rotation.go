@@ -0,0 +1,173 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/libp2p/go-libp2p"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	cdiscovery "github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	discovery "github.com/libp2p/go-libp2p/p2p/discovery/routing"
+	discutil "github.com/libp2p/go-libp2p/p2p/discovery/util"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// previousNodeKeyFileName is the name of the file with P2P key used before key rotation.
+const previousNodeKeyFileName = "node_key_previous.json"
+
+// RotatedKey is the P2P key used before key rotation.
+type RotatedKey struct {
+	PrivKey   crypto.PrivKey `json:"priv_key"`
+	RotatedAt time.Time      `json:"rotated_at"`
+}
+
+// PreviousNodeKeyFile returns path of the file with P2P key used before key rotation.
+// It's stored in the same directory as node key file.
+func PreviousNodeKeyFile(nodeKeyFile string) string {
+	return filepath.Join(filepath.Dir(nodeKeyFile), previousNodeKeyFileName)
+}
+
+// RotateNodeKey replaces node key with a newly generated one, and saves the replaced key in PreviousNodeKeyFile.
+// Node has to be restarted to use the new key. Previous identity is announced during grace period after rotation.
+func RotateNodeKey(nodeKeyFile string, now time.Time) (oldID peer.ID, newID peer.ID, err error) {
+	oldKey, err := p2p.LoadNodeKey(nodeKeyFile)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load node key: %w", err)
+	}
+	if oldID, err = nodeKeyID(oldKey); err != nil {
+		return "", "", err
+	}
+
+	// previous key is saved first, so that it isn't lost if writing new key fails
+	blob, err := cmjson.Marshal(RotatedKey{PrivKey: oldKey.PrivKey, RotatedAt: now})
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(PreviousNodeKeyFile(nodeKeyFile), blob, 0o600); err != nil {
+		return "", "", fmt.Errorf("failed to save previous node key: %w", err)
+	}
+
+	newKey := &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+	if newID, err = nodeKeyID(newKey); err != nil {
+		return "", "", err
+	}
+	if err := newKey.SaveAs(nodeKeyFile); err != nil {
+		return "", "", fmt.Errorf("failed to save node key: %w", err)
+	}
+	return oldID, newID, nil
+}
+
+// LoadPreviousNodeKey loads P2P key used before key rotation. It returns nil if node key was never rotated.
+func LoadPreviousNodeKey(nodeKeyFile string) (*RotatedKey, error) {
+	blob, err := os.ReadFile(PreviousNodeKeyFile(nodeKeyFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	key := new(RotatedKey)
+	if err := cmjson.Unmarshal(blob, key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal previous node key: %w", err)
+	}
+	return key, nil
+}
+
+func nodeKeyID(nodeKey *p2p.NodeKey) (peer.ID, error) {
+	privKey, err := types.GetNodeKey(nodeKey)
+	if err != nil {
+		return "", err
+	}
+	return peer.IDFromPrivateKey(privKey)
+}
+
+// SetPreviousKey sets P2P key used before key rotation. Until the end of grace period, Client announces previous
+// identity on PreviousListenAddress, so that peers knowing only previous identity can still find the node.
+func (c *Client) SetPreviousKey(key *RotatedKey) error {
+	privKey, err := types.GetNodeKey(&p2p.NodeKey{PrivKey: key.PrivKey})
+	if err != nil {
+		return err
+	}
+	c.prevKey = privKey
+	c.prevRotatedAt = key.RotatedAt
+	return nil
+}
+
+// startPreviousIdentity starts a libp2p host with previous identity, that joins DHT and advertises the namespace
+// together with the current identity. It's closed at the end of grace period.
+func (c *Client) startPreviousIdentity(ctx context.Context) error {
+	if c.prevKey == nil || c.conf.KeyRotationGracePeriod == 0 {
+		return nil
+	}
+	expires := c.prevRotatedAt.Add(c.conf.KeyRotationGracePeriod)
+	if !time.Now().Before(expires) {
+		c.logger.Info("grace period of previous P2P identity has ended", "expired", expires)
+		return nil
+	}
+
+	maddr, err := multiaddr.NewMultiaddr(c.conf.PreviousListenAddress)
+	if err != nil {
+		return fmt.Errorf("invalid listen address of previous identity: %w", err)
+	}
+	h, err := libp2p.New(libp2p.ListenAddrs(maddr), libp2p.Identity(c.prevKey), libp2p.ConnectionGater(c.gater))
+	if err != nil {
+		return fmt.Errorf("failed to start previous identity: %w", err)
+	}
+	// peers routed to previous identity learn about the current one
+	current := peer.AddrInfo{ID: c.host.ID(), Addrs: c.host.Addrs()}
+	h.Peerstore().AddAddrs(current.ID, current.Addrs, peerstore.PermanentAddrTTL)
+	d, err := dht.New(ctx, h, dht.Mode(dht.ModeServer), dht.BootstrapPeers(append(c.parseAddrInfoList(c.conf.Seeds), current)...))
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to create DHT of previous identity: %w", err), h.Close())
+	}
+	if err := d.Bootstrap(ctx); err != nil {
+		return errors.Join(fmt.Errorf("failed to bootstrap DHT of previous identity: %w", err), d.Close(), h.Close())
+	}
+	discutil.Advertise(ctx, discovery.NewRoutingDiscovery(d), c.getNamespace(), cdiscovery.TTL(reAdvertisePeriod))
+
+	c.prevMtx.Lock()
+	c.prevHost, c.prevDHT = h, d
+	c.prevMtx.Unlock()
+	time.AfterFunc(time.Until(expires), func() {
+		c.logger.Info("grace period of previous P2P identity has ended", "id", h.ID())
+		if err := c.closePreviousIdentity(); err != nil {
+			c.logger.Error("failed to close previous identity", "error", err)
+		}
+	})
+	c.logger.Info("announcing previous P2P identity", "id", h.ID(), "addrs", h.Addrs(), "until", expires)
+	return nil
+}
+
+// closePreviousIdentity stops the host with previous identity, if it's running.
+func (c *Client) closePreviousIdentity() error {
+	c.prevMtx.Lock()
+	defer c.prevMtx.Unlock()
+	if c.prevHost == nil {
+		return nil
+	}
+	err := errors.Join(c.prevDHT.Close(), c.prevHost.Close())
+	c.prevHost, c.prevDHT = nil, nil
+	return err
+}
+
+// PreviousID returns ID of previous identity, if it's announced during grace period after key rotation.
+func (c *Client) PreviousID() (peer.ID, bool) {
+	c.prevMtx.Lock()
+	defer c.prevMtx.Unlock()
+	if c.prevHost == nil {
+		return "", false
+	}
+	return c.prevHost.ID(), true
+}
@@ -0,0 +1,82 @@
+package p2p
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestRotateNodeKey(t *testing.T) {
+	require := require.New(t)
+	nodeKeyFile := filepath.Join(t.TempDir(), "node_key.json")
+
+	_, _, err := RotateNodeKey(nodeKeyFile, time.Now())
+	require.Error(err)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(nodeKeyFile)
+	require.NoError(err)
+	prev, err := LoadPreviousNodeKey(nodeKeyFile)
+	require.NoError(err)
+	require.Nil(prev)
+
+	rotatedAt := time.Unix(1700000000, 0).UTC()
+	oldID, newID, err := RotateNodeKey(nodeKeyFile, rotatedAt)
+	require.NoError(err)
+	require.NotEqual(oldID, newID)
+	id, err := nodeKeyID(nodeKey)
+	require.NoError(err)
+	require.Equal(id, oldID)
+
+	newKey, err := p2p.LoadNodeKey(nodeKeyFile)
+	require.NoError(err)
+	id, err = nodeKeyID(newKey)
+	require.NoError(err)
+	require.Equal(newID, id)
+
+	prev, err = LoadPreviousNodeKey(nodeKeyFile)
+	require.NoError(err)
+	require.NotNil(prev)
+	require.Equal(nodeKey.PrivKey, prev.PrivKey)
+	require.True(rotatedAt.Equal(prev.RotatedAt))
+}
+
+func TestPreviousIdentity(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := test.NewFileLogger(t)
+
+	clients := startTestNetwork(ctx, t, 1, map[int]hostDescr{0: {chainID: "1"}}, make([]GossipValidator, 1), logger)
+	c := clients[0]
+	c.conf.PreviousListenAddress = "/ip4/127.0.0.1/tcp/0"
+	c.conf.KeyRotationGracePeriod = time.Hour
+
+	// previous identity is not announced after grace period
+	require.NoError(c.SetPreviousKey(&RotatedKey{PrivKey: ed25519.GenPrivKey(), RotatedAt: time.Now().Add(-2 * time.Hour)}))
+	require.NoError(c.startPreviousIdentity(ctx))
+	_, ok := c.PreviousID()
+	require.False(ok)
+
+	prevKey := ed25519.GenPrivKey()
+	require.NoError(c.SetPreviousKey(&RotatedKey{PrivKey: prevKey, RotatedAt: time.Now().Add(-time.Hour + time.Second)}))
+	require.NoError(c.startPreviousIdentity(ctx))
+	prevID, ok := c.PreviousID()
+	require.True(ok)
+	expectedID, err := nodeKeyID(&p2p.NodeKey{PrivKey: prevKey})
+	require.NoError(err)
+	require.Equal(expectedID, prevID)
+	// previous identity knows the current one
+	require.NotEmpty(c.prevHost.Peerstore().Addrs(c.host.ID()))
+
+	require.Eventually(func() bool {
+		_, ok := c.PreviousID()
+		return !ok
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,238 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// statusMessageType is used as message type in metrics of status messages.
+const statusMessageType = "status"
+
+// HeightRange is a range of heights [Earliest, Latest] available in a store.
+type HeightRange struct {
+	Earliest uint64 `json:"earliest"`
+	Latest   uint64 `json:"latest"`
+}
+
+// Contains checks if all heights from the range [from, to] are available.
+func (r HeightRange) Contains(from, to uint64) bool {
+	return r.Latest > 0 && r.Earliest <= from && to <= r.Latest
+}
+
+// statusHandshake is the response to status handshake.
+type statusHandshake struct {
+	// GenesisHash is the hash of genesis of the chain, empty if not known.
+	GenesisHash []byte `json:"genesis_hash,omitempty"`
+	Status      Status `json:"status"`
+}
+
+// StatusSource returns the range of heights currently available in a store.
+type StatusSource func() HeightRange
+
+// Status is the block availability advertised by a peer, keyed by store name.
+type Status map[string]HeightRange
+
+// SetStatusSource registers the source of heights advertised for the store with given name.
+//
+// Available heights are sent to peers during handshake (after connection is established) and periodically
+// gossiped, so that sync requests can be routed only to peers that have the requested range.
+func (c *Client) SetStatusSource(store string, source StatusSource) {
+	c.statusMtx.Lock()
+	defer c.statusMtx.Unlock()
+	c.statusSources[store] = source
+}
+
+// PeerStatus returns the last status advertised by given peer.
+func (c *Client) PeerStatus(id peer.ID) (Status, bool) {
+	c.statusMtx.Lock()
+	defer c.statusMtx.Unlock()
+	status, ok := c.peerStatuses[id]
+	return status, ok
+}
+
+// PeersWithRange returns connected peers that advertised having heights [from, to] in the store with given name.
+func (c *Client) PeersWithRange(store string, from, to uint64) []peer.ID {
+	c.statusMtx.Lock()
+	defer c.statusMtx.Unlock()
+	var peers []peer.ID
+	for id, status := range c.peerStatuses {
+		if status[store].Contains(from, to) && c.host.Network().Connectedness(id) == network.Connected {
+			peers = append(peers, id)
+		}
+	}
+	return peers
+}
+
+// status returns current status of this node.
+func (c *Client) status() Status {
+	c.statusMtx.Lock()
+	defer c.statusMtx.Unlock()
+	status := make(Status, len(c.statusSources))
+	for store, source := range c.statusSources {
+		status[store] = source()
+	}
+	return status
+}
+
+func (c *Client) setPeerStatus(id peer.ID, status Status) {
+	c.statusMtx.Lock()
+	defer c.statusMtx.Unlock()
+	c.peerStatuses[id] = status
+}
+
+func (c *Client) getStatusProtocol() protocol.ID {
+	return protocol.ID("/" + c.getNamespace() + statusProtocolSuffix)
+}
+
+func (c *Client) getStatusTopic() string {
+	return c.GossipNamespace() + statusTopicSuffix
+}
+
+// setupStatus starts the status handshake and periodic status gossiping.
+func (c *Client) setupStatus(ctx context.Context) error {
+	c.host.SetStreamHandler(c.getStatusProtocol(), c.handleStatusStream)
+
+	sub, err := c.host.EventBus().Subscribe([]interface{}{
+		new(event.EvtPeerIdentificationCompleted),
+		new(event.EvtPeerProtocolsUpdated),
+		new(event.EvtPeerConnectednessChanged),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to peer events: %w", err)
+	}
+	go func() {
+		defer sub.Close() //nolint:errcheck
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				switch evt := e.(type) {
+				case event.EvtPeerIdentificationCompleted:
+					if slices.Contains(evt.Protocols, c.getStatusProtocol()) {
+						go c.requestStatus(ctx, evt.Peer)
+					}
+				case event.EvtPeerProtocolsUpdated:
+					if slices.Contains(evt.Added, c.getStatusProtocol()) {
+						go c.requestStatus(ctx, evt.Peer)
+					}
+				case event.EvtPeerConnectednessChanged:
+					if evt.Connectedness == network.NotConnected {
+						c.statusMtx.Lock()
+						delete(c.peerStatuses, evt.Peer)
+						c.statusMtx.Unlock()
+					}
+				}
+			}
+		}
+	}()
+	// peers connected before subscription are handled here
+	for _, id := range c.host.Network().Peers() {
+		if protocols, err := c.host.Peerstore().SupportsProtocols(id, c.getStatusProtocol()); err == nil && len(protocols) > 0 {
+			go c.requestStatus(ctx, id)
+		}
+	}
+
+	c.statusGossiper, err = NewGossiper(c.host, c.ps, c.getStatusTopic(), c.logger, c.withEnvelope(), WithValidator(c.statusValidator))
+	if err != nil {
+		return err
+	}
+	go c.statusGossiper.ProcessMessages(ctx)
+	go c.statusLoop(ctx)
+	return nil
+}
+
+// handleStatusStream responds to status handshake with the current status of this node.
+func (c *Client) handleStatusStream(s network.Stream) {
+	defer s.Close() //nolint:errcheck
+	if err := json.NewEncoder(s).Encode(statusHandshake{GenesisHash: c.genesisHash, Status: c.status()}); err != nil {
+		c.logger.Debug("failed to send status", "peer", s.Conn().RemotePeer(), "error", err)
+		s.Reset() //nolint:errcheck
+	}
+}
+
+// requestStatus performs status handshake with newly connected peer.
+func (c *Client) requestStatus(ctx context.Context, id peer.ID) {
+	ctx, cancel := context.WithTimeout(ctx, statusRequestTimeout)
+	defer cancel()
+	s, err := c.host.NewStream(ctx, id, c.getStatusProtocol())
+	if err != nil {
+		c.logger.Debug("failed to open status stream", "peer", id, "error", err)
+		return
+	}
+	defer s.Close() //nolint:errcheck
+	if err := s.SetDeadline(time.Now().Add(statusRequestTimeout)); err != nil {
+		c.logger.Debug("failed to set status stream deadline", "error", err)
+	}
+	var handshake statusHandshake
+	if err := json.NewDecoder(io.LimitReader(s, maxStatusSize)).Decode(&handshake); err != nil {
+		c.logger.Debug("failed to receive status", "peer", id, "error", err)
+		s.Reset() //nolint:errcheck
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			c.ReportMisbehavior(id, MisbehaviorProtocolViolation, "malformed status handshake")
+		}
+		return
+	}
+	if len(c.genesisHash) > 0 && len(handshake.GenesisHash) > 0 && !bytes.Equal(c.genesisHash, handshake.GenesisHash) {
+		c.logger.Error("disconnecting peer running different genesis", "peer", id,
+			"genesis hash", fmt.Sprintf("%X", c.genesisHash), "peer genesis hash", fmt.Sprintf("%X", handshake.GenesisHash))
+		if err := c.host.Network().ClosePeer(id); err != nil {
+			c.logger.Debug("failed to disconnect peer", "peer", id, "error", err)
+		}
+		return
+	}
+	c.setPeerStatus(id, handshake.Status)
+}
+
+// statusValidator records status gossiped by other peers.
+func (c *Client) statusValidator(msg *GossipMessage) bool {
+	// validator is also invoked for messages published by this node
+	if msg.From == c.host.ID() {
+		return true
+	}
+	c.metrics.MessageReceiveBytesTotal.With("message_type", statusMessageType).Add(float64(len(msg.Data)))
+	var status Status
+	if len(msg.Data) > maxStatusSize || json.Unmarshal(msg.Data, &status) != nil {
+		return false
+	}
+	c.setPeerStatus(msg.From, status)
+	return true
+}
+
+// statusLoop periodically gossips the status of this node.
+func (c *Client) statusLoop(ctx context.Context) {
+	ticker := time.NewTicker(statusInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			blob, err := json.Marshal(c.status())
+			if err != nil {
+				c.logger.Error("failed to marshal status", "error", err)
+				continue
+			}
+			c.metrics.MessageSendBytesTotal.With("message_type", statusMessageType).Add(float64(len(blob)))
+			if err := c.statusGossiper.Publish(ctx, blob); err != nil && ctx.Err() == nil {
+				c.logger.Debug("failed to gossip status", "error", err)
+			}
+		}
+	}
+}
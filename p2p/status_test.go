@@ -0,0 +1,140 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestHeightRangeContains(t *testing.T) {
+	assert := assert.New(t)
+
+	r := HeightRange{Earliest: 10, Latest: 20}
+	assert.True(r.Contains(10, 20))
+	assert.True(r.Contains(15, 15))
+	assert.False(r.Contains(9, 20))
+	assert.False(r.Contains(10, 21))
+	assert.False(HeightRange{}.Contains(0, 0))
+}
+
+func TestStatus(t *testing.T) {
+	require := require.New(t)
+	logger := test.NewFileLogger(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mnet := mocknet.New()
+	for i := 0; i < 3; i++ {
+		privKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+		addr, err := getAddr(privKey)
+		require.NoError(err)
+		_, err = mnet.AddPeer(privKey, addr)
+		require.NoError(err)
+	}
+	require.NoError(mnet.LinkAll())
+	require.NoError(mnet.ConnectAllButSelf())
+
+	ranges := []HeightRange{{}, {Earliest: 1, Latest: 100}, {Earliest: 50, Latest: 200}}
+	var err error
+	clients := make([]*Client, 3)
+	for i, h := range mnet.Hosts() {
+		clients[i], err = NewClient(config.P2PConfig{}, h.Peerstore().PrivKey(h.ID()), "TestChain",
+			sync.MutexWrap(datastore.NewMapDatastore()), logger, NopMetrics())
+		require.NoError(err)
+		clients[i].SetTxValidator(func(*GossipMessage) bool { return true })
+		r := ranges[i]
+		clients[i].SetStatusSource("headerSync", func() HeightRange { return r })
+	}
+	for i, c := range clients {
+		require.NoError(c.startWithHost(ctx, mnet.Hosts()[i]))
+	}
+
+	// status is exchanged during handshake
+	c := clients[0]
+	require.Eventually(func() bool {
+		_, ok1 := c.PeerStatus(clients[1].host.ID())
+		_, ok2 := c.PeerStatus(clients[2].host.ID())
+		return ok1 && ok2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.ElementsMatch(c.PeersWithRange("headerSync", 1, 100), []peer.ID{clients[1].host.ID()})
+	require.ElementsMatch(c.PeersWithRange("headerSync", 60, 100), []peer.ID{clients[1].host.ID(), clients[2].host.ID()})
+	require.ElementsMatch(c.PeersWithRange("headerSync", 150, 200), []peer.ID{clients[2].host.ID()})
+	require.Empty(c.PeersWithRange("headerSync", 150, 201))
+	require.Empty(c.PeersWithRange("dataSync", 1, 1))
+
+	// status is updated by gossiped message
+	blob, err := json.Marshal(Status{"headerSync": {Earliest: 1, Latest: 300}})
+	require.NoError(err)
+	require.True(c.statusValidator(&GossipMessage{Data: blob, From: clients[1].host.ID()}))
+	require.ElementsMatch(c.PeersWithRange("headerSync", 150, 300), []peer.ID{clients[1].host.ID()})
+	require.False(c.statusValidator(&GossipMessage{Data: []byte("invalid"), From: clients[1].host.ID()}))
+
+	// status of disconnected peer is forgotten
+	require.NoError(mnet.DisconnectPeers(c.host.ID(), clients[2].host.ID()))
+	require.Eventually(func() bool {
+		_, ok := c.PeerStatus(clients[2].host.ID())
+		return !ok
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestStatusGenesisMismatch(t *testing.T) {
+	require := require.New(t)
+	logger := test.NewFileLogger(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mnet := mocknet.New()
+	for i := 0; i < 3; i++ {
+		privKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+		addr, err := getAddr(privKey)
+		require.NoError(err)
+		_, err = mnet.AddPeer(privKey, addr)
+		require.NoError(err)
+	}
+	require.NoError(mnet.LinkAll())
+	require.NoError(mnet.ConnectAllButSelf())
+
+	genesisHashes := [][]byte{{1}, {1}, {2}}
+	clients := make([]*Client, 3)
+	for i, h := range mnet.Hosts() {
+		var err error
+		clients[i], err = NewClient(config.P2PConfig{}, h.Peerstore().PrivKey(h.ID()), "TestChain",
+			sync.MutexWrap(datastore.NewMapDatastore()), logger, NopMetrics())
+		require.NoError(err)
+		clients[i].SetTxValidator(func(*GossipMessage) bool { return true })
+		clients[i].SetGenesisHash(genesisHashes[i])
+		clients[i].SetStatusSource("headerSync", func() HeightRange { return HeightRange{Earliest: 1, Latest: 10} })
+	}
+	for i, c := range clients {
+		require.NoError(c.startWithHost(ctx, mnet.Hosts()[i]))
+	}
+
+	// peer with the same genesis is kept, peer with different genesis is disconnected
+	c := clients[0]
+	require.Eventually(func() bool {
+		_, ok := c.PeerStatus(clients[1].host.ID())
+		return ok
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Eventually(func() bool {
+		return c.host.Network().Connectedness(clients[2].host.ID()) != network.Connected
+	}, 5*time.Second, 50*time.Millisecond)
+	_, ok := c.PeerStatus(clients[2].host.ID())
+	require.False(ok)
+}
@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTopicExists is returned when gossip topic with the same name is already registered.
+	ErrTopicExists = errors.New("gossip topic already registered")
+
+	// ErrTopicNotFound is returned when publishing to a gossip topic that wasn't registered.
+	ErrTopicNotFound = errors.New("gossip topic not registered")
+)
+
+// GossipHandler is a callback function type, invoked for every valid message received from other peers.
+type GossipHandler func(context.Context, *GossipMessage)
+
+// TopicConfig describes an application-specific gossip topic, e.g. for preconfirmations or oracle data.
+type TopicConfig struct {
+	// Name identifies the topic within ORU network. Gossip namespace is prepended to create pubsub topic.
+	Name string
+	// Validator decides if message is accepted and relayed to other peers. If nil, all messages are accepted.
+	Validator GossipValidator
+	// Handler is invoked for every accepted message received from other peers. It's optional.
+	Handler GossipHandler
+}
+
+// RegisterTopic registers additional gossip topic. Topics have to be registered before Client is started.
+//
+// Topics share libp2p host and pubsub instance with transaction gossiping. Received and sent bytes are
+// reported in p2p metrics with topic name as message type.
+func (c *Client) RegisterTopic(topic TopicConfig) error {
+	if topic.Name == "" {
+		return errors.New("gossip topic name can't be empty")
+	}
+	if name := c.GossipNamespace() + "-" + topic.Name; name == c.getTxTopic() || name == c.getStatusTopic() {
+		return fmt.Errorf("gossip topic name %q is reserved", topic.Name)
+	}
+
+	c.topicsMtx.Lock()
+	defer c.topicsMtx.Unlock()
+	if c.topicGossipers != nil {
+		return errors.New("gossip topics must be registered before P2P client is started")
+	}
+	for _, t := range c.topics {
+		if t.Name == topic.Name {
+			return fmt.Errorf("%w: %s", ErrTopicExists, topic.Name)
+		}
+	}
+	c.topics = append(c.topics, topic)
+	return nil
+}
+
+// Publish sends data to registered gossip topic.
+func (c *Client) Publish(ctx context.Context, topic string, data []byte) error {
+	c.topicsMtx.Lock()
+	gossiper, ok := c.topicGossipers[topic]
+	c.topicsMtx.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTopicNotFound, topic)
+	}
+	c.logger.Debug("gossiping message", "topic", topic, "len", len(data))
+	c.metrics.MessageSendBytesTotal.With("message_type", topic).Add(float64(len(data)))
+	return gossiper.Publish(ctx, data)
+}
+
+// setupTopics joins registered gossip topics and starts processing messages.
+func (c *Client) setupTopics(ctx context.Context) error {
+	c.topicsMtx.Lock()
+	defer c.topicsMtx.Unlock()
+	c.topicGossipers = make(map[string]*Gossiper, len(c.topics))
+	for _, topic := range c.topics {
+		options := []GossiperOption{c.withEnvelope(), WithValidator(c.topicValidator(topic))}
+		if topic.Handler != nil {
+			options = append(options, WithHandler(topic.Handler))
+		}
+		gossiper, err := NewGossiper(c.host, c.ps, c.GossipNamespace()+"-"+topic.Name, c.logger, options...)
+		if err != nil {
+			return fmt.Errorf("failed to join gossip topic %s: %w", topic.Name, err)
+		}
+		c.topicGossipers[topic.Name] = gossiper
+		go gossiper.ProcessMessages(ctx)
+	}
+	return nil
+}
+
+// closeTopics leaves all gossip topics.
+func (c *Client) closeTopics() error {
+	c.topicsMtx.Lock()
+	defer c.topicsMtx.Unlock()
+	errs := make([]error, 0, len(c.topicGossipers))
+	for _, gossiper := range c.topicGossipers {
+		errs = append(errs, gossiper.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// topicValidator wraps validator of a topic, to report received messages in metrics.
+func (c *Client) topicValidator(topic TopicConfig) GossipValidator {
+	return func(msg *GossipMessage) bool {
+		// validator is also invoked for messages published by this node
+		if msg.From == c.host.ID() {
+			return true
+		}
+		c.metrics.PeerReceiveBytesTotal.With("peer_id", msg.From.String(), "chID", c.chainID).Add(float64(len(msg.Data)))
+		c.metrics.MessageReceiveBytesTotal.With("message_type", topic.Name).Add(float64(len(msg.Data)))
+		if topic.Validator == nil {
+			return true
+		}
+		return topic.Validator(msg)
+	}
+}
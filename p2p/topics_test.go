@@ -0,0 +1,83 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestCustomTopics(t *testing.T) {
+	require := require.New(t)
+	logger := test.NewFileLogger(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mnet := mocknet.New()
+	for i := 0; i < 2; i++ {
+		privKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+		addr, err := getAddr(privKey)
+		require.NoError(err)
+		_, err = mnet.AddPeer(privKey, addr)
+		require.NoError(err)
+	}
+	require.NoError(mnet.LinkAll())
+	require.NoError(mnet.ConnectAllButSelf())
+	var err error
+	clients := make([]*Client, 2)
+	for i, h := range mnet.Hosts() {
+		clients[i], err = NewClient(config.P2PConfig{}, h.Peerstore().PrivKey(h.ID()), "TestChain",
+			sync.MutexWrap(datastore.NewMapDatastore()), logger, NopMetrics())
+		require.NoError(err)
+		clients[i].SetTxValidator(func(*GossipMessage) bool { return true })
+	}
+
+	received := make(chan *GossipMessage, 2)
+	require.NoError(clients[0].RegisterTopic(TopicConfig{Name: "oracle"}))
+	require.NoError(clients[1].RegisterTopic(TopicConfig{
+		Name:      "oracle",
+		Validator: func(msg *GossipMessage) bool { return string(msg.Data) != "invalid" },
+		Handler: func(_ context.Context, msg *GossipMessage) {
+			received <- msg
+		},
+	}))
+	require.ErrorIs(clients[1].RegisterTopic(TopicConfig{Name: "oracle"}), ErrTopicExists)
+	require.Error(clients[1].RegisterTopic(TopicConfig{Name: "tx"}))
+	require.Error(clients[1].RegisterTopic(TopicConfig{}))
+
+	for i, c := range clients {
+		require.NoError(c.startWithHost(ctx, mnet.Hosts()[i]))
+	}
+	require.Error(clients[0].RegisterTopic(TopicConfig{Name: "preconf"}))
+	require.ErrorIs(clients[0].Publish(ctx, "preconf", []byte("data")), ErrTopicNotFound)
+
+	// this sleep is required for pubsub to "propagate" subscription information
+	time.Sleep(1 * time.Second)
+
+	require.NoError(clients[0].Publish(ctx, "oracle", []byte("invalid")))
+	require.NoError(clients[0].Publish(ctx, "oracle", []byte("price")))
+	select {
+	case msg := <-received:
+		require.Equal([]byte("price"), msg.Data)
+		require.Equal(clients[0].host.ID(), msg.From)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not received")
+	}
+	// handler is not invoked for own messages
+	require.NoError(clients[1].Publish(ctx, "oracle", []byte("own")))
+	select {
+	case msg := <-received:
+		t.Fatalf("unexpected message: %s", msg.Data)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
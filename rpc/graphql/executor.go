@@ -0,0 +1,125 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// resolver returns the value of a field of parent object.
+type resolver func(ctx context.Context, parent interface{}, args map[string]interface{}) (interface{}, error)
+
+// objectType maps field names to resolvers.
+type objectType map[string]resolver
+
+// field is a single key-value pair of result object.
+type field struct {
+	name  string
+	value interface{}
+}
+
+// object is a result object, that preserves the order of requested fields when encoded.
+type object []field
+
+// MarshalJSON implements json.Marshaler.
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// executor resolves parsed queries using given schema.
+type executor struct {
+	// typeOf returns object type of a value, or nil for scalars.
+	typeOf func(v interface{}) objectType
+	vars   map[string]interface{}
+}
+
+func (e *executor) resolveObject(ctx context.Context, typ objectType, parent interface{}, sels []selection) (object, error) {
+	result := make(object, 0, len(sels))
+	for _, sel := range sels {
+		r, ok := typ[sel.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", sel.name)
+		}
+		args, err := e.resolveArgs(sel.args)
+		if err != nil {
+			return nil, err
+		}
+		v, err := r(ctx, parent, args)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", sel.key(), err)
+		}
+		v, err = e.complete(ctx, v, sel)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, field{name: sel.key(), value: v})
+	}
+	return result, nil
+}
+
+// complete resolves nested selections of a field value.
+func (e *executor) complete(ctx context.Context, v interface{}, sel selection) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, nil
+	}
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			item, err := e.complete(ctx, rv.Index(i).Interface(), sel)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = item
+		}
+		return list, nil
+	}
+	if typ := e.typeOf(v); typ != nil {
+		if len(sel.selections) == 0 {
+			return nil, fmt.Errorf("field %q of object type must have a selection of subfields", sel.name)
+		}
+		return e.resolveObject(ctx, typ, v, sel.selections)
+	}
+	if len(sel.selections) > 0 {
+		return nil, fmt.Errorf("field %q of scalar type must not have a selection of subfields", sel.name)
+	}
+	return v, nil
+}
+
+func (e *executor) resolveArgs(args map[string]interface{}) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(args))
+	for name, val := range args {
+		if v, ok := val.(variable); ok {
+			val, ok = e.vars[string(v)]
+			if !ok {
+				return nil, fmt.Errorf("variable %q is not defined", string(v))
+			}
+		}
+		resolved[name] = val
+	}
+	return resolved, nil
+}
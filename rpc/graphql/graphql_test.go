@@ -0,0 +1,137 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/test/mocks"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		name        string
+		query       string
+		expectedErr bool
+	}{
+		{"shorthand", `{ block { height } }`, false},
+		{"named query", `query Q { block(height: 1) { height hash } }`, false},
+		{"variables", `query Q($h: Int! = 2, $q: String) { b: block(height: $h) { height } txs(query: $q) { hash } }`, false},
+		{"comments and commas", "{ # comment\n block { height, hash } }", false},
+		{"string escapes", `{ txs(query: "tx.height=\"1\"") { hash } }`, false},
+		{"mutation", `mutation { block { height } }`, true},
+		{"fragment", `{ block { ...f } }`, true},
+		{"empty selection", `{ block { } }`, true},
+		{"unterminated", `{ block { height }`, true},
+		{"trailing garbage", `{ block { height } } }`, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, _, err := parseQuery(c.query)
+			if c.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	sels, defaults, err := parseQuery(`query Q($h: Int = 2) { b: block(height: $h) { height } }`)
+	require.NoError(t, err)
+	require.Len(t, sels, 1)
+	assert.Equal(t, "b", sels[0].key())
+	assert.Equal(t, "block", sels[0].name)
+	assert.Equal(t, variable("h"), sels[0].args["height"])
+	assert.Equal(t, int64(2), defaults["h"])
+}
+
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	txs := cmtypes.Txs{cmtypes.Tx("tx0"), cmtypes.Tx("tx1")}
+	block := &ctypes.ResultBlock{
+		Block: &cmtypes.Block{
+			Header: cmtypes.Header{ChainID: "test", Height: 5, Time: time.Unix(0, 0).UTC()},
+			Data:   cmtypes.Data{Txs: txs},
+		},
+	}
+	results := &ctypes.ResultBlockResults{
+		Height: 5,
+		TxsResults: []*abci.ExecTxResult{
+			{Code: 0, Log: "ok", GasUsed: 10},
+			{Code: 1, Log: "failed", GasUsed: 20, Events: []abci.Event{
+				{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: "100"}}},
+			}},
+		},
+	}
+
+	client := &mocks.Client{}
+	client.On("Block", mock.Anything, mock.Anything).Return(block, nil)
+	client.On("BlockResults", mock.Anything, mock.Anything).Return(results, nil)
+
+	handler := NewHandler(client, log.TestingLogger())
+
+	query := `query Q($h: Int) {
+		block(height: $h) {
+			height
+			chainId
+			numTxs
+			txs {
+				index
+				log
+				gasUsed
+				events { type attributes { key value } }
+			}
+		}
+	}`
+	body, err := json.Marshal(request{Query: query, Variables: map[string]interface{}{"h": 5}})
+	require.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.JSONEq(`{"data":{"block":{
+		"height":5,
+		"chainId":"test",
+		"numTxs":2,
+		"txs":[
+			{"index":0,"log":"ok","gasUsed":10,"events":[]},
+			{"index":1,"log":"failed","gasUsed":20,"events":[{"type":"transfer","attributes":[{"key":"amount","value":"100"}]}]}
+		]
+	}}}`, resp.Body.String())
+	client.AssertNumberOfCalls(t, "BlockResults", 1)
+
+	// field order is preserved
+	req = httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(`{ block { numTxs height } }`), nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.Equal(`{"data":{"block":{"numTxs":2,"height":5}}}`+"\n", resp.Body.String())
+
+	// errors
+	for _, q := range []string{`{ block { unknown } }`, `{ block }`, `{ block { height { x } } }`, `{ tx(hash: "zz") { hash } }`} {
+		req = httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(q), nil)
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		var r response
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &r))
+		assert.Nil(r.Data, q)
+		assert.Len(r.Errors, 1, q)
+	}
+}
@@ -0,0 +1,101 @@
+// Package graphql implements read-only GraphQL endpoint, exposing blocks, transactions and events.
+//
+// Only a subset of GraphQL is supported: a single query operation with fields, aliases,
+// arguments and variables. See schema for available types and fields.
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+
+	"github.com/rollkit/rollkit/third_party/log"
+)
+
+// maxRequestSize is the maximum size of GraphQL request body.
+const maxRequestSize = 1 << 20
+
+type request struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type response struct {
+	Data   interface{}     `json:"data"`
+	Errors []responseError `json:"errors,omitempty"`
+}
+
+type responseError struct {
+	Message string `json:"message"`
+}
+
+type handler struct {
+	schema *schema
+	logger log.Logger
+}
+
+// NewHandler returns HTTP handler serving GraphQL queries using given client.
+//
+// Queries can be sent with POST requests (JSON encoded body with "query" and "variables")
+// or with GET requests ("query" URL parameter).
+func NewHandler(client rpcclient.Client, logger log.Logger) http.Handler {
+	return &handler{
+		schema: newSchema(client),
+		logger: logger,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		if vars := r.URL.Query().Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				h.writeError(w, http.StatusBadRequest, fmt.Errorf("failed to parse variables: %w", err))
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+			h.writeError(w, http.StatusBadRequest, fmt.Errorf("failed to parse request: %w", err))
+			return
+		}
+	default:
+		h.writeError(w, http.StatusMethodNotAllowed, errors.New("only GET and POST requests are supported"))
+		return
+	}
+
+	sels, defaults, err := parseQuery(req.Query)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	vars := defaults
+	for k, v := range req.Variables {
+		vars[k] = v
+	}
+
+	e := &executor{typeOf: h.schema.typeOf, vars: vars}
+	data, err := e.resolveObject(r.Context(), h.schema.query, nil, sels)
+	if err != nil {
+		h.writeError(w, http.StatusOK, err)
+		return
+	}
+	h.write(w, http.StatusOK, response{Data: data})
+}
+
+func (h *handler) writeError(w http.ResponseWriter, status int, err error) {
+	h.write(w, status, response{Errors: []responseError{{Message: err.Error()}}})
+}
+
+func (h *handler) write(w http.ResponseWriter, status int, resp response) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode GraphQL response", "error", err)
+	}
+}
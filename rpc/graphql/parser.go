@@ -0,0 +1,295 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// selection is a single field requested in a query.
+type selection struct {
+	alias      string
+	name       string
+	args       map[string]interface{}
+	selections []selection
+}
+
+// key returns the name of the field in response.
+func (s selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// variable is a reference to query variable, resolved at execution time.
+type variable string
+
+// parser is a minimal GraphQL parser supporting a single query operation with fields,
+// aliases, arguments and variables. Fragments, directives and mutations are not supported.
+type parser struct {
+	src string
+	pos int
+}
+
+// parseQuery parses query document and returns top level selections and default values of variables.
+func parseQuery(src string) ([]selection, map[string]interface{}, error) {
+	p := &parser{src: src}
+	defaults := make(map[string]interface{})
+
+	p.skipIgnored()
+	if p.peek() != '{' {
+		op := p.name()
+		if op != "query" {
+			return nil, nil, p.errorf("unsupported operation %q", op)
+		}
+		p.skipIgnored()
+		if isNameStart(p.peek()) {
+			p.name()
+			p.skipIgnored()
+		}
+		if p.peek() == '(' {
+			if err := p.variableDefinitions(defaults); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, nil, err
+	}
+	p.skipIgnored()
+	if p.pos < len(p.src) {
+		return nil, nil, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return sels, defaults, nil
+}
+
+func (p *parser) variableDefinitions(defaults map[string]interface{}) error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	for {
+		p.skipIgnored()
+		if p.peek() == ')' {
+			p.pos++
+			return nil
+		}
+		if err := p.expect('$'); err != nil {
+			return err
+		}
+		name := p.name()
+		if err := p.expect(':'); err != nil {
+			return err
+		}
+		// variable types are not validated
+		p.skipIgnored()
+		for c := p.peek(); c == '[' || c == ']' || c == '!' || isNameStart(c); c = p.peek() {
+			if isNameStart(c) {
+				p.name()
+			} else {
+				p.pos++
+			}
+			p.skipIgnored()
+		}
+		if p.peek() == '=' {
+			p.pos++
+			val, err := p.value()
+			if err != nil {
+				return err
+			}
+			defaults[name] = val
+		}
+	}
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+	var sels []selection
+	for {
+		p.skipIgnored()
+		switch c := p.peek(); {
+		case c == '}':
+			p.pos++
+			if len(sels) == 0 {
+				return nil, p.errorf("empty selection set")
+			}
+			return sels, nil
+		case c == '.':
+			return nil, p.errorf("fragments are not supported")
+		case isNameStart(c):
+			sel, err := p.field()
+			if err != nil {
+				return nil, err
+			}
+			sels = append(sels, sel)
+		default:
+			return nil, p.errorf("expected field")
+		}
+	}
+}
+
+func (p *parser) field() (selection, error) {
+	var sel selection
+	sel.name = p.name()
+	p.skipIgnored()
+	if p.peek() == ':' {
+		p.pos++
+		p.skipIgnored()
+		sel.alias = sel.name
+		sel.name = p.name()
+		if sel.name == "" {
+			return sel, p.errorf("expected field name")
+		}
+		p.skipIgnored()
+	}
+	if p.peek() == '(' {
+		p.pos++
+		sel.args = make(map[string]interface{})
+		for {
+			p.skipIgnored()
+			if p.peek() == ')' {
+				p.pos++
+				break
+			}
+			name := p.name()
+			if name == "" {
+				return sel, p.errorf("expected argument name")
+			}
+			if err := p.expect(':'); err != nil {
+				return sel, err
+			}
+			val, err := p.value()
+			if err != nil {
+				return sel, err
+			}
+			sel.args[name] = val
+		}
+		p.skipIgnored()
+	}
+	if p.peek() == '@' {
+		return sel, p.errorf("directives are not supported")
+	}
+	if p.peek() == '{' {
+		sels, err := p.selectionSet()
+		if err != nil {
+			return sel, err
+		}
+		sel.selections = sels
+	}
+	return sel, nil
+}
+
+func (p *parser) value() (interface{}, error) {
+	p.skipIgnored()
+	switch c := p.peek(); {
+	case c == '$':
+		p.pos++
+		return variable(p.name()), nil
+	case c == '"':
+		return p.stringValue()
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && strings.ContainsRune("0123456789.eE+-", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		lit := p.src[start:p.pos]
+		if i, err := strconv.ParseInt(lit, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(lit, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", lit)
+		}
+		return f, nil
+	case isNameStart(c):
+		switch name := p.name(); name {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		default:
+			// enum values are passed as strings
+			return name, nil
+		}
+	default:
+		return nil, p.errorf("expected value")
+	}
+}
+
+func (p *parser) stringValue() (string, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			s, err := strconv.Unquote(p.src[start:p.pos])
+			if err != nil {
+				return "", p.errorf("invalid string: %v", err)
+			}
+			return s, nil
+		default:
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) name() string {
+	p.skipIgnored()
+	start := p.pos
+	for p.pos < len(p.src) && (isNameStart(p.src[p.pos]) || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *parser) expect(c byte) error {
+	p.skipIgnored()
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// skipIgnored skips white spaces, commas and comments.
+func (p *parser) skipIgnored() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == ',' || unicode.IsSpace(rune(c)):
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("syntax error at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
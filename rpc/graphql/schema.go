@@ -0,0 +1,299 @@
+package graphql
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// schema describes the data exposed by GraphQL endpoint:
+//
+//	type Query {
+//	  block(height: Int): Block
+//	  blocks(query: String!, page: Int, perPage: Int, orderBy: String): [Block]
+//	  tx(hash: String!): Tx
+//	  txs(query: String!, page: Int, perPage: Int, orderBy: String): [Tx]
+//	}
+//
+//	type Block {
+//	  height: Int
+//	  hash: String
+//	  chainId: String
+//	  time: String
+//	  proposer: String
+//	  appHash: String
+//	  numTxs: Int
+//	  txs: [Tx]
+//	  events: [Event]
+//	}
+//
+//	type Tx {
+//	  hash: String
+//	  height: Int
+//	  index: Int
+//	  tx: String
+//	  code: Int
+//	  codespace: String
+//	  log: String
+//	  gasWanted: Int
+//	  gasUsed: Int
+//	  events: [Event]
+//	  block: Block
+//	}
+//
+//	type Event {
+//	  type: String
+//	  attributes: [Attribute]
+//	}
+//
+//	type Attribute {
+//	  key: String
+//	  value: String
+//	}
+type schema struct {
+	client rpcclient.Client
+
+	query     objectType
+	block     objectType
+	tx        objectType
+	event     objectType
+	attribute objectType
+}
+
+func newSchema(client rpcclient.Client) *schema {
+	s := &schema{client: client}
+	s.query = objectType{
+		"block":  s.resolveBlock,
+		"blocks": s.resolveBlocks,
+		"tx":     s.resolveTx,
+		"txs":    s.resolveTxs,
+	}
+	s.block = objectType{
+		"height": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultBlock).Block.Height, nil
+		},
+		"hash": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultBlock).BlockID.Hash, nil
+		},
+		"chainId": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultBlock).Block.ChainID, nil
+		},
+		"time": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultBlock).Block.Time.Format(time.RFC3339Nano), nil
+		},
+		"proposer": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultBlock).Block.ProposerAddress, nil
+		},
+		"appHash": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultBlock).Block.AppHash, nil
+		},
+		"numTxs": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return len(p.(*ctypes.ResultBlock).Block.Txs), nil
+		},
+		"txs":    s.resolveBlockTxs,
+		"events": s.resolveBlockEvents,
+	}
+	s.tx = objectType{
+		"hash": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).Hash, nil
+		},
+		"height": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).Height, nil
+		},
+		"index": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).Index, nil
+		},
+		"tx": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return hex.EncodeToString(p.(*ctypes.ResultTx).Tx), nil
+		},
+		"code": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).TxResult.Code, nil
+		},
+		"codespace": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).TxResult.Codespace, nil
+		},
+		"log": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).TxResult.Log, nil
+		},
+		"gasWanted": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).TxResult.GasWanted, nil
+		},
+		"gasUsed": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).TxResult.GasUsed, nil
+		},
+		"events": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(*ctypes.ResultTx).TxResult.Events, nil
+		},
+		"block": func(ctx context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			height := p.(*ctypes.ResultTx).Height
+			return s.client.Block(ctx, &height)
+		},
+	}
+	s.event = objectType{
+		"type": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(abci.Event).Type, nil
+		},
+		"attributes": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(abci.Event).Attributes, nil
+		},
+	}
+	s.attribute = objectType{
+		"key": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(abci.EventAttribute).Key, nil
+		},
+		"value": func(_ context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+			return p.(abci.EventAttribute).Value, nil
+		},
+	}
+	return s
+}
+
+// typeOf returns object type of a value, or nil for scalars.
+func (s *schema) typeOf(v interface{}) objectType {
+	switch v.(type) {
+	case *ctypes.ResultBlock:
+		return s.block
+	case *ctypes.ResultTx:
+		return s.tx
+	case abci.Event:
+		return s.event
+	case abci.EventAttribute:
+		return s.attribute
+	default:
+		return nil
+	}
+}
+
+func (s *schema) resolveBlock(ctx context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	height, err := optionalInt(args, "height")
+	if err != nil {
+		return nil, err
+	}
+	var h *int64
+	if height != nil {
+		h64 := int64(*height)
+		h = &h64
+	}
+	return s.client.Block(ctx, h)
+}
+
+func (s *schema) resolveBlocks(ctx context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	query, page, perPage, orderBy, err := searchArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.BlockSearch(ctx, query, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	return res.Blocks, nil
+}
+
+func (s *schema) resolveTx(ctx context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	hash, err := requiredString(args, "hash")
+	if err != nil {
+		return nil, err
+	}
+	h, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hash: %w", err)
+	}
+	return s.client.Tx(ctx, h, false)
+}
+
+func (s *schema) resolveTxs(ctx context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	query, page, perPage, orderBy, err := searchArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.TxSearch(ctx, query, false, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	return res.Txs, nil
+}
+
+func (s *schema) resolveBlockTxs(ctx context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+	block := p.(*ctypes.ResultBlock).Block
+	txs := make([]*ctypes.ResultTx, len(block.Txs))
+	if len(txs) == 0 {
+		return txs, nil
+	}
+	results, err := s.client.BlockResults(ctx, &block.Height)
+	if err != nil {
+		return nil, err
+	}
+	for i, tx := range block.Txs {
+		txs[i] = &ctypes.ResultTx{
+			Hash:   types.TxHash(tx),
+			Height: block.Height,
+			Index:  uint32(i), //nolint:gosec
+			Tx:     tx,
+		}
+		if i < len(results.TxsResults) && results.TxsResults[i] != nil {
+			txs[i].TxResult = *results.TxsResults[i]
+		}
+	}
+	return txs, nil
+}
+
+func (s *schema) resolveBlockEvents(ctx context.Context, p interface{}, _ map[string]interface{}) (interface{}, error) {
+	height := p.(*ctypes.ResultBlock).Block.Height
+	results, err := s.client.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	return results.FinalizeBlockEvents, nil
+}
+
+func searchArgs(args map[string]interface{}) (query string, page, perPage *int, orderBy string, err error) {
+	if query, err = requiredString(args, "query"); err != nil {
+		return
+	}
+	if page, err = optionalInt(args, "page"); err != nil {
+		return
+	}
+	if perPage, err = optionalInt(args, "perPage"); err != nil {
+		return
+	}
+	if v, ok := args["orderBy"]; ok && v != nil {
+		if orderBy, ok = v.(string); !ok {
+			err = fmt.Errorf("argument %q must be a string", "orderBy")
+		}
+	}
+	return
+}
+
+func requiredString(args map[string]interface{}, name string) (string, error) {
+	v, ok := args[name].(string)
+	if !ok {
+		return "", fmt.Errorf("argument %q must be a string", name)
+	}
+	return v, nil
+}
+
+func optionalInt(args map[string]interface{}, name string) (*int, error) {
+	var i int
+	switch v := args[name].(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		i = int(v)
+	case float64:
+		// numbers in JSON encoded variables are decoded as float64
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("argument %q must be an integer", name)
+		}
+		i = int(v)
+	default:
+		return nil, fmt.Errorf("argument %q must be an integer", name)
+	}
+	return &i, nil
+}
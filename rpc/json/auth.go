@@ -0,0 +1,218 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// APIKeyHeader is the HTTP header used to pass API key.
+	APIKeyHeader = "X-API-Key"
+	// APIKeyParam is the URL query parameter used to pass API key, useful for WebSocket clients.
+	APIKeyParam = "api_key"
+)
+
+var (
+	// ErrMissingAPIKey is returned when request doesn't contain API key.
+	ErrMissingAPIKey = errors.New("missing API key")
+	// ErrInvalidAPIKey is returned when API key is not known.
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	// ErrMethodNotAllowed is returned when API key doesn't allow calling the method.
+	ErrMethodNotAllowed = errors.New("method not allowed for API key")
+	// ErrRateLimited is returned when API key exceeded its rate limit.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrAdminWithoutAPIKeys is returned when admin methods are enabled without API keys.
+	ErrAdminWithoutAPIKeys = errors.New("admin methods require API keys")
+)
+
+// APIKey describes access to RPC granted to a single tenant.
+type APIKey struct {
+	// Name identifies the tenant in logs and metrics.
+	Name string `json:"name"`
+	// Key is the secret passed by clients.
+	Key string `json:"key"`
+	// RateLimit is the number of requests per second allowed for the key. 0 means no limit.
+	RateLimit float64 `json:"rate_limit"`
+	// Burst is the maximum number of requests above the rate limit. Defaults to RateLimit.
+	Burst int `json:"burst"`
+	// Methods is the list of methods allowed for the key. All methods except admin methods are allowed if empty.
+	Methods []string `json:"methods"`
+	// AdminMethods is the list of admin methods (admin_* and debug_*) allowed for the key. Admin methods are
+	// denied by default.
+	AdminMethods []string `json:"admin_methods"`
+}
+
+// isAdminMethod returns true if method is an admin method, which must be allowed explicitly for API key.
+func isAdminMethod(method string) bool {
+	return strings.HasPrefix(method, "admin_") || strings.HasPrefix(method, "debug_")
+}
+
+// LoadAPIKeys reads API keys from JSON file.
+func LoadAPIKeys(path string) ([]APIKey, error) {
+	blob, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(blob, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse API keys file: %w", err)
+	}
+	return keys, nil
+}
+
+// tenant holds the state of a single API key.
+type tenant struct {
+	name string
+	// methods is nil if all methods except admin methods are allowed
+	methods map[string]bool
+	admin   map[string]bool
+	limiter *rateLimiter
+}
+
+// authenticator authenticates requests using API keys and enforces per-key quotas.
+type authenticator struct {
+	tenants map[string]*tenant
+	metrics *AuthMetrics
+}
+
+func newAuthenticator(keys []APIKey, metrics *AuthMetrics) (*authenticator, error) {
+	if metrics == nil {
+		metrics = NopAuthMetrics()
+	}
+	a := &authenticator{
+		tenants: make(map[string]*tenant, len(keys)),
+		metrics: metrics,
+	}
+	for _, k := range keys {
+		if k.Key == "" {
+			return nil, fmt.Errorf("empty API key for %q", k.Name)
+		}
+		if _, ok := a.tenants[k.Key]; ok {
+			return nil, fmt.Errorf("duplicated API key for %q", k.Name)
+		}
+		if k.RateLimit < 0 || k.Burst < 0 {
+			return nil, fmt.Errorf("negative rate limit for %q", k.Name)
+		}
+		t := &tenant{name: k.Name, admin: make(map[string]bool, len(k.AdminMethods))}
+		if len(k.Methods) > 0 {
+			t.methods = make(map[string]bool, len(k.Methods))
+			for _, m := range k.Methods {
+				if isAdminMethod(m) {
+					return nil, fmt.Errorf("admin method %s allowed for %q must be listed in admin_methods", m, k.Name)
+				}
+				t.methods[m] = true
+			}
+		}
+		for _, m := range k.AdminMethods {
+			if !isAdminMethod(m) {
+				return nil, fmt.Errorf("method %s allowed for %q in admin_methods is not an admin method", m, k.Name)
+			}
+			t.admin[m] = true
+		}
+		if k.RateLimit > 0 {
+			burst := float64(k.Burst)
+			if burst == 0 {
+				burst = k.RateLimit
+			}
+			t.limiter = newRateLimiter(k.RateLimit, burst)
+		}
+		a.tenants[k.Key] = t
+	}
+	return a, nil
+}
+
+// authenticate returns tenant identified by API key passed in request.
+func (a *authenticator) authenticate(r *http.Request) (*tenant, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	if key == "" {
+		key = r.URL.Query().Get(APIKeyParam)
+	}
+	if key == "" {
+		a.metrics.Requests.With("key", "", "method", "", "result", "unauthenticated").Add(1)
+		return nil, ErrMissingAPIKey
+	}
+	t, ok := a.tenants[key]
+	if !ok {
+		a.metrics.Requests.With("key", "", "method", "", "result", "unauthenticated").Add(1)
+		return nil, ErrInvalidAPIKey
+	}
+	return t, nil
+}
+
+// authorize checks if tenant is allowed to call the method and records usage.
+func (a *authenticator) authorize(t *tenant, method string) error {
+	var err error
+	result := "ok"
+	if !t.allowed(method) {
+		err = ErrMethodNotAllowed
+		result = "forbidden"
+	} else if t.limiter != nil && !t.limiter.allow(time.Now()) {
+		err = ErrRateLimited
+		result = "rate_limited"
+	}
+	a.metrics.Requests.With("key", t.name, "method", method, "result", result).Add(1)
+	return err
+}
+
+// allowed returns true if tenant is allowed to call the method.
+func (t *tenant) allowed(method string) bool {
+	if isAdminMethod(method) {
+		return t.admin[method]
+	}
+	return t.methods == nil || t.methods[method]
+}
+
+// authStatusCode returns HTTP status code for authentication/authorization error.
+func authStatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrMethodNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, ErrRateLimited):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusUnauthorized
+	}
+}
+
+// rateLimiter is a simple token bucket.
+type rateLimiter struct {
+	mtx    sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate, burst float64) *rateLimiter {
+	return &rateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+func (l *rateLimiter) allow(now time.Time) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
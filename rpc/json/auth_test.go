@@ -0,0 +1,141 @@
+package json
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/gorilla/rpc/v2/json2"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys(t *testing.T) {
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestAPIKeys")
+	handler, err := GetHTTPHandler(local, log.TestingLogger(), WithAPIKeys([]APIKey{
+		{Name: "full", Key: "full-key"},
+		{Name: "limited", Key: "limited-key", Methods: []string{"health"}, RateLimit: 0.001, Burst: 1},
+	}, nil))
+	require.NoError(err)
+
+	jsonReq, err := json2.EncodeClientRequest("health", &healthArgs{})
+	require.NoError(err)
+	statusReq, err := json2.EncodeClientRequest("status", &statusArgs{})
+	require.NoError(err)
+
+	cases := []struct {
+		name         string
+		method       string
+		uri          string
+		body         []byte
+		key          string
+		bodyContains string
+	}{
+		{"missing key", http.MethodPost, "/", jsonReq, "", ErrMissingAPIKey.Error()},
+		{"invalid key", http.MethodPost, "/", jsonReq, "invalid", ErrInvalidAPIKey.Error()},
+		{"valid key", http.MethodPost, "/", jsonReq, "full-key", `"result":{}`},
+		{"valid key/REST", http.MethodGet, "/health?api_key=full-key", nil, "", `"result":{}`},
+		{"method not allowed", http.MethodPost, "/", statusReq, "limited-key", ErrMethodNotAllowed.Error()},
+		{"method not allowed/REST", http.MethodGet, "/status", nil, "limited-key", ErrMethodNotAllowed.Error()},
+		{"allowed method", http.MethodPost, "/", jsonReq, "limited-key", `"result":{}`},
+		{"rate limited", http.MethodPost, "/", jsonReq, "limited-key", ErrRateLimited.Error()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, c.uri, bytes.NewReader(c.body))
+			if c.key != "" {
+				req.Header.Set(APIKeyHeader, c.key)
+			}
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Contains(t, resp.Body.String(), c.bodyContains)
+		})
+	}
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	wsURL := strings.Replace(srv.URL, "http://", "ws://", 1) + "/websocket"
+
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.NotNil(resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL+"?api_key=full-key", nil)
+	require.NoError(err)
+	require.NotNil(resp)
+	defer func() {
+		_ = conn.Close()
+	}()
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+}
+
+func TestRateLimiter(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newRateLimiter(2, 2)
+	now := time.Now()
+	assert.True(l.allow(now))
+	assert.True(l.allow(now))
+	assert.False(l.allow(now))
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(l.allow(now))
+	assert.False(l.allow(now))
+
+	// tokens are capped at burst
+	now = now.Add(time.Hour)
+	assert.True(l.allow(now))
+	assert.True(l.allow(now))
+	assert.False(l.allow(now))
+}
+
+func TestAdminMethods(t *testing.T) {
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestAdminMethods")
+	_, err := GetHTTPHandler(local, log.TestingLogger(), WithAdminAPI())
+	require.ErrorIs(err, ErrAdminWithoutAPIKeys)
+
+	_, err = newAuthenticator([]APIKey{{Name: "admin", Key: "key", Methods: []string{"admin_halt"}}}, nil)
+	require.Error(err)
+	_, err = newAuthenticator([]APIKey{{Name: "admin", Key: "key", AdminMethods: []string{"status"}}}, nil)
+	require.Error(err)
+
+	auth, err := newAuthenticator([]APIKey{
+		{Name: "full", Key: "full-key"},
+		{Name: "admin", Key: "admin-key", Methods: []string{"health"}, AdminMethods: []string{"admin_halt_status"}},
+	}, nil)
+	require.NoError(err)
+	full, admin := auth.tenants["full-key"], auth.tenants["admin-key"]
+
+	// admin methods are denied unless allowed explicitly
+	require.NoError(auth.authorize(full, "status"))
+	require.ErrorIs(auth.authorize(full, "admin_halt"), ErrMethodNotAllowed)
+	require.ErrorIs(auth.authorize(full, "debug_traceTx"), ErrMethodNotAllowed)
+	require.NoError(auth.authorize(admin, "health"))
+	require.NoError(auth.authorize(admin, "admin_halt_status"))
+	require.ErrorIs(auth.authorize(admin, "admin_halt"), ErrMethodNotAllowed)
+	require.ErrorIs(auth.authorize(admin, "status"), ErrMethodNotAllowed)
+
+	// admin methods are served over URI routes too
+	handler, err := GetHTTPHandler(local, log.TestingLogger(), WithAPIKeys([]APIKey{
+		{Name: "admin", Key: "admin-key", AdminMethods: []string{"admin_halt_status"}},
+	}, nil), WithAdminAPI())
+	require.NoError(err)
+	req := httptest.NewRequest(http.MethodGet, "/admin_halt_status?api_key=admin-key", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(http.StatusOK, resp.Code)
+	require.Contains(resp.Body.String(), `"result":`)
+}
@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	cmjson "github.com/cometbft/cometbft/libs/json"
@@ -16,6 +18,7 @@ import (
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json2"
 
+	"github.com/rollkit/rollkit/store"
 	"github.com/rollkit/rollkit/third_party/log"
 )
 
@@ -24,27 +27,61 @@ type handler struct {
 	mux    *http.ServeMux
 	codec  rpc.Codec
 	logger log.Logger
+	// auth is nil if API keys are not required
+	auth *authenticator
+	// admin is true if admin methods are enabled, they require API keys
+	admin bool
+	// limit is nil if number of concurrently served requests is not limited
+	limit chan struct{}
+	// deprecatedCalls contains names of deprecated methods called so far
+	deprecatedCalls sync.Map
 }
 
 func newHandler(s *service, codec rpc.Codec, logger log.Logger) *handler {
-	mux := http.NewServeMux()
-	h := &handler{
+	return &handler{
 		srv:    s,
-		mux:    mux,
+		mux:    http.NewServeMux(),
 		codec:  codec,
 		logger: logger,
 	}
+}
 
-	mux.HandleFunc("/", h.serveJSONRPC)
-	mux.HandleFunc("/websocket", h.wsHandler)
-	for name, method := range s.methods {
-		logger.Debug("registering method", "name", name)
-		mux.HandleFunc("/"+name, h.newHandler(method))
+// registerRoutes registers JSON-RPC, WebSocket and URI routes of all methods of the service. It must be called
+// after handler options are applied, so that URI routes include methods enabled by options (e.g. admin methods).
+func (h *handler) registerRoutes() {
+	h.mux.HandleFunc("/", h.serveJSONRPC)
+	h.mux.HandleFunc("/websocket", h.wsHandler)
+	for name, method := range h.srv.methods {
+		h.logger.Debug("registering method", "name", name)
+		h.mux.HandleFunc("/"+name, h.newHandler(name, method))
+	}
+	// versioned routes
+	for version := apiV1; version <= latestAPIVersion; version++ {
+		prefix := versionPrefix(version)
+		h.mux.HandleFunc(prefix, h.serveJSONRPC)
+		h.mux.HandleFunc(prefix+"/", h.serveJSONRPC)
+		h.mux.HandleFunc(prefix+"/websocket", h.wsHandler)
+		for name, method := range h.srv.methods {
+			if servedIn(version, name) {
+				h.mux.HandleFunc(prefix+"/"+name, h.newHandler(name, method))
+			} else {
+				h.mux.HandleFunc(prefix+"/"+name, http.NotFound)
+			}
+		}
 	}
-
-	return h
 }
+
 func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// websocket connections are long-lived, they are not counted against the limit
+	if h.limit != nil && !strings.HasSuffix(r.URL.Path, "/websocket") {
+		select {
+		case h.limit <- struct{}{}:
+			defer func() { <-h.limit }()
+		case <-r.Context().Done():
+			http.Error(w, "server is busy", http.StatusServiceUnavailable)
+			return
+		}
+	}
 	h.mux.ServeHTTP(w, r)
 }
 
@@ -72,9 +109,17 @@ func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsCo
 		codecReq.WriteError(w, http.StatusBadRequest, err)
 		return
 	}
-	methodSpec, ok := h.srv.methods[method]
+	version := requestVersion(r.URL.Path)
+	if wsConn != nil {
+		version = wsConn.version
+	}
+	methodSpec, ok := h.lookupMethod(version, method)
 	if !ok {
-		codecReq.WriteError(w, int(json2.E_NO_METHOD), err)
+		codecReq.WriteError(w, int(json2.E_NO_METHOD), fmt.Errorf("method not found: %s", method))
+		return
+	}
+	if err := h.authorize(r, wsConn, method); err != nil {
+		codecReq.WriteError(w, authStatusCode(err), err)
 		return
 	}
 
@@ -106,6 +151,7 @@ func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsCo
 	// Prevents Internet Explorer from MIME-sniffing a response away
 	// from the declared content-type
 	w.Header().Set("x-content-type-options", "nosniff")
+	h.warnDeprecated(w, method)
 
 	// Encode the response.
 	if errResult == nil {
@@ -121,8 +167,12 @@ func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsCo
 	}
 }
 
-func (h *handler) newHandler(methodSpec *method) func(http.ResponseWriter, *http.Request) {
+func (h *handler) newHandler(name string, methodSpec *method) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.authorize(r, nil, name); err != nil {
+			h.encodeAndWriteResponse(w, nil, err, int(json2.E_INVALID_REQ))
+			return
+		}
 		args := reflect.New(methodSpec.argsType)
 		values, err := url.ParseQuery(r.URL.RawQuery)
 		if err != nil {
@@ -174,12 +224,36 @@ func (h *handler) newHandler(methodSpec *method) func(http.ResponseWriter, *http
 		if errInter != nil {
 			statusCode = int(json2.E_INTERNAL)
 			err = errInter.(error)
+			if errors.Is(err, store.ErrHeightOutOfRange) {
+				statusCode = int(json2.E_BAD_PARAMS)
+			}
 		}
 
+		h.warnDeprecated(w, name)
 		h.encodeAndWriteResponse(w, rets[0].Interface(), err, statusCode)
 	}
 }
 
+// authorize checks if request is allowed to call the method, when API keys are required.
+//
+// WebSocket connections are authenticated once, when connection is established.
+func (h *handler) authorize(r *http.Request, wsConn *wsConn, method string) error {
+	if h.auth == nil {
+		return nil
+	}
+	var t *tenant
+	if wsConn != nil {
+		t = wsConn.tenant
+	} else {
+		var err error
+		t, err = h.auth.authenticate(r)
+		if err != nil {
+			return err
+		}
+	}
+	return h.auth.authorize(t, method)
+}
+
 func (h *handler) encodeAndWriteResponse(w http.ResponseWriter, result interface{}, errResult error, statusCode int) {
 	// Prevents Internet Explorer from MIME-sniffing a response away
 	// from the declared content-type
@@ -0,0 +1,45 @@
+package json
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+// AuthMetrics contains metrics related to RPC API keys usage.
+type AuthMetrics struct {
+	// Number of requests by API key, method and result.
+	Requests metrics.Counter
+}
+
+// PrometheusAuthMetrics returns AuthMetrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusAuthMetrics(namespace string, labelsAndValues ...string) *AuthMetrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &AuthMetrics{
+		Requests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "api_key_requests",
+			Help:      "Number of requests by API key, method and result.",
+		}, append(labels, "key", "method", "result")).With(labelsAndValues...),
+	}
+}
+
+// NopAuthMetrics returns no-op AuthMetrics.
+func NopAuthMetrics() *AuthMetrics {
+	return &AuthMetrics{
+		Requests: discard.NewCounter(),
+	}
+}
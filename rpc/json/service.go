@@ -4,24 +4,106 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmjson "github.com/cometbft/cometbft/libs/json"
 
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json2"
 
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/node"
 	"github.com/rollkit/rollkit/third_party/log"
+	rktypes "github.com/rollkit/rollkit/types"
 )
 
+// HandlerOption configures RPC handler.
+type HandlerOption func(*handler) error
+
+// WithAPIKeys enables authentication of requests with API keys. Each key can be limited
+// to selected methods and given rate of requests.
+func WithAPIKeys(keys []APIKey, metrics *AuthMetrics) HandlerOption {
+	return func(h *handler) error {
+		auth, err := newAuthenticator(keys, metrics)
+		if err != nil {
+			return err
+		}
+		h.auth = auth
+		return nil
+	}
+}
+
+// WithAdminAPI enables admin methods, if supported by the client. API keys are required with admin methods, see
+// WithAPIKeys, and each admin method must be allowed explicitly for the key (see APIKey.AdminMethods).
+func WithAdminAPI() HandlerOption {
+	return func(h *handler) error {
+		h.admin = true
+		if _, ok := h.srv.client.(adminClient); ok {
+			h.srv.methods["admin_halt"] = newMethod(h.srv.AdminHalt)
+			h.srv.methods["admin_halt_status"] = newMethod(h.srv.AdminHaltStatus)
+			h.srv.methods["admin_simulate_block"] = newMethod(h.srv.AdminSimulateBlock)
+			h.srv.methods["admin_misbehavior_ledger"] = newMethod(h.srv.AdminMisbehaviorLedger)
+			h.srv.methods["admin_store_stats"] = newMethod(h.srv.AdminStoreStats)
+		}
+		// tracing re-executes the block, so it's not exposed to public
+		if _, ok := h.srv.client.(debugClient); ok {
+			h.srv.methods["debug_traceTx"] = newMethod(h.srv.TraceTx)
+		}
+		return nil
+	}
+}
+
+// WithHTTPHandler serves additional HTTP handler at given path. When API keys are required,
+// requests are authorized as calls to method named after the path (without leading slash).
+func WithHTTPHandler(path string, httpHandler http.Handler) HandlerOption {
+	return func(h *handler) error {
+		name := strings.TrimPrefix(path, "/")
+		h.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if err := h.authorize(r, nil, name); err != nil {
+				http.Error(w, err.Error(), authStatusCode(err))
+				return
+			}
+			httpHandler.ServeHTTP(w, r)
+		})
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests limits number of concurrently served HTTP requests. Requests over the limit
+// wait for a free slot until they are canceled. Websocket connections are not limited.
+func WithMaxConcurrentRequests(n int) HandlerOption {
+	return func(h *handler) error {
+		if n <= 0 {
+			return fmt.Errorf("invalid maximum number of concurrent requests: %d", n)
+		}
+		h.limit = make(chan struct{}, n)
+		return nil
+	}
+}
+
 // GetHTTPHandler returns handler configured to serve Tendermint-compatible RPC.
-func GetHTTPHandler(l rpcclient.Client, logger log.Logger) (http.Handler, error) {
-	return newHandler(newService(l, logger), json2.NewCodec(), logger), nil
+func GetHTTPHandler(l rpcclient.Client, logger log.Logger, opts ...HandlerOption) (http.Handler, error) {
+	h := newHandler(newService(l, logger), json2.NewCodec(), logger)
+	for _, opt := range opts {
+		if err := opt(h); err != nil {
+			return nil, err
+		}
+	}
+	if h.admin && h.auth == nil {
+		return nil, ErrAdminWithoutAPIKeys
+	}
+	h.registerRoutes()
+	return h, nil
 }
 
 type method struct {
@@ -86,9 +168,173 @@ func newService(c rpcclient.Client, l log.Logger) *service {
 		"abci_info":            newMethod(s.ABCIInfo),
 		"broadcast_evidence":   newMethod(s.BroadcastEvidence),
 	}
+	if _, ok := c.(performanceClient); ok {
+		s.methods["proposer_performance"] = newMethod(s.ProposerPerformance)
+	}
+	if _, ok := c.(finalityStatsClient); ok {
+		s.methods["finality_stats"] = newMethod(s.FinalityStats)
+	}
+	if _, ok := c.(nodeStateDumper); ok {
+		s.methods["dump_node_state"] = newMethod(s.DumpNodeState)
+	}
+	if _, ok := c.(stateClient); ok {
+		s.methods["state"] = newMethod(s.State)
+	}
+	if _, ok := c.(finalityClient); ok {
+		s.methods["subscribe_tx_finality"] = newMethod(s.SubscribeTxFinality)
+	}
+	if _, ok := c.(gasEstimator); ok {
+		s.methods["estimate_gas"] = newMethod(s.EstimateGas)
+	}
+	if _, ok := c.(batchBroadcaster); ok {
+		s.methods["broadcast_txs"] = newMethod(s.BroadcastTxs)
+	}
+	if _, ok := c.(bundleBroadcaster); ok {
+		s.methods["broadcast_bundle"] = newMethod(s.BroadcastBundle)
+	}
+	if _, ok := c.(quarantineClient); ok {
+		s.methods["da_quarantine"] = newMethod(s.DAQuarantine)
+	}
+	if _, ok := c.(daCertificateClient); ok {
+		s.methods["da_inclusion_certificate"] = newMethod(s.DAInclusionCertificate)
+	}
+	if _, ok := c.(heightWaiter); ok {
+		s.methods["wait_for_height"] = newMethod(s.WaitForHeight)
+	}
+	if _, ok := c.(daInclusionWaiter); ok {
+		s.methods["wait_for_da_inclusion"] = newMethod(s.WaitForDAInclusion)
+	}
+	if _, ok := c.(blockByTimeClient); ok {
+		s.methods["block_by_time"] = newMethod(s.BlockByTime)
+	}
+	if _, ok := c.(chainInfoClient); ok {
+		s.methods["chain_info"] = newMethod(s.ChainInfo)
+	}
+	if _, ok := c.(dashboardClient); ok {
+		s.methods["dashboard"] = newMethod(s.Dashboard)
+	}
+	if _, ok := c.(preconfirmationClient); ok {
+		s.methods["broadcast_tx_preconf"] = newMethod(s.BroadcastTxPreconf)
+		s.methods["preconfirmation_evidence"] = newMethod(s.PreconfirmationEvidence)
+	}
+	if _, ok := c.(messagingClient); ok {
+		s.methods["outbound_messages"] = newMethod(s.OutboundMessages)
+		s.methods["relay_messages"] = newMethod(s.RelayMessages)
+	}
 	return &s
 }
 
+// debugClient is implemented by clients supporting debug API.
+type debugClient interface {
+	TraceTx(ctx context.Context, hash []byte) (*node.ResultTraceTx, error)
+}
+
+// performanceClient is implemented by clients exposing block production performance.
+type performanceClient interface {
+	ProposerPerformance(ctx context.Context) (*node.ResultProposerPerformance, error)
+}
+
+// finalityStatsClient is implemented by clients exposing latency between soft confirmation and DA inclusion.
+type finalityStatsClient interface {
+	FinalityStats(ctx context.Context) (*node.ResultFinalityStats, error)
+}
+
+// nodeStateDumper is implemented by clients exposing internal state of the node for debugging.
+type nodeStateDumper interface {
+	DumpNodeState(ctx context.Context) (*block.ManagerState, error)
+}
+
+// stateClient is implemented by clients serving historical state records.
+type stateClient interface {
+	State(ctx context.Context, height *int64) (*node.ResultState, error)
+}
+
+// finalityClient is implemented by clients supporting transaction finality notifications.
+type finalityClient interface {
+	SubscribeTxFinality(ctx context.Context, hash []byte) (<-chan node.TxFinalityEvent, error)
+}
+
+// gasEstimator is implemented by clients supporting gas and fee estimation.
+type gasEstimator interface {
+	EstimateGas(ctx context.Context, tx types.Tx) (*node.ResultEstimateGas, error)
+}
+
+// batchBroadcaster is implemented by clients supporting atomic submission of transaction batches.
+type batchBroadcaster interface {
+	BroadcastTxs(ctx context.Context, txs []types.Tx) (*node.ResultBroadcastTxs, error)
+}
+
+// bundleBroadcaster is implemented by clients supporting submission of transaction bundles to the sequencer.
+type bundleBroadcaster interface {
+	BroadcastBundle(ctx context.Context, txs []types.Tx) (*node.ResultBroadcastBundle, error)
+}
+
+// preconfirmationClient is implemented by clients supporting preconfirmations signed by the sequencer.
+type preconfirmationClient interface {
+	BroadcastTxPreconf(ctx context.Context, tx types.Tx) (*node.ResultBroadcastTxPreconf, error)
+	PreconfirmationEvidence(ctx context.Context, p rktypes.Preconfirmation) (*rktypes.PreconfirmationViolation, error)
+}
+
+// messagingClient is implemented by clients supporting cross-rollup messaging.
+type messagingClient interface {
+	OutboundMessages(ctx context.Context, height *int64) (*node.ResultOutboundMessages, error)
+	RelayMessages(ctx context.Context, proof rktypes.MessageProof) (*node.ResultRelayMessages, error)
+}
+
+// quarantineClient is implemented by clients exposing blobs retrieved from DA which failed validation.
+type quarantineClient interface {
+	DAQuarantine(ctx context.Context) (*node.ResultDAQuarantine, error)
+}
+
+// daCertificateClient is implemented by clients serving DA inclusion certificates of blocks.
+type daCertificateClient interface {
+	DAInclusionCertificate(ctx context.Context, height int64) (*rktypes.DAInclusionCertificate, error)
+}
+
+// heightWaiter is implemented by clients supporting waiting for blocks to be committed.
+type heightWaiter interface {
+	WaitForHeight(ctx context.Context, height int64) (*ctypes.ResultBlock, error)
+}
+
+// daInclusionWaiter is implemented by clients supporting waiting for blocks to be included in the DA.
+type daInclusionWaiter interface {
+	WaitForDAInclusion(ctx context.Context, height int64) (*node.ResultDAInclusion, error)
+}
+
+// blockByTimeClient is implemented by clients mapping timestamps to blocks.
+type blockByTimeClient interface {
+	BlockByTime(ctx context.Context, t time.Time) (*ctypes.ResultBlock, error)
+}
+
+// chainInfoClient is implemented by clients serving the descriptor of the chain.
+type chainInfoClient interface {
+	ChainInfo(ctx context.Context) (*node.ResultChainInfo, error)
+}
+
+// dashboardClient is implemented by clients serving aggregated data of the node for dashboards.
+type dashboardClient interface {
+	Dashboard(ctx context.Context) (*node.ResultDashboard, error)
+}
+
+// genesisHasher is implemented by clients reporting the canonical hash of genesis.
+type genesisHasher interface {
+	GenesisHash() cmbytes.HexBytes
+}
+
+// syncProfiler is implemented by clients reporting their sync profile.
+type syncProfiler interface {
+	SyncProfile(ctx context.Context) (string, []string)
+}
+
+// adminClient is implemented by clients supporting admin API.
+type adminClient interface {
+	ScheduleHalt(ctx context.Context, height uint64, haltTime uint64) (*node.ResultHaltStatus, error)
+	HaltStatus(ctx context.Context) (*node.ResultHaltStatus, error)
+	SimulateBlock(ctx context.Context) (*node.ResultSimulateBlock, error)
+	MisbehaviorLedger(ctx context.Context) (*node.ResultMisbehaviorLedger, error)
+	StoreStats(ctx context.Context) (*node.ResultStoreStats, error)
+}
+
 func (s *service) Subscribe(req *http.Request, args *subscribeArgs, wsConn *wsConn) (*ctypes.ResultSubscribe, error) {
 	// TODO(tzdybal): pass config and check subscriptions limits
 	// TODO(tzdybal): extract consts or configs
@@ -138,6 +384,41 @@ func (s *service) Subscribe(req *http.Request, args *subscribeArgs, wsConn *wsCo
 	return &ctypes.ResultSubscribe{}, nil
 }
 
+func (s *service) SubscribeTxFinality(req *http.Request, args *subscribeTxFinalityArgs, wsConn *wsConn) (*emptyResult, error) {
+	// subscription is dropped if transaction doesn't reach the final milestone in time
+	const txFinalitySubscriptionTimeout = 1 * time.Hour
+
+	// finality notifications are pushed, so they can't be delivered as a response to HTTP request
+	if wsConn == nil {
+		return nil, errors.New("subscribe_tx_finality is only available over WebSocket")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), txFinalitySubscriptionTimeout)
+	events, err := s.client.(finalityClient).SubscribeTxFinality(ctx, args.Hash)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	codecReq := wsConn.codecReq
+	go func() {
+		defer cancel()
+		for ev := range events {
+			btz := new(bytes.Buffer)
+			w := newResponseWriter(btz)
+			raw, err := cmjson.Marshal(ev)
+			if err != nil {
+				codecReq.WriteError(w, http.StatusInternalServerError, err)
+				wsConn.queue <- btz.Bytes()
+				return
+			}
+			codecReq.WriteResponse(w, raw)
+			wsConn.queue <- btz.Bytes()
+		}
+	}()
+
+	return &emptyResult{}, nil
+}
+
 func (s *service) Unsubscribe(req *http.Request, args *unsubscribeArgs) (*emptyResult, error) {
 	s.logger.Debug("unsubscribe from query", "remote", req.RemoteAddr, "query", args.Query)
 
@@ -167,8 +448,40 @@ func (s *service) Health(req *http.Request, args *healthArgs) (*ctypes.ResultHea
 	return s.client.Health(req.Context())
 }
 
-func (s *service) Status(req *http.Request, args *statusArgs) (*ctypes.ResultStatus, error) {
-	return s.client.Status(req.Context())
+func (s *service) Status(req *http.Request, args *statusArgs) (*node.ResultStatus, error) {
+	status, err := s.client.Status(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	res := &node.ResultStatus{
+		NodeInfo:      status.NodeInfo,
+		SyncInfo:      status.SyncInfo,
+		ValidatorInfo: status.ValidatorInfo,
+	}
+	if c, ok := s.client.(genesisHasher); ok {
+		res.GenesisHash = c.GenesisHash()
+	}
+	if c, ok := s.client.(syncProfiler); ok {
+		res.SyncProfile, res.Limitations = c.SyncProfile(req.Context())
+	}
+	return res, nil
+}
+
+func (s *service) ChainInfo(req *http.Request, args *chainInfoArgs) (*node.ResultChainInfo, error) {
+	res, err := s.client.(chainInfoClient).ChainInfo(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	res.RPCFeatures = make([]string, 0, len(s.methods))
+	for name := range s.methods {
+		res.RPCFeatures = append(res.RPCFeatures, name)
+	}
+	sort.Strings(res.RPCFeatures)
+	return res, nil
+}
+
+func (s *service) Dashboard(req *http.Request, args *dashboardArgs) (*node.ResultDashboard, error) {
+	return s.client.(dashboardClient).Dashboard(req.Context())
 }
 
 func (s *service) NetInfo(req *http.Request, args *netInfoArgs) (*ctypes.ResultNetInfo, error) {
@@ -209,6 +522,46 @@ func (s *service) BlockByHash(req *http.Request, args *blockByHashArgs) (*ctypes
 	return s.client.BlockByHash(req.Context(), args.Hash)
 }
 
+func (s *service) WaitForHeight(req *http.Request, args *waitForHeightArgs) (*ctypes.ResultBlock, error) {
+	ctx, cancel, err := waitContext(req, args.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	return s.client.(heightWaiter).WaitForHeight(ctx, int64(args.Height))
+}
+
+func (s *service) WaitForDAInclusion(req *http.Request, args *waitForHeightArgs) (*node.ResultDAInclusion, error) {
+	ctx, cancel, err := waitContext(req, args.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	return s.client.(daInclusionWaiter).WaitForDAInclusion(ctx, int64(args.Height))
+}
+
+// waitContext returns context of the request limited by optional timeout (e.g. "10s") of wait_for_* methods.
+// Clients cap the wait regardless of the timeout.
+func waitContext(req *http.Request, timeout string) (context.Context, context.CancelFunc, error) {
+	if timeout == "" {
+		return req.Context(), func() {}, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d <= 0 {
+		return nil, nil, fmt.Errorf("invalid timeout %q, expected positive duration (e.g. 10s)", timeout)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), d)
+	return ctx, cancel, nil
+}
+
+func (s *service) BlockByTime(req *http.Request, args *blockByTimeArgs) (*ctypes.ResultBlock, error) {
+	t, err := time.Parse(time.RFC3339Nano, args.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp, expected RFC 3339 time: %w", err)
+	}
+	return s.client.(blockByTimeClient).BlockByTime(req.Context(), t)
+}
+
 func (s *service) BlockResults(req *http.Request, args *blockResultsArgs) (*ctypes.ResultBlockResults, error) {
 	var height *int64
 	if args.Height != nil {
@@ -218,6 +571,15 @@ func (s *service) BlockResults(req *http.Request, args *blockResultsArgs) (*ctyp
 	return s.client.BlockResults(req.Context(), height)
 }
 
+func (s *service) State(req *http.Request, args *stateArgs) (*node.ResultState, error) {
+	var height *int64
+	if args.Height != nil {
+		h := int64(*args.Height)
+		height = &h
+	}
+	return s.client.(stateClient).State(req.Context(), height)
+}
+
 func (s *service) Commit(req *http.Request, args *commitArgs) (*ctypes.ResultCommit, error) {
 	var height *int64
 	if args.Height != nil {
@@ -349,6 +711,30 @@ func (s *service) BroadcastTxAsync(req *http.Request, args *broadcastTxAsyncArgs
 	return s.client.BroadcastTxAsync(req.Context(), args.Tx)
 }
 
+func (s *service) BroadcastTxs(req *http.Request, args *broadcastTxsArgs) (*node.ResultBroadcastTxs, error) {
+	return s.client.(batchBroadcaster).BroadcastTxs(req.Context(), args.Txs)
+}
+
+func (s *service) BroadcastBundle(req *http.Request, args *broadcastBundleArgs) (*node.ResultBroadcastBundle, error) {
+	return s.client.(bundleBroadcaster).BroadcastBundle(req.Context(), args.Txs)
+}
+
+func (s *service) BroadcastTxPreconf(req *http.Request, args *broadcastTxPreconfArgs) (*node.ResultBroadcastTxPreconf, error) {
+	return s.client.(preconfirmationClient).BroadcastTxPreconf(req.Context(), args.Tx)
+}
+
+func (s *service) PreconfirmationEvidence(req *http.Request, args *preconfirmationEvidenceArgs) (*rktypes.PreconfirmationViolation, error) {
+	return s.client.(preconfirmationClient).PreconfirmationEvidence(req.Context(), args.Preconfirmation)
+}
+
+func (s *service) OutboundMessages(req *http.Request, args *outboundMessagesArgs) (*node.ResultOutboundMessages, error) {
+	return s.client.(messagingClient).OutboundMessages(req.Context(), (*int64)(args.Height))
+}
+
+func (s *service) RelayMessages(req *http.Request, args *relayMessagesArgs) (*node.ResultRelayMessages, error) {
+	return s.client.(messagingClient).RelayMessages(req.Context(), args.Proof)
+}
+
 // abci API
 func (s *service) ABCIQuery(req *http.Request, args *ABCIQueryArgs) (*ctypes.ResultABCIQuery, error) {
 	options := rpcclient.ABCIQueryOptions{}
@@ -367,6 +753,61 @@ func (s *service) ABCIInfo(req *http.Request, args *ABCIInfoArgs) (*ctypes.Resul
 	return s.client.ABCIInfo(req.Context())
 }
 
+// debug API
+func (s *service) TraceTx(req *http.Request, args *traceTxArgs) (*node.ResultTraceTx, error) {
+	return s.client.(debugClient).TraceTx(req.Context(), args.Hash)
+}
+
+// performance API
+func (s *service) DumpNodeState(req *http.Request, args *dumpNodeStateArgs) (*block.ManagerState, error) {
+	return s.client.(nodeStateDumper).DumpNodeState(req.Context())
+}
+
+func (s *service) ProposerPerformance(req *http.Request, args *proposerPerformanceArgs) (*node.ResultProposerPerformance, error) {
+	return s.client.(performanceClient).ProposerPerformance(req.Context())
+}
+
+func (s *service) FinalityStats(req *http.Request, args *finalityStatsArgs) (*node.ResultFinalityStats, error) {
+	return s.client.(finalityStatsClient).FinalityStats(req.Context())
+}
+
+func (s *service) DAQuarantine(req *http.Request, args *daQuarantineArgs) (*node.ResultDAQuarantine, error) {
+	return s.client.(quarantineClient).DAQuarantine(req.Context())
+}
+
+func (s *service) DAInclusionCertificate(req *http.Request, args *daInclusionCertificateArgs) (*rktypes.DAInclusionCertificate, error) {
+	return s.client.(daCertificateClient).DAInclusionCertificate(req.Context(), int64(args.Height))
+}
+
+// fee API
+func (s *service) EstimateGas(req *http.Request, args *estimateGasArgs) (*node.ResultEstimateGas, error) {
+	return s.client.(gasEstimator).EstimateGas(req.Context(), args.Tx)
+}
+
+// admin API
+func (s *service) AdminHalt(req *http.Request, args *adminHaltArgs) (*node.ResultHaltStatus, error) {
+	if args.Height < 0 || args.Time < 0 {
+		return nil, errors.New("halt height and time must not be negative")
+	}
+	return s.client.(adminClient).ScheduleHalt(req.Context(), uint64(args.Height), uint64(args.Time))
+}
+
+func (s *service) AdminHaltStatus(req *http.Request, args *adminHaltStatusArgs) (*node.ResultHaltStatus, error) {
+	return s.client.(adminClient).HaltStatus(req.Context())
+}
+
+func (s *service) AdminSimulateBlock(req *http.Request, args *adminSimulateBlockArgs) (*node.ResultSimulateBlock, error) {
+	return s.client.(adminClient).SimulateBlock(req.Context())
+}
+
+func (s *service) AdminMisbehaviorLedger(req *http.Request, args *adminMisbehaviorLedgerArgs) (*node.ResultMisbehaviorLedger, error) {
+	return s.client.(adminClient).MisbehaviorLedger(req.Context())
+}
+
+func (s *service) AdminStoreStats(req *http.Request, args *adminStoreStatsArgs) (*node.ResultStoreStats, error) {
+	return s.client.(adminClient).StoreStats(req.Context())
+}
+
 // evidence API
 func (s *service) BroadcastEvidence(req *http.Request, args *broadcastEvidenceArgs) (*ctypes.ResultBroadcastEvidence, error) {
 	return s.client.BroadcastEvidence(req.Context(), args.Evidence)
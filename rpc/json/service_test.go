@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -17,6 +18,7 @@ import (
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/p2p"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/rollkit/rollkit/test/mocks"
@@ -46,6 +48,180 @@ func TestHandlerMapping(t *testing.T) {
 	assert.Equal(http.StatusOK, resp.Code)
 }
 
+func TestMaxConcurrentRequests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	})
+
+	_, local := getRPC(t, "TestMaxConcurrentRequests")
+	handler, err := GetHTTPHandler(local, log.TestingLogger(), WithHTTPHandler("/slow", slow), WithMaxConcurrentRequests(1))
+	require.NoError(err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+	}()
+	<-started
+
+	// request over the limit waits for a free slot until it's canceled
+	jsonReq, err := json2.EncodeClientRequest("health", &healthArgs{})
+	require.NoError(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonReq)).WithContext(ctx))
+	assert.Equal(http.StatusServiceUnavailable, resp.Code)
+
+	close(release)
+	<-done
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonReq)))
+	assert.Equal(http.StatusOK, resp.Code)
+
+	_, err = GetHTTPHandler(local, log.TestingLogger(), WithMaxConcurrentRequests(0))
+	assert.Error(err)
+}
+
+func TestEstimateGas(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestEstimateGas")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	jsonReq, err := json2.EncodeClientRequest("estimate_gas", &estimateGasArgs{Tx: []byte{0xDE, 0xAD}})
+	require.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonReq))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.Contains(resp.Body.String(), `"gas_wanted":"1000"`)
+}
+
+func TestChainInfo(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestChainInfo")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, "/chain_info", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	assert.Equal(http.StatusOK, resp.Code)
+	body := resp.Body.String()
+	assert.Contains(body, `"chain_id":"TestChainInfo"`)
+	assert.Contains(body, fmt.Sprintf(`"genesis_hash":"%s"`, local.(genesisHasher).GenesisHash()))
+	assert.Contains(body, `"mode":"aggregator"`)
+	assert.Contains(body, `"chain_info"`)
+	assert.Contains(body, `"broadcast_txs"`)
+}
+
+func TestDashboard(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestDashboard")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	assert.Equal(http.StatusOK, resp.Code)
+	body := resp.Body.String()
+	assert.Contains(body, `"chain_id":"TestDashboard"`)
+	assert.Contains(body, `"mode":"aggregator"`)
+	assert.Contains(body, `"recent_blocks"`)
+	assert.Contains(body, `"pending_headers"`)
+}
+
+func TestBlockByTime(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestBlockByTime")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/block_by_time?timestamp=yesterday", nil))
+	assert.Contains(resp.Body.String(), "invalid timestamp")
+
+	// there are no blocks yet
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/block_by_time?timestamp=2024-01-01T00:00:00Z", nil))
+	assert.Contains(resp.Body.String(), "no blocks at or before")
+}
+
+func TestWaitTimeout(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestWaitTimeout")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	for _, method := range []string{"wait_for_height", "wait_for_da_inclusion"} {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/"+method+"?height=1&timeout=soon", nil))
+		assert.Contains(resp.Body.String(), "invalid timeout")
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/"+method+"?height=100&timeout=10ms", nil))
+		assert.Contains(resp.Body.String(), "context deadline exceeded")
+	}
+}
+
+func TestDAQuarantine(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestDAQuarantine")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, "/da_quarantine", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.Contains(resp.Body.String(), `"blobs":[]`)
+}
+
+func TestBroadcastTxs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestBroadcastTxs")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	jsonReq, err := json2.EncodeClientRequest("broadcast_txs", &broadcastTxsArgs{Txs: []types.Tx{[]byte("tx1"), []byte("tx2")}})
+	require.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonReq))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.Contains(resp.Body.String(), `"accepted":true`)
+	assert.Contains(resp.Body.String(), fmt.Sprintf(`"hash":"%X"`, types.Tx("tx2").Hash()))
+}
+
 func TestREST(t *testing.T) {
 	txSearchParams := url.Values{}
 	txSearchParams.Set("query", "message.sender='cosmos1njr26e02fjcq3schxstv458a3w5szp678h23dh'")
@@ -459,3 +635,46 @@ func mustTimeParse(str string) time.Time {
 	}
 	return t
 }
+
+func TestAPIVersions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestAPIVersions")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	jsonReq, err := json2.EncodeClientRequest("dump_consensus_state", &dumpConsensusStateArgs{})
+	require.NoError(err)
+
+	// unversioned path serves v1
+	for _, path := range []string{"/", "/v1"} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(jsonReq))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		assert.Equal("true", resp.Header().Get("Deprecation"))
+		assert.Contains(resp.Header().Get("Warning"), "dump_consensus_state is deprecated")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v2", bytes.NewReader(jsonReq))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Empty(resp.Header().Get("Deprecation"))
+	assert.Contains(resp.Body.String(), "method not found")
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/consensus_state", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(`</v2/dump_node_state>; rel="successor-version"`, resp.Header().Get("Link"))
+
+	req = httptest.NewRequest(http.MethodGet, "/v2/consensus_state", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(http.StatusNotFound, resp.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/v2/health", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(http.StatusOK, resp.Code)
+	assert.Empty(resp.Header().Get("Deprecation"))
+}
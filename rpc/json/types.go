@@ -9,12 +9,18 @@ import (
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/types"
 	"github.com/gorilla/rpc/v2/json2"
+
+	rktypes "github.com/rollkit/rollkit/types"
 )
 
 type subscribeArgs struct {
 	Query *string `json:"query"`
 }
 
+type subscribeTxFinalityArgs struct {
+	Hash []byte `json:"hash"`
+}
+
 type unsubscribeArgs struct {
 	Query *string `json:"query"`
 }
@@ -26,6 +32,10 @@ type healthArgs struct {
 }
 type statusArgs struct {
 }
+type chainInfoArgs struct {
+}
+type dashboardArgs struct {
+}
 type netInfoArgs struct {
 }
 type blockchainInfoArgs struct {
@@ -47,6 +57,15 @@ type blockByHashArgs struct {
 	Hash []byte `json:"hash"`
 }
 
+type waitForHeightArgs struct {
+	Height  StrInt64 `json:"height"`
+	Timeout string   `json:"timeout"`
+}
+
+type blockByTimeArgs struct {
+	Timestamp string `json:"timestamp"`
+}
+
 type blockResultsArgs struct {
 	Height *StrInt64 `json:"height"`
 }
@@ -55,6 +74,10 @@ type commitArgs struct {
 	Height *StrInt64 `json:"height"`
 }
 
+type stateArgs struct {
+	Height *StrInt64 `json:"height"`
+}
+
 type headerArgs struct {
 	Height *StrInt64 `json:"height"`
 }
@@ -67,6 +90,10 @@ type checkTxArgs struct {
 	Tx types.Tx `json:"tx"`
 }
 
+type estimateGasArgs struct {
+	Tx types.Tx `json:"tx"`
+}
+
 type txArgs struct {
 	Hash  []byte `json:"hash"`
 	Prove bool   `json:"prove"`
@@ -117,6 +144,27 @@ type broadcastTxSyncArgs struct {
 type broadcastTxAsyncArgs struct {
 	Tx types.Tx `json:"tx"`
 }
+type broadcastTxsArgs struct {
+	Txs []types.Tx `json:"txs"`
+}
+type broadcastBundleArgs struct {
+	Txs []types.Tx `json:"txs"`
+}
+type broadcastTxPreconfArgs struct {
+	Tx types.Tx `json:"tx"`
+}
+type preconfirmationEvidenceArgs struct {
+	Preconfirmation rktypes.Preconfirmation `json:"preconfirmation"`
+}
+
+// cross-rollup messaging API
+
+type outboundMessagesArgs struct {
+	Height *StrInt64 `json:"height"`
+}
+type relayMessagesArgs struct {
+	Proof rktypes.MessageProof `json:"proof"`
+}
 
 // abci API
 
@@ -132,6 +180,46 @@ type ABCIQueryArgs struct {
 type ABCIInfoArgs struct {
 }
 
+// debug API
+
+type traceTxArgs struct {
+	Hash []byte `json:"hash"`
+}
+
+// performance API
+
+type proposerPerformanceArgs struct {
+}
+type finalityStatsArgs struct {
+}
+type dumpNodeStateArgs struct {
+}
+type daQuarantineArgs struct {
+}
+
+type daInclusionCertificateArgs struct {
+	Height StrInt64 `json:"height"`
+}
+
+// admin API
+
+type adminHaltArgs struct {
+	Height StrInt64 `json:"height"`
+	Time   StrInt64 `json:"time"`
+}
+
+type adminHaltStatusArgs struct {
+}
+
+type adminSimulateBlockArgs struct {
+}
+
+type adminMisbehaviorLedgerArgs struct {
+}
+
+type adminStoreStatsArgs struct {
+}
+
 // evidence API
 
 type broadcastEvidenceArgs struct {
@@ -0,0 +1,86 @@
+package json
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Versions of RPC API. Each version is served under /v<N> path prefix, e.g. /v2/status, /v2/websocket or /v2/
+// for JSON-RPC requests. Unversioned paths serve v1 (CometBFT compatible API), so that existing clients keep
+// working.
+const (
+	apiV1 = 1
+	apiV2 = 2
+	// latestAPIVersion is the latest version of RPC API
+	latestAPIVersion = apiV2
+)
+
+// deprecation describes a deprecated method.
+type deprecation struct {
+	// removedIn is the first API version which doesn't serve the method.
+	removedIn int
+	// replacement is the method to use instead, if any.
+	replacement string
+}
+
+// deprecations lists deprecated methods. Versions preceding the version in which a method is removed serve it
+// with deprecation warnings in response headers.
+var deprecations = map[string]deprecation{
+	// there is no consensus state in Rollkit, these methods always fail
+	"dump_consensus_state": {removedIn: apiV2, replacement: "dump_node_state"},
+	"consensus_state":      {removedIn: apiV2, replacement: "dump_node_state"},
+	// evidence is not supported, broadcast evidence is ignored
+	"broadcast_evidence": {removedIn: apiV2},
+}
+
+// versionPrefix returns path prefix of API version.
+func versionPrefix(version int) string {
+	return "/v" + strconv.Itoa(version)
+}
+
+// requestVersion returns API version requested with path.
+func requestVersion(path string) int {
+	for v := apiV2; v <= latestAPIVersion; v++ {
+		prefix := versionPrefix(v)
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return v
+		}
+	}
+	return apiV1
+}
+
+// lookupMethod returns method with given name served by API version.
+func (h *handler) lookupMethod(version int, name string) (*method, bool) {
+	m, ok := h.srv.methods[name]
+	if !ok || !servedIn(version, name) {
+		return nil, false
+	}
+	return m, true
+}
+
+// servedIn returns true if method with given name is served by API version.
+func servedIn(version int, name string) bool {
+	d, ok := deprecations[name]
+	return !ok || version < d.removedIn
+}
+
+// warnDeprecated sets deprecation headers (Deprecation, Warning and successor Link) of response, if method is
+// deprecated. First call of every deprecated method is logged.
+func (h *handler) warnDeprecated(w http.ResponseWriter, name string) {
+	d, ok := deprecations[name]
+	if !ok {
+		return
+	}
+	msg := fmt.Sprintf("method %s is deprecated and removed in API v%d", name, d.removedIn)
+	w.Header().Set("Deprecation", "true")
+	if d.replacement != "" {
+		msg += ", use " + d.replacement
+		w.Header().Set("Link", fmt.Sprintf("<%s/%s>; rel=\"successor-version\"", versionPrefix(d.removedIn), d.replacement))
+	}
+	w.Header().Set("Warning", fmt.Sprintf("299 - %q", msg))
+	if _, logged := h.deprecatedCalls.LoadOrStore(name, struct{}{}); !logged {
+		h.logger.Info("deprecated RPC method called", "method", name, "removedIn", versionPrefix(d.removedIn))
+	}
+}
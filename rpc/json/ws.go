@@ -17,6 +17,10 @@ type wsConn struct {
 	codecReq rpc.CodecRequest
 	queue    chan []byte
 	logger   log.Logger
+	// tenant is set when API keys are required
+	tenant *tenant
+	// version is the API version requested when connection was established
+	version int
 }
 
 func (wsc *wsConn) sendLoop() {
@@ -44,6 +48,16 @@ func (h *handler) wsHandler(w http.ResponseWriter, r *http.Request) {
 		CheckOrigin:     func(r *http.Request) bool { return true },
 	}
 
+	var t *tenant
+	if h.auth != nil {
+		var err error
+		t, err = h.auth.authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), authStatusCode(err))
+			return
+		}
+	}
+
 	wsc, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		h.logger.Error("failed to update to WebSocket connection", "error", err)
@@ -58,9 +72,11 @@ func (h *handler) wsHandler(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	ws := &wsConn{
-		conn:   wsc,
-		queue:  make(chan []byte),
-		logger: h.logger,
+		conn:    wsc,
+		queue:   make(chan []byte),
+		logger:  h.logger,
+		tenant:  t,
+		version: requestVersion(r.URL.Path),
 	}
 	go ws.sendLoop()
 
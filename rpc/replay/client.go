@@ -0,0 +1,61 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// Client is a client of EventReplay gRPC service.
+type Client struct {
+	client pb.EventReplayClient
+}
+
+// NewClient returns Client using given connection.
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{client: pb.NewEventReplayClient(conn)}
+}
+
+// ReplayEvents requests events matching req, calling handle for each received response.
+// Streaming is stopped on first error returned by handle.
+func (c *Client) ReplayEvents(ctx context.Context, req *pb.ReplayEventsRequest, handle func(*pb.ReplayEventsResponse) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client.ReplayEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+	return receive(stream.Recv, handle)
+}
+
+// SubscribeTxFinality requests finality milestones of transaction, calling handle for each received response.
+// It returns after the final milestone is received, or on first error returned by handle.
+func (c *Client) SubscribeTxFinality(ctx context.Context, req *pb.SubscribeTxFinalityRequest, handle func(*pb.TxFinalityResponse) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client.SubscribeTxFinality(ctx, req)
+	if err != nil {
+		return err
+	}
+	return receive(stream.Recv, handle)
+}
+
+// receive calls handle for each message received from server stream, until the stream ends.
+func receive[Resp any](recv func() (*Resp, error), handle func(*Resp) error) error {
+	for {
+		resp, err := recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := handle(resp); err != nil {
+			return err
+		}
+	}
+}
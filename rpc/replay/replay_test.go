@@ -0,0 +1,201 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/test/mocks"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	req := &pb.ReplayEventsRequest{FromHeight: 1, ToHeight: 10, Query: "tm.event='Tx'"}
+	b, err := req.Marshal()
+	require.NoError(t, err)
+	var decodedReq pb.ReplayEventsRequest
+	require.NoError(t, decodedReq.Unmarshal(b))
+	assert.Equal(t, *req, decodedReq)
+
+	resp := &pb.ReplayEventsResponse{
+		Height:  3,
+		TxHash:  []byte{1, 2, 3},
+		TxIndex: 2,
+		Events: []abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: "100", Index: true}}},
+			{Type: "message"},
+		},
+	}
+	b, err = resp.Marshal()
+	require.NoError(t, err)
+	var decodedResp pb.ReplayEventsResponse
+	require.NoError(t, decodedResp.Unmarshal(b))
+	assert.Equal(t, *resp, decodedResp)
+
+	assert.Error(t, decodedResp.Unmarshal([]byte{0x22, 0x05, 0x01}))
+
+	finalityReq := &pb.SubscribeTxFinalityRequest{TxHash: []byte{1, 2, 3}}
+	b, err = finalityReq.Marshal()
+	require.NoError(t, err)
+	var decodedFinalityReq pb.SubscribeTxFinalityRequest
+	require.NoError(t, decodedFinalityReq.Unmarshal(b))
+	assert.Equal(t, *finalityReq, decodedFinalityReq)
+
+	finalityResp := &pb.TxFinalityResponse{TxHash: []byte{1, 2, 3}, Stage: "soft_block", Height: 5, TxIndex: 1}
+	b, err = finalityResp.Marshal()
+	require.NoError(t, err)
+	var decodedFinalityResp pb.TxFinalityResponse
+	require.NoError(t, decodedFinalityResp.Unmarshal(b))
+	assert.Equal(t, *finalityResp, decodedFinalityResp)
+}
+
+func TestReplayEvents(t *testing.T) {
+	txs := cmtypes.Txs{cmtypes.Tx("tx0"), cmtypes.Tx("tx1")}
+	transfer := abci.Event{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: "100"}}}
+	blockEvent := abci.Event{Type: "rewards", Attributes: []abci.EventAttribute{{Key: "validator", Value: "val"}}}
+
+	client := &mocks.Client{}
+	client.On("Status", mock.Anything).Return(&ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{EarliestBlockHeight: 1, LatestBlockHeight: 2}}, nil)
+	for _, h := range []int64{1, 2} {
+		height := h
+		client.On("Block", mock.Anything, &height).Return(&ctypes.ResultBlock{
+			Block: &cmtypes.Block{Header: cmtypes.Header{Height: height}, Data: cmtypes.Data{Txs: txs}},
+		}, nil)
+		client.On("BlockResults", mock.Anything, &height).Return(&ctypes.ResultBlockResults{
+			Height:              height,
+			TxsResults:          []*abci.ExecTxResult{{}, {Events: []abci.Event{transfer}}},
+			FinalizeBlockEvents: []abci.Event{blockEvent},
+		}, nil)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	srv := NewServer(client, "", log.TestingLogger())
+	grpcServer := grpc.NewServer()
+	pb.RegisterEventReplayServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	replayClient := NewClient(conn)
+
+	replay := func(req *pb.ReplayEventsRequest) ([]*pb.ReplayEventsResponse, error) {
+		var resps []*pb.ReplayEventsResponse
+		err := replayClient.ReplayEvents(context.Background(), req, func(resp *pb.ReplayEventsResponse) error {
+			resps = append(resps, resp)
+			return nil
+		})
+		return resps, err
+	}
+
+	cases := []struct {
+		name     string
+		req      *pb.ReplayEventsRequest
+		expected []*pb.ReplayEventsResponse
+	}{
+		{"all events", &pb.ReplayEventsRequest{}, []*pb.ReplayEventsResponse{
+			{Height: 1, Events: []abci.Event{blockEvent}},
+			{Height: 1, TxHash: txs[1].Hash(), TxIndex: 1, Events: []abci.Event{transfer}},
+			{Height: 2, Events: []abci.Event{blockEvent}},
+			{Height: 2, TxHash: txs[1].Hash(), TxIndex: 1, Events: []abci.Event{transfer}},
+		}},
+		{"tx events in range", &pb.ReplayEventsRequest{FromHeight: 2, ToHeight: 2, Query: "tm.event='Tx' AND transfer.amount=100"}, []*pb.ReplayEventsResponse{
+			{Height: 2, TxHash: txs[1].Hash(), TxIndex: 1, Events: []abci.Event{transfer}},
+		}},
+		{"block events", &pb.ReplayEventsRequest{Query: "rewards.validator='val' AND block.height=1"}, []*pb.ReplayEventsResponse{
+			{Height: 1, Events: []abci.Event{blockEvent}},
+		}},
+		{"no matches", &pb.ReplayEventsRequest{Query: "transfer.amount=1"}, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resps, err := replay(c.req)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, resps)
+		})
+	}
+
+	_, err = replay(&pb.ReplayEventsRequest{Query: "invalid query ="})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = replay(&pb.ReplayEventsRequest{FromHeight: 3, ToHeight: 2})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// mockFinalityClient adds transaction finality notifications to mocked client.
+type mockFinalityClient struct {
+	*mocks.Client
+	events []node.TxFinalityEvent
+}
+
+func (c *mockFinalityClient) SubscribeTxFinality(_ context.Context, hash []byte) (<-chan node.TxFinalityEvent, error) {
+	if len(hash) == 0 {
+		return nil, errors.New("empty hash")
+	}
+	out := make(chan node.TxFinalityEvent, len(c.events))
+	for _, ev := range c.events {
+		out <- ev
+	}
+	close(out)
+	return out, nil
+}
+
+func TestSubscribeTxFinality(t *testing.T) {
+	hash := cmtypes.Tx("tx").Hash()
+	client := &mockFinalityClient{Client: &mocks.Client{}, events: []node.TxFinalityEvent{
+		{Hash: hash, Stage: node.TxStageSequenced},
+		{Hash: hash, Stage: node.TxStageSoftBlock, Height: 3, Index: 1},
+		{Hash: hash, Stage: node.TxStageDAIncluded, Height: 3, Index: 1},
+	}}
+
+	listener := bufconn.Listen(1 << 20)
+	srv := NewServer(client, "", log.TestingLogger())
+	grpcServer := grpc.NewServer()
+	pb.RegisterEventReplayServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	replayClient := NewClient(conn)
+
+	var resps []*pb.TxFinalityResponse
+	err = replayClient.SubscribeTxFinality(context.Background(), &pb.SubscribeTxFinalityRequest{TxHash: hash}, func(resp *pb.TxFinalityResponse) error {
+		resps = append(resps, resp)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.TxFinalityResponse{
+		{TxHash: hash, Stage: node.TxStageSequenced},
+		{TxHash: hash, Stage: node.TxStageSoftBlock, Height: 3, TxIndex: 1},
+		{TxHash: hash, Stage: node.TxStageDAIncluded, Height: 3, TxIndex: 1},
+	}, resps)
+
+	err = replayClient.SubscribeTxFinality(context.Background(), &pb.SubscribeTxFinalityRequest{}, func(*pb.TxFinalityResponse) error { return nil })
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// client without finality notifications
+	srv.client = &mocks.Client{}
+	err = replayClient.SubscribeTxFinality(context.Background(), &pb.SubscribeTxFinalityRequest{TxHash: hash}, func(*pb.TxFinalityResponse) error { return nil })
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
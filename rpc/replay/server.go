@@ -0,0 +1,211 @@
+// Package replay implements gRPC service re-emitting events from stored block results.
+//
+// It allows downstream indexers that lost data to backfill it, without syncing their own node.
+// The service also streams finality milestones of transactions, so bridges and exchanges don't
+// have to poll for transaction status.
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cmquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/libs/service"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// finalityClient is implemented by clients supporting transaction finality notifications.
+type finalityClient interface {
+	SubscribeTxFinality(ctx context.Context, hash []byte) (<-chan node.TxFinalityEvent, error)
+}
+
+// Server serves EventReplay gRPC service, defined in proto/rollkit/replay.proto.
+type Server struct {
+	*service.BaseService
+
+	addr   string
+	client rpcclient.Client
+	server *grpc.Server
+}
+
+// NewServer creates new instance of Server, listening on given address (e.g. "tcp://0.0.0.0:9090").
+func NewServer(client rpcclient.Client, addr string, logger log.Logger) *Server {
+	srv := &Server{
+		addr:   addr,
+		client: client,
+	}
+	srv.BaseService = service.NewBaseService(logger, "EventReplay", srv)
+	return srv
+}
+
+// OnStart is called when Server is started (see service.BaseService for details).
+func (s *Server) OnStart() error {
+	proto, addr := "tcp", s.addr
+	if parts := strings.SplitN(s.addr, "://", 2); len(parts) == 2 {
+		proto, addr = parts[0], parts[1]
+	}
+	listener, err := net.Listen(proto, addr)
+	if err != nil {
+		return err
+	}
+	s.server = grpc.NewServer()
+	pb.RegisterEventReplayServer(s.server, s)
+
+	s.Logger.Info("serving gRPC", "service", "EventReplay", "listen address", listener.Addr())
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.Logger.Error("error while serving gRPC", "error", err)
+		}
+	}()
+	return nil
+}
+
+// OnStop is called when Server is stopped (see service.BaseService for details).
+func (s *Server) OnStop() {
+	s.server.GracefulStop()
+}
+
+// ReplayEvents streams events from blocks in requested range, matching the query.
+func (s *Server) ReplayEvents(req *pb.ReplayEventsRequest, stream pb.EventReplay_ReplayEventsServer) error {
+	return replayEvents(stream.Context(), s.client, req, stream.Send)
+}
+
+// SubscribeTxFinality streams finality milestones reached by requested transaction.
+func (s *Server) SubscribeTxFinality(req *pb.SubscribeTxFinalityRequest, stream pb.EventReplay_SubscribeTxFinalityServer) error {
+	client, ok := s.client.(finalityClient)
+	if !ok {
+		return status.Error(codes.Unimplemented, "transaction finality notifications are not supported")
+	}
+	ctx := stream.Context()
+	events, err := client.SubscribeTxFinality(ctx, req.TxHash)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to subscribe: %v", err)
+	}
+	for ev := range events {
+		if err := stream.Send(&pb.TxFinalityResponse{
+			TxHash:  ev.Hash,
+			Stage:   ev.Stage,
+			Height:  uint64(ev.Height), //nolint:gosec
+			TxIndex: ev.Index,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+func replayEvents(ctx context.Context, client rpcclient.Client, req *pb.ReplayEventsRequest, send func(*pb.ReplayEventsResponse) error) error {
+	var query *cmquery.Query
+	if req.Query != "" {
+		var err error
+		query, err = cmquery.New(req.Query)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+		}
+	}
+
+	fromHeight, toHeight := req.FromHeight, req.ToHeight
+	if fromHeight == 0 || toHeight == 0 {
+		// chain may start at initial height other than 1, and old blocks may be pruned
+		st, err := client.Status(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get available heights: %v", err)
+		}
+		if fromHeight == 0 {
+			fromHeight = uint64(max(st.SyncInfo.EarliestBlockHeight, 1)) //nolint:gosec
+		}
+		if toHeight == 0 {
+			toHeight = uint64(st.SyncInfo.LatestBlockHeight) //nolint:gosec
+		}
+	}
+	if fromHeight > toHeight {
+		return status.Errorf(codes.InvalidArgument, "from height %d is greater than to height %d", fromHeight, toHeight)
+	}
+
+	for h := fromHeight; h <= toHeight; h++ {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		height := int64(h) //nolint:gosec
+		results, err := client.BlockResults(ctx, &height)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "failed to load block results at height %d: %v", h, err)
+		}
+		block, err := client.Block(ctx, &height)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "failed to load block at height %d: %v", h, err)
+		}
+
+		blockResp := &pb.ReplayEventsResponse{Height: h, Events: results.FinalizeBlockEvents}
+		matches, err := matchEvents(query, blockResp, map[string][]string{
+			cmtypes.EventTypeKey:   {cmtypes.EventNewBlockEvents},
+			cmtypes.BlockHeightKey: {fmt.Sprint(h)},
+		})
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to match query: %v", err)
+		}
+		if matches {
+			if err := send(blockResp); err != nil {
+				return err
+			}
+		}
+
+		for i, txResult := range results.TxsResults {
+			if i >= len(block.Block.Txs) {
+				break
+			}
+			hash := types.TxHash(block.Block.Txs[i])
+			txResp := &pb.ReplayEventsResponse{Height: h, TxHash: hash, TxIndex: uint32(i), Events: txResult.Events} //nolint:gosec
+			matches, err := matchEvents(query, txResp, map[string][]string{
+				cmtypes.EventTypeKey: {cmtypes.EventTx},
+				cmtypes.TxHashKey:    {fmt.Sprintf("%X", hash)},
+				cmtypes.TxHeightKey:  {fmt.Sprint(h)},
+			})
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "failed to match query: %v", err)
+			}
+			if matches {
+				if err := send(txResp); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// matchEvents checks if response matches the query. Composite keys of events are added to given
+// reserved keys. Responses without events never match.
+func matchEvents(query *cmquery.Query, resp *pb.ReplayEventsResponse, keys map[string][]string) (bool, error) {
+	if len(resp.Events) == 0 {
+		return false, nil
+	}
+	for _, ev := range resp.Events {
+		if ev.Type == "" {
+			continue
+		}
+		for _, attr := range ev.Attributes {
+			if attr.Key == "" {
+				continue
+			}
+			key := ev.Type + "." + attr.Key
+			keys[key] = append(keys[key], attr.Value)
+		}
+	}
+	return query.Matches(keys)
+}
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/net/netutil"
 
 	"github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/rpc/graphql"
 	"github.com/rollkit/rollkit/rpc/json"
 )
 
@@ -26,16 +27,51 @@ type Server struct {
 	config *config.RPCConfig
 	client rpcclient.Client
 
-	server http.Server
+	server      http.Server
+	handlerOpts []json.HandlerOption
+}
+
+// ServerOption configures Server.
+type ServerOption func(*Server)
+
+// WithAPIKeys requires RPC requests to be authenticated with one of given API keys.
+func WithAPIKeys(keys []json.APIKey, metrics *json.AuthMetrics) ServerOption {
+	return func(s *Server) {
+		s.handlerOpts = append(s.handlerOpts, json.WithAPIKeys(keys, metrics))
+	}
+}
+
+// WithAdminAPI enables admin RPC methods.
+func WithAdminAPI() ServerOption {
+	return func(s *Server) {
+		s.handlerOpts = append(s.handlerOpts, json.WithAdminAPI())
+	}
+}
+
+// WithGraphQL enables GraphQL endpoint at /graphql.
+func WithGraphQL() ServerOption {
+	return func(s *Server) {
+		s.handlerOpts = append(s.handlerOpts, json.WithHTTPHandler("/graphql", graphql.NewHandler(s.client, s.Logger)))
+	}
+}
+
+// WithMaxConcurrentRequests limits number of concurrently served RPC requests.
+func WithMaxConcurrentRequests(n int) ServerOption {
+	return func(s *Server) {
+		s.handlerOpts = append(s.handlerOpts, json.WithMaxConcurrentRequests(n))
+	}
 }
 
 // NewServer creates new instance of Server with given configuration.
-func NewServer(node node.Node, config *config.RPCConfig, logger log.Logger) *Server {
+func NewServer(node node.Node, config *config.RPCConfig, logger log.Logger, opts ...ServerOption) *Server {
 	srv := &Server{
 		config: config,
 		client: node.GetClient(),
 	}
 	srv.BaseService = service.NewBaseService(logger, "RPC", srv)
+	for _, opt := range opts {
+		opt(srv)
+	}
 	return srv
 }
 
@@ -82,7 +118,7 @@ func (s *Server) startRPC() error {
 		listener = netutil.LimitListener(listener, s.config.MaxOpenConnections)
 	}
 
-	handler, err := json.GetHTTPHandler(s.client, s.Logger)
+	handler, err := json.GetHTTPHandler(s.client, s.Logger, s.handlerOpts...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,84 @@
+package state
+
+import (
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// txBundle is a bundle of transactions, which must be included in the same block in order, or not at all.
+type txBundle []cmtypes.TxKey
+
+// unwrapBundles replaces bundle transactions with transactions they wrap, and returns the bundles.
+// Malformed bundles are dropped.
+func (e *BlockExecutor) unwrapBundles(txs cmtypes.Txs) (cmtypes.Txs, []txBundle) {
+	var bundles []txBundle
+	unwrapped := make(cmtypes.Txs, 0, len(txs))
+	for _, tx := range txs {
+		if !types.IsBundleTx(types.Tx(tx)) {
+			unwrapped = append(unwrapped, tx)
+			continue
+		}
+		bundleTxs, err := types.UnwrapBundleTx(types.Tx(tx))
+		if err != nil {
+			e.logger.Error("dropping bundle", "hash", types.TxHash(tx), "error", err)
+			continue
+		}
+		bundle := make(txBundle, len(bundleTxs))
+		for i, bundleTx := range bundleTxs {
+			bundle[i] = cmtypes.Tx(bundleTx).Key()
+			unwrapped = append(unwrapped, cmtypes.Tx(bundleTx))
+		}
+		bundles = append(bundles, bundle)
+	}
+	return unwrapped, bundles
+}
+
+// enforceBundles removes transactions of bundles which are not included in txs completely and in order,
+// e.g. because application dropped some of them in PrepareProposal. It returns the remaining transactions
+// and the number of dropped bundles.
+func enforceBundles(txs cmtypes.Txs, bundles []txBundle) (cmtypes.Txs, int) {
+	if len(bundles) == 0 {
+		return txs, 0
+	}
+	positions := make(map[cmtypes.TxKey]int, len(txs))
+	for i, tx := range txs {
+		if _, ok := positions[tx.Key()]; !ok {
+			positions[tx.Key()] = i
+		}
+	}
+	dropped := make(map[cmtypes.TxKey]struct{})
+	droppedBundles := 0
+	for _, bundle := range bundles {
+		if bundleIncluded(bundle, positions) {
+			continue
+		}
+		droppedBundles++
+		for _, key := range bundle {
+			dropped[key] = struct{}{}
+		}
+	}
+	if droppedBundles == 0 {
+		return txs, 0
+	}
+	included := make(cmtypes.Txs, 0, len(txs))
+	for _, tx := range txs {
+		if _, ok := dropped[tx.Key()]; !ok {
+			included = append(included, tx)
+		}
+	}
+	return included, droppedBundles
+}
+
+// bundleIncluded returns true if all transactions of bundle are included, in order.
+func bundleIncluded(bundle txBundle, positions map[cmtypes.TxKey]int) bool {
+	last := -1
+	for _, key := range bundle {
+		pos, ok := positions[key]
+		if !ok || pos <= last {
+			return false
+		}
+		last = pos
+	}
+	return true
+}
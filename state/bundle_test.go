@@ -0,0 +1,83 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/proxy"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestCreateBlockWithBundles(t *testing.T) {
+	require := require.New(t)
+
+	// app drops transaction "drop"
+	app := &mocks.Application{}
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+			var txs [][]byte
+			for _, tx := range req.Txs {
+				if !bytes.Equal(tx, []byte("drop")) {
+					txs = append(txs, tx)
+				}
+			}
+			return &abci.ResponsePrepareProposal{Txs: txs}, nil
+		})
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client, proxy.NopMetrics()), 0)
+	executor := NewBlockExecutor([]byte("test address"), "TestCreateBlockWithBundles", mpool, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 1000, log.TestingLogger(), NopMetrics())
+
+	state := types.State{}
+	state.ConsensusParams.Block = &cmproto.BlockParams{MaxBytes: 1000, MaxGas: 100000}
+	vKey := ed25519.GenPrivKey()
+	state.Validators = cmtypes.NewValidatorSet([]*cmtypes.Validator{{Address: vKey.PubKey().Address(), PubKey: vKey.PubKey(), VotingPower: 100}})
+
+	included, err := types.NewBundleTx(types.Txs{types.Tx("a"), types.Tx("b")})
+	require.NoError(err)
+	dropped, err := types.NewBundleTx(types.Txs{types.Tx("c"), types.Tx("drop")})
+	require.NoError(err)
+	malformed := append(bytes.Clone(included), 0xFF)
+
+	txs := cmtypes.Txs{cmtypes.Tx("x"), cmtypes.Tx(included), cmtypes.Tx(dropped), cmtypes.Tx(malformed), cmtypes.Tx("y")}
+	_, data, err := executor.CreateBlock(context.Background(), 1, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, txs, time.Now())
+	require.NoError(err)
+	assert.Equal(t, types.Txs{types.Tx("x"), types.Tx("a"), types.Tx("b"), types.Tx("y")}, data.Txs)
+}
+
+func TestEnforceBundles(t *testing.T) {
+	a, b, c := cmtypes.Tx("a"), cmtypes.Tx("b"), cmtypes.Tx("c")
+	bundle := txBundle{a.Key(), b.Key()}
+
+	cases := []struct {
+		name     string
+		txs      cmtypes.Txs
+		expected cmtypes.Txs
+		dropped  int
+	}{
+		{"included", cmtypes.Txs{c, a, b}, cmtypes.Txs{c, a, b}, 0},
+		{"missing tx", cmtypes.Txs{a, c}, cmtypes.Txs{c}, 1},
+		{"reordered", cmtypes.Txs{b, c, a}, cmtypes.Txs{c}, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			txs, dropped := enforceBundles(tc.txs, []txBundle{bundle})
+			assert.Equal(t, tc.expected, txs)
+			assert.Equal(t, tc.dropped, dropped)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// DataSectionSource builds and verifies sections of block data of a type defined by the application, e.g.
+// references of blobs or SNARK commitments. It's implemented by node plugins.
+type DataSectionSource interface {
+	// Section returns payload of the section of the block at height with given transactions, built by the
+	// proposer. Empty payload omits the section from the block.
+	Section(ctx context.Context, height uint64, timestamp time.Time, txs types.Txs) ([]byte, error)
+	// Verify checks payload of the section of the block at height, e.g. while syncing. Payload is nil if the block
+	// doesn't have the section.
+	Verify(height uint64, timestamp time.Time, txs types.Txs, payload []byte) error
+}
+
+// SetDataSectionSource sets source of block data sections of given type, which must not be lower than
+// types.MinAppDataSectionType. It must be called before blocks are produced or synced. Sections of types without
+// sources are kept in blocks, but not verified.
+func (e *BlockExecutor) SetDataSectionSource(t types.DataSectionType, source DataSectionSource) error {
+	if t < types.MinAppDataSectionType {
+		return fmt.Errorf("data section type %d is reserved, application types start at %d", t, types.MinAppDataSectionType)
+	}
+	if e.sectionSources == nil {
+		e.sectionSources = make(map[types.DataSectionType]DataSectionSource)
+	}
+	e.sectionSources[t] = source
+	return nil
+}
+
+// sectionTypes returns types of sections with sources, in order.
+func (e *BlockExecutor) sectionTypes() []types.DataSectionType {
+	sectionTypes := make([]types.DataSectionType, 0, len(e.sectionSources))
+	for t := range e.sectionSources {
+		sectionTypes = append(sectionTypes, t)
+	}
+	sort.Slice(sectionTypes, func(i, j int) bool { return sectionTypes[i] < sectionTypes[j] })
+	return sectionTypes
+}
+
+// buildSections builds sections of the block with given transactions.
+func (e *BlockExecutor) buildSections(ctx context.Context, height uint64, timestamp time.Time, txs types.Txs) ([]types.DataSection, error) {
+	var sections []types.DataSection
+	for _, t := range e.sectionTypes() {
+		payload, err := e.sectionSources[t].Section(ctx, height, timestamp, txs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build data section %d: %w", t, err)
+		}
+		if len(payload) > 0 {
+			sections = append(sections, types.DataSection{Type: t, Payload: payload})
+		}
+	}
+	return sections, nil
+}
+
+// validateSections verifies sections of the block which types have sources.
+func (e *BlockExecutor) validateSections(header *types.SignedHeader, data *types.Data) error {
+	for _, t := range e.sectionTypes() {
+		payload, _ := data.Section(t)
+		if err := e.sectionSources[t].Verify(header.Height(), header.Time(), data.Txs, payload); err != nil {
+			return fmt.Errorf("invalid data section %d: %w", t, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/proxy"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+// countSource builds sections with the number of transactions, and omits the section from empty blocks.
+type countSource struct{}
+
+func (countSource) Section(_ context.Context, _ uint64, _ time.Time, txs types.Txs) ([]byte, error) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	return []byte{byte(len(txs))}, nil
+}
+
+func (countSource) Verify(_ uint64, _ time.Time, txs types.Txs, payload []byte) error {
+	if len(txs) == 0 && payload == nil {
+		return nil
+	}
+	if !bytes.Equal(payload, []byte{byte(len(txs))}) {
+		return errors.New("wrong count")
+	}
+	return nil
+}
+
+func TestCreateBlockWithDataSections(t *testing.T) {
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(prepareProposalResponse)
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client, proxy.NopMetrics()), 0)
+	executor := NewBlockExecutor([]byte("test address"), "TestCreateBlockWithDataSections", mpool, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 100, log.TestingLogger(), NopMetrics())
+	require.Error(executor.SetDataSectionSource(types.DataSectionIntermediateStateRoots, countSource{}))
+	require.NoError(executor.SetDataSectionSource(types.MinAppDataSectionType, countSource{}))
+
+	state := types.State{}
+	state.ConsensusParams.Block = &cmproto.BlockParams{MaxBytes: 100, MaxGas: 100000}
+	vKey := ed25519.GenPrivKey()
+	state.Validators = cmtypes.NewValidatorSet([]*cmtypes.Validator{{Address: vKey.PubKey().Address(), PubKey: vKey.PubKey(), VotingPower: 100}})
+
+	// empty block doesn't have the section
+	header, data, err := executor.CreateBlock(context.Background(), 1, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{}, time.Now())
+	require.NoError(err)
+	assert.Empty(t, data.Sections)
+	require.NoError(executor.validateSections(header, data))
+
+	header, data, err = executor.CreateBlock(context.Background(), 2, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{cmtypes.Tx("x"), cmtypes.Tx("y")}, time.Now())
+	require.NoError(err)
+	assert.Equal(t, []types.DataSection{{Type: types.MinAppDataSectionType, Payload: []byte{2}}}, data.Sections)
+	require.NoError(data.ValidateBasic())
+	require.NoError(executor.validateSections(header, data))
+
+	// synced blocks must have valid sections
+	data.Sections[0].Payload = []byte{3}
+	assert.Error(t, executor.validateSections(header, data))
+	data.Sections = nil
+	assert.Error(t, executor.validateSections(header, data))
+
+	// sections count towards the maximum block size
+	_, _, err = executor.CreateBlock(context.Background(), 3, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{make(cmtypes.Tx, 95)}, time.Now())
+	assert.Error(t, err)
+}
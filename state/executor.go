@@ -25,6 +25,18 @@ var ErrEmptyValSetGenerated = errors.New("applying the validator changes would r
 // ErrAddingValidatorToBased is returned when trying to add a validator to an empty validator set.
 var ErrAddingValidatorToBased = errors.New("cannot add validators to empty validator set")
 
+// AppHashMismatchError is returned when app hash of the block doesn't match the app hash
+// resulting from local execution of previous blocks.
+type AppHashMismatchError struct {
+	Height   uint64
+	Expected []byte
+	Actual   []byte
+}
+
+func (e *AppHashMismatchError) Error() string {
+	return fmt.Sprintf("AppHash mismatch at height %d: block has %X, local state has %X", e.Height, e.Expected, e.Actual)
+}
+
 // BlockExecutor creates and applies blocks and maintains state.
 type BlockExecutor struct {
 	proposerAddress []byte
@@ -36,6 +48,11 @@ type BlockExecutor struct {
 
 	eventBus *cmtypes.EventBus
 
+	// template defines system transactions of every block, nil if there are none
+	template *ProposalTemplate
+	// sectionSources build and verify application-defined sections of block data, by type
+	sectionSources map[types.DataSectionType]DataSectionSource
+
 	logger log.Logger
 
 	metrics *Metrics
@@ -58,6 +75,11 @@ func NewBlockExecutor(proposerAddress []byte, chainID string, mempool mempool.Me
 
 // InitChain calls InitChainSync using consensus connection to app.
 func (e *BlockExecutor) InitChain(genesis *cmtypes.GenesisDoc) (*abci.ResponseInitChain, error) {
+	return InitChain(context.Background(), e.proxyApp, genesis)
+}
+
+// InitChain calls InitChain on given app connection, e.g. to initialize application restarted with empty state.
+func InitChain(ctx context.Context, app proxy.AppConnConsensus, genesis *cmtypes.GenesisDoc) (*abci.ResponseInitChain, error) {
 	params := genesis.ConsensusParams
 
 	validators := make([]*cmtypes.Validator, len(genesis.Validators))
@@ -65,7 +87,7 @@ func (e *BlockExecutor) InitChain(genesis *cmtypes.GenesisDoc) (*abci.ResponseIn
 		validators[i] = cmtypes.NewValidator(v.PubKey, v.Power)
 	}
 
-	return e.proxyApp.InitChain(context.Background(), &abci.RequestInitChain{
+	return app.InitChain(ctx, &abci.RequestInitChain{
 		Time:    genesis.GenesisTime,
 		ChainId: genesis.ChainID,
 		ConsensusParams: &cmproto.ConsensusParams{
@@ -94,8 +116,21 @@ func (e *BlockExecutor) InitChain(genesis *cmtypes.GenesisDoc) (*abci.ResponseIn
 	})
 }
 
+// SetProposalTemplate sets template of system transactions included in every block.
+func (e *BlockExecutor) SetProposalTemplate(template *ProposalTemplate) {
+	e.template = template
+}
+
+// SetSystemTxSource sets source of system transactions of given kind, defined by proposal template.
+func (e *BlockExecutor) SetSystemTxSource(kind string, source SystemTxSource) error {
+	if e.template == nil {
+		return errors.New("block proposal template is not defined")
+	}
+	return e.template.SetSource(kind, source)
+}
+
 // CreateBlock reaps transactions from mempool and builds a block.
-func (e *BlockExecutor) CreateBlock(height uint64, lastSignature *types.Signature, lastExtendedCommit abci.ExtendedCommitInfo, lastHeaderHash types.Hash, state types.State, txs cmtypes.Txs, timestamp time.Time) (*types.SignedHeader, *types.Data, error) {
+func (e *BlockExecutor) CreateBlock(ctx context.Context, height uint64, lastSignature *types.Signature, lastExtendedCommit abci.ExtendedCommitInfo, lastHeaderHash types.Hash, state types.State, txs cmtypes.Txs, timestamp time.Time) (*types.SignedHeader, *types.Data, error) {
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	emptyMaxBytes := maxBytes == -1
 	if emptyMaxBytes {
@@ -106,36 +141,28 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastSignature *types.Signatur
 		maxBytes = int64(e.maxBytes) //nolint:gosec
 	}
 
-	header := &types.SignedHeader{
-		Header: types.Header{
-			Version: types.Version{
-				Block: state.Version.Consensus.Block,
-				App:   state.Version.Consensus.App,
-			},
-			BaseHeader: types.BaseHeader{
-				ChainID: e.chainID,
-				Height:  height,
-				Time:    uint64(timestamp.UnixNano()), //nolint:gosec
-			},
-			DataHash:        make(types.Hash, 32),
-			ConsensusHash:   make(types.Hash, 32),
-			AppHash:         state.AppHash,
-			LastResultsHash: state.LastResultsHash,
-			ProposerAddress: e.proposerAddress,
-		},
-		Signature: *lastSignature,
-	}
-	data := &types.Data{
-		Txs: toRollkitTxs(txs),
-		// IntermediateStateRoots: types.IntermediateStateRoots{RawRootsList: nil},
-		// Note: Temporarily remove Evidence #896
-		// Evidence:               types.EvidenceData{Evidence: nil},
+	// transactions of bundles are passed to the app, the bundle constraint is enforced after PrepareProposal
+	txs, bundles := e.unwrapBundles(txs)
+	header, data := e.newBlock(height, lastSignature, lastHeaderHash, state, txs, timestamp)
+
+	appMaxBytes := maxBytes
+	var sourceTxs map[string]cmtypes.Tx
+	if e.template != nil {
+		// system transactions are injected by the proposer only
+		txs = dropSystemTxs(txs)
+		var err error
+		if sourceTxs, err = e.template.sourceTxs(ctx, height, header.Time()); err != nil {
+			return nil, nil, err
+		}
+		for _, tx := range sourceTxs {
+			appMaxBytes -= cmtypes.ComputeProtoSizeForTxs([]cmtypes.Tx{tx})
+		}
 	}
 
 	rpp, err := e.proxyApp.PrepareProposal(
-		context.TODO(),
+		ctx,
 		&abci.RequestPrepareProposal{
-			MaxTxBytes:         maxBytes,
+			MaxTxBytes:         appMaxBytes,
 			Txs:                txs.ToSliceOfBytes(),
 			LocalLastCommit:    lastExtendedCommit,
 			Misbehavior:        []abci.Misbehavior{},
@@ -158,16 +185,75 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastSignature *types.Signatur
 	}
 
 	txl := cmtypes.ToTxs(rpp.Txs)
-	if err := txl.Validate(maxBytes); err != nil {
+	if err := txl.Validate(appMaxBytes); err != nil {
 		return nil, nil, err
 	}
+	txl, droppedBundles := enforceBundles(txl, bundles)
+	if droppedBundles > 0 {
+		e.logger.Info("dropped bundles not included completely", "height", height, "bundles", droppedBundles)
+	}
+	if e.template != nil {
+		var dropped int
+		if txl, dropped, err = e.template.assemble(txl, sourceTxs); err != nil {
+			return nil, nil, err
+		}
+		if dropped > 0 {
+			e.logger.Info("dropped system transactions not defined by block proposal template", "height", height, "txs", dropped)
+		}
+		if err := txl.Validate(maxBytes); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	data.Txs = toRollkitTxs(txl)
+	if data.Sections, err = e.buildSections(ctx, height, header.Time(), data.Txs); err != nil {
+		return nil, nil, err
+	}
+	if size := data.Size(); len(data.Sections) > 0 && int64(size) > maxBytes {
+		return nil, nil, fmt.Errorf("block data size %d with sections exceeds %d bytes", size, maxBytes)
+	}
+
+	return header, data, nil
+}
+
+// CreateDerivedBlock builds a block from transactions included directly in DA, without the sequencer.
+// Transactions are not passed to PrepareProposal, as all full nodes must derive exactly the same block.
+func (e *BlockExecutor) CreateDerivedBlock(height uint64, lastSignature *types.Signature, lastHeaderHash types.Hash, state types.State, txs cmtypes.Txs, timestamp time.Time) (*types.SignedHeader, *types.Data) {
+	return e.newBlock(height, lastSignature, lastHeaderHash, state, txs, timestamp)
+}
+
+func (e *BlockExecutor) newBlock(height uint64, lastSignature *types.Signature, lastHeaderHash types.Hash, state types.State, txs cmtypes.Txs, timestamp time.Time) (*types.SignedHeader, *types.Data) {
+	header := &types.SignedHeader{
+		Header: types.Header{
+			Version: types.Version{
+				Block: state.Version.Consensus.Block,
+				App:   state.Version.Consensus.App,
+			},
+			BaseHeader: types.BaseHeader{
+				ChainID: e.chainID,
+				Height:  height,
+				Time:    uint64(timestamp.UnixNano()), //nolint:gosec
+			},
+			DataHash:        make(types.Hash, 32),
+			ConsensusHash:   make(types.Hash, 32),
+			AppHash:         state.AppHash,
+			LastResultsHash: state.LastResultsHash,
+			ProposerAddress: e.proposerAddress,
+		},
+		Signature: *lastSignature,
+	}
+	data := &types.Data{
+		Txs: toRollkitTxs(txs),
+		// IntermediateStateRoots: types.IntermediateStateRoots{RawRootsList: nil},
+		// Note: Temporarily remove Evidence #896
+		// Evidence:               types.EvidenceData{Evidence: nil},
+	}
+
 	// Note: This is hash of an ABCI type commit equivalent of the last signature in the signed header.
 	header.LastCommitHash = lastSignature.GetCommitHash(&header.Header, e.proposerAddress)
 	header.LastHeaderHash = lastHeaderHash
 
-	return header, data, nil
+	return header, data
 }
 
 // ProcessProposal calls the corresponding ABCI method on the app.
@@ -221,23 +307,29 @@ func (e *BlockExecutor) ApplyBlock(ctx context.Context, state types.State, heade
 	if err != nil {
 		return types.State{}, nil, err
 	}
-	// This makes calls to the AppClient
-	resp, err := e.execute(ctx, state, header, data)
-	if err != nil {
+	return e.applyBlock(ctx, state, header, data)
+}
+
+// ApplyDerivedBlock validates and executes a block derived from DA by the node itself (see CreateDerivedBlock).
+// Derived block is not signed by the sequencer, and it's not passed to ProcessProposal, as it can't be rejected.
+func (e *BlockExecutor) ApplyDerivedBlock(ctx context.Context, state types.State, header *types.SignedHeader, data *types.Data) (types.State, *abci.ResponseFinalizeBlock, error) {
+	if err := e.validate(state, header, data); err != nil {
 		return types.State{}, nil, err
 	}
-	abciValUpdates := resp.ValidatorUpdates
+	return e.applyBlock(ctx, state, header, data)
+}
 
-	validatorUpdates, err := cmtypes.PB2TM.ValidatorUpdates(abciValUpdates)
+func (e *BlockExecutor) applyBlock(ctx context.Context, state types.State, header *types.SignedHeader, data *types.Data) (types.State, *abci.ResponseFinalizeBlock, error) {
+	// This makes calls to the AppClient
+	resp, err := e.execute(ctx, state, header, data)
 	if err != nil {
-		return state, nil, err
+		return types.State{}, nil, err
 	}
-
 	if resp.ConsensusParamUpdates != nil {
 		e.metrics.ConsensusParamUpdates.Add(1)
 	}
 
-	state, err = e.updateState(state, header, data, resp, validatorUpdates)
+	state, err = e.NextState(state, header, data, resp)
 	if err != nil {
 		return types.State{}, nil, err
 	}
@@ -249,6 +341,16 @@ func (e *BlockExecutor) ApplyBlock(ctx context.Context, state types.State, heade
 	return state, resp, nil
 }
 
+// NextState returns the state after the block, given responses of its execution. It doesn't call the app, so it
+// can re-derive the state of a block committed in the app from saved block responses.
+func (e *BlockExecutor) NextState(state types.State, header *types.SignedHeader, data *types.Data, resp *abci.ResponseFinalizeBlock) (types.State, error) {
+	validatorUpdates, err := cmtypes.PB2TM.ValidatorUpdates(resp.ValidatorUpdates)
+	if err != nil {
+		return state, err
+	}
+	return e.updateState(state, header, data, resp, validatorUpdates)
+}
+
 // ExtendVote calls the ExtendVote ABCI method on the proxy app.
 func (e *BlockExecutor) ExtendVote(ctx context.Context, header *types.SignedHeader, data *types.Data) ([]byte, error) {
 	resp, err := e.proxyApp.ExtendVote(ctx, &abci.RequestExtendVote{
@@ -385,11 +487,26 @@ func (e *BlockExecutor) commit(ctx context.Context, state types.State, header *t
 	return resp.AppHash, uint64(commitResp.RetainHeight), err //nolint:gosec
 }
 
-// Validate validates the state and the block for the executor
+// Validate validates the state and the block for the executor, including system transactions required by
+// proposal template and sections of block data with sources.
 func (e *BlockExecutor) Validate(state types.State, header *types.SignedHeader, data *types.Data) error {
 	if err := header.ValidateBasic(); err != nil {
 		return err
 	}
+	if err := e.validate(state, header, data); err != nil {
+		return err
+	}
+	if err := e.validateSections(header, data); err != nil {
+		return err
+	}
+	if e.template != nil {
+		return e.template.validate(header.Height(), header.Time(), data.Txs)
+	}
+	return nil
+}
+
+// validate validates the block against the state, without verifying header signature.
+func (e *BlockExecutor) validate(state types.State, header *types.SignedHeader, data *types.Data) error {
 	if err := data.ValidateBasic(); err != nil {
 		return err
 	}
@@ -407,7 +524,11 @@ func (e *BlockExecutor) Validate(state types.State, header *types.SignedHeader,
 		return errors.New("block height mismatch")
 	}
 	if !bytes.Equal(header.AppHash[:], state.AppHash[:]) {
-		return errors.New("AppHash mismatch")
+		return &AppHashMismatchError{
+			Height:   header.Height(),
+			Expected: header.AppHash,
+			Actual:   state.AppHash,
+		}
 	}
 
 	if !bytes.Equal(header.LastResultsHash[:], state.LastResultsHash[:]) {
@@ -424,30 +545,13 @@ func (e *BlockExecutor) execute(ctx context.Context, state types.State, header *
 		return nil, ctx.Err()
 	default:
 	}
-	abciHeader, err := abciconv.ToABCIHeaderPB(&header.Header)
-	if err != nil {
-		return nil, err
-	}
-	abciHeader.ChainID = e.chainID
-	abciBlock, err := abciconv.ToABCIBlock(header, data)
+	req, err := newFinalizeBlockRequest(state, header, data)
 	if err != nil {
 		return nil, err
 	}
 
 	startTime := time.Now().UnixNano()
-	finalizeBlockResponse, err := e.proxyApp.FinalizeBlock(ctx, &abci.RequestFinalizeBlock{
-		Hash:               header.Hash(),
-		NextValidatorsHash: state.Validators.Hash(),
-		ProposerAddress:    abciHeader.ProposerAddress,
-		Height:             abciHeader.Height,
-		Time:               abciHeader.Time,
-		DecidedLastCommit: abci.CommitInfo{
-			Round: 0,
-			Votes: nil,
-		},
-		Misbehavior: abciBlock.Evidence.Evidence.ToABCI(),
-		Txs:         abciBlock.Txs.ToSliceOfBytes(),
-	})
+	finalizeBlockResponse, err := e.proxyApp.FinalizeBlock(ctx, req)
 	endTime := time.Now().UnixNano()
 	e.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
 	if err != nil {
@@ -457,22 +561,94 @@ func (e *BlockExecutor) execute(ctx context.Context, state types.State, header *
 
 	e.logger.Info(
 		"finalized block",
-		"height", abciBlock.Height,
+		"height", req.Height,
 		"num_txs_res", len(finalizeBlockResponse.TxResults),
 		"num_val_updates", len(finalizeBlockResponse.ValidatorUpdates),
 		"block_app_hash", fmt.Sprintf("%X", finalizeBlockResponse.AppHash),
 	)
 
 	// Assert that the application correctly returned tx results for each of the transactions provided in the block
-	if len(abciBlock.Data.Txs) != len(finalizeBlockResponse.TxResults) {
+	if len(req.Txs) != len(finalizeBlockResponse.TxResults) {
 		return nil, fmt.Errorf("expected tx results length to match size of transactions in block. Expected %d, got %d", len(data.Txs), len(finalizeBlockResponse.TxResults))
 	}
 
-	e.logger.Info("executed block", "height", abciHeader.Height, "app_hash", fmt.Sprintf("%X", finalizeBlockResponse.AppHash))
+	e.logger.Info("executed block", "height", req.Height, "app_hash", fmt.Sprintf("%X", finalizeBlockResponse.AppHash))
 
 	return finalizeBlockResponse, nil
 }
 
+// TraceTx executes the block up to (and including) the transaction at txIndex and returns
+// the result of that transaction.
+//
+// The application behind app must be at the state right before the block. Commit is never
+// called, so app connection should be discarded after tracing.
+func TraceTx(ctx context.Context, app proxy.AppConnConsensus, state types.State, header *types.SignedHeader, data *types.Data, txIndex int) (*abci.ExecTxResult, error) {
+	if txIndex < 0 || txIndex >= len(data.Txs) {
+		return nil, fmt.Errorf("tx index %d out of range, block %d has %d txs", txIndex, header.Height(), len(data.Txs))
+	}
+	req, err := newFinalizeBlockRequest(state, header, data)
+	if err != nil {
+		return nil, err
+	}
+	req.Txs = req.Txs[:txIndex+1]
+
+	resp, err := app.FinalizeBlock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.TxResults) != len(req.Txs) {
+		return nil, fmt.Errorf("expected %d tx results, got %d", len(req.Txs), len(resp.TxResults))
+	}
+	return resp.TxResults[txIndex], nil
+}
+
+// ReplayBlock executes and commits the block already applied by the node, and returns the resulting app hash.
+//
+// It's used to bring the application behind app back in sync with the store, e.g. after it was restarted
+// without persisting its state. Mempool is not updated and no events are published.
+func ReplayBlock(ctx context.Context, app proxy.AppConnConsensus, state types.State, header *types.SignedHeader, data *types.Data) ([]byte, error) {
+	req, err := newFinalizeBlockRequest(state, header, data)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.FinalizeBlock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.TxResults) != len(req.Txs) {
+		return nil, fmt.Errorf("expected %d tx results, got %d", len(req.Txs), len(resp.TxResults))
+	}
+	if _, err := app.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return resp.AppHash, nil
+}
+
+func newFinalizeBlockRequest(state types.State, header *types.SignedHeader, data *types.Data) (*abci.RequestFinalizeBlock, error) {
+	abciHeader, err := abciconv.ToABCIHeaderPB(&header.Header)
+	if err != nil {
+		return nil, err
+	}
+	abciBlock, err := abciconv.ToABCIBlock(header, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &abci.RequestFinalizeBlock{
+		Hash:               header.Hash(),
+		NextValidatorsHash: state.Validators.Hash(),
+		ProposerAddress:    abciHeader.ProposerAddress,
+		Height:             abciHeader.Height,
+		Time:               abciHeader.Time,
+		DecidedLastCommit: abci.CommitInfo{
+			Round: 0,
+			Votes: nil,
+		},
+		Misbehavior: abciBlock.Evidence.Evidence.ToABCI(),
+		Txs:         abciBlock.Txs.ToSliceOfBytes(),
+	}, nil
+}
+
 func (e *BlockExecutor) publishEvents(resp *abci.ResponseFinalizeBlock, header *types.SignedHeader, data *types.Data, state types.State) {
 	if e.eventBus == nil {
 		return
@@ -77,7 +77,7 @@ func doTestCreateBlock(t *testing.T) {
 	state.Validators = cmtypes.NewValidatorSet(validators)
 
 	// empty block
-	header, data, err := executor.CreateBlock(1, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{}, time.Now())
+	header, data, err := executor.CreateBlock(context.Background(), 1, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{}, time.Now())
 	require.NoError(err)
 	require.NotNil(header)
 	assert.Empty(data.Txs)
@@ -87,7 +87,7 @@ func doTestCreateBlock(t *testing.T) {
 	tx := []byte{1, 2, 3, 4}
 	err = mpool.CheckTx(tx, func(r *abci.ResponseCheckTx) {}, mempool.TxInfo{})
 	require.NoError(err)
-	header, data, err = executor.CreateBlock(2, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{tx}, time.Now())
+	header, data, err = executor.CreateBlock(context.Background(), 2, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{tx}, time.Now())
 	require.NoError(err)
 	require.NotNil(header)
 	assert.Equal(uint64(2), header.Height())
@@ -100,7 +100,7 @@ func doTestCreateBlock(t *testing.T) {
 	require.NoError(err)
 	err = mpool.CheckTx(tx2, func(r *abci.ResponseCheckTx) {}, mempool.TxInfo{})
 	require.NoError(err)
-	header, data, err = executor.CreateBlock(3, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{tx1, tx2}, time.Now())
+	header, data, err = executor.CreateBlock(context.Background(), 3, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{tx1, tx2}, time.Now())
 	require.Error(err)
 	require.Nil(header)
 	require.Nil(data)
@@ -111,7 +111,7 @@ func doTestCreateBlock(t *testing.T) {
 	executor.maxBytes = 10
 	err = mpool.CheckTx(tx, func(r *abci.ResponseCheckTx) {}, mempool.TxInfo{})
 	require.NoError(err)
-	header, data, err = executor.CreateBlock(4, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{tx}, time.Now())
+	header, data, err = executor.CreateBlock(context.Background(), 4, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{tx}, time.Now())
 	require.Error(err)
 	require.Nil(header)
 	require.Nil(data)
@@ -208,7 +208,7 @@ func doTestApplyBlock(t *testing.T) {
 	err = mpool.CheckTx(tx, func(r *abci.ResponseCheckTx) {}, mempool.TxInfo{})
 	require.NoError(err)
 	signature := types.Signature([]byte{1, 1, 1})
-	header, data, err := executor.CreateBlock(1, &signature, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{tx}, time.Now())
+	header, data, err := executor.CreateBlock(context.Background(), 1, &signature, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{tx}, time.Now())
 	require.NoError(err)
 	require.NotNil(header)
 	assert.Equal(uint64(1), header.Height())
@@ -238,7 +238,7 @@ func doTestApplyBlock(t *testing.T) {
 	require.NoError(mpool.CheckTx(tx2, func(r *abci.ResponseCheckTx) {}, mempool.TxInfo{}))
 	require.NoError(mpool.CheckTx(tx3, func(r *abci.ResponseCheckTx) {}, mempool.TxInfo{}))
 	signature = types.Signature([]byte{1, 1, 1})
-	header, data, err = executor.CreateBlock(2, &signature, abci.ExtendedCommitInfo{}, []byte{}, newState, cmtypes.Txs{tx1, tx2, tx3}, time.Now())
+	header, data, err = executor.CreateBlock(context.Background(), 2, &signature, abci.ExtendedCommitInfo{}, []byte{}, newState, cmtypes.Txs{tx1, tx2, tx3}, time.Now())
 	require.NoError(err)
 	require.NotNil(header)
 	assert.Equal(uint64(2), header.Height())
@@ -292,6 +292,57 @@ func TestApplyBlockWithFraudProofsDisabled(t *testing.T) {
 	doTestApplyBlock(t)
 }
 
+func TestApplyDerivedBlock(t *testing.T) {
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("FinalizeBlock", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+			txResults := make([]*abci.ExecTxResult, len(req.Txs))
+			for i := range txResults {
+				txResults[i] = &abci.ExecTxResult{Code: abci.CodeTypeOK}
+			}
+			return &abci.ResponseFinalizeBlock{
+				TxResults: txResults,
+				AppHash:   []byte("app hash"),
+			}, nil
+		},
+	)
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	vKey := ed25519.GenPrivKey()
+	proposer := vKey.PubKey().Address()
+	executor := NewBlockExecutor(proposer, "TestApplyDerivedBlock", nil, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 100, log.TestingLogger(), NopMetrics())
+
+	validators := cmtypes.NewValidatorSet([]*cmtypes.Validator{cmtypes.NewValidator(vKey.PubKey(), 100)})
+	state := types.State{
+		InitialHeight:   1,
+		LastBlockHeight: 1,
+		Validators:      validators,
+		NextValidators:  validators,
+		LastValidators:  validators,
+	}
+	state.ConsensusParams.Block = &cmproto.BlockParams{MaxBytes: 100, MaxGas: 100000}
+
+	txs := cmtypes.Txs{[]byte{1, 2, 3}, []byte{4, 5, 6}}
+	header, data := executor.CreateDerivedBlock(2, &types.Signature{}, types.Hash{1, 2, 3}, state, txs, time.Now())
+	require.Equal(uint64(2), header.Height())
+	require.Equal(types.Hash{1, 2, 3}, header.LastHeaderHash)
+	require.Equal([]byte(proposer), header.ProposerAddress)
+	require.Len(data.Txs, 2)
+	header.DataHash = data.Hash()
+	header.Validators = validators
+
+	// derived block is not signed
+	require.Error(executor.Validate(state, header, data))
+
+	newState, resp, err := executor.ApplyDerivedBlock(context.Background(), state, header, data)
+	require.NoError(err)
+	require.Len(resp.TxResults, 2)
+	require.Equal(uint64(2), newState.LastBlockHeight)
+	app.AssertNotCalled(t, "ProcessProposal", mock.Anything, mock.Anything)
+}
+
 func TestUpdateStateConsensusParams(t *testing.T) {
 	logger := log.TestingLogger()
 	app := &mocks.Application{}
@@ -81,6 +81,35 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockEvents) error {
 	return batch.Commit(idx.ctx)
 }
 
+// Prune removes everything indexed by Index for given block: primary key and keys of BeginBlock
+// and EndBlock events.
+func (idx *BlockerIndexer) Prune(bh types.EventDataNewBlockEvents) error {
+	batch, err := idx.store.NewTransaction(idx.ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
+	}
+	defer batch.Discard(idx.ctx)
+
+	if err := batch.Delete(idx.ctx, ds.NewKey(heightKey(bh.Height))); err != nil {
+		return err
+	}
+	for _, typ := range []string{"begin_block", "end_block"} {
+		for _, event := range bh.Events {
+			for _, attr := range event.Attributes {
+				if len(event.Type) == 0 || len(attr.Key) == 0 || !attr.GetIndex() {
+					continue
+				}
+				key := eventKey(event.Type+"."+attr.Key, typ, attr.Value, bh.Height)
+				if err := batch.Delete(idx.ctx, ds.NewKey(key)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return batch.Commit(idx.ctx)
+}
+
 // Search performs a query for block heights that match a given BeginBlock
 // and Endblock event search criteria. The given query can match against zero,
 // one or more block heights. In the case of height queries, i.e. block.height=H,
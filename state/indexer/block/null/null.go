@@ -26,3 +26,7 @@ func (idx *BlockerIndexer) Index(types.EventDataNewBlockEvents) error {
 func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
 	return []int64{}, nil
 }
+
+func (idx *BlockerIndexer) Prune(types.EventDataNewBlockEvents) error {
+	return nil
+}
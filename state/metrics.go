@@ -22,6 +22,10 @@ type Metrics struct {
 	// updated the consensus params since process start.
 	//metrics:Number of consensus parameter updates returned by the application since process start.
 	ConsensusParamUpdates metrics.Counter
+
+	// ABCITimeouts is the number of calls to ABCI application that didn't finish within configured timeout.
+	//metrics:Number of calls to ABCI application that timed out, by method.
+	ABCITimeouts metrics.Counter `metrics_labels:"method"`
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -47,6 +51,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "consensus_param_updates",
 			Help:      "Number of consensus parameter updates returned by the application since process start.",
 		}, labels).With(labelsAndValues...),
+		ABCITimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "abci_timeouts",
+			Help:      "Number of calls to ABCI application that timed out, by method.",
+		}, append(labels, "method")).With(labelsAndValues...),
 	}
 }
 
@@ -55,5 +65,6 @@ func NopMetrics() *Metrics {
 	return &Metrics{
 		BlockProcessingTime:   discard.NewHistogram(),
 		ConsensusParamUpdates: discard.NewCounter(),
+		ABCITimeouts:          discard.NewCounter(),
 	}
 }
@@ -0,0 +1,228 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// SystemTxSource builds and verifies payloads of system transactions of a kind, e.g. the latest oracle prices or
+// a timestamp beacon. It's implemented by node plugins.
+type SystemTxSource interface {
+	// Payload returns payload of system transaction of the block at height, built by the proposer.
+	Payload(ctx context.Context, height uint64, timestamp time.Time) ([]byte, error)
+	// Verify checks payload of system transaction included in the block at height, e.g. while syncing.
+	Verify(height uint64, timestamp time.Time, payload []byte) error
+}
+
+// SystemTxSlot is a fixed position of system transaction of a kind in every block.
+type SystemTxSlot struct {
+	Kind string
+	// Position is the index of the transaction in the block; negative positions count from the end, e.g. -1 is
+	// the last transaction.
+	Position int
+	// Source builds and verifies the payload. If nil, the application injects the transaction (see
+	// types.NewSystemTx) in PrepareProposal, and verifies its payload in ProcessProposal.
+	Source SystemTxSource
+}
+
+// ProposalTemplate defines system transactions which must be included at fixed positions of every block proposed
+// by the sequencer. Slots occupy the beginning (positions 0, 1, ...) and the end (positions ..., -2, -1) of the
+// block, other transactions are placed between them. All nodes of the chain must use the same template.
+type ProposalTemplate struct {
+	// head and tail are slots at the beginning and at the end of the block, in order of transactions
+	head []*SystemTxSlot
+	tail []*SystemTxSlot
+}
+
+// NewProposalTemplate creates template with given slots. Kinds must be unique, and positions must be contiguous
+// from the beginning and from the end of the block.
+func NewProposalTemplate(slots []SystemTxSlot) (*ProposalTemplate, error) {
+	t := &ProposalTemplate{}
+	kinds := make(map[string]struct{}, len(slots))
+	for i := range slots {
+		slot := slots[i]
+		if slot.Kind == "" {
+			return nil, errors.New("system transaction kind is required")
+		}
+		if _, ok := kinds[slot.Kind]; ok {
+			return nil, fmt.Errorf("duplicate system transaction kind %q", slot.Kind)
+		}
+		kinds[slot.Kind] = struct{}{}
+		if slot.Position >= 0 {
+			t.head = append(t.head, &slot)
+		} else {
+			t.tail = append(t.tail, &slot)
+		}
+	}
+	sort.Slice(t.head, func(i, j int) bool { return t.head[i].Position < t.head[j].Position })
+	sort.Slice(t.tail, func(i, j int) bool { return t.tail[i].Position < t.tail[j].Position })
+	for i, slot := range t.head {
+		if slot.Position != i {
+			return nil, fmt.Errorf("system transaction %q at position %d doesn't follow position %d", slot.Kind, slot.Position, i-1)
+		}
+	}
+	for i, slot := range t.tail {
+		if slot.Position != i-len(t.tail) {
+			return nil, fmt.Errorf("system transaction %q at position %d doesn't precede position %d", slot.Kind, slot.Position, i-len(t.tail)+1)
+		}
+	}
+	return t, nil
+}
+
+// ParseProposalTemplate parses template from a comma separated list of kind:position pairs, e.g. "oracle:0,beacon:-1".
+// Slots don't have sources, see SetSource.
+func ParseProposalTemplate(s string) (*ProposalTemplate, error) {
+	var slots []SystemTxSlot
+	for _, field := range strings.Split(s, ",") {
+		kind, position, ok := strings.Cut(strings.TrimSpace(field), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid system transaction slot %q, expected kind:position", field)
+		}
+		pos, err := strconv.Atoi(position)
+		if err != nil {
+			return nil, fmt.Errorf("invalid position of system transaction %q: %w", kind, err)
+		}
+		slots = append(slots, SystemTxSlot{Kind: kind, Position: pos})
+	}
+	return NewProposalTemplate(slots)
+}
+
+// SetSource sets source of system transactions of given kind. It must be called before blocks are produced or
+// synced.
+func (t *ProposalTemplate) SetSource(kind string, source SystemTxSource) error {
+	for _, slot := range t.slots() {
+		if slot.Kind == kind {
+			slot.Source = source
+			return nil
+		}
+	}
+	return fmt.Errorf("system transaction %q is not defined by block proposal template", kind)
+}
+
+func (t *ProposalTemplate) slots() []*SystemTxSlot {
+	return append(append([]*SystemTxSlot{}, t.head...), t.tail...)
+}
+
+// sourceTxs builds system transactions of slots with sources, by kind.
+func (t *ProposalTemplate) sourceTxs(ctx context.Context, height uint64, timestamp time.Time) (map[string]cmtypes.Tx, error) {
+	txs := make(map[string]cmtypes.Tx)
+	for _, slot := range t.slots() {
+		if slot.Source == nil {
+			continue
+		}
+		payload, err := slot.Source.Payload(ctx, height, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build system transaction %q: %w", slot.Kind, err)
+		}
+		tx, err := types.NewSystemTx(slot.Kind, payload)
+		if err != nil {
+			return nil, err
+		}
+		txs[slot.Kind] = cmtypes.Tx(tx)
+	}
+	return txs, nil
+}
+
+// assemble places system transactions at their positions around other transactions. System transactions of slots
+// without sources are taken from txs, where they were injected by the application; other system transactions are
+// dropped, and their number is returned.
+func (t *ProposalTemplate) assemble(txs cmtypes.Txs, sourceTxs map[string]cmtypes.Tx) (cmtypes.Txs, int, error) {
+	appTxs := make(map[string]cmtypes.Tx)
+	rest := make(cmtypes.Txs, 0, len(txs))
+	dropped := 0
+	for _, tx := range txs {
+		if !types.IsSystemTx(types.Tx(tx)) {
+			rest = append(rest, tx)
+			continue
+		}
+		stx, err := types.ParseSystemTx(types.Tx(tx))
+		if _, ok := appTxs[stx.Kind]; err != nil || ok {
+			dropped++
+			continue
+		}
+		appTxs[stx.Kind] = tx
+	}
+	res := make(cmtypes.Txs, 0, len(rest)+len(t.head)+len(t.tail))
+	pick := func(slot *SystemTxSlot) (cmtypes.Tx, error) {
+		if slot.Source != nil {
+			return sourceTxs[slot.Kind], nil
+		}
+		tx, ok := appTxs[slot.Kind]
+		if !ok {
+			return nil, fmt.Errorf("application didn't inject system transaction %q", slot.Kind)
+		}
+		delete(appTxs, slot.Kind)
+		return tx, nil
+	}
+	for _, slot := range t.head {
+		tx, err := pick(slot)
+		if err != nil {
+			return nil, 0, err
+		}
+		res = append(res, tx)
+	}
+	res = append(res, rest...)
+	for _, slot := range t.tail {
+		tx, err := pick(slot)
+		if err != nil {
+			return nil, 0, err
+		}
+		res = append(res, tx)
+	}
+	return res, dropped + len(appTxs), nil
+}
+
+// validate checks that system transactions are included at their positions, and nowhere else.
+func (t *ProposalTemplate) validate(height uint64, timestamp time.Time, txs types.Txs) error {
+	if len(txs) < len(t.head)+len(t.tail) {
+		return fmt.Errorf("block has %d transactions, %d system transactions are required", len(txs), len(t.head)+len(t.tail))
+	}
+	tailStart := len(txs) - len(t.tail)
+	for i, tx := range txs {
+		var slot *SystemTxSlot
+		switch {
+		case i < len(t.head):
+			slot = t.head[i]
+		case i >= tailStart:
+			slot = t.tail[i-tailStart]
+		default:
+			if types.IsSystemTx(tx) {
+				return fmt.Errorf("unexpected system transaction at position %d", i)
+			}
+			continue
+		}
+		stx, err := types.ParseSystemTx(tx)
+		if err != nil {
+			return fmt.Errorf("system transaction %q at position %d: %w", slot.Kind, i, err)
+		}
+		if stx.Kind != slot.Kind {
+			return fmt.Errorf("system transaction %q is required at position %d, got %q", slot.Kind, i, stx.Kind)
+		}
+		if slot.Source != nil {
+			if err := slot.Source.Verify(height, timestamp, stx.Payload); err != nil {
+				return fmt.Errorf("invalid system transaction %q: %w", slot.Kind, err)
+			}
+		}
+	}
+	return nil
+}
+
+// dropSystemTxs removes system transactions submitted by users.
+func dropSystemTxs(txs cmtypes.Txs) cmtypes.Txs {
+	res := txs[:0:0]
+	for _, tx := range txs {
+		if !types.IsSystemTx(types.Tx(tx)) {
+			res = append(res, tx)
+		}
+	}
+	return res
+}
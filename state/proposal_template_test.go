@@ -0,0 +1,108 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/proxy"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+// beaconSource builds payloads with block height.
+type beaconSource struct{}
+
+func (beaconSource) Payload(_ context.Context, height uint64, _ time.Time) ([]byte, error) {
+	return []byte{byte(height)}, nil
+}
+
+func (beaconSource) Verify(height uint64, _ time.Time, payload []byte) error {
+	if !bytes.Equal(payload, []byte{byte(height)}) {
+		return errors.New("wrong beacon")
+	}
+	return nil
+}
+
+func TestParseProposalTemplate(t *testing.T) {
+	template, err := ParseProposalTemplate("beacon:-1, oracle:0,fees:1")
+	require.NoError(t, err)
+	assert.Len(t, template.head, 2)
+	assert.Equal(t, "fees", template.head[1].Kind)
+	assert.Len(t, template.tail, 1)
+	assert.NoError(t, template.SetSource("beacon", beaconSource{}))
+	assert.Error(t, template.SetSource("unknown", beaconSource{}))
+
+	for _, s := range []string{"oracle", "oracle:x", "oracle:1", "oracle:-2", "oracle:0,oracle:-1", ":0"} {
+		_, err := ParseProposalTemplate(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestCreateBlockWithSystemTxs(t *testing.T) {
+	require := require.New(t)
+
+	oracleTx, err := types.NewSystemTx("oracle", []byte("prices"))
+	require.NoError(err)
+	unknownTx, err := types.NewSystemTx("unknown", nil)
+	require.NoError(err)
+
+	// app injects oracle update at the end of the block
+	app := &mocks.Application{}
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+			return &abci.ResponsePrepareProposal{Txs: append(req.Txs, oracleTx, unknownTx)}, nil
+		})
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client, proxy.NopMetrics()), 0)
+	executor := NewBlockExecutor([]byte("test address"), "TestCreateBlockWithSystemTxs", mpool, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 1000, log.TestingLogger(), NopMetrics())
+	require.Error(executor.SetSystemTxSource("beacon", beaconSource{}))
+	template, err := ParseProposalTemplate("beacon:0,oracle:-1")
+	require.NoError(err)
+	executor.SetProposalTemplate(template)
+	require.NoError(executor.SetSystemTxSource("beacon", beaconSource{}))
+
+	state := types.State{}
+	state.ConsensusParams.Block = &cmproto.BlockParams{MaxBytes: 1000, MaxGas: 100000}
+	vKey := ed25519.GenPrivKey()
+	state.Validators = cmtypes.NewValidatorSet([]*cmtypes.Validator{{Address: vKey.PubKey().Address(), PubKey: vKey.PubKey(), VotingPower: 100}})
+
+	// system transaction submitted by user is dropped
+	forged, err := types.NewSystemTx("beacon", []byte{99})
+	require.NoError(err)
+	txs := cmtypes.Txs{cmtypes.Tx("x"), cmtypes.Tx(forged), cmtypes.Tx("y")}
+	header, data, err := executor.CreateBlock(context.Background(), 7, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, txs, time.Now())
+	require.NoError(err)
+	beaconTx, err := types.NewSystemTx("beacon", []byte{7})
+	require.NoError(err)
+	assert.Equal(t, types.Txs{beaconTx, types.Tx("x"), types.Tx("y"), oracleTx}, data.Txs)
+	require.NoError(template.validate(header.Height(), header.Time(), data.Txs))
+
+	// synced blocks must include system transactions at their positions only
+	invalid := []types.Txs{
+		{types.Tx("x"), oracleTx},
+		{beaconTx, types.Tx("x")},
+		{forged, types.Tx("x"), oracleTx},
+		{beaconTx, oracleTx, types.Tx("x"), oracleTx},
+		{oracleTx, beaconTx},
+		{beaconTx},
+	}
+	for i, txs := range invalid {
+		assert.Error(t, template.validate(7, header.Time(), txs), i)
+	}
+	assert.NoError(t, template.validate(7, header.Time(), types.Txs{beaconTx, oracleTx}))
+}
@@ -24,6 +24,9 @@ type TxIndexer interface {
 
 	// Search allows you to query for transactions.
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
+
+	// PruneHeight removes all transactions indexed at given height.
+	PruneHeight(height int64) error
 }
 
 // Batch groups together multiple Index operations to be performed at the same time.
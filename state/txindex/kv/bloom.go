@@ -0,0 +1,115 @@
+package kv
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"sync"
+)
+
+const (
+	// initialFilterCapacity is the number of hashes the first layer of the filter is sized for.
+	initialFilterCapacity = 1 << 16
+
+	// initialFilterFPRate is the false positive rate of the first layer of the filter. Each next layer has
+	// half of the rate of the previous one, so the total rate stays below twice the initial one.
+	initialFilterFPRate = 0.01
+)
+
+// existenceFilter is a scalable bloom filter of transaction hashes. It never reports an added hash as
+// missing, but might report a missing hash as present, so it is used to answer lookups of missing hashes
+// without reading the store. Hashes can't be removed; hashes of pruned transactions are just false positives.
+//
+// When a layer is full, a new layer with twice the capacity is added, so the filter grows with the number of
+// hashes without knowing it upfront.
+type existenceFilter struct {
+	mtx    sync.RWMutex
+	layers []*bloomLayer
+}
+
+func newExistenceFilter() *existenceFilter {
+	return &existenceFilter{
+		layers: []*bloomLayer{newBloomLayer(initialFilterCapacity, initialFilterFPRate)},
+	}
+}
+
+// Add adds the hash to the filter.
+func (f *existenceFilter) Add(hash []byte) {
+	h1, h2 := filterHashes(hash)
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	last := f.layers[len(f.layers)-1]
+	if last.count >= last.capacity {
+		last = newBloomLayer(last.capacity*2, last.fpRate/2)
+		f.layers = append(f.layers, last)
+	}
+	last.add(h1, h2)
+}
+
+// MayContain returns false if the hash was never added to the filter.
+func (f *existenceFilter) MayContain(hash []byte) bool {
+	h1, h2 := filterHashes(hash)
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	for _, layer := range f.layers {
+		if layer.contains(h1, h2) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterHashes returns two independent hashes of the key, which are combined to get positions of all bits of
+// the key (Kirsch-Mitzenmacher double hashing). Transaction hashes are uniformly distributed already, other
+// keys are hashed first.
+func filterHashes(key []byte) (uint64, uint64) {
+	if len(key) != sha256.Size {
+		sum := sha256.Sum256(key)
+		key = sum[:]
+	}
+	// second hash must be odd, so that it's coprime with the number of bits being power of 2
+	return binary.LittleEndian.Uint64(key[:8]), binary.LittleEndian.Uint64(key[8:16]) | 1
+}
+
+// bloomLayer is a standard bloom filter with fixed capacity.
+type bloomLayer struct {
+	bits     []uint64
+	mask     uint64
+	k        uint64
+	count    uint64
+	capacity uint64
+	fpRate   float64
+}
+
+func newBloomLayer(capacity uint64, fpRate float64) *bloomLayer {
+	// optimal number of bits is -n*ln(p)/ln(2)^2, rounded up to power of 2 so positions can be masked
+	m := uint64(math.Ceil(-float64(capacity) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	m = max(uint64(1)<<uint64(math.Ceil(math.Log2(float64(m)))), 64)
+	// optimal number of hash functions is m/n*ln(2)
+	k := max(uint64(math.Round(float64(m)/float64(capacity)*math.Ln2)), 1)
+	return &bloomLayer{
+		bits:     make([]uint64, m/64),
+		mask:     m - 1,
+		k:        k,
+		capacity: capacity,
+		fpRate:   fpRate,
+	}
+}
+
+func (l *bloomLayer) add(h1, h2 uint64) {
+	for i := uint64(0); i < l.k; i++ {
+		pos := (h1 + i*h2) & l.mask
+		l.bits[pos/64] |= 1 << (pos % 64)
+	}
+	l.count++
+}
+
+func (l *bloomLayer) contains(h1, h2 uint64) bool {
+	for i := uint64(0); i < l.k; i++ {
+		pos := (h1 + i*h2) & l.mask
+		if l.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
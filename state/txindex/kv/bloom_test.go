@@ -0,0 +1,45 @@
+package kv
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExistenceFilter(t *testing.T) {
+	hash := func(i uint64) []byte {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], i)
+		sum := sha256.Sum256(b[:])
+		return sum[:]
+	}
+
+	f := newExistenceFilter()
+	// more than capacity of the first layer, to add layers
+	const added = 3 * initialFilterCapacity
+	for i := uint64(0); i < added; i++ {
+		f.Add(hash(i))
+	}
+	require.Len(t, f.layers, 2)
+
+	// no false negatives
+	for i := uint64(0); i < added; i++ {
+		require.True(t, f.MayContain(hash(i)))
+	}
+
+	const checked = 100000
+	falsePositives := 0
+	for i := uint64(added); i < added+checked; i++ {
+		if f.MayContain(hash(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/checked, 2*initialFilterFPRate)
+
+	// keys of other sizes are supported too
+	f.Add([]byte("short"))
+	assert.True(t, f.MayContain([]byte("short")))
+}
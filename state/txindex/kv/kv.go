@@ -8,9 +8,11 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gogo/protobuf/proto"
 	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/pubsub/query"
@@ -21,6 +23,7 @@ import (
 	"github.com/rollkit/rollkit/state/indexer"
 	"github.com/rollkit/rollkit/state/txindex"
 	"github.com/rollkit/rollkit/store"
+	rktypes "github.com/rollkit/rollkit/types"
 )
 
 const (
@@ -34,14 +37,82 @@ type TxIndex struct {
 	store ds.TxnDatastore
 
 	ctx context.Context
+
+	// workers is the number of goroutines preparing index entries in AddBatch
+	workers int
+
+	// filter answers lookups of transactions which were never indexed without reading the store, nil if disabled
+	filter *existenceFilter
+}
+
+// TxIndexOption configures TxIndex.
+type TxIndexOption func(*TxIndex)
+
+// WithWorkers sets the number of goroutines preparing index entries (hashes, encoded results and event keys)
+// of transactions in a batch. Entries are written to the store in a single transaction anyway.
+func WithWorkers(n int) TxIndexOption {
+	return func(txi *TxIndex) {
+		txi.workers = n
+	}
+}
+
+// WithExistenceFilter enables in-memory bloom filter of hashes of indexed transactions, so lookups of unknown
+// hashes (e.g. of retried or not yet included transactions) are answered without reading the store. The filter
+// is built from the store on creation of the indexer and must not be enabled if transactions are indexed by
+// another process, as it wouldn't see them.
+func WithExistenceFilter() TxIndexOption {
+	return func(txi *TxIndex) {
+		txi.filter = newExistenceFilter()
+	}
 }
 
 // NewTxIndex creates new KV indexer.
-func NewTxIndex(ctx context.Context, store ds.TxnDatastore) *TxIndex {
-	return &TxIndex{
+func NewTxIndex(ctx context.Context, store ds.TxnDatastore, opts ...TxIndexOption) *TxIndex {
+	txi := &TxIndex{
 		store: store,
 		ctx:   ctx,
 	}
+	for _, opt := range opts {
+		opt(txi)
+	}
+	if txi.filter != nil {
+		if err := txi.loadFilter(); err != nil {
+			// lookups are still correct without the filter, just slower
+			txi.filter = nil
+		}
+	}
+	return txi
+}
+
+// loadFilter adds hashes of all transactions indexed in the store to the existence filter.
+func (txi *TxIndex) loadFilter() error {
+	results, err := txi.store.Query(txi.ctx, dsq.Query{KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+	for result := range results.Next() {
+		if result.Error != nil {
+			return result.Error
+		}
+		// transactions are indexed by hash under top-level keys, other keys have multiple segments
+		key := strings.TrimPrefix(result.Key, "/")
+		if len(key) != 2*len(rktypes.TxHash(nil)) || strings.Contains(key, "/") {
+			continue
+		}
+		hash, err := hex.DecodeString(key)
+		if err != nil {
+			continue
+		}
+		txi.filter.Add(hash)
+	}
+	return nil
+}
+
+// MayContain returns false if transaction with given hash is certainly not indexed. It always returns true
+// if existence filter is disabled.
+func (txi *TxIndex) MayContain(hash []byte) bool {
+	return txi.filter == nil || txi.filter.MayContain(hash)
 }
 
 // Get gets transaction from the TxIndex storage and returns it or nil if the
@@ -50,6 +121,9 @@ func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
 	if len(hash) == 0 {
 		return nil, txindex.ErrorEmptyHash
 	}
+	if !txi.MayContain(hash) {
+		return nil, nil
+	}
 
 	rawBytes, err := txi.store.Get(txi.ctx, ds.NewKey(hex.EncodeToString(hash)))
 	if err != nil {
@@ -76,34 +150,19 @@ func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
 // the respective attribute's key delimited by a "." (eg. "account.number").
 // Any event with an empty type is not indexed.
 func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
+	entries, err := txi.prepareEntries(b.Ops)
+	if err != nil {
+		return err
+	}
+
 	storeBatch, err := txi.store.NewTransaction(txi.ctx, false)
 	if err != nil {
 		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
 	}
 	defer storeBatch.Discard(txi.ctx)
 
-	for _, result := range b.Ops {
-		hash := types.Tx(result.Tx).Hash()
-
-		// index tx by events
-		err := txi.indexEvents(result, hash, storeBatch)
-		if err != nil {
-			return err
-		}
-
-		// index by height (always)
-		err = storeBatch.Put(txi.ctx, ds.NewKey(keyForHeight(result)), hash)
-		if err != nil {
-			return err
-		}
-
-		rawBytes, err := proto.Marshal(result)
-		if err != nil {
-			return err
-		}
-		// index by hash (always)
-		err = storeBatch.Put(txi.ctx, ds.NewKey(hex.EncodeToString(hash)), rawBytes)
-		if err != nil {
+	for _, entry := range entries {
+		if err := txi.putEntry(storeBatch, entry); err != nil {
 			return err
 		}
 	}
@@ -116,40 +175,148 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 // respective attribute's key delimited by a "." (eg. "account.number").
 // Any event with an empty type is not indexed.
 func (txi *TxIndex) Index(result *abci.TxResult) error {
+	entry, err := prepareEntry(result)
+	if err != nil {
+		return err
+	}
+
 	b, err := txi.store.NewTransaction(txi.ctx, false)
 	if err != nil {
 		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
 	}
 	defer b.Discard(txi.ctx)
 
-	hash := types.Tx(result.Tx).Hash()
+	if err := txi.putEntry(b, entry); err != nil {
+		return err
+	}
 
-	// index tx by events
-	err = txi.indexEvents(result, hash, b)
+	return b.Commit(txi.ctx)
+}
+
+// txEntry holds all index entries of a transaction.
+type txEntry struct {
+	hash      []byte
+	heightKey string
+	eventKeys []string
+	rawBytes  []byte
+}
+
+// prepareEntry computes index entries of a transaction.
+func prepareEntry(result *abci.TxResult) (txEntry, error) {
+	rawBytes, err := proto.Marshal(result)
 	if err != nil {
-		return err
+		return txEntry{}, err
+	}
+	return txEntry{
+		hash:      rktypes.TxHash(result.Tx),
+		heightKey: keyForHeight(result),
+		eventKeys: eventKeys(result),
+		rawBytes:  rawBytes,
+	}, nil
+}
+
+// prepareEntries computes index entries of transactions, using up to workers goroutines.
+func (txi *TxIndex) prepareEntries(results []*abci.TxResult) ([]txEntry, error) {
+	entries := make([]txEntry, len(results))
+	errs := make([]error, len(results))
+	workers := min(max(txi.workers, 1), len(results))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w; i < len(results); i += workers {
+				entries[i], errs[i] = prepareEntry(results[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return entries, errors.Join(errs...)
+}
+
+// putEntry writes index entries of a transaction.
+func (txi *TxIndex) putEntry(b ds.Txn, entry txEntry) error {
+	// index tx by events
+	for _, key := range entry.eventKeys {
+		if err := b.Put(txi.ctx, ds.NewKey(key), entry.hash); err != nil {
+			return err
+		}
 	}
 
 	// index by height (always)
-	err = b.Put(txi.ctx, ds.NewKey(keyForHeight(result)), hash)
-	if err != nil {
+	if err := b.Put(txi.ctx, ds.NewKey(entry.heightKey), entry.hash); err != nil {
 		return err
 	}
 
-	rawBytes, err := proto.Marshal(result)
+	// index by hash (always)
+	if err := b.Put(txi.ctx, ds.NewKey(hex.EncodeToString(entry.hash)), entry.rawBytes); err != nil {
+		return err
+	}
+	// if the transaction isn't committed after all, it's just a false positive of the filter
+	if txi.filter != nil {
+		txi.filter.Add(entry.hash)
+	}
+	return nil
+}
+
+// PruneHeight removes all transactions indexed at given height, along with their event keys.
+func (txi *TxIndex) PruneHeight(height int64) error {
+	prefix := ds.NewKey(fmt.Sprintf("%s/%d/%d", types.TxHeightKey, height, height)).String()
+	results, err := store.PrefixEntries(txi.ctx, txi.store, prefix)
 	if err != nil {
 		return err
 	}
-	// index by hash (always)
-	err = b.Put(txi.ctx, ds.NewKey(hex.EncodeToString(hash)), rawBytes)
+	entries, err := results.Rest()
 	if err != nil {
 		return err
 	}
 
+	b, err := txi.store.NewTransaction(txi.ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
+	}
+	defer b.Discard(txi.ctx)
+
+	for _, entry := range entries {
+		if err := b.Delete(txi.ctx, ds.NewKey(entry.Key)); err != nil {
+			return err
+		}
+		result, err := txi.Get(entry.Value)
+		if err != nil {
+			return err
+		}
+		// the same transaction might be included again at another height, which is kept
+		if result == nil || result.Height != height {
+			continue
+		}
+		if err := txi.pruneEvents(result, b); err != nil {
+			return err
+		}
+		if err := b.Delete(txi.ctx, ds.NewKey(hex.EncodeToString(entry.Value))); err != nil {
+			return err
+		}
+	}
+
 	return b.Commit(txi.ctx)
 }
 
-func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store ds.Txn) error {
+func (txi *TxIndex) pruneEvents(result *abci.TxResult, store ds.Txn) error {
+	for _, event := range result.Result.Events {
+		for _, attr := range event.Attributes {
+			if len(event.Type) == 0 || len(attr.Key) == 0 || !attr.GetIndex() {
+				continue
+			}
+			if err := store.Delete(txi.ctx, ds.NewKey(keyForEvent(event.Type+"."+attr.Key, attr.Value, result))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// eventKeys returns keys under which transaction is indexed by its events.
+func eventKeys(result *abci.TxResult) []string {
+	var keys []string
 	for _, event := range result.Result.Events {
 		// only index events with a non-empty type
 		if len(event.Type) == 0 {
@@ -164,15 +331,12 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store ds.Txn
 			// index if `index: true` is set
 			compositeTag := event.Type + "." + attr.Key
 			if attr.GetIndex() {
-				err := store.Put(txi.ctx, ds.NewKey(keyForEvent(compositeTag, attr.Value, result)), hash)
-				if err != nil {
-					return err
-				}
+				keys = append(keys, keyForEvent(compositeTag, attr.Value, result))
 			}
 		}
 	}
 
-	return nil
+	return keys
 }
 
 // Search performs a search using the given query.
@@ -20,6 +20,7 @@ import (
 
 	"github.com/rollkit/rollkit/state/txindex"
 	"github.com/rollkit/rollkit/store"
+	rktypes "github.com/rollkit/rollkit/types"
 )
 
 func TestTxIndex(t *testing.T) {
@@ -69,6 +70,62 @@ func TestTxIndex(t *testing.T) {
 	assert.True(t, proto.Equal(txResult2, loadedTxResult2))
 }
 
+func TestTxIndexCustomHasher(t *testing.T) {
+	defer rktypes.SetTxHasher(nil)
+	require.NoError(t, rktypes.SetTxHasherByName(rktypes.TxHashKeccak256))
+
+	kvStore, _ := store.NewDefaultInMemoryKVStore()
+	indexer := NewTxIndex(context.Background(), kvStore, WithExistenceFilter())
+	txResult := txResultWithEvents(nil)
+	require.NoError(t, indexer.Index(txResult))
+
+	hash := rktypes.TxHash(txResult.Tx)
+	loaded, err := indexer.Get(hash)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(txResult, loaded))
+
+	// transactions are looked up by configured hash only
+	loaded, err = indexer.Get(types.Tx(txResult.Tx).Hash())
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	// "tx.hash" queries use configured hash too
+	results, err := indexer.Search(context.Background(), query.MustCompile(fmt.Sprintf("tx.hash = '%X'", hash)))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	reloaded := NewTxIndex(context.Background(), kvStore, WithExistenceFilter())
+	assert.True(t, reloaded.MayContain(hash))
+}
+
+func TestTxIndexWorkers(t *testing.T) {
+	kvStore, _ := store.NewDefaultInMemoryKVStore()
+	indexer := NewTxIndex(context.Background(), kvStore, WithWorkers(4))
+
+	const txsCount = 50
+	batch := txindex.NewBatch(txsCount)
+	for i := 0; i < txsCount; i++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: fmt.Sprint(i), Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Index = uint32(i)
+		require.NoError(t, batch.Add(txResult))
+	}
+	require.NoError(t, indexer.AddBatch(batch))
+
+	for i, txResult := range batch.Ops {
+		loadedTxResult, err := indexer.Get(types.Tx(txResult.Tx).Hash())
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(txResult, loadedTxResult))
+
+		results, err := indexer.Search(context.Background(), query.MustCompile(fmt.Sprintf("account.number = %d", i)))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, proto.Equal(txResult, results[0]))
+	}
+}
+
 func TestTxSearch(t *testing.T) {
 	kvStore, _ := store.NewDefaultInMemoryKVStore()
 	indexer := NewTxIndex(context.Background(), kvStore)
@@ -324,6 +381,52 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxIndexPruneHeight(t *testing.T) {
+	kvStore, _ := store.NewDefaultInMemoryKVStore()
+	indexer := NewTxIndex(context.Background(), kvStore)
+
+	events := []abci.Event{{Type: "account", Attributes: []abci.EventAttribute{{Key: "owner", Value: "Ivan", Index: true}}}}
+	newResult := func(tx string, height int64, index uint32) *abci.TxResult {
+		return &abci.TxResult{
+			Height: height,
+			Index:  index,
+			Tx:     types.Tx(tx),
+			Result: abci.ExecTxResult{Code: abci.CodeTypeOK, Events: events},
+		}
+	}
+	pruned := newResult("pruned", 1, 0)
+	kept := newResult("kept", 10, 0)
+	// the same transaction included again at later height is kept
+	duplicate := newResult("duplicate", 1, 1)
+	for _, result := range []*abci.TxResult{pruned, kept, duplicate, newResult("duplicate", 11, 0)} {
+		require.NoError(t, indexer.Index(result))
+	}
+
+	require.NoError(t, indexer.PruneHeight(1))
+
+	result, err := indexer.Get(types.Tx(pruned.Tx).Hash())
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	result, err = indexer.Get(types.Tx(kept.Tx).Hash())
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	result, err = indexer.Get(types.Tx(duplicate.Tx).Hash())
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.EqualValues(t, 11, result.Height)
+
+	ctx := context.Background()
+	results, err := indexer.Search(ctx, query.MustCompile("account.owner = 'Ivan'"))
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+	results, err = indexer.Search(ctx, query.MustCompile("tx.height = 1"))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = indexer.Search(ctx, query.MustCompile("tx.height = 10"))
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -386,3 +489,40 @@ func BenchmarkTxIndex500(b *testing.B)   { benchmarkTxIndex(500, b) }
 func BenchmarkTxIndex1000(b *testing.B)  { benchmarkTxIndex(1000, b) }
 func BenchmarkTxIndex2000(b *testing.B)  { benchmarkTxIndex(2000, b) }
 func BenchmarkTxIndex10000(b *testing.B) { benchmarkTxIndex(10000, b) }
+
+func TestTxIndexExistenceFilter(t *testing.T) {
+	kvStore, _ := store.NewDefaultInMemoryKVStore()
+	indexer := NewTxIndex(context.Background(), kvStore, WithExistenceFilter())
+
+	const txsCount = 20
+	batch := txindex.NewBatch(txsCount)
+	for i := 0; i < txsCount; i++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: fmt.Sprint(i), Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Index = uint32(i)
+		require.NoError(t, batch.Add(txResult))
+	}
+	require.NoError(t, indexer.AddBatch(batch))
+
+	missing := types.Tx("missing").Hash()
+	assert.False(t, indexer.MayContain(missing))
+	res, err := indexer.Get(missing)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	// filter is rebuilt from the store
+	reloaded := NewTxIndex(context.Background(), kvStore, WithExistenceFilter())
+	for _, txResult := range batch.Ops {
+		hash := types.Tx(txResult.Tx).Hash()
+		assert.True(t, reloaded.MayContain(hash))
+		res, err := reloaded.Get(hash)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(txResult, res))
+	}
+	assert.False(t, reloaded.MayContain(missing))
+
+	// without filter every hash may be indexed
+	assert.True(t, NewTxIndex(context.Background(), kvStore).MayContain(missing))
+}
@@ -33,3 +33,8 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	return []*abci.TxResult{}, nil
 }
+
+// PruneHeight is a noop and always returns nil.
+func (txi *TxIndex) PruneHeight(height int64) error {
+	return nil
+}
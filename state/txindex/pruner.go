@@ -0,0 +1,148 @@
+package txindex
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/state/indexer"
+	"github.com/rollkit/rollkit/store"
+)
+
+// EventsPrunedHeightKey is the metadata key of the height up to which (inclusive) events were pruned.
+const EventsPrunedHeightKey = "events pruned height"
+
+// maxPrunedHeightsPerRun limits the number of heights pruned in a single run, so that pruning of
+// a long history is spread over multiple runs.
+const maxPrunedHeightsPerRun = 10000
+
+// ErrEventsPruned is returned when events at requested height were already pruned.
+var ErrEventsPruned = errors.New("events were pruned")
+
+// Pruner removes indexed transactions, block events and block responses older than configured number of
+// blocks. Blocks are kept, so that event data, which usually dominates disk usage, can be retained for a
+// shorter period than blocks.
+type Pruner struct {
+	store     store.Store
+	txIdxr    TxIndexer
+	blockIdxr indexer.BlockIndexer
+
+	retainBlocks uint64
+	interval     time.Duration
+
+	logger log.Logger
+}
+
+// NewPruner creates Pruner keeping events of retainBlocks latest blocks. Pruning is disabled if retainBlocks is 0.
+func NewPruner(store store.Store, txIdxr TxIndexer, blockIdxr indexer.BlockIndexer, retainBlocks uint64, interval time.Duration, logger log.Logger) *Pruner {
+	return &Pruner{
+		store:        store,
+		txIdxr:       txIdxr,
+		blockIdxr:    blockIdxr,
+		retainBlocks: retainBlocks,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// Run prunes events periodically until context is cancelled.
+func (p *Pruner) Run(ctx context.Context) {
+	if p.retainBlocks == 0 || p.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.Prune(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Error("failed to prune events", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune removes events of blocks older than retained ones and returns the height up to which (inclusive)
+// events are pruned.
+func (p *Pruner) Prune(ctx context.Context) (uint64, error) {
+	pruned, err := PrunedHeight(ctx, p.store)
+	if err != nil {
+		return 0, err
+	}
+	height := p.store.Height()
+	if height <= p.retainBlocks {
+		return pruned, nil
+	}
+	target := min(height-p.retainBlocks, pruned+maxPrunedHeightsPerRun)
+	if target <= pruned {
+		return pruned, nil
+	}
+
+	start := time.Now()
+	for h := pruned + 1; h <= target; h++ {
+		if err := ctx.Err(); err != nil {
+			return pruned, err
+		}
+		if err := p.pruneHeight(ctx, h); err != nil {
+			return pruned, err
+		}
+		pruned = h
+		// progress is saved periodically, pruning already pruned heights again is harmless
+		if h%100 == 0 || h == target {
+			if err := setPrunedHeight(ctx, p.store, pruned); err != nil {
+				return pruned, err
+			}
+		}
+	}
+	p.logger.Info("pruned events", "height", pruned, "duration", time.Since(start))
+	return pruned, nil
+}
+
+// pruneHeight removes events of block at given height. Block events are known only from block
+// responses, so they are removed from the block indexer before the responses.
+func (p *Pruner) pruneHeight(ctx context.Context, height uint64) error {
+	h := int64(height) //nolint:gosec
+	if err := p.txIdxr.PruneHeight(h); err != nil {
+		return fmt.Errorf("failed to prune transactions at height %d: %w", height, err)
+	}
+	responses, err := p.store.GetBlockResponses(ctx, height)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := p.blockIdxr.Prune(types.EventDataNewBlockEvents{Height: h, Events: responses.Events}); err != nil {
+		return fmt.Errorf("failed to prune block events at height %d: %w", height, err)
+	}
+	return p.store.DeleteBlockResponses(ctx, height)
+}
+
+// PrunedHeight returns the height up to which (inclusive) events were pruned, 0 if events were never pruned.
+func PrunedHeight(ctx context.Context, s store.Store) (uint64, error) {
+	value, err := s.GetMetadata(ctx, EventsPrunedHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid events pruned height, length %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+func setPrunedHeight(ctx context.Context, s store.Store, height uint64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, height)
+	return s.SetMetadata(ctx, EventsPrunedHeightKey, value)
+}
@@ -0,0 +1,71 @@
+package txindex_test
+
+import (
+	"context"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+	ktds "github.com/ipfs/go-datastore/keytransform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	blockidxkv "github.com/rollkit/rollkit/state/indexer/block/kv"
+	"github.com/rollkit/rollkit/state/txindex"
+	"github.com/rollkit/rollkit/state/txindex/kv"
+	"github.com/rollkit/rollkit/store"
+)
+
+func TestPruner(t *testing.T) {
+	ctx := context.Background()
+	kvStore, _ := store.NewDefaultInMemoryKVStore()
+	s := store.New(kvStore)
+	txIndexer := kv.NewTxIndex(ctx, kvStore)
+	prefixStore := (ktds.Wrap(kvStore, ktds.PrefixTransform{Prefix: ds.NewKey("block_events")}).Children()[0]).(ds.TxnDatastore)
+	blockIndexer := blockidxkv.New(ctx, prefixStore)
+
+	events := []abci.Event{{Type: "begin_event", Attributes: []abci.EventAttribute{{Key: "proposer", Value: "FCAA001", Index: true}}}}
+	const height = 5
+	for h := uint64(1); h <= height; h++ {
+		tx := types.Tx{byte(h)}
+		require.NoError(t, s.SaveBlockResponses(ctx, h, &abci.ResponseFinalizeBlock{
+			Events:    events,
+			TxResults: []*abci.ExecTxResult{{Code: abci.CodeTypeOK}},
+		}))
+		require.NoError(t, blockIndexer.Index(types.EventDataNewBlockEvents{Height: int64(h), Events: events, NumTxs: 1}))
+		require.NoError(t, txIndexer.Index(&abci.TxResult{Height: int64(h), Tx: tx}))
+		s.SetHeight(ctx, h)
+	}
+
+	pruner := txindex.NewPruner(s, txIndexer, blockIndexer, 2, 0, log.TestingLogger())
+	pruned, err := pruner.Prune(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pruned)
+	stored, err := txindex.PrunedHeight(ctx, s)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, stored)
+
+	for h := uint64(1); h <= height; h++ {
+		_, err := s.GetBlockResponses(ctx, h)
+		result, txErr := txIndexer.Get(types.Tx{byte(h)}.Hash())
+		require.NoError(t, txErr)
+		if h <= pruned {
+			assert.ErrorIs(t, err, ds.ErrNotFound)
+			assert.Nil(t, result)
+		} else {
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+		}
+	}
+	heights, err := blockIndexer.Search(ctx, query.MustCompile("begin_event.proposer = 'FCAA001'"))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4, 5}, heights)
+
+	// nothing to prune until new blocks are added
+	pruned, err = pruner.Prune(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pruned)
+}
@@ -0,0 +1,321 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// ArchiveVersion is the version of the archive format written by Export. Import reads archives of this version.
+const ArchiveVersion = 1
+
+// archiveMagic identifies archives written by Export.
+const archiveMagic = "rollkit-archive"
+
+// maxArchiveRecordSize limits size of a record read by Import, so that corrupted archives don't cause huge
+// allocations.
+const maxArchiveRecordSize = 1 << 30
+
+// archiveBlockFields returns records of the block, in order of field numbers.
+func archiveBlockFields(b *pb.ArchiveBlock) []*[]byte {
+	return []*[]byte{&b.Header, &b.Data, &b.Signature, &b.Responses, &b.ExtendedCommit, &b.StateRecord, &b.DaCertificate}
+}
+
+// Export writes blocks from from to to (inclusive) with their signatures, extended commits, responses, state
+// records and DA inclusion certificates, followed by the state after applying block to, into a versioned archive.
+// Archive is streamed, so it can be written to a file or piped to Import of another store, e.g. to bootstrap a new
+// node or to migrate to another database backend without syncing from DA.
+//
+// Blocks must be available: data of pruned blocks can't be exported. Blocks above the state height are not
+// exported, as they are not applied yet.
+func (s *DefaultStore) Export(ctx context.Context, w io.Writer, from, to uint64) error {
+	state, err := s.GetState(ctx)
+	if err != nil {
+		return err
+	}
+	if from == 0 || from > to || to > state.LastBlockHeight {
+		return fmt.Errorf("invalid export range [%d, %d], must be within [1, %d]", from, to, state.LastBlockHeight)
+	}
+	if to < state.LastBlockHeight {
+		if state, err = s.stateAt(ctx, state, to); err != nil {
+			return err
+		}
+	}
+
+	header := &pb.ArchiveHeader{Magic: archiveMagic, Version: ArchiveVersion, From: from, To: to}
+	if err := writeArchiveRecord(w, &pb.ArchiveRecord{Record: &pb.ArchiveRecord_Header{Header: header}}); err != nil {
+		return err
+	}
+
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		block, err := s.exportBlock(ctx, height)
+		if err != nil {
+			return fmt.Errorf("failed to export block %d: %w", height, err)
+		}
+		if err := writeArchiveRecord(w, &pb.ArchiveRecord{Record: &pb.ArchiveRecord_Block{Block: block}}); err != nil {
+			return err
+		}
+	}
+
+	pbState, err := state.ToProto()
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	blob, err := pbState.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	return writeArchiveRecord(w, &pb.ArchiveRecord{Record: &pb.ArchiveRecord_State{State: blob}})
+}
+
+// exportBlock reads records of the block at given height, as they are saved in the datastore.
+func (s *DefaultStore) exportBlock(ctx context.Context, height uint64) (*pb.ArchiveBlock, error) {
+	block := &pb.ArchiveBlock{}
+	keys := []string{
+		getHeaderKey(height),
+		getDataKey(height),
+		getSignatureKey(height),
+		getResponsesKey(height),
+		getExtendedCommitKey(height),
+		getStateRecordKey(height),
+		getDACertificateKey(height),
+	}
+	for i, field := range archiveBlockFields(block) {
+		blob, err := s.db.Get(ctx, ds.NewKey(keys[i]))
+		switch {
+		case err == nil:
+			*field = blob
+		case !errors.Is(err, ds.ErrNotFound):
+			return nil, err
+		case i == 1:
+			// header is available, but data was pruned
+			if block.Header != nil {
+				return nil, ErrBlockDataPruned
+			}
+			return nil, err
+		case i < 3:
+			return nil, err
+		}
+	}
+	return block, nil
+}
+
+// writeArchiveRecord writes record prefixed with its size.
+func writeArchiveRecord(w io.Writer, record *pb.ArchiveRecord) error {
+	blob, err := record.Marshal()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(blob)))); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if _, err := w.Write(blob); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// Import reads archive written by Export and saves its blocks and state. Blocks are validated against their headers
+// and linked with previous headers. If the store is not empty, the archive must continue from the state height of
+// the store, and belong to the same chain. Height of the store is set to the height of the last imported block.
+//
+// Import must not be used while the node is running. It can be repeated if it's interrupted, as State is saved
+// after all blocks. Range of imported blocks is returned.
+func (s *DefaultStore) Import(ctx context.Context, r io.Reader) (from, to uint64, err error) {
+	br := bufio.NewReader(r)
+	record, err := readArchiveRecord(br)
+	if err != nil {
+		return 0, 0, err
+	}
+	if record.GetHeader() == nil {
+		return 0, 0, errors.New("invalid archive: header not found")
+	}
+	if from, to, err = parseArchiveHeader(record.GetHeader()); err != nil {
+		return 0, 0, err
+	}
+	if err := s.importRecords(ctx, br, from, to); err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
+
+// importRecords imports blocks from from to to and state following the header of the archive.
+func (s *DefaultStore) importRecords(ctx context.Context, br *bufio.Reader, from, to uint64) error {
+	var (
+		prevHeader *types.SignedHeader
+		chainID    string
+	)
+	state, err := s.GetState(ctx)
+	switch {
+	case errors.Is(err, ds.ErrNotFound):
+	case err != nil:
+		return err
+	case from != state.LastBlockHeight+1:
+		return fmt.Errorf("archive starts at height %d, but store state height is %d", from, state.LastBlockHeight)
+	default:
+		chainID = state.ChainID
+		if prevHeader, err = s.GetHeader(ctx, state.LastBlockHeight); err != nil {
+			return err
+		}
+	}
+
+	for height := from; ; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		record, err := readArchiveRecord(br)
+		if errors.Is(err, io.EOF) {
+			return errors.New("invalid archive: state not found, archive is truncated")
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case record.GetBlock() != nil && height <= to:
+			if prevHeader, err = s.importBlock(ctx, record.GetBlock(), height, prevHeader); err != nil {
+				return fmt.Errorf("failed to import block %d: %w", height, err)
+			}
+			continue
+		case record.GetState() == nil || height != to+1:
+			return fmt.Errorf("invalid archive: unexpected record %T at height %d", record.Record, height)
+		}
+
+		var imported types.State
+		if err := imported.UnmarshalBinary(record.GetState()); err != nil {
+			return fmt.Errorf("failed to unmarshal state: %w", err)
+		}
+		if imported.LastBlockHeight != to {
+			return fmt.Errorf("invalid archive: state height %d doesn't match the last block %d", imported.LastBlockHeight, to)
+		}
+		if chainID != "" && imported.ChainID != chainID {
+			return fmt.Errorf("archive of chain %q can't be imported into store of chain %q", imported.ChainID, chainID)
+		}
+		if _, err := readArchiveRecord(br); !errors.Is(err, io.EOF) {
+			return errors.New("invalid archive: unexpected data after state")
+		}
+		if err := s.UpdateState(ctx, imported); err != nil {
+			return fmt.Errorf("failed to save state: %w", err)
+		}
+		s.SetHeight(ctx, to)
+		return nil
+	}
+}
+
+// importBlock validates and saves block from given archive record, and returns its header.
+func (s *DefaultStore) importBlock(ctx context.Context, block *pb.ArchiveBlock, height uint64, prevHeader *types.SignedHeader) (*types.SignedHeader, error) {
+	header := new(types.SignedHeader)
+	if err := header.UnmarshalBinary(block.Header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal header: %w", err)
+	}
+	data := new(types.Data)
+	if err := data.UnmarshalBinary(block.Data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+	if header.Height() != height {
+		return nil, fmt.Errorf("unexpected height of header %d", header.Height())
+	}
+	if err := types.Validate(header, data); err != nil {
+		return nil, err
+	}
+	if prevHeader != nil && !bytes.Equal(header.LastHeaderHash, prevHeader.Hash()) {
+		return nil, errors.New("header doesn't link to the previous header")
+	}
+	signature := types.Signature(block.Signature)
+	if err := s.SaveBlockData(ctx, header, data, &signature); err != nil {
+		return nil, err
+	}
+
+	if len(block.Responses) != 0 {
+		responses := new(abci.ResponseFinalizeBlock)
+		if err := responses.Unmarshal(block.Responses); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal responses: %w", err)
+		}
+		if err := s.SaveBlockResponses(ctx, height, responses); err != nil {
+			return nil, err
+		}
+	}
+	if len(block.ExtendedCommit) != 0 {
+		commit := new(abci.ExtendedCommitInfo)
+		if err := commit.Unmarshal(block.ExtendedCommit); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal extended commit: %w", err)
+		}
+		if err := s.SaveExtendedCommit(ctx, height, commit); err != nil {
+			return nil, err
+		}
+	}
+	if len(block.StateRecord) != 0 {
+		if err := new(types.StateRecord).UnmarshalBinary(block.StateRecord); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal state record: %w", err)
+		}
+		if err := s.db.Put(ctx, ds.NewKey(getStateRecordKey(height)), block.StateRecord); err != nil {
+			return nil, err
+		}
+	}
+	if len(block.DaCertificate) != 0 {
+		cert := new(types.DAInclusionCertificate)
+		if err := cert.UnmarshalBinary(block.DaCertificate); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal DA inclusion certificate: %w", err)
+		}
+		if err := s.SaveDAInclusionCertificate(ctx, cert); err != nil {
+			return nil, err
+		}
+	}
+	return header, nil
+}
+
+// parseArchiveHeader validates header of the archive and returns range of exported blocks.
+func parseArchiveHeader(header *pb.ArchiveHeader) (from, to uint64, err error) {
+	from, to = header.From, header.To
+	switch {
+	case header.Magic != archiveMagic:
+		return 0, 0, errors.New("invalid archive: unknown format")
+	case header.Version != ArchiveVersion:
+		return 0, 0, fmt.Errorf("unsupported archive version %d, supported version is %d", header.Version, ArchiveVersion)
+	case from == 0 || from > to:
+		return 0, 0, fmt.Errorf("invalid archive: invalid range [%d, %d]", from, to)
+	}
+	return from, to, nil
+}
+
+// readArchiveRecord reads next record of the archive. io.EOF is returned at the end of the archive.
+func readArchiveRecord(r *bufio.Reader) (*pb.ArchiveRecord, error) {
+	size, err := binary.ReadUvarint(r)
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if size > maxArchiveRecordSize {
+		return nil, fmt.Errorf("invalid archive: record of %d bytes is too big", size)
+	}
+	blob := make([]byte, size)
+	if _, err := io.ReadFull(r, blob); err != nil {
+		// io.EOF means the end of the archive, it's unexpected inside a record
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	record := new(pb.ArchiveRecord)
+	if err := record.Unmarshal(blob); err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	if record.Record == nil {
+		return nil, errors.New("invalid archive: empty record")
+	}
+	return record, nil
+}
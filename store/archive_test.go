@@ -0,0 +1,120 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx := context.Background()
+	const chainID = "TestExportImport"
+	validatorSet := types.GetRandomValidatorSet()
+
+	newStore := func() Store {
+		kv, err := NewDefaultInMemoryKVStore()
+		require.NoError(err)
+		return New(kv)
+	}
+	src := newStore()
+
+	header, data, privKey := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 1, NTxs: 2}, chainID)
+	for h := uint64(1); h <= 3; h++ {
+		if h > 1 {
+			header, data = types.GetRandomNextBlock(header, data, privKey, nil, 2, chainID)
+		}
+		require.NoError(src.SaveBlockData(ctx, header, data, &header.Signature))
+		require.NoError(src.SaveBlockResponses(ctx, h, &abci.ResponseFinalizeBlock{AppHash: []byte{byte(h)}}))
+		require.NoError(src.SaveDAInclusionCertificate(ctx, &types.DAInclusionCertificate{Height: h, DAHeight: 10 + h}))
+		src.SetHeight(ctx, h)
+		require.NoError(src.UpdateState(ctx, types.State{
+			ChainID:         chainID,
+			InitialHeight:   1,
+			LastBlockHeight: h,
+			LastBlockTime:   header.Time(),
+			AppHash:         []byte(fmt.Sprintf("app hash %d", h)),
+			DAHeight:        10 + h,
+			NextValidators:  validatorSet,
+			Validators:      validatorSet,
+			LastValidators:  validatorSet,
+		}))
+	}
+
+	var archive bytes.Buffer
+	require.Error(src.Export(ctx, &archive, 0, 3))
+	require.Error(src.Export(ctx, &archive, 1, 4))
+	require.NoError(src.Export(ctx, &archive, 1, 3))
+
+	dst := newStore()
+	from, to, err := dst.Import(ctx, bytes.NewReader(archive.Bytes()))
+	require.NoError(err)
+	require.Equal(uint64(1), from)
+	require.Equal(uint64(3), to)
+	require.Equal(uint64(3), dst.Height())
+	for h := uint64(1); h <= 3; h++ {
+		srcHeader, srcData, err := src.GetBlockData(ctx, h)
+		require.NoError(err)
+		dstHeader, dstData, err := dst.GetBlockData(ctx, h)
+		require.NoError(err)
+		require.Equal(srcHeader.Hash(), dstHeader.Hash())
+		require.Equal(srcData.Txs, dstData.Txs)
+		_, err = dst.GetHeaderByHash(ctx, srcHeader.Hash())
+		require.NoError(err)
+		signature, err := dst.GetSignature(ctx, h)
+		require.NoError(err)
+		require.Equal(srcHeader.Signature, *signature)
+		responses, err := dst.GetBlockResponses(ctx, h)
+		require.NoError(err)
+		require.Equal([]byte{byte(h)}, responses.AppHash)
+		record, err := dst.GetStateRecord(ctx, h)
+		require.NoError(err)
+		require.Equal(types.Hash(fmt.Sprintf("app hash %d", h)), record.AppHash)
+		cert, err := dst.GetDAInclusionCertificate(ctx, h)
+		require.NoError(err)
+		require.Equal(10+h, cert.DAHeight)
+	}
+	srcState, err := src.GetState(ctx)
+	require.NoError(err)
+	dstState, err := dst.GetState(ctx)
+	require.NoError(err)
+	require.Equal(srcState.AppHash, dstState.AppHash)
+	require.Equal(srcState.DAHeight, dstState.DAHeight)
+
+	// archive continuing from the state height is appended, other ranges are rejected
+	partial := newStore()
+	archive.Reset()
+	require.NoError(src.Export(ctx, &archive, 1, 2))
+	_, _, err = partial.Import(ctx, &archive)
+	require.NoError(err)
+	state, err := partial.GetState(ctx)
+	require.NoError(err)
+	require.Equal(uint64(2), state.LastBlockHeight)
+	require.Equal(types.Hash("app hash 2"), state.AppHash)
+
+	archive.Reset()
+	require.NoError(src.Export(ctx, &archive, 1, 1))
+	_, _, err = partial.Import(ctx, &archive)
+	require.Error(err)
+
+	archive.Reset()
+	require.NoError(src.Export(ctx, &archive, 3, 3))
+	_, _, err = partial.Import(ctx, &archive)
+	require.NoError(err)
+	require.Equal(uint64(3), partial.Height())
+
+	// truncated and corrupted archives are rejected
+	archive.Reset()
+	require.NoError(src.Export(ctx, &archive, 1, 3))
+	_, _, err = newStore().Import(ctx, bytes.NewReader(archive.Bytes()[:archive.Len()-10]))
+	require.Error(err)
+	_, _, err = newStore().Import(ctx, bytes.NewReader([]byte("not an archive")))
+	require.Error(err)
+}
@@ -0,0 +1,200 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dssync "github.com/ipfs/go-datastore/sync"
+
+	"github.com/rollkit/rollkit/config"
+)
+
+// syncKey is deleted with a synced write to flush previous writes of the database to disk, see dbmDatastore.Sync.
+var syncKey = []byte("/sync")
+
+// NewBackendKVStore creates key-value store with given backend (see config.DBBackendBadger and others) located in
+// directory of given name. Stores other than badger don't support transactions, so they are wrapped with
+// NewTxnDatastore. Garbage collection discard ratio is used only by badger.
+//
+// Pebble backend is available only in binaries built with pebbledb build tag, like in CometBFT.
+func NewBackendKVStore(backend, rootDir, dbPath, dbName string, gcDiscardRatio float64) (ds.TxnDatastore, error) {
+	switch backend {
+	case "", config.DBBackendBadger:
+		return NewKVStore(rootDir, dbPath, dbName, gcDiscardRatio)
+	case config.DBBackendPebble:
+		return newDBMKVStore(dbm.PebbleDBBackend, rootDir, dbPath, dbName)
+	case config.DBBackendLevelDB:
+		return newDBMKVStore(dbm.GoLevelDBBackend, rootDir, dbPath, dbName)
+	case config.DBBackendMemory:
+		return NewMapKVStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown database backend %q", backend)
+	}
+}
+
+// NewMapKVStore creates in-memory key-value store backed by a map. Unlike in-memory badger, it doesn't
+// preallocate memory tables, so it's cheap to create, but reads are not isolated from concurrent writes.
+func NewMapKVStore() ds.TxnDatastore {
+	return NewTxnDatastore(dssync.MutexWrap(ds.NewMapDatastore()))
+}
+
+// newDBMKVStore opens CometBFT database in the directory of the store (see Path), like badger, so that disk
+// space of all backends is watched by Maintainer in the same directory.
+func newDBMKVStore(backend dbm.BackendType, rootDir, dbPath, dbName string) (ds.TxnDatastore, error) {
+	db, err := dbm.NewDB("data", backend, Path(rootDir, dbPath, dbName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", backend, err)
+	}
+	return NewTxnDatastore(&dbmDatastore{db: db}), nil
+}
+
+// dbmDatastore adapts CometBFT database (e.g. goleveldb or pebble) to ds.Batching.
+type dbmDatastore struct {
+	db dbm.DB
+}
+
+var _ ds.Batching = &dbmDatastore{}
+
+// Get returns value of the key, or ds.ErrNotFound if the key doesn't exist.
+func (d *dbmDatastore) Get(_ context.Context, key ds.Key) ([]byte, error) {
+	value, err := d.db.Get(key.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, ds.ErrNotFound
+	}
+	return value, nil
+}
+
+// Has returns whether the key exists.
+func (d *dbmDatastore) Has(_ context.Context, key ds.Key) (bool, error) {
+	return d.db.Has(key.Bytes())
+}
+
+// GetSize returns size of value of the key, or ds.ErrNotFound if the key doesn't exist.
+func (d *dbmDatastore) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	value, err := d.Get(ctx, key)
+	if err != nil {
+		return -1, err
+	}
+	return len(value), nil
+}
+
+// Put sets value of the key. Value is copied, as some databases keep the slice.
+func (d *dbmDatastore) Put(_ context.Context, key ds.Key, value []byte) error {
+	return d.db.Set(key.Bytes(), cloneValue(value))
+}
+
+// Delete removes the key.
+func (d *dbmDatastore) Delete(_ context.Context, key ds.Key) error {
+	return d.db.Delete(key.Bytes())
+}
+
+// Sync flushes all previous writes to disk. Databases write to a single write-ahead log, so a synced write of any
+// key flushes writes of all keys, regardless of the prefix.
+func (d *dbmDatastore) Sync(context.Context, ds.Key) error {
+	return d.db.DeleteSync(syncKey)
+}
+
+// Query iterates over keys with the prefix of the query in ascending order, or in descending order if the query is
+// ordered by key descending. Offset of queries without filters is applied by skipping keys; other filters, orders,
+// offset and limit are applied to the results naively.
+func (d *dbmDatastore) Query(_ context.Context, q dsq.Query) (dsq.Results, error) {
+	prefix := ds.NewKey(q.Prefix).String()
+	if prefix != "/" {
+		prefix += "/"
+	}
+	start := []byte(prefix)
+	iterator := d.db.Iterator
+	if len(q.Orders) > 0 {
+		switch q.Orders[0].(type) {
+		case dsq.OrderByKey, *dsq.OrderByKey:
+			q.Orders = nil
+		case dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+			iterator = d.db.ReverseIterator
+			q.Orders = nil
+		}
+	}
+	it, err := iterator(start, prefixEnd(start))
+	if err != nil {
+		return nil, err
+	}
+	if len(q.Filters) == 0 && len(q.Orders) == 0 {
+		for ; q.Offset > 0 && it.Valid(); q.Offset-- {
+			it.Next()
+		}
+	}
+	results := dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			if !it.Valid() {
+				if err := it.Error(); err != nil {
+					return dsq.Result{Error: err}, true
+				}
+				return dsq.Result{}, false
+			}
+			entry := dsq.Entry{Key: string(it.Key()), Size: len(it.Value())}
+			if !q.KeysOnly {
+				entry.Value = bytes.Clone(it.Value())
+			}
+			it.Next()
+			return dsq.Result{Entry: entry}, true
+		},
+		Close: it.Close,
+	})
+	q.Prefix = "" // already applied by the iterator
+	return dsq.NaiveQueryApply(q, results), nil
+}
+
+// Batch returns batch of writes of the database.
+func (d *dbmDatastore) Batch(context.Context) (ds.Batch, error) {
+	return &dbmBatch{batch: d.db.NewBatch()}, nil
+}
+
+// Close closes the database.
+func (d *dbmDatastore) Close() error {
+	return d.db.Close()
+}
+
+// dbmBatch adapts batch of CometBFT database to ds.Batch.
+type dbmBatch struct {
+	batch dbm.Batch
+}
+
+// Put adds write of the key to the batch. Value is copied, as some databases keep the slice until the batch is
+// written.
+func (b *dbmBatch) Put(_ context.Context, key ds.Key, value []byte) error {
+	return b.batch.Set(key.Bytes(), cloneValue(value))
+}
+
+// Delete adds removal of the key to the batch.
+func (b *dbmBatch) Delete(_ context.Context, key ds.Key) error {
+	return b.batch.Delete(key.Bytes())
+}
+
+// Commit writes the batch to the database and releases it.
+func (b *dbmBatch) Commit(context.Context) error {
+	return errors.Join(b.batch.Write(), b.batch.Close())
+}
+
+// cloneValue copies the value. CometBFT databases don't accept nil values, so nil is copied as an empty slice.
+func cloneValue(value []byte) []byte {
+	return append([]byte{}, value...)
+}
+
+// prefixEnd returns the smallest key greater than all keys with the prefix, or nil if there is no such key.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestBackendKVStore(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []string{config.DBBackendBadger, config.DBBackendLevelDB, config.DBBackendMemory} {
+		t.Run(backend, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+			dir := t.TempDir()
+
+			kv, err := NewBackendKVStore(backend, dir, "data", "rollkit", 0.5)
+			require.NoError(err)
+			s := New(kv, WithSyncInterval(1))
+			header, data := types.GetRandomBlock(1, 2, "TestBackendKVStore")
+			require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+			s.SetHeight(ctx, 1)
+
+			loaded, err := s.LoadBlockRange(ctx, 1, 1)
+			require.NoError(err)
+			require.Equal(header.Hash(), loaded[0].Header.Hash())
+			require.Equal(data.Txs, loaded[0].Data.Txs)
+
+			// values written outside of transactions from pooled buffers are not overwritten by later writes
+			for h := uint64(1); h <= 2; h++ {
+				require.NoError(s.SaveBlockResponses(ctx, h, &abci.ResponseFinalizeBlock{AppHash: []byte{byte(h)}}))
+			}
+			responses, err := s.GetBlockResponses(ctx, 1)
+			require.NoError(err)
+			require.Equal([]byte{1}, responses.AppHash)
+			require.NoError(s.Close())
+			if backend == config.DBBackendMemory {
+				return
+			}
+
+			// blocks are persisted in the directory of the store
+			kv, err = NewBackendKVStore(backend, dir, "data", "rollkit", 0.5)
+			require.NoError(err)
+			s = New(kv)
+			s.SetHeight(ctx, 1)
+			loaded, err = s.LoadBlockRange(ctx, 1, 1)
+			require.NoError(err)
+			require.Equal(header.Hash(), loaded[0].Header.Hash())
+			require.NoError(s.Close())
+		})
+	}
+
+	_, err := NewBackendKVStore("rocksdb", t.TempDir(), "data", "rollkit", 0.5)
+	require.Error(t, err)
+}
+
+func TestDBMDatastoreQuery(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := NewBackendKVStore(config.DBBackendLevelDB, t.TempDir(), "", "rollkit", 0)
+	require.NoError(err)
+	defer kv.Close()
+
+	for _, key := range []string{"/h/1", "/h/2", "/ha/1", "/i/1"} {
+		require.NoError(kv.Put(ctx, ds.NewKey(key), []byte(key)))
+	}
+	require.NoError(kv.Put(ctx, ds.NewKey("/h/3"), nil))
+
+	// prefix matches whole path segments, like in other datastores
+	results, err := kv.Query(ctx, dsq.Query{Prefix: "/h"})
+	require.NoError(err)
+	entries, err := results.Rest()
+	require.NoError(err)
+	require.Len(entries, 3)
+	require.Equal("/h/1", entries[0].Key)
+	require.Equal([]byte("/h/1"), entries[0].Value)
+	require.Empty(entries[2].Value)
+
+	results, err = kv.Query(ctx, dsq.Query{KeysOnly: true, Limit: 2, Orders: []dsq.Order{dsq.OrderByKeyDescending{}}})
+	require.NoError(err)
+	entries, err = results.Rest()
+	require.NoError(err)
+	require.Equal([]string{"/i/1", "/ha/1"}, []string{entries[0].Key, entries[1].Key})
+	require.Nil(entries[0].Value)
+
+	_, err = kv.Get(ctx, ds.NewKey("/h/4"))
+	require.ErrorIs(err, ds.ErrNotFound)
+}
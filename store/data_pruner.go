@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/third_party/log"
+)
+
+// BlockDataPrunedHeightKey is the metadata key of the height up to which (inclusive) block data was pruned.
+const BlockDataPrunedHeightKey = "block data pruned height"
+
+// maxPrunedBlocksPerRun limits the number of blocks pruned in a single run, so that pruning of a long
+// history is spread over multiple runs.
+const maxPrunedBlocksPerRun = 10000
+
+// DataPruner removes data of blocks older than configured number of blocks. Headers, signatures and hashes
+// of transactions are kept, so that light clients can still verify headers and inclusion proofs of old
+// transactions can be served without archiving full blocks.
+type DataPruner struct {
+	store        Store
+	retainBlocks uint64
+	interval     time.Duration
+	logger       log.Logger
+}
+
+// NewDataPruner creates DataPruner keeping data of retainBlocks latest blocks. Pruning is disabled if retainBlocks is 0.
+func NewDataPruner(store Store, retainBlocks uint64, interval time.Duration, logger log.Logger) *DataPruner {
+	return &DataPruner{
+		store:        store,
+		retainBlocks: retainBlocks,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// Run prunes block data periodically until context is cancelled.
+func (p *DataPruner) Run(ctx context.Context) {
+	if p.retainBlocks == 0 || p.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.Prune(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Error("failed to prune block data", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune removes data of blocks older than retained ones and returns the height up to which (inclusive)
+// block data is pruned.
+func (p *DataPruner) Prune(ctx context.Context) (uint64, error) {
+	pruned, err := DataPrunedHeight(ctx, p.store)
+	if err != nil {
+		return 0, err
+	}
+	// blocks removed by Pruner don't have data to prune
+	if removed, err := PrunedHeight(ctx, p.store); err == nil {
+		pruned = max(pruned, removed)
+	}
+	height := p.store.Height()
+	if height <= p.retainBlocks {
+		return pruned, nil
+	}
+	target := min(height-p.retainBlocks, pruned+maxPrunedBlocksPerRun)
+	if target <= pruned {
+		return pruned, nil
+	}
+
+	start := time.Now()
+	for h := pruned + 1; h <= target; h++ {
+		if err := ctx.Err(); err != nil {
+			return pruned, err
+		}
+		err := p.store.PruneBlockData(ctx, h)
+		// blocks before initial height don't exist
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return pruned, fmt.Errorf("failed to prune data of block %d: %w", h, err)
+		}
+		pruned = h
+		// progress is saved periodically, pruning already pruned blocks again is harmless
+		if h%100 == 0 || h == target {
+			if err := p.store.SetMetadata(ctx, BlockDataPrunedHeightKey, encodeHeight(pruned)); err != nil {
+				return pruned, err
+			}
+		}
+	}
+	p.logger.Info("pruned block data", "height", pruned, "duration", time.Since(start))
+	return pruned, nil
+}
+
+// DataPrunedHeight returns the height up to which (inclusive) block data was pruned, 0 if it was never pruned.
+func DataPrunedHeight(ctx context.Context, s Store) (uint64, error) {
+	value, err := s.GetMetadata(ctx, BlockDataPrunedHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return decodeHeight(value)
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestDataPruner(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	const height = 5
+	blocks := make([]*types.Data, height+1)
+	for h := uint64(1); h <= height; h++ {
+		header, data := types.GetRandomBlock(h, 3, "TestDataPruner")
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		s.SetHeight(ctx, h)
+		blocks[h] = data
+	}
+
+	pruner := NewDataPruner(s, 2, 0, test.NewLogger(t))
+	pruned, err := pruner.Prune(ctx)
+	require.NoError(err)
+	assert.EqualValues(3, pruned)
+	stored, err := DataPrunedHeight(ctx, s)
+	require.NoError(err)
+	assert.EqualValues(3, stored)
+
+	for h := uint64(1); h <= height; h++ {
+		_, _, err := s.GetBlockData(ctx, h)
+		if h <= pruned {
+			assert.ErrorIs(err, ErrBlockDataPruned)
+		} else {
+			assert.NoError(err)
+		}
+		header, err := s.GetHeader(ctx, h)
+		require.NoError(err)
+		assert.Equal(h, header.Height())
+
+		// inclusion proofs created after pruning are the same as created from full data
+		hashes, err := s.GetTxHashes(ctx, h)
+		require.NoError(err)
+		require.Len(hashes, len(blocks[h].Txs))
+		for i, tx := range blocks[h].Txs {
+			proof := types.ProofFromTxHashes(hashes, i, tx)
+			assert.Equal(blocks[h].Txs.Proof(i), proof)
+			assert.NoError(proof.Proof.Verify(proof.RootHash, tx.Hash()))
+		}
+	}
+
+	// pruning again is a no-op
+	require.NoError(s.PruneBlockData(ctx, 1))
+	pruned, err = pruner.Prune(ctx)
+	require.NoError(err)
+	assert.EqualValues(3, pruned)
+
+	// blocks which were never saved are reported as not found
+	_, err = s.GetTxHashes(ctx, height+1)
+	assert.ErrorIs(err, ds.ErrNotFound)
+}
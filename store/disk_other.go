@@ -0,0 +1,10 @@
+//go:build !unix
+
+package store
+
+import "errors"
+
+// FreeDiskSpace is not supported on this platform.
+func FreeDiskSpace(string) (uint64, error) {
+	return 0, errors.New("checking free disk space is not supported on this platform")
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package store
+
+import "syscall"
+
+// FreeDiskSpace returns the number of bytes available to unprivileged users on the volume containing path.
+func FreeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil //nolint:gosec,unconvert
+}
@@ -0,0 +1,352 @@
+package store
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// ErrDecryption is returned when a value of encrypted datastore can't be decrypted, e.g. because the key is wrong
+// or the value was saved without encryption.
+var ErrDecryption = errors.New("failed to decrypt value")
+
+// ErrEncryptionMode is returned when a datastore written without encryption is opened with encryption key, or the
+// other way round, or with a different key.
+var ErrEncryptionMode = errors.New("encryption of the datastore doesn't match configuration")
+
+// EncryptionModeKey is the metadata key of the encryption mode of the datastore, see CheckEncryptionMode.
+const EncryptionModeKey = "encryption mode"
+
+// Encryption modes saved with EncryptionModeKey.
+const (
+	encryptionModeNone   = "none"
+	encryptionModeAESGCM = "aes-gcm"
+)
+
+// LoadEncryptionKey returns AES key decoded from given hex encoded key or, if it's empty, read from given file.
+// Nil is returned if both are empty, i.e. encryption is disabled.
+func LoadEncryptionKey(key, keyFile string) ([]byte, error) {
+	if key == "" && keyFile != "" {
+		blob, err := os.ReadFile(keyFile) //nolint:gosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key file: %w", err)
+		}
+		key = string(blob)
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, nil
+	}
+	decoded, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption key: %w", err)
+	}
+	if _, err := aes.NewCipher(decoded); err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return decoded, nil
+}
+
+// CheckEncryptionMode returns ErrEncryptionMode if the datastore was written with a different encryption mode.
+// kv is the datastore as it's used, i.e. wrapped with NewEncryptedDatastore if encrypted is true. The mode is
+// saved as metadata, encrypted like other values, so that a wrong key is detected as well.
+//
+// If the mode is not saved yet, it's saved if writable is true. Datastores written before the mode was saved are
+// checked by decrypting their first value, if encrypted is true.
+func CheckEncryptionMode(ctx context.Context, kv ds.Datastore, encrypted, writable bool) error {
+	mode := encryptionModeNone
+	if encrypted {
+		mode = encryptionModeAESGCM
+	}
+	key := ds.NewKey(getMetaKey(EncryptionModeKey))
+	saved, err := kv.Get(ctx, key)
+	switch {
+	case errors.Is(err, ErrDecryption):
+		return fmt.Errorf("%w: datastore was written without encryption or with another key", ErrEncryptionMode)
+	case errors.Is(err, ds.ErrNotFound):
+		if encrypted {
+			if err := checkDecryption(ctx, kv); err != nil {
+				return err
+			}
+		}
+		if !writable {
+			return nil
+		}
+		if err := kv.Put(ctx, key, []byte(mode)); err != nil {
+			return fmt.Errorf("failed to save encryption mode: %w", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to load encryption mode: %w", err)
+	case string(saved) != mode && !encrypted:
+		// without encryption key, encrypted value is read as is
+		return fmt.Errorf("%w: datastore is encrypted, but encryption key is not configured", ErrEncryptionMode)
+	case string(saved) != mode:
+		return fmt.Errorf("%w: unknown encryption mode %q", ErrEncryptionMode, saved)
+	}
+	return nil
+}
+
+// checkDecryption returns ErrEncryptionMode if the first value of encrypted datastore can't be decrypted.
+func checkDecryption(ctx context.Context, kv ds.Datastore) error {
+	results, err := kv.Query(ctx, dsq.Query{Limit: 1})
+	if err != nil {
+		return err
+	}
+	defer results.Close() //nolint:errcheck
+	res, ok := results.NextSync()
+	if ok && errors.Is(res.Error, ErrDecryption) {
+		return fmt.Errorf("%w: datastore was written without encryption or with another key", ErrEncryptionMode)
+	}
+	if ok {
+		return res.Error
+	}
+	return nil
+}
+
+// NewEncryptedDatastore wraps given datastore, so that values are encrypted at rest with AES-GCM using given
+// key (16, 24 or 32 bytes). Keys are not encrypted, as they are required for ordered queries, so heights and
+// hashes of blocks remain visible. Each value is sealed with a random nonce and authenticated along with its
+// key, so values can't be moved between keys unnoticed.
+//
+// Values saved before encryption was enabled can't be read, so encryption must be enabled for a new datastore.
+func NewEncryptedDatastore(d ds.Datastore, key []byte) (ds.TxnDatastore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedDatastore{child: d, txn: NewTxnDatastore(d), aead: aead}, nil
+}
+
+// encryptedDatastore encrypts values of the child datastore. It supports batches and transactions, like badger,
+// which are required by the store and header sync services.
+type encryptedDatastore struct {
+	child ds.Datastore
+	txn   ds.TxnDatastore
+	aead  cipher.AEAD
+}
+
+var (
+	_ ds.Batching     = &encryptedDatastore{}
+	_ ds.TxnDatastore = &encryptedDatastore{}
+)
+
+// seal encrypts value of given key. Nonce is prepended to the ciphertext.
+func (d *encryptedDatastore) seal(key ds.Key, value []byte) ([]byte, error) {
+	nonce := make([]byte, d.aead.NonceSize(), d.aead.NonceSize()+len(value)+d.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return d.aead.Seal(nonce, nonce, value, key.Bytes()), nil
+}
+
+// open decrypts value of given key.
+func (d *encryptedDatastore) open(key ds.Key, sealed []byte) ([]byte, error) {
+	if len(sealed) < d.aead.NonceSize() {
+		return nil, fmt.Errorf("%w of %s: too short", ErrDecryption, key)
+	}
+	nonce, ciphertext := sealed[:d.aead.NonceSize()], sealed[d.aead.NonceSize():]
+	value, err := d.aead.Open(nil, nonce, ciphertext, key.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w of %s: %w", ErrDecryption, key, err)
+	}
+	return value, nil
+}
+
+// plainSize returns size of the value of given sealed size.
+func (d *encryptedDatastore) plainSize(size int) int {
+	if size < 0 {
+		return size
+	}
+	return max(size-d.aead.NonceSize()-d.aead.Overhead(), 0)
+}
+
+// Get returns decrypted value of the key.
+func (d *encryptedDatastore) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	return getDecrypted(ctx, d, d.child, key)
+}
+
+// Has returns true if the key exists.
+func (d *encryptedDatastore) Has(ctx context.Context, key ds.Key) (bool, error) {
+	return d.child.Has(ctx, key)
+}
+
+// GetSize returns size of decrypted value of the key.
+func (d *encryptedDatastore) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	size, err := d.child.GetSize(ctx, key)
+	return d.plainSize(size), err
+}
+
+// Query returns entries with decrypted values. Filters and orders by value are applied after decryption.
+func (d *encryptedDatastore) Query(ctx context.Context, q dsq.Query) (dsq.Results, error) {
+	return queryDecrypted(ctx, d, d.child, q)
+}
+
+// Put encrypts the value and saves it.
+func (d *encryptedDatastore) Put(ctx context.Context, key ds.Key, value []byte) error {
+	sealed, err := d.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return d.child.Put(ctx, key, sealed)
+}
+
+// Delete removes the key.
+func (d *encryptedDatastore) Delete(ctx context.Context, key ds.Key) error {
+	return d.child.Delete(ctx, key)
+}
+
+// Sync syncs the child datastore.
+func (d *encryptedDatastore) Sync(ctx context.Context, prefix ds.Key) error {
+	return d.child.Sync(ctx, prefix)
+}
+
+// Close closes the child datastore.
+func (d *encryptedDatastore) Close() error {
+	return d.child.Close()
+}
+
+// Batch returns batch encrypting written values.
+func (d *encryptedDatastore) Batch(ctx context.Context) (ds.Batch, error) {
+	batching, ok := d.child.(ds.Batching)
+	if !ok {
+		return ds.NewBasicBatch(d), nil
+	}
+	batch, err := batching.Batch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedWrite{batchOrTxn: batch, d: d}, nil
+}
+
+// NewTransaction returns transaction of the child datastore, encrypting written and decrypting read values.
+func (d *encryptedDatastore) NewTransaction(ctx context.Context, readOnly bool) (ds.Txn, error) {
+	txn, err := d.txn.NewTransaction(ctx, readOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedTxn{encryptedWrite: encryptedWrite{batchOrTxn: txn, d: d}, txn: txn}, nil
+}
+
+// batchOrTxn is the part of ds.Batch and ds.Txn writing values.
+type batchOrTxn interface {
+	Put(ctx context.Context, key ds.Key, value []byte) error
+	Delete(ctx context.Context, key ds.Key) error
+	Commit(ctx context.Context) error
+}
+
+// encryptedWrite encrypts values written to a batch or transaction.
+type encryptedWrite struct {
+	batchOrTxn
+	d *encryptedDatastore
+}
+
+// Put encrypts the value and adds it to the batch or transaction.
+func (w *encryptedWrite) Put(ctx context.Context, key ds.Key, value []byte) error {
+	sealed, err := w.d.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return w.batchOrTxn.Put(ctx, key, sealed)
+}
+
+// encryptedTxn is a transaction of encrypted datastore.
+type encryptedTxn struct {
+	encryptedWrite
+	txn ds.Txn
+}
+
+// Get returns decrypted value of the key.
+func (t *encryptedTxn) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	return getDecrypted(ctx, t.d, t.txn, key)
+}
+
+// Has returns true if the key exists.
+func (t *encryptedTxn) Has(ctx context.Context, key ds.Key) (bool, error) {
+	return t.txn.Has(ctx, key)
+}
+
+// GetSize returns size of decrypted value of the key.
+func (t *encryptedTxn) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	size, err := t.txn.GetSize(ctx, key)
+	return t.d.plainSize(size), err
+}
+
+// Query returns entries with decrypted values.
+func (t *encryptedTxn) Query(ctx context.Context, q dsq.Query) (dsq.Results, error) {
+	return queryDecrypted(ctx, t.d, t.txn, q)
+}
+
+// Discard discards the transaction.
+func (t *encryptedTxn) Discard(ctx context.Context) {
+	t.txn.Discard(ctx)
+}
+
+func getDecrypted(ctx context.Context, d *encryptedDatastore, r ds.Read, key ds.Key) ([]byte, error) {
+	sealed, err := r.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return d.open(key, sealed)
+}
+
+// queryDecrypted queries keys with given prefix and applies the rest of the query to decrypted entries. Keys are
+// not encrypted, so queries without filters and with orders by key only are applied by the child datastore, which
+// can skip entries without reading them.
+func queryDecrypted(ctx context.Context, d *encryptedDatastore, r ds.Read, q dsq.Query) (dsq.Results, error) {
+	child := dsq.Query{
+		Prefix:            q.Prefix,
+		KeysOnly:          q.KeysOnly,
+		ReturnExpirations: q.ReturnExpirations,
+		ReturnsSizes:      q.ReturnsSizes,
+	}
+	if len(q.Filters) == 0 && ordersByKey(q.Orders) {
+		child.Orders, child.Offset, child.Limit = q.Orders, q.Offset, q.Limit
+		q.Orders, q.Offset, q.Limit = nil, 0, 0
+	}
+	results, err := r.Query(ctx, child)
+	if err != nil {
+		return nil, err
+	}
+	decrypted := dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			res, ok := results.NextSync()
+			if !ok || res.Error != nil {
+				return res, ok
+			}
+			res.Size = d.plainSize(res.Size)
+			if !q.KeysOnly {
+				res.Value, res.Error = d.open(ds.RawKey(res.Key), res.Value)
+			}
+			return res, true
+		},
+		Close: results.Close,
+	})
+	q.Prefix = "" // already applied by the child datastore
+	return dsq.NaiveQueryApply(q, decrypted), nil
+}
+
+// ordersByKey returns true if the query is ordered by key only, or not ordered.
+func ordersByKey(orders []dsq.Order) bool {
+	for _, o := range orders {
+		switch o.(type) {
+		case dsq.OrderByKey, *dsq.OrderByKey, dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestEncryptedDatastore(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{1}, 32)
+	children := map[string]func() (ds.Datastore, error){
+		"badger": func() (ds.Datastore, error) { return NewDefaultInMemoryKVStore() },
+		"map":    func() (ds.Datastore, error) { return dssync.MutexWrap(ds.NewMapDatastore()), nil },
+	}
+	for name, newChild := range children {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			child, err := newChild()
+			require.NoError(err)
+			kv, err := NewEncryptedDatastore(child, key)
+			require.NoError(err)
+
+			s := New(kv)
+			header, data := types.GetRandomBlock(1, 2, "TestEncryptedDatastore")
+			require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+			s.SetHeight(ctx, 1)
+			loaded, err := s.LoadBlockRange(ctx, 1, 1)
+			require.NoError(err)
+			require.Equal(header.Hash(), loaded[0].Header.Hash())
+			require.Equal(data.Txs, loaded[0].Data.Txs)
+
+			// values are not stored in plain text
+			sealed, err := child.Get(ctx, ds.NewKey(getDataKey(1)))
+			require.NoError(err)
+			require.False(bytes.Contains(sealed, data.Txs[0]))
+			size, err := kv.GetSize(ctx, ds.NewKey(getDataKey(1)))
+			require.NoError(err)
+			plain, err := kv.Get(ctx, ds.NewKey(getDataKey(1)))
+			require.NoError(err)
+			require.Equal(len(plain), size)
+
+			// queries and batches decrypt and encrypt values
+			batching, ok := kv.(ds.Batching)
+			require.True(ok)
+			batch, err := batching.Batch(ctx)
+			require.NoError(err)
+			require.NoError(batch.Put(ctx, ds.NewKey("/q/1"), []byte("one")))
+			require.NoError(batch.Put(ctx, ds.NewKey("/q/2"), []byte("two")))
+			require.NoError(batch.Commit(ctx))
+			results, err := kv.Query(ctx, dsq.Query{Prefix: "/q", Filters: []dsq.Filter{
+				dsq.FilterValueCompare{Op: dsq.Equal, Value: []byte("two")},
+			}})
+			require.NoError(err)
+			entries, err := results.Rest()
+			require.NoError(err)
+			require.Len(entries, 1)
+			require.Equal("/q/2", entries[0].Key)
+
+			// values can't be moved between keys
+			sealed, err = child.Get(ctx, ds.NewKey("/q/1"))
+			require.NoError(err)
+			require.NoError(child.Put(ctx, ds.NewKey("/q/2"), sealed))
+			_, err = kv.Get(ctx, ds.NewKey("/q/2"))
+			require.ErrorIs(err, ErrDecryption)
+
+			// values can't be read with another key
+			other, err := NewEncryptedDatastore(child, bytes.Repeat([]byte{2}, 32))
+			require.NoError(err)
+			_, err = New(other).GetHeader(ctx, 1)
+			require.ErrorIs(err, ErrDecryption)
+			_, err = kv.Get(ctx, ds.NewKey("/missing"))
+			require.ErrorIs(err, ds.ErrNotFound)
+		})
+	}
+
+	_, err := NewEncryptedDatastore(ds.NewMapDatastore(), []byte("short"))
+	require.Error(t, err)
+}
+
+func TestCheckEncryptionMode(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx := context.Background()
+	encrypt := func(child ds.Datastore, key byte) ds.Datastore {
+		kv, err := NewEncryptedDatastore(child, bytes.Repeat([]byte{key}, 32))
+		require.NoError(err)
+		return kv
+	}
+
+	// mode of a new datastore is saved on the first check
+	plain := ds.NewMapDatastore()
+	require.NoError(CheckEncryptionMode(ctx, plain, false, true))
+	require.NoError(CheckEncryptionMode(ctx, plain, false, true))
+	require.ErrorIs(CheckEncryptionMode(ctx, encrypt(plain, 1), true, true), ErrEncryptionMode)
+
+	encrypted := ds.NewMapDatastore()
+	require.NoError(CheckEncryptionMode(ctx, encrypt(encrypted, 1), true, true))
+	require.NoError(CheckEncryptionMode(ctx, encrypt(encrypted, 1), true, true))
+	require.ErrorIs(CheckEncryptionMode(ctx, encrypted, false, true), ErrEncryptionMode)
+	require.ErrorIs(CheckEncryptionMode(ctx, encrypt(encrypted, 2), true, true), ErrEncryptionMode)
+
+	// datastores written before the mode was saved are checked by decrypting a value
+	legacy := ds.NewMapDatastore()
+	require.NoError(legacy.Put(ctx, ds.NewKey("/s"), []byte("state")))
+	require.ErrorIs(CheckEncryptionMode(ctx, encrypt(legacy, 1), true, true), ErrEncryptionMode)
+	legacy = ds.NewMapDatastore()
+	require.NoError(encrypt(legacy, 1).Put(ctx, ds.NewKey("/s"), []byte("state")))
+	require.ErrorIs(CheckEncryptionMode(ctx, encrypt(legacy, 2), true, true), ErrEncryptionMode)
+	// mode isn't saved if datastore is not writable
+	require.NoError(CheckEncryptionMode(ctx, encrypt(legacy, 1), true, false))
+	has, err := legacy.Has(ctx, ds.NewKey(getMetaKey(EncryptionModeKey)))
+	require.NoError(err)
+	require.False(has)
+	require.NoError(CheckEncryptionMode(ctx, encrypt(legacy, 1), true, true))
+	require.ErrorIs(CheckEncryptionMode(ctx, legacy, false, true), ErrEncryptionMode)
+}
+
+func TestLoadEncryptionKey(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	key, err := LoadEncryptionKey("", "")
+	require.NoError(err)
+	require.Nil(key)
+
+	hexKey := "000102030405060708090a0b0c0d0e0f"
+	key, err = LoadEncryptionKey(hexKey, "")
+	require.NoError(err)
+	require.Len(key, 16)
+
+	file := filepath.Join(t.TempDir(), "db_key")
+	require.NoError(os.WriteFile(file, []byte(hexKey+"\n"), 0o600))
+	fromFile, err := LoadEncryptionKey("", file)
+	require.NoError(err)
+	require.Equal(key, fromFile)
+
+	_, err = LoadEncryptionKey("zz", "")
+	require.Error(err)
+	_, err = LoadEncryptionKey("0001", "")
+	require.Error(err)
+	_, err = LoadEncryptionKey("", filepath.Join(t.TempDir(), "missing"))
+	require.Error(err)
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/go-header"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrHeaderStoreReadOnly is returned when headers are written to HeaderStore. Headers are saved with blocks.
+var ErrHeaderStoreReadOnly = errors.New("header store is read-only, headers are saved with blocks")
+
+// HeaderStore exposes headers of blocks in Store via go-header Store and Exchange interfaces, so that go-header
+// tooling, e.g. ExchangeServer or Syncer of light clients, can serve and read headers of all stored blocks,
+// including blocks synced from DA. Headers in Store are already verified, so they are returned as they are.
+type HeaderStore struct {
+	store Store
+}
+
+var (
+	_ header.Store[*types.SignedHeader]    = &HeaderStore{}
+	_ header.Exchange[*types.SignedHeader] = &HeaderStore{}
+)
+
+// NewHeaderStore returns HeaderStore backed by given store.
+func NewHeaderStore(store Store) *HeaderStore {
+	return &HeaderStore{store: store}
+}
+
+// Head returns header of the highest block in the store, or header.ErrNoHead if there are no blocks yet.
+func (hs *HeaderStore) Head(ctx context.Context, _ ...header.HeadOption[*types.SignedHeader]) (*types.SignedHeader, error) {
+	height := hs.store.Height()
+	if height == 0 {
+		return nil, header.ErrNoHead
+	}
+	head, err := hs.store.GetHeader(ctx, height)
+	// chain starting at initial height greater than 1 has no blocks below it
+	if errors.Is(err, ErrHeightOutOfRange) {
+		return nil, header.ErrNoHead
+	}
+	return head, notFound(err)
+}
+
+// Get returns header with given hash.
+func (hs *HeaderStore) Get(ctx context.Context, hash header.Hash) (*types.SignedHeader, error) {
+	h, err := hs.store.GetHeaderByHash(ctx, types.Hash(hash))
+	return h, notFound(err)
+}
+
+// GetByHeight returns header at given height.
+func (hs *HeaderStore) GetByHeight(ctx context.Context, height uint64) (*types.SignedHeader, error) {
+	h, err := hs.store.GetHeader(ctx, height)
+	return h, notFound(err)
+}
+
+// GetRangeByHeight returns headers in range [from.Height()+1:to).
+func (hs *HeaderStore) GetRangeByHeight(ctx context.Context, from *types.SignedHeader, to uint64) ([]*types.SignedHeader, error) {
+	return hs.GetRange(ctx, from.Height()+1, to)
+}
+
+// GetRange returns headers in range [from:to).
+func (hs *HeaderStore) GetRange(ctx context.Context, from, to uint64) ([]*types.SignedHeader, error) {
+	if from >= to {
+		return nil, fmt.Errorf("invalid range [%d:%d)", from, to)
+	}
+	if to-from > header.MaxRangeRequestSize {
+		return nil, header.ErrHeadersLimitExceeded
+	}
+	headers := make([]*types.SignedHeader, 0, to-from)
+	for height := from; height < to; height++ {
+		h, err := hs.GetByHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, h)
+	}
+	return headers, nil
+}
+
+// Height returns height of the highest block in the store.
+func (hs *HeaderStore) Height() uint64 {
+	return hs.store.Height()
+}
+
+// Has checks whether header with given hash is stored.
+func (hs *HeaderStore) Has(ctx context.Context, hash header.Hash) (bool, error) {
+	_, err := hs.Get(ctx, hash)
+	if errors.Is(err, header.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// HasAt checks whether header at given height is stored.
+func (hs *HeaderStore) HasAt(ctx context.Context, height uint64) bool {
+	if height == 0 || height > hs.store.Height() {
+		return false
+	}
+	_, err := hs.GetByHeight(ctx, height)
+	return err == nil
+}
+
+// Init returns ErrHeaderStoreReadOnly.
+func (hs *HeaderStore) Init(context.Context, *types.SignedHeader) error {
+	return ErrHeaderStoreReadOnly
+}
+
+// Append returns ErrHeaderStoreReadOnly.
+func (hs *HeaderStore) Append(context.Context, ...*types.SignedHeader) error {
+	return ErrHeaderStoreReadOnly
+}
+
+// notFound replaces errors of missing headers with header.ErrNotFound, which go-header compares by equality.
+func notFound(err error) error {
+	if errors.Is(err, ds.ErrNotFound) {
+		return header.ErrNotFound
+	}
+	return err
+}
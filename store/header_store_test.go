@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/celestiaorg/go-header"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestHeaderStore(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+	hs := NewHeaderStore(s)
+
+	_, err = hs.Head(ctx)
+	assert.ErrorIs(err, header.ErrNoHead)
+
+	const height = 4
+	headers := make([]*types.SignedHeader, height+1)
+	for h := uint64(1); h <= height; h++ {
+		signed, data := types.GetRandomBlock(h, 1, "TestHeaderStore")
+		require.NoError(s.SaveBlockData(ctx, signed, data, &types.Signature{}))
+		s.SetHeight(ctx, h)
+		headers[h] = signed
+	}
+	// headers are served also after block data is pruned
+	require.NoError(s.PruneBlockData(ctx, 1))
+
+	head, err := hs.Head(ctx)
+	require.NoError(err)
+	assert.Equal(headers[height].Hash(), head.Hash())
+	assert.EqualValues(height, hs.Height())
+
+	byHash, err := hs.Get(ctx, header.Hash(headers[1].Hash()))
+	require.NoError(err)
+	assert.EqualValues(1, byHash.Height())
+	has, err := hs.Has(ctx, header.Hash(headers[2].Hash()))
+	require.NoError(err)
+	assert.True(has)
+
+	rng, err := hs.GetRangeByHeight(ctx, headers[1], height+1)
+	require.NoError(err)
+	require.Len(rng, height-1)
+	for i, h := range rng {
+		assert.Equal(headers[i+2].Hash(), h.Hash())
+	}
+
+	assert.True(hs.HasAt(ctx, height))
+	assert.False(hs.HasAt(ctx, height+1))
+	assert.False(hs.HasAt(ctx, 0))
+
+	// go-header compares errors by equality
+	_, err = hs.GetByHeight(ctx, height+1)
+	assert.Equal(header.ErrNotFound, err)
+	_, err = hs.Get(ctx, header.Hash(types.GetRandomBytes(32)))
+	assert.Equal(header.ErrNotFound, err)
+	has, err = hs.Has(ctx, header.Hash(types.GetRandomBytes(32)))
+	require.NoError(err)
+	assert.False(has)
+	_, err = hs.GetRange(ctx, 3, height+2)
+	assert.Equal(header.ErrNotFound, err)
+
+	assert.ErrorIs(hs.Append(ctx, headers[1]), ErrHeaderStoreReadOnly)
+	assert.ErrorIs(hs.Init(ctx, headers[1]), ErrHeaderStoreReadOnly)
+}
+
+func TestHeaderStoreInitialHeight(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+	hs := NewHeaderStore(s)
+
+	// chain starting at height 100 has no head until the first block is saved
+	validatorSet := types.GetRandomValidatorSet()
+	require.NoError(s.UpdateState(ctx, types.State{
+		InitialHeight:   100,
+		LastBlockHeight: 99,
+		Validators:      validatorSet,
+		NextValidators:  validatorSet,
+		LastValidators:  validatorSet,
+	}))
+	s.SetHeight(ctx, 99)
+	_, err = hs.Head(ctx)
+	require.ErrorIs(err, header.ErrNoHead)
+
+	signed, data := types.GetRandomBlock(100, 1, "TestHeaderStoreInitialHeight")
+	require.NoError(s.SaveBlockData(ctx, signed, data, &types.Signature{}))
+	s.SetHeight(ctx, 100)
+	head, err := hs.Head(ctx)
+	require.NoError(err)
+	require.Equal(signed.Hash(), head.Hash())
+}
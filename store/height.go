@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+// ErrHeightOutOfRange is returned when requested height is outside of the range of heights available in the store.
+var ErrHeightOutOfRange = errors.New("height out of range")
+
+// HeightRangeError is returned when requested height is lower than the earliest or higher than the latest
+// available height. It matches ErrHeightOutOfRange and the underlying error (if any) with errors.Is.
+type HeightRangeError struct {
+	// Height is the requested height. It's signed, because RPC requests can contain negative heights.
+	Height   int64
+	Earliest uint64
+	Latest   uint64
+	// Err is the underlying error, e.g. ds.ErrNotFound.
+	Err error
+}
+
+// Error implements error.
+func (e *HeightRangeError) Error() string {
+	if e.Latest < e.Earliest {
+		return fmt.Sprintf("%s: height %d is not available, there are no blocks yet", ErrHeightOutOfRange, e.Height)
+	}
+	return fmt.Sprintf("%s: height %d is not available, available heights are [%d, %d]",
+		ErrHeightOutOfRange, e.Height, e.Earliest, e.Latest)
+}
+
+// Is returns true for ErrHeightOutOfRange.
+func (e *HeightRangeError) Is(target error) bool {
+	return target == ErrHeightOutOfRange
+}
+
+// Unwrap returns the underlying error.
+func (e *HeightRangeError) Unwrap() error {
+	return e.Err
+}
+
+// earliestHeight returns the earliest height of blocks in the store, i.e. the initial height of the chain, or
+// the lowest height kept by Pruner. Headers of all blocks from the earliest to the latest height are available;
+// data of some blocks might be pruned. It returns 1 if the state is not saved yet.
+func (s *DefaultStore) earliestHeight(ctx context.Context) uint64 {
+	earliest := uint64(1)
+	if state, err := s.GetState(ctx); err == nil && state.InitialHeight > 0 {
+		earliest = state.InitialHeight
+	}
+	if pruned, err := PrunedHeight(ctx, s); err == nil {
+		earliest = max(earliest, pruned+1)
+	}
+	return earliest
+}
+
+// rangeError returns HeightRangeError wrapping err, if err is ds.ErrNotFound and height is outside of the range of
+// heights available in the store. Otherwise, err is returned, as the height is valid but the record is missing.
+func (s *DefaultStore) rangeError(ctx context.Context, height uint64, err error) error {
+	if !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	earliest, latest := s.earliestHeight(ctx), s.Height()
+	if height >= earliest && height <= latest {
+		return err
+	}
+	return &HeightRangeError{Height: int64(height), Earliest: earliest, Latest: latest, Err: err} //nolint:gosec
+}
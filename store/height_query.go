@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// heightRecords iterates over records with given prefix in order of heights. Heights are encoded in keys as
+// fixed-width big-endian (see encodeHeightKey), so a prefix query ordered by key returns records in order of
+// heights. Records below the start height are skipped with the offset of the query, without reading them.
+type heightRecords struct {
+	results dsq.Results
+	start   uint64
+
+	// height and value of the current record, valid if ok is true
+	height uint64
+	value  []byte
+	ok     bool
+	done   bool
+}
+
+// queryHeightRecords returns records with given prefix at or above start height, read with r. Values are not read
+// if keysOnly is true. Records must be closed with close.
+func queryHeightRecords(ctx context.Context, r ds.Read, prefix string, start uint64, keysOnly bool) (*heightRecords, error) {
+	offset, err := heightOffset(ctx, r, prefix, start)
+	if err != nil {
+		return nil, err
+	}
+	results, err := queryHeights(ctx, r, prefix, offset, 0, keysOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &heightRecords{results: results, start: start}, nil
+}
+
+// seek advances to the record at given height, or to the first record above it, and returns the value of the
+// record at given height. Found is false if there is no record at given height. Heights must be sought in
+// ascending order.
+func (h *heightRecords) seek(height uint64) (value []byte, found bool, err error) {
+	for !h.done && (!h.ok || h.height < height) {
+		if err := h.next(); err != nil {
+			return nil, false, err
+		}
+	}
+	if h.ok && h.height == height {
+		return h.value, true, nil
+	}
+	return nil, false, nil
+}
+
+// next reads the next record at or above the start height.
+func (h *heightRecords) next() error {
+	for {
+		res, ok := h.results.NextSync()
+		if !ok {
+			h.ok, h.done = false, true
+			return nil
+		}
+		if res.Error != nil {
+			return res.Error
+		}
+		height, err := decodeHeightKey(res.Key)
+		if err != nil {
+			return err
+		}
+		if height >= h.start {
+			h.height, h.value, h.ok = height, res.Value, true
+			return nil
+		}
+	}
+}
+
+// close releases resources of the query.
+func (h *heightRecords) close() error {
+	return h.results.Close()
+}
+
+// heightOffset returns offset of the query of records with given prefix, which skips records below start height,
+// but no records at or above it. Heights are unique, so the record at offset i is at least i heights above the
+// first record, and records are probed at offsets estimated from their heights.
+func heightOffset(ctx context.Context, r ds.Read, prefix string, start uint64) (int, error) {
+	first, ok, err := probeHeight(ctx, r, prefix, 0)
+	if err != nil || !ok || first >= start {
+		return 0, err
+	}
+	offset := int(start - first) //nolint:gosec
+	for offset > 0 {
+		height, ok, err := probeHeight(ctx, r, prefix, offset)
+		switch {
+		case err != nil:
+			return 0, err
+		case !ok:
+			// there are gaps between heights of records, e.g. data of blocks was pruned
+			offset /= 2
+		case height <= start:
+			return offset, nil
+		default:
+			// record at the new offset is at most start height
+			offset -= int(height - start) //nolint:gosec
+		}
+	}
+	return 0, nil
+}
+
+// probeHeight returns height of the record with given prefix at given offset, if there is any.
+func probeHeight(ctx context.Context, r ds.Read, prefix string, offset int) (uint64, bool, error) {
+	results, err := queryHeights(ctx, r, prefix, offset, 1, true)
+	if err != nil {
+		return 0, false, err
+	}
+	defer results.Close() //nolint:errcheck
+	res, ok := results.NextSync()
+	if !ok {
+		return 0, false, nil
+	}
+	if res.Error != nil {
+		return 0, false, res.Error
+	}
+	height, err := decodeHeightKey(res.Key)
+	return height, err == nil, err
+}
+
+// queryHeights queries records with given prefix in order of heights.
+func queryHeights(ctx context.Context, r ds.Read, prefix string, offset, limit int, keysOnly bool) (dsq.Results, error) {
+	return r.Query(ctx, dsq.Query{
+		Prefix:   "/" + prefix,
+		Orders:   []dsq.Order{dsq.OrderByKey{}},
+		Offset:   offset,
+		Limit:    limit,
+		KeysOnly: keysOnly,
+	})
+}
+
+// decodeHeightKey returns height encoded in the last field of key of record stored by height, see encodeHeightKey.
+func decodeHeightKey(key string) (uint64, error) {
+	field := key[strings.LastIndexByte(key, '/')+1:]
+	b, err := hex.DecodeString(field)
+	if err != nil {
+		return 0, fmt.Errorf("invalid height in key %s: %w", key, err)
+	}
+	return decodeHeight(b)
+}
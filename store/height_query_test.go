@@ -0,0 +1,73 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+)
+
+func TestQueryHeightRecords(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(t *testing.T) (ds.TxnDatastore, error){
+		"badger": func(*testing.T) (ds.TxnDatastore, error) { return NewDefaultInMemoryKVStore() },
+		"map":    func(*testing.T) (ds.TxnDatastore, error) { return NewMapKVStore(), nil },
+		"leveldb": func(t *testing.T) (ds.TxnDatastore, error) {
+			return NewBackendKVStore(config.DBBackendLevelDB, t.TempDir(), "data", "rollkit", 0)
+		},
+		"encrypted": func(*testing.T) (ds.TxnDatastore, error) {
+			child, err := NewDefaultInMemoryKVStore()
+			if err != nil {
+				return nil, err
+			}
+			return NewEncryptedDatastore(child, bytes.Repeat([]byte{1}, 32))
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+			kv, err := newStore(t)
+			require.NoError(err)
+			defer kv.Close() //nolint:errcheck
+
+			// gaps between heights, like after pruning of block data
+			var heights []uint64
+			for _, r := range [][2]uint64{{3, 10}, {20, 30}, {100, 100}} {
+				for h := r[0]; h <= r[1]; h++ {
+					heights = append(heights, h)
+					require.NoError(kv.Put(ctx, ds.NewKey(getDataKey(h)), encodeHeight(h)))
+				}
+			}
+			require.NoError(kv.Put(ctx, ds.NewKey(getDACertificateKey(1)), nil))
+
+			for _, start := range []uint64{0, 3, 5, 11, 15, 20, 25, 31, 50, 100, 101} {
+				records, err := queryHeightRecords(ctx, kv, dataPrefix, start, false)
+				require.NoError(err)
+				var read []uint64
+				for {
+					require.NoError(records.next())
+					if !records.ok {
+						break
+					}
+					require.Equal(encodeHeight(records.height), records.value)
+					read = append(read, records.height)
+				}
+				require.NoError(records.close())
+
+				var expected []uint64
+				for _, h := range heights {
+					if h >= start {
+						expected = append(expected, h)
+					}
+				}
+				require.Equal(expected, read, "start %d", start)
+			}
+		})
+	}
+}
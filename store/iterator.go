@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// Block is a block header with data, as returned by LoadBlockRange.
+type Block struct {
+	Header *types.SignedHeader
+	Data   *types.Data
+}
+
+// BlockIterator iterates over blocks in a range of heights in ascending order. All blocks are read from a
+// single read-only transaction of the datastore, so the iterator sees a consistent snapshot of the store (e.g.
+// blocks pruned concurrently are still returned). Headers and data are read with prefix queries, which return
+// records in order of heights, instead of a point lookup per height.
+//
+// Iterator must be closed with Close to release the transaction.
+type BlockIterator struct {
+	ctx   context.Context
+	store *DefaultStore
+	txn   ds.Txn
+
+	headerRecords *heightRecords
+	dataRecords   *heightRecords
+	txHashRecords *heightRecords
+
+	next uint64
+	end  uint64
+	done bool
+
+	header *types.SignedHeader
+	data   *types.Data
+	err    error
+}
+
+// BlockIterator returns iterator over blocks from start to end (inclusive).
+func (s *DefaultStore) BlockIterator(ctx context.Context, start, end uint64) (*BlockIterator, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid range [%d:%d]", start, end)
+	}
+	txn, err := s.db.NewTransaction(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a read-only transaction: %w", err)
+	}
+	it := &BlockIterator{ctx: ctx, store: s, txn: txn, next: start, end: end}
+	for _, q := range []struct {
+		records  **heightRecords
+		prefix   string
+		keysOnly bool
+	}{
+		{&it.headerRecords, headerPrefix, false},
+		{&it.dataRecords, dataPrefix, false},
+		// hashes of transactions are kept when data is pruned, so their keys tell pruned data from missing one
+		{&it.txHashRecords, txHashesPrefix, true},
+	} {
+		if *q.records, err = queryHeightRecords(ctx, txn, q.prefix, start, q.keysOnly); err != nil {
+			it.Close()
+			return nil, fmt.Errorf("failed to query blocks: %w", err)
+		}
+	}
+	return it, nil
+}
+
+// Next reads the next block. It returns false when all blocks were read or on error, see Err.
+func (it *BlockIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	header, data, err := it.readBlock(it.next)
+	if err != nil {
+		it.err = fmt.Errorf("failed to read block %d: %w", it.next, err)
+		return false
+	}
+	it.header, it.data = header, data
+	if it.next == it.end {
+		it.done = true
+	} else {
+		it.next++
+	}
+	return true
+}
+
+// readBlock reads block at given height from the prefix queries.
+func (it *BlockIterator) readBlock(height uint64) (*types.SignedHeader, *types.Data, error) {
+	headerBlob, found, err := it.headerRecords.seek(height)
+	if err == nil && !found {
+		err = it.store.rangeError(it.ctx, height, ds.ErrNotFound)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load block header: %w", err)
+	}
+	header := new(types.SignedHeader)
+	if err := header.UnmarshalBinary(headerBlob); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal block header: %w", err)
+	}
+
+	dataBlob, found, err := it.dataRecords.seek(height)
+	if err == nil && !found {
+		if _, pruned, err := it.txHashRecords.seek(height); err == nil && pruned {
+			return nil, nil, fmt.Errorf("%w: height %d", ErrBlockDataPruned, height)
+		}
+		err = it.store.rangeError(it.ctx, height, ds.ErrNotFound)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load block data: %w", err)
+	}
+	data := new(types.Data)
+	if err := data.UnmarshalBinary(dataBlob); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal block data: %w", err)
+	}
+	return header, data, nil
+}
+
+// Header returns header of the block read by the last call to Next.
+func (it *BlockIterator) Header() *types.SignedHeader {
+	return it.header
+}
+
+// Data returns data of the block read by the last call to Next.
+func (it *BlockIterator) Data() *types.Data {
+	return it.data
+}
+
+// Err returns error which stopped the iteration, e.g. ErrBlockDataPruned if data of a block in the range was
+// pruned, or nil if all blocks were read.
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// Close closes the queries and discards the transaction of the iterator.
+func (it *BlockIterator) Close() {
+	for _, records := range []*heightRecords{it.headerRecords, it.dataRecords, it.txHashRecords} {
+		if records != nil {
+			records.close() //nolint:errcheck
+		}
+	}
+	it.txn.Discard(it.ctx)
+}
+
+// LoadBlockRange returns blocks from start to end (inclusive), read with BlockIterator.
+func (s *DefaultStore) LoadBlockRange(ctx context.Context, start, end uint64) ([]Block, error) {
+	it, err := s.BlockIterator(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	blocks := make([]Block, 0, end-start+1)
+	for it.Next() {
+		blocks = append(blocks, Block{Header: it.Header(), Data: it.Data()})
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestBlockIterator(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kv, _ := NewDefaultInMemoryKVStore()
+	s := New(kv)
+
+	// heights crossing a power of 10, which don't sort lexicographically
+	for h := uint64(1); h <= 12; h++ {
+		header, data := types.GetRandomBlock(h, 1, "TestBlockIterator")
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		s.SetHeight(ctx, h)
+	}
+
+	blocks, err := s.LoadBlockRange(ctx, 8, 11)
+	require.NoError(err)
+	require.Len(blocks, 4)
+	for i, block := range blocks {
+		require.Equal(uint64(8+i), block.Header.Height())
+		require.Equal(block.Header.Height(), block.Data.Metadata.Height)
+	}
+
+	blocks, err = s.LoadBlockRange(ctx, 12, 12)
+	require.NoError(err)
+	require.Len(blocks, 1)
+
+	_, err = s.LoadBlockRange(ctx, 5, 4)
+	require.Error(err)
+
+	_, err = s.LoadBlockRange(ctx, 10, 13)
+	require.ErrorIs(err, ErrHeightOutOfRange)
+
+	require.NoError(s.PruneBlockData(ctx, 3))
+	_, err = s.LoadBlockRange(ctx, 1, 5)
+	require.ErrorIs(err, ErrBlockDataPruned)
+
+	// iterator reads from a snapshot, so blocks pruned during iteration are still returned
+	it, err := s.BlockIterator(ctx, 4, 6)
+	require.NoError(err)
+	defer it.Close()
+	require.True(it.Next())
+	require.Equal(uint64(4), it.Header().Height())
+	require.NoError(s.Prune(ctx, 1, 6))
+	require.True(it.Next())
+	require.Equal(uint64(5), it.Header().Height())
+	require.True(it.Next())
+	require.Equal(uint64(6), it.Data().Metadata.Height)
+	require.False(it.Next())
+	require.NoError(it.Err())
+}
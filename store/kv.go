@@ -26,8 +26,30 @@ func NewDefaultInMemoryKVStore() (ds.TxnDatastore, error) {
 
 // NewDefaultKVStore creates instance of default key-value store.
 func NewDefaultKVStore(rootDir, dbPath, dbName string) (ds.TxnDatastore, error) {
-	path := filepath.Join(rootify(rootDir, dbPath), dbName)
-	return badger4.NewDatastore(path, nil)
+	return badger4.NewDatastore(Path(rootDir, dbPath, dbName), nil)
+}
+
+// NewKVStore creates instance of key-value store with periodic garbage collection disabled.
+// Garbage collection removes value log files with at least gcDiscardRatio of stale data, and
+// is expected to be scheduled by Maintainer.
+func NewKVStore(rootDir, dbPath, dbName string, gcDiscardRatio float64) (ds.TxnDatastore, error) {
+	options := badger4.DefaultOptions
+	options.GcInterval = 0
+	options.GcDiscardRatio = gcDiscardRatio
+	return badger4.NewDatastore(Path(rootDir, dbPath, dbName), &options)
+}
+
+// NewReadOnlyKVStore opens existing key-value store in read-only mode. All write operations fail.
+func NewReadOnlyKVStore(rootDir, dbPath, dbName string) (ds.TxnDatastore, error) {
+	options := badger4.DefaultOptions
+	options.GcInterval = 0
+	options.Options = options.Options.WithReadOnly(true)
+	return badger4.NewDatastore(Path(rootDir, dbPath, dbName), &options)
+}
+
+// Path returns the directory of key-value store with given name.
+func Path(rootDir, dbPath, dbName string) string {
+	return filepath.Join(rootify(rootDir, dbPath), dbName)
 }
 
 // PrefixEntries retrieves all entries in the datastore whose keys have the supplied prefix
@@ -0,0 +1,201 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/alert"
+	"github.com/rollkit/rollkit/third_party/log"
+)
+
+// diskCheckInterval is the interval between checks of free disk space.
+const diskCheckInterval = 10 * time.Second
+
+// ErrSafeMode is returned by SafeModeErr when node is in safe mode.
+var ErrSafeMode = errors.New("node is in safe mode, not enough free disk space")
+
+// garbageCollector is implemented by datastores supporting garbage collection, like badger datastore.
+type garbageCollector interface {
+	CollectGarbage(ctx context.Context) error
+}
+
+// Maintainer schedules garbage collection of key-value store and watches free disk space.
+//
+// When free disk space drops below configured limit, node is switched to safe mode; it's expected to stop
+// writing blocks until disk space is available again, instead of risking database corruption.
+type Maintainer struct {
+	kv          ds.Datastore
+	dir         string
+	gcInterval  time.Duration
+	minFreeDisk uint64
+
+	safeMode atomic.Bool
+	// alertHandler is notified when node enters safe mode, it's nil if alerts are disabled
+	alertHandler alert.Handler
+
+	metrics *Metrics
+	logger  log.Logger
+}
+
+// NewMaintainer creates Maintainer of key-value store located in dir.
+// Garbage collection or disk space checks are disabled if gcInterval or minFreeDisk (in bytes) are 0 respectively.
+func NewMaintainer(kv ds.Datastore, dir string, gcInterval time.Duration, minFreeDisk uint64, metrics *Metrics, logger log.Logger) *Maintainer {
+	return &Maintainer{
+		kv:          kv,
+		dir:         dir,
+		gcInterval:  gcInterval,
+		minFreeDisk: minFreeDisk,
+		metrics:     metrics,
+		logger:      logger,
+	}
+}
+
+// SetAlertHandler sets function notified when node enters safe mode. It must be called before Run.
+func (m *Maintainer) SetAlertHandler(handler alert.Handler) {
+	m.alertHandler = handler
+}
+
+// SafeModeErr returns ErrSafeMode if node is in safe mode, nil otherwise.
+func (m *Maintainer) SafeModeErr() error {
+	if m.safeMode.Load() {
+		return ErrSafeMode
+	}
+	return nil
+}
+
+// DiskUsage describes disk usage of the store.
+type DiskUsage struct {
+	// Used is the total size of files of the store, Free is free space of the disk it's located on.
+	Used     uint64 `json:"used_bytes"`
+	Free     uint64 `json:"free_bytes"`
+	SafeMode bool   `json:"safe_mode"`
+}
+
+// DiskUsage returns current disk usage of the store.
+func (m *Maintainer) DiskUsage() (*DiskUsage, error) {
+	used, err := dirSize(m.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute size of the store: %w", err)
+	}
+	free, err := FreeDiskSpace(m.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check free disk space: %w", err)
+	}
+	return &DiskUsage{Used: used, Free: free, SafeMode: m.safeMode.Load()}, nil
+}
+
+// Run performs garbage collection and disk space checks until context is cancelled.
+func (m *Maintainer) Run(ctx context.Context) {
+	var gcCh, diskCh <-chan time.Time
+	if _, ok := m.kv.(garbageCollector); ok && m.gcInterval > 0 {
+		gcTicker := time.NewTicker(m.gcInterval)
+		defer gcTicker.Stop()
+		gcCh = gcTicker.C
+	}
+	if m.minFreeDisk > 0 {
+		m.checkDiskSpace()
+		diskTicker := time.NewTicker(diskCheckInterval)
+		defer diskTicker.Stop()
+		diskCh = diskTicker.C
+	}
+	if gcCh == nil && diskCh == nil {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-gcCh:
+			// value log GC rewrites live data to new files, so it's not safe to run it while disk is almost full
+			if m.safeMode.Load() {
+				m.logger.Info("skipping database garbage collection in safe mode")
+				continue
+			}
+			if _, err := m.collectGarbage(ctx); err != nil {
+				m.logger.Error("database garbage collection failed", "error", err)
+			}
+		case <-diskCh:
+			m.checkDiskSpace()
+		}
+	}
+}
+
+// collectGarbage runs garbage collection and returns the number of reclaimed bytes.
+func (m *Maintainer) collectGarbage(ctx context.Context) (uint64, error) {
+	gc, ok := m.kv.(garbageCollector)
+	if !ok {
+		return 0, nil
+	}
+	before, err := dirSize(m.dir)
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	if err := gc.CollectGarbage(ctx); err != nil {
+		return 0, err
+	}
+	after, err := dirSize(m.dir)
+	if err != nil {
+		return 0, err
+	}
+	var reclaimed uint64
+	if after < before {
+		reclaimed = before - after
+	}
+	m.metrics.GCRuns.Add(1)
+	m.metrics.GCReclaimedBytes.Add(float64(reclaimed))
+	m.logger.Debug("database garbage collection finished", "reclaimed", reclaimed, "duration", time.Since(start))
+	return reclaimed, nil
+}
+
+// checkDiskSpace switches node to or from safe mode, depending on free disk space.
+func (m *Maintainer) checkDiskSpace() {
+	free, err := FreeDiskSpace(m.dir)
+	if err != nil {
+		m.logger.Error("failed to check free disk space", "error", err)
+		return
+	}
+	m.metrics.FreeDiskBytes.Set(float64(free))
+
+	low := free < m.minFreeDisk
+	if m.safeMode.Swap(low) == low {
+		return
+	}
+	if low {
+		m.metrics.SafeMode.Set(1)
+		m.logger.Error("free disk space is low, entering safe mode", "free", free, "limit", m.minFreeDisk)
+		if m.alertHandler != nil {
+			m.alertHandler(alert.DiskPressure, fmt.Sprintf("free disk space is low (%d MiB, limit %d MiB), node entered safe mode", free>>20, m.minFreeDisk>>20))
+		}
+	} else {
+		m.metrics.SafeMode.Set(0)
+		m.logger.Info("free disk space is available again, leaving safe mode", "free", free, "limit", m.minFreeDisk)
+	}
+}
+
+// dirSize returns total size of files in dir.
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			var info fs.FileInfo
+			if info, err = d.Info(); err == nil {
+				size += uint64(info.Size()) //nolint:gosec
+			}
+		}
+		// files might be removed by garbage collection in the meantime
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	})
+	return size, err
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/alert"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestMaintainerGC(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	kv, err := NewKVStore(dir, "data", "test", 0.5)
+	require.NoError(err)
+	defer func() { require.NoError(kv.Close()) }()
+
+	value := make([]byte, 1<<20)
+	_, err = rand.Read(value)
+	require.NoError(err)
+	for i := 0; i < 10; i++ {
+		key := ds.NewKey(fmt.Sprint(i))
+		require.NoError(kv.Put(ctx, key, value))
+		require.NoError(kv.Delete(ctx, key))
+	}
+
+	m := NewMaintainer(kv, Path(dir, "data", "test"), 0, 0, NopMetrics(), test.NewLogger(t))
+	_, err = m.collectGarbage(ctx)
+	require.NoError(err)
+}
+
+func TestMaintainerSafeMode(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	m := NewMaintainer(ds.NewMapDatastore(), dir, 0, math.MaxUint64, NopMetrics(), test.NewLogger(t))
+	var alerts []string
+	m.SetAlertHandler(func(kind, message string) { alerts = append(alerts, kind) })
+	assert.NoError(m.SafeModeErr())
+
+	m.checkDiskSpace()
+	assert.ErrorIs(m.SafeModeErr(), ErrSafeMode)
+	m.checkDiskSpace()
+	assert.Equal([]string{alert.DiskPressure}, alerts)
+
+	m.minFreeDisk = 1
+	m.checkDiskSpace()
+	assert.NoError(m.SafeModeErr())
+
+	size, err := dirSize(dir)
+	assert.NoError(err)
+	assert.Zero(size)
+
+	usage, err := m.DiskUsage()
+	assert.NoError(err)
+	assert.Zero(usage.Used)
+	assert.NotZero(usage.Free)
+	assert.False(usage.SafeMode)
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "store"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of bytes reclaimed by database garbage collection.
+	GCReclaimedBytes metrics.Counter
+	// Number of database garbage collection runs.
+	GCRuns metrics.Counter
+	// Free disk space on the database volume, in bytes.
+	FreeDiskBytes metrics.Gauge
+	// Whether the node is in safe mode because of low disk space (1 if true).
+	SafeMode metrics.Gauge
+	// Number of keys in the store, by kind of data. Updated when store statistics are collected.
+	UsageKeys metrics.Gauge `metrics_labels:"kind"`
+	// Approximate size of keys and values in the store, by kind of data, in bytes.
+	UsageBytes metrics.Gauge `metrics_labels:"kind"`
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		GCReclaimedBytes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "gc_reclaimed_bytes",
+			Help:      "Number of bytes reclaimed by database garbage collection.",
+		}, labels).With(labelsAndValues...),
+		GCRuns: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "gc_runs",
+			Help:      "Number of database garbage collection runs.",
+		}, labels).With(labelsAndValues...),
+		FreeDiskBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "free_disk_bytes",
+			Help:      "Free disk space on the database volume, in bytes.",
+		}, labels).With(labelsAndValues...),
+		SafeMode: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "safe_mode",
+			Help:      "Whether the node is in safe mode because of low disk space (1 if true).",
+		}, labels).With(labelsAndValues...),
+		UsageKeys: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "usage_keys",
+			Help:      "Number of keys in the store, by kind of data. Updated when store statistics are collected.",
+		}, append(labels, "kind")).With(labelsAndValues...),
+		UsageBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "usage_bytes",
+			Help:      "Approximate size of keys and values in the store, by kind of data, in bytes.",
+		}, append(labels, "kind")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		GCReclaimedBytes: discard.NewCounter(),
+		GCRuns:           discard.NewCounter(),
+		FreeDiskBytes:    discard.NewGauge(),
+		SafeMode:         discard.NewGauge(),
+		UsageKeys:        discard.NewGauge(),
+		UsageBytes:       discard.NewGauge(),
+	}
+}
@@ -0,0 +1,383 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/rollkit/rollkit/third_party/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+// SchemaVersion is the version of the layout of keys and values of the store written by this version of Rollkit.
+// It must be increased, and a migration added to migrations, whenever the layout changes.
+const SchemaVersion = 3
+
+// SchemaVersionKey is the metadata key of the schema version of the store.
+const SchemaVersionKey = "schema version"
+
+// migrationBatchSize is the number of entries written by migrations in a single transaction.
+const migrationBatchSize = 1000
+
+// Migration changes layout of the datastore from schema version Version-1 to Version.
+//
+// Migrations must be idempotent: if a node is stopped during a migration, the migration is run again on the next
+// start, as schema version is saved only after the migration is completed.
+type Migration struct {
+	Version     uint64
+	Description string
+	Migrate     func(ctx context.Context, kv ds.TxnDatastore, logger log.Logger) error
+}
+
+// migrations are ordered by versions, the last one migrates to SchemaVersion.
+var migrations = []Migration{
+	{Version: 1, Description: "index times of blocks", Migrate: migrateBlockTimeIndex},
+	{Version: 2, Description: "encode heights in keys as fixed-width big-endian", Migrate: migrateHeightKeys},
+	{Version: 3, Description: "index transactions by hash", Migrate: migrateTxIndex},
+}
+
+// heightKeyPrefixes are prefixes of keys of records stored by height, see encodeHeightKey.
+var heightKeyPrefixes = []string{
+	headerPrefix, dataPrefix, txHashesPrefix, signaturePrefix, extendedCommitPrefix, stateRecordPrefix,
+	daCertificatePrefix, responsesPrefix, blockTimePrefix,
+}
+
+// legacyHeightKey returns key of record stored by height, with decimal height, used before schema version 2.
+func legacyHeightKey(prefix string, height uint64) string {
+	return GenerateKey([]string{prefix, strconv.FormatUint(height, 10)})
+}
+
+// ErrSchemaVersion is returned when schema version of the store is not supported, or the store has to be migrated
+// but it's not writable.
+var ErrSchemaVersion = errors.New("unsupported store schema version")
+
+// LoadSchemaVersion returns schema version of the store. Stores created before the schema version was introduced
+// have version 0, unless they are empty: new stores have the current SchemaVersion.
+func LoadSchemaVersion(ctx context.Context, kv ds.Datastore) (uint64, error) {
+	version, _, err := loadSchemaVersion(ctx, kv)
+	return version, err
+}
+
+// loadSchemaVersion returns schema version of the store, and whether it's saved.
+func loadSchemaVersion(ctx context.Context, kv ds.Datastore) (uint64, bool, error) {
+	value, err := kv.Get(ctx, ds.NewKey(getMetaKey(SchemaVersionKey)))
+	if errors.Is(err, ds.ErrNotFound) {
+		// state is saved when the chain is initialized, so store without state is new
+		hasState, err := kv.Has(ctx, ds.NewKey(getStateKey()))
+		if err != nil || !hasState {
+			return SchemaVersion, false, err
+		}
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to load schema version: %w", err)
+	}
+	if len(value) != 8 {
+		return 0, false, fmt.Errorf("invalid schema version, length %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), true, nil
+}
+
+// Migrate runs, in order, migrations of the store newer than its schema version, and saves the version after each
+// of them. It's called on startup, before the store is used. Stores with version newer than SchemaVersion, written
+// by a newer version of Rollkit, are not supported.
+func Migrate(ctx context.Context, kv ds.TxnDatastore, logger log.Logger) error {
+	version, saved, err := loadSchemaVersion(ctx, kv)
+	if err != nil {
+		return err
+	}
+	if version > SchemaVersion {
+		return fmt.Errorf("%w %d, the latest supported version is %d", ErrSchemaVersion, version, SchemaVersion)
+	}
+	if version == SchemaVersion {
+		if saved {
+			return nil
+		}
+		// version of a new store is saved, so that it's not mistaken for a legacy store later
+		return saveSchemaVersion(ctx, kv, SchemaVersion)
+	}
+	for _, m := range migrations {
+		if m.Version <= version {
+			continue
+		}
+		logger.Info("migrating store", "version", m.Version, "migration", m.Description)
+		if err := m.Migrate(ctx, kv, logger); err != nil {
+			return fmt.Errorf("failed to migrate store to version %d (%s): %w", m.Version, m.Description, err)
+		}
+		if err := saveSchemaVersion(ctx, kv, m.Version); err != nil {
+			return err
+		}
+	}
+	logger.Info("store migrated", "from", version, "to", SchemaVersion)
+	return nil
+}
+
+// CheckSchemaVersion returns ErrSchemaVersion if the store has to be migrated, or was written by a newer version
+// of Rollkit. It's used for stores opened read-only, which can't be migrated.
+func CheckSchemaVersion(ctx context.Context, kv ds.Datastore) error {
+	version, err := LoadSchemaVersion(ctx, kv)
+	if err != nil {
+		return err
+	}
+	if version != SchemaVersion {
+		return fmt.Errorf("%w %d, store must be migrated to version %d by a writing node", ErrSchemaVersion, version, SchemaVersion)
+	}
+	return nil
+}
+
+func saveSchemaVersion(ctx context.Context, kv ds.Datastore, version uint64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, version)
+	if err := kv.Put(ctx, ds.NewKey(getMetaKey(SchemaVersionKey)), value); err != nil {
+		return fmt.Errorf("failed to save schema version: %w", err)
+	}
+	return nil
+}
+
+// migrationBatch writes entries in transactions of migrationBatchSize entries, so that migrations can write while
+// iterating over results of a query, without holding all entries in memory.
+type migrationBatch struct {
+	kv      ds.TxnDatastore
+	txn     ds.Txn
+	pending int
+}
+
+// put writes the entry, and commits the transaction when it's full. It returns true if the transaction was
+// committed.
+func (b *migrationBatch) put(ctx context.Context, key ds.Key, value []byte) (bool, error) {
+	if b.txn == nil {
+		txn, err := b.kv.NewTransaction(ctx, false)
+		if err != nil {
+			return false, err
+		}
+		b.txn = txn
+	}
+	if err := b.txn.Put(ctx, key, value); err != nil {
+		return false, err
+	}
+	if b.pending++; b.pending < migrationBatchSize {
+		return false, nil
+	}
+	return true, b.commit(ctx)
+}
+
+// commit commits entries written since the last commit.
+func (b *migrationBatch) commit(ctx context.Context) error {
+	if b.txn == nil {
+		return nil
+	}
+	err := b.txn.Commit(ctx)
+	b.discard(ctx)
+	return err
+}
+
+// discard discards entries written since the last commit.
+func (b *migrationBatch) discard(ctx context.Context) {
+	if b.txn != nil {
+		b.txn.Discard(ctx)
+	}
+	b.txn, b.pending = nil, 0
+}
+
+// migrateBlockTimeIndex indexes times of blocks saved before the time index was added, see LoadBlockByTime.
+func migrateBlockTimeIndex(ctx context.Context, kv ds.TxnDatastore, logger log.Logger) error {
+	results, err := kv.Query(ctx, dsq.Query{Prefix: "/" + headerPrefix})
+	if err != nil {
+		return err
+	}
+	defer results.Close() //nolint:errcheck
+
+	batch := &migrationBatch{kv: kv}
+	defer batch.discard(ctx)
+	var indexed uint64
+	for res := range results.Next() {
+		if res.Error != nil {
+			return res.Error
+		}
+		// keys of headers are /h/<height>
+		parts := ds.RawKey(res.Key).List()
+		if len(parts) != 2 || parts[0] != headerPrefix {
+			continue
+		}
+		height, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		var header types.SignedHeader
+		if err := header.UnmarshalBinary(res.Value); err != nil {
+			return fmt.Errorf("failed to unmarshal header %d: %w", height, err)
+		}
+		committed, err := batch.put(ctx, ds.NewKey(legacyHeightKey(blockTimePrefix, height)), encodeBlockTime(header.BaseHeader.Time))
+		if err != nil {
+			return err
+		}
+		indexed++
+		if committed {
+			logger.Info("indexing times of blocks", "indexed", indexed)
+		}
+	}
+	if err := batch.commit(ctx); err != nil {
+		return err
+	}
+	logger.Info("indexed times of blocks", "blocks", indexed)
+	return nil
+}
+
+// migrateHeightKeys replaces decimal heights in keys of records stored by height with fixed-width big-endian heights
+// (see encodeHeightKey), so that prefix scans return records in order of heights, instead of 1, 10, 11, 2...
+func migrateHeightKeys(ctx context.Context, kv ds.TxnDatastore, logger log.Logger) error {
+	for _, prefix := range heightKeyPrefixes {
+		migrated, err := migrateHeightKeysWithPrefix(ctx, kv, prefix)
+		if err != nil {
+			return fmt.Errorf("failed to migrate keys with prefix %s: %w", prefix, err)
+		}
+		logger.Info("migrated keys of records stored by height", "prefix", prefix, "keys", migrated)
+	}
+	return nil
+}
+
+// migrateHeightKeysWithPrefix migrates keys with given prefix, and returns the number of migrated keys. Keys are
+// collected before they are migrated, as not every datastore supports writes during iteration. Keys which were
+// already migrated, with heights of heightKeyLength digits, are skipped, as decimal heights are shorter.
+func migrateHeightKeysWithPrefix(ctx context.Context, kv ds.TxnDatastore, prefix string) (int, error) {
+	prefixFields := strings.Split(prefix, "/")
+	results, err := kv.Query(ctx, dsq.Query{Prefix: "/" + prefix, KeysOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	var keys []ds.Key
+	var heights []uint64
+	for res := range results.Next() {
+		if res.Error != nil {
+			results.Close() //nolint:errcheck
+			return 0, res.Error
+		}
+		fields := ds.RawKey(res.Key).List()
+		if len(fields) != len(prefixFields)+1 || len(fields[len(fields)-1]) == heightKeyLength {
+			continue
+		}
+		height, err := strconv.ParseUint(fields[len(fields)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, ds.RawKey(res.Key))
+		heights = append(heights, height)
+	}
+	if err := results.Close(); err != nil {
+		return 0, err
+	}
+
+	for start := 0; start < len(keys); start += migrationBatchSize {
+		end := min(start+migrationBatchSize, len(keys))
+		if err := migrateKeys(ctx, kv, keys[start:end], func(i int) ds.Key {
+			return ds.NewKey(GenerateKey([]string{prefix, encodeHeightKey(heights[start+i])}))
+		}); err != nil {
+			return start, err
+		}
+	}
+	return len(keys), nil
+}
+
+// migrateKeys moves values of keys to keys returned by newKey, in a single transaction.
+func migrateKeys(ctx context.Context, kv ds.TxnDatastore, keys []ds.Key, newKey func(i int) ds.Key) error {
+	txn, err := kv.NewTransaction(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+	for i, key := range keys {
+		value, err := txn.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if err := txn.Put(ctx, newKey(i), value); err != nil {
+			return err
+		}
+		if err := txn.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return txn.Commit(ctx)
+}
+
+// migrateTxIndex indexes transactions of blocks saved before the transaction index was added, see LoadTxByHash.
+// Transactions of blocks which data was pruned are indexed from the saved hashes, if they were computed with the
+// configured hash function: before schema version 3, PruneBlockData saved SHA-256 hashes, the default.
+func migrateTxIndex(ctx context.Context, kv ds.TxnDatastore, logger log.Logger) error {
+	batch := &migrationBatch{kv: kv}
+	defer batch.discard(ctx)
+	var indexed uint64
+	index := func(height uint64, hashes [][]byte) error {
+		for i, hash := range hashes {
+			committed, err := batch.put(ctx, ds.NewKey(getTxIndexKey(hash)), encodeTxLocation(height, uint32(i))) //nolint:gosec
+			if err != nil {
+				return err
+			}
+			indexed++
+			if committed {
+				logger.Info("indexing transactions", "indexed", indexed, "height", height)
+			}
+		}
+		return nil
+	}
+
+	err := queryRecords(ctx, kv, dataPrefix, func(height uint64, value []byte) error {
+		var data types.Data
+		if err := data.UnmarshalBinary(value); err != nil {
+			return fmt.Errorf("failed to unmarshal data of block %d: %w", height, err)
+		}
+		hashes := make([][]byte, len(data.Txs))
+		for i, tx := range data.Txs {
+			hashes[i] = types.TxHash(tx)
+		}
+		return index(height, hashes)
+	})
+	if err != nil {
+		return err
+	}
+	if types.TxHashCometBFT() {
+		err = queryRecords(ctx, kv, txHashesPrefix, func(height uint64, value []byte) error {
+			hashes, err := decodeTxHashes(value)
+			if err != nil {
+				return fmt.Errorf("failed to decode transaction hashes of block %d: %w", height, err)
+			}
+			return index(height, hashes)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.commit(ctx); err != nil {
+		return err
+	}
+	logger.Info("indexed transactions", "transactions", indexed)
+	return nil
+}
+
+// queryRecords calls fn with every record with given prefix stored by height, in order of heights.
+func queryRecords(ctx context.Context, kv ds.Datastore, prefix string, fn func(height uint64, value []byte) error) error {
+	results, err := queryHeights(ctx, kv, prefix, 0, 0, false)
+	if err != nil {
+		return err
+	}
+	defer results.Close() //nolint:errcheck
+	for res := range results.Next() {
+		if res.Error != nil {
+			return res.Error
+		}
+		height, err := decodeHeightKey(res.Key)
+		if err != nil {
+			return err
+		}
+		if err := fn(height, res.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
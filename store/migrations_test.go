@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx := context.Background()
+	logger := test.NewLogger(t)
+
+	// new store is created with the current version
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	require.NoError(CheckSchemaVersion(ctx, kv))
+	require.NoError(Migrate(ctx, kv, logger))
+	blob, err := kv.Get(ctx, ds.NewKey(getMetaKey(SchemaVersionKey)))
+	require.NoError(err)
+	require.Equal(uint64(SchemaVersion), binary.BigEndian.Uint64(blob))
+
+	// store created before versioning, without time and transaction indexes and with decimal heights in keys
+	kv, err = NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+	var headers []*types.SignedHeader
+	var txs []types.Tx
+	for h := uint64(1); h <= 12; h++ {
+		header, data := types.GetRandomBlock(h, 1, "TestMigrate")
+		headers = append(headers, header)
+		txs = append(txs, data.Txs[0])
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		require.NoError(kv.Delete(ctx, ds.NewKey(getBlockTimeKey(h))))
+		require.NoError(kv.Delete(ctx, ds.NewKey(getTxIndexKey(types.TxHash(data.Txs[0])))))
+		for _, key := range []string{getHeaderKey(h), getDataKey(h), getSignatureKey(h)} {
+			value, err := kv.Get(ctx, ds.NewKey(key))
+			require.NoError(err)
+			require.NoError(kv.Delete(ctx, ds.NewKey(key)))
+			legacyKey := ds.NewKey(key).Parent().ChildString(strconv.FormatUint(h, 10))
+			require.NoError(kv.Put(ctx, legacyKey, value))
+		}
+	}
+	require.NoError(s.UpdateState(ctx, types.State{LastBlockHeight: 12}))
+	version, err := LoadSchemaVersion(ctx, kv)
+	require.NoError(err)
+	require.Zero(version)
+	require.ErrorIs(CheckSchemaVersion(ctx, kv), ErrSchemaVersion)
+
+	require.NoError(Migrate(ctx, kv, logger))
+	version, err = LoadSchemaVersion(ctx, kv)
+	require.NoError(err)
+	require.Equal(uint64(SchemaVersion), version)
+	require.NoError(CheckSchemaVersion(ctx, kv))
+	for h, header := range headers {
+		blob, err := kv.Get(ctx, ds.NewKey(getBlockTimeKey(uint64(h+1))))
+		require.NoError(err)
+		require.Equal(encodeBlockTime(header.BaseHeader.Time), blob)
+		stored, data, err := s.GetBlockData(ctx, uint64(h+1))
+		require.NoError(err)
+		require.Equal(header.Hash(), stored.Hash())
+		require.Equal(uint64(h+1), data.Height())
+		_, err = s.GetSignature(ctx, uint64(h+1))
+		require.NoError(err)
+		height, index, err := s.LoadTxByHash(ctx, types.TxHash(txs[h]))
+		require.NoError(err)
+		require.Equal(uint64(h+1), height)
+		require.Zero(index)
+	}
+	// prefix scans return records in order of heights
+	results, err := kv.Query(ctx, dsq.Query{Prefix: "/" + headerPrefix, KeysOnly: true})
+	require.NoError(err)
+	entries, err := results.Rest()
+	require.NoError(err)
+	require.Len(entries, len(headers))
+	for i, e := range entries {
+		require.Equal(getHeaderKey(uint64(i+1)), e.Key)
+	}
+	// migrated store is not migrated again
+	require.NoError(Migrate(ctx, kv, logger))
+
+	// store written by a newer version is not supported
+	require.NoError(saveSchemaVersion(ctx, kv, SchemaVersion+1))
+	require.ErrorIs(Migrate(ctx, kv, logger), ErrSchemaVersion)
+	require.ErrorIs(CheckSchemaVersion(ctx, kv), ErrSchemaVersion)
+}
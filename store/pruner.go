@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/third_party/log"
+)
+
+// PrunedHeightKey is the metadata key of the height up to which (inclusive) blocks were pruned.
+const PrunedHeightKey = "pruned height"
+
+// prunedBlocksPerBatch is the number of blocks pruned before progress is saved.
+const prunedBlocksPerBatch = 100
+
+// Pruner removes blocks older than configured number of blocks, along with their signatures, responses and
+// state records. Latest state is kept, so that the node can continue to sync and execute blocks.
+type Pruner struct {
+	store      Store
+	keepRecent uint64
+	interval   time.Duration
+	// limit returns the highest height which can be pruned, e.g. DA included height
+	limit  func() uint64
+	logger log.Logger
+}
+
+// NewPruner creates Pruner keeping keepRecent latest blocks. Blocks above height returned by limit are never
+// pruned, limit can be nil. Pruning is disabled if keepRecent is 0.
+func NewPruner(store Store, keepRecent uint64, interval time.Duration, limit func() uint64, logger log.Logger) *Pruner {
+	return &Pruner{
+		store:      store,
+		keepRecent: keepRecent,
+		interval:   interval,
+		limit:      limit,
+		logger:     logger,
+	}
+}
+
+// Run prunes blocks periodically until context is cancelled.
+func (p *Pruner) Run(ctx context.Context) {
+	if p.keepRecent == 0 || p.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.Prune(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Error("failed to prune blocks", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune removes blocks older than retained ones and returns the height up to which (inclusive) blocks are pruned.
+func (p *Pruner) Prune(ctx context.Context) (uint64, error) {
+	pruned, err := PrunedHeight(ctx, p.store)
+	if err != nil {
+		return 0, err
+	}
+	height := p.store.Height()
+	if height <= p.keepRecent {
+		return pruned, nil
+	}
+	target := min(height-p.keepRecent, pruned+maxPrunedBlocksPerRun)
+	if p.limit != nil {
+		target = min(target, p.limit())
+	}
+	if target <= pruned {
+		return pruned, nil
+	}
+
+	start := time.Now()
+	for pruned < target {
+		to := min(pruned+prunedBlocksPerBatch, target)
+		if err := p.store.Prune(ctx, pruned+1, to); err != nil {
+			return pruned, err
+		}
+		pruned = to
+		if err := p.store.SetMetadata(ctx, PrunedHeightKey, encodeHeight(pruned)); err != nil {
+			return pruned, err
+		}
+	}
+	p.logger.Info("pruned blocks", "height", pruned, "duration", time.Since(start))
+	return pruned, nil
+}
+
+// PrunedHeight returns the height up to which (inclusive) blocks were pruned, 0 if they were never pruned.
+func PrunedHeight(ctx context.Context, s Store) (uint64, error) {
+	value, err := s.GetMetadata(ctx, PrunedHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return decodeHeight(value)
+}
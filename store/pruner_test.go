@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestPruner(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	const height = 6
+	headers := make([]*types.SignedHeader, height+1)
+	for h := uint64(1); h <= height; h++ {
+		header, data := types.GetRandomBlock(h, 2, "TestPruner")
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		require.NoError(s.SaveBlockResponses(ctx, h, &abcitypes.ResponseFinalizeBlock{}))
+		s.SetHeight(ctx, h)
+		headers[h] = header
+	}
+	validatorSet := types.GetRandomValidatorSet()
+	require.NoError(s.UpdateState(ctx, types.State{
+		InitialHeight:   1,
+		LastBlockHeight: height,
+		NextValidators:  validatorSet,
+		Validators:      validatorSet,
+		LastValidators:  validatorSet,
+	}))
+
+	// blocks above limit (e.g. not DA included yet) are kept
+	limit := uint64(2)
+	pruner := NewPruner(s, 2, 0, func() uint64 { return limit }, test.NewLogger(t))
+	pruned, err := pruner.Prune(ctx)
+	require.NoError(err)
+	assert.EqualValues(2, pruned)
+
+	limit = height
+	pruned, err = pruner.Prune(ctx)
+	require.NoError(err)
+	assert.EqualValues(4, pruned)
+	stored, err := PrunedHeight(ctx, s)
+	require.NoError(err)
+	assert.EqualValues(4, stored)
+
+	for h := uint64(1); h <= height; h++ {
+		_, err := s.GetHeader(ctx, h)
+		_, hashErr := s.GetSignatureByHash(ctx, headers[h].Hash())
+		_, respErr := s.GetBlockResponses(ctx, h)
+		if h <= pruned {
+			assert.ErrorIs(err, ErrHeightOutOfRange)
+			assert.ErrorIs(hashErr, ds.ErrNotFound)
+			assert.ErrorIs(respErr, ds.ErrNotFound)
+		} else {
+			assert.NoError(err)
+			assert.NoError(hashErr)
+			assert.NoError(respErr)
+		}
+	}
+
+	// state is kept
+	state, err := s.GetState(ctx)
+	require.NoError(err)
+	assert.EqualValues(height, state.LastBlockHeight)
+
+	// block data of removed blocks is not pruned again
+	dataPruned, err := NewDataPruner(s, 1, 0, test.NewLogger(t)).Prune(ctx)
+	require.NoError(err)
+	assert.EqualValues(5, dataPruned)
+	_, err = s.GetTxHashes(ctx, 1)
+	assert.ErrorIs(err, ds.ErrNotFound)
+}
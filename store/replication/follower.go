@@ -0,0 +1,197 @@
+package replication
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	ds "github.com/ipfs/go-datastore"
+	"google.golang.org/grpc"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// ReplicatedHeightKey is the key used for persisting the height of the last replicated entry in follower's store.
+const ReplicatedHeightKey = "replicated height"
+
+// retryInterval is the time to wait before reconnecting to primary node after replication stream failure.
+const retryInterval = time.Second
+
+// ApplyHook is called by Follower after entry is persisted, e.g. to index the block.
+type ApplyHook func(ctx context.Context, header *types.SignedHeader, data *types.Data, responses *abci.ResponseFinalizeBlock) error
+
+// Follower replicates store of a primary node into local store.
+type Follower struct {
+	*service.BaseService
+
+	conn  *grpc.ClientConn
+	store store.Store
+	hook  ApplyHook
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewFollower creates Follower replicating entries from primary node's Replication service over conn into store.
+// hook is optional.
+func NewFollower(conn *grpc.ClientConn, store store.Store, hook ApplyHook, logger log.Logger) *Follower {
+	f := &Follower{
+		conn:  conn,
+		store: store,
+		hook:  hook,
+	}
+	f.BaseService = service.NewBaseService(logger, "ReplicationFollower", f)
+	return f
+}
+
+// OnStart is called when Follower is started (see service.BaseService for details).
+func (f *Follower) OnStart() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	// entries are persisted before state is updated, so replicated height can be higher than state height
+	height, err := f.store.GetMetadata(ctx, ReplicatedHeightKey)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		cancel()
+		return fmt.Errorf("failed to load replicated height: %w", err)
+	}
+	if len(height) == 8 {
+		f.store.SetHeight(ctx, binary.BigEndian.Uint64(height))
+	}
+
+	f.cancel = cancel
+	f.done = make(chan struct{})
+	go f.run(ctx)
+	return nil
+}
+
+// OnStop is called when Follower is stopped (see service.BaseService for details).
+func (f *Follower) OnStop() {
+	f.cancel()
+	<-f.done
+}
+
+// run keeps replication stream open, resuming it from the last persisted height after failures.
+func (f *Follower) run(ctx context.Context) {
+	defer close(f.done)
+	for {
+		err := f.follow(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		f.Logger.Error("replication stream failed, reconnecting", "error", err, "height", f.store.Height())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// follow persists entries received in a single replication stream, acknowledging each of them.
+func (f *Follower) follow(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := pb.NewReplicationClient(f.conn).Replicate(ctx)
+	if err != nil {
+		return err
+	}
+	next := f.store.Height() + 1
+	if err := stream.Send(&pb.ReplicateRequest{FromHeight: next}); err != nil {
+		return err
+	}
+	f.Logger.Info("replicating store from primary node", "from height", next)
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return errors.New("stream closed by primary node")
+		}
+		if err != nil {
+			return err
+		}
+		if entry.Height != next {
+			return fmt.Errorf("unexpected entry height %d, expected %d", entry.Height, next)
+		}
+		if err := f.apply(ctx, entry); err != nil {
+			return fmt.Errorf("failed to apply entry at height %d: %w", entry.Height, err)
+		}
+		if err := stream.Send(&pb.ReplicateRequest{AckHeight: entry.Height}); err != nil {
+			return err
+		}
+		next++
+	}
+}
+
+// apply persists entry in the store. Replicated height is persisted after the hook is called, so the entry
+// is applied again if the hook fails.
+func (f *Follower) apply(ctx context.Context, entry *pb.Entry) error {
+	header := new(types.SignedHeader)
+	if err := header.FromProto(entry.Header); err != nil {
+		return fmt.Errorf("failed to decode header: %w", err)
+	}
+	data := new(types.Data)
+	if err := data.UnmarshalBinary(entry.Data); err != nil {
+		return fmt.Errorf("failed to decode data: %w", err)
+	}
+	if header.Height() != entry.Height {
+		return fmt.Errorf("header height %d doesn't match entry height", header.Height())
+	}
+	if err := types.Validate(header, data); err != nil {
+		return err
+	}
+	signature := types.Signature(entry.Signature)
+
+	if err := f.store.SaveBlockData(ctx, header, data, &signature); err != nil {
+		return err
+	}
+	if entry.ExtendedCommit != nil {
+		if err := f.store.SaveExtendedCommit(ctx, entry.Height, entry.ExtendedCommit); err != nil {
+			return err
+		}
+	}
+	// responses are not replicated if events were pruned on primary node, there is nothing to index then
+	pruned := entry.Responses == nil
+	if !pruned {
+		if err := f.store.SaveBlockResponses(ctx, entry.Height, entry.Responses); err != nil {
+			return err
+		}
+	}
+	if entry.DaIncludedHeight != 0 {
+		if err := f.store.SetMetadata(ctx, block.DAIncludedHeightKey, uint64Bytes(entry.DaIncludedHeight)); err != nil {
+			return err
+		}
+	}
+	if f.hook != nil && !pruned {
+		if err := f.hook(ctx, header, data, entry.Responses); err != nil {
+			return err
+		}
+	}
+	if err := f.store.SetMetadata(ctx, ReplicatedHeightKey, uint64Bytes(entry.Height)); err != nil {
+		return err
+	}
+	f.store.SetHeight(ctx, entry.Height)
+	if entry.State != nil {
+		var state types.State
+		if err := state.FromProto(entry.State); err != nil {
+			return fmt.Errorf("failed to decode state: %w", err)
+		}
+		if err := f.store.UpdateState(ctx, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uint64Bytes(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
@@ -0,0 +1,127 @@
+package replication
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+const chainID = "TestReplication"
+
+func TestReplication(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	primary := newStore(t)
+	for h := uint64(1); h <= 3; h++ {
+		commitBlock(t, primary, h)
+	}
+	daIncludedHeight := make([]byte, 8)
+	binary.BigEndian.PutUint64(daIncludedHeight, 2)
+	require.NoError(primary.SetMetadata(ctx, block.DAIncludedHeightKey, daIncludedHeight))
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterReplicationServer(grpcServer, NewServer(primary, "", log.TestingLogger()))
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(err)
+	defer func() { _ = conn.Close() }()
+
+	var mtx sync.Mutex
+	var applied []uint64
+	hook := func(_ context.Context, header *types.SignedHeader, _ *types.Data, _ *abci.ResponseFinalizeBlock) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		applied = append(applied, header.Height())
+		return nil
+	}
+
+	replica := newStore(t)
+	follower := NewFollower(conn, replica, hook, log.TestingLogger())
+	require.NoError(follower.Start())
+	waitForState(t, replica, 3)
+
+	for h := uint64(1); h <= 3; h++ {
+		expectedHeader, expectedData, err := primary.GetBlockData(ctx, h)
+		require.NoError(err)
+		header, data, err := replica.GetBlockData(ctx, h)
+		require.NoError(err)
+		assert.Equal(t, expectedHeader.Hash(), header.Hash())
+		assert.Equal(t, expectedData.Txs, data.Txs)
+		_, err = replica.GetBlockResponses(ctx, h)
+		require.NoError(err)
+	}
+	height, err := replica.GetMetadata(ctx, block.DAIncludedHeightKey)
+	require.NoError(err)
+	assert.Equal(t, daIncludedHeight, height)
+
+	// new blocks are streamed as they're committed
+	commitBlock(t, primary, 4)
+	waitForState(t, replica, 4)
+	require.NoError(follower.Stop())
+
+	// replication is resumed from the last persisted entry
+	commitBlock(t, primary, 5)
+	follower = NewFollower(conn, replica, hook, log.TestingLogger())
+	require.NoError(follower.Start())
+	waitForState(t, replica, 5)
+	require.NoError(follower.Stop())
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, applied)
+}
+
+func newStore(t *testing.T) store.Store {
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	return store.New(kv)
+}
+
+// commitBlock saves block at given height in the same way as block manager does.
+func commitBlock(t *testing.T, s store.Store, height uint64) {
+	ctx := context.Background()
+	header, data := types.GetRandomBlock(height, 2, chainID)
+	require.NoError(t, s.SaveBlockData(ctx, header, data, &header.Signature))
+	require.NoError(t, s.SaveBlockResponses(ctx, height, &abci.ResponseFinalizeBlock{
+		TxResults: []*abci.ExecTxResult{{Code: 0}, {Code: 1}},
+	}))
+	s.SetHeight(ctx, height)
+	validators := types.GetRandomValidatorSet()
+	require.NoError(t, s.UpdateState(ctx, types.State{
+		ChainID:         chainID,
+		InitialHeight:   1,
+		LastBlockHeight: height,
+		Validators:      validators,
+		NextValidators:  validators,
+		LastValidators:  validators,
+	}))
+}
+
+func waitForState(t *testing.T, s store.Store, height uint64) {
+	require.Eventually(t, func() bool {
+		state, err := s.GetState(context.Background())
+		return err == nil && state.LastBlockHeight == height && s.Height() == height
+	}, 5*time.Second, 10*time.Millisecond)
+}
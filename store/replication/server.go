@@ -0,0 +1,217 @@
+// Package replication implements streaming of committed store entries from a primary node to follower read-replicas.
+//
+// Followers persist received entries in their own store and acknowledge them. Replication is resumed
+// from the last persisted height after reconnecting, so followers don't need to sync blocks from DA.
+package replication
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	ds "github.com/ipfs/go-datastore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/store"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+const (
+	// maxUnacked is the maximum number of entries sent to follower ahead of the last acknowledged height.
+	maxUnacked = 64
+
+	// pollInterval is the interval between checks for newly committed blocks.
+	pollInterval = 50 * time.Millisecond
+)
+
+// Server serves Replication gRPC service (defined in proto/rollkit/replication.proto), streaming entries of the
+// primary node's store.
+type Server struct {
+	*service.BaseService
+
+	addr   string
+	store  store.Store
+	server *grpc.Server
+}
+
+// NewServer creates new instance of Server, listening on given address (e.g. "tcp://0.0.0.0:9092").
+func NewServer(store store.Store, addr string, logger log.Logger) *Server {
+	srv := &Server{
+		addr:  addr,
+		store: store,
+	}
+	srv.BaseService = service.NewBaseService(logger, "Replication", srv)
+	return srv
+}
+
+// OnStart is called when Server is started (see service.BaseService for details).
+func (s *Server) OnStart() error {
+	proto, addr := "tcp", s.addr
+	if parts := strings.SplitN(s.addr, "://", 2); len(parts) == 2 {
+		proto, addr = parts[0], parts[1]
+	}
+	listener, err := net.Listen(proto, addr)
+	if err != nil {
+		return err
+	}
+	s.server = grpc.NewServer()
+	pb.RegisterReplicationServer(s.server, s)
+
+	s.Logger.Info("serving gRPC", "service", "Replication", "listen address", listener.Addr())
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.Logger.Error("error while serving gRPC", "error", err)
+		}
+	}()
+	return nil
+}
+
+// OnStop is called when Server is stopped (see service.BaseService for details).
+func (s *Server) OnStop() {
+	// replication streams never end on their own, so graceful stop would block
+	s.server.Stop()
+}
+
+// Replicate streams committed entries, starting at height requested by follower.
+func (s *Server) Replicate(stream pb.Replication_ReplicateServer) error {
+	ctx := stream.Context()
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	next := req.FromHeight
+	if next == 0 {
+		next = 1
+	}
+	s.Logger.Info("follower connected", "from height", next)
+
+	// sent and acked are accessed concurrently by goroutine receiving acknowledgments
+	var sent, acked atomic.Uint64
+	sent.Store(next - 1)
+	acked.Store(next - 1)
+	ackCh := make(chan struct{}, 1)
+	recvErrCh := make(chan error, 1)
+	go func() {
+		for {
+			ack, err := stream.Recv()
+			if err != nil {
+				recvErrCh <- err
+				return
+			}
+			if ack.AckHeight < acked.Load() || ack.AckHeight > sent.Load() {
+				recvErrCh <- status.Errorf(codes.InvalidArgument, "invalid acknowledged height %d, sent up to %d", ack.AckHeight, sent.Load())
+				return
+			}
+			acked.Store(ack.AckHeight)
+			select {
+			case ackCh <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		committed, err := s.committedHeight(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get committed height: %v", err)
+		}
+		for next <= committed && next <= acked.Load()+maxUnacked {
+			entry, err := newEntry(ctx, s.store, next)
+			if err != nil {
+				return status.Errorf(codes.NotFound, "failed to load entry at height %d: %v", next, err)
+			}
+			if err := stream.Send(entry); err != nil {
+				return err
+			}
+			sent.Store(next)
+			next++
+		}
+
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("follower disconnected", "acknowledged height", acked.Load())
+			return status.FromContextError(ctx.Err()).Err()
+		case err := <-recvErrCh:
+			s.Logger.Info("follower disconnected", "acknowledged height", acked.Load())
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case <-ackCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// committedHeight returns the height of the last block applied to state.
+//
+// Store height is increased before state is updated, so entries are streamed only up to state height,
+// to include the state in the entry of the block that committed it.
+func (s *Server) committedHeight(ctx context.Context) (uint64, error) {
+	state, err := s.store.GetState(ctx)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return state.LastBlockHeight, nil
+}
+
+// newEntry loads everything stored for block at given height.
+func newEntry(ctx context.Context, s store.Store, height uint64) (*pb.Entry, error) {
+	header, data, err := s.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	entry := &pb.Entry{Height: height}
+	if entry.Header, err = header.ToProto(); err != nil {
+		return nil, err
+	}
+	if entry.Data, err = data.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	signature, err := s.GetSignature(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	entry.Signature = *signature
+	// block responses are missing if events were pruned
+	entry.Responses, err = s.GetBlockResponses(ctx, height)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	entry.ExtendedCommit, err = s.GetExtendedCommit(ctx, height)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	state, err := s.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state.LastBlockHeight == height {
+		if entry.State, err = state.ToProto(); err != nil {
+			return nil, err
+		}
+	}
+	daIncludedHeight, err := s.GetMetadata(ctx, block.DAIncludedHeightKey)
+	if err == nil && len(daIncludedHeight) == 8 {
+		entry.DaIncludedHeight = binary.BigEndian.Uint64(daIncludedHeight)
+	} else if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load DA included height: %w", err)
+	}
+	return entry, nil
+}
@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// Kinds of data reported by Stats.
+const (
+	StatsHeaders         = "headers"
+	StatsData            = "data"
+	StatsTxHashes        = "tx_hashes"
+	StatsSignatures      = "signatures"
+	StatsExtendedCommits = "extended_commits"
+	StatsState           = "state"
+	StatsStateRecords    = "state_records"
+	StatsResponses       = "responses"
+	StatsMetadata        = "metadata"
+	StatsHashIndex       = "hash_index"
+	StatsTxIndex         = "tx_index"
+	StatsBlockTimes      = "block_times"
+	StatsDACertificates  = "da_certificates"
+	StatsOther           = "other"
+)
+
+// statsKinds maps the first segment of a key to kind of data.
+var statsKinds = map[string]string{
+	headerPrefix:         StatsHeaders,
+	dataPrefix:           StatsData,
+	txHashesPrefix:       StatsTxHashes,
+	signaturePrefix:      StatsSignatures,
+	extendedCommitPrefix: StatsExtendedCommits,
+	statePrefix:          StatsState,
+	responsesPrefix:      StatsResponses,
+	metaPrefix:           StatsMetadata,
+	indexPrefix:          StatsHashIndex,
+	txIndexPrefix:        StatsTxIndex,
+	blockTimePrefix:      StatsBlockTimes,
+	daCertificatePrefix:  StatsDACertificates,
+	// stateRecordPrefix is versioned, only its first segment is matched
+	"sr": StatsStateRecords,
+}
+
+// Usage is the number of keys and approximate size (keys and values, before compression) of stored data.
+type Usage struct {
+	Keys  uint64 `json:"keys"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// Stats describes usage of the store by kind of data, e.g. block data vs block responses.
+type Stats struct {
+	Kinds map[string]Usage `json:"kinds"`
+	Total Usage            `json:"total"`
+}
+
+// Add records usage of given kind of data.
+func (s *Stats) Add(kind string, u Usage) {
+	if s.Kinds == nil {
+		s.Kinds = make(map[string]Usage)
+	}
+	k := s.Kinds[kind]
+	k.Keys += u.Keys
+	k.Bytes += u.Bytes
+	s.Kinds[kind] = k
+	s.Total.Keys += u.Keys
+	s.Total.Bytes += u.Bytes
+}
+
+// Stats iterates over all keys in the store and returns usage by kind of data. It's expensive for large stores.
+func (s *DefaultStore) Stats(ctx context.Context) (*Stats, error) {
+	stats := &Stats{Kinds: make(map[string]Usage)}
+	err := scanUsage(ctx, s.db, func(key string, u Usage) {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(key, "/"), "/")
+		kind, ok := statsKinds[segment]
+		if !ok {
+			kind = StatsOther
+		}
+		stats.Add(kind, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// KVUsage returns usage of all keys in the key-value store, e.g. of the transaction indexer.
+func KVUsage(ctx context.Context, kv ds.Datastore) (Usage, error) {
+	var total Usage
+	err := scanUsage(ctx, kv, func(_ string, u Usage) {
+		total.Keys += u.Keys
+		total.Bytes += u.Bytes
+	})
+	return total, err
+}
+
+// scanUsage calls fn with usage of every key in kv. Values are not read, if datastore reports their sizes.
+func scanUsage(ctx context.Context, kv ds.Datastore, fn func(key string, u Usage)) error {
+	results, err := kv.Query(ctx, dsq.Query{KeysOnly: true, ReturnsSizes: true})
+	if err != nil {
+		return err
+	}
+	defer results.Close() //nolint:errcheck
+	for r := range results.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		size := r.Size
+		if size < 0 {
+			if size, err = kv.GetSize(ctx, ds.NewKey(r.Key)); err != nil {
+				return err
+			}
+		}
+		fn(r.Key, Usage{Keys: 1, Bytes: uint64(len(r.Key) + size)}) //nolint:gosec
+	}
+	return nil
+}
+
+// ObserveStats updates usage metrics.
+func (m *Metrics) ObserveStats(stats *Stats) {
+	for kind, u := range stats.Kinds {
+		m.UsageKeys.With("kind", kind).Set(float64(u.Keys))
+		m.UsageBytes.With("kind", kind).Set(float64(u.Bytes))
+	}
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestStats(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	stats, err := s.Stats(ctx)
+	require.NoError(err)
+	require.Zero(stats.Total)
+
+	for h := uint64(1); h <= 3; h++ {
+		header, data := types.GetRandomBlock(h, 2, "TestStats")
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		require.NoError(s.SaveBlockResponses(ctx, h, &abci.ResponseFinalizeBlock{}))
+	}
+	require.NoError(s.SetMetadata(ctx, "key", []byte("value")))
+
+	stats, err = s.Stats(ctx)
+	require.NoError(err)
+	assert.EqualValues(t, 3, stats.Kinds[StatsHeaders].Keys)
+	assert.EqualValues(t, 3, stats.Kinds[StatsData].Keys)
+	assert.EqualValues(t, 3, stats.Kinds[StatsSignatures].Keys)
+	assert.EqualValues(t, 3, stats.Kinds[StatsHashIndex].Keys)
+	assert.EqualValues(t, 3, stats.Kinds[StatsBlockTimes].Keys)
+	assert.EqualValues(t, 3, stats.Kinds[StatsResponses].Keys)
+	assert.EqualValues(t, 1, stats.Kinds[StatsMetadata].Keys)
+	assert.Greater(t, stats.Kinds[StatsData].Bytes, stats.Kinds[StatsSignatures].Bytes)
+
+	var total Usage
+	for _, u := range stats.Kinds {
+		total.Keys += u.Keys
+		total.Bytes += u.Bytes
+	}
+	assert.Equal(t, total, stats.Total)
+
+	usage, err := KVUsage(ctx, ds.NewMapDatastore())
+	require.NoError(err)
+	assert.Zero(t, usage)
+}
@@ -1,17 +1,22 @@
 package store
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"strconv"
+	"sync"
 	"sync/atomic"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtypes "github.com/cometbft/cometbft/types"
 	ds "github.com/ipfs/go-datastore"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/rollkit/rollkit/types"
-	pb "github.com/rollkit/rollkit/types/pb/rollkit"
 )
 
 var (
@@ -21,23 +26,71 @@ var (
 	signaturePrefix      = "c"
 	extendedCommitPrefix = "ec"
 	statePrefix          = "s"
-	responsesPrefix      = "r"
-	metaPrefix           = "m"
+	// stateRecordPrefix is versioned, so that format of records can be changed without migrating old records
+	stateRecordPrefix = "sr/v1"
+	responsesPrefix   = "r"
+	metaPrefix        = "m"
+	// txHashesPrefix stores hashes of transactions of blocks which data was pruned, see PruneBlockData
+	txHashesPrefix = "th"
+	// txIndexPrefix stores height and index of transactions by their hashes (computed with types.TxHash)
+	txIndexPrefix = "t"
+	// blockTimePrefix stores times of blocks by height, see LoadBlockByTime
+	blockTimePrefix = "bt"
+	// daCertificatePrefix stores DA inclusion certificates of blocks by height
+	daCertificatePrefix = "dc"
 )
 
+// ErrBlockDataPruned is returned when data of requested block was pruned. Header of the block is still available.
+var ErrBlockDataPruned = errors.New("block data was pruned")
+
 // DefaultStore is a default store implmementation.
 type DefaultStore struct {
 	db     ds.TxnDatastore
 	height atomic.Uint64
+	// heightChanged is closed and replaced whenever height increases, see WaitForHeight
+	heightMtx     sync.Mutex
+	heightChanged chan struct{}
+
+	// reads coalesces concurrent reads of the same block or signature, e.g. when many RPC clients request
+	// the latest block right after it's saved, so that it's read and unmarshalled once
+	reads singleflight.Group
+
+	// syncInterval is the number of blocks between syncs of the datastore to disk, see WithSyncInterval
+	syncInterval uint64
+}
+
+// Option configures DefaultStore.
+type Option func(*DefaultStore)
+
+// WithSyncInterval sets the number of blocks between syncs (fsync) of the datastore to disk. Datastore is
+// synced when state of a block with height divisible by blocks is saved with UpdateState. 1 syncs every block,
+// 0 never syncs explicitly, leaving it to the datastore. Blocks saved since the last sync may be lost on crash
+// (but not on graceful shutdown), and have to be synced again, e.g. from DA.
+func WithSyncInterval(blocks uint64) Option {
+	return func(s *DefaultStore) {
+		s.syncInterval = blocks
+	}
+}
+
+// blockData is the result of coalesced GetBlockData.
+type blockData struct {
+	header *types.SignedHeader
+	data   *types.Data
 }
 
 var _ Store = &DefaultStore{}
 
-// New returns new, default store.
-func New(ds ds.TxnDatastore) Store {
-	return &DefaultStore{
-		db: ds,
+// New returns new, default store. Any datastore can be used, datastores not supporting transactions are wrapped
+// with NewTxnDatastore.
+func New(kv ds.Datastore, opts ...Option) Store {
+	s := &DefaultStore{
+		db:            NewTxnDatastore(kv),
+		heightChanged: make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Close safely closes underlying data storage, to ensure that data is actually saved.
@@ -45,17 +98,41 @@ func (s *DefaultStore) Close() error {
 	return s.db.Close()
 }
 
-// SetHeight sets the height saved in the Store if it is higher than the existing height
+// SetHeight sets the height saved in the Store if it is higher than the existing height. Height never decreases:
+// if it's set concurrently, e.g. by the block manager and when state is loaded, the highest height is kept.
+// Callers waiting for the height with WaitForHeight are notified.
 func (s *DefaultStore) SetHeight(ctx context.Context, height uint64) {
 	for {
 		storeHeight := s.height.Load()
 		if height <= storeHeight {
-			break
+			return
 		}
 		if s.height.CompareAndSwap(storeHeight, height) {
 			break
 		}
 	}
+	s.heightMtx.Lock()
+	close(s.heightChanged)
+	s.heightChanged = make(chan struct{})
+	s.heightMtx.Unlock()
+}
+
+// WaitForHeight blocks until height of the Store is at least given height, or context is done.
+func (s *DefaultStore) WaitForHeight(ctx context.Context, height uint64) error {
+	for {
+		s.heightMtx.Lock()
+		changed := s.heightChanged
+		s.heightMtx.Unlock()
+		// height is checked after taking the channel, so an increase right after the check closes the channel
+		if s.Height() >= height {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 }
 
 // Height returns height of the highest block saved in the Store.
@@ -66,17 +143,41 @@ func (s *DefaultStore) Height() uint64 {
 // SaveBlockData adds block header and data to the store along with corresponding signature.
 // Stored height is updated if block height is greater than stored value.
 func (s *DefaultStore) SaveBlockData(ctx context.Context, header *types.SignedHeader, data *types.Data, signature *types.Signature) error {
+	return s.SaveBlockDataWithMetadata(ctx, header, data, signature, nil)
+}
+
+// SaveBlockDataWithMetadata saves block like SaveBlockData, and metadata values in the same transaction, e.g. the
+// last batch or inbound message included in the block, so that they can't diverge after a crash.
+func (s *DefaultStore) SaveBlockDataWithMetadata(ctx context.Context, header *types.SignedHeader, data *types.Data, signature *types.Signature, metadata map[string][]byte) error {
+	return s.SaveBlockDataWithResponses(ctx, header, data, signature, nil, metadata)
+}
+
+// SaveBlockDataWithResponses saves block like SaveBlockDataWithMetadata, and responses of its execution in the same
+// transaction. Responses are optional.
+func (s *DefaultStore) SaveBlockDataWithResponses(ctx context.Context, header *types.SignedHeader, data *types.Data, signature *types.Signature, responses *abci.ResponseFinalizeBlock, metadata map[string][]byte) error {
 	hash := header.Hash()
 	height := header.Height()
 	signatureHash := *signature
-	headerBlob, err := header.MarshalBinary()
+	// blobs are copied by the datastore, so buffers can be reused once the transaction is done
+	headerBlob, releaseHeader, err := header.MarshalPooled()
 	if err != nil {
 		return fmt.Errorf("failed to marshal Header to binary: %w", err)
 	}
-	dataBlob, err := data.MarshalBinary()
+	defer releaseHeader()
+	dataBlob, releaseData, err := data.MarshalPooled()
 	if err != nil {
 		return fmt.Errorf("failed to marshal Data to binary: %w", err)
 	}
+	defer releaseData()
+	var responsesBlob []byte
+	if responses != nil {
+		var releaseResponses func()
+		responsesBlob, releaseResponses, err = types.MarshalPooled(responses)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+		defer releaseResponses()
+	}
 
 	bb, err := s.db.NewTransaction(ctx, false)
 	if err != nil {
@@ -100,6 +201,26 @@ func (s *DefaultStore) SaveBlockData(ctx context.Context, header *types.SignedHe
 	if err != nil {
 		return fmt.Errorf("failed to create a new key using height of the block: %w", err)
 	}
+	err = bb.Put(ctx, ds.NewKey(getBlockTimeKey(height)), encodeBlockTime(header.BaseHeader.Time))
+	if err != nil {
+		return fmt.Errorf("failed to index time of the block: %w", err)
+	}
+	for i, tx := range data.Txs {
+		err = bb.Put(ctx, ds.NewKey(getTxIndexKey(types.TxHash(tx))), encodeTxLocation(height, uint32(i))) //nolint:gosec
+		if err != nil {
+			return fmt.Errorf("failed to index transaction %d: %w", i, err)
+		}
+	}
+	if responses != nil {
+		if err = bb.Put(ctx, ds.NewKey(getResponsesKey(height)), responsesBlob); err != nil {
+			return fmt.Errorf("failed to save block responses: %w", err)
+		}
+	}
+	for key, value := range metadata {
+		if err = bb.Put(ctx, ds.NewKey(getMetaKey(key)), value); err != nil {
+			return fmt.Errorf("failed to set metadata for key '%s': %w", key, err)
+		}
+	}
 
 	if err = bb.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -109,27 +230,375 @@ func (s *DefaultStore) SaveBlockData(ctx context.Context, header *types.SignedHe
 }
 
 // GetBlockData returns block header and data at given height, or error if it's not found in Store.
+// ErrBlockDataPruned is returned if data of the block was pruned.
+//
+// Concurrent calls for the same height are coalesced and share the result, so returned block must not be modified.
 func (s *DefaultStore) GetBlockData(ctx context.Context, height uint64) (*types.SignedHeader, *types.Data, error) {
-	headerBlob, err := s.db.Get(ctx, ds.NewKey(getHeaderKey(height)))
+	v, err, _ := s.reads.Do(getDataKey(height), func() (interface{}, error) {
+		header, data, err := s.getBlockData(ctx, height)
+		return blockData{header: header, data: data}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	block := v.(blockData)
+	return block.header, block.data, nil
+}
+
+func (s *DefaultStore) getBlockData(ctx context.Context, height uint64) (*types.SignedHeader, *types.Data, error) {
+	return s.readBlockData(ctx, s.db, height)
+}
+
+// readBlockData reads block at given height with given reader.
+func (s *DefaultStore) readBlockData(ctx context.Context, r ds.Read, height uint64) (*types.SignedHeader, *types.Data, error) {
+	header, err := s.readHeader(ctx, r, height)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load block header: %w", err)
+		return nil, nil, err
+	}
+	data, err := s.readData(ctx, r, height)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, data, nil
+}
+
+// GetHeader returns block header at given height, or error if it's not found in Store.
+// Header is returned even if data of the block was pruned with PruneBlockData.
+func (s *DefaultStore) GetHeader(ctx context.Context, height uint64) (*types.SignedHeader, error) {
+	return s.readHeader(ctx, s.db, height)
+}
+
+func (s *DefaultStore) readHeader(ctx context.Context, r ds.Read, height uint64) (*types.SignedHeader, error) {
+	headerBlob, err := r.Get(ctx, ds.NewKey(getHeaderKey(height)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block header: %w", s.rangeError(ctx, height, err))
 	}
 	header := new(types.SignedHeader)
 	err = header.UnmarshalBinary(headerBlob)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to unmarshal block header: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal block header: %w", err)
 	}
+	return header, nil
+}
+
+// GetHeaderByHash returns block header with given hash, or error if it's not found in Store.
+func (s *DefaultStore) GetHeaderByHash(ctx context.Context, hash types.Hash) (*types.SignedHeader, error) {
+	height, err := s.getHeightByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load height from index: %w", err)
+	}
+	return s.GetHeader(ctx, height)
+}
+
+func (s *DefaultStore) getData(ctx context.Context, height uint64) (*types.Data, error) {
+	return s.readData(ctx, s.db, height)
+}
 
-	dataBlob, err := s.db.Get(ctx, ds.NewKey(getDataKey(height)))
+func (s *DefaultStore) readData(ctx context.Context, r ds.Read, height uint64) (*types.Data, error) {
+	dataBlob, err := r.Get(ctx, ds.NewKey(getDataKey(height)))
+	if errors.Is(err, ds.ErrNotFound) {
+		if pruned, _ := r.Has(ctx, ds.NewKey(getTxHashesKey(height))); pruned {
+			return nil, fmt.Errorf("%w: height %d", ErrBlockDataPruned, height)
+		}
+	}
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load block data: %w", err)
+		return nil, fmt.Errorf("failed to load block data: %w", s.rangeError(ctx, height, err))
 	}
 	data := new(types.Data)
 	err = data.UnmarshalBinary(dataBlob)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to unmarshal block data: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal block data: %w", err)
+	}
+	return data, nil
+}
+
+// PruneBlockData removes data of block at given height. Hashes of transactions (computed with types.TxHash) are
+// kept, so that entries of the transaction index can be removed with the block and inclusion proofs of transactions
+// can still be created, see GetTxHashes.
+func (s *DefaultStore) PruneBlockData(ctx context.Context, height uint64) error {
+	data, err := s.getData(ctx, height)
+	if errors.Is(err, ErrBlockDataPruned) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	hashes := make([]byte, 0, len(data.Txs)*txHashSize)
+	for i, tx := range data.Txs {
+		hash := types.TxHash(tx)
+		if len(hash) != txHashSize {
+			return fmt.Errorf("invalid length of hash of transaction %d: %d, expected %d", i, len(hash), txHashSize)
+		}
+		hashes = append(hashes, hash...)
+	}
+
+	bb, err := s.db.NewTransaction(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
+	}
+	defer bb.Discard(ctx)
+	if err := bb.Put(ctx, ds.NewKey(getTxHashesKey(height)), hashes); err != nil {
+		return fmt.Errorf("failed to save transaction hashes: %w", err)
+	}
+	if err := bb.Delete(ctx, ds.NewKey(getDataKey(height))); err != nil {
+		return fmt.Errorf("failed to delete block data: %w", err)
+	}
+	return bb.Commit(ctx)
+}
+
+// Prune removes blocks at heights from from to to (inclusive), along with their signatures, extended commits,
+// responses, state records and hash index entries. Latest state is kept. Headers, data and hashes of transactions
+// of the range, needed to remove index entries, are read with prefix queries before blocks are removed, as not every
+// datastore supports writes during iteration. Each height is removed in a separate transaction, so that pruning of
+// a long range doesn't exceed transaction limits of the datastore; pruning heights which were already pruned is
+// harmless.
+func (s *DefaultStore) Prune(ctx context.Context, from, to uint64) error {
+	if from > to {
+		return nil
+	}
+	records := make([]blockRecords, to-from+1)
+	for _, field := range []struct {
+		prefix string
+		value  func(*blockRecords) *[]byte
+	}{
+		{headerPrefix, func(r *blockRecords) *[]byte { return &r.header }},
+		{dataPrefix, func(r *blockRecords) *[]byte { return &r.data }},
+		{txHashesPrefix, func(r *blockRecords) *[]byte { return &r.txHashes }},
+	} {
+		err := s.queryRange(ctx, field.prefix, from, to, func(height uint64, value []byte) {
+			*field.value(&records[height-from]) = value
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, r := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		height := from + uint64(i)
+		if err := s.pruneHeight(ctx, height, r); err != nil {
+			return fmt.Errorf("failed to prune height %d: %w", height, err)
+		}
+	}
+	return nil
+}
+
+// queryRange calls fn with height and value of each record with given prefix from from to to (inclusive), in order
+// of heights.
+func (s *DefaultStore) queryRange(ctx context.Context, prefix string, from, to uint64, fn func(height uint64, value []byte)) error {
+	records, err := queryHeightRecords(ctx, s.db, prefix, from, false)
+	if err != nil {
+		return err
+	}
+	defer records.close() //nolint:errcheck
+	for {
+		if err := records.next(); err != nil {
+			return err
+		}
+		if !records.ok || records.height > to {
+			return nil
+		}
+		fn(records.height, records.value)
 	}
-	return header, data, nil
+}
+
+// Rollback removes blocks above given height, along with their signatures, extended commits, responses, state
+// records, DA inclusion certificates and index entries, and rewrites saved State to the state after applying block
+// at given height, like `tendermint rollback --hard`. Blocks are removed before State is rewritten, so that an
+// interrupted rollback can be repeated. Validators and DA height of the State are kept.
+//
+// Rollback must not be used while the node is running, and the store should be closed afterwards, so that changes
+// are saved. Application state has to be rolled back separately.
+func (s *DefaultStore) Rollback(ctx context.Context, height uint64) error {
+	state, err := s.GetState(ctx)
+	if err != nil {
+		return err
+	}
+	if height == 0 || height >= state.LastBlockHeight {
+		return fmt.Errorf("invalid rollback height %d, must be between 1 and %d", height, state.LastBlockHeight-1)
+	}
+	// blocks may be saved above state height, e.g. when node stopped before applying them
+	top := max(state.LastBlockHeight, s.Height())
+	state, err = s.stateAt(ctx, state, height)
+	if err != nil {
+		return err
+	}
+
+	for h := top; h > height; h-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		records, err := s.readBlockRecords(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to remove height %d: %w", h, err)
+		}
+		if err := s.pruneHeight(ctx, h, records); err != nil {
+			return fmt.Errorf("failed to remove height %d: %w", h, err)
+		}
+	}
+
+	if err := s.UpdateState(ctx, state); err != nil {
+		return fmt.Errorf("failed to rewrite state: %w", err)
+	}
+	s.height.Store(height)
+	return nil
+}
+
+// stateAt returns given state as it was after applying block at given height, using the record of the state and
+// the header of the block. Validators and DA height of the state are kept.
+func (s *DefaultStore) stateAt(ctx context.Context, state types.State, height uint64) (types.State, error) {
+	record, err := s.GetStateRecord(ctx, height)
+	if err != nil {
+		return types.State{}, err
+	}
+	header, err := s.GetHeader(ctx, height)
+	if err != nil {
+		return types.State{}, err
+	}
+	state.LastBlockHeight = height
+	state.LastBlockTime = header.Time()
+	state.LastBlockID = cmtypes.BlockID{Hash: cmbytes.HexBytes(header.Hash())}
+	state.AppHash = record.AppHash
+	state.LastResultsHash = record.LastResultsHash
+	state.LastHeightConsensusParamsChanged = record.LastHeightConsensusParamsChanged
+	state.Version.Consensus.App = record.AppVersion
+	return state, nil
+}
+
+// blockRecords are encoded records of a block needed to remove its index entries, nil if they are missing.
+type blockRecords struct {
+	header   []byte
+	data     []byte
+	txHashes []byte
+}
+
+// readBlockRecords reads records of block at given height needed to remove its index entries.
+func (s *DefaultStore) readBlockRecords(ctx context.Context, height uint64) (blockRecords, error) {
+	var records blockRecords
+	for key, value := range map[string]*[]byte{
+		getHeaderKey(height):   &records.header,
+		getDataKey(height):     &records.data,
+		getTxHashesKey(height): &records.txHashes,
+	} {
+		blob, err := s.db.Get(ctx, ds.NewKey(key))
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return records, err
+		}
+		*value = blob
+	}
+	return records, nil
+}
+
+// pruneHeight removes all records of block at given height, and index entries of the block found in its records.
+func (s *DefaultStore) pruneHeight(ctx context.Context, height uint64, records blockRecords) error {
+	keys := []string{
+		getHeaderKey(height),
+		getDataKey(height),
+		getTxHashesKey(height),
+		getSignatureKey(height),
+		getExtendedCommitKey(height),
+		getResponsesKey(height),
+		getStateRecordKey(height),
+		getBlockTimeKey(height),
+		getDACertificateKey(height),
+	}
+	// hash index entry can be removed only while the header is available
+	if records.header != nil {
+		header := new(types.SignedHeader)
+		if err := header.UnmarshalBinary(records.header); err != nil {
+			return fmt.Errorf("failed to unmarshal block header: %w", err)
+		}
+		keys = append(keys, getIndexKey(header.Hash()))
+	}
+	// transaction index entries can be removed only while hashes of transactions are known
+	switch {
+	case records.data != nil:
+		data := new(types.Data)
+		if err := data.UnmarshalBinary(records.data); err != nil {
+			return fmt.Errorf("failed to unmarshal block data: %w", err)
+		}
+		for _, tx := range data.Txs {
+			keys = append(keys, getTxIndexKey(types.TxHash(tx)))
+		}
+	case records.txHashes != nil:
+		hashes, err := decodeTxHashes(records.txHashes)
+		if err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			keys = append(keys, getTxIndexKey(hash))
+		}
+	}
+
+	bb, err := s.db.NewTransaction(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
+	}
+	defer bb.Discard(ctx)
+	for _, key := range keys {
+		if err := bb.Delete(ctx, ds.NewKey(key)); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+	return bb.Commit(ctx)
+}
+
+// GetTxHashes returns hashes of transactions (computed with types.TxHash) of block at given height, in order.
+// They are available also after data of the block was pruned.
+func (s *DefaultStore) GetTxHashes(ctx context.Context, height uint64) ([][]byte, error) {
+	data, err := s.getData(ctx, height)
+	if err == nil {
+		hashes := make([][]byte, len(data.Txs))
+		for i, tx := range data.Txs {
+			hashes[i] = types.TxHash(tx)
+		}
+		return hashes, nil
+	}
+	if !errors.Is(err, ErrBlockDataPruned) {
+		return nil, err
+	}
+	blob, err := s.db.Get(ctx, ds.NewKey(getTxHashesKey(height)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction hashes: %w", err)
+	}
+	return decodeTxHashes(blob)
+}
+
+// txHashSize is the size of hashes of transactions saved when data of a block is pruned. Both built-in hash
+// functions of transactions return 32 bytes.
+const txHashSize = 32
+
+// decodeTxHashes splits hashes of transactions saved when data of a block is pruned.
+func decodeTxHashes(blob []byte) ([][]byte, error) {
+	if len(blob)%txHashSize != 0 {
+		return nil, fmt.Errorf("invalid length of transaction hashes: %d", len(blob))
+	}
+	hashes := make([][]byte, 0, len(blob)/txHashSize)
+	for len(blob) > 0 {
+		hashes = append(hashes, bytes.Clone(blob[:txHashSize]))
+		blob = blob[txHashSize:]
+	}
+	return hashes, nil
+}
+
+// LoadTxByHash returns height of the block including transaction with given hash (computed with types.TxHash)
+// and index of the transaction in the block, or error if it's not found in Store.
+func (s *DefaultStore) LoadTxByHash(ctx context.Context, hash []byte) (uint64, uint32, error) {
+	blob, err := s.db.Get(ctx, ds.NewKey(getTxIndexKey(hash)))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load transaction %X from index: %w", hash, err)
+	}
+	height, index, err := decodeTxLocation(blob)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to decode transaction location: %w", err)
+	}
+	// entries of blocks which data was pruned before removal of the block can't always be removed, see Prune
+	if height < s.earliestHeight(ctx) {
+		return 0, 0, fmt.Errorf("transaction %X not found: %w", hash, ds.ErrNotFound)
+	}
+	return height, index, nil
 }
 
 // GetBlockByHash returns block with given block header hash, or error if it's not found in Store.
@@ -156,10 +625,11 @@ func (s *DefaultStore) getHeightByHash(ctx context.Context, hash types.Hash) (ui
 
 // SaveBlockResponses saves block responses (events, tx responses, validator set updates, etc) in Store.
 func (s *DefaultStore) SaveBlockResponses(ctx context.Context, height uint64, responses *abci.ResponseFinalizeBlock) error {
-	data, err := responses.Marshal()
+	data, release, err := types.MarshalPooled(responses)
 	if err != nil {
 		return fmt.Errorf("failed to marshal response: %w", err)
 	}
+	defer release()
 	return s.db.Put(ctx, ds.NewKey(getResponsesKey(height)), data)
 }
 
@@ -167,7 +637,7 @@ func (s *DefaultStore) SaveBlockResponses(ctx context.Context, height uint64, re
 func (s *DefaultStore) GetBlockResponses(ctx context.Context, height uint64) (*abci.ResponseFinalizeBlock, error) {
 	data, err := s.db.Get(ctx, ds.NewKey(getResponsesKey(height)))
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve block results from height %v: %w", height, err)
+		return nil, fmt.Errorf("failed to retrieve block results from height %v: %w", height, s.rangeError(ctx, height, err))
 	}
 	var responses abci.ResponseFinalizeBlock
 	err = responses.Unmarshal(data)
@@ -177,6 +647,14 @@ func (s *DefaultStore) GetBlockResponses(ctx context.Context, height uint64) (*a
 	return &responses, nil
 }
 
+// DeleteBlockResponses removes block responses at given height, e.g. when events are pruned.
+func (s *DefaultStore) DeleteBlockResponses(ctx context.Context, height uint64) error {
+	if err := s.db.Delete(ctx, ds.NewKey(getResponsesKey(height))); err != nil {
+		return fmt.Errorf("failed to delete block results at height %v: %w", height, err)
+	}
+	return nil
+}
+
 // GetSignatureByHash returns signature for a block at given height, or error if it's not found in Store.
 func (s *DefaultStore) GetSignatureByHash(ctx context.Context, hash types.Hash) (*types.Signature, error) {
 	height, err := s.getHeightByHash(ctx, hash)
@@ -187,13 +665,21 @@ func (s *DefaultStore) GetSignatureByHash(ctx context.Context, hash types.Hash)
 }
 
 // GetSignature returns signature for a block with given block header hash, or error if it's not found in Store.
+//
+// Concurrent calls for the same height are coalesced and share the result, so returned signature must not be modified.
 func (s *DefaultStore) GetSignature(ctx context.Context, height uint64) (*types.Signature, error) {
-	signatureData, err := s.db.Get(ctx, ds.NewKey(getSignatureKey(height)))
+	v, err, _ := s.reads.Do(getSignatureKey(height), func() (interface{}, error) {
+		signatureData, err := s.db.Get(ctx, ds.NewKey(getSignatureKey(height)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve signature from height %v: %w", height, s.rangeError(ctx, height, err))
+		}
+		signature := types.Signature(signatureData)
+		return &signature, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve signature from height %v: %w", height, err)
+		return nil, err
 	}
-	signature := types.Signature(signatureData)
-	return &signature, nil
+	return v.(*types.Signature), nil
 }
 
 // SaveExtendedCommit saves extended commit information in Store.
@@ -221,16 +707,79 @@ func (s *DefaultStore) GetExtendedCommit(ctx context.Context, height uint64) (*a
 
 // UpdateState updates state saved in Store. Only one State is stored.
 // If there is no State in Store, state will be saved.
+// Record of the state is saved for state height, see GetStateRecord.
+// Datastore is synced to disk according to sync interval, see WithSyncInterval.
 func (s *DefaultStore) UpdateState(ctx context.Context, state types.State) error {
 	pbState, err := state.ToProto()
 	if err != nil {
 		return fmt.Errorf("failed to marshal state to JSON: %w", err)
 	}
-	data, err := pbState.Marshal()
+	data, release, err := types.MarshalPooled(pbState)
+	if err != nil {
+		return err
+	}
+	defer release()
+	record, err := types.NewStateRecord(state).MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal state record: %w", err)
+	}
+
+	bb, err := s.db.NewTransaction(ctx, false)
 	if err != nil {
+		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
+	}
+	defer bb.Discard(ctx)
+
+	if err = bb.Put(ctx, ds.NewKey(getStateKey()), data); err != nil {
+		return err
+	}
+	if err = bb.Put(ctx, ds.NewKey(getStateRecordKey(state.LastBlockHeight)), record); err != nil {
 		return err
 	}
-	return s.db.Put(ctx, ds.NewKey(getStateKey()), data)
+	if err = bb.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if s.syncInterval > 0 && state.LastBlockHeight%s.syncInterval == 0 {
+		if err := s.db.Sync(ctx, ds.NewKey("/")); err != nil {
+			return fmt.Errorf("failed to sync datastore: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetStateRecord returns record of the state after applying block at given height.
+func (s *DefaultStore) GetStateRecord(ctx context.Context, height uint64) (*types.StateRecord, error) {
+	blob, err := s.db.Get(ctx, ds.NewKey(getStateRecordKey(height)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve state record: %w", s.rangeError(ctx, height, err))
+	}
+	record := new(types.StateRecord)
+	if err := record.UnmarshalBinary(blob); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state record: %w", err)
+	}
+	return record, nil
+}
+
+// SaveDAInclusionCertificate saves certificate of inclusion of the block at cert.Height in DA.
+func (s *DefaultStore) SaveDAInclusionCertificate(ctx context.Context, cert *types.DAInclusionCertificate) error {
+	blob, err := cert.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal DA inclusion certificate: %w", err)
+	}
+	return s.db.Put(ctx, ds.NewKey(getDACertificateKey(cert.Height)), blob)
+}
+
+// GetDAInclusionCertificate returns certificate of inclusion of the block at given height in DA.
+func (s *DefaultStore) GetDAInclusionCertificate(ctx context.Context, height uint64) (*types.DAInclusionCertificate, error) {
+	blob, err := s.db.Get(ctx, ds.NewKey(getDACertificateKey(height)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DA inclusion certificate: %w", s.rangeError(ctx, height, err))
+	}
+	cert := new(types.DAInclusionCertificate)
+	if err := cert.UnmarshalBinary(blob); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal DA inclusion certificate: %w", err)
+	}
+	return cert, nil
 }
 
 // GetState returns last state saved with UpdateState.
@@ -239,15 +788,12 @@ func (s *DefaultStore) GetState(ctx context.Context) (types.State, error) {
 	if err != nil {
 		return types.State{}, fmt.Errorf("failed to retrieve state: %w", err)
 	}
-	var pbState pb.State
-	err = pbState.Unmarshal(blob)
+	var state types.State
+	err = state.UnmarshalBinary(blob)
 	if err != nil {
-		return types.State{}, fmt.Errorf("failed to unmarshal state from JSON: %w", err)
+		return types.State{}, fmt.Errorf("failed to unmarshal state: %w", err)
 	}
-
-	var state types.State
-	err = state.FromProto(&pbState)
-	return state, err
+	return state, nil
 }
 
 // SetMetadata saves arbitrary value in the store.
@@ -271,27 +817,46 @@ func (s *DefaultStore) GetMetadata(ctx context.Context, key string) ([]byte, err
 }
 
 func getHeaderKey(height uint64) string {
-	return GenerateKey([]string{headerPrefix, strconv.FormatUint(height, 10)})
+	return GenerateKey([]string{headerPrefix, encodeHeightKey(height)})
 }
 
 func getDataKey(height uint64) string {
-	return GenerateKey([]string{dataPrefix, strconv.FormatUint(height, 10)})
+	return GenerateKey([]string{dataPrefix, encodeHeightKey(height)})
+}
+
+func getTxHashesKey(height uint64) string {
+	return GenerateKey([]string{txHashesPrefix, encodeHeightKey(height)})
 }
 
 func getSignatureKey(height uint64) string {
-	return GenerateKey([]string{signaturePrefix, strconv.FormatUint(height, 10)})
+	return GenerateKey([]string{signaturePrefix, encodeHeightKey(height)})
 }
 
 func getExtendedCommitKey(height uint64) string {
-	return GenerateKey([]string{extendedCommitPrefix, strconv.FormatUint(height, 10)})
+	return GenerateKey([]string{extendedCommitPrefix, encodeHeightKey(height)})
 }
 
 func getStateKey() string {
 	return statePrefix
 }
 
+func getStateRecordKey(height uint64) string {
+	return GenerateKey([]string{stateRecordPrefix, encodeHeightKey(height)})
+}
+
+func getDACertificateKey(height uint64) string {
+	return GenerateKey([]string{daCertificatePrefix, encodeHeightKey(height)})
+}
+
 func getResponsesKey(height uint64) string {
-	return GenerateKey([]string{responsesPrefix, strconv.FormatUint(height, 10)})
+	return GenerateKey([]string{responsesPrefix, encodeHeightKey(height)})
+}
+
+// encodeHeightKey encodes height as the last field of keys of records stored by height: hex encoded fixed-width
+// big-endian height, so that prefix scans return records in order of heights. Raw bytes are not used, as keys are
+// paths and they could contain separators.
+func encodeHeightKey(height uint64) string {
+	return hex.EncodeToString(encodeHeight(height))
 }
 
 func getMetaKey(key string) string {
@@ -302,14 +867,33 @@ func getIndexKey(hash types.Hash) string {
 	return GenerateKey([]string{indexPrefix, hash.String()})
 }
 
+func getTxIndexKey(hash []byte) string {
+	return GenerateKey([]string{txIndexPrefix, hex.EncodeToString(hash)})
+}
+
 const heightLength = 8
 
+// heightKeyLength is the length of heights encoded in keys, see encodeHeightKey.
+const heightKeyLength = 2 * heightLength
+
 func encodeHeight(height uint64) []byte {
 	heightBytes := make([]byte, heightLength)
 	binary.BigEndian.PutUint64(heightBytes, height)
 	return heightBytes
 }
 
+// encodeTxLocation encodes height of a block and index of a transaction in the block.
+func encodeTxLocation(height uint64, index uint32) []byte {
+	return binary.BigEndian.AppendUint32(encodeHeight(height), index)
+}
+
+func decodeTxLocation(b []byte) (uint64, uint32, error) {
+	if len(b) != heightLength+4 {
+		return 0, 0, fmt.Errorf("invalid transaction location length: %d (expected %d)", len(b), heightLength+4)
+	}
+	return binary.BigEndian.Uint64(b), binary.BigEndian.Uint32(b[heightLength:]), nil
+}
+
 func decodeHeight(heightBytes []byte) (uint64, error) {
 	if len(heightBytes) != heightLength {
 		return 0, fmt.Errorf("invalid height length: %d (expected %d)", len(heightBytes), heightLength)
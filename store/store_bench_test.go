@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+const benchmarkTxs = 1000
+
+func BenchmarkSaveBlockData(b *testing.B) {
+	kv, err := NewDefaultInMemoryKVStore()
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := New(kv)
+	header, data := types.GetRandomBlock(1, benchmarkTxs, "BenchmarkSaveBlockData")
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := s.SaveBlockData(ctx, header, data, &header.Signature); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetBlockData(b *testing.B) {
+	kv, err := NewDefaultInMemoryKVStore()
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := New(kv)
+	header, data := types.GetRandomBlock(1, benchmarkTxs, "BenchmarkGetBlockData")
+	ctx := context.Background()
+	if err := s.SaveBlockData(ctx, header, data, &header.Signature); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := s.GetBlockData(ctx, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLoadBlockRange compares reading a range of blocks with one Get per key and with LoadBlockRange.
+func BenchmarkLoadBlockRange(b *testing.B) {
+	const blocks = 100
+	kv, err := NewDefaultInMemoryKVStore()
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := New(kv)
+	ctx := context.Background()
+	for h := uint64(1); h <= blocks; h++ {
+		header, data := types.GetRandomBlock(h, 10, "BenchmarkLoadBlockRange")
+		if err := s.SaveBlockData(ctx, header, data, &header.Signature); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("GetBlockData", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for h := uint64(1); h <= blocks; h++ {
+				if _, _, err := s.GetBlockData(ctx, h); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("LoadBlockRange", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := s.LoadBlockRange(ctx, 1, blocks); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetBlockByHash(b *testing.B) {
+	kv, err := NewDefaultInMemoryKVStore()
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := New(kv)
+	header, data := types.GetRandomBlock(1, benchmarkTxs, "BenchmarkGetBlockByHash")
+	ctx := context.Background()
+	if err := s.SaveBlockData(ctx, header, data, &header.Signature); err != nil {
+		b.Fatal(err)
+	}
+	hash := header.Hash()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := s.GetBlockByHash(ctx, hash); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSaveBlockResponses(b *testing.B) {
+	kv, err := NewDefaultInMemoryKVStore()
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := New(kv)
+	responses := &abcitypes.ResponseFinalizeBlock{AppHash: types.GetRandomBytes(32)}
+	for i := 0; i < benchmarkTxs; i++ {
+		responses.TxResults = append(responses.TxResults, &abcitypes.ExecTxResult{
+			Data:   types.GetRandomBytes(32),
+			Events: []abcitypes.Event{{Type: "transfer", Attributes: []abcitypes.EventAttribute{{Key: "amount", Value: "100", Index: true}}}},
+		})
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := s.SaveBlockResponses(ctx, 1, responses); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
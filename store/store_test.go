@@ -3,7 +3,10 @@ package store
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	abcitypes "github.com/cometbft/cometbft/abci/types"
 	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -164,6 +167,163 @@ func TestRestart(t *testing.T) {
 	assert.Equal(expectedHeight, state2.LastBlockHeight)
 }
 
+func TestStateRecords(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx := context.Background()
+	validatorSet := types.GetRandomValidatorSet()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	_, err = s.GetStateRecord(ctx, 1)
+	require.ErrorIs(err, ds.ErrNotFound)
+
+	for h := uint64(1); h <= 3; h++ {
+		state := types.State{
+			LastBlockHeight:                  h,
+			AppHash:                          []byte(fmt.Sprintf("app hash %d", h)),
+			LastResultsHash:                  []byte(fmt.Sprintf("results hash %d", h)),
+			LastHeightConsensusParamsChanged: 1,
+			NextValidators:                   validatorSet,
+			Validators:                       validatorSet,
+			LastValidators:                   validatorSet,
+		}
+		state.Version.Consensus.App = 2
+		require.NoError(s.UpdateState(ctx, state))
+	}
+
+	// records are kept for all heights, only the latest state is stored
+	for h := uint64(1); h <= 3; h++ {
+		record, err := s.GetStateRecord(ctx, h)
+		require.NoError(err)
+		require.Equal(&types.StateRecord{
+			Height:                           h,
+			AppHash:                          []byte(fmt.Sprintf("app hash %d", h)),
+			LastResultsHash:                  []byte(fmt.Sprintf("results hash %d", h)),
+			LastHeightConsensusParamsChanged: 1,
+			AppVersion:                       2,
+		}, record)
+	}
+	state, err := s.GetState(ctx)
+	require.NoError(err)
+	require.Equal(uint64(3), state.LastBlockHeight)
+
+	_, err = s.GetStateRecord(ctx, 4)
+	require.ErrorIs(err, ds.ErrNotFound)
+}
+
+func TestDAInclusionCertificates(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	_, err = s.GetDAInclusionCertificate(ctx, 1)
+	require.ErrorIs(err, ds.ErrNotFound)
+
+	for h := uint64(1); h <= 2; h++ {
+		header, data := types.GetRandomBlock(h, 0, "TestDAInclusionCertificates")
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		s.SetHeight(ctx, h)
+		require.NoError(s.SaveDAInclusionCertificate(ctx, &types.DAInclusionCertificate{
+			Height:     h,
+			DAHeight:   h + 10,
+			ID:         []byte(fmt.Sprintf("id %d", h)),
+			Commitment: []byte("commitment"),
+			Proof:      []byte("proof"),
+		}))
+	}
+	cert, err := s.GetDAInclusionCertificate(ctx, 2)
+	require.NoError(err)
+	require.Equal(uint64(12), cert.DAHeight)
+	require.Equal([]byte("id 2"), []byte(cert.ID))
+
+	// certificates are pruned with blocks
+	require.NoError(s.Prune(ctx, 1, 1))
+	_, err = s.GetDAInclusionCertificate(ctx, 1)
+	require.ErrorIs(err, ds.ErrNotFound)
+	_, err = s.GetDAInclusionCertificate(ctx, 2)
+	require.NoError(err)
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx := context.Background()
+	validatorSet := types.GetRandomValidatorSet()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	var headers []*types.SignedHeader
+	var datas []*types.Data
+	for h := uint64(1); h <= 4; h++ {
+		header, data := types.GetRandomBlock(h, 2, "TestRollback")
+		headers = append(headers, header)
+		datas = append(datas, data)
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		require.NoError(s.SaveDAInclusionCertificate(ctx, &types.DAInclusionCertificate{Height: h, DAHeight: h}))
+		s.SetHeight(ctx, h)
+		// block 4 is saved, but not applied
+		if h == 4 {
+			break
+		}
+		state := types.State{
+			ChainID:         "TestRollback",
+			LastBlockHeight: h,
+			LastBlockTime:   header.Time(),
+			AppHash:         []byte(fmt.Sprintf("app hash %d", h)),
+			LastResultsHash: []byte(fmt.Sprintf("results hash %d", h)),
+			DAHeight:        10,
+			NextValidators:  validatorSet,
+			Validators:      validatorSet,
+			LastValidators:  validatorSet,
+		}
+		require.NoError(s.UpdateState(ctx, state))
+	}
+
+	require.Error(s.Rollback(ctx, 0))
+	require.Error(s.Rollback(ctx, 3))
+
+	require.NoError(s.Rollback(ctx, 1))
+	require.Equal(uint64(1), s.Height())
+	state, err := s.GetState(ctx)
+	require.NoError(err)
+	require.Equal(uint64(1), state.LastBlockHeight)
+	require.True(headers[0].Time().Equal(state.LastBlockTime))
+	require.Equal([]byte(headers[0].Hash()), []byte(state.LastBlockID.Hash))
+	require.Equal(types.Hash("app hash 1"), state.AppHash)
+	require.Equal(types.Hash("results hash 1"), state.LastResultsHash)
+	require.Equal(uint64(10), state.DAHeight)
+	require.Equal("TestRollback", state.ChainID)
+
+	_, _, err = s.GetBlockData(ctx, 1)
+	require.NoError(err)
+	for h := uint64(2); h <= 4; h++ {
+		_, _, err = s.GetBlockData(ctx, h)
+		require.ErrorIs(err, ds.ErrNotFound)
+		_, err = s.GetHeaderByHash(ctx, headers[h-1].Hash())
+		require.ErrorIs(err, ds.ErrNotFound)
+		_, _, err = s.LoadTxByHash(ctx, types.TxHash(datas[h-1].Txs[0]))
+		require.ErrorIs(err, ds.ErrNotFound)
+		_, err = s.GetStateRecord(ctx, h)
+		require.ErrorIs(err, ds.ErrNotFound)
+		_, err = s.GetDAInclusionCertificate(ctx, h)
+		require.ErrorIs(err, ds.ErrNotFound)
+	}
+	_, _, err = s.LoadTxByHash(ctx, types.TxHash(datas[0].Txs[0]))
+	require.NoError(err)
+
+	// there is nothing to roll back to below height 1
+	require.Error(s.Rollback(ctx, 1))
+}
+
 func TestBlockResponses(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -237,6 +397,20 @@ func TestMetadata(t *testing.T) {
 	v, err := s.GetMetadata(ctx, "unused key")
 	require.Error(err)
 	require.Nil(v)
+
+	// metadata saved with block
+	header, data := types.GetRandomBlock(1, 2, "TestMetadata")
+	require.NoError(s.SaveBlockDataWithMetadata(ctx, header, data, &header.Signature, map[string][]byte{
+		getKey(0):   getValue(n),
+		"block key": getValue(n + 1),
+	}))
+	_, _, err = s.GetBlockData(ctx, 1)
+	require.NoError(err)
+	for key, expected := range map[string][]byte{getKey(0): getValue(n), "block key": getValue(n + 1), getKey(1): getValue(1)} {
+		value, err := s.GetMetadata(ctx, key)
+		require.NoError(err)
+		require.Equal(expected, value)
+	}
 }
 
 func TestExtendedCommits(t *testing.T) {
@@ -276,3 +450,260 @@ func TestExtendedCommits(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expected, commit)
 }
+
+// gatedKV delays reads until gate is closed and counts them.
+type gatedKV struct {
+	ds.TxnDatastore
+	gate  chan struct{}
+	reads atomic.Int32
+}
+
+func (kv *gatedKV) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	kv.reads.Add(1)
+	<-kv.gate
+	return kv.TxnDatastore.Get(ctx, key)
+}
+
+func TestCoalescedReads(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	base, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	kv := &gatedKV{TxnDatastore: base, gate: make(chan struct{})}
+	s := New(kv)
+	header, data := types.GetRandomBlock(1, 2, "TestCoalescedReads")
+	require.NoError(s.SaveBlockData(ctx, header, data, &header.Signature))
+
+	const readers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			h, d, err := s.GetBlockData(ctx, 1)
+			assert.NoError(err)
+			assert.Equal(header.Hash(), h.Hash())
+			assert.Equal(data.Hash(), d.Hash())
+		}()
+		go func() {
+			defer wg.Done()
+			signature, err := s.GetSignature(ctx, 1)
+			assert.NoError(err)
+			assert.Equal(header.Signature, *signature)
+		}()
+	}
+	// let all readers join in-flight reads
+	time.Sleep(50 * time.Millisecond)
+	close(kv.gate)
+	wg.Wait()
+
+	// header, data and signature are read once
+	assert.Equal(int32(3), kv.reads.Load())
+}
+
+// syncCountingKV counts syncs of the datastore. In-memory datastore can't be synced, so syncs are not forwarded.
+type syncCountingKV struct {
+	ds.TxnDatastore
+	syncs atomic.Int32
+}
+
+func (kv *syncCountingKV) Sync(_ context.Context, _ ds.Key) error {
+	kv.syncs.Add(1)
+	return nil
+}
+
+func TestSyncInterval(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	validatorSet := types.GetRandomValidatorSet()
+
+	cases := []struct {
+		name     string
+		interval uint64
+		syncs    int32
+	}{
+		{"every block", 1, 10},
+		{"every 4 blocks", 4, 2},
+		{"async", 0, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require := require.New(t)
+			base, err := NewDefaultInMemoryKVStore()
+			require.NoError(err)
+			kv := &syncCountingKV{TxnDatastore: base}
+			s := New(kv, WithSyncInterval(c.interval))
+			for h := uint64(1); h <= 10; h++ {
+				require.NoError(s.UpdateState(ctx, types.State{
+					LastBlockHeight: h,
+					NextValidators:  validatorSet,
+					Validators:      validatorSet,
+					LastValidators:  validatorSet,
+				}))
+			}
+			require.Equal(c.syncs, kv.syncs.Load())
+		})
+	}
+}
+
+func TestHeightRange(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kv, _ := NewDefaultInMemoryKVStore()
+	s := New(kv)
+
+	_, err := s.GetHeader(ctx, 1)
+	var rangeErr *HeightRangeError
+	require.ErrorAs(err, &rangeErr)
+	require.Contains(err.Error(), "there are no blocks yet")
+
+	validatorSet := types.GetRandomValidatorSet()
+	require.NoError(s.UpdateState(ctx, types.State{
+		InitialHeight:   5,
+		LastBlockHeight: 6,
+		NextValidators:  validatorSet,
+		Validators:      validatorSet,
+		LastValidators:  validatorSet,
+	}))
+	for h := uint64(5); h <= 6; h++ {
+		header, data := types.GetRandomBlock(h, 0, "TestHeightRange")
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		s.SetHeight(ctx, h)
+	}
+
+	for _, height := range []uint64{0, 4, 7} {
+		_, _, err := s.GetBlockData(ctx, height)
+		require.ErrorIs(err, ErrHeightOutOfRange, "height %d", height)
+		require.ErrorIs(err, ds.ErrNotFound, "height %d", height)
+		require.ErrorAs(err, &rangeErr)
+		require.Equal(HeightRangeError{Height: int64(height), Earliest: 5, Latest: 6, Err: ds.ErrNotFound}, *rangeErr)
+
+		_, err = s.GetSignature(ctx, height)
+		require.ErrorIs(err, ErrHeightOutOfRange, "height %d", height)
+		_, err = s.GetBlockResponses(ctx, height)
+		require.ErrorIs(err, ErrHeightOutOfRange, "height %d", height)
+	}
+
+	// height in range without results is just not found
+	_, err = s.GetBlockResponses(ctx, 5)
+	require.ErrorIs(err, ds.ErrNotFound)
+	require.NotErrorIs(err, ErrHeightOutOfRange)
+}
+
+func TestTxIndex(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kv, _ := NewDefaultInMemoryKVStore()
+	s := New(kv)
+
+	blocks := make(map[uint64]*types.Data)
+	for h := uint64(1); h <= 3; h++ {
+		header, data := types.GetRandomBlock(h, 2, "TestTxIndex")
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		s.SetHeight(ctx, h)
+		blocks[h] = data
+	}
+
+	for h, data := range blocks {
+		for i, tx := range data.Txs {
+			height, index, err := s.LoadTxByHash(ctx, types.TxHash(tx))
+			require.NoError(err)
+			require.Equal(h, height)
+			require.Equal(uint32(i), index) //nolint:gosec
+		}
+	}
+
+	_, _, err := s.LoadTxByHash(ctx, types.TxHash([]byte("unknown")))
+	require.ErrorIs(err, ds.ErrNotFound)
+
+	// entries are removed with blocks, also if data of the block was pruned earlier
+	require.NoError(s.PruneBlockData(ctx, 1))
+	require.NoError(s.Prune(ctx, 1, 2))
+	for h := uint64(1); h <= 2; h++ {
+		for _, tx := range blocks[h].Txs {
+			_, _, err := s.LoadTxByHash(ctx, types.TxHash(tx))
+			require.ErrorIs(err, ds.ErrNotFound)
+		}
+	}
+	_, _, err = s.LoadTxByHash(ctx, types.TxHash(blocks[3].Txs[0]))
+	require.NoError(err)
+}
+
+// TestTxIndexHasher is not parallel, as the hash function of transactions is global.
+func TestTxIndexHasher(t *testing.T) {
+	require := require.New(t)
+	defer types.SetTxHasher(nil)
+	require.NoError(types.SetTxHasherByName(types.TxHashKeccak256))
+
+	ctx := context.Background()
+	kv, _ := NewDefaultInMemoryKVStore()
+	s := New(kv)
+	header, data := types.GetRandomBlock(1, 2, "TestTxIndexHasher")
+	require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+	s.SetHeight(ctx, 1)
+
+	// hashes kept after data is pruned are computed with the configured hash function, like the index
+	require.NoError(s.PruneBlockData(ctx, 1))
+	hashes, err := s.GetTxHashes(ctx, 1)
+	require.NoError(err)
+	require.Len(hashes, len(data.Txs))
+	for i, tx := range data.Txs {
+		require.Equal(types.TxHash(tx), hashes[i])
+		has, err := kv.Has(ctx, ds.NewKey(getTxIndexKey(hashes[i])))
+		require.NoError(err)
+		require.True(has)
+	}
+
+	// so entries of the index are removed with the block
+	require.NoError(s.Prune(ctx, 1, 1))
+	for _, hash := range hashes {
+		has, err := kv.Has(ctx, ds.NewKey(getTxIndexKey(hash)))
+		require.NoError(err)
+		require.False(has)
+	}
+}
+
+func TestWaitForHeight(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kv, _ := NewDefaultInMemoryKVStore()
+	s := New(kv)
+	s.SetHeight(ctx, 2)
+	require.NoError(s.WaitForHeight(ctx, 2))
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer waitCancel()
+	require.ErrorIs(s.WaitForHeight(waitCtx, 3), context.DeadlineExceeded)
+
+	// concurrent writers only increase height, and every waiter is notified
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.WaitForHeight(ctx, 100)
+		}()
+	}
+	for h := uint64(100); h >= 3; h-- {
+		go s.SetHeight(ctx, h)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(err)
+	}
+	require.Equal(uint64(100), s.Height())
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+// blockTimeLength is the length of encoded time of a block, unix time in nanoseconds.
+const blockTimeLength = 8
+
+func getBlockTimeKey(height uint64) string {
+	return GenerateKey([]string{blockTimePrefix, encodeHeightKey(height)})
+}
+
+func encodeBlockTime(t uint64) []byte {
+	b := make([]byte, blockTimeLength)
+	binary.BigEndian.PutUint64(b, t)
+	return b
+}
+
+// blockTime returns time of block at given height as unix time in nanoseconds. Times of blocks saved before
+// the time index was added are read from their headers.
+func (s *DefaultStore) blockTime(ctx context.Context, height uint64) (uint64, error) {
+	blob, err := s.db.Get(ctx, ds.NewKey(getBlockTimeKey(height)))
+	if errors.Is(err, ds.ErrNotFound) {
+		header, err := s.GetHeader(ctx, height)
+		if err != nil {
+			return 0, err
+		}
+		return header.BaseHeader.Time, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load time of block %d: %w", height, err)
+	}
+	if len(blob) != blockTimeLength {
+		return 0, fmt.Errorf("invalid length of time of block %d: %d", height, len(blob))
+	}
+	return binary.BigEndian.Uint64(blob), nil
+}
+
+// LoadBlockByTime returns height of the latest block with time not after t. Times of blocks are monotonic, so
+// the height is found with binary search over the time index, reading O(log n) small entries instead of
+// scanning headers. It returns ds.ErrNotFound if there are no blocks at or before t.
+func (s *DefaultStore) LoadBlockByTime(ctx context.Context, t time.Time) (uint64, error) {
+	earliest, latest := s.earliestHeight(ctx), s.Height()
+	if latest < earliest || t.UnixNano() < 0 {
+		return 0, fmt.Errorf("no blocks at or before %s: %w", t, ds.ErrNotFound)
+	}
+	target := uint64(t.UnixNano())
+
+	// find the lowest height in [lo, hi) with time after target
+	lo, hi := earliest, latest+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		blockTime, err := s.blockTime(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if blockTime > target {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if lo == earliest {
+		return 0, fmt.Errorf("no blocks at or before %s: %w", t, ds.ErrNotFound)
+	}
+	return lo - 1, nil
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestLoadBlockByTime(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kv, _ := NewDefaultInMemoryKVStore()
+	s := New(kv)
+
+	_, err := s.LoadBlockByTime(ctx, time.Now())
+	require.ErrorIs(err, ds.ErrNotFound)
+
+	// blocks 1..10 every 10 seconds, blocks 4 and 5 have the same time
+	start := time.Unix(1700000000, 0)
+	blockTime := func(h uint64) time.Time {
+		if h == 5 {
+			h = 4
+		}
+		return start.Add(time.Duration(h) * 10 * time.Second)
+	}
+	for h := uint64(1); h <= 10; h++ {
+		header, data := types.GetRandomBlock(h, 0, "TestLoadBlockByTime")
+		header.BaseHeader.Time = uint64(blockTime(h).UnixNano()) //nolint:gosec
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		s.SetHeight(ctx, h)
+	}
+
+	cases := []struct {
+		time   time.Time
+		height uint64
+	}{
+		{blockTime(1), 1},
+		{blockTime(1).Add(time.Second), 1},
+		{blockTime(4), 5},
+		{blockTime(6).Add(-time.Nanosecond), 5},
+		{blockTime(10), 10},
+		{blockTime(10).Add(time.Hour), 10},
+	}
+	for _, c := range cases {
+		height, err := s.LoadBlockByTime(ctx, c.time)
+		require.NoError(err)
+		require.Equal(c.height, height, "time %s", c.time)
+	}
+
+	_, err = s.LoadBlockByTime(ctx, blockTime(1).Add(-time.Nanosecond))
+	require.ErrorIs(err, ds.ErrNotFound)
+
+	// times of blocks saved before the index was added are read from headers
+	for h := uint64(1); h <= 10; h++ {
+		require.NoError(kv.Delete(ctx, ds.NewKey(getBlockTimeKey(h))))
+	}
+	height, err := s.LoadBlockByTime(ctx, blockTime(7))
+	require.NoError(err)
+	require.Equal(uint64(7), height)
+}
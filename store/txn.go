@@ -0,0 +1,69 @@
+package store
+
+import (
+	"bytes"
+	"context"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+// NewTxnDatastore returns given datastore as ds.TxnDatastore. Datastores supporting transactions (e.g. badger) are
+// returned as they are. Other datastores (e.g. in-memory map datastore, leveldb or custom datastores) are wrapped,
+// so that writes of a transaction are applied atomically on commit with a batch of the datastore (ds.Batching), or
+// with a basic batch writing keys one by one if the datastore doesn't support batching. Reads of wrapped
+// transactions go directly to the datastore, so they are not isolated from concurrent writes.
+func NewTxnDatastore(d ds.Datastore) ds.TxnDatastore {
+	if txnDS, ok := d.(ds.TxnDatastore); ok {
+		return txnDS
+	}
+	return &batchTxnDatastore{Datastore: d}
+}
+
+// batchTxnDatastore implements transactions of a datastore which doesn't support them with batches.
+type batchTxnDatastore struct {
+	ds.Datastore
+}
+
+var _ ds.TxnDatastore = &batchTxnDatastore{}
+
+// Put writes the value of the key. Value is copied, like in transactions, see batchTxn.Put.
+func (d *batchTxnDatastore) Put(ctx context.Context, key ds.Key, value []byte) error {
+	return d.Datastore.Put(ctx, key, bytes.Clone(value))
+}
+
+// NewTransaction returns transaction writing to a batch of the datastore.
+func (d *batchTxnDatastore) NewTransaction(ctx context.Context, _ bool) (ds.Txn, error) {
+	if batching, ok := d.Datastore.(ds.Batching); ok {
+		batch, err := batching.Batch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &batchTxn{Read: d.Datastore, batch: batch}, nil
+	}
+	return &batchTxn{Read: d.Datastore, batch: ds.NewBasicBatch(d.Datastore)}, nil
+}
+
+// batchTxn is a transaction reading from the datastore and writing to a batch.
+type batchTxn struct {
+	ds.Read
+	batch ds.Batch
+}
+
+// Put adds write of the key to the batch. Value is copied, as datastores like map datastore keep the slice, and
+// written values might be pooled buffers reused after the transaction, see SaveBlockData.
+func (t *batchTxn) Put(ctx context.Context, key ds.Key, value []byte) error {
+	return t.batch.Put(ctx, key, bytes.Clone(value))
+}
+
+// Delete adds removal of the key to the batch.
+func (t *batchTxn) Delete(ctx context.Context, key ds.Key) error {
+	return t.batch.Delete(ctx, key)
+}
+
+// Commit writes the batch to the datastore.
+func (t *batchTxn) Commit(ctx context.Context) error {
+	return t.batch.Commit(ctx)
+}
+
+// Discard does nothing, batch is not written until it's committed.
+func (t *batchTxn) Discard(context.Context) {}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// plainDatastore hides optional features of the datastore, e.g. batching.
+type plainDatastore struct {
+	ds.Datastore
+}
+
+func TestNonTransactionalDatastores(t *testing.T) {
+	t.Parallel()
+
+	badger, err := NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	require.Same(t, badger, NewTxnDatastore(badger))
+
+	datastores := map[string]ds.Datastore{
+		"batching": dssync.MutexWrap(ds.NewMapDatastore()),
+		"plain":    plainDatastore{dssync.MutexWrap(ds.NewMapDatastore())},
+	}
+	for name, kv := range datastores {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+			s := New(kv)
+
+			var blocks []*types.Data
+			for h := uint64(1); h <= 3; h++ {
+				header, data := types.GetRandomBlock(h, 2, "TestNonTransactionalDatastores")
+				require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+				s.SetHeight(ctx, h)
+				blocks = append(blocks, data)
+			}
+
+			loaded, err := s.LoadBlockRange(ctx, 1, 3)
+			require.NoError(err)
+			require.Len(loaded, 3)
+			height, index, err := s.LoadTxByHash(ctx, types.TxHash(blocks[1].Txs[1]))
+			require.NoError(err)
+			require.Equal(uint64(2), height)
+			require.Equal(uint32(1), index)
+
+			require.NoError(s.Prune(ctx, 1, 1))
+			_, err = s.GetHeader(ctx, 1)
+			require.ErrorIs(err, ds.ErrNotFound)
+			_, _, err = s.LoadTxByHash(ctx, types.TxHash(blocks[0].Txs[0]))
+			require.ErrorIs(err, ds.ErrNotFound)
+		})
+	}
+}
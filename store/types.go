@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"io"
+	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 
@@ -16,13 +18,52 @@ type Store interface {
 	// SetHeight sets the height saved in the Store if it is higher than the existing height.
 	SetHeight(ctx context.Context, height uint64)
 
+	// WaitForHeight blocks until height of the Store is at least given height, or context is done.
+	WaitForHeight(ctx context.Context, height uint64) error
+
 	// SaveBlock saves block along with its seen signature (which will be included in the next block).
 	SaveBlockData(ctx context.Context, header *types.SignedHeader, data *types.Data, signature *types.Signature) error
+	// SaveBlockDataWithMetadata saves block like SaveBlockData, and metadata values (see SetMetadata) in the same
+	// transaction, so that checkpoints of subsystems are persisted atomically with the block.
+	SaveBlockDataWithMetadata(ctx context.Context, header *types.SignedHeader, data *types.Data, signature *types.Signature, metadata map[string][]byte) error
+	// SaveBlockDataWithResponses saves block like SaveBlockDataWithMetadata, and responses of its execution (see
+	// SaveBlockResponses) in the same transaction, so that responses of a block committed in the app are not lost.
+	SaveBlockDataWithResponses(ctx context.Context, header *types.SignedHeader, data *types.Data, signature *types.Signature, responses *abci.ResponseFinalizeBlock, metadata map[string][]byte) error
 
 	// GetBlock returns block at given height, or error if it's not found in Store.
 	GetBlockData(ctx context.Context, height uint64) (*types.SignedHeader, *types.Data, error)
 	// GetBlockByHash returns block with given block header hash, or error if it's not found in Store.
 	GetBlockByHash(ctx context.Context, hash types.Hash) (*types.SignedHeader, *types.Data, error)
+	// BlockIterator returns iterator over blocks from start to end (inclusive), reading them from a consistent
+	// snapshot of the store. Iterator must be closed.
+	BlockIterator(ctx context.Context, start, end uint64) (*BlockIterator, error)
+	// LoadBlockRange returns blocks from start to end (inclusive).
+	LoadBlockRange(ctx context.Context, start, end uint64) ([]Block, error)
+	// GetHeader returns block header at given height, also if data of the block was pruned.
+	GetHeader(ctx context.Context, height uint64) (*types.SignedHeader, error)
+	// GetHeaderByHash returns block header with given hash, also if data of the block was pruned.
+	GetHeaderByHash(ctx context.Context, hash types.Hash) (*types.SignedHeader, error)
+
+	// PruneBlockData removes data of block at given height, keeping hashes of its transactions.
+	PruneBlockData(ctx context.Context, height uint64) error
+	// Prune removes blocks, signatures, responses and state records of heights from from to to (inclusive).
+	// Latest state is kept.
+	Prune(ctx context.Context, from, to uint64) error
+	// Rollback removes blocks above given height and rewrites saved State to the state after applying block at
+	// given height. It must not be used while the node is running.
+	Rollback(ctx context.Context, height uint64) error
+	// Export writes blocks from from to to (inclusive) with their commits, responses and the state after applying
+	// block to into a versioned archive, see Import.
+	Export(ctx context.Context, w io.Writer, from, to uint64) error
+	// Import saves blocks and state read from archive written by Export, and returns range of imported blocks.
+	Import(ctx context.Context, r io.Reader) (from, to uint64, err error)
+	// GetTxHashes returns hashes of transactions (computed with types.TxHash) of block at given height, also if
+	// data of the block was pruned.
+	GetTxHashes(ctx context.Context, height uint64) ([][]byte, error)
+	// LoadBlockByTime returns height of the latest block with time not after given time.
+	LoadBlockByTime(ctx context.Context, t time.Time) (uint64, error)
+	// LoadTxByHash returns height and index in block of transaction with given hash, computed with types.TxHash.
+	LoadTxByHash(ctx context.Context, hash []byte) (uint64, uint32, error)
 
 	// SaveBlockResponses saves block responses (events, tx responses, validator set updates, etc) in Store.
 	SaveBlockResponses(ctx context.Context, height uint64, responses *abci.ResponseFinalizeBlock) error
@@ -30,6 +71,9 @@ type Store interface {
 	// GetBlockResponses returns block results at given height, or error if it's not found in Store.
 	GetBlockResponses(ctx context.Context, height uint64) (*abci.ResponseFinalizeBlock, error)
 
+	// DeleteBlockResponses removes block responses at given height, e.g. when events are pruned.
+	DeleteBlockResponses(ctx context.Context, height uint64) error
+
 	// GetSignature returns signature for a block at given height, or error if it's not found in Store.
 	GetSignature(ctx context.Context, height uint64) (*types.Signature, error)
 	// GetSignatureByHash returns signature for a block with given block header hash, or error if it's not found in Store.
@@ -46,6 +90,13 @@ type Store interface {
 	UpdateState(ctx context.Context, state types.State) error
 	// GetState returns last state saved with UpdateState.
 	GetState(ctx context.Context) (types.State, error)
+	// GetStateRecord returns record of the state after applying block at given height.
+	GetStateRecord(ctx context.Context, height uint64) (*types.StateRecord, error)
+
+	// SaveDAInclusionCertificate saves certificate of inclusion of the block at cert.Height in DA.
+	SaveDAInclusionCertificate(ctx context.Context, cert *types.DAInclusionCertificate) error
+	// GetDAInclusionCertificate returns certificate of inclusion of the block at given height in DA.
+	GetDAInclusionCertificate(ctx context.Context, height uint64) (*types.DAInclusionCertificate, error)
 
 	// SetMetadata saves arbitrary value in the store.
 	//
@@ -55,6 +106,9 @@ type Store interface {
 	// GetMetadata returns values stored for given key with SetMetadata.
 	GetMetadata(ctx context.Context, key string) ([]byte, error)
 
+	// Stats returns number of keys and approximate size of stored data, by kind of data.
+	Stats(ctx context.Context) (*Stats, error)
+
 	// Close safely closes underlying data storage, to ensure that data is actually saved.
 	Close() error
 }
@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestAnonymizeID(t *testing.T) {
+	assert := assert.New(t)
+
+	id := AnonymizeID("chain", "12D3KooWPeer")
+	assert.Len(id, 32)
+	assert.Equal(id, AnonymizeID("chain", "12D3KooWPeer"))
+	assert.NotEqual(id, AnonymizeID("other", "12D3KooWPeer"))
+	assert.NotContains(id, "12D3KooWPeer")
+}
+
+func TestReporter(t *testing.T) {
+	require := require.New(t)
+
+	reports := make(chan Report, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var report Report
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		reports <- report
+	}))
+	defer srv.Close()
+
+	height := uint64(0)
+	source := func() Report {
+		height++
+		return Report{NodeID: "node", ChainID: "chain", Version: "v", Mode: "full", Height: height, Peers: 3, SyncLag: 1}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := NewReporter(srv.URL, 50*time.Millisecond, source, test.NewFileLogger(t))
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+
+	for i := uint64(1); i <= 2; i++ {
+		select {
+		case report := <-reports:
+			require.Equal(i, report.Height)
+			require.Equal("chain", report.ChainID)
+			require.Equal(3, report.Peers)
+		case <-time.After(5 * time.Second):
+			t.Fatal("report not received")
+		}
+	}
+	cancel()
+	<-done
+}
+
+func TestReporterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	r := NewReporter(srv.URL, time.Minute, func() Report { return Report{} }, test.NewFileLogger(t))
+	require.Error(t, r.send(context.Background(), Report{}))
+}
@@ -0,0 +1,290 @@
+// Package app provides a programmable ABCI application for integration tests.
+//
+// Unlike mocks.Application, App keeps real state: app hash evolves deterministically with executed
+// transactions, events are derived from block and transaction contents, and state can be snapshotted and
+// restored with state sync. Behavior of CheckTx and transaction execution can be customized with options.
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+const (
+	// EventTypeBlock is the type of event emitted by FinalizeBlock for each block.
+	EventTypeBlock = "block"
+	// EventTypeTx is the type of event emitted for each executed transaction.
+	EventTypeTx = "tx"
+
+	// snapshotFormat is the only supported snapshot format.
+	snapshotFormat = 1
+)
+
+var _ abci.Application = (*App)(nil)
+
+// CheckTxFunc decides whether transaction is accepted to the mempool.
+type CheckTxFunc func(tx []byte) *abci.ResponseCheckTx
+
+// DeliverTxFunc executes transaction included in block at given height.
+type DeliverTxFunc func(height int64, tx []byte) *abci.ExecTxResult
+
+// Option configures App.
+type Option func(*App)
+
+// WithCheckTx sets function used by CheckTx. By default, all transactions are accepted.
+func WithCheckTx(fn CheckTxFunc) Option {
+	return func(a *App) {
+		a.checkTx = fn
+	}
+}
+
+// WithDeliverTx sets function executing transactions in FinalizeBlock. By default, all transactions succeed.
+// Events returned by fn are emitted along with the default transaction event.
+func WithDeliverTx(fn DeliverTxFunc) Option {
+	return func(a *App) {
+		a.deliverTx = fn
+	}
+}
+
+// WithSnapshotInterval makes App take a snapshot of its state every interval blocks.
+func WithSnapshotInterval(interval uint64) Option {
+	return func(a *App) {
+		a.snapshotInterval = interval
+	}
+}
+
+// State is the state of App. It's also the content of snapshots.
+type State struct {
+	Height  int64  `json:"height"`
+	AppHash []byte `json:"app_hash"`
+	TxCount uint64 `json:"tx_count"`
+}
+
+// App is a programmable ABCI application with deterministic app hash, events and snapshots.
+type App struct {
+	abci.BaseApplication
+
+	checkTx          CheckTxFunc
+	deliverTx        DeliverTxFunc
+	snapshotInterval uint64
+
+	mtx     sync.Mutex
+	state   State
+	pending *State
+	// pendingTxs are transactions of finalized, but not yet committed block
+	pendingTxs [][]byte
+	txs        [][]byte
+	snapshots  map[uint64][]byte
+	// restoring is the snapshot being applied during state sync
+	restoring *abci.Snapshot
+}
+
+// New creates App configured with given options.
+func New(opts ...Option) *App {
+	a := &App{
+		checkTx: func([]byte) *abci.ResponseCheckTx {
+			return &abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+		},
+		deliverTx: func(int64, []byte) *abci.ExecTxResult {
+			return &abci.ExecTxResult{Code: abci.CodeTypeOK}
+		},
+		snapshots: make(map[uint64][]byte),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// State returns committed state of the application.
+func (a *App) State() State {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.state
+}
+
+// Txs returns all transactions executed and committed by the application, in order.
+func (a *App) Txs() [][]byte {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return append([][]byte(nil), a.txs...)
+}
+
+// Info implements abci.Application.
+func (a *App) Info(context.Context, *abci.RequestInfo) (*abci.ResponseInfo, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return &abci.ResponseInfo{
+		Data:             "rollkit test app",
+		LastBlockHeight:  a.state.Height,
+		LastBlockAppHash: a.state.AppHash,
+	}, nil
+}
+
+// InitChain implements abci.Application. Initial app hash is derived from chain ID.
+func (a *App) InitChain(_ context.Context, req *abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	hash := sha256.Sum256([]byte(req.ChainId))
+	a.state = State{Height: req.InitialHeight - 1, AppHash: hash[:]}
+	return &abci.ResponseInitChain{AppHash: a.state.AppHash}, nil
+}
+
+// CheckTx implements abci.Application.
+func (a *App) CheckTx(_ context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	return a.checkTx(req.Tx), nil
+}
+
+// FinalizeBlock implements abci.Application. New app hash is a hash of previous app hash, height and results
+// of all transactions. Results become visible after Commit.
+func (a *App) FinalizeBlock(_ context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	h := sha256.New()
+	h.Write(a.state.AppHash)
+	_ = binary.Write(h, binary.BigEndian, req.Height)
+
+	results := make([]*abci.ExecTxResult, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := a.deliverTx(req.Height, tx)
+		txHash := sha256.Sum256(tx)
+		res.Events = append([]abci.Event{{
+			Type: EventTypeTx,
+			Attributes: []abci.EventAttribute{
+				{Key: "height", Value: strconv.FormatInt(req.Height, 10), Index: true},
+				{Key: "index", Value: strconv.Itoa(i), Index: true},
+				{Key: "hash", Value: fmt.Sprintf("%X", txHash), Index: true},
+			},
+		}}, res.Events...)
+		results[i] = res
+		_ = binary.Write(h, binary.BigEndian, res.Code)
+		h.Write(txHash[:])
+		h.Write(res.Data)
+	}
+
+	a.pending = &State{
+		Height:  req.Height,
+		AppHash: h.Sum(nil),
+		TxCount: a.state.TxCount + uint64(len(req.Txs)),
+	}
+	a.pendingTxs = req.Txs
+	return &abci.ResponseFinalizeBlock{
+		Events: []abci.Event{{
+			Type: EventTypeBlock,
+			Attributes: []abci.EventAttribute{
+				{Key: "height", Value: strconv.FormatInt(req.Height, 10), Index: true},
+				{Key: "num_txs", Value: strconv.Itoa(len(req.Txs)), Index: true},
+			},
+		}},
+		TxResults: results,
+		AppHash:   a.pending.AppHash,
+	}, nil
+}
+
+// Commit implements abci.Application. Snapshot is taken if snapshot interval is configured.
+func (a *App) Commit(context.Context, *abci.RequestCommit) (*abci.ResponseCommit, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.pending == nil {
+		return nil, errors.New("commit without finalized block")
+	}
+	a.state, a.pending = *a.pending, nil
+	a.txs, a.pendingTxs = append(a.txs, a.pendingTxs...), nil
+	if a.snapshotInterval > 0 && uint64(a.state.Height)%a.snapshotInterval == 0 { //nolint:gosec
+		blob, err := json.Marshal(a.state)
+		if err != nil {
+			return nil, err
+		}
+		a.snapshots[uint64(a.state.Height)] = blob //nolint:gosec
+	}
+	return &abci.ResponseCommit{}, nil
+}
+
+// Query implements abci.Application. Committed state is returned as JSON.
+func (a *App) Query(context.Context, *abci.RequestQuery) (*abci.ResponseQuery, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	blob, err := json.Marshal(a.state)
+	if err != nil {
+		return nil, err
+	}
+	return &abci.ResponseQuery{Code: abci.CodeTypeOK, Value: blob, Height: a.state.Height}, nil
+}
+
+// ListSnapshots implements abci.Application.
+func (a *App) ListSnapshots(context.Context, *abci.RequestListSnapshots) (*abci.ResponseListSnapshots, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	heights := make([]uint64, 0, len(a.snapshots))
+	for height := range a.snapshots {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	resp := &abci.ResponseListSnapshots{}
+	for _, height := range heights {
+		hash := sha256.Sum256(a.snapshots[height])
+		resp.Snapshots = append(resp.Snapshots, &abci.Snapshot{
+			Height: height,
+			Format: snapshotFormat,
+			Chunks: 1,
+			Hash:   hash[:],
+		})
+	}
+	return resp, nil
+}
+
+// LoadSnapshotChunk implements abci.Application. Snapshots consist of a single chunk.
+func (a *App) LoadSnapshotChunk(_ context.Context, req *abci.RequestLoadSnapshotChunk) (*abci.ResponseLoadSnapshotChunk, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if req.Format != snapshotFormat || req.Chunk != 0 {
+		return &abci.ResponseLoadSnapshotChunk{}, nil
+	}
+	return &abci.ResponseLoadSnapshotChunk{Chunk: a.snapshots[req.Height]}, nil
+}
+
+// OfferSnapshot implements abci.Application.
+func (a *App) OfferSnapshot(_ context.Context, req *abci.RequestOfferSnapshot) (*abci.ResponseOfferSnapshot, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if req.Snapshot == nil || req.Snapshot.Format != snapshotFormat {
+		return &abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT_FORMAT}, nil
+	}
+	if req.Snapshot.Chunks != 1 {
+		return &abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}, nil
+	}
+	a.restoring = req.Snapshot
+	return &abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil
+}
+
+// ApplySnapshotChunk implements abci.Application. Restored state must match the offered snapshot.
+func (a *App) ApplySnapshotChunk(_ context.Context, req *abci.RequestApplySnapshotChunk) (*abci.ResponseApplySnapshotChunk, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.restoring == nil {
+		return &abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ABORT}, nil
+	}
+	hash := sha256.Sum256(req.Chunk)
+	var state State
+	if !bytes.Equal(hash[:], a.restoring.Hash) || json.Unmarshal(req.Chunk, &state) != nil {
+		return &abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}, nil
+	}
+	if uint64(state.Height) != a.restoring.Height { //nolint:gosec
+		return &abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}, nil
+	}
+	a.state, a.pending, a.pendingTxs, a.restoring = state, nil, nil, nil
+	a.snapshots[uint64(state.Height)] = req.Chunk //nolint:gosec
+	return &abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil
+}
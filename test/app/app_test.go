@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func finalizeAndCommit(t *testing.T, a *App, height int64, txs ...[]byte) *abci.ResponseFinalizeBlock {
+	resp, err := a.FinalizeBlock(context.Background(), &abci.RequestFinalizeBlock{Height: height, Txs: txs})
+	require.NoError(t, err)
+	_, err = a.Commit(context.Background(), &abci.RequestCommit{})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestDeterministicExecution(t *testing.T) {
+	assert := assert.New(t)
+
+	apps := []*App{New(), New()}
+	var responses []*abci.ResponseFinalizeBlock
+	for _, a := range apps {
+		_, err := a.InitChain(context.Background(), &abci.RequestInitChain{ChainId: "test", InitialHeight: 1})
+		require.NoError(t, err)
+		finalizeAndCommit(t, a, 1, []byte("tx1"))
+		responses = append(responses, finalizeAndCommit(t, a, 2, []byte("tx2"), []byte("tx3")))
+	}
+
+	assert.Equal(responses[0], responses[1])
+	assert.Equal(apps[0].State(), apps[1].State())
+	assert.Equal(State{Height: 2, AppHash: responses[0].AppHash, TxCount: 3}, apps[0].State())
+	assert.Equal([][]byte{[]byte("tx1"), []byte("tx2"), []byte("tx3")}, apps[0].Txs())
+
+	require.Len(t, responses[0].TxResults, 2)
+	assert.Equal(EventTypeTx, responses[0].TxResults[1].Events[0].Type)
+	assert.Equal("1", responses[0].TxResults[1].Events[0].Attributes[1].Value)
+	assert.Equal(EventTypeBlock, responses[0].Events[0].Type)
+
+	// different transactions result in different app hash
+	other := New()
+	finalizeAndCommit(t, other, 1, []byte("tx1"))
+	assert.NotEqual(apps[0].State().AppHash, finalizeAndCommit(t, other, 2, []byte("tx3"), []byte("tx2")).AppHash)
+
+	info, err := apps[0].Info(context.Background(), &abci.RequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(int64(2), info.LastBlockHeight)
+	assert.Equal(responses[0].AppHash, info.LastBlockAppHash)
+}
+
+func TestProgrammableBehavior(t *testing.T) {
+	assert := assert.New(t)
+
+	a := New(
+		WithCheckTx(func(tx []byte) *abci.ResponseCheckTx {
+			if string(tx) == "bad" {
+				return &abci.ResponseCheckTx{Code: 1}
+			}
+			return &abci.ResponseCheckTx{}
+		}),
+		WithDeliverTx(func(height int64, tx []byte) *abci.ExecTxResult {
+			return &abci.ExecTxResult{Code: uint32(len(tx)), Events: []abci.Event{{Type: "custom"}}} //nolint:gosec
+		}),
+	)
+
+	res, err := a.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte("bad")})
+	require.NoError(t, err)
+	assert.Equal(uint32(1), res.Code)
+	res, err = a.CheckTx(context.Background(), &abci.RequestCheckTx{Tx: []byte("good")})
+	require.NoError(t, err)
+	assert.Equal(abci.CodeTypeOK, res.Code)
+
+	resp := finalizeAndCommit(t, a, 1, []byte("abc"))
+	assert.Equal(uint32(3), resp.TxResults[0].Code)
+	require.Len(t, resp.TxResults[0].Events, 2)
+	assert.Equal(EventTypeTx, resp.TxResults[0].Events[0].Type)
+	assert.Equal("custom", resp.TxResults[0].Events[1].Type)
+
+	_, err = a.Commit(context.Background(), &abci.RequestCommit{})
+	assert.Error(err)
+}
+
+func TestSnapshots(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	a := New(WithSnapshotInterval(2))
+	for height := int64(1); height <= 5; height++ {
+		finalizeAndCommit(t, a, height, []byte{byte(height)})
+	}
+	list, err := a.ListSnapshots(ctx, &abci.RequestListSnapshots{})
+	require.NoError(err)
+	require.Len(list.Snapshots, 2)
+	snapshot := list.Snapshots[1]
+	assert.Equal(uint64(4), snapshot.Height)
+
+	chunk, err := a.LoadSnapshotChunk(ctx, &abci.RequestLoadSnapshotChunk{Height: snapshot.Height, Format: snapshot.Format})
+	require.NoError(err)
+
+	restored := New()
+	offer, err := restored.OfferSnapshot(ctx, &abci.RequestOfferSnapshot{Snapshot: snapshot})
+	require.NoError(err)
+	assert.Equal(abci.ResponseOfferSnapshot_ACCEPT, offer.Result)
+	applied, err := restored.ApplySnapshotChunk(ctx, &abci.RequestApplySnapshotChunk{Chunk: []byte("corrupted")})
+	require.NoError(err)
+	assert.Equal(abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT, applied.Result)
+
+	_, err = restored.OfferSnapshot(ctx, &abci.RequestOfferSnapshot{Snapshot: snapshot})
+	require.NoError(err)
+	applied, err = restored.ApplySnapshotChunk(ctx, &abci.RequestApplySnapshotChunk{Chunk: chunk.Chunk})
+	require.NoError(err)
+	assert.Equal(abci.ResponseApplySnapshotChunk_ACCEPT, applied.Result)
+	assert.Equal(int64(4), restored.State().Height)
+
+	// restored app continues with the same app hash as the original one
+	assert.Equal(a.State().AppHash, finalizeAndCommit(t, restored, 5, []byte{5}).AppHash)
+
+	offer, err = restored.OfferSnapshot(ctx, &abci.RequestOfferSnapshot{Snapshot: &abci.Snapshot{Format: 2}})
+	require.NoError(err)
+	assert.Equal(abci.ResponseOfferSnapshot_REJECT_FORMAT, offer.Result)
+}
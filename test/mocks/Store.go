@@ -9,8 +9,14 @@ import (
 
 	header "github.com/celestiaorg/go-header"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
+	store "github.com/rollkit/rollkit/store"
+
+	time "time"
+
 	types "github.com/rollkit/rollkit/types"
 )
 
@@ -19,6 +25,36 @@ type Store struct {
 	mock.Mock
 }
 
+// BlockIterator provides a mock function with given fields: ctx, start, end
+func (_m *Store) BlockIterator(ctx context.Context, start uint64, end uint64) (*store.BlockIterator, error) {
+	ret := _m.Called(ctx, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockIterator")
+	}
+
+	var r0 *store.BlockIterator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*store.BlockIterator, error)); ok {
+		return rf(ctx, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *store.BlockIterator); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.BlockIterator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *Store) Close() error {
 	ret := _m.Called()
@@ -37,6 +73,42 @@ func (_m *Store) Close() error {
 	return r0
 }
 
+// DeleteBlockResponses provides a mock function with given fields: ctx, height
+func (_m *Store) DeleteBlockResponses(ctx context.Context, height uint64) error {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBlockResponses")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: ctx, w, from, to
+func (_m *Store) Export(ctx context.Context, w io.Writer, from uint64, to uint64) error {
+	ret := _m.Called(ctx, w, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, uint64, uint64) error); ok {
+		r0 = rf(ctx, w, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetBlockByHash provides a mock function with given fields: ctx, hash
 func (_m *Store) GetBlockByHash(ctx context.Context, hash header.Hash) (*types.SignedHeader, *types.Data, error) {
 	ret := _m.Called(ctx, hash)
@@ -145,6 +217,36 @@ func (_m *Store) GetBlockResponses(ctx context.Context, height uint64) (*abcityp
 	return r0, r1
 }
 
+// GetDAInclusionCertificate provides a mock function with given fields: ctx, height
+func (_m *Store) GetDAInclusionCertificate(ctx context.Context, height uint64) (*types.DAInclusionCertificate, error) {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDAInclusionCertificate")
+	}
+
+	var r0 *types.DAInclusionCertificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.DAInclusionCertificate, error)); ok {
+		return rf(ctx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.DAInclusionCertificate); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DAInclusionCertificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetExtendedCommit provides a mock function with given fields: ctx, height
 func (_m *Store) GetExtendedCommit(ctx context.Context, height uint64) (*abcitypes.ExtendedCommitInfo, error) {
 	ret := _m.Called(ctx, height)
@@ -175,6 +277,66 @@ func (_m *Store) GetExtendedCommit(ctx context.Context, height uint64) (*abcityp
 	return r0, r1
 }
 
+// GetHeader provides a mock function with given fields: ctx, height
+func (_m *Store) GetHeader(ctx context.Context, height uint64) (*types.SignedHeader, error) {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHeader")
+	}
+
+	var r0 *types.SignedHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.SignedHeader, error)); ok {
+		return rf(ctx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.SignedHeader); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SignedHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHeaderByHash provides a mock function with given fields: ctx, hash
+func (_m *Store) GetHeaderByHash(ctx context.Context, hash header.Hash) (*types.SignedHeader, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHeaderByHash")
+	}
+
+	var r0 *types.SignedHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, header.Hash) (*types.SignedHeader, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, header.Hash) *types.SignedHeader); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SignedHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, header.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMetadata provides a mock function with given fields: ctx, key
 func (_m *Store) GetMetadata(ctx context.Context, key string) ([]byte, error) {
 	ret := _m.Called(ctx, key)
@@ -293,6 +455,66 @@ func (_m *Store) GetState(ctx context.Context) (types.State, error) {
 	return r0, r1
 }
 
+// GetStateRecord provides a mock function with given fields: ctx, height
+func (_m *Store) GetStateRecord(ctx context.Context, height uint64) (*types.StateRecord, error) {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStateRecord")
+	}
+
+	var r0 *types.StateRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.StateRecord, error)); ok {
+		return rf(ctx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.StateRecord); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StateRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxHashes provides a mock function with given fields: ctx, height
+func (_m *Store) GetTxHashes(ctx context.Context, height uint64) ([][]byte, error) {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxHashes")
+	}
+
+	var r0 [][]byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([][]byte, error)); ok {
+		return rf(ctx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) [][]byte); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Height provides a mock function with given fields:
 func (_m *Store) Height() uint64 {
 	ret := _m.Called()
@@ -311,6 +533,188 @@ func (_m *Store) Height() uint64 {
 	return r0
 }
 
+// Import provides a mock function with given fields: ctx, r
+func (_m *Store) Import(ctx context.Context, r io.Reader) (uint64, uint64, error) {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) (uint64, uint64, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) uint64); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader) uint64); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, io.Reader) error); ok {
+		r2 = rf(ctx, r)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// LoadBlockByTime provides a mock function with given fields: ctx, t
+func (_m *Store) LoadBlockByTime(ctx context.Context, t time.Time) (uint64, error) {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadBlockByTime")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (uint64, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) uint64); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadBlockRange provides a mock function with given fields: ctx, start, end
+func (_m *Store) LoadBlockRange(ctx context.Context, start uint64, end uint64) ([]store.Block, error) {
+	ret := _m.Called(ctx, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadBlockRange")
+	}
+
+	var r0 []store.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]store.Block, error)); ok {
+		return rf(ctx, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []store.Block); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]store.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadTxByHash provides a mock function with given fields: ctx, hash
+func (_m *Store) LoadTxByHash(ctx context.Context, hash []byte) (uint64, uint32, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadTxByHash")
+	}
+
+	var r0 uint64
+	var r1 uint32
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (uint64, uint32, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) uint64); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) uint32); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []byte) error); ok {
+		r2 = rf(ctx, hash)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Prune provides a mock function with given fields: ctx, from, to
+func (_m *Store) Prune(ctx context.Context, from uint64, to uint64) error {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prune")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) error); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PruneBlockData provides a mock function with given fields: ctx, height
+func (_m *Store) PruneBlockData(ctx context.Context, height uint64) error {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneBlockData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Rollback provides a mock function with given fields: ctx, height
+func (_m *Store) Rollback(ctx context.Context, height uint64) error {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rollback")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveBlockData provides a mock function with given fields: ctx, _a1, data, signature
 func (_m *Store) SaveBlockData(ctx context.Context, _a1 *types.SignedHeader, data *types.Data, signature *types.Signature) error {
 	ret := _m.Called(ctx, _a1, data, signature)
@@ -329,6 +733,42 @@ func (_m *Store) SaveBlockData(ctx context.Context, _a1 *types.SignedHeader, dat
 	return r0
 }
 
+// SaveBlockDataWithMetadata provides a mock function with given fields: ctx, _a1, data, signature, metadata
+func (_m *Store) SaveBlockDataWithMetadata(ctx context.Context, _a1 *types.SignedHeader, data *types.Data, signature *types.Signature, metadata map[string][]byte) error {
+	ret := _m.Called(ctx, _a1, data, signature, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBlockDataWithMetadata")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.SignedHeader, *types.Data, *types.Signature, map[string][]byte) error); ok {
+		r0 = rf(ctx, _a1, data, signature, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveBlockDataWithResponses provides a mock function with given fields: ctx, _a1, data, signature, responses, metadata
+func (_m *Store) SaveBlockDataWithResponses(ctx context.Context, _a1 *types.SignedHeader, data *types.Data, signature *types.Signature, responses *abcitypes.ResponseFinalizeBlock, metadata map[string][]byte) error {
+	ret := _m.Called(ctx, _a1, data, signature, responses, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBlockDataWithResponses")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.SignedHeader, *types.Data, *types.Signature, *abcitypes.ResponseFinalizeBlock, map[string][]byte) error); ok {
+		r0 = rf(ctx, _a1, data, signature, responses, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveBlockResponses provides a mock function with given fields: ctx, height, responses
 func (_m *Store) SaveBlockResponses(ctx context.Context, height uint64, responses *abcitypes.ResponseFinalizeBlock) error {
 	ret := _m.Called(ctx, height, responses)
@@ -347,6 +787,24 @@ func (_m *Store) SaveBlockResponses(ctx context.Context, height uint64, response
 	return r0
 }
 
+// SaveDAInclusionCertificate provides a mock function with given fields: ctx, cert
+func (_m *Store) SaveDAInclusionCertificate(ctx context.Context, cert *types.DAInclusionCertificate) error {
+	ret := _m.Called(ctx, cert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDAInclusionCertificate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.DAInclusionCertificate) error); ok {
+		r0 = rf(ctx, cert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveExtendedCommit provides a mock function with given fields: ctx, height, commit
 func (_m *Store) SaveExtendedCommit(ctx context.Context, height uint64, commit *abcitypes.ExtendedCommitInfo) error {
 	ret := _m.Called(ctx, height, commit)
@@ -388,6 +846,36 @@ func (_m *Store) SetMetadata(ctx context.Context, key string, value []byte) erro
 	return r0
 }
 
+// Stats provides a mock function with given fields: ctx
+func (_m *Store) Stats(ctx context.Context) (*store.Stats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 *store.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*store.Stats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *store.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateState provides a mock function with given fields: ctx, state
 func (_m *Store) UpdateState(ctx context.Context, state types.State) error {
 	ret := _m.Called(ctx, state)
@@ -406,6 +894,24 @@ func (_m *Store) UpdateState(ctx context.Context, state types.State) error {
 	return r0
 }
 
+// WaitForHeight provides a mock function with given fields: ctx, height
+func (_m *Store) WaitForHeight(ctx context.Context, height uint64) error {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForHeight")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStore(t interface {
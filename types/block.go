@@ -33,6 +33,8 @@ type Metadata struct {
 type Data struct {
 	*Metadata
 	Txs Txs
+	// Sections carry auxiliary data of the block, ordered by type, see DataSection.
+	Sections []DataSection
 	// IntermediateStateRoots IntermediateStateRoots
 	// Note: Temporarily remove Evidence #896
 	// Evidence               EvidenceData
@@ -60,10 +62,9 @@ func (signature *Signature) GetCommitHash(header *Header, proposerAddress []byte
 	return abciCommit.Hash()
 }
 
-// ValidateBasic performs basic validation of block data.
-// Actually it's a placeholder, because nothing is checked.
+// ValidateBasic performs basic validation of block data. Only sections are checked.
 func (d *Data) ValidateBasic() error {
-	return nil
+	return validateDataSections(d.Sections)
 }
 
 // ValidateBasic performs basic validation of a signature.
@@ -85,7 +86,7 @@ func Validate(header *SignedHeader, data *Data) error {
 		}
 	}
 	// exclude Metadata while computing the data hash for comparison
-	d := Data{Txs: data.Txs}
+	d := Data{Txs: data.Txs, Sections: data.Sections}
 	dataHash := d.Hash()
 	if !bytes.Equal(dataHash[:], header.DataHash[:]) {
 		return errors.New("dataHash from the header does not match with hash of the block's data")
@@ -143,5 +144,8 @@ func (d *Data) Validate() error {
 
 // Size returns size of the block in bytes.
 func (d *Data) Size() int {
-	return d.ToProto().Size()
+	p := d.ToProto()
+	// sections are measured as they are encoded, see AppendBinary
+	p.Sections = nil
+	return p.Size() + dataSectionsSize(d.Sections)
 }
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// bundleTxPrefix marks a transaction wrapping a bundle of transactions. It's unlikely to be a prefix of
+// an application transaction.
+var bundleTxPrefix = []byte("rollkit/bundle/v1\x00")
+
+// NewBundleTx wraps transactions in a single transaction, which is submitted to the sequencer as a whole.
+// Block builder unwraps bundles and includes all transactions of a bundle in the same block, in order, or
+// none of them. Bundle transactions never appear in blocks.
+func NewBundleTx(txs Txs) (Tx, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("bundle must contain at least one transaction")
+	}
+	bundle := &pb.Bundle{Txs: make([][]byte, len(txs))}
+	for i, tx := range txs {
+		if IsBundleTx(tx) {
+			return nil, errors.New("bundles can't be nested")
+		}
+		bundle.Txs[i] = tx
+	}
+	b, err := bundle.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes.Clone(bundleTxPrefix), b...), nil
+}
+
+// IsBundleTx returns true if tx wraps a bundle of transactions.
+func IsBundleTx(tx Tx) bool {
+	return bytes.HasPrefix(tx, bundleTxPrefix)
+}
+
+// UnwrapBundleTx returns transactions wrapped in bundle transaction.
+func UnwrapBundleTx(tx Tx) (Txs, error) {
+	if !IsBundleTx(tx) {
+		return nil, errors.New("not a bundle transaction")
+	}
+	var bundle pb.Bundle
+	if err := bundle.Unmarshal(tx[len(bundleTxPrefix):]); err != nil {
+		return nil, fmt.Errorf("malformed bundle: %w", err)
+	}
+	if len(bundle.Txs) == 0 {
+		return nil, errors.New("malformed bundle: no transactions")
+	}
+	txs := byteSlicesToTxs(bundle.Txs)
+	for _, tx := range txs {
+		if IsBundleTx(tx) {
+			return nil, errors.New("malformed bundle: bundles can't be nested")
+		}
+	}
+	return txs, nil
+}
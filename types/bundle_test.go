@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleTx(t *testing.T) {
+	txs := Txs{Tx("a"), Tx{}, Tx("c")}
+	bundle, err := NewBundleTx(txs)
+	require.NoError(t, err)
+	assert.True(t, IsBundleTx(bundle))
+	assert.False(t, IsBundleTx(Tx("a")))
+
+	unwrapped, err := UnwrapBundleTx(bundle)
+	require.NoError(t, err)
+	assert.Equal(t, len(txs), len(unwrapped))
+	for i := range txs {
+		assert.Equal(t, []byte(txs[i]), []byte(unwrapped[i]))
+	}
+
+	_, err = NewBundleTx(nil)
+	assert.Error(t, err)
+	_, err = NewBundleTx(Txs{bundle})
+	assert.Error(t, err)
+	_, err = UnwrapBundleTx(bundle[:len(bundle)-1])
+	assert.Error(t, err)
+	_, err = UnwrapBundleTx(Tx(bundleTxPrefix))
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// DAInclusionCertificate is a compact proof that header of the block at Height was included in the DA layer.
+// ID, Commitment and Proof are opaque values defined by the DA layer (see go-da), so the certificate can be
+// verified against the DA layer (e.g. with Validate of go-da) without retrieving the blob.
+type DAInclusionCertificate struct {
+	Height   uint64 `json:"height,string"`
+	DAHeight uint64 `json:"da_height,string"`
+	// ID identifies the blob with the header in the DA layer.
+	ID cmbytes.HexBytes `json:"id"`
+	// Commitment is the commitment to the blob with the header.
+	Commitment cmbytes.HexBytes `json:"commitment"`
+	// Proof is the proof of inclusion of the blob.
+	Proof cmbytes.HexBytes `json:"proof"`
+}
+
+// MarshalBinary encodes DAInclusionCertificate into binary form and returns it.
+func (c *DAInclusionCertificate) MarshalBinary() ([]byte, error) {
+	return c.ToProto().Marshal()
+}
+
+// UnmarshalBinary decodes binary form of DAInclusionCertificate into object.
+func (c *DAInclusionCertificate) UnmarshalBinary(data []byte) error {
+	var pCertificate pb.DAInclusionCertificate
+	if err := pCertificate.Unmarshal(data); err != nil {
+		return err
+	}
+	c.FromProto(&pCertificate)
+	return nil
+}
+
+// ToProto converts DAInclusionCertificate into protobuf representation and returns it.
+func (c *DAInclusionCertificate) ToProto() *pb.DAInclusionCertificate {
+	return &pb.DAInclusionCertificate{
+		Height:     c.Height,
+		DaHeight:   c.DAHeight,
+		Id:         c.ID,
+		Commitment: c.Commitment,
+		Proof:      c.Proof,
+	}
+}
+
+// FromProto fills DAInclusionCertificate with data from its protobuf representation.
+func (c *DAInclusionCertificate) FromProto(other *pb.DAInclusionCertificate) {
+	*c = DAInclusionCertificate{
+		Height:     other.Height,
+		DAHeight:   other.DaHeight,
+		ID:         other.Id,
+		Commitment: other.Commitment,
+		Proof:      other.Proof,
+	}
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// DataSectionType identifies the kind of auxiliary data carried by a section of block data.
+type DataSectionType uint32
+
+const (
+	// DataSectionIntermediateStateRoots carries intermediate state roots of the block. It's reserved for fraud
+	// proofs.
+	DataSectionIntermediateStateRoots DataSectionType = 1
+	// MinAppDataSectionType is the lowest type of sections defined by applications, e.g. references of blobs or
+	// SNARK commitments. Lower types are reserved for Rollkit.
+	MinAppDataSectionType DataSectionType = 1 << 16
+)
+
+// MaxDataSections is the maximum number of sections of block data.
+const MaxDataSections = 64
+
+// dataSectionsField is the number of the field of Data message with sections, see AppendBinary.
+const dataSectionsField = 3
+
+// DataSection is a typed section of block data, carrying auxiliary data in the block without encoding it in
+// transactions. Sections are part of the data hash, so they are signed by the proposer with the header. Sections
+// are encoded as DataSection messages in the sections field of the Data message (see proto/rollkit/rollkit.proto).
+//
+// Nodes keep, hash and relay sections of types they don't know, so new types can be introduced without breaking
+// older nodes. Sections decoded from blocks also keep their encoding, which is used when the data is encoded or
+// hashed again, so fields added to the message by newer versions are kept as well. Decoded sections must not be
+// modified, replace them with new ones instead. Blocks without sections are encoded exactly like before sections
+// were introduced.
+type DataSection struct {
+	Type    DataSectionType
+	Payload []byte
+
+	// raw is the encoding of the section it was decoded from, nil for new sections
+	raw []byte
+}
+
+// Section returns payload of the section of given type, and whether the data has it.
+func (d *Data) Section(t DataSectionType) ([]byte, bool) {
+	for _, s := range d.Sections {
+		if s.Type == t {
+			return s.Payload, true
+		}
+	}
+	return nil, false
+}
+
+// validateDataSections checks that there are at most MaxDataSections sections, ordered by unique types, with
+// non-empty payloads, so that encoding of data is deterministic.
+func validateDataSections(sections []DataSection) error {
+	if len(sections) > MaxDataSections {
+		return fmt.Errorf("too many data sections: %d, maximum is %d", len(sections), MaxDataSections)
+	}
+	for i, s := range sections {
+		if s.Type == 0 {
+			return errors.New("data section type is required")
+		}
+		if i > 0 && s.Type <= sections[i-1].Type {
+			return fmt.Errorf("data section %d is not ordered after section %d", s.Type, sections[i-1].Type)
+		}
+		if len(s.Payload) == 0 {
+			return fmt.Errorf("payload of data section %d is empty", s.Type)
+		}
+	}
+	return nil
+}
+
+// ToProto converts DataSection into protobuf representation and returns it. Fields unknown to this version are
+// not included.
+func (s *DataSection) ToProto() *pb.DataSection {
+	return &pb.DataSection{
+		Type:    uint32(s.Type),
+		Payload: s.Payload,
+	}
+}
+
+// FromProto fills the DataSection with data from its protobuf representation.
+func (s *DataSection) FromProto(other *pb.DataSection) {
+	s.Type = DataSectionType(other.Type)
+	s.Payload = other.Payload
+	s.raw = nil
+}
+
+// appendDataSection appends section s as a field of Data message to b.
+func appendDataSection(b []byte, s DataSection) ([]byte, error) {
+	b = protowire.AppendTag(b, dataSectionsField, protowire.BytesType)
+	if s.raw != nil {
+		return protowire.AppendBytes(b, s.raw), nil
+	}
+	p := s.ToProto()
+	b = protowire.AppendVarint(b, uint64(p.Size()))
+	return appendProto(b, p)
+}
+
+// dataSectionSize returns size of encoded section message, without its tag and length.
+func dataSectionSize(s DataSection) int {
+	if s.raw != nil {
+		return len(s.raw)
+	}
+	return s.ToProto().Size()
+}
+
+// dataSectionsSize returns size of encoded sections, as fields of Data message.
+func dataSectionsSize(sections []DataSection) int {
+	size := 0
+	for _, s := range sections {
+		size += protowire.SizeTag(dataSectionsField) + protowire.SizeBytes(dataSectionSize(s))
+	}
+	return size
+}
+
+// unmarshalDataSection decodes section message, keeping its encoding, which references b.
+func unmarshalDataSection(b []byte) (DataSection, error) {
+	var p pb.DataSection
+	if err := p.Unmarshal(b); err != nil {
+		return DataSection{}, err
+	}
+	var s DataSection
+	s.FromProto(&p)
+	// capacity is limited, so that appending to the encoding doesn't overwrite the next field
+	s.raw = b[:len(b):len(b)]
+	return s, nil
+}
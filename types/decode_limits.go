@@ -0,0 +1,151 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ErrDecodeLimitExceeded is returned when untrusted data exceeds configured decoding limits.
+var ErrDecodeLimitExceeded = errors.New("decode limit exceeded")
+
+// DecodeLimits bound the size of data accepted by UnmarshalBinary methods of blocks and state. Data
+// received from DA and peers is untrusted, so limits are checked before anything is allocated, to prevent
+// crafted blobs from exhausting memory of syncing nodes. Zero value of a field disables the limit.
+type DecodeLimits struct {
+	// MaxBytes is the maximum size of encoded object.
+	MaxBytes uint64
+	// MaxTxs is the maximum number of transactions in block data.
+	MaxTxs uint64
+	// MaxValidators is the maximum number of validators in a validator set.
+	MaxValidators uint64
+}
+
+// DefaultDecodeLimits returns limits generous enough for any valid block.
+func DefaultDecodeLimits() DecodeLimits {
+	return DecodeLimits{
+		MaxBytes:      64 << 20,
+		MaxTxs:        1 << 20,
+		MaxValidators: 10000,
+	}
+}
+
+var decodeLimits atomic.Pointer[DecodeLimits]
+
+func init() {
+	SetDecodeLimits(DefaultDecodeLimits())
+}
+
+// SetDecodeLimits sets limits enforced by UnmarshalBinary methods in this package.
+func SetDecodeLimits(l DecodeLimits) {
+	decodeLimits.Store(&l)
+}
+
+// GetDecodeLimits returns limits enforced by UnmarshalBinary methods in this package.
+func GetDecodeLimits() DecodeLimits {
+	return *decodeLimits.Load()
+}
+
+// CheckSize returns error if encoded object of given kind is larger than MaxBytes.
+func (l DecodeLimits) CheckSize(kind string, data []byte) error {
+	if l.MaxBytes != 0 && uint64(len(data)) > l.MaxBytes {
+		return fmt.Errorf("%w: %s size %d exceeds %d bytes", ErrDecodeLimitExceeded, kind, len(data), l.MaxBytes)
+	}
+	return nil
+}
+
+// checkData checks limits of protobuf encoded Data.
+func (l DecodeLimits) checkData(data []byte) error {
+	if err := l.CheckSize("data", data); err != nil {
+		return err
+	}
+	if err := checkCount("transactions", data, l.MaxTxs, 2); err != nil {
+		return err
+	}
+	return checkCount("data sections", data, MaxDataSections, dataSectionsField)
+}
+
+// checkSignedHeader checks limits of protobuf encoded SignedHeader.
+func (l DecodeLimits) checkSignedHeader(data []byte) error {
+	if err := l.CheckSize("signed header", data); err != nil {
+		return err
+	}
+	return l.checkValidatorSets(data, 3)
+}
+
+// checkState checks limits of protobuf encoded State.
+func (l DecodeLimits) checkState(data []byte) error {
+	if err := l.CheckSize("state", data); err != nil {
+		return err
+	}
+	return l.checkValidatorSets(data, 7, 8, 9)
+}
+
+// checkValidatorSets checks the number of validators in validator sets encoded in given fields.
+func (l DecodeLimits) checkValidatorSets(data []byte, fields ...protowire.Number) error {
+	for _, field := range fields {
+		// validators are the field 1 of ValidatorSet message
+		if err := checkCount("validators", data, l.MaxValidators, field, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCount walks protobuf encoded message without allocating and returns error if the number of
+// occurrences of field at given path exceeds max. All but the last field of path are embedded messages.
+// Occurrences in repeated embedded messages are summed, as protobuf decoding merges them.
+func checkCount(what string, data []byte, max uint64, path ...protowire.Number) error {
+	if max == 0 {
+		return nil
+	}
+	count := uint64(0)
+	var walk func(data []byte, path []protowire.Number) error
+	walk = func(data []byte, path []protowire.Number) error {
+		return walkFields(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+			if num != path[0] {
+				return nil
+			}
+			if len(path) > 1 {
+				if typ != protowire.BytesType {
+					return nil
+				}
+				return walk(value, path[1:])
+			}
+			count++
+			if count > max {
+				return fmt.Errorf("%w: more than %d %s", ErrDecodeLimitExceeded, max, what)
+			}
+			return nil
+		})
+	}
+	return walk(data, path)
+}
+
+// walkFields calls fn for each top level field of protobuf encoded message. Value of length-delimited
+// fields is passed to fn.
+func walkFields(data []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("invalid field tag: %w", protowire.ParseError(n))
+		}
+		data = data[n:]
+		var value []byte
+		if typ == protowire.BytesType {
+			value, n = protowire.ConsumeBytes(data)
+		} else {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return fmt.Errorf("invalid value of field %d: %w", num, protowire.ParseError(n))
+		}
+		data = data[n:]
+		if err := fn(num, typ, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestDecodeLimits(t *testing.T) {
+	defaults := GetDecodeLimits()
+	defer SetDecodeLimits(defaults)
+	SetDecodeLimits(DecodeLimits{MaxBytes: 1000, MaxTxs: 2, MaxValidators: 1})
+
+	t.Run("transactions", func(t *testing.T) {
+		_, data := GetRandomBlock(1, 2, "test")
+		blob, err := data.MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, new(Data).UnmarshalBinary(blob))
+
+		data.Txs = append(data.Txs, Tx{})
+		blob, err = data.MarshalBinary()
+		require.NoError(t, err)
+		assert.ErrorIs(t, new(Data).UnmarshalBinary(blob), ErrDecodeLimitExceeded)
+	})
+
+	t.Run("size", func(t *testing.T) {
+		_, data := GetRandomBlock(1, 0, "test")
+		data.Txs = Txs{GetRandomBytes(1000)}
+		blob, err := data.MarshalBinary()
+		require.NoError(t, err)
+		assert.ErrorIs(t, new(Data).UnmarshalBinary(blob), ErrDecodeLimitExceeded)
+	})
+
+	t.Run("validators", func(t *testing.T) {
+		header, _ := GetRandomBlock(1, 0, "test")
+		blob, err := header.MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, new(SignedHeader).UnmarshalBinary(blob))
+
+		// repeated validator sets are merged by protobuf decoding, so they are counted together
+		vals, err := GetRandomValidatorSet().ToProto()
+		require.NoError(t, err)
+		valsBlob, err := vals.Marshal()
+		require.NoError(t, err)
+		blob = protowire.AppendTag(blob, 3, protowire.BytesType)
+		blob = protowire.AppendBytes(blob, valsBlob)
+		assert.ErrorIs(t, new(SignedHeader).UnmarshalBinary(blob), ErrDecodeLimitExceeded)
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		assert.Error(t, new(SignedHeader).UnmarshalBinary(nil))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		SetDecodeLimits(DecodeLimits{})
+		_, data := GetRandomBlock(1, 10, "test")
+		blob, err := data.MarshalBinary()
+		require.NoError(t, err)
+		assert.NoError(t, new(Data).UnmarshalBinary(blob))
+	})
+}
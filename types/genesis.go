@@ -0,0 +1,146 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
+	cmjson "github.com/cometbft/cometbft/libs/json"
+	cmtypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrInvalidGenesis is returned when genesis document fails validation.
+var ErrInvalidGenesis = errors.New("invalid genesis")
+
+// ValidateGenesis checks genesis document for misconfigurations which would prevent the chain from producing
+// or syncing blocks, or make nodes disagree on the chain. All problems are reported at once, each with a hint
+// how to fix it. Defaults completed by CometBFT (initial height, consensus params) are accepted as missing, but
+// genesis time is required, as it would be completed with current time, different on every node.
+func ValidateGenesis(genDoc *cmtypes.GenesisDoc) error {
+	if genDoc == nil {
+		return fmt.Errorf("%w: genesis document is missing", ErrInvalidGenesis)
+	}
+	var errs []error
+	if genDoc.ChainID == "" {
+		errs = append(errs, errors.New("chain_id is empty: set chain_id to the ID of the rollup"))
+	} else if len(genDoc.ChainID) > cmtypes.MaxChainIDLen {
+		errs = append(errs, fmt.Errorf("chain_id is %d characters long: maximum is %d", len(genDoc.ChainID), cmtypes.MaxChainIDLen))
+	}
+	if genDoc.GenesisTime.IsZero() {
+		errs = append(errs, errors.New("genesis_time is missing: set it to the time the chain starts"))
+	}
+	if genDoc.InitialHeight < 0 {
+		errs = append(errs, fmt.Errorf("initial_height is %d: set it to 1, or the height the chain is restarted from", genDoc.InitialHeight))
+	}
+
+	switch len(genDoc.Validators) {
+	case 0:
+		errs = append(errs, errors.New("validators are empty: add the sequencer key (pub_key of priv_validator_key.json of the sequencer) as the only validator"))
+	case 1:
+		errs = append(errs, validateGenesisValidator(genDoc.Validators[0])...)
+	default:
+		errs = append(errs, fmt.Errorf("there are %d validators: Rollkit uses a centralized sequencer, keep only the sequencer key", len(genDoc.Validators)))
+	}
+
+	if genDoc.ConsensusParams != nil {
+		if err := genDoc.ConsensusParams.ValidateBasic(); err != nil {
+			errs = append(errs, fmt.Errorf("consensus_params are invalid: %w", err))
+		}
+	}
+	if len(genDoc.AppState) > 0 && !json.Valid(genDoc.AppState) {
+		errs = append(errs, errors.New("app_state is not valid JSON: check the output of the application genesis export"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidGenesis, errors.Join(errs...))
+	}
+	return nil
+}
+
+func validateGenesisValidator(v cmtypes.GenesisValidator) []error {
+	if v.PubKey == nil {
+		return []error{errors.New("validator pub_key is missing: set it to the public key of the sequencer")}
+	}
+	var errs []error
+	if keyType := v.PubKey.Type(); keyType != ed25519.KeyType && keyType != secp256k1.KeyType {
+		errs = append(errs, fmt.Errorf("validator key type %q is not supported: use %s or %s key", keyType, ed25519.KeyType, secp256k1.KeyType))
+	}
+	if len(v.Address) > 0 && !bytes.Equal(v.Address, v.PubKey.Address()) {
+		errs = append(errs, fmt.Errorf("validator address %X doesn't match its pub_key (address %X): remove the address, or fix the key", v.Address, v.PubKey.Address()))
+	}
+	if v.Power <= 0 {
+		errs = append(errs, fmt.Errorf("validator power is %d: set it to 1", v.Power))
+	}
+	return errs
+}
+
+// GenesisDocFromFile reads genesis document from file and completes its defaults. Unlike
+// cmtypes.GenesisDocFromFile, it requires genesis_time to be set: missing genesis time would be completed with
+// current time, so the genesis hash would be different on every node and on every start.
+func GenesisDocFromFile(path string) (*cmtypes.GenesisDoc, error) {
+	jsonBlob, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read genesis file: %w", err)
+	}
+	genDoc := new(cmtypes.GenesisDoc)
+	if err := cmjson.Unmarshal(jsonBlob, genDoc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidGenesis, err)
+	}
+	if genDoc.GenesisTime.IsZero() {
+		return nil, fmt.Errorf("%w: genesis_time is missing: set it to the time the chain starts", ErrInvalidGenesis)
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidGenesis, err)
+	}
+	return genDoc, nil
+}
+
+// GenesisHash returns canonical hash of genesis document. The hash doesn't depend on formatting of the genesis
+// file, order of keys in app state or on initial height and consensus params completed when genesis is loaded,
+// so all nodes of the chain compute the same hash. Nodes compare it to detect that they were started with a
+// different genesis. Genesis time is hashed as it is: ValidateAndComplete sets missing genesis time to current
+// time, so genesis without genesis time must be hashed before it's completed.
+func GenesisHash(genDoc *cmtypes.GenesisDoc) (Hash, error) {
+	// defaults are completed the same way as in ValidateAndComplete, except genesis time
+	doc := *genDoc
+	if doc.InitialHeight == 0 {
+		doc.InitialHeight = 1
+	}
+	if doc.ConsensusParams == nil {
+		doc.ConsensusParams = cmtypes.DefaultConsensusParams()
+	}
+	doc.Validators = make([]cmtypes.GenesisValidator, len(genDoc.Validators))
+	for i, v := range genDoc.Validators {
+		if len(v.Address) == 0 && v.PubKey != nil {
+			v.Address = v.PubKey.Address()
+		}
+		doc.Validators[i] = v
+	}
+
+	encoded, err := cmjson.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode genesis: %w", err)
+	}
+	canonical, err := canonicalJSON(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize genesis: %w", err)
+	}
+	hash := sha256.Sum256(canonical)
+	return hash[:], nil
+}
+
+// canonicalJSON re-encodes JSON without whitespace and with keys of objects sorted. Numbers are kept as they are.
+func canonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	genDoc, _ := GetGenesisWithPrivkey("ed25519", "TestValidateGenesis")
+	require.NoError(t, ValidateGenesis(genDoc))
+	assert.ErrorIs(t, ValidateGenesis(nil), ErrInvalidGenesis)
+
+	otherKey := ed25519.GenPrivKey().PubKey()
+	cases := []struct {
+		name   string
+		modify func(*cmtypes.GenesisDoc)
+		errors []string
+	}{
+		{"empty chain ID", func(g *cmtypes.GenesisDoc) { g.ChainID = "" }, []string{"chain_id is empty"}},
+		{"no genesis time", func(g *cmtypes.GenesisDoc) { g.GenesisTime = time.Time{} }, []string{"genesis_time is missing"}},
+		{"no validators", func(g *cmtypes.GenesisDoc) { g.Validators = nil }, []string{"validators are empty"}},
+		{"many validators", func(g *cmtypes.GenesisDoc) {
+			g.Validators = append(g.Validators, cmtypes.GenesisValidator{PubKey: otherKey, Power: 1})
+		}, []string{"there are 2 validators"}},
+		{"address mismatch and zero power", func(g *cmtypes.GenesisDoc) {
+			g.Validators[0].Address = otherKey.Address()
+			g.Validators[0].Power = 0
+		}, []string{"doesn't match its pub_key", "power is 0"}},
+		{"invalid app state", func(g *cmtypes.GenesisDoc) { g.AppState = json.RawMessage("{") }, []string{"app_state is not valid JSON"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			doc := *genDoc
+			doc.Validators = append([]cmtypes.GenesisValidator(nil), genDoc.Validators...)
+			c.modify(&doc)
+			err := ValidateGenesis(&doc)
+			require.ErrorIs(t, err, ErrInvalidGenesis)
+			for _, msg := range c.errors {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func TestGenesisHash(t *testing.T) {
+	genDoc, _ := GetGenesisWithPrivkey("ed25519", "TestGenesisHash")
+	genDoc.AppState = json.RawMessage(`{"b": 1, "a": {"y": "2", "x": 1.50}}`)
+	hash, err := GenesisHash(genDoc)
+	require.NoError(t, err)
+	assert.Len(t, hash, 32)
+
+	// formatting, key order and completed defaults don't change the hash
+	same := *genDoc
+	same.AppState = json.RawMessage(`{"a":{"x":1.50,"y":"2"},"b":1}`)
+	same.InitialHeight = 1
+	same.ConsensusParams = cmtypes.DefaultConsensusParams()
+	same.Validators = []cmtypes.GenesisValidator{genDoc.Validators[0]}
+	same.Validators[0].Address = nil
+	sameHash, err := GenesisHash(&same)
+	require.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	other := *genDoc
+	other.ChainID = "other"
+	otherHash, err := GenesisHash(&other)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+
+	other = *genDoc
+	other.AppState = json.RawMessage(`{"a":{"x":1.5,"y":"2"},"b":1}`)
+	otherHash, err = GenesisHash(&other)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash, "numbers are not normalized")
+}
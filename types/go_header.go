@@ -0,0 +1,19 @@
+//go:build !js
+
+package types
+
+import (
+	"github.com/celestiaorg/go-header"
+)
+
+// Hash is a 32-byte array which is used to represent a hash result.
+//
+// Headers and block data implement the header interface of go-header, to be synced over P2P, so Hash is the hash
+// type of go-header. It's defined separately in js builds, which exclude go-header and its libp2p dependencies.
+type Hash = header.Hash
+
+// VerifyError is returned when verification of a header against a trusted header fails.
+type VerifyError = header.VerifyError
+
+var _ header.Header[*Header] = &Header{}
+var _ header.Header[*SignedHeader] = &SignedHeader{}
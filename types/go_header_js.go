@@ -0,0 +1,55 @@
+//go:build js
+
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Hash is a 32-byte array which is used to represent a hash result.
+//
+// It has the same encoding as the hash type of go-header, which is used in other builds.
+type Hash []byte
+
+// String implements fmt.Stringer interface.
+func (h Hash) String() string {
+	return strings.ToUpper(hex.EncodeToString(h))
+}
+
+// MarshalJSON serializes Hash into upper case hex string.
+func (h Hash) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + h.String() + `"`), nil
+}
+
+// UnmarshalJSON deserializes Hash from hex string.
+func (h *Hash) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("invalid hex string: %s", data)
+	}
+	b, err := hex.DecodeString(string(data[1 : len(data)-1]))
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+// VerifyError is returned when verification of a header against a trusted header fails.
+//
+// It mirrors the verification error of go-header, which is used in other builds.
+type VerifyError struct {
+	// Reason why verification failed.
+	Reason error
+	// SoftFailure means verification didn't have enough information to conclude if header is correct.
+	SoftFailure bool
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("header verification failed: %s", e.Reason)
+}
+
+func (e *VerifyError) Unwrap() error {
+	return e.Reason
+}
@@ -47,7 +47,11 @@ func (h *Header) Hash() Hash {
 func (d *Data) Hash() Hash {
 	// Ignoring the marshal error for now to satisfy the go-header interface
 	// Later on the usage of Hash should be replaced with DA commitment
-	dBytes, _ := d.MarshalBinary()
+	dBytes, release, err := d.MarshalPooled()
+	if err != nil {
+		return merkle.HashFromByteSlices([][]byte{nil})
+	}
+	defer release()
 	return merkle.HashFromByteSlices([][]byte{
 		dBytes,
 	})
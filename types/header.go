@@ -10,13 +10,9 @@ import (
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	cmtypes "github.com/cometbft/cometbft/types"
 
-	"github.com/celestiaorg/go-header"
 	cmbytes "github.com/cometbft/cometbft/libs/bytes"
 )
 
-// Hash is a 32-byte array which is used to represent a hash result.
-type Hash = header.Hash
-
 var (
 	// ErrNoProposerAddress is returned when the proposer address is not set.
 	ErrNoProposerAddress = errors.New("no proposer address")
@@ -98,7 +94,7 @@ func (h *Header) Time() time.Time {
 // Verify verifies the header.
 func (h *Header) Verify(untrstH *Header) error {
 	if !bytes.Equal(untrstH.ProposerAddress, h.ProposerAddress) {
-		return &header.VerifyError{
+		return &VerifyError{
 			Reason: fmt.Errorf("%w: expected proposer (%X) got (%X)",
 				ErrProposerVerificationFailed,
 				h.ProposerAddress,
@@ -146,6 +142,5 @@ func (h *Header) MakeCometBFTVote() []byte {
 	return consensusVoteBytes
 }
 
-var _ header.Header[*Header] = &Header{}
 var _ encoding.BinaryMarshaler = &Header{}
 var _ encoding.BinaryUnmarshaler = &Header{}
@@ -0,0 +1,203 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	cmcrypto "github.com/cometbft/cometbft/crypto"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// Prefixes of transactions carrying messages between rollups. They are unlikely to be prefixes of application
+// transactions.
+var (
+	// outboundMessageTxPrefix marks a transaction carrying a message to another rollup. Application includes
+	// outbound messages in blocks (e.g. in PrepareProposal); they are posted to DA with the block.
+	outboundMessageTxPrefix = []byte("rollkit/xmsg/out/v1\x00")
+	// inboundMessageTxPrefix marks a transaction carrying a verified message from another rollup. Inbound
+	// messages are included in blocks by the aggregator only.
+	inboundMessageTxPrefix = []byte("rollkit/xmsg/in/v1\x00")
+)
+
+// ErrInvalidMessageProof is returned when a proof of outbound messages can't be verified.
+var ErrInvalidMessageProof = errors.New("invalid message proof")
+
+// OutboundMessage is a message sent to another rollup.
+type OutboundMessage struct {
+	// Destination is the chain ID of the receiving rollup.
+	Destination string `json:"destination"`
+	Payload     []byte `json:"payload"`
+}
+
+// InboundMessage is a message received from another rollup. It's identified by the height of the source block
+// and the index of the outbound message transaction in the block.
+type InboundMessage struct {
+	SourceChainID string `json:"source_chain_id"`
+	SourceHeight  uint64 `json:"source_height"`
+	Index         uint32 `json:"index"`
+	Payload       []byte `json:"payload"`
+}
+
+// ToProto converts OutboundMessage into protobuf representation and returns it.
+func (m *OutboundMessage) ToProto() *pb.OutboundMessage {
+	return &pb.OutboundMessage{Destination: m.Destination, Payload: m.Payload}
+}
+
+// FromProto fills OutboundMessage with data from its protobuf representation.
+func (m *OutboundMessage) FromProto(other *pb.OutboundMessage) {
+	*m = OutboundMessage{Destination: other.Destination, Payload: other.Payload}
+}
+
+// ToProto converts InboundMessage into protobuf representation and returns it.
+func (m *InboundMessage) ToProto() *pb.InboundMessage {
+	return &pb.InboundMessage{
+		SourceChainId: m.SourceChainID,
+		SourceHeight:  m.SourceHeight,
+		Index:         m.Index,
+		Payload:       m.Payload,
+	}
+}
+
+// FromProto fills InboundMessage with data from its protobuf representation.
+func (m *InboundMessage) FromProto(other *pb.InboundMessage) {
+	*m = InboundMessage{
+		SourceChainID: other.SourceChainId,
+		SourceHeight:  other.SourceHeight,
+		Index:         other.Index,
+		Payload:       other.Payload,
+	}
+}
+
+// NewOutboundMessageTx wraps message in a transaction, which can be included in a block by the application.
+func NewOutboundMessageTx(msg OutboundMessage) (Tx, error) {
+	if msg.Destination == "" {
+		return nil, errors.New("message destination must be set")
+	}
+	b, err := msg.ToProto().Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes.Clone(outboundMessageTxPrefix), b...), nil
+}
+
+// IsOutboundMessageTx returns true if tx carries a message to another rollup.
+func IsOutboundMessageTx(tx Tx) bool {
+	return bytes.HasPrefix(tx, outboundMessageTxPrefix)
+}
+
+// ParseOutboundMessageTx returns the message carried by outbound message transaction.
+func ParseOutboundMessageTx(tx Tx) (OutboundMessage, error) {
+	var msg OutboundMessage
+	if !IsOutboundMessageTx(tx) {
+		return msg, errors.New("not an outbound message transaction")
+	}
+	var pMsg pb.OutboundMessage
+	if err := pMsg.Unmarshal(tx[len(outboundMessageTxPrefix):]); err != nil {
+		return msg, fmt.Errorf("malformed message: %w", err)
+	}
+	msg.FromProto(&pMsg)
+	if msg.Destination == "" {
+		return msg, errors.New("malformed message: no destination")
+	}
+	return msg, nil
+}
+
+// NewInboundMessageTx wraps verified message in a transaction, delivered to the application in a block.
+func NewInboundMessageTx(msg InboundMessage) Tx {
+	// encoding of messages with scalar fields only never fails
+	b, _ := msg.ToProto().Marshal()
+	return append(bytes.Clone(inboundMessageTxPrefix), b...)
+}
+
+// IsInboundMessageTx returns true if tx carries a message received from another rollup.
+func IsInboundMessageTx(tx Tx) bool {
+	return bytes.HasPrefix(tx, inboundMessageTxPrefix)
+}
+
+// ParseInboundMessageTx returns the message carried by inbound message transaction.
+func ParseInboundMessageTx(tx Tx) (InboundMessage, error) {
+	var msg InboundMessage
+	if !IsInboundMessageTx(tx) {
+		return msg, errors.New("not an inbound message transaction")
+	}
+	var pMsg pb.InboundMessage
+	if err := pMsg.Unmarshal(tx[len(inboundMessageTxPrefix):]); err != nil {
+		return msg, fmt.Errorf("malformed message: %w", err)
+	}
+	msg.FromProto(&pMsg)
+	return msg, nil
+}
+
+// Before returns true if message precedes the message at given source height and index.
+func (m InboundMessage) Before(height uint64, index uint32) bool {
+	return m.SourceHeight < height || (m.SourceHeight == height && m.Index < index)
+}
+
+// MessageProof proves that outbound messages were included in a block of the source rollup: it contains the block
+// and the DA height at which its header was included. Proof is self-contained except for DA inclusion, which is
+// verified by retrieving headers at DAHeight from the namespace of the source rollup.
+type MessageProof struct {
+	DAHeight uint64 `json:"da_height"`
+	// Header and Data of the block, encoded with MarshalBinary.
+	Header []byte `json:"header"`
+	Data   []byte `json:"data"`
+}
+
+// NewMessageProof creates proof of outbound messages included in the block.
+func NewMessageProof(daHeight uint64, header *SignedHeader, data *Data) (*MessageProof, error) {
+	p := &MessageProof{DAHeight: daHeight}
+	var err error
+	if p.Header, err = header.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if p.Data, err = data.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Verify checks that the block of the proof belongs to the source chain, is signed by the sequencer with given
+// public key and matches the data. It returns the header and messages of the block sent to destination chain.
+// DA inclusion of the header must be checked by the caller.
+func (p *MessageProof) Verify(sourceChainID string, pubKey cmcrypto.PubKey, destination string) (*SignedHeader, []InboundMessage, error) {
+	header, data := new(SignedHeader), new(Data)
+	if err := header.UnmarshalBinary(p.Header); err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid header: %w", ErrInvalidMessageProof, err)
+	}
+	if err := data.UnmarshalBinary(p.Data); err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid data: %w", ErrInvalidMessageProof, err)
+	}
+	if err := header.ValidateBasic(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidMessageProof, err)
+	}
+	if !header.Validators.Validators[0].PubKey.Equals(pubKey) {
+		return nil, nil, fmt.Errorf("%w: header is not signed by the source sequencer", ErrInvalidMessageProof)
+	}
+	if header.ChainID() != sourceChainID {
+		return nil, nil, fmt.Errorf("%w: chain ID %q, expected %q", ErrInvalidMessageProof, header.ChainID(), sourceChainID)
+	}
+	if err := Validate(header, data); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidMessageProof, err)
+	}
+
+	var msgs []InboundMessage
+	for i, tx := range data.Txs {
+		if !IsOutboundMessageTx(tx) {
+			continue
+		}
+		// malformed messages can't be rejected by the source chain after the block is produced, they are skipped
+		msg, err := ParseOutboundMessageTx(tx)
+		if err != nil || msg.Destination != destination {
+			continue
+		}
+		msgs = append(msgs, InboundMessage{
+			SourceChainID: sourceChainID,
+			SourceHeight:  header.Height(),
+			Index:         uint32(i), //nolint:gosec
+			Payload:       msg.Payload,
+		})
+	}
+	return header, msgs, nil
+}
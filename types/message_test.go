@@ -0,0 +1,75 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageTxs(t *testing.T) {
+	require := require.New(t)
+
+	out := OutboundMessage{Destination: "dest", Payload: []byte("payload")}
+	tx, err := NewOutboundMessageTx(out)
+	require.NoError(err)
+	require.True(IsOutboundMessageTx(tx))
+	require.False(IsInboundMessageTx(tx))
+	parsed, err := ParseOutboundMessageTx(tx)
+	require.NoError(err)
+	require.Equal(out, parsed)
+	_, err = NewOutboundMessageTx(OutboundMessage{Payload: []byte("payload")})
+	require.Error(err)
+	_, err = ParseOutboundMessageTx(append(tx, 0xff))
+	require.Error(err)
+
+	in := InboundMessage{SourceChainID: "source", SourceHeight: 7, Index: 3, Payload: []byte("payload")}
+	tx = NewInboundMessageTx(in)
+	require.True(IsInboundMessageTx(tx))
+	decoded, err := ParseInboundMessageTx(tx)
+	require.NoError(err)
+	require.Equal(in, decoded)
+	_, err = ParseInboundMessageTx(GetRandomTx())
+	require.Error(err)
+
+	require.True(in.Before(8, 0))
+	require.True(in.Before(7, 4))
+	require.False(in.Before(7, 3))
+	require.False(in.Before(6, 10))
+}
+
+func TestMessageProof(t *testing.T) {
+	require := require.New(t)
+	const source = "TestMessageProof"
+
+	header, data, privKey := GenerateRandomBlockCustom(&BlockConfig{Height: 5, NTxs: 1}, source)
+	toDest, err := NewOutboundMessageTx(OutboundMessage{Destination: "dest", Payload: []byte("1")})
+	require.NoError(err)
+	toOther, err := NewOutboundMessageTx(OutboundMessage{Destination: "other", Payload: []byte("2")})
+	require.NoError(err)
+	data.Txs = append(data.Txs, toOther, toDest)
+	header.DataHash = (&Data{Txs: data.Txs}).Hash()
+	signature, err := GetSignature(header.Header, privKey)
+	require.NoError(err)
+	header.Signature = *signature
+
+	proof, err := NewMessageProof(10, header, data)
+	require.NoError(err)
+	verified, msgs, err := proof.Verify(source, privKey.PubKey(), "dest")
+	require.NoError(err)
+	require.Equal(header.Hash(), verified.Hash())
+	require.Equal([]InboundMessage{{SourceChainID: source, SourceHeight: 5, Index: 2, Payload: []byte("1")}}, msgs)
+
+	_, _, err = proof.Verify(source, ed25519.GenPrivKey().PubKey(), "dest")
+	assert.ErrorIs(t, err, ErrInvalidMessageProof)
+	_, _, err = proof.Verify("other", privKey.PubKey(), "dest")
+	assert.ErrorIs(t, err, ErrInvalidMessageProof)
+
+	// data not matching the header
+	data.Txs = data.Txs[:2]
+	proof, err = NewMessageProof(10, header, data)
+	require.NoError(err)
+	_, _, err = proof.Verify(source, privKey.PubKey(), "dest")
+	assert.ErrorIs(t, err, ErrInvalidMessageProof)
+}
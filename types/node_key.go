@@ -0,0 +1,52 @@
+//go:build !js
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	cmcrypto "github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// Conversion of keys depends on CometBFT p2p package, which doesn't build for js/wasm, so it's excluded from
+// builds of types for browser light clients.
+
+var (
+	errNilKey             = errors.New("key can't be nil")
+	errUnsupportedKeyType = errors.New("unsupported key type")
+)
+
+// GetNodeKey creates libp2p private key from Tendermints NodeKey.
+func GetNodeKey(nodeKey *p2p.NodeKey) (crypto.PrivKey, error) {
+	if nodeKey == nil || nodeKey.PrivKey == nil {
+		return nil, errNilKey
+	}
+	switch nodeKey.PrivKey.Type() {
+	case "ed25519":
+		privKey, err := crypto.UnmarshalEd25519PrivateKey(nodeKey.PrivKey.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling node private key: %w", err)
+		}
+		return privKey, nil
+	case "secp256k1":
+		privKey, err := crypto.UnmarshalSecp256k1PrivateKey(nodeKey.PrivKey.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling node private key: %w", err)
+		}
+		return privKey, nil
+	default:
+		return nil, errUnsupportedKeyType
+	}
+}
+
+// PrivKeyToSigningKey converts a privKey to a signing key
+func PrivKeyToSigningKey(privKey cmcrypto.PrivKey) (crypto.PrivKey, error) {
+	nodeKey := &p2p.NodeKey{
+		PrivKey: privKey,
+	}
+	signingKey, err := GetNodeKey(nodeKey)
+	return signingKey, err
+}
@@ -0,0 +1,1358 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: rollkit/replay.proto
+
+package rollkit
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/cometbft/cometbft/abci/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ReplayEventsRequest struct {
+	// from_height equal to 0 means the earliest available height.
+	FromHeight uint64 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	// to_height equal to 0 means the latest height.
+	ToHeight uint64 `protobuf:"varint,2,opt,name=to_height,json=toHeight,proto3" json:"to_height,omitempty"`
+	// query uses CometBFT event query syntax, empty query matches all events.
+	Query string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (m *ReplayEventsRequest) Reset()         { *m = ReplayEventsRequest{} }
+func (m *ReplayEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplayEventsRequest) ProtoMessage()    {}
+func (*ReplayEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d344f1e3edec2a4d, []int{0}
+}
+func (m *ReplayEventsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplayEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplayEventsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReplayEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayEventsRequest.Merge(m, src)
+}
+func (m *ReplayEventsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReplayEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayEventsRequest proto.InternalMessageInfo
+
+func (m *ReplayEventsRequest) GetFromHeight() uint64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
+func (m *ReplayEventsRequest) GetToHeight() uint64 {
+	if m != nil {
+		return m.ToHeight
+	}
+	return 0
+}
+
+func (m *ReplayEventsRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+type ReplayEventsResponse struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// tx_hash is empty for block events.
+	TxHash  []byte        `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	TxIndex uint32        `protobuf:"varint,3,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	Events  []types.Event `protobuf:"bytes,4,rep,name=events,proto3" json:"events"`
+}
+
+func (m *ReplayEventsResponse) Reset()         { *m = ReplayEventsResponse{} }
+func (m *ReplayEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplayEventsResponse) ProtoMessage()    {}
+func (*ReplayEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d344f1e3edec2a4d, []int{1}
+}
+func (m *ReplayEventsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplayEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplayEventsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReplayEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayEventsResponse.Merge(m, src)
+}
+func (m *ReplayEventsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReplayEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayEventsResponse proto.InternalMessageInfo
+
+func (m *ReplayEventsResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReplayEventsResponse) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *ReplayEventsResponse) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *ReplayEventsResponse) GetEvents() []types.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type SubscribeTxFinalityRequest struct {
+	TxHash []byte `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+}
+
+func (m *SubscribeTxFinalityRequest) Reset()         { *m = SubscribeTxFinalityRequest{} }
+func (m *SubscribeTxFinalityRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeTxFinalityRequest) ProtoMessage()    {}
+func (*SubscribeTxFinalityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d344f1e3edec2a4d, []int{2}
+}
+func (m *SubscribeTxFinalityRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeTxFinalityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeTxFinalityRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeTxFinalityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeTxFinalityRequest.Merge(m, src)
+}
+func (m *SubscribeTxFinalityRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeTxFinalityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeTxFinalityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeTxFinalityRequest proto.InternalMessageInfo
+
+func (m *SubscribeTxFinalityRequest) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+type TxFinalityResponse struct {
+	TxHash []byte `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	// stage is one of "sequenced", "soft_block" or "da_included".
+	Stage string `protobuf:"bytes,2,opt,name=stage,proto3" json:"stage,omitempty"`
+	// height and tx_index are set once transaction is included in a block.
+	Height  uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	TxIndex uint32 `protobuf:"varint,4,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+}
+
+func (m *TxFinalityResponse) Reset()         { *m = TxFinalityResponse{} }
+func (m *TxFinalityResponse) String() string { return proto.CompactTextString(m) }
+func (*TxFinalityResponse) ProtoMessage()    {}
+func (*TxFinalityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d344f1e3edec2a4d, []int{3}
+}
+func (m *TxFinalityResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxFinalityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxFinalityResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxFinalityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxFinalityResponse.Merge(m, src)
+}
+func (m *TxFinalityResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxFinalityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxFinalityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxFinalityResponse proto.InternalMessageInfo
+
+func (m *TxFinalityResponse) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *TxFinalityResponse) GetStage() string {
+	if m != nil {
+		return m.Stage
+	}
+	return ""
+}
+
+func (m *TxFinalityResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *TxFinalityResponse) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ReplayEventsRequest)(nil), "rollkit.ReplayEventsRequest")
+	proto.RegisterType((*ReplayEventsResponse)(nil), "rollkit.ReplayEventsResponse")
+	proto.RegisterType((*SubscribeTxFinalityRequest)(nil), "rollkit.SubscribeTxFinalityRequest")
+	proto.RegisterType((*TxFinalityResponse)(nil), "rollkit.TxFinalityResponse")
+}
+
+func init() { proto.RegisterFile("rollkit/replay.proto", fileDescriptor_d344f1e3edec2a4d) }
+
+var fileDescriptor_d344f1e3edec2a4d = []byte{
+	// 427 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0xcd, 0x92, 0xe0, 0x34, 0x93, 0x72, 0xd9, 0x5a, 0x25, 0x38, 0xe0, 0x46, 0xe6, 0x12, 0x09,
+	0xc9, 0xae, 0x0a, 0xfc, 0x40, 0xa5, 0xa2, 0x72, 0xe0, 0xb2, 0x70, 0x81, 0x4b, 0x64, 0xa7, 0x8b,
+	0xbd, 0xc2, 0xf1, 0xba, 0xde, 0x71, 0x65, 0xff, 0x05, 0x47, 0xfe, 0x84, 0x5f, 0xe8, 0xb1, 0x47,
+	0x4e, 0x08, 0x25, 0x3f, 0x82, 0xbc, 0x76, 0x82, 0x2d, 0x92, 0x93, 0x3d, 0xef, 0x8d, 0xe7, 0xcd,
+	0x7b, 0x1e, 0x30, 0x33, 0x19, 0xc7, 0xdf, 0x04, 0x7a, 0x19, 0x4f, 0x63, 0xbf, 0x74, 0xd3, 0x4c,
+	0xa2, 0xa4, 0xc3, 0x06, 0xb5, 0xcc, 0x50, 0x86, 0x52, 0x63, 0x5e, 0xf5, 0x56, 0xd3, 0xd6, 0x14,
+	0x79, 0x72, 0xc3, 0xb3, 0x95, 0x48, 0xd0, 0xf3, 0x83, 0xa5, 0xf0, 0xb0, 0x4c, 0xb9, 0xaa, 0x49,
+	0x47, 0xc0, 0x09, 0xd3, 0xb3, 0xae, 0xee, 0x78, 0x82, 0x8a, 0xf1, 0xdb, 0x9c, 0x2b, 0xa4, 0x67,
+	0x30, 0xfe, 0x9a, 0xc9, 0xd5, 0x22, 0xe2, 0x22, 0x8c, 0x70, 0x42, 0x66, 0x64, 0x3e, 0x60, 0x50,
+	0x41, 0xd7, 0x1a, 0xa1, 0x53, 0x18, 0xa1, 0xdc, 0xd2, 0x8f, 0x34, 0x7d, 0x84, 0xb2, 0x21, 0x4d,
+	0x78, 0x7c, 0x9b, 0xf3, 0xac, 0x9c, 0xf4, 0x67, 0x64, 0x3e, 0x62, 0x75, 0xe1, 0xfc, 0x20, 0x60,
+	0x76, 0xb5, 0x54, 0x2a, 0x13, 0xc5, 0xe9, 0x29, 0x18, 0x1d, 0x9d, 0xa6, 0xa2, 0x4f, 0x61, 0x88,
+	0xc5, 0x22, 0xf2, 0x55, 0xa4, 0x15, 0x8e, 0x99, 0x81, 0xc5, 0xb5, 0xaf, 0x22, 0xfa, 0x0c, 0x8e,
+	0xb0, 0x58, 0x88, 0xe4, 0x86, 0x17, 0x5a, 0xe2, 0x09, 0x1b, 0x62, 0xf1, 0xbe, 0x2a, 0xe9, 0x1b,
+	0x30, 0xb8, 0x9e, 0x3e, 0x19, 0xcc, 0xfa, 0xf3, 0xf1, 0xc5, 0xa9, 0xfb, 0xcf, 0xbd, 0x5b, 0xb9,
+	0x77, 0xb5, 0xf8, 0xe5, 0xe0, 0xfe, 0xf7, 0x59, 0x8f, 0x35, 0xbd, 0xce, 0x5b, 0xb0, 0x3e, 0xe6,
+	0x81, 0x5a, 0x66, 0x22, 0xe0, 0x9f, 0x8a, 0x77, 0x22, 0xf1, 0x63, 0x81, 0xe5, 0x36, 0x8c, 0xd6,
+	0x1e, 0xa4, 0xbd, 0x87, 0x73, 0x07, 0xb4, 0xdd, 0xdd, 0xd8, 0x39, 0xd4, 0x5e, 0xc5, 0xa2, 0xd0,
+	0x0f, 0xb9, 0x76, 0x33, 0x62, 0x75, 0xd1, 0x72, 0xdf, 0xef, 0xb8, 0x6f, 0x9b, 0x1c, 0x74, 0x4c,
+	0x5e, 0xfc, 0x24, 0x30, 0xd6, 0x36, 0xea, 0x38, 0xe9, 0x07, 0x38, 0x6e, 0x07, 0x4b, 0x9f, 0xbb,
+	0xcd, 0x45, 0xb8, 0x7b, 0xfe, 0xad, 0xf5, 0xe2, 0x00, 0x5b, 0xaf, 0x7f, 0x4e, 0xe8, 0x67, 0x38,
+	0xd9, 0x93, 0x06, 0x7d, 0xb9, 0xfb, 0xee, 0x70, 0x56, 0xd6, 0x74, 0xd7, 0xf4, 0x7f, 0x32, 0xe7,
+	0xe4, 0xf2, 0xea, 0x7e, 0x6d, 0x93, 0x87, 0xb5, 0x4d, 0xfe, 0xac, 0x6d, 0xf2, 0x7d, 0x63, 0xf7,
+	0x1e, 0x36, 0x76, 0xef, 0xd7, 0xc6, 0xee, 0x7d, 0x79, 0x15, 0x0a, 0x8c, 0xf2, 0xc0, 0x5d, 0xca,
+	0x95, 0xb7, 0xbb, 0xf2, 0xe6, 0xa9, 0x0f, 0xd6, 0x4b, 0x83, 0x2d, 0x10, 0x18, 0xfa, 0x78, 0x5f,
+	0xff, 0x1d, 0x00, 0x21, 0xfa, 0xed, 0x04, 0x10, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// EventReplayClient is the client API for EventReplay service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EventReplayClient interface {
+	// ReplayEvents streams events from blocks in [from_height, to_height] range, matching the query.
+	ReplayEvents(ctx context.Context, in *ReplayEventsRequest, opts ...grpc.CallOption) (EventReplay_ReplayEventsClient, error)
+	// SubscribeTxFinality streams finality milestones reached by the transaction. Stream is closed after
+	// the final milestone.
+	SubscribeTxFinality(ctx context.Context, in *SubscribeTxFinalityRequest, opts ...grpc.CallOption) (EventReplay_SubscribeTxFinalityClient, error)
+}
+
+type eventReplayClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEventReplayClient(cc *grpc.ClientConn) EventReplayClient {
+	return &eventReplayClient{cc}
+}
+
+func (c *eventReplayClient) ReplayEvents(ctx context.Context, in *ReplayEventsRequest, opts ...grpc.CallOption) (EventReplay_ReplayEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventReplay_serviceDesc.Streams[0], "/rollkit.EventReplay/ReplayEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventReplayReplayEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventReplay_ReplayEventsClient interface {
+	Recv() (*ReplayEventsResponse, error)
+	grpc.ClientStream
+}
+
+type eventReplayReplayEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventReplayReplayEventsClient) Recv() (*ReplayEventsResponse, error) {
+	m := new(ReplayEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *eventReplayClient) SubscribeTxFinality(ctx context.Context, in *SubscribeTxFinalityRequest, opts ...grpc.CallOption) (EventReplay_SubscribeTxFinalityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventReplay_serviceDesc.Streams[1], "/rollkit.EventReplay/SubscribeTxFinality", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventReplaySubscribeTxFinalityClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventReplay_SubscribeTxFinalityClient interface {
+	Recv() (*TxFinalityResponse, error)
+	grpc.ClientStream
+}
+
+type eventReplaySubscribeTxFinalityClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventReplaySubscribeTxFinalityClient) Recv() (*TxFinalityResponse, error) {
+	m := new(TxFinalityResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventReplayServer is the server API for EventReplay service.
+type EventReplayServer interface {
+	// ReplayEvents streams events from blocks in [from_height, to_height] range, matching the query.
+	ReplayEvents(*ReplayEventsRequest, EventReplay_ReplayEventsServer) error
+	// SubscribeTxFinality streams finality milestones reached by the transaction. Stream is closed after
+	// the final milestone.
+	SubscribeTxFinality(*SubscribeTxFinalityRequest, EventReplay_SubscribeTxFinalityServer) error
+}
+
+// UnimplementedEventReplayServer can be embedded to have forward compatible implementations.
+type UnimplementedEventReplayServer struct {
+}
+
+func (*UnimplementedEventReplayServer) ReplayEvents(req *ReplayEventsRequest, srv EventReplay_ReplayEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReplayEvents not implemented")
+}
+func (*UnimplementedEventReplayServer) SubscribeTxFinality(req *SubscribeTxFinalityRequest, srv EventReplay_SubscribeTxFinalityServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTxFinality not implemented")
+}
+
+func RegisterEventReplayServer(s *grpc.Server, srv EventReplayServer) {
+	s.RegisterService(&_EventReplay_serviceDesc, srv)
+}
+
+func _EventReplay_ReplayEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReplayEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventReplayServer).ReplayEvents(m, &eventReplayReplayEventsServer{stream})
+}
+
+type EventReplay_ReplayEventsServer interface {
+	Send(*ReplayEventsResponse) error
+	grpc.ServerStream
+}
+
+type eventReplayReplayEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventReplayReplayEventsServer) Send(m *ReplayEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EventReplay_SubscribeTxFinality_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTxFinalityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventReplayServer).SubscribeTxFinality(m, &eventReplaySubscribeTxFinalityServer{stream})
+}
+
+type EventReplay_SubscribeTxFinalityServer interface {
+	Send(*TxFinalityResponse) error
+	grpc.ServerStream
+}
+
+type eventReplaySubscribeTxFinalityServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventReplaySubscribeTxFinalityServer) Send(m *TxFinalityResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _EventReplay_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rollkit.EventReplay",
+	HandlerType: (*EventReplayServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReplayEvents",
+			Handler:       _EventReplay_ReplayEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTxFinality",
+			Handler:       _EventReplay_SubscribeTxFinality_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rollkit/replay.proto",
+}
+
+func (m *ReplayEventsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplayEventsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReplayEventsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintReplay(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ToHeight != 0 {
+		i = encodeVarintReplay(dAtA, i, uint64(m.ToHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.FromHeight != 0 {
+		i = encodeVarintReplay(dAtA, i, uint64(m.FromHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReplayEventsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplayEventsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReplayEventsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintReplay(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.TxIndex != 0 {
+		i = encodeVarintReplay(dAtA, i, uint64(m.TxIndex))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.TxHash) > 0 {
+		i -= len(m.TxHash)
+		copy(dAtA[i:], m.TxHash)
+		i = encodeVarintReplay(dAtA, i, uint64(len(m.TxHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintReplay(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeTxFinalityRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeTxFinalityRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeTxFinalityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TxHash) > 0 {
+		i -= len(m.TxHash)
+		copy(dAtA[i:], m.TxHash)
+		i = encodeVarintReplay(dAtA, i, uint64(len(m.TxHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TxFinalityResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxFinalityResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxFinalityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TxIndex != 0 {
+		i = encodeVarintReplay(dAtA, i, uint64(m.TxIndex))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Height != 0 {
+		i = encodeVarintReplay(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Stage) > 0 {
+		i -= len(m.Stage)
+		copy(dAtA[i:], m.Stage)
+		i = encodeVarintReplay(dAtA, i, uint64(len(m.Stage)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TxHash) > 0 {
+		i -= len(m.TxHash)
+		copy(dAtA[i:], m.TxHash)
+		i = encodeVarintReplay(dAtA, i, uint64(len(m.TxHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintReplay(dAtA []byte, offset int, v uint64) int {
+	offset -= sovReplay(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ReplayEventsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromHeight != 0 {
+		n += 1 + sovReplay(uint64(m.FromHeight))
+	}
+	if m.ToHeight != 0 {
+		n += 1 + sovReplay(uint64(m.ToHeight))
+	}
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovReplay(uint64(l))
+	}
+	return n
+}
+
+func (m *ReplayEventsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovReplay(uint64(m.Height))
+	}
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovReplay(uint64(l))
+	}
+	if m.TxIndex != 0 {
+		n += 1 + sovReplay(uint64(m.TxIndex))
+	}
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovReplay(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubscribeTxFinalityRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovReplay(uint64(l))
+	}
+	return n
+}
+
+func (m *TxFinalityResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovReplay(uint64(l))
+	}
+	l = len(m.Stage)
+	if l > 0 {
+		n += 1 + l + sovReplay(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovReplay(uint64(m.Height))
+	}
+	if m.TxIndex != 0 {
+		n += 1 + sovReplay(uint64(m.TxIndex))
+	}
+	return n
+}
+
+func sovReplay(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozReplay(x uint64) (n int) {
+	return sovReplay(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ReplayEventsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReplay
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplayEventsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplayEventsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromHeight", wireType)
+			}
+			m.FromHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToHeight", wireType)
+			}
+			m.ToHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ToHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReplay
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReplay(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReplayEventsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReplay
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplayEventsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplayEventsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReplay
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = append(m.TxHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxHash == nil {
+				m.TxHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
+			}
+			m.TxIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReplay
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, types.Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReplay(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeTxFinalityRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReplay
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeTxFinalityRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeTxFinalityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReplay
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = append(m.TxHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxHash == nil {
+				m.TxHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReplay(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxFinalityResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReplay
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxFinalityResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxFinalityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReplay
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = append(m.TxHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxHash == nil {
+				m.TxHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReplay
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stage = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
+			}
+			m.TxIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReplay(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReplay
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipReplay(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowReplay
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowReplay
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthReplay
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupReplay
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthReplay
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthReplay        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowReplay          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupReplay = fmt.Errorf("proto: unexpected end of group")
+)
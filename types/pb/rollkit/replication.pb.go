@@ -0,0 +1,1015 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: rollkit/replication.proto
+
+package rollkit
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/cometbft/cometbft/abci/types"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ReplicateRequest struct {
+	// from_height is the height of the first entry to stream, set only in the first request.
+	FromHeight uint64 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	// ack_height acknowledges that all entries up to given height were persisted by the follower.
+	AckHeight uint64 `protobuf:"varint,2,opt,name=ack_height,json=ackHeight,proto3" json:"ack_height,omitempty"`
+}
+
+func (m *ReplicateRequest) Reset()         { *m = ReplicateRequest{} }
+func (m *ReplicateRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplicateRequest) ProtoMessage()    {}
+func (*ReplicateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8fb2a52c90d75269, []int{0}
+}
+func (m *ReplicateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplicateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplicateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReplicateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicateRequest.Merge(m, src)
+}
+func (m *ReplicateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReplicateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicateRequest proto.InternalMessageInfo
+
+func (m *ReplicateRequest) GetFromHeight() uint64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
+func (m *ReplicateRequest) GetAckHeight() uint64 {
+	if m != nil {
+		return m.AckHeight
+	}
+	return 0
+}
+
+// Entry contains everything stored by the primary node for a single block.
+type Entry struct {
+	Height uint64        `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Header *SignedHeader `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// data is encoded rollkit.Data. It's kept encoded, so that data sections unknown to the follower are
+	// persisted unchanged and the data hash matches the header.
+	Data      []byte                       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Signature []byte                       `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Responses *types.ResponseFinalizeBlock `protobuf:"bytes,5,opt,name=responses,proto3" json:"responses,omitempty"`
+	// extended_commit is set only if vote extensions are enabled.
+	ExtendedCommit *types.ExtendedCommitInfo `protobuf:"bytes,6,opt,name=extended_commit,json=extendedCommit,proto3" json:"extended_commit,omitempty"`
+	// state is set only if it was committed at this height; primary keeps only the latest state.
+	State            *State `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	DaIncludedHeight uint64 `protobuf:"varint,8,opt,name=da_included_height,json=daIncludedHeight,proto3" json:"da_included_height,omitempty"`
+}
+
+func (m *Entry) Reset()         { *m = Entry{} }
+func (m *Entry) String() string { return proto.CompactTextString(m) }
+func (*Entry) ProtoMessage()    {}
+func (*Entry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8fb2a52c90d75269, []int{1}
+}
+func (m *Entry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Entry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Entry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Entry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Entry.Merge(m, src)
+}
+func (m *Entry) XXX_Size() int {
+	return m.Size()
+}
+func (m *Entry) XXX_DiscardUnknown() {
+	xxx_messageInfo_Entry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Entry proto.InternalMessageInfo
+
+func (m *Entry) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Entry) GetHeader() *SignedHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *Entry) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Entry) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Entry) GetResponses() *types.ResponseFinalizeBlock {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+func (m *Entry) GetExtendedCommit() *types.ExtendedCommitInfo {
+	if m != nil {
+		return m.ExtendedCommit
+	}
+	return nil
+}
+
+func (m *Entry) GetState() *State {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+func (m *Entry) GetDaIncludedHeight() uint64 {
+	if m != nil {
+		return m.DaIncludedHeight
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ReplicateRequest)(nil), "rollkit.ReplicateRequest")
+	proto.RegisterType((*Entry)(nil), "rollkit.Entry")
+}
+
+func init() { proto.RegisterFile("rollkit/replication.proto", fileDescriptor_8fb2a52c90d75269) }
+
+var fileDescriptor_8fb2a52c90d75269 = []byte{
+	// 426 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0xeb, 0xd1, 0x76, 0xf4, 0x15, 0x8d, 0xc9, 0x68, 0x28, 0x2b, 0x10, 0xa6, 0x81, 0x50,
+	0x25, 0x20, 0x41, 0xe3, 0xc6, 0x71, 0x50, 0xb4, 0x4a, 0x9c, 0xcc, 0x8d, 0x4b, 0xe5, 0x3a, 0x6f,
+	0xad, 0x95, 0xc4, 0x0e, 0x8e, 0x23, 0x31, 0x3e, 0x05, 0x1f, 0x8b, 0xe3, 0x8e, 0x1c, 0x51, 0x7b,
+	0xe1, 0x63, 0xa0, 0x38, 0xce, 0xa2, 0x75, 0xa7, 0xc4, 0xff, 0xdf, 0xf3, 0xfb, 0xcb, 0xff, 0xf7,
+	0xe0, 0xd8, 0xe8, 0x2c, 0x4b, 0xa5, 0x8d, 0x0d, 0x16, 0x99, 0x14, 0xdc, 0x4a, 0xad, 0xa2, 0xc2,
+	0x68, 0xab, 0xe9, 0xbe, 0x47, 0x93, 0xa3, 0x9b, 0x9a, 0xe6, 0xdb, 0xf0, 0xc9, 0xa3, 0x56, 0x2e,
+	0x2d, 0xb7, 0xe8, 0xc5, 0x27, 0x16, 0x55, 0x82, 0x26, 0x97, 0xca, 0xc6, 0x7c, 0x29, 0x64, 0x6c,
+	0xaf, 0x0a, 0x2c, 0x1b, 0x78, 0xca, 0xe0, 0x90, 0x79, 0x1b, 0x64, 0xf8, 0xbd, 0xc2, 0xd2, 0xd2,
+	0xe7, 0x30, 0xbe, 0x34, 0x3a, 0x5f, 0xac, 0x51, 0xae, 0xd6, 0x36, 0x20, 0x27, 0x64, 0xda, 0x67,
+	0x50, 0x4b, 0x17, 0x4e, 0xa1, 0xcf, 0x00, 0xb8, 0x48, 0x5b, 0xbe, 0xe7, 0xf8, 0x88, 0x8b, 0xb4,
+	0xc1, 0xa7, 0xff, 0xf6, 0x60, 0x30, 0x53, 0xd6, 0x5c, 0xd1, 0xc7, 0x30, 0xbc, 0xd5, 0xc4, 0x9f,
+	0xe8, 0xdb, 0x5a, 0xe7, 0x09, 0x1a, 0x77, 0x79, 0x7c, 0x76, 0x14, 0xb5, 0xef, 0xf8, 0x2a, 0x57,
+	0x0a, 0x93, 0x0b, 0x07, 0x99, 0x2f, 0xa2, 0x14, 0xfa, 0x09, 0xb7, 0x3c, 0xb8, 0x77, 0x42, 0xa6,
+	0x0f, 0x98, 0xfb, 0xa7, 0x4f, 0x61, 0x54, 0xca, 0x95, 0xe2, 0xb6, 0x32, 0x18, 0xf4, 0x1d, 0xe8,
+	0x04, 0xfa, 0x09, 0x46, 0x06, 0xcb, 0x42, 0xab, 0x12, 0xcb, 0x60, 0xe0, 0x3c, 0x5e, 0x45, 0x5d,
+	0x0e, 0x51, 0x9d, 0x43, 0xc4, 0x7c, 0xc5, 0x67, 0xa9, 0x78, 0x26, 0x7f, 0xe2, 0x79, 0xa6, 0x45,
+	0xca, 0xba, 0x8b, 0xf4, 0x0b, 0x3c, 0xc4, 0x1f, 0xee, 0x56, 0xb2, 0x10, 0x3a, 0xcf, 0xa5, 0x0d,
+	0x86, 0xae, 0xd7, 0x8b, 0x3b, 0xbd, 0x66, 0xbe, 0xee, 0xa3, 0x2b, 0x9b, 0xab, 0x4b, 0xcd, 0x0e,
+	0xf0, 0x96, 0x46, 0x5f, 0xc2, 0xc0, 0x8d, 0x25, 0xd8, 0x77, 0x3d, 0x0e, 0xba, 0x37, 0xd7, 0x2a,
+	0x6b, 0x20, 0x7d, 0x03, 0x34, 0xe1, 0x0b, 0xa9, 0x44, 0x56, 0xd5, 0xb6, 0x3e, 0xbe, 0xfb, 0x2e,
+	0xbe, 0xc3, 0x84, 0xcf, 0x3d, 0x68, 0xa2, 0x3e, 0x9b, 0xc3, 0x98, 0x75, 0x5b, 0x42, 0x3f, 0xc0,
+	0xa8, 0x3d, 0x22, 0x3d, 0xbe, 0x31, 0xd8, 0x9d, 0xf0, 0xa4, 0xf3, 0x76, 0x73, 0x9a, 0x92, 0x77,
+	0xe4, 0x7c, 0xf6, 0x7b, 0x13, 0x92, 0xeb, 0x4d, 0x48, 0xfe, 0x6e, 0x42, 0xf2, 0x6b, 0x1b, 0xf6,
+	0xae, 0xb7, 0x61, 0xef, 0xcf, 0x36, 0xec, 0x7d, 0x7b, 0xbd, 0x92, 0x76, 0x5d, 0x2d, 0x23, 0xa1,
+	0xf3, 0x78, 0x67, 0xef, 0x9a, 0x5d, 0x8a, 0x8b, 0x65, 0x2b, 0x2c, 0x87, 0x6e, 0xaf, 0xde, 0xff,
+	0x1f, 0x00, 0x89, 0xce, 0x80, 0xda, 0xc6, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ReplicationClient is the client API for Replication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReplicationClient interface {
+	// Replicate streams entries starting at from_height set in the first request. Follower acknowledges
+	// persisted entries in subsequent requests; primary doesn't send more than a fixed window of entries
+	// ahead of the last acknowledged height.
+	Replicate(ctx context.Context, opts ...grpc.CallOption) (Replication_ReplicateClient, error)
+}
+
+type replicationClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewReplicationClient(cc *grpc.ClientConn) ReplicationClient {
+	return &replicationClient{cc}
+}
+
+func (c *replicationClient) Replicate(ctx context.Context, opts ...grpc.CallOption) (Replication_ReplicateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Replication_serviceDesc.Streams[0], "/rollkit.Replication/Replicate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replicationReplicateClient{stream}
+	return x, nil
+}
+
+type Replication_ReplicateClient interface {
+	Send(*ReplicateRequest) error
+	Recv() (*Entry, error)
+	grpc.ClientStream
+}
+
+type replicationReplicateClient struct {
+	grpc.ClientStream
+}
+
+func (x *replicationReplicateClient) Send(m *ReplicateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *replicationReplicateClient) Recv() (*Entry, error) {
+	m := new(Entry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReplicationServer is the server API for Replication service.
+type ReplicationServer interface {
+	// Replicate streams entries starting at from_height set in the first request. Follower acknowledges
+	// persisted entries in subsequent requests; primary doesn't send more than a fixed window of entries
+	// ahead of the last acknowledged height.
+	Replicate(Replication_ReplicateServer) error
+}
+
+// UnimplementedReplicationServer can be embedded to have forward compatible implementations.
+type UnimplementedReplicationServer struct {
+}
+
+func (*UnimplementedReplicationServer) Replicate(srv Replication_ReplicateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Replicate not implemented")
+}
+
+func RegisterReplicationServer(s *grpc.Server, srv ReplicationServer) {
+	s.RegisterService(&_Replication_serviceDesc, srv)
+}
+
+func _Replication_Replicate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReplicationServer).Replicate(&replicationReplicateServer{stream})
+}
+
+type Replication_ReplicateServer interface {
+	Send(*Entry) error
+	Recv() (*ReplicateRequest, error)
+	grpc.ServerStream
+}
+
+type replicationReplicateServer struct {
+	grpc.ServerStream
+}
+
+func (x *replicationReplicateServer) Send(m *Entry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *replicationReplicateServer) Recv() (*ReplicateRequest, error) {
+	m := new(ReplicateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Replication_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rollkit.Replication",
+	HandlerType: (*ReplicationServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Replicate",
+			Handler:       _Replication_Replicate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "rollkit/replication.proto",
+}
+
+func (m *ReplicateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplicateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReplicateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AckHeight != 0 {
+		i = encodeVarintReplication(dAtA, i, uint64(m.AckHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.FromHeight != 0 {
+		i = encodeVarintReplication(dAtA, i, uint64(m.FromHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Entry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Entry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Entry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DaIncludedHeight != 0 {
+		i = encodeVarintReplication(dAtA, i, uint64(m.DaIncludedHeight))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.State != nil {
+		{
+			size, err := m.State.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReplication(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.ExtendedCommit != nil {
+		{
+			size, err := m.ExtendedCommit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReplication(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Responses != nil {
+		{
+			size, err := m.Responses.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReplication(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintReplication(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintReplication(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReplication(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintReplication(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintReplication(dAtA []byte, offset int, v uint64) int {
+	offset -= sovReplication(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ReplicateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromHeight != 0 {
+		n += 1 + sovReplication(uint64(m.FromHeight))
+	}
+	if m.AckHeight != 0 {
+		n += 1 + sovReplication(uint64(m.AckHeight))
+	}
+	return n
+}
+
+func (m *Entry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovReplication(uint64(m.Height))
+	}
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovReplication(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovReplication(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovReplication(uint64(l))
+	}
+	if m.Responses != nil {
+		l = m.Responses.Size()
+		n += 1 + l + sovReplication(uint64(l))
+	}
+	if m.ExtendedCommit != nil {
+		l = m.ExtendedCommit.Size()
+		n += 1 + l + sovReplication(uint64(l))
+	}
+	if m.State != nil {
+		l = m.State.Size()
+		n += 1 + l + sovReplication(uint64(l))
+	}
+	if m.DaIncludedHeight != 0 {
+		n += 1 + sovReplication(uint64(m.DaIncludedHeight))
+	}
+	return n
+}
+
+func sovReplication(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozReplication(x uint64) (n int) {
+	return sovReplication(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ReplicateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplicateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplicateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromHeight", wireType)
+			}
+			m.FromHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckHeight", wireType)
+			}
+			m.AckHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AckHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Entry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Entry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Entry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &SignedHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReplication
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReplication
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Responses == nil {
+				m.Responses = &types.ResponseFinalizeBlock{}
+			}
+			if err := m.Responses.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExtendedCommit == nil {
+				m.ExtendedCommit = &types.ExtendedCommitInfo{}
+			}
+			if err := m.ExtendedCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReplication
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.State == nil {
+				m.State = &State{}
+			}
+			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DaIncludedHeight", wireType)
+			}
+			m.DaIncludedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DaIncludedHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipReplication(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowReplication
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowReplication
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthReplication
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupReplication
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthReplication
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthReplication        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowReplication          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupReplication = fmt.Errorf("proto: unexpected end of group")
+)
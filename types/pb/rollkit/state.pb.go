@@ -191,51 +191,132 @@ func (m *State) GetAppHash() []byte {
 	return nil
 }
 
+// StateRecord is a compact record of the state after applying block at given height, kept for all heights.
+type StateRecord struct {
+	Height                           uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	AppHash                          []byte `protobuf:"bytes,2,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	LastResultsHash                  []byte `protobuf:"bytes,3,opt,name=last_results_hash,json=lastResultsHash,proto3" json:"last_results_hash,omitempty"`
+	LastHeightConsensusParamsChanged uint64 `protobuf:"varint,4,opt,name=last_height_consensus_params_changed,json=lastHeightConsensusParamsChanged,proto3" json:"last_height_consensus_params_changed,omitempty"`
+	AppVersion                       uint64 `protobuf:"varint,5,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+}
+
+func (m *StateRecord) Reset()         { *m = StateRecord{} }
+func (m *StateRecord) String() string { return proto.CompactTextString(m) }
+func (*StateRecord) ProtoMessage()    {}
+func (*StateRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c88f9697fdbf8e5, []int{1}
+}
+func (m *StateRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StateRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StateRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StateRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateRecord.Merge(m, src)
+}
+func (m *StateRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *StateRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateRecord proto.InternalMessageInfo
+
+func (m *StateRecord) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateRecord) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
+func (m *StateRecord) GetLastResultsHash() []byte {
+	if m != nil {
+		return m.LastResultsHash
+	}
+	return nil
+}
+
+func (m *StateRecord) GetLastHeightConsensusParamsChanged() uint64 {
+	if m != nil {
+		return m.LastHeightConsensusParamsChanged
+	}
+	return 0
+}
+
+func (m *StateRecord) GetAppVersion() uint64 {
+	if m != nil {
+		return m.AppVersion
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*State)(nil), "rollkit.State")
+	proto.RegisterType((*StateRecord)(nil), "rollkit.StateRecord")
 }
 
 func init() { proto.RegisterFile("rollkit/state.proto", fileDescriptor_6c88f9697fdbf8e5) }
 
 var fileDescriptor_6c88f9697fdbf8e5 = []byte{
-	// 579 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6e, 0xd3, 0x4c,
-	0x14, 0x8d, 0xbf, 0xfe, 0x24, 0x99, 0xfc, 0x7d, 0xb8, 0x2c, 0xdc, 0x00, 0x8e, 0x41, 0x20, 0x05,
-	0x90, 0x6c, 0x89, 0xee, 0x91, 0x70, 0x82, 0x68, 0xa4, 0x0a, 0xa1, 0x29, 0xea, 0x82, 0x8d, 0x35,
-	0xb1, 0x07, 0x7b, 0x54, 0xc7, 0x63, 0x79, 0x26, 0x15, 0xbc, 0x45, 0x1f, 0xab, 0xcb, 0x2e, 0x59,
-	0x05, 0x94, 0x3c, 0x05, 0x3b, 0x34, 0x33, 0x1e, 0xc7, 0x34, 0x2c, 0xba, 0x4a, 0xe6, 0xdc, 0x73,
-	0x8e, 0xef, 0xf5, 0xb9, 0x1e, 0x70, 0x54, 0xd0, 0x34, 0xbd, 0x24, 0xdc, 0x63, 0x1c, 0x71, 0xec,
-	0xe6, 0x05, 0xe5, 0xd4, 0x6c, 0x96, 0xe0, 0xf0, 0x61, 0x4c, 0x63, 0x2a, 0x31, 0x4f, 0xfc, 0x53,
-	0xe5, 0xe1, 0x28, 0xa6, 0x34, 0x4e, 0xb1, 0x27, 0x4f, 0xf3, 0xe5, 0x57, 0x8f, 0x93, 0x05, 0x66,
-	0x1c, 0x2d, 0xf2, 0x92, 0xf0, 0x98, 0xe3, 0x2c, 0xc2, 0xc5, 0x82, 0x64, 0xa5, 0xaf, 0xc7, 0xbf,
-	0xe7, 0x98, 0x95, 0xd5, 0x27, 0xb5, 0xaa, 0xc4, 0xbd, 0x1c, 0x15, 0x68, 0xc1, 0xfe, 0x21, 0x56,
-	0xe5, 0xba, 0xd8, 0xd9, 0xa9, 0x5e, 0xa1, 0x94, 0x44, 0x88, 0xd3, 0x42, 0x31, 0x9e, 0xfd, 0x3e,
-	0x04, 0x07, 0xe7, 0xe2, 0xa1, 0xe6, 0x09, 0x68, 0x5e, 0xe1, 0x82, 0x11, 0x9a, 0x59, 0x86, 0x63,
-	0x8c, 0x3b, 0x6f, 0x8e, 0xdd, 0xad, 0xda, 0x55, 0x03, 0x5f, 0x28, 0x02, 0xd4, 0x4c, 0xf3, 0x18,
-	0xb4, 0xc2, 0x04, 0x91, 0x2c, 0x20, 0x91, 0xf5, 0x9f, 0x63, 0x8c, 0xdb, 0xb0, 0x29, 0xcf, 0xb3,
-	0xc8, 0x7c, 0x01, 0xfa, 0x24, 0x23, 0x9c, 0xa0, 0x34, 0x48, 0x30, 0x89, 0x13, 0x6e, 0xed, 0x39,
-	0xc6, 0x78, 0x1f, 0xf6, 0x4a, 0xf4, 0x54, 0x82, 0xe6, 0x2b, 0xf0, 0x20, 0x45, 0x8c, 0x07, 0xf3,
-	0x94, 0x86, 0x97, 0x9a, 0xb9, 0x2f, 0x99, 0x03, 0x51, 0xf0, 0x05, 0x5e, 0x72, 0x21, 0xe8, 0xd5,
-	0xb8, 0x24, 0xb2, 0x0e, 0x76, 0x1b, 0x55, 0xe3, 0x4b, 0xd5, 0x6c, 0xea, 0x1f, 0xdd, 0xac, 0x46,
-	0x8d, 0xf5, 0x6a, 0xd4, 0x39, 0xd3, 0x56, 0xb3, 0x29, 0xec, 0x54, 0xbe, 0xb3, 0xc8, 0x3c, 0x03,
-	0x83, 0x9a, 0xa7, 0xc8, 0xc6, 0x3a, 0x94, 0xae, 0x43, 0x57, 0x05, 0xe7, 0xea, 0xe0, 0xdc, 0xcf,
-	0x3a, 0x38, 0xbf, 0x25, 0x6c, 0xaf, 0x7f, 0x8e, 0x0c, 0xd8, 0xab, 0xbc, 0x44, 0xd5, 0xfc, 0x00,
-	0x06, 0x19, 0xfe, 0xc6, 0x83, 0xea, 0x35, 0x33, 0xab, 0x29, 0xdd, 0xec, 0xdd, 0x1e, 0x2f, 0x34,
-	0xe7, 0x1c, 0x73, 0xd8, 0x17, 0xb2, 0x0a, 0x61, 0xe6, 0x5b, 0x00, 0x6a, 0x1e, 0xad, 0x7b, 0x79,
-	0xd4, 0x14, 0xa2, 0x11, 0x39, 0x56, 0xcd, 0xa4, 0x7d, 0xbf, 0x46, 0x84, 0xac, 0xd6, 0xc8, 0x04,
-	0xd8, 0xd2, 0x48, 0x25, 0x53, 0xf3, 0x0b, 0xc2, 0x04, 0x65, 0x31, 0x8e, 0x2c, 0xe0, 0x18, 0xe3,
-	0x3d, 0xf8, 0x48, 0xb0, 0x54, 0x4e, 0x5b, 0xf5, 0x44, 0x51, 0xcc, 0x97, 0xa0, 0x1d, 0x21, 0x1d,
-	0x6e, 0x47, 0x84, 0xeb, 0x77, 0xd7, 0xab, 0x51, 0x6b, 0xfa, 0x4e, 0x29, 0x60, 0x2b, 0x42, 0x55,
-	0xc6, 0xff, 0x87, 0x34, 0x63, 0x38, 0x63, 0x4b, 0x16, 0xa8, 0x55, 0xb7, 0xba, 0xb2, 0xf3, 0xa7,
-	0xbb, 0x9d, 0x4f, 0x34, 0xf3, 0x93, 0x24, 0xfa, 0xfb, 0x22, 0x17, 0x38, 0x08, 0xff, 0x86, 0xcd,
-	0x8f, 0xe0, 0x79, 0x7d, 0x86, 0xbb, 0xfe, 0xd5, 0x24, 0x3d, 0xb9, 0x76, 0xce, 0x76, 0x92, 0x3b,
-	0xfe, 0x7a, 0x1c, 0xbd, 0xb3, 0x05, 0x66, 0xcb, 0x94, 0xb3, 0x20, 0x41, 0x2c, 0xb1, 0xfa, 0x8e,
-	0x31, 0xee, 0xaa, 0x9d, 0x85, 0x0a, 0x3f, 0x45, 0x2c, 0x11, 0x5f, 0x08, 0xca, 0x73, 0x45, 0x19,
-	0x48, 0x4a, 0x13, 0xe5, 0xb9, 0x28, 0xf9, 0xef, 0x6f, 0xd6, 0xb6, 0x71, 0xbb, 0xb6, 0x8d, 0x5f,
-	0x6b, 0xdb, 0xb8, 0xde, 0xd8, 0x8d, 0xdb, 0x8d, 0xdd, 0xf8, 0xb1, 0xb1, 0x1b, 0x5f, 0x5e, 0xc7,
-	0x84, 0x27, 0xcb, 0xb9, 0x1b, 0xd2, 0x85, 0xa7, 0xaf, 0x1c, 0xfd, 0x5b, 0x5e, 0x02, 0x73, 0x0d,
-	0xcc, 0x0f, 0xe5, 0x82, 0x9e, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0xca, 0xdd, 0x87, 0x6b, 0x9d,
-	0x04, 0x00, 0x00,
+	// 631 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x41, 0x6f, 0xd3, 0x3e,
+	0x1c, 0xad, 0xb7, 0xae, 0xed, 0xdc, 0x75, 0xfd, 0xff, 0x33, 0x84, 0xb2, 0x01, 0x69, 0x98, 0x40,
+	0x2a, 0x20, 0x25, 0x12, 0xbb, 0x23, 0xd1, 0x0d, 0xb1, 0x4a, 0x13, 0x42, 0x1e, 0xda, 0x81, 0x4b,
+	0xe4, 0x26, 0x26, 0xb1, 0x96, 0xc6, 0x51, 0xec, 0x4e, 0xf0, 0x2d, 0xf6, 0xb1, 0x76, 0xdc, 0x91,
+	0x0b, 0x03, 0x75, 0x9f, 0x82, 0x1b, 0xb2, 0x1d, 0x67, 0xd9, 0xda, 0x43, 0x4f, 0xad, 0x7f, 0xbf,
+	0xf7, 0x5e, 0xde, 0xcf, 0xcf, 0x36, 0xdc, 0x29, 0x58, 0x9a, 0x9e, 0x53, 0xe1, 0x73, 0x81, 0x05,
+	0xf1, 0xf2, 0x82, 0x09, 0x66, 0xb5, 0xcb, 0xe2, 0xde, 0xa3, 0x98, 0xc5, 0x4c, 0xd5, 0x7c, 0xf9,
+	0x4f, 0xb7, 0xf7, 0x06, 0x31, 0x63, 0x71, 0x4a, 0x7c, 0xb5, 0x9a, 0xcc, 0xbe, 0xf9, 0x82, 0x4e,
+	0x09, 0x17, 0x78, 0x9a, 0x97, 0x80, 0xa7, 0x82, 0x64, 0x11, 0x29, 0xa6, 0x34, 0x2b, 0x75, 0x7d,
+	0xf1, 0x23, 0x27, 0xbc, 0xec, 0x3e, 0xab, 0x75, 0x55, 0xdd, 0xcf, 0x71, 0x81, 0xa7, 0x7c, 0x09,
+	0x59, 0xb7, 0xeb, 0x64, 0x77, 0xa1, 0x7b, 0x81, 0x53, 0x1a, 0x61, 0xc1, 0x0a, 0x8d, 0xd8, 0xff,
+	0xdb, 0x82, 0x1b, 0xa7, 0xf2, 0xa3, 0xd6, 0x01, 0x6c, 0x5f, 0x90, 0x82, 0x53, 0x96, 0xd9, 0xc0,
+	0x05, 0xc3, 0xee, 0xdb, 0x5d, 0xef, 0x8e, 0xed, 0xe9, 0x81, 0xcf, 0x34, 0x00, 0x19, 0xa4, 0xb5,
+	0x0b, 0x3b, 0x61, 0x82, 0x69, 0x16, 0xd0, 0xc8, 0x5e, 0x73, 0xc1, 0x70, 0x13, 0xb5, 0xd5, 0x7a,
+	0x1c, 0x59, 0x2f, 0xe1, 0x36, 0xcd, 0xa8, 0xa0, 0x38, 0x0d, 0x12, 0x42, 0xe3, 0x44, 0xd8, 0xeb,
+	0x2e, 0x18, 0x36, 0x51, 0xaf, 0xac, 0x1e, 0xab, 0xa2, 0xf5, 0x1a, 0xfe, 0x9f, 0x62, 0x2e, 0x82,
+	0x49, 0xca, 0xc2, 0x73, 0x83, 0x6c, 0x2a, 0x64, 0x5f, 0x36, 0x46, 0xb2, 0x5e, 0x62, 0x11, 0xec,
+	0xd5, 0xb0, 0x34, 0xb2, 0x37, 0x16, 0x8d, 0xea, 0xf1, 0x15, 0x6b, 0x7c, 0x34, 0xda, 0xb9, 0xba,
+	0x19, 0x34, 0xe6, 0x37, 0x83, 0xee, 0x89, 0x91, 0x1a, 0x1f, 0xa1, 0x6e, 0xa5, 0x3b, 0x8e, 0xac,
+	0x13, 0xd8, 0xaf, 0x69, 0xca, 0x6c, 0xec, 0x96, 0x52, 0xdd, 0xf3, 0x74, 0x70, 0x9e, 0x09, 0xce,
+	0xfb, 0x62, 0x82, 0x1b, 0x75, 0xa4, 0xec, 0xe5, 0xef, 0x01, 0x40, 0xbd, 0x4a, 0x4b, 0x76, 0xad,
+	0x8f, 0xb0, 0x9f, 0x91, 0xef, 0x22, 0xa8, 0xb6, 0x99, 0xdb, 0x6d, 0xa5, 0xe6, 0x2c, 0x7a, 0x3c,
+	0x33, 0x98, 0x53, 0x22, 0xd0, 0xb6, 0xa4, 0x55, 0x15, 0x6e, 0xbd, 0x83, 0xb0, 0xa6, 0xd1, 0x59,
+	0x49, 0xa3, 0xc6, 0x90, 0x46, 0xd4, 0x58, 0x35, 0x91, 0xcd, 0xd5, 0x8c, 0x48, 0x5a, 0xcd, 0xc8,
+	0x21, 0x74, 0x94, 0x90, 0x4e, 0xa6, 0xa6, 0x17, 0x84, 0x09, 0xce, 0x62, 0x12, 0xd9, 0xd0, 0x05,
+	0xc3, 0x75, 0xf4, 0x44, 0xa2, 0x74, 0x4e, 0x77, 0xec, 0x43, 0x0d, 0xb1, 0x5e, 0xc1, 0xcd, 0x08,
+	0x9b, 0x70, 0xbb, 0x32, 0xdc, 0xd1, 0xd6, 0xfc, 0x66, 0xd0, 0x39, 0x7a, 0xaf, 0x19, 0xa8, 0x13,
+	0xe1, 0x2a, 0xe3, 0xff, 0x42, 0x96, 0x71, 0x92, 0xf1, 0x19, 0x0f, 0xf4, 0x51, 0xb7, 0xb7, 0x94,
+	0xf3, 0xe7, 0x8b, 0xce, 0x0f, 0x0d, 0xf2, 0xb3, 0x02, 0x8e, 0x9a, 0x32, 0x17, 0xd4, 0x0f, 0xef,
+	0x97, 0xad, 0x4f, 0xf0, 0x45, 0x7d, 0x86, 0x87, 0xfa, 0xd5, 0x24, 0x3d, 0x75, 0xec, 0xdc, 0xbb,
+	0x49, 0x1e, 0xe8, 0x9b, 0x71, 0xcc, 0x99, 0x2d, 0x08, 0x9f, 0xa5, 0x82, 0x07, 0x09, 0xe6, 0x89,
+	0xbd, 0xed, 0x82, 0xe1, 0x96, 0x3e, 0xb3, 0x48, 0xd7, 0x8f, 0x31, 0x4f, 0xe4, 0x0d, 0xc1, 0x79,
+	0xae, 0x21, 0x7d, 0x05, 0x69, 0xe3, 0x3c, 0x97, 0xad, 0xfd, 0x5f, 0x00, 0x76, 0xd5, 0xdd, 0x43,
+	0x24, 0x64, 0x45, 0x64, 0x3d, 0x86, 0xad, 0x72, 0x8b, 0x80, 0x32, 0x52, 0xae, 0xee, 0x49, 0xac,
+	0xdd, 0x93, 0x58, 0xee, 0x64, 0x7d, 0xb9, 0x93, 0x55, 0x77, 0xa1, 0xb9, 0xe2, 0x2e, 0x0c, 0x60,
+	0x57, 0xda, 0x32, 0x8f, 0xc6, 0x86, 0xa2, 0x41, 0x9c, 0xe7, 0xe5, 0x2b, 0x31, 0xfa, 0x70, 0x35,
+	0x77, 0xc0, 0xf5, 0xdc, 0x01, 0x7f, 0xe6, 0x0e, 0xb8, 0xbc, 0x75, 0x1a, 0xd7, 0xb7, 0x4e, 0xe3,
+	0xe7, 0xad, 0xd3, 0xf8, 0xfa, 0x26, 0xa6, 0x22, 0x99, 0x4d, 0xbc, 0x90, 0x4d, 0x7d, 0xf3, 0xa4,
+	0x9a, 0xdf, 0xf2, 0x91, 0x9b, 0x98, 0xc2, 0xa4, 0xa5, 0x2e, 0xe0, 0xc1, 0xbf, 0x01, 0x00, 0x94,
+	0xe6, 0x54, 0xa0, 0x7d, 0x05, 0x00, 0x00,
 }
 
 func (m *State) Marshal() (dAtA []byte, err error) {
@@ -383,6 +464,58 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *StateRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StateRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StateRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AppVersion != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.AppVersion))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.LastHeightConsensusParamsChanged != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.LastHeightConsensusParamsChanged))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.LastResultsHash) > 0 {
+		i -= len(m.LastResultsHash)
+		copy(dAtA[i:], m.LastResultsHash)
+		i = encodeVarintState(dAtA, i, uint64(len(m.LastResultsHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.AppHash) > 0 {
+		i -= len(m.AppHash)
+		copy(dAtA[i:], m.AppHash)
+		i = encodeVarintState(dAtA, i, uint64(len(m.AppHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintState(dAtA []byte, offset int, v uint64) int {
 	offset -= sovState(v)
 	base := offset
@@ -452,6 +585,32 @@ func (m *State) Size() (n int) {
 	return n
 }
 
+func (m *StateRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovState(uint64(m.Height))
+	}
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
+	l = len(m.LastResultsHash)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.LastHeightConsensusParamsChanged != 0 {
+		n += 1 + sovState(uint64(m.LastHeightConsensusParamsChanged))
+	}
+	if m.AppVersion != 0 {
+		n += 1 + sovState(uint64(m.AppVersion))
+	}
+	return n
+}
+
 func sovState(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -946,6 +1105,181 @@ func (m *State) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *StateRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StateRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StateRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastResultsHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastResultsHash = append(m.LastResultsHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.LastResultsHash == nil {
+				m.LastResultsHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastHeightConsensusParamsChanged", wireType)
+			}
+			m.LastHeightConsensusParamsChanged = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastHeightConsensusParamsChanged |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppVersion", wireType)
+			}
+			m.AppVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipState(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"slices"
+	"sync"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// maxPooledBuffer limits the size of buffers kept in the pool, so that a single huge block doesn't pin memory.
+const maxPooledBuffer = 16 << 20
+
+// buffers pools buffers for encodings which are needed only temporarily, e.g. for hashing or writing to
+// the store, to avoid allocating a new buffer for every block.
+var buffers = sync.Pool{New: func() any { return new([]byte) }}
+
+// dataProtos pools protobuf representations of Data used for encoding, to avoid allocating a slice of
+// transactions for every block.
+var dataProtos = sync.Pool{New: func() any { return new(pb.Data) }}
+
+// ProtoMarshaler is implemented by protobuf messages generated by gogoproto.
+type ProtoMarshaler interface {
+	Size() int
+	MarshalToSizedBuffer(dAtA []byte) (int, error)
+}
+
+// MarshalPooled encodes protobuf message m into a pooled buffer. Returned blob is valid only until release is
+// called, which returns the buffer to the pool.
+func MarshalPooled(m ProtoMarshaler) (blob []byte, release func(), err error) {
+	return marshalPooled(func(b []byte) ([]byte, error) {
+		return appendProto(b, m)
+	})
+}
+
+// marshalPooled appends encoding to a pooled buffer, see MarshalPooled.
+func marshalPooled(appendTo func([]byte) ([]byte, error)) (blob []byte, release func(), err error) {
+	buf := buffers.Get().(*[]byte)
+	release = func() {
+		if cap(*buf) <= maxPooledBuffer {
+			buffers.Put(buf)
+		}
+	}
+	if *buf, err = appendTo((*buf)[:0]); err != nil {
+		*buf = nil
+		release()
+		return nil, nil, err
+	}
+	return *buf, release, nil
+}
+
+// appendProto appends encoding of m to b. Buffer is grown at most once, to the exact size of the encoding.
+func appendProto(b []byte, m ProtoMarshaler) ([]byte, error) {
+	size := m.Size()
+	b = slices.Grow(b, size)
+	n, err := m.MarshalToSizedBuffer(b[len(b) : len(b)+size])
+	if err != nil {
+		return nil, err
+	}
+	return b[:len(b)+n], nil
+}
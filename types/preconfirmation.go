@@ -0,0 +1,229 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	cmcrypto "github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// preconfirmationSignPrefix separates preconfirmation signatures from signatures of other messages.
+var preconfirmationSignPrefix = []byte("rollkit/preconfirmation/v1\x00")
+
+var (
+	// ErrPreconfirmationSignature is returned when preconfirmation is not signed by the sequencer.
+	ErrPreconfirmationSignature = errors.New("invalid preconfirmation signature")
+	// ErrPreconfirmationFulfilled is returned when transaction was included before the promised height.
+	ErrPreconfirmationFulfilled = errors.New("preconfirmation was fulfilled")
+)
+
+// Preconfirmation is a promise of the sequencer to include transaction in a block not higher than MaxHeight.
+// Sequencer signs preconfirmation when it accepts transaction for ordering. If the promise is broken, signed
+// preconfirmation together with blocks not containing the transaction is an evidence of misbehavior, see
+// PreconfirmationViolation.
+type Preconfirmation struct {
+	ChainID string           `json:"chain_id"`
+	TxHash  cmbytes.HexBytes `json:"tx_hash"`
+	// Height is the height of the last block at the time transaction was accepted.
+	Height uint64 `json:"height,string"`
+	// MaxHeight is the promised maximum inclusion height.
+	MaxHeight uint64           `json:"max_height,string"`
+	Signature cmbytes.HexBytes `json:"signature"`
+}
+
+// SignBytes returns bytes signed by the sequencer: protobuf encoding of preconfirmation without signature.
+func (p *Preconfirmation) SignBytes() []byte {
+	pp := p.ToProto()
+	pp.Signature = nil
+	// encoding of messages with scalar fields only never fails
+	b, _ := pp.Marshal()
+	return append(bytes.Clone(preconfirmationSignPrefix), b...)
+}
+
+// MarshalBinary encodes Preconfirmation into binary form and returns it.
+func (p *Preconfirmation) MarshalBinary() ([]byte, error) {
+	return p.ToProto().Marshal()
+}
+
+// UnmarshalBinary decodes binary form of Preconfirmation into object.
+func (p *Preconfirmation) UnmarshalBinary(data []byte) error {
+	var pp pb.Preconfirmation
+	if err := pp.Unmarshal(data); err != nil {
+		return err
+	}
+	p.FromProto(&pp)
+	return nil
+}
+
+// ToProto converts Preconfirmation into protobuf representation and returns it.
+func (p *Preconfirmation) ToProto() *pb.Preconfirmation {
+	return &pb.Preconfirmation{
+		ChainId:   p.ChainID,
+		TxHash:    p.TxHash,
+		Height:    p.Height,
+		MaxHeight: p.MaxHeight,
+		Signature: p.Signature,
+	}
+}
+
+// FromProto fills Preconfirmation with data from its protobuf representation.
+func (p *Preconfirmation) FromProto(other *pb.Preconfirmation) {
+	*p = Preconfirmation{
+		ChainID:   other.ChainId,
+		TxHash:    other.TxHash,
+		Height:    other.Height,
+		MaxHeight: other.MaxHeight,
+		Signature: other.Signature,
+	}
+}
+
+// ValidateBasic performs basic validation of a preconfirmation.
+func (p *Preconfirmation) ValidateBasic() error {
+	if len(p.TxHash) != tmhash.Size {
+		return fmt.Errorf("invalid tx hash length %d, expected %d", len(p.TxHash), tmhash.Size)
+	}
+	if p.MaxHeight <= p.Height {
+		return fmt.Errorf("max height %d must be greater than height %d", p.MaxHeight, p.Height)
+	}
+	if len(p.Signature) == 0 {
+		return ErrSignatureEmpty
+	}
+	return nil
+}
+
+// Verify checks that preconfirmation is signed by the sequencer with given public key.
+func (p *Preconfirmation) Verify(pubKey cmcrypto.PubKey) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	if !pubKey.VerifySignature(p.SignBytes(), p.Signature) {
+		return ErrPreconfirmationSignature
+	}
+	return nil
+}
+
+// PreconfirmationViolation is an evidence that the sequencer broke the promise of a preconfirmation: it contains
+// all blocks from the one following Preconfirmation.Height up to Preconfirmation.MaxHeight, and none of them
+// includes the transaction. Evidence is self-contained and can be verified by anyone knowing the sequencer key,
+// e.g. to slash the sequencer.
+type PreconfirmationViolation struct {
+	Preconfirmation Preconfirmation `json:"preconfirmation"`
+	// Headers and Data of blocks, encoded with MarshalBinary, in order of heights.
+	Headers [][]byte `json:"headers"`
+	Data    [][]byte `json:"data"`
+}
+
+// NewPreconfirmationViolation creates evidence from blocks following preconfirmation height up to the promised
+// height. It returns ErrPreconfirmationFulfilled if any of the blocks includes the transaction.
+func NewPreconfirmationViolation(p Preconfirmation, headers []*SignedHeader, data []*Data) (*PreconfirmationViolation, error) {
+	if len(headers) != len(data) {
+		return nil, errors.New("number of headers and data must be equal")
+	}
+	v := &PreconfirmationViolation{
+		Preconfirmation: p,
+		Headers:         make([][]byte, len(headers)),
+		Data:            make([][]byte, len(data)),
+	}
+	for i := range headers {
+		if containsTx(data[i].Txs, p.TxHash) {
+			return nil, fmt.Errorf("%w: transaction included at height %d", ErrPreconfirmationFulfilled, headers[i].Height())
+		}
+		var err error
+		if v.Headers[i], err = headers[i].MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if v.Data[i], err = data[i].MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// MarshalBinary encodes PreconfirmationViolation into binary form and returns it.
+func (v *PreconfirmationViolation) MarshalBinary() ([]byte, error) {
+	return v.ToProto().Marshal()
+}
+
+// UnmarshalBinary decodes binary form of PreconfirmationViolation into object.
+func (v *PreconfirmationViolation) UnmarshalBinary(data []byte) error {
+	var pv pb.PreconfirmationViolation
+	if err := pv.Unmarshal(data); err != nil {
+		return err
+	}
+	v.FromProto(&pv)
+	return nil
+}
+
+// ToProto converts PreconfirmationViolation into protobuf representation and returns it.
+func (v *PreconfirmationViolation) ToProto() *pb.PreconfirmationViolation {
+	return &pb.PreconfirmationViolation{
+		Preconfirmation: v.Preconfirmation.ToProto(),
+		Headers:         v.Headers,
+		Data:            v.Data,
+	}
+}
+
+// FromProto fills PreconfirmationViolation with data from its protobuf representation.
+func (v *PreconfirmationViolation) FromProto(other *pb.PreconfirmationViolation) {
+	*v = PreconfirmationViolation{Headers: other.Headers, Data: other.Data}
+	if other.Preconfirmation != nil {
+		v.Preconfirmation.FromProto(other.Preconfirmation)
+	}
+}
+
+// Verify checks that evidence proves the violation of a preconfirmation signed by the sequencer with given
+// public key: blocks are signed by the sequencer, form a chain covering all promised heights and none of them
+// includes the transaction.
+func (v *PreconfirmationViolation) Verify(pubKey cmcrypto.PubKey) error {
+	p := &v.Preconfirmation
+	if err := p.Verify(pubKey); err != nil {
+		return err
+	}
+	if len(v.Headers) != len(v.Data) || uint64(len(v.Headers)) != p.MaxHeight-p.Height {
+		return fmt.Errorf("evidence must contain %d blocks", p.MaxHeight-p.Height)
+	}
+	var prev *SignedHeader
+	for i := range v.Headers {
+		header, data := new(SignedHeader), new(Data)
+		if err := header.UnmarshalBinary(v.Headers[i]); err != nil {
+			return fmt.Errorf("invalid header %d: %w", i, err)
+		}
+		if err := data.UnmarshalBinary(v.Data[i]); err != nil {
+			return fmt.Errorf("invalid data %d: %w", i, err)
+		}
+		if err := header.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid header %d: %w", i, err)
+		}
+		if !header.Validators.Validators[0].PubKey.Equals(pubKey) {
+			return fmt.Errorf("header %d is not signed by the sequencer", i)
+		}
+		if header.ChainID() != p.ChainID || header.Height() != p.Height+uint64(i)+1 {
+			return fmt.Errorf("unexpected chain ID or height of header %d", i)
+		}
+		if prev != nil && !bytes.Equal(header.LastHeaderHash, prev.Hash()) {
+			return fmt.Errorf("header %d doesn't follow the previous one: %w", i, ErrLastHeaderHashMismatch)
+		}
+		if err := Validate(header, data); err != nil {
+			return fmt.Errorf("invalid block %d: %w", i, err)
+		}
+		if containsTx(data.Txs, p.TxHash) {
+			return fmt.Errorf("%w: transaction included at height %d", ErrPreconfirmationFulfilled, header.Height())
+		}
+		prev = header
+	}
+	return nil
+}
+
+func containsTx(txs Txs, hash []byte) bool {
+	for _, tx := range txs {
+		if bytes.Equal(TxHash(tx), hash) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreconfirmationViolation(t *testing.T) {
+	require := require.New(t)
+	chainID := "TestPreconfirmationViolation"
+
+	header1, data1, privKey := GenerateRandomBlockCustom(&BlockConfig{Height: 1, NTxs: 2}, chainID)
+	header2, data2 := GetRandomNextBlock(header1, data1, privKey, nil, 2, chainID)
+	header3, data3 := GetRandomNextBlock(header2, data2, privKey, nil, 2, chainID)
+
+	sign := func(p Preconfirmation) Preconfirmation {
+		sig, err := privKey.Sign(p.SignBytes())
+		require.NoError(err)
+		p.Signature = sig
+		return p
+	}
+	p := sign(Preconfirmation{ChainID: chainID, TxHash: GetRandomTx().Hash(), Height: 1, MaxHeight: 3})
+	require.NoError(p.Verify(privKey.PubKey()))
+	assert.ErrorIs(t, p.Verify(ed25519.GenPrivKey().PubKey()), ErrPreconfirmationSignature)
+
+	v, err := NewPreconfirmationViolation(p, []*SignedHeader{header2, header3}, []*Data{data2, data3})
+	require.NoError(err)
+	require.NoError(v.Verify(privKey.PubKey()))
+
+	// evidence is verified after JSON round trip, e.g. by a slashing service
+	blob, err := json.Marshal(v)
+	require.NoError(err)
+	decoded := new(PreconfirmationViolation)
+	require.NoError(json.Unmarshal(blob, decoded))
+	require.NoError(decoded.Verify(privKey.PubKey()))
+	// and after binary round trip
+	blob, err = v.MarshalBinary()
+	require.NoError(err)
+	decoded = new(PreconfirmationViolation)
+	require.NoError(decoded.UnmarshalBinary(blob))
+	require.NoError(decoded.Verify(privKey.PubKey()))
+
+	t.Run("missing block", func(t *testing.T) {
+		v, err := NewPreconfirmationViolation(p, []*SignedHeader{header2}, []*Data{data2})
+		require.NoError(err)
+		assert.Error(t, v.Verify(privKey.PubKey()))
+	})
+
+	t.Run("wrong block", func(t *testing.T) {
+		v, err := NewPreconfirmationViolation(p, []*SignedHeader{header2, header3}, []*Data{data2, data2})
+		require.NoError(err)
+		assert.Error(t, v.Verify(privKey.PubKey()))
+	})
+
+	t.Run("fulfilled", func(t *testing.T) {
+		p := sign(Preconfirmation{ChainID: chainID, TxHash: data3.Txs[1].Hash(), Height: 1, MaxHeight: 3})
+		_, err := NewPreconfirmationViolation(p, []*SignedHeader{header2, header3}, []*Data{data2, data3})
+		assert.ErrorIs(t, err, ErrPreconfirmationFulfilled)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := *v
+		tampered.Preconfirmation.MaxHeight = 2
+		assert.ErrorIs(t, tampered.Verify(privKey.PubKey()), ErrPreconfirmationSignature)
+	})
+}
@@ -1,8 +1,12 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+
 	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	pb "github.com/rollkit/rollkit/types/pb/rollkit"
 )
@@ -14,6 +18,9 @@ func (m *Metadata) MarshalBinary() ([]byte, error) {
 
 // UnmarshalBinary decodes binary form of Metadata into object.
 func (m *Metadata) UnmarshalBinary(metadata []byte) error {
+	if err := GetDecodeLimits().CheckSize("metadata", metadata); err != nil {
+		return err
+	}
 	var pMetadata pb.Metadata
 	err := pMetadata.Unmarshal(metadata)
 	if err != nil {
@@ -30,6 +37,9 @@ func (h *Header) MarshalBinary() ([]byte, error) {
 
 // UnmarshalBinary decodes binary form of Header into object.
 func (h *Header) UnmarshalBinary(data []byte) error {
+	if err := GetDecodeLimits().CheckSize("header", data); err != nil {
+		return err
+	}
 	var pHeader pb.Header
 	err := pHeader.Unmarshal(data)
 	if err != nil {
@@ -41,18 +51,104 @@ func (h *Header) UnmarshalBinary(data []byte) error {
 
 // MarshalBinary encodes Data into binary form and returns it.
 func (d *Data) MarshalBinary() ([]byte, error) {
-	return d.ToProto().Marshal()
+	return d.AppendBinary(nil)
+}
+
+// AppendBinary appends binary form of Data to b and returns the extended buffer. Transactions are
+// copied only once, directly into b. Sections follow other fields, see DataSection.
+func (d *Data) AppendBinary(b []byte) ([]byte, error) {
+	pData := dataProtos.Get().(*pb.Data)
+	defer func() {
+		// pooled object must not retain transactions
+		clear(pData.Txs)
+		*pData = pb.Data{Txs: pData.Txs[:0]}
+		dataProtos.Put(pData)
+	}()
+	if d.Metadata != nil {
+		pData.Metadata = d.Metadata.ToProto()
+	}
+	for _, tx := range d.Txs {
+		pData.Txs = append(pData.Txs, tx)
+	}
+	b, err := appendProto(b, pData)
+	if err != nil {
+		return nil, err
+	}
+	for _, section := range d.Sections {
+		if b, err = appendDataSection(b, section); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// MarshalPooled encodes Data into a pooled buffer, see MarshalPooled.
+func (d *Data) MarshalPooled() (blob []byte, release func(), err error) {
+	return marshalPooled(d.AppendBinary)
 }
 
-// UnmarshalBinary decodes binary form of Data into object.
+// UnmarshalBinary decodes binary form of Data into object. Transactions are not copied, they reference
+// data, which must not be modified afterwards.
 func (d *Data) UnmarshalBinary(data []byte) error {
-	var pData pb.Data
-	err := pData.Unmarshal(data)
+	if err := GetDecodeLimits().checkData(data); err != nil {
+		return err
+	}
+	// transactions are counted first, so that they are decoded into a slice of exact size
+	count := 0
+	_ = walkFields(data, func(num protowire.Number, typ protowire.Type, _ []byte) error {
+		if num == 2 && typ == protowire.BytesType {
+			count++
+		}
+		return nil
+	})
+	var (
+		metadata *pb.Metadata
+		txs      Txs
+		sections []DataSection
+	)
+	if count > 0 {
+		txs = make(Txs, 0, count)
+	}
+	err := walkFields(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch num {
+		case 1:
+			if typ != protowire.BytesType {
+				return fmt.Errorf("wrong wire type %d of field Metadata", typ)
+			}
+			if metadata == nil {
+				metadata = new(pb.Metadata)
+			}
+			return metadata.Unmarshal(value)
+		case 2:
+			if typ != protowire.BytesType {
+				return fmt.Errorf("wrong wire type %d of field Txs", typ)
+			}
+			// capacity is limited, so that appending to a transaction doesn't overwrite the next one
+			txs = append(txs, value[:len(value):len(value)])
+		case dataSectionsField:
+			if typ != protowire.BytesType {
+				return fmt.Errorf("wrong wire type %d of field Sections", typ)
+			}
+			section, err := unmarshalDataSection(value)
+			if err != nil {
+				return fmt.Errorf("invalid data section: %w", err)
+			}
+			sections = append(sections, section)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	err = d.FromProto(&pData)
-	return err
+	if metadata != nil {
+		if d.Metadata == nil {
+			d.Metadata = &Metadata{}
+		}
+		d.Metadata.FromProto(metadata)
+	}
+	d.Txs = txs
+	d.Sections = sections
+	return nil
 }
 
 // ToProto converts SignedHeader into protobuf representation and returns it.
@@ -87,6 +183,15 @@ func (sh *SignedHeader) FromProto(other *pb.SignedHeader) error {
 	return nil
 }
 
+// MarshalPooled encodes SignedHeader into a pooled buffer, see MarshalPooled.
+func (sh *SignedHeader) MarshalPooled() (blob []byte, release func(), err error) {
+	hp, err := sh.ToProto()
+	if err != nil {
+		return nil, nil, err
+	}
+	return MarshalPooled(hp)
+}
+
 // MarshalBinary encodes SignedHeader into binary form and returns it.
 func (sh *SignedHeader) MarshalBinary() ([]byte, error) {
 	hp, err := sh.ToProto()
@@ -98,6 +203,9 @@ func (sh *SignedHeader) MarshalBinary() ([]byte, error) {
 
 // UnmarshalBinary decodes binary form of SignedHeader into object.
 func (sh *SignedHeader) UnmarshalBinary(data []byte) error {
+	if err := GetDecodeLimits().checkSignedHeader(data); err != nil {
+		return err
+	}
 	var pHeader pb.SignedHeader
 	err := pHeader.Unmarshal(data)
 	if err != nil {
@@ -133,6 +241,9 @@ func (h *Header) ToProto() *pb.Header {
 
 // FromProto fills Header with data from its protobuf representation.
 func (h *Header) FromProto(other *pb.Header) error {
+	if other == nil || other.Version == nil {
+		return errors.New("header or its version is missing")
+	}
 	h.Version.Block = other.Version.Block
 	h.Version.App = other.Version.App
 	h.BaseHeader.ChainID = other.ChainId
@@ -171,15 +282,21 @@ func (m *Metadata) FromProto(other *pb.Metadata) {
 	m.LastDataHash = other.LastDataHash
 }
 
-// ToProto converts Data into protobuf representation and returns it.
+// ToProto converts Data into protobuf representation and returns it. Fields of sections unknown to this version
+// are not included, use MarshalBinary to encode data as it was received.
 func (d *Data) ToProto() *pb.Data {
 	var mProto *pb.Metadata
 	if d.Metadata != nil {
 		mProto = d.Metadata.ToProto()
 	}
+	var sections []*pb.DataSection
+	for i := range d.Sections {
+		sections = append(sections, d.Sections[i].ToProto())
+	}
 	return &pb.Data{
 		Metadata: mProto,
 		Txs:      txsToByteSlices(d.Txs),
+		Sections: sections,
 		// IntermediateStateRoots: d.IntermediateStateRoots.RawRootsList,
 		// Note: Temporarily remove Evidence #896
 		// Evidence:               evidenceToProto(d.Evidence),
@@ -195,6 +312,12 @@ func (d *Data) FromProto(other *pb.Data) error {
 		d.Metadata.FromProto(other.Metadata)
 	}
 	d.Txs = byteSlicesToTxs(other.Txs)
+	d.Sections = nil
+	for _, section := range other.Sections {
+		var s DataSection
+		s.FromProto(section)
+		d.Sections = append(d.Sections, s)
+	}
 	// d.IntermediateStateRoots.RawRootsList = other.IntermediateStateRoots
 	// Note: Temporarily remove Evidence #896
 	// d.Evidence = evidenceFromProto(other.Evidence)
@@ -202,6 +325,28 @@ func (d *Data) FromProto(other *pb.Data) error {
 	return nil
 }
 
+// MarshalBinary encodes State into binary form and returns it.
+func (s *State) MarshalBinary() ([]byte, error) {
+	ps, err := s.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return ps.Marshal()
+}
+
+// UnmarshalBinary decodes binary form of State into object.
+func (s *State) UnmarshalBinary(data []byte) error {
+	if err := GetDecodeLimits().checkState(data); err != nil {
+		return err
+	}
+	var pState pb.State
+	err := pState.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	return s.FromProto(&pState)
+}
+
 // ToProto converts State into protobuf representation and returns it.
 func (s *State) ToProto() (*pb.State, error) {
 	nextValidators, err := s.NextValidators.ToProto()
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+var benchmarkTxCounts = []int{1000, 10000}
+
+func BenchmarkDataMarshalBinary(b *testing.B) {
+	for _, nTxs := range benchmarkTxCounts {
+		_, data := GetRandomBlock(1, nTxs, "BenchmarkDataMarshalBinary")
+		b.Run(fmt.Sprintf("txs=%d", nTxs), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := data.MarshalBinary(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDataMarshalPooled(b *testing.B) {
+	for _, nTxs := range benchmarkTxCounts {
+		_, data := GetRandomBlock(1, nTxs, "BenchmarkDataMarshalPooled")
+		b.Run(fmt.Sprintf("txs=%d", nTxs), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, release, err := data.MarshalPooled()
+				if err != nil {
+					b.Fatal(err)
+				}
+				release()
+			}
+		})
+	}
+}
+
+func BenchmarkDataUnmarshalBinary(b *testing.B) {
+	for _, nTxs := range benchmarkTxCounts {
+		_, data := GetRandomBlock(1, nTxs, "BenchmarkDataUnmarshalBinary")
+		blob, err := data.MarshalBinary()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("txs=%d", nTxs), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := new(Data).UnmarshalBinary(blob); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDataHash(b *testing.B) {
+	for _, nTxs := range benchmarkTxCounts {
+		_, data := GetRandomBlock(1, nTxs, "BenchmarkDataHash")
+		b.Run(fmt.Sprintf("txs=%d", nTxs), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				data.Hash()
+			}
+		})
+	}
+}
+
+func BenchmarkSignedHeaderMarshalBinary(b *testing.B) {
+	header, _ := GetRandomBlock(1, 0, "BenchmarkSignedHeaderMarshalBinary")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := header.MarshalBinary(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
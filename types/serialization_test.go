@@ -9,10 +9,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/merkle"
 	cmstate "github.com/cometbft/cometbft/proto/tendermint/state"
 	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	cmversion "github.com/cometbft/cometbft/proto/tendermint/version"
 	cmtypes "github.com/cometbft/cometbft/types"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	pb "github.com/rollkit/rollkit/types/pb/rollkit"
 )
@@ -196,6 +198,52 @@ func TestStateRoundTrip(t *testing.T) {
 	}
 }
 
+func TestStateRecordRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	for _, record := range []*StateRecord{
+		{},
+		{Height: 10, AppHash: []byte("app hash")},
+		{Height: 10, AppHash: []byte("app hash"), LastResultsHash: []byte("results"), LastHeightConsensusParamsChanged: 3, AppVersion: 1},
+	} {
+		blob, err := record.MarshalBinary()
+		require.NoError(err)
+		decoded := new(StateRecord)
+		require.NoError(decoded.UnmarshalBinary(blob))
+		require.Equal(record, decoded)
+	}
+
+	// unknown fields are skipped
+	decoded := new(StateRecord)
+	require.NoError(decoded.UnmarshalBinary([]byte{0x08, 0x05, 0x30, 0x01}))
+	require.Equal(&StateRecord{Height: 5}, decoded)
+
+	require.Error(decoded.UnmarshalBinary([]byte{0x12, 0x05, 0x01}))
+}
+
+func TestDAInclusionCertificateRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	for _, cert := range []*DAInclusionCertificate{
+		{},
+		{Height: 10, DAHeight: 20},
+		{Height: 10, DAHeight: 20, ID: []byte("id"), Commitment: []byte("commitment"), Proof: []byte("proof")},
+	} {
+		blob, err := cert.MarshalBinary()
+		require.NoError(err)
+		decoded := new(DAInclusionCertificate)
+		require.NoError(decoded.UnmarshalBinary(blob))
+		require.Equal(cert, decoded)
+	}
+
+	// unknown fields are skipped
+	decoded := new(DAInclusionCertificate)
+	require.NoError(decoded.UnmarshalBinary([]byte{0x08, 0x05, 0x30, 0x01}))
+	require.Equal(&DAInclusionCertificate{Height: 5}, decoded)
+
+	require.Error(decoded.UnmarshalBinary([]byte{0x1a, 0x05, 0x01}))
+}
+
 func TestTxsRoundtrip(t *testing.T) {
 	// Test the nil case
 	var txs Txs
@@ -220,6 +268,141 @@ func TestTxsRoundtrip(t *testing.T) {
 	}
 }
 
+func TestDataZeroCopy(t *testing.T) {
+	require := require.New(t)
+
+	_, data := GetRandomBlock(1, 10, "TestDataZeroCopy")
+	expected, err := data.ToProto().Marshal()
+	require.NoError(err)
+
+	blob, err := data.MarshalBinary()
+	require.NoError(err)
+	require.Equal(expected, blob)
+
+	pooled, release, err := data.MarshalPooled()
+	require.NoError(err)
+	require.Equal(expected, pooled)
+	release()
+
+	prefix := []byte("prefix")
+	appended, err := data.AppendBinary(prefix)
+	require.NoError(err)
+	require.Equal(append([]byte("prefix"), expected...), appended)
+
+	decoded := new(Data)
+	require.NoError(decoded.UnmarshalBinary(blob))
+	require.Equal(data, decoded)
+	require.Equal(data.Hash(), decoded.Hash())
+
+	// transactions reference the blob
+	blob[len(blob)-1]++
+	require.Equal(blob[len(blob)-1], decoded.Txs[len(decoded.Txs)-1][len(decoded.Txs[len(decoded.Txs)-1])-1])
+	// appending to a transaction doesn't overwrite the next one
+	next := Tx(append([]byte(nil), decoded.Txs[1]...))
+	_ = append(decoded.Txs[0], 0xff)
+	require.Equal(next, decoded.Txs[1])
+
+	// wrong wire type of Txs field
+	require.Error(new(Data).UnmarshalBinary(protowire.AppendVarint(protowire.AppendTag(nil, 2, protowire.VarintType), 1)))
+}
+
+func TestDataSections(t *testing.T) {
+	require := require.New(t)
+
+	_, data := GetRandomBlock(1, 3, "TestDataSections")
+	withoutSections, err := data.MarshalBinary()
+	require.NoError(err)
+	hash := data.Hash()
+
+	data.Sections = []DataSection{
+		{Type: DataSectionIntermediateStateRoots, Payload: []byte("roots")},
+		{Type: MinAppDataSectionType, Payload: []byte("blob refs")},
+	}
+	require.NoError(data.ValidateBasic())
+	require.NotEqual(hash, data.Hash())
+	payload, ok := data.Section(MinAppDataSectionType)
+	require.True(ok)
+	require.Equal([]byte("blob refs"), payload)
+	_, ok = data.Section(MinAppDataSectionType + 1)
+	require.False(ok)
+
+	blob, err := data.MarshalBinary()
+	require.NoError(err)
+	require.Len(blob, data.Size())
+	// data without sections is encoded like before sections were introduced
+	require.Equal(withoutSections, blob[:len(withoutSections)])
+
+	decoded := new(Data)
+	require.NoError(decoded.UnmarshalBinary(blob))
+	require.Equal(data.Txs, decoded.Txs)
+	require.Len(decoded.Sections, 2)
+	for i, s := range data.Sections {
+		require.Equal(s.Type, decoded.Sections[i].Type)
+		require.Equal(s.Payload, decoded.Sections[i].Payload)
+	}
+	require.Equal(data.Hash(), decoded.Hash())
+
+	// protobuf representation includes sections
+	var p pb.Data
+	require.NoError(p.Unmarshal(blob))
+	require.Len(p.Txs, 3)
+	require.Len(p.Sections, 2)
+	fromProto := new(Data)
+	require.NoError(fromProto.FromProto(&p))
+	require.Equal(data.Sections, fromProto.Sections)
+
+	// fields of sections unknown to this version are kept, so the data hashes the same as on newer nodes
+	section := protowire.AppendTag(nil, 1, protowire.VarintType)
+	section = protowire.AppendVarint(section, uint64(MinAppDataSectionType+1))
+	section = protowire.AppendTag(section, 5, protowire.BytesType)
+	section = protowire.AppendBytes(section, []byte("future"))
+	section = protowire.AppendTag(section, 2, protowire.BytesType)
+	section = protowire.AppendBytes(section, []byte("payload"))
+	blob = protowire.AppendTag(blob, dataSectionsField, protowire.BytesType)
+	blob = protowire.AppendBytes(blob, section)
+	require.NoError(decoded.UnmarshalBinary(blob))
+	require.Len(decoded.Sections, 3)
+	require.Equal(MinAppDataSectionType+1, decoded.Sections[2].Type)
+	require.Equal([]byte("payload"), decoded.Sections[2].Payload)
+	require.NoError(decoded.ValidateBasic())
+	reencoded, err := decoded.MarshalBinary()
+	require.NoError(err)
+	require.Equal(blob, reencoded)
+	require.Len(blob, decoded.Size())
+	require.Equal(Hash(merkle.HashFromByteSlices([][]byte{blob})), decoded.Hash())
+
+	for _, sections := range [][]DataSection{
+		{{Type: 0, Payload: []byte{1}}},
+		{{Type: 2, Payload: []byte{1}}, {Type: 1, Payload: []byte{1}}},
+		{{Type: 1, Payload: []byte{1}}, {Type: 1, Payload: []byte{1}}},
+		{{Type: 1}},
+		make([]DataSection, MaxDataSections+1),
+	} {
+		data.Sections = sections
+		require.Error(data.ValidateBasic())
+	}
+}
+
+func TestMarshalPooled(t *testing.T) {
+	require := require.New(t)
+
+	header, _ := GetRandomBlock(1, 0, "TestMarshalPooled")
+	expected, err := header.MarshalBinary()
+	require.NoError(err)
+	blob, release, err := header.MarshalPooled()
+	require.NoError(err)
+	require.Equal(expected, blob)
+	release()
+
+	state := &pb.State{ChainId: "TestMarshalPooled", LastBlockHeight: 10}
+	expected, err = state.Marshal()
+	require.NoError(err)
+	blob, release, err = MarshalPooled(state)
+	require.NoError(err)
+	require.Equal(expected, blob)
+	release()
+}
+
 func TestConsensusParamsFromProto(t *testing.T) {
 	// Prepare test case
 	pbParams := cmproto.ConsensusParams{
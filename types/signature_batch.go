@@ -0,0 +1,132 @@
+package types
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	cmcrypto "github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/batch"
+)
+
+// verifiedSignaturesCacheSize is the number of recently verified signatures remembered, so signatures verified
+// in a batch during sync are not verified again when blocks are validated before execution.
+const verifiedSignaturesCacheSize = 4096
+
+var verifiedSignatures = newSignatureCache(verifiedSignaturesCacheSize)
+
+// signatureCache is a bounded set of verified signatures. Oldest entries are evicted first.
+type signatureCache struct {
+	mtx  sync.Mutex
+	keys map[[sha256.Size]byte]struct{}
+	ring [][sha256.Size]byte
+	next int
+}
+
+func newSignatureCache(size int) *signatureCache {
+	return &signatureCache{
+		keys: make(map[[sha256.Size]byte]struct{}, size),
+		ring: make([][sha256.Size]byte, size),
+	}
+}
+
+// signatureKey identifies a signature of the message by the public key.
+func signatureKey(pubKey cmcrypto.PubKey, msg, signature []byte) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte(pubKey.Type()))
+	h.Write(pubKey.Bytes())
+	h.Write(msg)
+	h.Write(signature)
+	var key [sha256.Size]byte
+	h.Sum(key[:0])
+	return key
+}
+
+func (c *signatureCache) contains(key [sha256.Size]byte) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	_, ok := c.keys[key]
+	return ok
+}
+
+func (c *signatureCache) add(key [sha256.Size]byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if _, ok := c.keys[key]; ok {
+		return
+	}
+	delete(c.keys, c.ring[c.next])
+	c.ring[c.next] = key
+	c.keys[key] = struct{}{}
+	c.next = (c.next + 1) % len(c.ring)
+}
+
+// verifySignature verifies signature of the header by the sequencer, unless it was verified recently.
+func (sh *SignedHeader) verifySignature() error {
+	pubKey := sh.Validators.Validators[0].PubKey
+	vote := sh.Header.MakeCometBFTVote()
+	key := signatureKey(pubKey, vote, sh.Signature)
+	if verifiedSignatures.contains(key) {
+		return nil
+	}
+	if !pubKey.VerifySignature(vote, sh.Signature) {
+		return ErrSignatureVerificationFailed
+	}
+	verifiedSignatures.add(key)
+	return nil
+}
+
+// pendingSignature is a signature added to a batch verifier.
+type pendingSignature struct {
+	index int
+	key   [sha256.Size]byte
+}
+
+// VerifySignedHeaders performs basic validation of signed headers, like ValidateBasic, and returns validation
+// error of each header. Signatures of headers are verified in batches, per key type, which is much cheaper than
+// verifying them one by one when many headers are synced. Verified signatures are remembered, so subsequent
+// ValidateBasic of the same headers doesn't verify them again.
+func VerifySignedHeaders(headers []*SignedHeader) []error {
+	errs := make([]error, len(headers))
+	verifiers := make(map[string]cmcrypto.BatchVerifier)
+	pending := make(map[string][]pendingSignature)
+	for i, sh := range headers {
+		if errs[i] = sh.validateBasicWithoutSignature(); errs[i] != nil {
+			continue
+		}
+		pubKey := sh.Validators.Validators[0].PubKey
+		vote := sh.Header.MakeCometBFTVote()
+		key := signatureKey(pubKey, vote, sh.Signature)
+		if verifiedSignatures.contains(key) {
+			continue
+		}
+		keyType := pubKey.Type()
+		verifier, ok := verifiers[keyType]
+		if !ok {
+			if verifier, ok = batch.CreateBatchVerifier(pubKey); !ok {
+				errs[i] = sh.verifySignature()
+				continue
+			}
+			verifiers[keyType] = verifier
+		}
+		if err := verifier.Add(pubKey, vote, sh.Signature); err != nil {
+			errs[i] = ErrSignatureVerificationFailed
+			continue
+		}
+		pending[keyType] = append(pending[keyType], pendingSignature{index: i, key: key})
+	}
+	for keyType, verifier := range verifiers {
+		signatures := pending[keyType]
+		if len(signatures) == 0 {
+			continue
+		}
+		_, valid := verifier.Verify()
+		for j, s := range signatures {
+			if j < len(valid) && valid[j] {
+				verifiedSignatures.add(s.key)
+			} else {
+				errs[s.index] = ErrSignatureVerificationFailed
+			}
+		}
+	}
+	return errs
+}
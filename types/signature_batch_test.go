@@ -0,0 +1,91 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getSignedHeaders(t testing.TB, n int, chainID string) []*SignedHeader {
+	header, privKey, err := GetRandomSignedHeader(chainID)
+	require.NoError(t, err)
+	headers := []*SignedHeader{header}
+	for len(headers) < n {
+		header, err = GetRandomNextSignedHeader(header, privKey, chainID)
+		require.NoError(t, err)
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+func TestVerifySignedHeaders(t *testing.T) {
+	headers := getSignedHeaders(t, 5, "TestVerifySignedHeaders")
+
+	badSignature := *headers[1]
+	badSignature.Signature = GetRandomBytes(64)
+	headers[1] = &badSignature
+
+	noSignature := *headers[3]
+	noSignature.Signature = nil
+	headers[3] = &noSignature
+
+	errs := VerifySignedHeaders(headers)
+	require.Len(t, errs, len(headers))
+	assert.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], ErrSignatureVerificationFailed)
+	assert.NoError(t, errs[2])
+	assert.ErrorIs(t, errs[3], ErrSignatureEmpty)
+	assert.NoError(t, errs[4])
+
+	// results are consistent with ValidateBasic
+	for i, header := range headers {
+		assert.Equal(t, errs[i], header.ValidateBasic())
+	}
+
+	// verified signatures are remembered, invalid ones are not
+	key := func(sh *SignedHeader) [32]byte {
+		return signatureKey(sh.Validators.Validators[0].PubKey, sh.Header.MakeCometBFTVote(), sh.Signature)
+	}
+	assert.True(t, verifiedSignatures.contains(key(headers[0])))
+	assert.False(t, verifiedSignatures.contains(key(headers[1])))
+}
+
+func TestSignatureCache(t *testing.T) {
+	c := newSignatureCache(2)
+	c.add([32]byte{1})
+	c.add([32]byte{2})
+	c.add([32]byte{2})
+	assert.True(t, c.contains([32]byte{1}))
+	assert.True(t, c.contains([32]byte{2}))
+
+	// oldest entry is evicted
+	c.add([32]byte{3})
+	assert.False(t, c.contains([32]byte{1}))
+	assert.True(t, c.contains([32]byte{2}))
+	assert.True(t, c.contains([32]byte{3}))
+}
+
+func BenchmarkVerifySignedHeaders(b *testing.B) {
+	headers := getSignedHeaders(b, 100, "BenchmarkVerifySignedHeaders")
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			verifiedSignatures = newSignatureCache(verifiedSignaturesCacheSize)
+			for _, err := range VerifySignedHeaders(headers) {
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			verifiedSignatures = newSignatureCache(verifiedSignaturesCacheSize)
+			for _, header := range headers {
+				if err := header.ValidateBasic(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/celestiaorg/go-header"
 	cmtypes "github.com/cometbft/cometbft/types"
 )
 
@@ -41,7 +40,7 @@ var (
 func (sh *SignedHeader) Verify(untrstH *SignedHeader) error {
 	// go-header ensures untrustH already passed ValidateBasic.
 	if err := sh.Header.Verify(&untrstH.Header); err != nil {
-		return &header.VerifyError{
+		return &VerifyError{
 			Reason: err,
 		}
 	}
@@ -83,8 +82,8 @@ func (sh *SignedHeader) isAdjacent(untrstH *SignedHeader) bool {
 }
 
 // newVerifyError creates and returns a new error verification.
-func (sh *SignedHeader) newVerifyError(err error, expected, got []byte) *header.VerifyError {
-	return &header.VerifyError{
+func (sh *SignedHeader) newVerifyError(err error, expected, got []byte) *VerifyError {
+	return &VerifyError{
 		Reason: fmt.Errorf("verification error at height %d: %w: expected %X, but got %X", sh.Height(), err, expected, got),
 	}
 }
@@ -127,6 +126,14 @@ func validatorsEqual(val1 *cmtypes.Validator, val2 *cmtypes.Validator) bool {
 
 // ValidateBasic performs basic validation of a signed header.
 func (sh *SignedHeader) ValidateBasic() error {
+	if err := sh.validateBasicWithoutSignature(); err != nil {
+		return err
+	}
+	return sh.verifySignature()
+}
+
+// validateBasicWithoutSignature performs basic validation of a signed header, except signature verification.
+func (sh *SignedHeader) validateBasicWithoutSignature() error {
 	if err := sh.Header.ValidateBasic(); err != nil {
 		return err
 	}
@@ -153,14 +160,6 @@ func (sh *SignedHeader) ValidateBasic() error {
 	if !validatorsEqual(sh.Validators.Proposer, sh.Validators.Validators[0]) {
 		return ErrProposerNotInValSet
 	}
-
-	signature := sh.Signature
-
-	vote := sh.Header.MakeCometBFTVote()
-	if !sh.Validators.Validators[0].PubKey.VerifySignature(vote, signature) {
-		return ErrSignatureVerificationFailed
-	}
 	return nil
 }
 
-var _ header.Header[*SignedHeader] = &SignedHeader{}
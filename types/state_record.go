@@ -0,0 +1,64 @@
+package types
+
+import pb "github.com/rollkit/rollkit/types/pb/rollkit"
+
+// StateRecord is a compact record of the state after applying block at given height. Unlike State, which is
+// stored only for the latest height, records are kept for all heights, to serve historical queries.
+type StateRecord struct {
+	Height uint64
+	// AppHash is the app hash after applying the block.
+	AppHash Hash
+	// LastResultsHash is the hash of results of executing the block.
+	LastResultsHash Hash
+	// LastHeightConsensusParamsChanged and AppVersion identify the version of consensus params.
+	LastHeightConsensusParamsChanged uint64
+	AppVersion                       uint64
+}
+
+// NewStateRecord returns record of given state.
+func NewStateRecord(s State) *StateRecord {
+	return &StateRecord{
+		Height:                           s.LastBlockHeight,
+		AppHash:                          s.AppHash,
+		LastResultsHash:                  s.LastResultsHash,
+		LastHeightConsensusParamsChanged: s.LastHeightConsensusParamsChanged,
+		AppVersion:                       s.Version.Consensus.App,
+	}
+}
+
+// MarshalBinary encodes StateRecord into binary form and returns it.
+func (r *StateRecord) MarshalBinary() ([]byte, error) {
+	return r.ToProto().Marshal()
+}
+
+// UnmarshalBinary decodes binary form of StateRecord into object.
+func (r *StateRecord) UnmarshalBinary(data []byte) error {
+	var pRecord pb.StateRecord
+	if err := pRecord.Unmarshal(data); err != nil {
+		return err
+	}
+	r.FromProto(&pRecord)
+	return nil
+}
+
+// ToProto converts StateRecord into protobuf representation and returns it.
+func (r *StateRecord) ToProto() *pb.StateRecord {
+	return &pb.StateRecord{
+		Height:                           r.Height,
+		AppHash:                          r.AppHash,
+		LastResultsHash:                  r.LastResultsHash,
+		LastHeightConsensusParamsChanged: r.LastHeightConsensusParamsChanged,
+		AppVersion:                       r.AppVersion,
+	}
+}
+
+// FromProto fills StateRecord with data from its protobuf representation.
+func (r *StateRecord) FromProto(other *pb.StateRecord) {
+	*r = StateRecord{
+		Height:                           other.Height,
+		AppHash:                          other.AppHash,
+		LastResultsHash:                  other.LastResultsHash,
+		LastHeightConsensusParamsChanged: other.LastHeightConsensusParamsChanged,
+		AppVersion:                       other.AppVersion,
+	}
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// systemTxPrefix marks a system transaction, injected at a fixed position of every block by the proposer (e.g. an
+// oracle update or timestamp beacon). It's unlikely to be a prefix of an application transaction.
+var systemTxPrefix = []byte("rollkit/system/v1\x00")
+
+// SystemTx is a transaction injected at a fixed position of every block, see state.ProposalTemplate.
+type SystemTx struct {
+	// Kind identifies the slot of the block proposal template, e.g. "oracle".
+	Kind    string
+	Payload []byte
+}
+
+// NewSystemTx wraps payload of system transaction of given kind in a transaction.
+func NewSystemTx(kind string, payload []byte) (Tx, error) {
+	if kind == "" {
+		return nil, errors.New("system transaction kind is required")
+	}
+	stx := SystemTx{Kind: kind, Payload: payload}
+	b, err := stx.ToProto().Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes.Clone(systemTxPrefix), b...), nil
+}
+
+// IsSystemTx returns true if tx is a system transaction.
+func IsSystemTx(tx Tx) bool {
+	return bytes.HasPrefix(tx, systemTxPrefix)
+}
+
+// ParseSystemTx returns kind and payload of system transaction.
+func ParseSystemTx(tx Tx) (SystemTx, error) {
+	var stx SystemTx
+	if !IsSystemTx(tx) {
+		return stx, errors.New("not a system transaction")
+	}
+	var pTx pb.SystemTx
+	if err := pTx.Unmarshal(tx[len(systemTxPrefix):]); err != nil {
+		return stx, fmt.Errorf("malformed system transaction: %w", err)
+	}
+	stx.FromProto(&pTx)
+	if stx.Kind == "" {
+		return stx, errors.New("malformed system transaction: no kind")
+	}
+	return stx, nil
+}
+
+// ToProto converts SystemTx into protobuf representation and returns it.
+func (stx *SystemTx) ToProto() *pb.SystemTx {
+	return &pb.SystemTx{Kind: stx.Kind, Payload: stx.Payload}
+}
+
+// FromProto fills SystemTx with data from its protobuf representation.
+func (stx *SystemTx) FromProto(other *pb.SystemTx) {
+	*stx = SystemTx{Kind: other.Kind, Payload: other.Payload}
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemTx(t *testing.T) {
+	tx, err := NewSystemTx("oracle", []byte("prices"))
+	require.NoError(t, err)
+	assert.True(t, IsSystemTx(tx))
+	assert.False(t, IsSystemTx(Tx("oracle")))
+
+	stx, err := ParseSystemTx(tx)
+	require.NoError(t, err)
+	assert.Equal(t, SystemTx{Kind: "oracle", Payload: []byte("prices")}, stx)
+
+	_, err = NewSystemTx("", nil)
+	assert.Error(t, err)
+	_, err = ParseSystemTx(Tx("oracle"))
+	assert.Error(t, err)
+	_, err = ParseSystemTx(append(tx, 0xFF))
+	assert.Error(t, err)
+}
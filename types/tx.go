@@ -19,7 +19,8 @@ type Tx []byte
 // Txs represents a slice of transactions.
 type Txs []Tx
 
-// Hash computes the TMHASH hash of the wire encoded transaction.
+// Hash computes the TMHASH hash of the wire encoded transaction. It's used as leaf of Merkle tree of
+// transactions of a block; transactions are identified by TxHash.
 func (tx Tx) Hash() []byte {
 	return tmhash.Sum(tx)
 }
@@ -42,11 +43,18 @@ func (txs Txs) Proof(i int) TxProof {
 	for i := 0; i < l; i++ {
 		bzs[i] = txs[i].Hash()
 	}
-	root, proofs := merkle.ProofsFromByteSlices(bzs)
+	return ProofFromTxHashes(bzs, i, txs[i])
+}
+
+// ProofFromTxHashes returns a simple merkle proof of tx at index i, from hashes of all transactions of
+// the block. It allows to prove inclusion of tx after data of the block was pruned.
+// Panics if i < 0 or i >= len(hashes)
+func ProofFromTxHashes(hashes [][]byte, i int, tx Tx) TxProof {
+	root, proofs := merkle.ProofsFromByteSlices(hashes)
 
 	return TxProof{
 		RootHash: root,
-		Data:     txs[i],
+		Data:     tx,
 		Proof:    *proofs[i],
 	}
 }
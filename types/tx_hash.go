@@ -0,0 +1,75 @@
+package types
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"golang.org/x/crypto/sha3"
+)
+
+// Names of built-in transaction hash functions, see SetTxHasherByName.
+const (
+	// TxHashSHA256 is SHA-256 of transaction bytes, compatible with CometBFT.
+	TxHashSHA256 = "sha256"
+	// TxHashKeccak256 is Keccak-256 of transaction bytes, e.g. hash of RLP encoded EVM transaction.
+	TxHashKeccak256 = "keccak256"
+)
+
+// TxHasher computes the hash identifying a transaction in RPC responses, the transaction indexer and logs. It doesn't affect commitments of blocks: leaves of Merkle tree of transactions are always hashed with
+// Tx.Hash, so proofs of inclusion stay compatible with CometBFT.
+type TxHasher func(tx []byte) []byte
+
+// txHashFunc is the configured hash function of transactions.
+type txHashFunc struct {
+	hash TxHasher
+	// cometBFT is set if hash is the default SHA-256, used by CometBFT event bus
+	cometBFT bool
+}
+
+var txHasher atomic.Pointer[txHashFunc]
+
+func init() {
+	SetTxHasher(nil)
+}
+
+// SetTxHasher sets the hash function of transactions used by all modules. It should be set before the node is
+// created, so that transactions indexed by the node can be found by hashes computed by clients. nil restores
+// the default SHA-256 hasher.
+func SetTxHasher(h TxHasher) {
+	if h == nil {
+		txHasher.Store(&txHashFunc{hash: tmhash.Sum, cometBFT: true})
+		return
+	}
+	txHasher.Store(&txHashFunc{hash: h})
+}
+
+// TxHash returns hash of transaction computed with hash function set with SetTxHasher, SHA-256 by default.
+func TxHash(tx []byte) []byte {
+	return txHasher.Load().hash(tx)
+}
+
+// TxHashCometBFT returns true if TxHash is the default SHA-256, which is also used as tx.hash of transaction
+// events published by CometBFT event bus and as mempool keys.
+func TxHashCometBFT() bool {
+	return txHasher.Load().cometBFT
+}
+
+// SetTxHasherByName sets built-in hash function with given name, see TxHashSHA256 and TxHashKeccak256.
+func SetTxHasherByName(name string) error {
+	switch name {
+	case TxHashSHA256:
+		SetTxHasher(nil)
+	case TxHashKeccak256:
+		SetTxHasher(keccak256)
+	default:
+		return fmt.Errorf("unknown tx hash function %q, expected %q or %q", name, TxHashSHA256, TxHashKeccak256)
+	}
+	return nil
+}
+
+func keccak256(tx []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(tx) //nolint:errcheck
+	return h.Sum(nil)
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxHasher(t *testing.T) {
+	defer SetTxHasher(nil)
+	tx := Tx("tx")
+
+	assert.Equal(t, tmhash.Sum(tx), TxHash(tx))
+	assert.True(t, TxHashCometBFT())
+
+	require.NoError(t, SetTxHasherByName(TxHashKeccak256))
+	assert.False(t, TxHashCometBFT())
+	// Keccak-256 of empty input, as used by Ethereum
+	assert.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(TxHash(nil)))
+	// commitments of blocks are not affected
+	assert.Equal(t, tmhash.Sum(tx), tx.Hash())
+
+	SetTxHasher(func(tx []byte) []byte { return append([]byte("custom"), tx...) })
+	assert.Equal(t, []byte("customtx"), TxHash(tx))
+
+	require.NoError(t, SetTxHasherByName(TxHashSHA256))
+	assert.Equal(t, tmhash.Sum(tx), TxHash(tx))
+	assert.True(t, TxHashCometBFT())
+
+	assert.Error(t, SetTxHasherByName("md5"))
+}
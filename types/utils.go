@@ -3,28 +3,19 @@ package types
 import (
 	cryptoRand "crypto/rand"
 	"errors"
-	"fmt"
 	"math/rand"
 	"time"
 
-	"github.com/celestiaorg/go-header"
 	cmcrypto "github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
 	cmbytes "github.com/cometbft/cometbft/libs/bytes"
-	"github.com/cometbft/cometbft/p2p"
 	cmtypes "github.com/cometbft/cometbft/types"
-	"github.com/libp2p/go-libp2p/core/crypto"
 )
 
 // DefaultSigningKeyType is the key type used by the sequencer signing key
 const DefaultSigningKeyType = "ed25519"
 
-var (
-	errNilKey             = errors.New("key can't be nil")
-	errUnsupportedKeyType = errors.New("unsupported key type")
-)
-
 // ValidatorConfig carries all necessary state for generating a Validator
 type ValidatorConfig struct {
 	PrivKey     cmcrypto.PrivKey
@@ -112,7 +103,7 @@ func GenerateRandomBlockCustom(config *BlockConfig, chainID string) (*SignedHead
 }
 
 // GetRandomNextBlock returns a block with random data and height of +1 from the provided block
-func GetRandomNextBlock(header *SignedHeader, data *Data, privKey cmcrypto.PrivKey, appHash header.Hash, nTxs int, chainID string) (*SignedHeader, *Data) {
+func GetRandomNextBlock(header *SignedHeader, data *Data, privKey cmcrypto.PrivKey, appHash Hash, nTxs int, chainID string) (*SignedHeader, *Data) {
 	nextData := getBlockDataWith(nTxs)
 	dataHash := nextData.Hash()
 
@@ -145,7 +136,7 @@ func GetRandomNextBlock(header *SignedHeader, data *Data, privKey cmcrypto.PrivK
 // HeaderConfig carries all necessary state for header generation
 type HeaderConfig struct {
 	Height      uint64
-	DataHash    header.Hash
+	DataHash    Hash
 	PrivKey     cmcrypto.PrivKey
 	VotingPower int64
 }
@@ -245,29 +236,6 @@ func GetRandomNextSignedHeader(signedHeader *SignedHeader, privKey cmcrypto.Priv
 	return newSignedHeader, nil
 }
 
-// GetNodeKey creates libp2p private key from Tendermints NodeKey.
-func GetNodeKey(nodeKey *p2p.NodeKey) (crypto.PrivKey, error) {
-	if nodeKey == nil || nodeKey.PrivKey == nil {
-		return nil, errNilKey
-	}
-	switch nodeKey.PrivKey.Type() {
-	case "ed25519":
-		privKey, err := crypto.UnmarshalEd25519PrivateKey(nodeKey.PrivKey.Bytes())
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling node private key: %w", err)
-		}
-		return privKey, nil
-	case "secp256k1":
-		privKey, err := crypto.UnmarshalSecp256k1PrivateKey(nodeKey.PrivKey.Bytes())
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling node private key: %w", err)
-		}
-		return privKey, nil
-	default:
-		return nil, errUnsupportedKeyType
-	}
-}
-
 // GetFirstSignedHeader creates a 1st signed header for a chain, given a valset and signing key.
 func GetFirstSignedHeader(privkey ed25519.PrivKey, valSet *cmtypes.ValidatorSet, chainID string) (*SignedHeader, error) {
 	header := Header{
@@ -336,21 +304,13 @@ func GetGenesisWithPrivkey(signingKeyType string, chainID string) (*cmtypes.Gene
 	}}
 	genDoc := &cmtypes.GenesisDoc{
 		ChainID:       chainID,
+		GenesisTime:   time.Now().UTC(),
 		InitialHeight: 0,
 		Validators:    genesisValidators,
 	}
 	return genDoc, genesisValidatorKey
 }
 
-// PrivKeyToSigningKey converts a privKey to a signing key
-func PrivKeyToSigningKey(privKey cmcrypto.PrivKey) (crypto.PrivKey, error) {
-	nodeKey := &p2p.NodeKey{
-		PrivKey: privKey,
-	}
-	signingKey, err := GetNodeKey(nodeKey)
-	return signingKey, err
-}
-
 // GetRandomTx returns a tx with random data
 func GetRandomTx() Tx {
 	size := rand.Int()%100 + 100 //nolint:gosec
@@ -0,0 +1,214 @@
+// Package verify verifies chains of rollup blocks without running a node. Given signed headers, block data and
+// proofs of publication of headers in DA, it checks that blocks were produced by the expected sequencer, that
+// they form a chain extending a trusted header, and that headers were published in the rollup namespace.
+//
+// The package only depends on block types and the generic DA interface, so it can be embedded in bridges,
+// mobile apps and other light clients.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	cmcrypto "github.com/cometbft/cometbft/crypto"
+
+	goDA "github.com/rollkit/go-da"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+var (
+	// ErrChainIDMismatch is returned when a header belongs to a different chain.
+	ErrChainIDMismatch = errors.New("chain ID mismatch")
+
+	// ErrUnexpectedProposer is returned when a header is not signed by the expected sequencer.
+	ErrUnexpectedProposer = errors.New("header is not signed by the expected proposer")
+
+	// ErrNotAboveTrusted is returned when a header doesn't extend the trusted header.
+	ErrNotAboveTrusted = errors.New("header height is not above trusted height")
+
+	// ErrMissingDAProof is returned when DA inclusion is verified, but a block has no DA proof.
+	ErrMissingDAProof = errors.New("missing DA inclusion proof")
+
+	// ErrDAInclusion is returned when a header is not proven to be published in the rollup namespace.
+	ErrDAInclusion = errors.New("header is not included in DA")
+)
+
+// DAVerifier verifies publication of blobs in DA. It's implemented by DA clients (see go-da), or by light clients
+// of the DA layer.
+type DAVerifier interface {
+	// Commit creates a commitment for each blob.
+	Commit(ctx context.Context, blobs []goDA.Blob, namespace goDA.Namespace) ([]goDA.Commitment, error)
+
+	// Validate validates inclusion proofs of blobs identified by IDs.
+	Validate(ctx context.Context, ids []goDA.ID, proofs []goDA.Proof, namespace goDA.Namespace) ([]bool, error)
+}
+
+// Block is a rollup block with proof of publication of its header in DA.
+type Block struct {
+	Header *types.SignedHeader
+	// Data is optional. If set, it's checked against the data hash of the header.
+	Data *types.Data
+	// DAID is the ID of the header blob in DA, ending with commitment of the blob.
+	DAID goDA.ID
+	// DAProof is the proof of inclusion of the header blob in DA.
+	DAProof goDA.Proof
+}
+
+// Config is the configuration of Verifier.
+type Config struct {
+	// ChainID is the ID of the rollup chain.
+	ChainID string
+	// Proposer is the public key of the sequencer, as set in genesis.
+	Proposer cmcrypto.PubKey
+	// Namespace is the DA namespace headers are published to.
+	Namespace goDA.Namespace
+	// DA verifies proofs of inclusion of headers in DA. If nil, DA inclusion is not verified.
+	DA DAVerifier
+}
+
+// Verifier verifies blocks extending the latest trusted header. It's not safe for concurrent use.
+type Verifier struct {
+	config  Config
+	trusted *types.SignedHeader
+}
+
+// NewVerifier creates a verifier of blocks extending the trusted header, e.g. the first header of the chain. If
+// trusted header is nil, the first verified block is only checked to be signed by the sequencer.
+func NewVerifier(config Config, trusted *types.SignedHeader) (*Verifier, error) {
+	if config.Proposer == nil {
+		return nil, errors.New("proposer public key is required")
+	}
+	if trusted != nil {
+		if err := checkHeader(config, trusted); err != nil {
+			return nil, fmt.Errorf("invalid trusted header: %w", err)
+		}
+	}
+	return &Verifier{config: config, trusted: trusted}, nil
+}
+
+// Trusted returns the latest verified header, or nil if no header was verified yet.
+func (v *Verifier) Trusted() *types.SignedHeader {
+	return v.trusted
+}
+
+// Verify verifies blocks ordered by height, each extending the previous one. Heights might be skipped; hashes
+// linking blocks are checked only for adjacent blocks. Blocks are verified atomically: if any block is invalid,
+// an error is returned and the trusted header is not changed. Otherwise, the last block becomes trusted.
+func (v *Verifier) Verify(ctx context.Context, blocks ...Block) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	headers := make([]*types.SignedHeader, len(blocks))
+	for i, b := range blocks {
+		if b.Header == nil {
+			return fmt.Errorf("block %d: missing header", i)
+		}
+		headers[i] = b.Header
+	}
+
+	// signatures of all headers are verified in a batch
+	for i, err := range types.VerifySignedHeaders(headers) {
+		if err != nil {
+			return fmt.Errorf("block at height %d: %w", headers[i].Height(), err)
+		}
+	}
+
+	trusted := v.trusted
+	for _, b := range blocks {
+		if err := v.verifyBlock(trusted, b); err != nil {
+			return fmt.Errorf("block at height %d: %w", b.Header.Height(), err)
+		}
+		trusted = b.Header
+	}
+
+	if v.config.DA != nil {
+		if err := v.verifyDAInclusion(ctx, blocks); err != nil {
+			return err
+		}
+	}
+
+	v.trusted = trusted
+	return nil
+}
+
+// verifyBlock verifies the block, except signature and DA inclusion, against the previous trusted header.
+func (v *Verifier) verifyBlock(trusted *types.SignedHeader, b Block) error {
+	if err := checkHeader(v.config, b.Header); err != nil {
+		return err
+	}
+	if trusted != nil {
+		if b.Header.Height() <= trusted.Height() {
+			return fmt.Errorf("%w: %d <= %d", ErrNotAboveTrusted, b.Header.Height(), trusted.Height())
+		}
+		if err := trusted.Verify(b.Header); err != nil {
+			return err
+		}
+	}
+	if b.Data != nil {
+		if err := types.Validate(b.Header, b.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkHeader checks that the header belongs to the chain and is proposed by the sequencer. Signature is
+// verified separately.
+func checkHeader(config Config, header *types.SignedHeader) error {
+	if header.ChainID() != config.ChainID {
+		return fmt.Errorf("%w: expected %q, got %q", ErrChainIDMismatch, config.ChainID, header.ChainID())
+	}
+	if header.Validators == nil || len(header.Validators.Validators) != 1 ||
+		!header.Validators.Validators[0].PubKey.Equals(config.Proposer) {
+		return ErrUnexpectedProposer
+	}
+	return nil
+}
+
+// verifyDAInclusion verifies that headers of blocks were published in the namespace. ID of each blob must end
+// with the commitment of the encoded header, so the proof is bound to the header.
+func (v *Verifier) verifyDAInclusion(ctx context.Context, blocks []Block) error {
+	blobs := make([]goDA.Blob, len(blocks))
+	ids := make([]goDA.ID, len(blocks))
+	proofs := make([]goDA.Proof, len(blocks))
+	for i, b := range blocks {
+		if len(b.DAID) == 0 || len(b.DAProof) == 0 {
+			return fmt.Errorf("block at height %d: %w", b.Header.Height(), ErrMissingDAProof)
+		}
+		blob, err := b.Header.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("block at height %d: failed to encode header: %w", b.Header.Height(), err)
+		}
+		blobs[i], ids[i], proofs[i] = blob, b.DAID, b.DAProof
+	}
+
+	commitments, err := v.config.DA.Commit(ctx, blobs, v.config.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to compute commitments of headers: %w", err)
+	}
+	if len(commitments) != len(blocks) {
+		return fmt.Errorf("expected %d commitments, got %d", len(blocks), len(commitments))
+	}
+	for i, b := range blocks {
+		if len(commitments[i]) == 0 || !bytes.HasSuffix(b.DAID, commitments[i]) {
+			return fmt.Errorf("block at height %d: %w: ID doesn't match commitment of the header", b.Header.Height(), ErrDAInclusion)
+		}
+	}
+
+	valid, err := v.config.DA.Validate(ctx, ids, proofs, v.config.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to validate DA proofs: %w", err)
+	}
+	if len(valid) != len(blocks) {
+		return fmt.Errorf("expected %d DA proof results, got %d", len(blocks), len(valid))
+	}
+	for i, b := range blocks {
+		if !valid[i] {
+			return fmt.Errorf("block at height %d: %w: invalid proof", b.Header.Height(), ErrDAInclusion)
+		}
+	}
+	return nil
+}
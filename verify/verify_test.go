@@ -0,0 +1,180 @@
+package verify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goDA "github.com/rollkit/go-da"
+	goDATest "github.com/rollkit/go-da/test"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+const chainID = "TestVerify"
+
+var namespace = goDA.Namespace("verify")
+
+// makeChain returns blocks at heights 1 to n, with headers published in DA.
+func makeChain(t *testing.T, dummyDA *goDATest.DummyDA, n int) ([]Block, ed25519.PrivKey) {
+	t.Helper()
+	ctx := context.Background()
+	privKey := ed25519.GenPrivKey()
+	header, data, _ := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 1, NTxs: 2, PrivKey: privKey}, chainID)
+	blocks := []Block{{Header: header, Data: data}}
+	for len(blocks) < n {
+		header, data = types.GetRandomNextBlock(header, data, privKey, nil, 2, chainID)
+		blocks = append(blocks, Block{Header: header, Data: data})
+	}
+
+	blobs := make([]goDA.Blob, n)
+	for i, b := range blocks {
+		blob, err := b.Header.MarshalBinary()
+		require.NoError(t, err)
+		blobs[i] = blob
+	}
+	ids, err := dummyDA.Submit(ctx, blobs, 1, namespace)
+	require.NoError(t, err)
+	proofs, err := dummyDA.GetProofs(ctx, ids, namespace)
+	require.NoError(t, err)
+	for i := range blocks {
+		blocks[i].DAID, blocks[i].DAProof = ids[i], proofs[i]
+	}
+	return blocks, privKey
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	dummyDA := goDATest.NewDummyDA()
+	blocks, privKey := makeChain(t, dummyDA, 5)
+	config := Config{
+		ChainID:   chainID,
+		Proposer:  privKey.PubKey(),
+		Namespace: namespace,
+		DA:        dummyDA,
+	}
+
+	v, err := NewVerifier(config, blocks[0].Header)
+	require.NoError(t, err)
+	require.NoError(t, v.Verify(ctx, blocks[1:3]...))
+	assert.Equal(t, blocks[2].Header, v.Trusted())
+
+	// skipping heights is allowed
+	require.NoError(t, v.Verify(ctx, blocks[4]))
+	assert.Equal(t, blocks[4].Header, v.Trusted())
+
+	// trusted header is not changed by invalid blocks
+	assert.ErrorIs(t, v.Verify(ctx, blocks[3]), ErrNotAboveTrusted)
+	assert.Equal(t, blocks[4].Header, v.Trusted())
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	ctx := context.Background()
+	dummyDA := goDATest.NewDummyDA()
+	blocks, privKey := makeChain(t, dummyDA, 3)
+	otherBlocks, _ := makeChain(t, dummyDA, 3)
+	config := Config{
+		ChainID:   chainID,
+		Proposer:  privKey.PubKey(),
+		Namespace: namespace,
+		DA:        dummyDA,
+	}
+
+	cases := []struct {
+		name   string
+		config func(*Config)
+		blocks func() []Block
+		err    error
+	}{
+		{
+			name:   "other proposer",
+			blocks: func() []Block { return otherBlocks[1:2] },
+			err:    ErrUnexpectedProposer,
+		},
+		{
+			name:   "other chain",
+			config: func(c *Config) { c.ChainID = "other" },
+			blocks: func() []Block { return blocks[1:2] },
+			err:    ErrChainIDMismatch,
+		},
+		{
+			name: "invalid signature",
+			blocks: func() []Block {
+				header := *blocks[1].Header
+				header.Signature = types.GetRandomBytes(64)
+				return []Block{{Header: &header, DAID: blocks[1].DAID, DAProof: blocks[1].DAProof}}
+			},
+			err: types.ErrSignatureVerificationFailed,
+		},
+		{
+			name: "not linked to trusted header",
+			blocks: func() []Block {
+				header, _ := types.GetRandomNextBlock(blocks[0].Header, blocks[0].Data, privKey, nil, 1, chainID)
+				header.LastHeaderHash = types.GetRandomBytes(32)
+				require.NoError(t, signHeader(header, privKey))
+				return []Block{{Header: header}}
+			},
+			config: func(c *Config) { c.DA = nil },
+			err:    types.ErrLastHeaderHashMismatch,
+		},
+		{
+			name: "data doesn't match header",
+			blocks: func() []Block {
+				return []Block{{Header: blocks[1].Header, Data: blocks[2].Data, DAID: blocks[1].DAID, DAProof: blocks[1].DAProof}}
+			},
+		},
+		{
+			name: "missing DA proof",
+			blocks: func() []Block {
+				return []Block{{Header: blocks[1].Header}}
+			},
+			err: ErrMissingDAProof,
+		},
+		{
+			name: "DA ID of another header",
+			blocks: func() []Block {
+				return []Block{{Header: blocks[1].Header, DAID: blocks[2].DAID, DAProof: blocks[2].DAProof}}
+			},
+			err: ErrDAInclusion,
+		},
+		{
+			name: "invalid DA proof",
+			blocks: func() []Block {
+				return []Block{{Header: blocks[1].Header, DAID: blocks[1].DAID, DAProof: blocks[2].DAProof}}
+			},
+			err: ErrDAInclusion,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := config
+			trusted := blocks[0].Header
+			if c.config != nil {
+				c.config(&cfg)
+				if cfg.ChainID != chainID {
+					trusted = nil
+				}
+			}
+			v, err := NewVerifier(cfg, trusted)
+			require.NoError(t, err)
+			err = v.Verify(ctx, c.blocks()...)
+			require.Error(t, err)
+			if c.err != nil {
+				assert.ErrorIs(t, err, c.err)
+			}
+			assert.Equal(t, trusted, v.Trusted())
+		})
+	}
+}
+
+func signHeader(header *types.SignedHeader, privKey ed25519.PrivKey) error {
+	signature, err := types.GetSignature(header.Header, privKey)
+	if err != nil {
+		return err
+	}
+	header.Signature = *signature
+	return nil
+}